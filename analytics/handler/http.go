@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[ANALYTICS] "
+)
+
+func NewAnalyticsController(useCase domain.AnalyticsUseCase) *AnalyticsController {
+	return &AnalyticsController{useCase: useCase}
+}
+
+type AnalyticsController struct {
+	useCase domain.AnalyticsUseCase
+}
+
+type AnalyticsEventRequest struct {
+	// Name, 이벤트 이름
+	Name string `json:"name" validate:"required,max=60" example:"page_view"`
+
+	// Properties, 이벤트 속성, 이벤트 이름별로 필수 속성이 정해져 있음
+	Properties map[string]interface{} `json:"properties" validate:"required"`
+
+	// OccurredAt, 이벤트 발생 일시, 없으면 수집 일시
+	OccurredAt *time.Time `json:"occurredAt" example:"2021-10-27T04:44:18+00:00"`
+} // @name AnalyticsEventRequest
+
+type TrackAnalyticsEventsRequest struct {
+	Events []AnalyticsEventRequest `json:"events" validate:"required,min=1,max=100,dive"`
+} // @name TrackAnalyticsEventsRequest
+
+// @Tags (Analytics) 공통 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 제품 이벤트 수집
+// @Description 프론트엔드에서 발생한 제품 이벤트를 묶어서 기록하는 기능, 고객별 분당 600개까지 수집, 로그인하지 않았으면 IP 별로 같은 한도
+// @Accept json
+// @Produce json
+// @Param requestBody body TrackAnalyticsEventsRequest true "이벤트 묶음 데이터 구조"
+// @Success 202 "수집 완료"
+// @Failure 400 {object} domain.ErrorResponse "스키마 오류"
+// @Failure 429 {object} domain.ErrorResponse "수집 한도 초과"
+// @Router /analytics/event [post]
+func (c *AnalyticsController) trackEvents(ctx echo.Context, userId *uuid.UUID) error {
+	var req TrackAnalyticsEventsRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "track events, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.TrackAnalyticsEvents{
+		UserId: userId,
+		Ip:     ctx.RealIP(),
+		Events: make([]domain.TrackAnalyticsEvent, len(req.Events)),
+	}
+	for i, event := range req.Events {
+		err = domain.ValidateAnalyticsEvent(event.Name, event.Properties)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Message: fmt.Sprintf("events[%d], %s", i, err.Error()),
+			})
+		}
+
+		in.Events[i] = domain.TrackAnalyticsEvent{
+			Name:       event.Name,
+			Properties: event.Properties,
+			OccurredAt: event.OccurredAt,
+		}
+	}
+	err = c.useCase.TrackEvents(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusAccepted)
+	case domain.ErrTooManyRequests:
+		return ctx.JSON(http.StatusTooManyRequests, domain.TooManyRequestsResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "trackEvents, unhandled error useCase.TrackEvents")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *AnalyticsController) Bind(e *echo.Echo) {
+	e.POST("/analytics/event", echox.OptionalUserID(c.trackEvents))
+}
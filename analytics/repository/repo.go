@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewAnalyticsRepository(db *gorm.DB) domain.AnalyticsRepository {
+	db.AutoMigrate(&domain.AnalyticsEvent{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) SaveAll(ctx context.Context, events []domain.AnalyticsEvent) error {
+	return r.db.WithContext(ctx).Create(&events).Error
+}
+
+func (r *repo) CountByCustomerIdSince(ctx context.Context, customerId uuid.UUID, since time.Time) (cnt int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.AnalyticsEvent{}).
+		Where("`customer_id` = ? AND `created_at` >= ?", customerId, since).
+		Count(&cnt).Error
+	return
+}
+
+func (r *repo) CountAnonymousByIpSince(ctx context.Context, ip string, since time.Time) (cnt int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.AnalyticsEvent{}).
+		Where("`customer_id` IS NULL AND `ip` = ? AND `created_at` >= ?", ip, since).
+		Count(&cnt).Error
+	return
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewAnalyticsUseCase(
+	analyticsRepo domain.AnalyticsRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.AnalyticsUseCase {
+	return &ucase{
+		analyticsRepo: analyticsRepo,
+		ids:           ids,
+		clock:         clock,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	analyticsRepo domain.AnalyticsRepository
+	ids           domain.IdGenerator
+	clock         domain.Clock
+	timeout       time.Duration
+}
+
+func (u *ucase) TrackEvents(ctx context.Context, in domain.TrackAnalyticsEvents) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	now := u.clock.Now()
+	since := now.Add(-domain.AnalyticsEventRateWindow)
+	var cnt int64
+	if in.UserId != nil {
+		cnt, err = u.analyticsRepo.CountByCustomerIdSince(c, *in.UserId, since)
+	} else {
+		cnt, err = u.analyticsRepo.CountAnonymousByIpSince(c, in.Ip, since)
+	}
+	if err != nil {
+		return
+	}
+
+	if cnt+int64(len(in.Events)) > domain.AnalyticsEventRateLimit {
+		return domain.ErrTooManyRequests
+	}
+
+	events := make([]domain.AnalyticsEvent, len(in.Events))
+	for i, src := range in.Events {
+		var properties []byte
+		properties, err = json.Marshal(src.Properties)
+		if err != nil {
+			return
+		}
+
+		occurredAt := now
+		if src.OccurredAt != nil {
+			occurredAt = *src.OccurredAt
+		}
+
+		events[i] = domain.AnalyticsEvent{
+			Id:         u.ids.NewId(),
+			Name:       src.Name,
+			CustomerId: in.UserId,
+			Ip:         in.Ip,
+			Properties: string(properties),
+			OccurredAt: occurredAt,
+			CreatedAt:  now,
+		}
+	}
+
+	return u.analyticsRepo.SaveAll(c, events)
+}
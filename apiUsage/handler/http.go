@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[API_USAGE] "
+)
+
+func NewApiUsageController(useCase domain.ApiUsageUseCase) *ApiUsageController {
+	return &ApiUsageController{useCase: useCase}
+}
+
+type ApiUsageController struct {
+	useCase domain.ApiUsageUseCase
+}
+
+func (c *ApiUsageController) Bind(e *echo.Echo) {
+	// ===== ALL =====
+	e.GET("/user/api-usage", echox.UserID(c.fetchMyApiUsage), middleware.RequireAuth())
+}
+
+type FetchApiUsageRequest struct {
+	// Hours 조회 기간(시간), 0 이면 24시간, 최대 30일
+	Hours int `query:"hours" validate:"min=0,max=720" example:"24"`
+} // @name FetchApiUsageRequest
+
+type ApiKeyUsageResponse struct {
+	// KeyId 범위를 줄인 토큰 발급 때 받은 keyId
+	KeyId    uuid.UUID `json:"keyId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Requests int64     `json:"requests" validate:"required" example:"5230"`
+	// Throttled 한도를 넘어 429 로 거절한 요청 수
+	Throttled int64 `json:"throttled" validate:"required" example:"12"`
+	// LastUsedAt 마지막 요청이 속한 구간(1분) 시작
+	LastUsedAt time.Time `json:"lastUsedAt" validate:"required" example:"2024-05-01T10:31:00+09:00"`
+
+	// Limit 구간(1분)당 허용 요청 수
+	Limit int64 `json:"limit" validate:"required" example:"600"`
+	// Remaining 지금 구간에 남은 요청 수
+	Remaining int64 `json:"remaining" validate:"required" example:"598"`
+	// Reset 지금 구간이 끝나는 시각
+	Reset time.Time `json:"reset" validate:"required" example:"2024-05-01T10:32:00+09:00"`
+} // @name ApiKeyUsageResponse
+
+// @Tags (ApiUsage) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary API 키 사용량
+// @Description 요청자가 발급한 범위를 줄인 토큰(API 키)별 사용량과 지금 구간의 남은 요청 수, 키로 호출하는 응답에는 X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset(unix 초) 헤더가 붙고 한도를 넘으면 429(T-1)
+// @Accept json
+// @Produce json
+// @Param hours query int false "조회 기간(시간), 기본 24"
+// @Success 200 {array} ApiKeyUsageResponse "성공"
+// @Success 204 "사용 기록 없음"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 기간"
+// @Router /user/api-usage [get]
+func (c *ApiUsageController) fetchMyApiUsage(ctx echo.Context, userId uuid.UUID) error {
+	var req FetchApiUsageRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetchMyApiUsage, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	hours := req.Hours
+	if hours == 0 {
+		hours = 24
+	}
+
+	list, err := c.useCase.FetchMyApiUsage(ctx.Request().Context(), userId, time.Duration(hours)*time.Hour)
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "fetchMyApiUsage, unhandled error useCase.FetchMyApiUsage")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]ApiKeyUsageResponse, len(list))
+	for i, src := range list {
+		res[i] = ApiKeyUsageResponse{
+			KeyId:      src.KeyId,
+			Requests:   src.Requests,
+			Throttled:  src.Throttled,
+			LastUsedAt: src.LastUsedAt,
+			Limit:      src.Current.Limit,
+			Remaining:  src.Current.Remaining,
+			Reset:      src.Current.Reset,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewApiUsageRepository(db *gorm.DB) domain.ApiUsageRepository {
+	db.AutoMigrate(&domain.ApiUsage{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Hit(ctx context.Context, id, keyId, userId uuid.UUID, windowStart time.Time) (requests int64, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"requests": gorm.Expr("`requests` + 1"),
+			}),
+		}).Create(&domain.ApiUsage{
+			Id:          id,
+			KeyId:       keyId,
+			WindowStart: windowStart,
+			UserId:      userId,
+			Requests:    1,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.ApiUsage{}).
+			Select("`requests`").
+			Where("`key_id` = ? AND `window_start` = ?", keyId, windowStart).
+			Scan(&requests).Error
+	})
+	return
+}
+
+func (r *repo) SumByUser(ctx context.Context, userId uuid.UUID, since time.Time, limit int64) (list []domain.ApiKeyUsage, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.ApiUsage{}).
+		Select("`key_id`, "+
+			"SUM(`requests`) AS `requests`, "+
+			"SUM(GREATEST(`requests` - ?, 0)) AS `throttled`, "+
+			"MAX(`window_start`) AS `last_used_at`", limit).
+		Where("`user_id` = ? AND `window_start` >= ?", userId, since).
+		Group("`key_id`").
+		Order("`last_used_at` DESC").
+		Scan(&list).Error
+	return
+}
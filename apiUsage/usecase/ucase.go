@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewApiUsageUseCase(apiUsageRepo domain.ApiUsageRepository, ids domain.IdGenerator, clock domain.Clock, timeout time.Duration) domain.ApiUsageUseCase {
+	return &ucase{
+		apiUsageRepo: apiUsageRepo,
+		ids:          ids,
+		clock:        clock,
+		timeout:      timeout,
+	}
+}
+
+type ucase struct {
+	apiUsageRepo domain.ApiUsageRepository
+	ids          domain.IdGenerator
+	clock        domain.Clock
+	timeout      time.Duration
+}
+
+// rateLimitOf requests 는 지금 구간에서 이번 요청까지 센 값
+func rateLimitOf(requests int64, windowStart time.Time) domain.RateLimit {
+	remaining := domain.ApiKeyRateLimit - requests
+	if remaining < 0 {
+		remaining = 0
+	}
+	return domain.RateLimit{
+		Limit:     domain.ApiKeyRateLimit,
+		Remaining: remaining,
+		Reset:     windowStart.Add(domain.ApiKeyRateWindow),
+		Exceeded:  requests > domain.ApiKeyRateLimit,
+	}
+}
+
+func (u *ucase) Hit(ctx context.Context, keyId, userId uuid.UUID) (res domain.RateLimit, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	windowStart := u.clock.Now().Truncate(domain.ApiKeyRateWindow)
+	requests, err := u.apiUsageRepo.Hit(c, u.ids.NewId(), keyId, userId, windowStart)
+	if err != nil {
+		return
+	}
+
+	res = rateLimitOf(requests, windowStart)
+	return
+}
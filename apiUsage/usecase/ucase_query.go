@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchMyApiUsage(ctx context.Context, userId uuid.UUID, period time.Duration) (res []domain.ApiKeyUsageInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if period <= 0 || period > domain.ApiUsageMaxPeriod {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	now := u.clock.Now()
+	list, err := u.apiUsageRepo.SumByUser(c, userId, now.Add(-period), domain.ApiKeyRateLimit)
+	if err != nil || len(list) == 0 {
+		return
+	}
+
+	windowStart := now.Truncate(domain.ApiKeyRateWindow)
+	current, err := u.apiUsageRepo.SumByUser(c, userId, windowStart, domain.ApiKeyRateLimit)
+	if err != nil {
+		return
+	}
+	currentByKey := make(map[uuid.UUID]int64, len(current))
+	for _, usage := range current {
+		currentByKey[usage.KeyId] = usage.Requests
+	}
+
+	res = make([]domain.ApiKeyUsageInfo, len(list))
+	for i := range list {
+		res[i] = domain.ApiKeyUsageInfo{
+			ApiKeyUsage: list[i],
+			Current:     rateLimitOf(currentByKey[list[i].KeyId], windowStart),
+		}
+	}
+	return
+}
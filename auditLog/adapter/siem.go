@@ -0,0 +1,431 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	tag = "[AUDIT] "
+
+	siemSendTimeout = 10 * time.Second
+	// siemSyslogPriority facility authpriv(10), severity notice(5)
+	siemSyslogPriority = 10*8 + 5
+	siemAppName        = "editfolio"
+)
+
+type SiemFormat string
+
+const (
+	SiemFormatJson SiemFormat = "json"
+	SiemFormatCef  SiemFormat = "cef"
+)
+
+type SiemOption struct {
+	// Addr syslog 주소 (tcp://host:514, udp://host:514, tls://host:6514), URL 과 둘 중 하나
+	Addr string
+	// URL HTTPS 수집 주소, 묶음을 한 번에 POST (json 이면 배열, cef 면 줄바꿈 구분)
+	URL   string
+	Token string
+
+	Format SiemFormat
+	// BufferSize 보내지 못하고 쌓아둘 수 있는 기록 수, 넘으면 새 기록을 버림
+	BufferSize int
+	// BatchSize 한 번에 보내는 최대 기록 수, FlushInterval 마다 모인 만큼 보냄
+	BatchSize     int
+	FlushInterval time.Duration
+	Retry         retry.Policy
+}
+
+// NewAuditExporter 주소가 없으면 아무것도 보내지 않는 exporter 반환
+func NewAuditExporter(option SiemOption) (domain.AuditExporter, error) {
+	if option.Format != SiemFormatCef {
+		option.Format = SiemFormatJson
+	}
+
+	var sender siemSender
+	switch {
+	case option.URL != "":
+		u, err := url.Parse(option.URL)
+		if err != nil || u.Scheme != "https" {
+			return nil, fmt.Errorf("siem url must be https: %q", option.URL)
+		}
+		sender = &siemHttpSender{url: option.URL, token: option.Token, format: option.Format, client: &http.Client{}}
+	case option.Addr != "":
+		u, err := url.Parse(option.Addr)
+		if err != nil || (u.Scheme != "tcp" && u.Scheme != "udp" && u.Scheme != "tls") {
+			return nil, fmt.Errorf("siem syslog addr must be tcp://, udp:// or tls://: %q", option.Addr)
+		}
+		hostname, _ := os.Hostname()
+		sender = &siemSyslogSender{network: u.Scheme, host: u.Host, hostname: hostname, format: option.Format}
+	default:
+		return &nopExporter{}, nil
+	}
+
+	if option.BufferSize <= 0 {
+		option.BufferSize = 10000
+	}
+	if option.BatchSize <= 0 {
+		option.BatchSize = 100
+	}
+	if option.FlushInterval <= 0 {
+		option.FlushInterval = time.Second
+	}
+
+	e := &siemExporter{
+		sender: sender,
+		option: option,
+		queue:  make(chan domain.AuditLog, option.BufferSize),
+		done:   make(chan struct{}),
+	}
+	diagnostics.RegisterProbe("siem", e.probe)
+	go e.run()
+	return e, nil
+}
+
+type nopExporter struct{}
+
+func (*nopExporter) Export(domain.AuditLog) {}
+
+func (*nopExporter) Close(context.Context) error {
+	return nil
+}
+
+type siemSender interface {
+	Send(ctx context.Context, list []domain.AuditLog) error
+	Close() error
+}
+
+type siemExporter struct {
+	sender siemSender
+	option SiemOption
+	queue  chan domain.AuditLog
+	done   chan struct{}
+
+	// closeMu Export 중에 queue 를 닫지 않도록
+	closeMu sync.RWMutex
+	closed  bool
+
+	// 기록 수, 재시작하면 0
+	exported int64
+	dropped  int64
+	failed   int64
+
+	mu      sync.Mutex
+	lastErr error
+	lastAt  time.Time
+}
+
+// Export 버퍼가 차면 요청을 막지 않도록 버리고 dropped 로 셈
+func (e *siemExporter) Export(log domain.AuditLog) {
+	e.closeMu.RLock()
+	defer e.closeMu.RUnlock()
+
+	if e.closed {
+		e.drop(1)
+		return
+	}
+
+	select {
+	case e.queue <- log:
+	default:
+		e.drop(1)
+	}
+}
+
+func (e *siemExporter) drop(n int64) {
+	if atomic.AddInt64(&e.dropped, n) == n {
+		log.Warn(tag, "siem buffer full, dropping audit events")
+	}
+	diagnostics.AddCounter("siem.dropped", uint64(n))
+}
+
+// Close 남은 기록을 보내지 못하고 ctx 가 끝나면 에러, 보내는 중인 연결은 그대로 둠
+func (e *siemExporter) Close(ctx context.Context) error {
+	e.closeMu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.queue)
+	}
+	e.closeMu.Unlock()
+
+	select {
+	case <-e.done:
+		return e.sender.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *siemExporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.option.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.AuditLog, 0, e.option.BatchSize)
+	for {
+		select {
+		case item, ok := <-e.queue:
+			if !ok {
+				e.flush(batch)
+				return
+			}
+			batch = append(batch, item)
+			if len(batch) < e.option.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		e.flush(batch)
+		batch = batch[:0]
+	}
+}
+
+// flush 재시도까지 실패하면 묶음을 버리고 failed 로 셈, 감사 로그 원본은 DB 에 남아있음
+func (e *siemExporter) flush(batch []domain.AuditLog) {
+	if len(batch) == 0 {
+		return
+	}
+
+	c, cancel := context.WithTimeout(context.Background(), siemSendTimeout*time.Duration(e.option.Retry.MaxAttempts+1))
+	defer cancel()
+
+	err := retry.Do(c, e.option.Retry, func(ctx context.Context) error {
+		return e.sender.Send(ctx, batch)
+	})
+
+	e.mu.Lock()
+	e.lastErr = err
+	e.lastAt = time.Now()
+	e.mu.Unlock()
+
+	n := int64(len(batch))
+	if err != nil {
+		atomic.AddInt64(&e.failed, n)
+		diagnostics.AddCounter("siem.failed", uint64(n))
+		log.WithError(err).WithField("count", n).Error(tag, "siem export failed")
+		return
+	}
+
+	atomic.AddInt64(&e.exported, n)
+	diagnostics.AddCounter("siem.exported", uint64(n))
+}
+
+// probe 마지막 전송이 실패했으면 DEGRADED, 감사 로그 기록은 계속되므로 DOWN 은 아님
+func (e *siemExporter) probe(context.Context) diagnostics.ProbeResult {
+	e.mu.Lock()
+	lastErr, lastAt := e.lastErr, e.lastAt
+	e.mu.Unlock()
+
+	res := diagnostics.ProbeResult{
+		Status: diagnostics.ProbeStatusUp,
+		Detail: map[string]interface{}{
+			"queued":   len(e.queue),
+			"buffer":   cap(e.queue),
+			"exported": atomic.LoadInt64(&e.exported),
+			"dropped":  atomic.LoadInt64(&e.dropped),
+			"failed":   atomic.LoadInt64(&e.failed),
+		},
+	}
+	if !lastAt.IsZero() {
+		res.Detail["lastSentAt"] = lastAt
+	}
+	if lastErr != nil {
+		res.Status = diagnostics.ProbeStatusDegraded
+		res.Detail["error"] = lastErr.Error()
+	}
+	return res
+}
+
+type siemEvent struct {
+	Id        string    `json:"id"`
+	ActorId   string    `json:"actorId"`
+	TargetId  string    `json:"targetId"`
+	Action    string    `json:"action"`
+	Ip        string    `json:"ip"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	App       string    `json:"app"`
+}
+
+func siemEventOf(src domain.AuditLog) siemEvent {
+	return siemEvent{
+		Id:        src.Id.String(),
+		ActorId:   src.ActorId.String(),
+		TargetId:  src.TargetId.String(),
+		Action:    string(src.Action),
+		Ip:        src.Ip,
+		Detail:    src.Detail,
+		CreatedAt: src.CreatedAt,
+		App:       siemAppName,
+	}
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+// formatCef ArcSight CEF, 관리자 변경 작업이라 심각도는 모두 5
+func formatCef(src domain.AuditLog) string {
+	action := cefHeaderEscaper.Replace(string(src.Action))
+	return fmt.Sprintf("CEF:0|Stockfolio|Editfolio|1.0|%s|%s|5|rt=%d suser=%s duser=%s src=%s externalId=%s msg=%s",
+		action, action,
+		src.CreatedAt.UnixNano()/int64(time.Millisecond),
+		cefExtensionEscaper.Replace(src.ActorId.String()),
+		cefExtensionEscaper.Replace(src.TargetId.String()),
+		cefExtensionEscaper.Replace(src.Ip),
+		cefExtensionEscaper.Replace(src.Id.String()),
+		cefExtensionEscaper.Replace(src.Detail))
+}
+
+func formatMessage(format SiemFormat, src domain.AuditLog) (string, error) {
+	if format == SiemFormatCef {
+		return formatCef(src), nil
+	}
+	b, err := json.Marshal(siemEventOf(src))
+	return string(b), err
+}
+
+type siemHttpSender struct {
+	url    string
+	token  string
+	format SiemFormat
+	client *http.Client
+}
+
+func (s *siemHttpSender) Send(ctx context.Context, list []domain.AuditLog) error {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+	if s.format == SiemFormatCef {
+		lines := make([]string, len(list))
+		for i := range list {
+			lines[i] = formatCef(list[i])
+		}
+		body = []byte(strings.Join(lines, "\n") + "\n")
+		contentType = "text/plain; charset=utf-8"
+	} else {
+		events := make([]siemEvent, len(list))
+		for i := range list {
+			events[i] = siemEventOf(list[i])
+		}
+		body, err = json.Marshal(events)
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		contentType = "application/json"
+	}
+
+	c, cancel := budget.Slice(ctx, siemSendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return retry.Permanent(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return &retry.StatusError{Service: "siem", Code: res.StatusCode}
+	}
+	return nil
+}
+
+func (s *siemHttpSender) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// siemSyslogSender RFC 5424, TCP, TLS 는 octet counting (RFC 6587) 으로 구분, 연결은 실패할 때까지 재사용
+type siemSyslogSender struct {
+	network  string
+	host     string
+	hostname string
+	format   SiemFormat
+
+	conn net.Conn
+}
+
+func (s *siemSyslogSender) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: siemSendTimeout}
+	if s.network == "tls" {
+		td := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{MinVersion: tls.VersionTLS12}}
+		return td.DialContext(ctx, "tcp", s.host)
+	}
+	return dialer.DialContext(ctx, s.network, s.host)
+}
+
+func (s *siemSyslogSender) Send(ctx context.Context, list []domain.AuditLog) (err error) {
+	if s.conn == nil {
+		s.conn, err = s.dial(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(siemSendTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = s.conn.SetWriteDeadline(deadline)
+
+	for i := range list {
+		msg, err := formatMessage(s.format, list[i])
+		if err != nil {
+			return retry.Permanent(err)
+		}
+
+		line := fmt.Sprintf("<%d>1 %s %s %s - - - %s",
+			siemSyslogPriority, list[i].CreatedAt.UTC().Format(time.RFC3339Nano), s.hostname, siemAppName, msg)
+		if s.network != "udp" {
+			line = fmt.Sprintf("%d %s", len(line), line)
+		}
+
+		_, err = s.conn.Write([]byte(line))
+		if err != nil {
+			// 다음 시도에서 다시 연결, 이미 보낸 기록은 중복될 수 있음 (externalId, id 로 구분)
+			_ = s.conn.Close()
+			s.conn = nil
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *siemSyslogSender) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[AUDIT_LOG] "
+)
+
+func NewAuditLogController(useCase domain.AuditLogUseCase) *AuditLogController {
+	return &AuditLogController{useCase: useCase}
+}
+
+type AuditLogController struct {
+	useCase domain.AuditLogUseCase
+}
+
+func (c *AuditLogController) Bind(e *echo.Echo) {
+	// ===== SUPER ADMIN =====
+	e.GET("/audit", c.fetchAuditLogs,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type FetchAuditLogsRequest struct {
+	From     string     `query:"from" validate:"required"`
+	To       string     `query:"to" validate:"required"`
+	ActorId  *uuid.UUID `query:"actorId"`
+	TargetId *uuid.UUID `query:"targetId"`
+	Action   string     `query:"action" validate:"omitempty,oneof=ADMIN_CREATED ADMIN_DELETED ADMIN_INFO_FORCE_UPDATED ADMIN_PASSWORD_FORCE_UPDATED CUSTOMER_DELETED ORDERS_REASSIGNED SUBSCRIPTION_ATTACHED USER_RESTORED CUSTOMER_MERGED PASSWORD_ROTATION_FORCED SETTING_UPDATED SETTING_RESET TENANT_CREDENTIAL_SET TENANT_CREDENTIAL_DELETED CREDIT_ADJUSTED"`
+	Limit    int        `query:"limit" validate:"omitempty,min=1,max=500"`
+} // @name FetchAuditLogsRequest
+
+type AuditLogResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// ActorId, 작업한 관리자
+	ActorId uuid.UUID `json:"actorId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// TargetId, 대상 유저, 대상이 유저가 아닌 작업은 00000000-0000-0000-0000-000000000000
+	TargetId uuid.UUID          `json:"targetId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Action   domain.AuditAction `json:"action" validate:"required" example:"ADMIN_CREATED"`
+	Ip       string             `json:"ip" validate:"required" example:"203.0.113.10"`
+	// Detail, 설정 키, 테넌트/연동, 조정한 크레딧 등 작업별 대상
+	Detail    string    `json:"detail" example:"security.password_min_length"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2024-05-03T14:00:00+09:00"`
+} // @name AuditLogResponse
+
+// @Tags (AuditLog) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 관리자 작업 기록
+// @Description 어드민 생성, 삭제, 강제 수정, 고객 삭제, 의뢰 일괄 이관 기록, 최근 것부터, 최대 93일, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param from query string true "시작 날짜(KST), 포함" example(2024-05-01)
+// @Param to query string true "끝 날짜(KST), 포함" example(2024-05-31)
+// @Param actorId query string false "작업한 관리자 아이디(UUID)"
+// @Param targetId query string false "대상 유저 아이디(UUID)"
+// @Param action query string false "작업" Enums(ADMIN_CREATED, ADMIN_DELETED, ADMIN_INFO_FORCE_UPDATED, ADMIN_PASSWORD_FORCE_UPDATED, CUSTOMER_DELETED, ORDERS_REASSIGNED, SUBSCRIPTION_ATTACHED, USER_RESTORED, CUSTOMER_MERGED, PASSWORD_ROTATION_FORCED, SETTING_UPDATED, SETTING_RESET, TENANT_CREDENTIAL_SET, TENANT_CREDENTIAL_DELETED, CREDIT_ADJUSTED)
+// @Param before query string false "이 시각(RFC3339)보다 먼저 남긴 것만, 다음 쪽은 이전 목록의 마지막 createdAt"
+// @Param limit query int false "개수 (기본 50, 최대 500)"
+// @Success 200 {array} AuditLogResponse "성공"
+// @Success 204 "없음"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류, 잘못된 날짜, 기간"
+// @Router /audit [get]
+func (c *AuditLogController) fetchAuditLogs(ctx echo.Context) error {
+	var req FetchAuditLogsRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch audit logs, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	var before *time.Time
+	if raw := ctx.QueryParam("before"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		}
+		before = &parsed
+	}
+
+	var action *domain.AuditAction
+	if req.Action != "" {
+		a := domain.AuditAction(req.Action)
+		action = &a
+	}
+
+	list, err := c.useCase.FetchAuditLogs(ctx.Request().Context(), domain.FetchAuditLogs{
+		From:     req.From,
+		To:       req.To,
+		ActorId:  req.ActorId,
+		TargetId: req.TargetId,
+		Action:   action,
+		Before:   before,
+		Limit:    req.Limit,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "fetchAuditLogs, unhandled error useCase.FetchAuditLogs")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]AuditLogResponse, len(list))
+	for i, info := range list {
+		res[i] = AuditLogResponse{
+			Id:        info.Id,
+			ActorId:   info.ActorId,
+			TargetId:  info.TargetId,
+			Action:    info.Action,
+			Ip:        info.Ip,
+			Detail:    info.Detail,
+			CreatedAt: info.CreatedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
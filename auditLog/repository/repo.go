@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewAuditLogRepository(db *gorm.DB) domain.AuditLogRepository {
+	db.AutoMigrate(&domain.AuditLog{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Create(ctx context.Context, log *domain.AuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+func (r *repo) Fetch(ctx context.Context, option domain.FetchAuditLogOption) (list []domain.AuditLog, err error) {
+	query := r.db.WithContext(ctx).
+		Where("`created_at` >= ? AND `created_at` < ?", option.From, option.To)
+	if option.ActorId != nil {
+		query = query.Where("`actor_id` = ?", *option.ActorId)
+	}
+	if option.TargetId != nil {
+		query = query.Where("`target_id` = ?", *option.TargetId)
+	}
+	if option.Action != nil {
+		query = query.Where("`action` = ?", *option.Action)
+	}
+	if option.Before != nil {
+		query = query.Where("`created_at` < ?", *option.Before)
+	}
+
+	err = query.
+		Order("`created_at` desc").
+		Limit(option.Limit).
+		Find(&list).Error
+	return
+}
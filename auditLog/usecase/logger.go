@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[AUDIT_LOG] "
+
+// NewAuditLogger 다른 유스케이스에서 감사 로그를 남길 때 사용, 남긴 기록은 exporter 로 보안 관제에도 보냄
+func NewAuditLogger(
+	auditLogRepo domain.AuditLogRepository,
+	exporter domain.AuditExporter,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.AuditLogger {
+	return &logger{
+		auditLogRepo: auditLogRepo,
+		exporter:     exporter,
+		ids:          ids,
+		clock:        clock,
+		timeout:      timeout,
+	}
+}
+
+type logger struct {
+	auditLogRepo domain.AuditLogRepository
+	exporter     domain.AuditExporter
+	ids          domain.IdGenerator
+	clock        domain.Clock
+	timeout      time.Duration
+}
+
+func (l *logger) Record(ctx context.Context, entry domain.AuditEntry) {
+	c, cancel := budget.Slice(ctx, l.timeout)
+	defer cancel()
+
+	entity := domain.AuditLog{
+		Id:        l.ids.NewId(),
+		ActorId:   entry.ActorId,
+		TargetId:  entry.TargetId,
+		Action:    entry.Action,
+		Ip:        entry.Ip,
+		Detail:    entry.Detail,
+		CreatedAt: l.clock.Now(),
+	}
+	err := l.auditLogRepo.Create(c, &entity)
+	if err != nil {
+		logx.From(ctx).WithError(err).
+			WithField("entry", entry).
+			Error(tag, "Record, unhandled error auditLogRepo.Create")
+		return
+	}
+
+	// DB 에 남은 기록만 보냄
+	l.exporter.Export(entity)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewAuditLogUseCase(
+	auditLogRepo domain.AuditLogRepository,
+	calendar domain.Calendar,
+	timeout time.Duration,
+) domain.AuditLogUseCase {
+	return &ucase{
+		auditLogRepo: auditLogRepo,
+		calendar:     calendar,
+		timeout:      timeout,
+	}
+}
+
+type ucase struct {
+	auditLogRepo domain.AuditLogRepository
+	calendar     domain.Calendar
+	timeout      time.Duration
+}
+
+func (u *ucase) FetchAuditLogs(ctx context.Context, in domain.FetchAuditLogs) (res []domain.AuditLogInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if in.Action != nil && !in.Action.IsValid() {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	from, err := time.ParseInLocation(domain.AuditLogDateLayout, in.From, u.calendar.Location())
+	if err != nil {
+		err = domain.ErrWeirdData
+		return
+	}
+	to, err := time.ParseInLocation(domain.AuditLogDateLayout, in.To, u.calendar.Location())
+	if err != nil {
+		err = domain.ErrWeirdData
+		return
+	}
+	// 마지막 날짜 포함
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) || to.Sub(from) > domain.AuditLogMaxPeriod {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = domain.AuditLogDefaultLimit
+	}
+
+	list, err := u.auditLogRepo.Fetch(c, domain.FetchAuditLogOption{
+		From:     from,
+		To:       to,
+		ActorId:  in.ActorId,
+		TargetId: in.TargetId,
+		Action:   in.Action,
+		Before:   in.Before,
+		Limit:    limit,
+	})
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.AuditLogInfo, len(list))
+	for i, log := range list {
+		res[i] = domain.AuditLogInfo{
+			Id:        log.Id,
+			ActorId:   log.ActorId,
+			TargetId:  log.TargetId,
+			Action:    log.Action,
+			Ip:        log.Ip,
+			Detail:    log.Detail,
+			CreatedAt: log.CreatedAt,
+		}
+	}
+	return
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[AVAILABILITY] "
+)
+
+func NewManagerAvailabilityController(useCase domain.ManagerAvailabilityUseCase) *ManagerAvailabilityController {
+	return &ManagerAvailabilityController{useCase: useCase}
+}
+
+type ManagerAvailabilityController struct {
+	useCase domain.ManagerAvailabilityUseCase
+}
+
+func (c *ManagerAvailabilityController) Bind(e *echo.Echo) {
+	// ADMIN
+	// 내 쉬는 날
+	e.GET("/availability/me", echox.UserID(c.fetchMyUnavailable),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/availability/me", echox.UserID(c.setMyUnavailable),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.DELETE("/availability/me", echox.UserID(c.clearMyUnavailable),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// SUPER_ADMIN
+	// 팀 2주 현황
+	e.GET("/availability/team", c.fetchTeamAvailability,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type UnavailableDateResponse struct {
+	// Date, 기준 시간대(KST) 날짜
+	Date   string  `json:"date" validate:"required" example:"2024-05-03"`
+	Reason *string `json:"reason" example:"여름 휴가"`
+} // @name UnavailableDateResponse
+
+func unavailableDatesResponseOf(list []domain.ManagerUnavailableDate) []UnavailableDateResponse {
+	res := make([]UnavailableDateResponse, len(list))
+	for i, src := range list {
+		res[i] = UnavailableDateResponse{
+			Date:   src.Date.Format(domain.ManagerAvailabilityDateLayout),
+			Reason: src.Reason,
+		}
+	}
+	return res
+}
+
+// @Tags (Availability) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 내 쉬는 날 목록
+// @Description 오늘부터 등록한 쉬는 날, 날짜 순, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} UnavailableDateResponse "성공"
+// @Success 204 "쉬는 날 없음"
+// @Router /availability/me [get]
+func (c *ManagerAvailabilityController) fetchMyUnavailable(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.FetchMyUnavailable(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchMyUnavailable, unhandled error useCase.FetchMyUnavailable")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+	return ctx.JSON(http.StatusOK, unavailableDatesResponseOf(list))
+}
+
+type SetUnavailableRequest struct {
+	// From, To 기준 시간대(KST) 날짜, 둘 다 포함, 최대 60일
+	From   string  `json:"from" validate:"required" example:"2024-08-01"`
+	To     string  `json:"to" validate:"required" example:"2024-08-05"`
+	Reason *string `json:"reason" validate:"omitempty,max=200" example:"여름 휴가"`
+} // @name SetUnavailableRequest
+
+// @Tags (Availability) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 쉬는 날 등록
+// @Description 기간의 모든 날을 쉬는 날로 등록, 이미 등록한 날은 그대로, 쉬는 날에는 자동 배정을 받지 않고 자동 배정된 의뢰 마감일은 쉬는 날만큼 늦춰짐
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body SetUnavailableRequest true "쉬는 기간"
+// @Success 204 "등록 완료"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 날짜, 지난 날짜, 60일 초과"
+// @Router /availability/me [put]
+func (c *ManagerAvailabilityController) setMyUnavailable(ctx echo.Context, userId uuid.UUID) error {
+	var req SetUnavailableRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "set unavailable, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.SetManagerUnavailable{
+		ManagerId: userId,
+		From:      req.From,
+		To:        req.To,
+		Reason:    req.Reason,
+	}
+	err = c.useCase.SetUnavailable(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "setMyUnavailable, unhandled error useCase.SetUnavailable")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ClearUnavailableRequest struct {
+	From string `query:"from" validate:"required"`
+	To   string `query:"to" validate:"required"`
+} // @name ClearUnavailableRequest
+
+// @Tags (Availability) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 쉬는 날 취소
+// @Description 기간 안의 쉬는 날 삭제, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param from query string true "시작 날짜(KST), 포함" example(2024-08-01)
+// @Param to query string true "끝 날짜(KST), 포함" example(2024-08-05)
+// @Success 204 "삭제 완료"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 날짜, 60일 초과"
+// @Router /availability/me [delete]
+func (c *ManagerAvailabilityController) clearMyUnavailable(ctx echo.Context, userId uuid.UUID) error {
+	var req ClearUnavailableRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "clear unavailable, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.ClearManagerUnavailable{
+		ManagerId: userId,
+		From:      req.From,
+		To:        req.To,
+	}
+	err = c.useCase.ClearUnavailable(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "clearMyUnavailable, unhandled error useCase.ClearUnavailable")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ManagerAvailabilityResponse struct {
+	ManagerId uuid.UUID `json:"managerId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name      string    `json:"name" validate:"required" example:"김철수"`
+	Nickname  string    `json:"nickname" validate:"required" example:"편집왕"`
+	// UnavailableDates, 기간 안에 쉬는 날, 없으면 빈 배열
+	UnavailableDates []UnavailableDateResponse `json:"unavailableDates" validate:"required"`
+} // @name ManagerAvailabilityResponse
+
+// @Tags (Availability) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 팀 쉬는 날 현황
+// @Description 모든 담당자의 오늘부터 2주 동안 쉬는 날, 쉬는 날이 없는 담당자 포함, 이름 순, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} ManagerAvailabilityResponse "성공"
+// @Success 204 "담당자 없음"
+// @Router /availability/team [get]
+func (c *ManagerAvailabilityController) fetchTeamAvailability(ctx echo.Context) error {
+	list, err := c.useCase.FetchTeamAvailability(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchTeamAvailability, unhandled error useCase.FetchTeamAvailability")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]ManagerAvailabilityResponse, len(list))
+	for i, src := range list {
+		res[i] = ManagerAvailabilityResponse{
+			ManagerId:        src.ManagerId,
+			Name:             src.Name,
+			Nickname:         src.Nickname,
+			UnavailableDates: unavailableDatesResponseOf(src.UnavailableDates),
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewManagerUnavailabilityRepository(db *gorm.DB) domain.ManagerUnavailabilityRepository {
+	db.AutoMigrate(&domain.ManagerUnavailability{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Add(ctx context.Context, list []domain.ManagerUnavailability) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&list).Error
+}
+
+func (r *repo) Delete(ctx context.Context, managerId uuid.UUID, from, to time.Time) (int64, error) {
+	db := r.db.WithContext(ctx).
+		Where("`manager_id` = ? AND `date` BETWEEN ? AND ?", managerId, from, to).
+		Delete(&domain.ManagerUnavailability{})
+	return db.RowsAffected, db.Error
+}
+
+func (r *repo) FetchByManagerId(ctx context.Context, managerId uuid.UUID, from time.Time) (list []domain.ManagerUnavailability, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`manager_id` = ? AND `date` >= ?", managerId, from).
+		Order("`date` asc").
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchBetween(ctx context.Context, from, to time.Time) (list []domain.ManagerUnavailability, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`date` BETWEEN ? AND ?", from, to).
+		Order("`date` asc").
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchManagers(ctx context.Context) (list []domain.Manager, err error) {
+	err = r.db.WithContext(ctx).
+		Joins("JOIN `user` ON `user`.`id` = `manager`.`id`").
+		Where("`user`.`deleted_at` IS NULL AND `user`.`role` IN ?",
+			[]domain.UserRole{domain.AdminUserRole, domain.SuperAdminUserRole}).
+		Order("`manager`.`name` asc").
+		Find(&list).Error
+	return
+}
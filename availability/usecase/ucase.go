@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewManagerAvailabilityUseCase(
+	unavailabilityRepo domain.ManagerUnavailabilityRepository,
+	userRepo domain.UserRepository,
+	calendar domain.Calendar,
+	ids domain.IdGenerator,
+	timeout time.Duration,
+) domain.ManagerAvailabilityUseCase {
+	return &ucase{
+		unavailabilityRepo: unavailabilityRepo,
+		userRepo:           userRepo,
+		calendar:           calendar,
+		ids:                ids,
+		timeout:            timeout,
+	}
+}
+
+type ucase struct {
+	unavailabilityRepo domain.ManagerUnavailabilityRepository
+	userRepo           domain.UserRepository
+	calendar           domain.Calendar
+	ids                domain.IdGenerator
+	timeout            time.Duration
+}
+
+// parsePeriod 기준 시간대 날짜, 둘 다 포함, 기간이 ManagerUnavailableMaxDays 를 넘으면 ErrWeirdData
+func (u *ucase) parsePeriod(rawFrom, rawTo string) (from, to time.Time, err error) {
+	from, err = time.ParseInLocation(domain.ManagerAvailabilityDateLayout, rawFrom, u.calendar.Location())
+	if err != nil {
+		err = domain.ErrWeirdData
+		return
+	}
+	to, err = time.ParseInLocation(domain.ManagerAvailabilityDateLayout, rawTo, u.calendar.Location())
+	if err != nil {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	from, to = u.calendar.DateOf(from), u.calendar.DateOf(to)
+	if to.Before(from) || to.Sub(from) >= domain.ManagerUnavailableMaxDays*24*time.Hour {
+		err = domain.ErrWeirdData
+	}
+	return
+}
+
+func (u *ucase) checkManager(ctx context.Context, managerId uuid.UUID) error {
+	user, err := u.userRepo.GetById(ctx, managerId)
+	if err != nil {
+		return err
+	}
+
+	if !domain.CheckUserAlive(user,
+		domain.User.IsAdmin,
+		domain.User.IsSuperAdmin) {
+		return domain.ErrNoPermission
+	}
+	return nil
+}
+
+func (u *ucase) SetUnavailable(ctx context.Context, in domain.SetManagerUnavailable) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	from, to, err := u.parsePeriod(in.From, in.To)
+	if err != nil {
+		return
+	}
+
+	// 지난 날은 이미 배정, 마감일 계산이 끝나서 의미 없음
+	if from.Before(u.calendar.DateOf(u.calendar.Now())) {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	err = u.checkManager(c, in.ManagerId)
+	if err != nil {
+		return
+	}
+
+	now := u.calendar.Now()
+	var list []domain.ManagerUnavailability
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		list = append(list, domain.ManagerUnavailability{
+			Id:        u.ids.NewId(),
+			ManagerId: in.ManagerId,
+			Date:      date,
+			Reason:    in.Reason,
+			CreatedAt: now,
+		})
+	}
+
+	err = u.unavailabilityRepo.Add(c, list)
+	return
+}
+
+func (u *ucase) ClearUnavailable(ctx context.Context, in domain.ClearManagerUnavailable) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	from, to, err := u.parsePeriod(in.From, in.To)
+	if err != nil {
+		return
+	}
+
+	err = u.checkManager(c, in.ManagerId)
+	if err != nil {
+		return
+	}
+
+	_, err = u.unavailabilityRepo.Delete(c, in.ManagerId, from, to)
+	return
+}
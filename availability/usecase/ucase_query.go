@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchMyUnavailable(ctx context.Context, managerId uuid.UUID) (res []domain.ManagerUnavailableDate, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.unavailabilityRepo.FetchByManagerId(c, managerId, u.calendar.DateOf(u.calendar.Now()))
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.ManagerUnavailableDate, len(list))
+	for i, src := range list {
+		res[i] = domain.ManagerUnavailableDate{
+			Date:   src.Date,
+			Reason: src.Reason,
+		}
+	}
+	return
+}
+
+func (u *ucase) FetchTeamAvailability(ctx context.Context) (res []domain.ManagerAvailabilityInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	from := u.calendar.DateOf(u.calendar.Now())
+	to := from.AddDate(0, 0, domain.ManagerAvailabilityOverviewDays-1)
+
+	var (
+		managers []domain.Manager
+		list     []domain.ManagerUnavailability
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		managers, err = u.unavailabilityRepo.FetchManagers(gc)
+		return
+	})
+	g.Go(func() (err error) {
+		list, err = u.unavailabilityRepo.FetchBetween(gc, from, to)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	byManager := make(map[uuid.UUID][]domain.ManagerUnavailableDate, len(managers))
+	for _, src := range list {
+		byManager[src.ManagerId] = append(byManager[src.ManagerId], domain.ManagerUnavailableDate{
+			Date:   src.Date,
+			Reason: src.Reason,
+		})
+	}
+
+	res = make([]domain.ManagerAvailabilityInfo, len(managers))
+	for i, manager := range managers {
+		dates := byManager[manager.Id]
+		if dates == nil {
+			dates = []domain.ManagerUnavailableDate{}
+		}
+		res[i] = domain.ManagerAvailabilityInfo{
+			ManagerId:        manager.Id,
+			Name:             manager.Name,
+			Nickname:         manager.Nickname,
+			UnavailableDates: dates,
+		}
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+type MysqlOption struct {
+	Host string
+	Port uint16
+	User string
+	// Pass 실행할 때마다 호출, 비밀 저장소에서 교체된 비밀번호 반영
+	Pass func(ctx context.Context) (string, error)
+	Name string
+	Dir  string
+}
+
+// NewMysqlDumpAdapter mysqldump, mysql client 로 백업/복원, 실행 환경에 client 가 설치되어 있어야 함
+func NewMysqlDumpAdapter(db *gorm.DB, option MysqlOption) domain.BackupAdapter {
+	return &mysqlDump{db: db, option: option}
+}
+
+type mysqlDump struct {
+	db     *gorm.DB
+	option MysqlOption
+}
+
+func (m *mysqlDump) command(ctx context.Context, name string, args ...string) (*exec.Cmd, error) {
+	pass, err := m.option.Pass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	base := []string{
+		"-h", m.option.Host,
+		"-P", strconv.Itoa(int(m.option.Port)),
+		"-u", m.option.User,
+	}
+	cmd := exec.CommandContext(ctx, name, append(base, args...)...)
+	cmd.Env = append(os.Environ(), "MYSQL_PWD="+pass)
+	return cmd, nil
+}
+
+func run(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("%s: %w, %s", filepath.Base(cmd.Path), err, stderr.String())
+	}
+	return nil
+}
+
+func (m *mysqlDump) Dump(ctx context.Context, name string) (location string, size int64, err error) {
+	// 덤프에 개인정보가 그대로 들어있으므로 소유자만 읽고 쓸 수 있게 만듦
+	err = os.MkdirAll(m.option.Dir, 0o700)
+	if err != nil {
+		return
+	}
+
+	location = filepath.Join(m.option.Dir, name+".sql")
+	file, err := os.OpenFile(location, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	cmd, err := m.command(ctx, "mysqldump", "--single-transaction", "--routines", "--triggers", m.option.Name)
+	if err != nil {
+		os.Remove(location)
+		return
+	}
+	cmd.Stdout = file
+	err = run(cmd)
+	if err != nil {
+		os.Remove(location)
+		return
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		return
+	}
+
+	size = stat.Size()
+	return
+}
+
+func (m *mysqlDump) Restore(ctx context.Context, location, schema string) (err error) {
+	err = m.db.WithContext(ctx).
+		Exec(fmt.Sprintf("CREATE DATABASE `%s` CHARACTER SET utf8mb4", schema)).Error
+	if err != nil {
+		return
+	}
+
+	file, err := os.Open(location)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	cmd, err := m.command(ctx, "mysql", schema)
+	if err != nil {
+		return
+	}
+	cmd.Stdin = file
+	return run(cmd)
+}
+
+func (m *mysqlDump) DropSchema(ctx context.Context, schema string) error {
+	return m.db.WithContext(ctx).
+		Exec(fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", schema)).Error
+}
+
+func (m *mysqlDump) CountRows(ctx context.Context, schema, table string) (cnt int64, err error) {
+	if schema == "" {
+		schema = m.option.Name
+	}
+
+	var exists int64
+	err = m.db.WithContext(ctx).
+		Raw("SELECT COUNT(*) FROM `information_schema`.`tables` WHERE `table_schema` = ? AND `table_name` = ?", schema, table).
+		Scan(&exists).Error
+	if err != nil {
+		return
+	}
+
+	if exists == 0 {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = m.db.WithContext(ctx).
+		Raw(fmt.Sprintf("SELECT COUNT(*) FROM `%s`.`%s`", schema, table)).
+		Scan(&cnt).Error
+	return
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[BACKUP] "
+)
+
+func NewBackupController(useCase domain.BackupUseCase) *BackupController {
+	return &BackupController{useCase: useCase}
+}
+
+type BackupController struct {
+	useCase domain.BackupUseCase
+}
+
+type BackupResponse struct {
+	Id           uuid.UUID  `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status       string     `json:"status" validate:"required" example:"COMPLETED" enums:"RUNNING,COMPLETED,FAILED"`
+	Location     *string    `json:"location" example:"backup/20211027T044418-550e8400-e29b-41d4-a716-446655440000.sql"`
+	SizeBytes    int64      `json:"sizeBytes" validate:"required" example:"10485760"`
+	Error        *string    `json:"error" example:"mysqldump: exit status 2"`
+	StartedAt    time.Time  `json:"startedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+	FinishedAt   *time.Time `json:"finishedAt" example:"2021-10-27T04:45:18+00:00"`
+	VerifyStatus string     `json:"verifyStatus" validate:"required" example:"PASSED" enums:"PENDING,PASSED,FAILED"`
+	VerifyError  *string    `json:"verifyError" example:"table=user, not restored"`
+	VerifiedAt   *time.Time `json:"verifiedAt" example:"2021-10-27T05:00:00+00:00"`
+} // @name BackupResponse
+
+func useCaseToBackupResponse(src domain.BackupInfo) BackupResponse {
+	return BackupResponse{
+		Id:           src.Id,
+		Status:       string(src.Status),
+		Location:     src.Location,
+		SizeBytes:    src.SizeBytes,
+		Error:        src.Error,
+		StartedAt:    src.StartedAt,
+		FinishedAt:   src.FinishedAt,
+		VerifyStatus: string(src.VerifyStatus),
+		VerifyError:  src.VerifyError,
+		VerifiedAt:   src.VerifiedAt,
+	}
+}
+
+func (c *BackupController) Bind(e *echo.Echo) {
+	// ADMIN
+	e.POST("/backup", echox.UserID(c.startBackup),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/backup/:backupId/verify", c.verifyBackup,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/dashboard/backups", c.fetchRecentBackups,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/backup", c.internalStartBackup)
+	e.POST("/internal/backup/verify", c.internalVerifyLatest)
+}
+
+func (c *BackupController) respondBackupNotFound(ctx echo.Context) error {
+	return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "completed backup not found"})
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type StartBackupResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name StartBackupResponse
+
+// @Tags (Backup) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] DB 백업 시작
+// @Description 논리 백업(mysqldump)을 백그라운드로 시작, 진행 상황은 /dashboard/backups 에서 확인, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 202 {object} StartBackupResponse "백업 시작"
+// @Router /backup [post]
+func (c *BackupController) startBackup(ctx echo.Context, userId uuid.UUID) error {
+	newId, err := c.useCase.StartBackup(ctx.Request().Context(), &userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "startBackup, unhandled error useCase.StartBackup")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusAccepted, StartBackupResponse{Id: newId})
+}
+
+// @Tags (Backup) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] DB 백업 복원 검증
+// @Description 백업을 임시 스키마에 복원해 핵심 테이블을 확인한 뒤 임시 스키마 삭제, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param backup_id path string true "백업 식별 아이디(UUID)"
+// @Success 200 {object} BackupResponse "검증 완료, 결과는 verifyStatus 확인"
+// @Failure 404 {object} domain.ErrorResponse "완료된 백업 없음"
+// @Router /backup/{backup_id}/verify [post]
+func (c *BackupController) verifyBackup(ctx echo.Context) error {
+	var req struct {
+		BackupId uuid.UUID `param:"backupId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "verify backup, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.VerifyBackup(ctx.Request().Context(), &req.BackupId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, useCaseToBackupResponse(res))
+	case domain.ErrItemNotFound:
+		return c.respondBackupNotFound(ctx)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("id", req.BackupId).
+			Error(tag, "verifyBackup, unhandled error useCase.VerifyBackup")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Backup) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] DB 백업 현황
+// @Description 최근 백업과 복원 검증 결과 목록, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} BackupResponse "성공"
+// @Success 204 "백업 없음"
+// @Router /dashboard/backups [get]
+func (c *BackupController) fetchRecentBackups(ctx echo.Context) error {
+	list, err := c.useCase.FetchRecentBackups(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchRecentBackups, unhandled error useCase.FetchRecentBackups")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]BackupResponse, len(list))
+	for i := range list {
+		res[i] = useCaseToBackupResponse(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+func (c *BackupController) internalStartBackup(ctx echo.Context) error {
+	newId, err := c.useCase.StartBackup(ctx.Request().Context(), nil)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalStartBackup, unhandled error useCase.StartBackup")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusAccepted, echo.Map{
+		"backupId": newId,
+	})
+}
+
+func (c *BackupController) internalVerifyLatest(ctx echo.Context) error {
+	res, err := c.useCase.VerifyBackup(ctx.Request().Context(), nil)
+
+	switch err {
+	case nil:
+		if res.VerifyStatus == domain.BackupVerifyStatusFailed {
+			echox.Log(ctx).WithField("id", res.Id).
+				WithField("error", res.VerifyError).
+				Error(tag, "internalVerifyLatest, backup verify failed")
+		}
+		return ctx.JSON(http.StatusOK, echo.Map{
+			"backupId":     res.Id,
+			"verifyStatus": res.VerifyStatus,
+		})
+	case domain.ErrItemNotFound:
+		return c.respondBackupNotFound(ctx)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "internalVerifyLatest, unhandled error useCase.VerifyBackup")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
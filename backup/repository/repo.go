@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewBackupRepository(db *gorm.DB) domain.BackupRepository {
+	db.AutoMigrate(&domain.Backup{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, backup *domain.Backup) error {
+	return gormx.Upsert(ctx, r.db, backup)
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (res *domain.Backup, err error) {
+	var entity domain.Backup
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetLatestCompleted(ctx context.Context) (res *domain.Backup, err error) {
+	var entity domain.Backup
+	err = r.db.WithContext(ctx).
+		Order("`started_at` desc").
+		Where("`status` = ?", domain.BackupStatusCompleted).
+		First(&entity).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchRecent(ctx context.Context, limit int) (list []domain.Backup, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`started_at` desc").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
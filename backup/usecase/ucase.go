@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const tag = "[BACKUP] "
+
+func NewBackupUseCase(
+	backupRepo domain.BackupRepository,
+	backupAdapter domain.BackupAdapter,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.BackupUseCase {
+	return &ucase{
+		backupRepo:    backupRepo,
+		backupAdapter: backupAdapter,
+		ids:           ids,
+		clock:         clock,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	backupRepo    domain.BackupRepository
+	backupAdapter domain.BackupAdapter
+	ids           domain.IdGenerator
+	clock         domain.Clock
+	timeout       time.Duration
+}
+
+func toBackupInfo(src domain.Backup) domain.BackupInfo {
+	return domain.BackupInfo{
+		Id:           src.Id,
+		Status:       src.Status,
+		Location:     src.Location,
+		SizeBytes:    src.SizeBytes,
+		Error:        src.Error,
+		StartedAt:    src.StartedAt,
+		FinishedAt:   src.FinishedAt,
+		VerifyStatus: src.VerifyStatus,
+		VerifyError:  src.VerifyError,
+		VerifiedAt:   src.VerifiedAt,
+	}
+}
+
+func (u *ucase) StartBackup(ctx context.Context, requestedBy *uuid.UUID) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	backup := domain.CreateBackup(u.ids.NewId(), requestedBy, u.clock.Now())
+	err = u.backupRepo.Save(c, &backup)
+	if err != nil {
+		return
+	}
+
+	// 덤프는 요청 시간을 넘길 수 있어 요청 context 와 분리
+	go u.dump(backup)
+
+	newId = backup.Id
+	return
+}
+
+func (u *ucase) dump(backup domain.Backup) {
+	c, cancel := context.WithTimeout(context.Background(), u.timeout)
+	defer cancel()
+
+	name := fmt.Sprintf("%s-%s", backup.StartedAt.UTC().Format("20060102T150405"), backup.Id)
+	location, size, err := u.backupAdapter.Dump(c, name)
+	if err != nil {
+		backup.Fail(err, u.clock.Now())
+	} else {
+		backup.Complete(location, size, u.clock.Now())
+	}
+
+	err = u.backupRepo.Save(c, &backup)
+	if err != nil {
+		log.WithError(err).WithField("id", backup.Id).Error(tag, "dump, backupRepo.Save failed")
+	}
+}
+
+// verify 임시 스키마에 복원 후 핵심 테이블이 있고, 운영에 데이터가 있는 테이블이 비어있지 않은지 확인
+func (u *ucase) verify(ctx context.Context, backup domain.Backup) (err error) {
+	schema := "verify_" + strings.ReplaceAll(backup.Id.String(), "-", "")[:12]
+	defer u.backupAdapter.DropSchema(context.Background(), schema)
+
+	err = u.backupAdapter.Restore(ctx, *backup.Location, schema)
+	if err != nil {
+		return
+	}
+
+	for _, table := range domain.BackupCheckTables {
+		var restored, live int64
+		restored, err = u.backupAdapter.CountRows(ctx, schema, table)
+		if err == domain.ErrItemNotFound {
+			return fmt.Errorf("table=%s, not restored", table)
+		}
+		if err != nil {
+			return
+		}
+
+		live, err = u.backupAdapter.CountRows(ctx, "", table)
+		if err != nil {
+			return
+		}
+
+		if live > 0 && restored == 0 {
+			return fmt.Errorf("table=%s, restored empty but live has %d rows", table, live)
+		}
+	}
+	return
+}
+
+func (u *ucase) VerifyBackup(ctx context.Context, id *uuid.UUID) (res domain.BackupInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var backup *domain.Backup
+	if id != nil {
+		backup, err = u.backupRepo.GetById(c, *id)
+	} else {
+		backup, err = u.backupRepo.GetLatestCompleted(c)
+	}
+	if err != nil {
+		return
+	}
+
+	if backup == nil || !backup.IsVerifiable() {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	backup.Verified(u.verify(c, *backup), u.clock.Now())
+	err = u.backupRepo.Save(c, backup)
+	if err != nil {
+		return
+	}
+
+	res = toBackupInfo(*backup)
+	return
+}
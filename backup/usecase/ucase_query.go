@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const recentBackupLimit = 30
+
+func (u *ucase) FetchRecentBackups(ctx context.Context) (res []domain.BackupInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.backupRepo.FetchRecent(c, recentBackupLimit)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.BackupInfo, len(list))
+	for i := range list {
+		res[i] = toBackupInfo(list[i])
+	}
+	return
+}
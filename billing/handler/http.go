@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[BILLING] "
+)
+
+func NewBillingController(useCase domain.BillingUseCase) *BillingController {
+	return &BillingController{useCase: useCase}
+}
+
+type BillingController struct {
+	useCase domain.BillingUseCase
+}
+
+func (c *BillingController) Bind(e *echo.Echo) {
+	// CUSTOMER
+	e.GET("/billing/me", echox.UserID(c.getMyBilling),
+		middleware.RequireRole(domain.CustomerUserRole))
+}
+
+type BillingPlanResponse struct {
+	TicketId uuid.UUID `json:"ticketId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Name, 결제 메시지에 상품 이름이 없었으면 null
+	Name                *string    `json:"name" example:"스탠다드"`
+	TotalOrderCount     uint8      `json:"totalOrderCount" validate:"required" example:"4"`
+	RemainingOrderCount uint8      `json:"remainingOrderCount" validate:"required" example:"1"`
+	EditCount           uint8      `json:"editCount" validate:"required" example:"3"`
+	StartAt             *time.Time `json:"startAt" example:"2024-05-01T00:00:00+09:00"`
+	EndAt               *time.Time `json:"endAt" example:"2024-06-01T00:00:00+09:00"`
+} // @name BillingPlanResponse
+
+type BillingInvoiceResponse struct {
+	TicketId  uuid.UUID `json:"ticketId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ExOrderId string    `json:"exOrderId" validate:"required" example:"20240501-0001"`
+	PlanName  *string   `json:"planName" example:"스탠다드"`
+	// Amount, 결제 금액 (원)
+	Amount *int64 `json:"amount" example:"99000"`
+	// CreditApplied, 이 결제에 사용한 크레딧
+	CreditApplied int64      `json:"creditApplied" validate:"required" example:"3000"`
+	OrderCount    uint8      `json:"orderCount" validate:"required" example:"4"`
+	StartAt       *time.Time `json:"startAt" example:"2024-05-01T00:00:00+09:00"`
+	EndAt         *time.Time `json:"endAt" example:"2024-06-01T00:00:00+09:00"`
+	PaidAt        time.Time  `json:"paidAt" validate:"required" example:"2024-05-01T10:12:00+09:00"`
+	// ReceiptUrl, 영수증 다운로드 주소, 없으면 null
+	ReceiptUrl *string `json:"receiptUrl" example:"https://pg.example.com/receipt/20240501-0001"`
+} // @name BillingInvoiceResponse
+
+type BillingPaymentMethodResponse struct {
+	Summary    string    `json:"summary" validate:"required" example:"신한카드 ****1234"`
+	LastUsedAt time.Time `json:"lastUsedAt" validate:"required" example:"2024-05-01T10:12:00+09:00"`
+} // @name BillingPaymentMethodResponse
+
+type BillingUpgradeResponse struct {
+	Recommended bool    `json:"recommended" validate:"required" example:"true"`
+	Reason      *string `json:"reason" example:"ORDER_LIMIT_NEAR" enums:"NO_PLAN,ORDER_LIMIT_NEAR,ORDER_LIMIT_REACHED"`
+} // @name BillingUpgradeResponse
+
+type BillingResponse struct {
+	// CurrentPlan, 이용 중인 이용권이 없으면 null
+	CurrentPlan *BillingPlanResponse `json:"currentPlan"`
+	// NextBillingAt, 미리 결제한 기간까지 끝나는 시각, 없으면 null
+	NextBillingAt *time.Time               `json:"nextBillingAt" example:"2024-06-01T00:00:00+09:00"`
+	Invoices      []BillingInvoiceResponse `json:"invoices" validate:"required"`
+	// PaymentMethod, 결제 수단 정보가 있는 결제가 없으면 null
+	PaymentMethod *BillingPaymentMethodResponse `json:"paymentMethod"`
+	CreditBalance int64                         `json:"creditBalance" validate:"required" example:"3000"`
+	Upgrade       BillingUpgradeResponse        `json:"upgrade" validate:"required"`
+} // @name BillingResponse
+
+func responseOf(src domain.BillingInfo) (res BillingResponse) {
+	res = BillingResponse{
+		NextBillingAt: src.NextBillingAt,
+		Invoices:      make([]BillingInvoiceResponse, len(src.Invoices)),
+		CreditBalance: src.CreditBalance,
+		Upgrade: BillingUpgradeResponse{
+			Recommended: src.Upgrade.Recommended,
+		},
+	}
+
+	if plan := src.CurrentPlan; plan != nil {
+		res.CurrentPlan = &BillingPlanResponse{
+			TicketId:            plan.TicketId,
+			Name:                plan.Name,
+			TotalOrderCount:     plan.TotalOrderCount,
+			RemainingOrderCount: plan.RemainingOrderCount,
+			EditCount:           plan.EditCount,
+			StartAt:             plan.StartAt,
+			EndAt:               plan.EndAt,
+		}
+	}
+
+	for i := range src.Invoices {
+		invoice := src.Invoices[i]
+		res.Invoices[i] = BillingInvoiceResponse{
+			TicketId:      invoice.TicketId,
+			ExOrderId:     invoice.ExOrderId,
+			PlanName:      invoice.PlanName,
+			Amount:        invoice.Amount,
+			CreditApplied: invoice.CreditApplied,
+			OrderCount:    invoice.OrderCount,
+			StartAt:       invoice.StartAt,
+			EndAt:         invoice.EndAt,
+			PaidAt:        invoice.PaidAt,
+			ReceiptUrl:    invoice.ReceiptUrl,
+		}
+	}
+
+	if method := src.PaymentMethod; method != nil {
+		res.PaymentMethod = &BillingPaymentMethodResponse{
+			Summary:    method.Summary,
+			LastUsedAt: method.LastUsedAt,
+		}
+	}
+
+	if reason := src.Upgrade.Reason; reason != nil {
+		value := string(*reason)
+		res.Upgrade.Reason = &value
+	}
+	return
+}
+
+// @Tags (Billing) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 결제 정보
+// @Description 결제 화면에 필요한 값을 한 번에 가져오는 기능, 이용 중인 이용권, 다음 결제일, 최근 결제 12건(영수증 주소 포함), 마지막 결제 수단, 크레딧 잔액, 상위 상품 안내 여부, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} BillingResponse "성공"
+// @Router /billing/me [get]
+func (c *BillingController) getMyBilling(ctx echo.Context, userId uuid.UUID) error {
+	res, err := c.useCase.GetMyBilling(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "getMyBilling, unhandled error useCase.GetMyBilling")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, responseOf(res))
+}
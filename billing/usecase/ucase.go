@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+func NewBillingUseCase(
+	orderTicketRepo domain.OrderTicketRepository,
+	creditRepo domain.CreditRepository,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.BillingUseCase {
+	return &ucase{
+		orderTicketRepo: orderTicketRepo,
+		creditRepo:      creditRepo,
+		clock:           clock,
+		timeout:         timeout,
+	}
+}
+
+type ucase struct {
+	orderTicketRepo domain.OrderTicketRepository
+	creditRepo      domain.CreditRepository
+	clock           domain.Clock
+	timeout         time.Duration
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+const invoiceReferencePrefix = "invoice:"
+
+func (u *ucase) GetMyBilling(ctx context.Context, customerId uuid.UUID) (res domain.BillingInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		tickets []domain.OrderTicket
+		entries []domain.CreditEntry
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		tickets, err = u.orderTicketRepo.FetchByOwnerId(gc, customerId)
+		return
+	})
+	g.Go(func() (err error) {
+		entries, err = u.creditRepo.FetchEntries(gc, customerId)
+		return
+	})
+	g.Go(func() (err error) {
+		res.CreditBalance, err = u.creditRepo.GetBalance(gc, customerId)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		res = domain.BillingInfo{}
+		return
+	}
+
+	// 결제에 사용한 크레딧, 분개는 음수로 기록
+	creditApplied := make(map[string]int64)
+	for _, entry := range entries {
+		if entry.Reference == nil || !strings.HasPrefix(*entry.Reference, invoiceReferencePrefix) {
+			continue
+		}
+		exId := strings.TrimPrefix(*entry.Reference, invoiceReferencePrefix)
+		creditApplied[exId] -= entry.Amount
+	}
+
+	now := u.clock.Now()
+	// FetchByOwnerId 는 오래된 순, 최근 결제부터 보여줌
+	for i := len(tickets) - 1; i >= 0; i-- {
+		ticket := tickets[i]
+
+		if res.CurrentPlan == nil && isActiveTicket(ticket, now) {
+			res.CurrentPlan = &domain.BillingPlan{
+				TicketId:            ticket.Id,
+				Name:                ticket.PlanName,
+				TotalOrderCount:     ticket.TotalOrderCount,
+				RemainingOrderCount: ticket.RemainingOrderCount(),
+				EditCount:           ticket.EditCount,
+				StartAt:             ticket.StartAt,
+				EndAt:               ticket.EndAt,
+			}
+		}
+
+		if ticket.EndAt != nil && ticket.EndAt.After(now) &&
+			(res.NextBillingAt == nil || ticket.EndAt.After(*res.NextBillingAt)) {
+			res.NextBillingAt = ticket.EndAt
+		}
+
+		if res.PaymentMethod == nil && ticket.PaymentMethod != nil {
+			res.PaymentMethod = &domain.BillingPaymentMethod{
+				Summary:    *ticket.PaymentMethod,
+				LastUsedAt: ticket.CreatedAt,
+			}
+		}
+
+		if len(res.Invoices) < domain.BillingInvoiceLimit {
+			res.Invoices = append(res.Invoices, domain.BillingInvoice{
+				TicketId:      ticket.Id,
+				ExOrderId:     ticket.ExOrderId,
+				PlanName:      ticket.PlanName,
+				Amount:        ticket.Amount,
+				CreditApplied: creditApplied[ticket.ExOrderId],
+				OrderCount:    ticket.TotalOrderCount,
+				StartAt:       ticket.StartAt,
+				EndAt:         ticket.EndAt,
+				PaidAt:        ticket.CreatedAt,
+				ReceiptUrl:    ticket.ReceiptUrl,
+			})
+		}
+	}
+
+	res.Upgrade = upgradeOf(res.CurrentPlan)
+	return
+}
+
+// isActiveTicket GetByOwnerIdBetweenStartAndEnd 와 같은 기준, 양 끝 포함
+func isActiveTicket(ticket domain.OrderTicket, at time.Time) bool {
+	return ticket.StartAt != nil && ticket.EndAt != nil &&
+		!at.Before(*ticket.StartAt) && !at.After(*ticket.EndAt)
+}
+
+func upgradeOf(plan *domain.BillingPlan) (res domain.BillingUpgrade) {
+	var reason domain.BillingUpgradeReason
+	switch {
+	case plan == nil:
+		reason = domain.BillingUpgradeReasonNoPlan
+	case plan.RemainingOrderCount == 0:
+		reason = domain.BillingUpgradeReasonOrderLimitReached
+	case plan.TotalOrderCount > 0 &&
+		float64(plan.TotalOrderCount-plan.RemainingOrderCount) >= float64(plan.TotalOrderCount)*domain.BillingUpgradeUsageRate:
+		reason = domain.BillingUpgradeReasonOrderLimitNear
+	default:
+		return
+	}
+
+	res.Recommended = true
+	res.Reason = &reason
+	return
+}
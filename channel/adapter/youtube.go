@@ -0,0 +1,242 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	googleAuthURL   = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL  = "https://oauth2.googleapis.com/token"
+	youtubeAPIURL   = "https://www.googleapis.com/youtube/v3"
+	youtubeScope    = "https://www.googleapis.com/auth/youtube.readonly"
+	youtubeTimeout  = 10 * time.Second
+	errorBodyLimit  = 1024
+	invalidGrantErr = "invalid_grant"
+)
+
+var ErrNotConfigured = errors.New("youtube client not configured")
+
+// YouTubeOption Google Cloud 콘솔의 OAuth 클라이언트
+type YouTubeOption struct {
+	ClientId     string
+	ClientSecret string
+	// RedirectURL 동의 후 돌아올 이 서버 주소 (/user/customer/channel/callback), 콘솔에 등록한 값과 같아야 함
+	RedirectURL string
+	// Retry 토큰, 채널 조회의 일시적인 실패(네트워크, 429, 5xx) 재시도
+	Retry retry.Policy
+}
+
+// NewYouTubeClient 클라이언트 아이디가 없으면 호출할 때만 ErrNotConfigured
+func NewYouTubeClient(option YouTubeOption) domain.YouTubeClient {
+	if option.ClientId == "" {
+		return disabledYouTube{}
+	}
+	return &youtube{option: option, client: &http.Client{}}
+}
+
+type youtube struct {
+	option YouTubeOption
+	client *http.Client
+}
+
+// AuthCodeURL refresh token 을 받기 위해 매번 동의 화면(prompt=consent)을 띄움
+func (y *youtube) AuthCodeURL(state string) (string, error) {
+	query := url.Values{}
+	query.Set("client_id", y.option.ClientId)
+	query.Set("redirect_uri", y.option.RedirectURL)
+	query.Set("response_type", "code")
+	query.Set("scope", youtubeScope)
+	query.Set("access_type", "offline")
+	query.Set("prompt", "consent")
+	query.Set("include_granted_scopes", "true")
+	query.Set("state", state)
+	return googleAuthURL + "?" + query.Encode(), nil
+}
+
+func (y *youtube) Exchange(ctx context.Context, code string) (domain.YouTubeToken, error) {
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("redirect_uri", y.option.RedirectURL)
+	form.Set("grant_type", "authorization_code")
+	return y.token(ctx, form)
+}
+
+func (y *youtube) Refresh(ctx context.Context, refreshToken string) (domain.YouTubeToken, error) {
+	form := url.Values{}
+	form.Set("refresh_token", refreshToken)
+	form.Set("grant_type", "refresh_token")
+	token, err := y.token(ctx, form)
+	if err == nil && token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, err
+}
+
+type googleTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+// token 취소되었거나 만료된 refresh token, 이미 쓴 code 는 invalid_grant
+func (y *youtube) token(ctx context.Context, form url.Values) (token domain.YouTubeToken, err error) {
+	err = retry.Do(ctx, y.option.Retry, func(ctx context.Context) (err error) {
+		token, err = y.requestToken(ctx, form)
+		return
+	})
+	return
+}
+
+func (y *youtube) requestToken(ctx context.Context, form url.Values) (token domain.YouTubeToken, err error) {
+	c, cancel := budget.Slice(ctx, youtubeTimeout)
+	defer cancel()
+
+	form.Set("client_id", y.option.ClientId)
+	form.Set("client_secret", y.option.ClientSecret)
+	req, err := http.NewRequestWithContext(c, http.MethodPost, googleTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := y.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	var body googleTokenResponse
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(raw, &body)
+
+	switch {
+	case body.Error == invalidGrantErr:
+		err = domain.ErrChannelRevoked
+		return
+	case res.StatusCode != http.StatusOK || body.AccessToken == "":
+		err = &retry.StatusError{Service: "google token", Code: res.StatusCode, Body: string(truncate(raw))}
+		return
+	}
+
+	token = domain.YouTubeToken{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+	}
+	return
+}
+
+type youtubeChannelsResponse struct {
+	Items []struct {
+		Id      string `json:"id"`
+		Snippet struct {
+			Title string `json:"title"`
+		} `json:"snippet"`
+		// Statistics 숫자를 문자열로 줌
+		Statistics struct {
+			ViewCount       string `json:"viewCount"`
+			SubscriberCount string `json:"subscriberCount"`
+			VideoCount      string `json:"videoCount"`
+		} `json:"statistics"`
+	} `json:"items"`
+}
+
+func (y *youtube) MyChannel(ctx context.Context, accessToken string) (channel domain.YouTubeChannel, err error) {
+	err = retry.Do(ctx, y.option.Retry, func(ctx context.Context) (err error) {
+		channel, err = y.myChannel(ctx, accessToken)
+		return
+	})
+	return
+}
+
+func (y *youtube) myChannel(ctx context.Context, accessToken string) (channel domain.YouTubeChannel, err error) {
+	c, cancel := budget.Slice(ctx, youtubeTimeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("part", "snippet,statistics")
+	query.Set("mine", "true")
+	req, err := http.NewRequestWithContext(c, http.MethodGet, youtubeAPIURL+"/channels?"+query.Encode(), nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	res, err := y.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusUnauthorized:
+		err = domain.ErrChannelRevoked
+		return
+	case res.StatusCode != http.StatusOK:
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, errorBodyLimit))
+		err = &retry.StatusError{Service: "youtube channels", Code: res.StatusCode, Body: string(raw)}
+		return
+	}
+
+	var body youtubeChannelsResponse
+	err = json.NewDecoder(res.Body).Decode(&body)
+	if err != nil {
+		return
+	}
+	if len(body.Items) == 0 {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	item := body.Items[0]
+	channel = domain.YouTubeChannel{
+		Id:    item.Id,
+		Title: item.Snippet.Title,
+	}
+	// 구독자 수를 숨긴 채널은 subscriberCount 가 없음, 0 으로 둠
+	channel.Subscribers, _ = strconv.ParseInt(item.Statistics.SubscriberCount, 10, 64)
+	channel.Views, _ = strconv.ParseInt(item.Statistics.ViewCount, 10, 64)
+	channel.Videos, _ = strconv.ParseInt(item.Statistics.VideoCount, 10, 64)
+	return
+}
+
+func truncate(raw []byte) []byte {
+	if len(raw) > errorBodyLimit {
+		return raw[:errorBodyLimit]
+	}
+	return raw
+}
+
+type disabledYouTube struct{}
+
+func (disabledYouTube) AuthCodeURL(string) (string, error) {
+	return "", ErrNotConfigured
+}
+
+func (disabledYouTube) Exchange(context.Context, string) (domain.YouTubeToken, error) {
+	return domain.YouTubeToken{}, ErrNotConfigured
+}
+
+func (disabledYouTube) Refresh(context.Context, string) (domain.YouTubeToken, error) {
+	return domain.YouTubeToken{}, ErrNotConfigured
+}
+
+func (disabledYouTube) MyChannel(context.Context, string) (domain.YouTubeChannel, error) {
+	return domain.YouTubeChannel{}, ErrNotConfigured
+}
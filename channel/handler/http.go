@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[CHANNEL] "
+)
+
+// NewChannelController returnURL 채널 연결을 마친 뒤 돌려보낼 프론트 주소, 결과는 channel 쿼리로 전달
+func NewChannelController(useCase domain.ChannelUseCase, returnURL string) *ChannelController {
+	return &ChannelController{useCase: useCase, returnURL: returnURL}
+}
+
+type ChannelController struct {
+	useCase   domain.ChannelUseCase
+	returnURL string
+}
+
+func (c *ChannelController) Bind(e *echo.Echo) {
+	// ===== CUSTOMER =====
+	e.POST("/user/customer/channel/connect", echox.UserID(c.requestChannelConnect),
+		middleware.RequireRole(domain.CustomerUserRole))
+	e.DELETE("/user/customer/channel", echox.UserID(c.disconnectChannel),
+		middleware.RequireRole(domain.CustomerUserRole))
+	// Google 동의 화면에서 브라우저가 돌아오는 주소, 토큰 없이 state 로 고객 확인
+	e.GET("/user/customer/channel/callback", c.channelCallback)
+
+	// ===== CUSTOMER, ADMIN =====
+	e.GET("/user/customer/:userId/channel-stats", echox.UserID(c.getChannelStats), middleware.RequireAuth())
+
+	// INTERNAL
+	e.POST("/internal/channel/stats/sync", c.internalSyncChannelStats)
+}
+
+type ChannelConnectResponse struct {
+	// AuthUrl, 이동할 Google 동의 화면 주소, 10분 안에 동의해야 함
+	AuthUrl string `json:"authUrl" validate:"required" example:"https://accounts.google.com/o/oauth2/v2/auth?client_id=..."`
+} // @name ChannelConnectResponse
+
+// @Tags (Channel) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] YouTube 채널 연결 시작
+// @Description Google 동의 화면 주소 반환, 동의하면 /user/customer/channel/callback 을 거쳐 프론트로 돌아감, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} ChannelConnectResponse "성공"
+// @Router /user/customer/channel/connect [post]
+func (c *ChannelController) requestChannelConnect(ctx echo.Context, userId uuid.UUID) error {
+	authURL, err := c.useCase.RequestChannelConnect(ctx.Request().Context(), userId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, ChannelConnectResponse{AuthUrl: authURL})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "requestChannelConnect, unhandled error useCase.RequestChannelConnect")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ChannelCallbackRequest struct {
+	State string `query:"state"`
+	Code  string `query:"code"`
+	// Error, 고객이 동의하지 않으면 access_denied
+	Error string `query:"error"`
+}
+
+// channelCallback 결과는 returnURL?channel=connected|expired|denied|failed 로 전달
+func (c *ChannelController) channelCallback(ctx echo.Context) error {
+	var req ChannelCallbackRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "channel callback, request query bind error")
+		return c.redirectResult(ctx, "failed")
+	}
+
+	if req.Error != "" || req.Code == "" || req.State == "" {
+		return c.redirectResult(ctx, "denied")
+	}
+
+	err = c.useCase.ConnectChannel(ctx.Request().Context(), domain.ConnectChannel{
+		State: req.State,
+		Code:  req.Code,
+	})
+
+	switch err {
+	case nil:
+		return c.redirectResult(ctx, "connected")
+	case domain.ErrItemNotFound, domain.ErrTokenExpired:
+		return c.redirectResult(ctx, "expired")
+	case domain.ErrChannelRevoked, domain.ErrWeirdData:
+		return c.redirectResult(ctx, "denied")
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "channelCallback, unhandled error useCase.ConnectChannel")
+		return c.redirectResult(ctx, "failed")
+	}
+}
+
+func (c *ChannelController) redirectResult(ctx echo.Context, result string) error {
+	if c.returnURL == "" {
+		return ctx.JSON(http.StatusOK, echo.Map{"channel": result})
+	}
+
+	u, err := url.Parse(c.returnURL)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "redirectResult, invalid return url")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+	query := u.Query()
+	query.Set("channel", result)
+	u.RawQuery = query.Encode()
+	return ctx.Redirect(http.StatusFound, u.String())
+}
+
+// @Tags (Channel) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] YouTube 채널 연결 해제
+// @Description 저장된 권한만 지우고 통계 기록은 남김, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 204 "연결 해제 완료"
+// @Failure 404 {object} domain.ErrorResponse "연결된 채널 없음"
+// @Router /user/customer/channel [delete]
+func (c *ChannelController) disconnectChannel(ctx echo.Context, userId uuid.UUID) error {
+	err := c.useCase.DisconnectChannel(ctx.Request().Context(), userId)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "disconnectChannel, unhandled error useCase.DisconnectChannel")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ChannelStatsPointResponse struct {
+	// Date, 통계 날짜 (Date, KST 기준 날짜를 UTC 0시로 표현)
+	Date        time.Time `json:"date" validate:"required" example:"2021-10-27T00:00:00+00:00"`
+	Subscribers int64     `json:"subscribers" validate:"required" example:"12000"`
+	Views       int64     `json:"views" validate:"required" example:"3400000"`
+	Videos      int64     `json:"videos" validate:"required" example:"210"`
+} // @name ChannelStatsPointResponse
+
+type ChannelStatsResponse struct {
+	// Connected, false 면 권한이 취소되어 더 이상 갱신되지 않음, 다시 연결해야 함
+	Connected    bool       `json:"connected" validate:"required" example:"true"`
+	ChannelId    *string    `json:"channelId" example:"UC_x5XG1OV2P6uZZ5FSM9Ttw"`
+	ChannelTitle *string    `json:"channelTitle" example:"에디트폴리오"`
+	LastSyncedAt *time.Time `json:"lastSyncedAt" example:"2021-10-27T04:44:18+00:00"`
+	// LastError, 마지막 조회 실패 사유, 성공하면 null
+	LastError *string                     `json:"lastError" example:"channel access revoked"`
+	Latest    *ChannelStatsPointResponse  `json:"latest"`
+	History   []ChannelStatsPointResponse `json:"history" validate:"required"`
+} // @name ChannelStatsResponse
+
+// @Tags (Channel) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 고객 YouTube 채널 통계
+// @Description 최근 통계와 30일 일별 기록, 고객 본인 또는 관리자만
+// @Accept json
+// @Produce json
+// @Param userId path string true "고객 아이디(UUID)"
+// @Success 200 {object} ChannelStatsResponse "성공"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "연결한 채널 없음"
+// @Router /user/customer/{userId}/channel-stats [get]
+func (c *ChannelController) getChannelStats(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		CustomerId uuid.UUID `param:"userId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get channel stats, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	info, err := c.useCase.GetChannelStats(ctx.Request().Context(), domain.ChannelStatsAccess{
+		CustomerId:  req.CustomerId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "getChannelStats, unhandled error useCase.GetChannelStats")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := ChannelStatsResponse{
+		Connected:    info.Connected,
+		ChannelId:    info.ChannelId,
+		ChannelTitle: info.ChannelTitle,
+		LastSyncedAt: info.LastSyncedAt,
+		LastError:    info.LastError,
+		History:      make([]ChannelStatsPointResponse, len(info.History)),
+	}
+	for i, point := range info.History {
+		res.History[i] = pointResponseOf(point)
+	}
+	if info.Latest != nil {
+		latest := pointResponseOf(*info.Latest)
+		res.Latest = &latest
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+func pointResponseOf(point domain.ChannelStatsPoint) ChannelStatsPointResponse {
+	return ChannelStatsPointResponse{
+		Date:        point.Date,
+		Subscribers: point.Subscribers,
+		Views:       point.Views,
+		Videos:      point.Videos,
+	}
+}
+
+func (c *ChannelController) internalSyncChannelStats(ctx echo.Context) error {
+	res, err := c.useCase.SyncChannelStats(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalSyncChannelStats, unhandled error useCase.SyncChannelStats")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("synced", res.Synced).
+		WithField("failed", res.Failed).
+		Info(tag, "sync channel stats")
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"synced": res.Synced,
+		"failed": res.Failed,
+	})
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewChannelRepository(db *gorm.DB) domain.ChannelRepository {
+	db.AutoMigrate(&domain.ChannelConnection{}, &domain.ChannelStats{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, connection *domain.ChannelConnection) error {
+	return gormx.Upsert(ctx, r.db, connection)
+}
+
+func (r *repo) SaveStats(ctx context.Context, stats *domain.ChannelStats) error {
+	return gormx.Upsert(ctx, r.db, stats)
+}
+
+func (r *repo) GetByCustomerId(ctx context.Context, customerId uuid.UUID) (connection *domain.ChannelConnection, err error) {
+	var entity domain.ChannelConnection
+	err = r.db.WithContext(ctx).First(&entity, customerId).Error
+	if err == nil {
+		connection = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetByPendingState(ctx context.Context, hashedState string) (connection *domain.ChannelConnection, err error) {
+	var entity domain.ChannelConnection
+	err = r.db.WithContext(ctx).Where("pending_state = ?", hashedState).First(&entity).Error
+	if err == nil {
+		connection = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchSyncTargets(ctx context.Context, before time.Time, limit int) (list []domain.ChannelConnection, err error) {
+	err = r.db.WithContext(ctx).
+		Where("sealed IS NOT NULL AND (last_synced_at IS NULL OR last_synced_at < ?)", before).
+		Order("last_synced_at").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchStats(ctx context.Context, customerId uuid.UUID, since time.Time) (list []domain.ChannelStats, err error) {
+	err = r.db.WithContext(ctx).
+		Where("customer_id = ? AND date >= ?", customerId, since).
+		Order("date").
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[CHANNEL] "
+
+func NewChannelUseCase(
+	channelRepo domain.ChannelRepository,
+	userRepo domain.UserRepository,
+	youtube domain.YouTubeClient,
+	cipher domain.CredentialCipher,
+	calendar domain.Calendar,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.ChannelUseCase {
+	return &ucase{
+		channelRepo: channelRepo,
+		userRepo:    userRepo,
+		youtube:     youtube,
+		cipher:      cipher,
+		calendar:    calendar,
+		clock:       clock,
+		timeout:     timeout,
+	}
+}
+
+type ucase struct {
+	channelRepo domain.ChannelRepository
+	userRepo    domain.UserRepository
+	youtube     domain.YouTubeClient
+	cipher      domain.CredentialCipher
+	calendar    domain.Calendar
+	clock       domain.Clock
+	timeout     time.Duration
+}
+
+func (u *ucase) RequestChannelConnect(ctx context.Context, customerId uuid.UUID) (authURL string, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetById(c, customerId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrNoPermission
+		return
+	}
+
+	connection, err := u.channelRepo.GetByCustomerId(c, customerId)
+	if err != nil {
+		return
+	}
+
+	if connection == nil {
+		connection = &domain.ChannelConnection{CustomerId: customerId}
+	}
+
+	state, err := connection.RequestConnect(u.clock.Now())
+	if err != nil {
+		return
+	}
+
+	authURL, err = u.youtube.AuthCodeURL(state)
+	if err != nil {
+		return
+	}
+
+	err = u.channelRepo.Save(c, connection)
+	return
+}
+
+func (u *ucase) ConnectChannel(ctx context.Context, in domain.ConnectChannel) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	connection, err := u.channelRepo.GetByPendingState(c, domain.HashChannelState(in.State))
+	if err != nil {
+		return
+	}
+
+	if connection == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	// code 는 한 번만 쓸 수 있으므로 만료된 요청은 교환 전에 거절
+	if connection.IsPendingExpired(u.clock.Now()) {
+		err = domain.ErrTokenExpired
+		return
+	}
+
+	token, err := u.youtube.Exchange(c, in.Code)
+	if err != nil {
+		return
+	}
+
+	if token.RefreshToken == "" {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	channel, err := u.youtube.MyChannel(c, token.AccessToken)
+	if err != nil {
+		return
+	}
+
+	sealed, err := u.cipher.Seal([]byte(token.RefreshToken), connection.AAD())
+	if err != nil {
+		return
+	}
+
+	now := u.clock.Now()
+	err = connection.ConfirmConnect(channel, sealed, now)
+	if err != nil {
+		return
+	}
+
+	connection.Synced(channel, now)
+	err = u.channelRepo.Save(c, connection)
+	if err != nil {
+		return
+	}
+
+	return u.saveStats(c, connection.CustomerId, channel, now)
+}
+
+func (u *ucase) DisconnectChannel(ctx context.Context, customerId uuid.UUID) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	connection, err := u.channelRepo.GetByCustomerId(c, customerId)
+	if err != nil {
+		return
+	}
+
+	if connection == nil || !connection.IsConnected() {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	connection.Disconnect()
+	return u.channelRepo.Save(c, connection)
+}
+
+func (u *ucase) SyncChannelStats(ctx context.Context) (res domain.ChannelStatsSync, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.channelRepo.FetchSyncTargets(c, u.clock.Now().Add(-domain.ChannelStatsSyncInterval), domain.ChannelStatsSyncBatch)
+	if err != nil {
+		return
+	}
+
+	var (
+		ran     = make([]bool, len(list))
+		results = make([]error, len(list))
+	)
+	pool, _ := workerpool.New(c, workerpool.Option{Size: domain.ChannelStatsSyncConcurrency})
+	for i := range list {
+		i := i
+		pool.Go(func(ctx context.Context) error {
+			ran[i] = true
+			results[i] = u.sync(ctx, &list[i])
+			return nil
+		})
+	}
+	_ = pool.Wait()
+
+	// 하나가 실패해도 나머지는 계속, 실패한 채널은 다음 주기에 다시 조회
+	// 시간이 다 되어 조회하지 못한 채널은 다음 실행에서 조회
+	for i := range list {
+		connection := &list[i]
+		if !ran[i] {
+			continue
+		}
+		if results[i] == nil {
+			res.Synced++
+			continue
+		}
+
+		logx.From(ctx).WithError(results[i]).WithField("customerId", connection.CustomerId).Warn(tag, "sync channel stats failed")
+		connection.SyncFailed(results[i], u.clock.Now())
+		res.Failed++
+
+		err = u.channelRepo.Save(c, connection)
+		if err != nil {
+			return
+		}
+	}
+
+	diagnostics.AddCounter("channel.stats.synced", uint64(res.Synced))
+	diagnostics.AddCounter("channel.stats.failed", uint64(res.Failed))
+	return
+}
+
+// sync 저장한 refresh token 으로 access token 을 받아 통계 조회, 성공하면 연결 정보까지 저장
+func (u *ucase) sync(ctx context.Context, connection *domain.ChannelConnection) error {
+	plain, err := u.cipher.Open(connection.Sealed, connection.AAD())
+	if err != nil {
+		return err
+	}
+
+	token, err := u.youtube.Refresh(ctx, string(plain))
+	if err != nil {
+		return err
+	}
+
+	channel, err := u.youtube.MyChannel(ctx, token.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	if token.RefreshToken != string(plain) {
+		connection.Sealed, err = u.cipher.Seal([]byte(token.RefreshToken), connection.AAD())
+		if err != nil {
+			return err
+		}
+	}
+
+	now := u.clock.Now()
+	err = u.saveStats(ctx, connection.CustomerId, channel, now)
+	if err != nil {
+		return err
+	}
+
+	connection.Synced(channel, now)
+	return u.channelRepo.Save(ctx, connection)
+}
+
+func (u *ucase) saveStats(ctx context.Context, customerId uuid.UUID, channel domain.YouTubeChannel, now time.Time) error {
+	return u.channelRepo.SaveStats(ctx, &domain.ChannelStats{
+		CustomerId:  customerId,
+		Date:        u.calendar.DateOf(now),
+		ChannelId:   channel.Id,
+		Subscribers: channel.Subscribers,
+		Views:       channel.Views,
+		Videos:      channel.Videos,
+		SyncedAt:    now,
+	})
+}
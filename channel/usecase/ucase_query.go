@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func (u *ucase) GetChannelStats(ctx context.Context, in domain.ChannelStatsAccess) (res domain.ChannelStatsInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		connection *domain.ChannelConnection
+		list       []domain.ChannelStats
+	)
+	since := u.calendar.DateOf(u.clock.Now()).AddDate(0, 0, -domain.ChannelStatsHistoryDays)
+
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		if in.RequesterId == in.CustomerId {
+			return
+		}
+
+		requester, err := u.userRepo.GetById(gc, in.RequesterId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(requester,
+			domain.User.IsAdmin,
+			domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		connection, err = u.channelRepo.GetByCustomerId(gc, in.CustomerId)
+		return
+	})
+	g.Go(func() (err error) {
+		list, err = u.channelRepo.FetchStats(gc, in.CustomerId, since)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	if connection == nil || connection.ChannelId == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = domain.ChannelStatsInfo{
+		Connected:    connection.IsConnected(),
+		ChannelId:    connection.ChannelId,
+		ChannelTitle: connection.ChannelTitle,
+		LastSyncedAt: connection.LastSyncedAt,
+		LastError:    connection.LastError,
+		History:      make([]domain.ChannelStatsPoint, 0, len(list)),
+	}
+
+	// 다른 채널로 다시 연결한 경우 이전 채널 기록은 제외
+	for _, stats := range list {
+		if stats.ChannelId != *connection.ChannelId {
+			continue
+		}
+		res.History = append(res.History, domain.ChannelStatsPoint{
+			Date:        stats.Date,
+			Subscribers: stats.Subscribers,
+			Views:       stats.Views,
+			Videos:      stats.Videos,
+		})
+	}
+
+	if n := len(res.History); n > 0 {
+		latest := res.History[n-1]
+		res.Latest = &latest
+	}
+	return
+}
@@ -0,0 +1,72 @@
+// replay 섀도우 기록(/shadow/record)을 로컬/스테이징 서버에 다시 실행해 응답을 비교
+//
+//	go run ./cmd/replay -from https://api.editfolio.com -from-token "Bearer ..." \
+//	    -route /order/:orderId -target http://localhost:8000 -fixture fixture.json
+//
+// 기록은 개인정보가 가려져 있으므로 -fixture 로 가려진 값과 운영 아이디를 스테이징 데이터로 바꿔서 보냄
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	var (
+		opt       sourceOption
+		target    string
+		fixture   string
+		recordId  string
+		dryRun    bool
+		timeoutMs int
+	)
+	flag.StringVar(&opt.From, "from", "", "base url of the server that holds the records (GET /shadow/record)")
+	flag.StringVar(&opt.FromToken, "from-token", "", "Authorization header for -from, super admin")
+	flag.StringVar(&opt.File, "file", "", "read records from a JSON file (array of /shadow/record responses) instead of -from")
+	flag.StringVar(&opt.Method, "method", "", "only records of this method")
+	flag.StringVar(&opt.Route, "route", "", "only records of this route pattern (ex. /order/:orderId)")
+	flag.IntVar(&opt.Limit, "limit", 20, "max records to replay")
+	flag.StringVar(&recordId, "record", "", "replay a single record id")
+	flag.StringVar(&target, "target", "http://localhost:8000", "base url of the server to replay against")
+	flag.StringVar(&fixture, "fixture", "", "fixture JSON: {token, ids, values}")
+	flag.BoolVar(&dryRun, "dry-run", false, "print the requests without sending them")
+	flag.IntVar(&timeoutMs, "timeout-ms", 10000, "timeout per request")
+	flag.Parse()
+
+	client := &http.Client{Timeout: time.Duration(timeoutMs) * time.Millisecond}
+
+	fx, err := loadFixture(fixture)
+	if err != nil {
+		exit(err)
+	}
+
+	records, err := loadRecords(client, opt, recordId)
+	if err != nil {
+		exit(err)
+	}
+	if len(records) == 0 {
+		fmt.Println("no records")
+		return
+	}
+
+	r := replayer{client: client, target: target, fixture: fx, dryRun: dryRun}
+	var diffs int
+	for _, record := range records {
+		if !r.replay(record) {
+			diffs++
+		}
+	}
+
+	fmt.Printf("\n%d replayed, %d differ\n", len(records), diffs)
+	if diffs > 0 {
+		os.Exit(1)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, "replay:", err)
+	os.Exit(2)
+}
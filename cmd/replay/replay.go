@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redactedMask util/redact.Mask 와 같은 값
+const redactedMask = "[REDACTED]"
+
+// fixture 가려진 값, 운영 아이디를 스테이징 데이터로 바꾸는 규칙
+type fixture struct {
+	// Token 재실행 요청의 Authorization 헤더 (스테이징 계정)
+	Token string `json:"token"`
+	// Ids 기록의 아이디 -> 스테이징 아이디, 경로/쿼리/본문 전체에서 치환
+	Ids map[string]string `json:"ids"`
+	// Values 가려진 JSON 키 -> 넣을 값 (ex. "email": "qa@editfolio.test")
+	Values map[string]interface{} `json:"values"`
+}
+
+func loadFixture(name string) (fx fixture, err error) {
+	if name == "" {
+		return
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	err = json.NewDecoder(file).Decode(&fx)
+	return
+}
+
+func (fx fixture) replaceIds(s string) string {
+	for from, to := range fx.Ids {
+		s = strings.ReplaceAll(s, from, to)
+	}
+	return s
+}
+
+// fillBody 가려진 값을 fixture 값으로 채움, 채울 값이 없으면 빠진 키 목록 반환
+func (fx fixture) fillBody(body string) (string, []string) {
+	body = fx.replaceIds(body)
+	if body == "" || !strings.Contains(body, redactedMask) {
+		return body, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body, nil
+	}
+
+	var missing []string
+	v = fx.fill(v, &missing)
+	res, err := json.Marshal(v)
+	if err != nil {
+		return body, missing
+	}
+	return string(res), missing
+}
+
+func (fx fixture) fill(v interface{}, missing *[]string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			if child == redactedMask {
+				if value, ok := fx.Values[key]; ok {
+					val[key] = value
+				} else {
+					*missing = append(*missing, key)
+				}
+				continue
+			}
+			val[key] = fx.fill(child, missing)
+		}
+	case []interface{}:
+		for i := range val {
+			val[i] = fx.fill(val[i], missing)
+		}
+	}
+	return v
+}
+
+// fillQuery 쿼리의 가려진 값을 fixture 값으로 채움
+func (fx fixture) fillQuery(query string) (string, []string) {
+	query = fx.replaceIds(query)
+	values, err := url.ParseQuery(query)
+	if err != nil || !strings.Contains(query, url.QueryEscape(redactedMask)) {
+		return query, nil
+	}
+
+	var missing []string
+	for key, list := range values {
+		for i, v := range list {
+			if v != redactedMask {
+				continue
+			}
+			if value, ok := fx.Values[key]; ok {
+				list[i] = fmt.Sprint(value)
+			} else {
+				missing = append(missing, key)
+			}
+		}
+	}
+	return values.Encode(), missing
+}
+
+type replayer struct {
+	client  *http.Client
+	target  string
+	fixture fixture
+	dryRun  bool
+}
+
+// replay 기록 하나를 다시 실행, 상태 코드와 응답 JSON 키가 같으면 true
+func (r replayer) replay(rec record) bool {
+	path := r.fixture.replaceIds(rec.Path)
+	query, missing := r.fixture.fillQuery(rec.Query)
+	if query != "" {
+		path += "?" + query
+	}
+	body, missingBody := r.fixture.fillBody(rec.RequestBody)
+	missing = append(missing, missingBody...)
+
+	fmt.Printf("\n[%s] %s %s (%s, recorded %s)\n", rec.Id, rec.Method, path, rec.Route, rec.RecordedAt.Format(time.RFC3339))
+	if len(missing) > 0 {
+		fmt.Printf("  warn: no fixture value for redacted keys %v\n", missing)
+	}
+	if r.dryRun {
+		if body != "" {
+			fmt.Printf("  body: %s\n", body)
+		}
+		return true
+	}
+
+	req, err := http.NewRequest(rec.Method, strings.TrimRight(r.target, "/")+path, strings.NewReader(body))
+	if err != nil {
+		fmt.Printf("  error: %v\n", err)
+		return false
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.fixture.Token != "" {
+		req.Header.Set("Authorization", r.fixture.Token)
+	}
+
+	start := time.Now()
+	res, err := r.client.Do(req)
+	if err != nil {
+		fmt.Printf("  error: %v\n", err)
+		return false
+	}
+	defer res.Body.Close()
+	resBody, _ := io.ReadAll(res.Body)
+	elapsed := time.Since(start)
+
+	same := res.StatusCode == rec.Status
+	fmt.Printf("  status: recorded %d, replayed %d (%dms -> %dms)\n",
+		rec.Status, res.StatusCode, rec.DurationMs, elapsed.Milliseconds())
+
+	added, removed := diffKeys(rec.ResponseBody, string(resBody))
+	if len(added) > 0 || len(removed) > 0 {
+		same = false
+		fmt.Printf("  keys: +%v -%v\n", added, removed)
+	}
+	if !same {
+		fmt.Printf("  recorded: %s\n  replayed: %s\n", clip(rec.ResponseBody), clip(string(resBody)))
+	}
+	return same
+}
+
+// diffKeys 응답 JSON 의 키 경로 비교, 값은 데이터가 달라서 비교하지 않음
+func diffKeys(recorded, replayed string) (added, removed []string) {
+	a, b := keyPaths(recorded), keyPaths(replayed)
+	for key := range b {
+		if !a[key] {
+			added = append(added, key)
+		}
+	}
+	for key := range a {
+		if !b[key] {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return
+}
+
+func keyPaths(body string) map[string]bool {
+	res := make(map[string]bool)
+	var v interface{}
+	if err := json.NewDecoder(bytes.NewReader([]byte(body))).Decode(&v); err != nil {
+		return res
+	}
+	collectKeys(v, "", res)
+	return res
+}
+
+func collectKeys(v interface{}, prefix string, res map[string]bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			res[prefix+key] = true
+			collectKeys(child, prefix+key+".", res)
+		}
+	case []interface{}:
+		// 배열은 첫 요소 형태만 비교
+		if len(val) > 0 {
+			collectKeys(val[0], prefix+"[].", res)
+		}
+	}
+}
+
+func clip(s string) string {
+	const max = 300
+	if len(s) > max {
+		return s[:max] + "..."
+	}
+	return s
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// record /shadow/record 응답 형식
+type record struct {
+	Id           string    `json:"id"`
+	Method       string    `json:"method"`
+	Route        string    `json:"route"`
+	Path         string    `json:"path"`
+	Query        string    `json:"query"`
+	UserId       *string   `json:"userId"`
+	RequestBody  string    `json:"requestBody"`
+	Status       int       `json:"status"`
+	ResponseBody string    `json:"responseBody"`
+	DurationMs   int64     `json:"durationMs"`
+	RecordedAt   time.Time `json:"recordedAt"`
+}
+
+type sourceOption struct {
+	From      string
+	FromToken string
+	File      string
+	Method    string
+	Route     string
+	Limit     int
+}
+
+func loadRecords(client *http.Client, opt sourceOption, recordId string) (list []record, err error) {
+	if opt.File != "" {
+		return readRecordFile(opt.File, recordId)
+	}
+	if opt.From == "" {
+		return nil, fmt.Errorf("-from or -file is required")
+	}
+
+	base := strings.TrimRight(opt.From, "/")
+	if recordId != "" {
+		var single record
+		err = getJSON(client, base+"/shadow/record/"+url.PathEscape(recordId), opt.FromToken, &single)
+		if err != nil {
+			return
+		}
+		return []record{single}, nil
+	}
+
+	query := url.Values{}
+	if opt.Method != "" {
+		query.Set("method", opt.Method)
+	}
+	if opt.Route != "" {
+		query.Set("route", opt.Route)
+	}
+	query.Set("limit", strconv.Itoa(opt.Limit))
+
+	err = getJSON(client, base+"/shadow/record?"+query.Encode(), opt.FromToken, &list)
+	// 오래된 기록부터 실행
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return
+}
+
+func readRecordFile(name, recordId string) (list []record, err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	err = json.NewDecoder(file).Decode(&list)
+	if err != nil || recordId == "" {
+		return
+	}
+
+	for _, r := range list {
+		if r.Id == recordId {
+			return []record{r}, nil
+		}
+	}
+	return nil, fmt.Errorf("record %s not in %s", recordId, name)
+}
+
+func getJSON(client *http.Client, rawUrl, token string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, rawUrl, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(res.Body).Decode(v)
+	case http.StatusNoContent:
+		return nil
+	default:
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("GET %s: %d %s", rawUrl, res.StatusCode, body)
+	}
+}
@@ -0,0 +1,217 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	modusignAPIURL  = "https://api.modusign.co.kr"
+	modusignTimeout = 15 * time.Second
+	errorBodyLimit  = 1024
+)
+
+var ErrNotConfigured = errors.New("e-sign provider not configured")
+
+// ModusignOption 모두싸인 API 키와 계약서 템플릿
+type ModusignOption struct {
+	// Email API 키를 발급한 계정 이메일
+	Email  string
+	ApiKey string
+	// TemplateId 계약서 템플릿, 서명자 역할은 SignerRole 하나
+	TemplateId string
+	SignerRole string
+	// Retry 일시적인 실패(네트워크, 429, 5xx) 재시도
+	Retry retry.Policy
+}
+
+// NewModusign API 키가 없으면 호출할 때만 ErrNotConfigured
+func NewModusign(option ModusignOption) domain.ESignAdapter {
+	if option.ApiKey == "" {
+		return disabledESign{}
+	}
+	return &modusign{option: option, client: &http.Client{}}
+}
+
+type modusign struct {
+	option ModusignOption
+	client *http.Client
+}
+
+func (m *modusign) Provider() domain.ESignProvider {
+	return domain.ESignProviderModusign
+}
+
+type modusignDocument struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+	File   struct {
+		DownloadUrl string `json:"downloadUrl"`
+	} `json:"file"`
+}
+
+func (m *modusign) Send(ctx context.Context, req domain.ESignRequest) (documentId string, err error) {
+	type signingMethod struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	type participantMapping struct {
+		Role          string        `json:"role"`
+		Name          string        `json:"name"`
+		SigningMethod signingMethod `json:"signingMethod"`
+	}
+	var body struct {
+		TemplateId string `json:"templateId"`
+		Document   struct {
+			Title               string               `json:"title"`
+			ParticipantMappings []participantMapping `json:"participantMappings"`
+		} `json:"document"`
+	}
+	body.TemplateId = m.option.TemplateId
+	body.Document.Title = req.Title
+	body.Document.ParticipantMappings = []participantMapping{{
+		Role:          m.option.SignerRole,
+		Name:          req.SignerName,
+		SigningMethod: signingMethod{Type: "EMAIL", Value: req.SignerEmail},
+	}}
+
+	var document modusignDocument
+	err = m.do(ctx, http.MethodPost, "/documents/request-with-template", body, &document)
+	documentId = document.Id
+	return
+}
+
+func (m *modusign) Status(ctx context.Context, documentId string) (status domain.ESignStatus, err error) {
+	document, err := m.document(ctx, documentId)
+	if err != nil {
+		return
+	}
+
+	switch document.Status {
+	case "COMPLETED":
+		status = domain.ESignStatusSigned
+	case "DECLINED", "ABORTED", "EXPIRED":
+		status = domain.ESignStatusDeclined
+	default:
+		status = domain.ESignStatusPending
+	}
+	return
+}
+
+func (m *modusign) DownloadSigned(ctx context.Context, documentId string) (io.ReadCloser, error) {
+	document, err := m.document(ctx, documentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Status != "COMPLETED" || document.File.DownloadUrl == "" {
+		return nil, domain.ErrItemNotFound
+	}
+
+	// 받은 주소는 서명된 주소라 인증 헤더 없이 요청
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, document.File.DownloadUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, errorBodyLimit))
+		return nil, &retry.StatusError{Service: "modusign file", Code: res.StatusCode, Body: string(raw)}
+	}
+	return res.Body, nil
+}
+
+func (m *modusign) document(ctx context.Context, documentId string) (document modusignDocument, err error) {
+	err = m.do(ctx, http.MethodGet, "/documents/"+url.PathEscape(documentId), nil, &document)
+	return
+}
+
+// do 없는 문서(404)는 ErrItemNotFound, 조회의 일시적인 실패는 option.Retry 로 다시 보냄
+// 서명 요청은 문서가 두 번 만들어질 수 있어 다시 보내지 않음
+func (m *modusign) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var raw []byte
+	if body != nil {
+		var err error
+		raw, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return retry.Do(ctx, m.option.Retry, func(ctx context.Context) error {
+		err := m.request(ctx, method, path, raw, out)
+		if method != http.MethodGet {
+			return retry.Permanent(err)
+		}
+		return err
+	})
+}
+
+func (m *modusign) request(ctx context.Context, method, path string, raw []byte, out interface{}) error {
+	c, cancel := budget.Slice(ctx, modusignTimeout)
+	defer cancel()
+
+	var reader io.Reader
+	if raw != nil {
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(c, method, modusignAPIURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(m.option.Email, m.option.ApiKey)
+	req.Header.Set("Accept", "application/json")
+	if raw != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return domain.ErrItemNotFound
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		errBody, _ := io.ReadAll(io.LimitReader(res.Body, errorBodyLimit))
+		return &retry.StatusError{Service: "modusign " + method + " " + path, Code: res.StatusCode, Body: string(errBody)}
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+type disabledESign struct{}
+
+func (disabledESign) Provider() domain.ESignProvider {
+	return domain.ESignProviderModusign
+}
+
+func (disabledESign) Send(context.Context, domain.ESignRequest) (string, error) {
+	return "", ErrNotConfigured
+}
+
+func (disabledESign) Status(context.Context, string) (domain.ESignStatus, error) {
+	return "", ErrNotConfigured
+}
+
+func (disabledESign) DownloadSigned(context.Context, string) (io.ReadCloser, error) {
+	return nil, ErrNotConfigured
+}
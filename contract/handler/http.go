@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[CONTRACT] "
+)
+
+// NewContractController webhookToken 이 비어있으면 서명 서비스 webhook 을 받지 않음
+func NewContractController(useCase domain.ContractUseCase, webhookToken string) *ContractController {
+	return &ContractController{useCase: useCase, webhookToken: webhookToken}
+}
+
+type ContractController struct {
+	useCase      domain.ContractUseCase
+	webhookToken string
+}
+
+func (c *ContractController) Bind(e *echo.Echo) {
+	// ===== ADMIN =====
+	e.POST("/customer/:userId/contract", echox.UserID(c.sendContract),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/customer/:userId/contract", c.fetchCustomerContracts,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/contract/:contractId/file", c.getSignedContractFile,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== PROVIDER =====
+	// 서명 서비스가 호출, 인증 대신 주소에 넣은 토큰 확인
+	e.POST("/contract/webhook/modusign", c.modusignWebhook)
+}
+
+type ContractResponse struct {
+	Id          uuid.UUID  `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CustomerId  uuid.UUID  `json:"customerId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title       string     `json:"title" validate:"required" example:"에디트폴리오 기업 구독 계약서"`
+	Provider    string     `json:"provider" validate:"required" example:"MODUSIGN" enums:"MODUSIGN"`
+	Status      string     `json:"status" validate:"required" example:"SENT" enums:"SENT,SIGNED,DECLINED"`
+	SignerName  string     `json:"signerName" validate:"required" example:"홍길동"`
+	SignerEmail string     `json:"signerEmail" validate:"required" example:"ceo@example.com"`
+	SentBy      uuid.UUID  `json:"sentBy" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SentAt      time.Time  `json:"sentAt" validate:"required" example:"2024-05-01T10:00:00+09:00"`
+	CompletedAt *time.Time `json:"completedAt" example:"2024-05-02T15:30:00+09:00"`
+	// HasSignedFile, true 면 /contract/{contractId}/file 로 서명된 PDF 내려받기 가능
+	HasSignedFile bool `json:"hasSignedFile" validate:"required" example:"false"`
+} // @name ContractResponse
+
+func responseOf(src domain.ContractInfo) ContractResponse {
+	return ContractResponse{
+		Id:            src.Id,
+		CustomerId:    src.CustomerId,
+		Title:         src.Title,
+		Provider:      string(src.Provider),
+		Status:        string(src.Status),
+		SignerName:    src.SignerName,
+		SignerEmail:   src.SignerEmail,
+		SentBy:        src.SentBy,
+		SentAt:        src.SentAt,
+		CompletedAt:   src.CompletedAt,
+		HasSignedFile: src.HasSignedFile,
+	}
+}
+
+type SendContractRequest struct {
+	UserId uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title  string    `json:"title" validate:"required,max=200" example:"에디트폴리오 기업 구독 계약서"`
+	// SignerName, 비어있으면 고객 이름
+	SignerName string `json:"signerName" validate:"omitempty,max=60" example:"홍길동"`
+	// SignerEmail, 비어있으면 고객 이메일
+	SignerEmail string `json:"signerEmail" validate:"omitempty,email,max=320" example:"ceo@example.com"`
+} // @name SendContractRequest
+
+// @Tags (Contract) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 기업 고객 계약서 발송
+// @Description 서명 서비스에 등록한 템플릿으로 서명 요청 메일 발송, 계약서를 보낸 고객은 가장 최근 계약서에 서명해야 구독권 생성, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Param requestBody body SendContractRequest true "계약서 제목, 서명자"
+// @Success 201 {object} ContractResponse "발송"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Failure 404 {object} domain.ErrorResponse "없는 고객"
+// @Router /customer/{user_id}/contract [post]
+func (c *ContractController) sendContract(ctx echo.Context, userId uuid.UUID) error {
+	var req SendContractRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "send contract, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	contract, err := c.useCase.SendContract(ctx.Request().Context(), domain.SendContract{
+		CustomerId:  req.UserId,
+		Title:       req.Title,
+		SignerName:  req.SignerName,
+		SignerEmail: req.SignerEmail,
+		SentBy:      userId,
+	})
+
+	switch err {
+	case nil:
+		echox.Log(ctx).WithField("contractId", contract.Id).
+			WithField("customerId", contract.CustomerId).
+			WithField("sentBy", userId).
+			Info(tag, "contract sent")
+		return ctx.JSON(http.StatusCreated, responseOf(contract))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("customerId", req.UserId).
+			Error(tag, "sendContract, unhandled error useCase.SendContract")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Contract) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 계약서 목록
+// @Description 최근에 보낸 계약서부터, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Success 200 {array} ContractResponse "성공"
+// @Success 204 "보낸 계약서 없음"
+// @Router /customer/{user_id}/contract [get]
+func (c *ContractController) fetchCustomerContracts(ctx echo.Context) error {
+	var req struct {
+		UserId uuid.UUID `param:"userId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch customer contracts, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.FetchCustomerContracts(ctx.Request().Context(), req.UserId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("customerId", req.UserId).
+			Error(tag, "fetchCustomerContracts, unhandled error useCase.FetchCustomerContracts")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]ContractResponse, len(list))
+	for i := range list {
+		res[i] = responseOf(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type SignedContractFileResponse struct {
+	URL       string    `json:"url" validate:"required" example:"https://bucket.s3.ap-northeast-2.amazonaws.com/contract/550e8400-e29b-41d4-a716-446655440000.pdf?X-Amz-Signature=..."`
+	ExpiresAt time.Time `json:"expiresAt" validate:"required" example:"2024-05-02T15:40:00+09:00"`
+} // @name SignedContractFileResponse
+
+// @Tags (Contract) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 서명된 계약서 다운로드 URL
+// @Description 인증 없이 10분 동안 쓸 수 있는 URL 반환, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param contract_id path string true "계약서 아이디(UUID)"
+// @Success 200 {object} SignedContractFileResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없거나 서명 전인 계약서"
+// @Router /contract/{contract_id}/file [get]
+func (c *ContractController) getSignedContractFile(ctx echo.Context) error {
+	var req struct {
+		ContractId uuid.UUID `param:"contractId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get signed contract file, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	url, err := c.useCase.GetSignedContractURL(ctx.Request().Context(), req.ContractId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, SignedContractFileResponse{
+			URL:       url,
+			ExpiresAt: time.Now().Add(domain.ContractDownloadTTL),
+		})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("contractId", req.ContractId).
+			Error(tag, "getSignedContractFile, unhandled error useCase.GetSignedContractURL")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// modusignWebhookRequest 모두싸인 webhook, 문서 아이디만 쓰고 상태는 다시 조회
+type modusignWebhookRequest struct {
+	Token    string `query:"token"`
+	Document struct {
+		Id string `json:"id"`
+	} `json:"document"`
+}
+
+// modusignWebhook 서명 완료, 거절 등 문서 이벤트 수신
+// 2xx 가 아니면 모두싸인이 다시 보내므로 처리 실패만 5xx, 우리 문서가 아니면 200
+func (c *ContractController) modusignWebhook(ctx echo.Context) error {
+	var req modusignWebhookRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "modusign webhook, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if c.webhookToken == "" || subtle.ConstantTimeCompare([]byte(req.Token), []byte(c.webhookToken)) != 1 {
+		echox.Log(ctx).WithField("ip", ctx.RealIP()).Warn(tag, "modusign webhook, invalid token")
+		return ctx.JSON(http.StatusUnauthorized, domain.InvalidateTokenResponse)
+	}
+
+	if req.Document.Id == "" {
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "document id required"})
+	}
+
+	err = c.useCase.SyncContract(ctx.Request().Context(), domain.ESignProviderModusign, req.Document.Id)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		echox.Log(ctx).WithField("documentId", req.Document.Id).Debug(tag, "modusign webhook, unknown document")
+		return ctx.NoContent(http.StatusOK)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("documentId", req.Document.Id).
+			Error(tag, "modusignWebhook, unhandled error useCase.SyncContract")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
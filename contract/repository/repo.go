@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewContractRepository(db *gorm.DB) domain.ContractRepository {
+	db.AutoMigrate(&domain.Contract{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.ContractTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(contractRepo domain.ContractTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) Save(ctx context.Context, contract *domain.Contract) error {
+	return gormx.Upsert(ctx, r.db, contract)
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (contract *domain.Contract, err error) {
+	var entity domain.Contract
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		contract = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetByDocumentId(ctx context.Context, provider domain.ESignProvider, documentId string) (contract *domain.Contract, err error) {
+	var entity domain.Contract
+	err = r.db.WithContext(ctx).
+		Where("`provider` = ? AND `document_id` = ?", provider, documentId).
+		First(&entity).Error
+	if err == nil {
+		contract = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetLatestByCustomerId(ctx context.Context, customerId uuid.UUID) (contract *domain.Contract, err error) {
+	var entity domain.Contract
+	err = r.db.WithContext(ctx).
+		Where("`customer_id` = ?", customerId).
+		Order("`sent_at` desc").
+		First(&entity).Error
+	if err == nil {
+		contract = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchByCustomerId(ctx context.Context, customerId uuid.UUID) (list []domain.Contract, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`customer_id` = ?", customerId).
+		Order("`sent_at` desc").
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewContractGate 계약서를 보낸 적 없는 고객(일반 고객)은 막지 않음
+func NewContractGate(contractRepo domain.ContractRepository) domain.ContractGate {
+	return &gate{contractRepo: contractRepo}
+}
+
+type gate struct {
+	contractRepo domain.ContractRepository
+}
+
+func (g *gate) RequireSigned(ctx context.Context, customerId uuid.UUID) error {
+	latest, err := g.contractRepo.GetLatestByCustomerId(ctx, customerId)
+	if err != nil {
+		return err
+	}
+
+	if latest != nil && !latest.IsSigned() {
+		return domain.ErrContractNotSigned
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+// signedFileMaxSize 서명된 계약서 PDF 최대 크기, 메모리에 받은 뒤 저장
+const signedFileMaxSize = 50 << 20
+
+var errSignedFileTooLarge = errors.New("signed contract file too large")
+
+func NewContractUseCase(
+	contractRepo domain.ContractRepository,
+	userRepo domain.UserRepository,
+	outboxRepo domain.OutboxRepository,
+	esign domain.ESignAdapter,
+	storage domain.BlobStorage,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.ContractUseCase {
+	return &ucase{
+		contractRepo: contractRepo,
+		userRepo:     userRepo,
+		outboxRepo:   outboxRepo,
+		esign:        esign,
+		storage:      storage,
+		ids:          ids,
+		clock:        clock,
+		timeout:      timeout,
+	}
+}
+
+type ucase struct {
+	contractRepo domain.ContractRepository
+	userRepo     domain.UserRepository
+	outboxRepo   domain.OutboxRepository
+	esign        domain.ESignAdapter
+	storage      domain.BlobStorage
+	ids          domain.IdGenerator
+	clock        domain.Clock
+	timeout      time.Duration
+}
+
+func (u *ucase) SendContract(ctx context.Context, in domain.SendContract) (res domain.ContractInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByIdWithCustomer(c, in.CustomerId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) || user.Customer == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	signerName, signerEmail := in.SignerName, in.SignerEmail
+	if signerName == "" {
+		signerName = user.Customer.Name
+	}
+	if signerEmail == "" {
+		signerEmail = user.Customer.Email
+	}
+
+	documentId, err := u.esign.Send(c, domain.ESignRequest{
+		Title:       in.Title,
+		SignerName:  signerName,
+		SignerEmail: signerEmail,
+	})
+	if err != nil {
+		return
+	}
+
+	contract := domain.CreateContract(domain.CreateContractOption{
+		Id:          u.ids.NewId(),
+		CustomerId:  user.Id,
+		Title:       in.Title,
+		Provider:    u.esign.Provider(),
+		DocumentId:  documentId,
+		SignerName:  signerName,
+		SignerEmail: signerEmail,
+		SentBy:      in.SentBy,
+		Now:         u.clock.Now(),
+	})
+	err = u.contractRepo.Save(c, &contract)
+	if err != nil {
+		return
+	}
+
+	res = contractInfoOf(contract)
+	return
+}
+
+func (u *ucase) SyncContract(ctx context.Context, provider domain.ESignProvider, documentId string) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	contract, err := u.contractRepo.GetByDocumentId(c, provider, documentId)
+	if err != nil {
+		return
+	}
+
+	if contract == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if contract.IsCompleted() {
+		return
+	}
+
+	status, err := u.esign.Status(c, documentId)
+	if err != nil {
+		return
+	}
+
+	switch status {
+	case domain.ESignStatusSigned:
+		return u.sign(c, contract)
+	case domain.ESignStatusDeclined:
+		contract.Decline(u.clock.Now())
+		return u.contractRepo.Save(c, contract)
+	}
+	return
+}
+
+// sign 서명된 PDF 를 저장소에 옮긴 뒤 서명 완료, 결제 시스템이 보류한 구독 결제를 다시 보내도록 이벤트 발행
+func (u *ucase) sign(ctx context.Context, contract *domain.Contract) (err error) {
+	key := domain.SignedContractKey(contract.Id)
+	err = u.storeSignedFile(ctx, contract.DocumentId, key)
+	if err != nil {
+		return
+	}
+
+	user, err := u.userRepo.GetById(ctx, contract.CustomerId)
+	if err != nil {
+		return
+	}
+
+	if user == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	contract.Sign(key, u.clock.Now())
+	event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeUser,
+		AggregateId:   contract.CustomerId,
+		EventType:     domain.OutboxEventTypeContractSigned,
+		Data: domain.ContractSignedEvent{
+			ContractId: contract.Id,
+			CustomerId: contract.CustomerId,
+			Username:   user.Username,
+			SignedAt:   *contract.CompletedAt,
+		},
+		Now: u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	return u.contractRepo.Transaction(ctx, func(contractRepo domain.ContractTxRepository) error {
+		err := contractRepo.Save(ctx, contract)
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.With(contractRepo).Save(ctx, &event)
+	})
+}
+
+func (u *ucase) storeSignedFile(ctx context.Context, documentId, key string) (err error) {
+	body, err := u.esign.DownloadSigned(ctx, documentId)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, io.LimitReader(body, signedFileMaxSize+1))
+	if err != nil {
+		return
+	}
+
+	if buf.Len() > signedFileMaxSize {
+		err = errSignedFileTooLarge
+		return
+	}
+
+	return u.storage.Put(ctx, key, &buf, int64(buf.Len()), "application/pdf")
+}
+
+func contractInfoOf(src domain.Contract) domain.ContractInfo {
+	return domain.ContractInfo{
+		Id:            src.Id,
+		CustomerId:    src.CustomerId,
+		Title:         src.Title,
+		Provider:      src.Provider,
+		Status:        src.Status,
+		SignerName:    src.SignerName,
+		SignerEmail:   src.SignerEmail,
+		SentBy:        src.SentBy,
+		SentAt:        src.SentAt,
+		CompletedAt:   src.CompletedAt,
+		HasSignedFile: src.SignedFileKey != nil,
+	}
+}
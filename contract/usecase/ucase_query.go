@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchCustomerContracts(ctx context.Context, customerId uuid.UUID) (res []domain.ContractInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.contractRepo.FetchByCustomerId(c, customerId)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.ContractInfo, len(list))
+	for i := range list {
+		res[i] = contractInfoOf(list[i])
+	}
+	return
+}
+
+func (u *ucase) GetSignedContractURL(ctx context.Context, contractId uuid.UUID) (url string, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	contract, err := u.contractRepo.GetById(c, contractId)
+	if err != nil {
+		return
+	}
+
+	if contract == nil || contract.SignedFileKey == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	return u.storage.PresignGet(c, *contract.SignedFileKey, domain.ContractDownloadTTL)
+}
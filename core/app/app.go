@@ -1,7 +1,11 @@
 package app
 
 import (
+	"context"
+
 	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/server"
 	_ "github.com/stockfolioofficial/back-editfolio/docs"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"gorm.io/gorm"
@@ -11,6 +15,7 @@ type OnStart func() error
 type OnClose func()
 
 type App interface {
+	// Start 종료 신호를 받아 처리 중인 요청이 끝날 때까지 막힘
 	Start() error
 }
 
@@ -21,8 +26,8 @@ func NewApp(
 	onClose OnClose,
 ) (res App) {
 	res = &app{
-		e: e,
-		db: db,
+		e:       e,
+		db:      db,
 		onStart: onStart,
 		onClose: onClose,
 	}
@@ -30,8 +35,8 @@ func NewApp(
 }
 
 type app struct {
-	e *echo.Echo
-	db *gorm.DB
+	e       *echo.Echo
+	db      *gorm.DB
 	onStart OnStart
 	onClose OnClose
 }
@@ -46,7 +51,13 @@ func (a *app) Start() (err error) {
 	var e = a.e
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
-	err = e.Start(":8000")
+	err = server.Run(context.Background(), e, server.Option{
+		Addr:              config.ServerAddr,
+		ReadHeaderTimeout: config.ServerReadHeaderTimeout,
+		ReadTimeout:       config.ServerReadTimeout,
+		WriteTimeout:      config.ServerWriteTimeout,
+		IdleTimeout:       config.ServerIdleTimeout,
+		ShutdownTimeout:   config.ServerShutdownTimeout,
+	})
 	return
 }
-
@@ -0,0 +1,101 @@
+package blob
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const tag = "[BLOB] "
+
+// NewBlobController 로컬 저장소일 때만 presigned URL 로 파일을 주고받는 경로 등록
+func NewBlobController(storage domain.BlobStorage) *BlobController {
+	local, _ := storage.(*Local)
+	return &BlobController{local: local}
+}
+
+type BlobController struct {
+	local *Local
+}
+
+func (c *BlobController) Bind(e *echo.Echo) {
+	if c.local == nil {
+		return
+	}
+
+	// presigned URL 로만 접근, jwt 없음
+	e.GET("/blob/*", c.get)
+	e.PUT("/blob/*", c.put)
+}
+
+func (c *BlobController) verify(ctx echo.Context) (string, error) {
+	key := ctx.Param("*")
+	return key, c.local.Verify(ctx.Request().Method, key, ctx.QueryParams())
+}
+
+func (c *BlobController) get(ctx echo.Context) error {
+	key, err := c.verify(ctx)
+	if err != nil {
+		return ctx.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+	}
+
+	body, info, err := c.local.Open(ctx.Request().Context(), key)
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "get, unhandled error local.Open")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+	defer body.Close()
+
+	ctx.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(info.Size, 10))
+	return ctx.Stream(http.StatusOK, info.ContentType, body)
+}
+
+func (c *BlobController) put(ctx echo.Context) error {
+	key, err := c.verify(ctx)
+	if err != nil {
+		return ctx.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+	}
+
+	req := ctx.Request()
+	if uploadId := ctx.QueryParam("uploadId"); uploadId != "" {
+		return c.putPart(ctx, key, uploadId)
+	}
+
+	err = c.local.PutChecked(req.Context(), key, req.Body, req.ContentLength, req.Header.Get(echo.HeaderContentType), ctx.QueryParam("checksum"))
+	if err == ErrChecksumMismatch {
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	}
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "put, unhandled error local.Put")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (c *BlobController) putPart(ctx echo.Context, key, uploadId string) error {
+	partNumber, err := strconv.Atoi(ctx.QueryParam("partNumber"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "invalid part number"})
+	}
+
+	req := ctx.Request()
+	etag, err := c.local.PutPart(req.Context(), key, uploadId, partNumber, req.Body, req.ContentLength)
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "upload not found"})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "putPart, unhandled error local.PutPart")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	ctx.Response().Header().Set("ETag", etag)
+	return ctx.NoContent(http.StatusOK)
+}
@@ -0,0 +1,232 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+var (
+	ErrInvalidKey       = errors.New("invalid blob key")
+	ErrInvalidSignature = errors.New("invalid blob signature")
+	ErrChecksumMismatch = errors.New("blob checksum mismatch")
+)
+
+// LocalOption 로컬 개발, 테스트용 디스크 저장소
+type LocalOption struct {
+	Dir string
+	// BaseURL presigned URL 에 쓸 이 서버 주소, 파일은 /blob/* 로 주고받음
+	BaseURL string
+	// SignKey presigned URL 서명 키, 비어있으면 실행할 때마다 새로 만들어서 재시작하면 기존 URL 은 무효
+	SignKey []byte
+}
+
+func NewLocal(option LocalOption) *Local {
+	key := option.SignKey
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+
+	return &Local{
+		dir:     option.Dir,
+		baseURL: strings.TrimRight(option.BaseURL, "/"),
+		signKey: key,
+	}
+}
+
+type Local struct {
+	dir     string
+	baseURL string
+	signKey []byte
+}
+
+// path 키를 디렉토리 밖으로 나가지 못하게 정리
+func (l *Local) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(cleaned)), nil
+}
+
+func (l *Local) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	return l.PutChecked(ctx, key, body, size, contentType, "")
+}
+
+// PutChecked checksum(SHA-256 hex)과 다르면 저장하지 않고 ErrChecksumMismatch, 비어있으면 검사 없음
+func (l *Local) PutChecked(ctx context.Context, key string, body io.Reader, size int64, contentType, checksum string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(p), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// 다 쓴 뒤에 옮겨서 쓰는 중인 파일을 읽지 않도록 함
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), body)
+	closeErr := tmp.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("blob %s: size mismatch %d != %d", key, written, size)
+	}
+	if checksum != "" && !strings.EqualFold(checksum, hex.EncodeToString(hash.Sum(nil))) {
+		return ErrChecksumMismatch
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+func (l *Local) Open(ctx context.Context, key string) (io.ReadCloser, domain.BlobInfo, error) {
+	info, err := l.Stat(ctx, key)
+	if err != nil {
+		return nil, info, err
+	}
+
+	p, _ := l.path(key)
+	file, err := os.Open(p)
+	if err != nil {
+		return nil, info, err
+	}
+	return file, info, nil
+}
+
+func (l *Local) Stat(ctx context.Context, key string) (info domain.BlobInfo, err error) {
+	p, err := l.path(key)
+	if err != nil {
+		return
+	}
+
+	stat, err := os.Stat(p)
+	if os.IsNotExist(err) {
+		err = domain.ErrItemNotFound
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	info = domain.BlobInfo{
+		Key:         key,
+		Size:        stat.Size(),
+		ContentType: mime.TypeByExtension(filepath.Ext(p)),
+		ModifiedAt:  stat.ModTime(),
+	}
+	if info.ContentType == "" {
+		info.ContentType = "application/octet-stream"
+	}
+	return
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(p)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (l *Local) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return l.presign(http.MethodGet, key, nil, ttl)
+}
+
+// PresignPut checksum 은 쿼리에 넣어 같이 서명하고 PUT 할 때 확인
+func (l *Local) PresignPut(ctx context.Context, key, contentType, checksum string, ttl time.Duration) (rawURL string, headers map[string]string, err error) {
+	var params url.Values
+	if checksum != "" {
+		params = url.Values{"checksum": {checksum}}
+	}
+	rawURL, err = l.presign(http.MethodPut, key, params, ttl)
+	return
+}
+
+func (l *Local) KeyOf(rawURL string) (string, bool) {
+	key, ok := keyOf(rawURL, l.baseURL+"/blob/")
+	if !ok {
+		return "", false
+	}
+	if _, err := l.path(key); err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// presign params 는 서명에 같이 포함할 쿼리 (ex. 멀티파트 조각 번호)
+func (l *Local) presign(method, key string, params url.Values, ttl time.Duration) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{}
+	for name, values := range params {
+		query[name] = values
+	}
+	query.Set("expires", expires)
+	query.Set("signature", l.sign(method, key, expires, params.Encode()))
+	escaped := (&url.URL{Path: "/blob/" + key}).EscapedPath()
+	return l.baseURL + escaped + "?" + query.Encode(), nil
+}
+
+// Verify presigned URL 확인, 만료되었거나 서명이 맞지 않으면 ErrInvalidSignature
+func (l *Local) Verify(method, key string, query url.Values) error {
+	expires, signature := query.Get("expires"), query.Get("signature")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+
+	params := url.Values{}
+	for name, values := range query {
+		if name != "expires" && name != "signature" {
+			params[name] = values
+		}
+	}
+
+	expected := l.sign(method, key, expires, params.Encode())
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (l *Local) sign(method, key, expires, params string) string {
+	mac := hmac.New(sha256.New, l.signKey)
+	mac.Write([]byte(method + "\n" + key + "\n" + expires + "\n" + params))
+	return hex.EncodeToString(mac.Sum(nil))
+}
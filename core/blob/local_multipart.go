@@ -0,0 +1,166 @@
+package blob
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// multipartDir 올리는 중인 조각 위치, 업로드마다 하위 디렉토리
+const multipartDir = ".multipart"
+
+var localUploadIdPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// uploadPath 업로드 디렉토리, 업로드 아이디는 직접 만든 hex 만 허용
+func (l *Local) uploadPath(uploadId string) (string, error) {
+	if !localUploadIdPattern.MatchString(uploadId) {
+		return "", domain.ErrItemNotFound
+	}
+	return filepath.Join(l.dir, multipartDir, uploadId), nil
+}
+
+func (l *Local) CreateMultipart(ctx context.Context, key, contentType string) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+
+	raw := make([]byte, 16)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+	uploadId := hex.EncodeToString(raw)
+
+	p, _ := l.uploadPath(uploadId)
+	return uploadId, os.MkdirAll(p, 0o755)
+}
+
+func (l *Local) PresignPart(ctx context.Context, key, uploadId string, partNumber int, ttl time.Duration) (string, error) {
+	return l.presign(http.MethodPut, key, url.Values{
+		"partNumber": {strconv.Itoa(partNumber)},
+		"uploadId":   {uploadId},
+	}, ttl)
+}
+
+// PutPart presigned 조각 URL 로 올린 조각 저장, S3 처럼 따옴표로 감싼 MD5 를 ETag 로 반환
+func (l *Local) PutPart(ctx context.Context, key, uploadId string, partNumber int, body io.Reader, size int64) (string, error) {
+	dir, err := l.uploadPath(uploadId)
+	if err != nil {
+		return "", err
+	}
+	if _, err = os.Stat(dir); os.IsNotExist(err) {
+		return "", domain.ErrItemNotFound
+	}
+
+	tmp, err := os.CreateTemp(dir, ".part-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), body)
+	closeErr := tmp.Close()
+	if err != nil {
+		return "", err
+	}
+	if closeErr != nil {
+		return "", closeErr
+	}
+	if size >= 0 && written != size {
+		return "", fmt.Errorf("blob %s: part %d size mismatch %d != %d", key, partNumber, written, size)
+	}
+
+	err = os.Rename(tmp.Name(), filepath.Join(dir, strconv.Itoa(partNumber)))
+	if err != nil {
+		return "", err
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)) + `"`, nil
+}
+
+func (l *Local) CompleteMultipart(ctx context.Context, key, uploadId string, parts []domain.BlobPart) error {
+	dir, err := l.uploadPath(uploadId)
+	if err != nil {
+		return err
+	}
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(p), 0o755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	for _, part := range parts {
+		err = appendPart(tmp, filepath.Join(dir, strconv.Itoa(part.Number)), part.ETag)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), p)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// appendPart 조각이 없거나 ETag 가 다르면 S3 와 같이 ErrWeirdData
+func appendPart(dst io.Writer, partPath, etag string) error {
+	part, err := os.Open(partPath)
+	if os.IsNotExist(err) {
+		return domain.ErrWeirdData
+	}
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+
+	hash := md5.New()
+	_, err = io.Copy(io.MultiWriter(dst, hash), part)
+	if err != nil {
+		return err
+	}
+
+	if `"`+hex.EncodeToString(hash.Sum(nil))+`"` != etag {
+		return domain.ErrWeirdData
+	}
+	return nil
+}
+
+func (l *Local) AbortMultipart(ctx context.Context, key, uploadId string) error {
+	dir, err := l.uploadPath(uploadId)
+	if err == domain.ErrItemNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,239 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/awsv4"
+)
+
+// checksumHeader 올릴 때 보내고 HEAD 로 받는 SHA-256 체크섬 (base64)
+const checksumHeader = "X-Amz-Checksum-Sha256"
+
+// S3Option S3 또는 S3 호환 저장소(MinIO 등)
+type S3Option struct {
+	// Endpoint 비어있으면 AWS S3, MinIO 는 http://localhost:9000 처럼 지정
+	Endpoint string
+	Region   string
+	Bucket   string
+	// PathStyle 버킷을 경로에 넣음 (MinIO 는 true)
+	PathStyle bool
+}
+
+// NewS3 SDK 없이 REST API 직접 호출, 자격 증명은 AWS_* 환경 변수 또는 ECS 작업 역할
+func NewS3(option S3Option) domain.BlobStorage {
+	endpoint := strings.TrimRight(option.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", option.Region)
+	}
+
+	client := &http.Client{}
+	return &s3{
+		option:   option,
+		endpoint: endpoint,
+		signer:   awsv4.Signer{Region: option.Region, Service: "s3"},
+		creds:    awsv4.NewCredentialSource(client),
+		client:   client,
+	}
+}
+
+type s3 struct {
+	option   S3Option
+	endpoint string
+	signer   awsv4.Signer
+	creds    *awsv4.CredentialSource
+	client   *http.Client
+}
+
+func (s *s3) objectURL(key string, query url.Values) (*url.URL, error) {
+	u, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	key = strings.TrimLeft(key, "/")
+	if s.option.PathStyle {
+		u.Path = "/" + s.option.Bucket + "/" + key
+	} else {
+		u.Host = s.option.Bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	u.RawQuery = query.Encode()
+	return u, nil
+}
+
+// do payload 는 스트리밍이라 해시 없이(UNSIGNED-PAYLOAD) 서명
+func (s *s3) do(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	creds, err := s.creds.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.objectURL(key, query)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.signer.Sign(req, awsv4.UnsignedPayload, creds, time.Now())
+	return s.client.Do(req)
+}
+
+func (s *s3) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, nil, body, size, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp, key)
+}
+
+func (s *s3) Open(ctx context.Context, key string) (io.ReadCloser, domain.BlobInfo, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, 0, nil)
+	if err != nil {
+		return nil, domain.BlobInfo{}, err
+	}
+
+	err = checkStatus(resp, key)
+	if err != nil {
+		resp.Body.Close()
+		return nil, domain.BlobInfo{}, err
+	}
+	return resp.Body, infoOf(key, resp), nil
+}
+
+// Stat 체크섬을 넣어 올린 객체는 체크섬도 같이 받음
+func (s *s3) Stat(ctx context.Context, key string) (domain.BlobInfo, error) {
+	header := http.Header{}
+	header.Set("X-Amz-Checksum-Mode", "ENABLED")
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil, 0, header)
+	if err != nil {
+		return domain.BlobInfo{}, err
+	}
+	defer resp.Body.Close()
+
+	err = checkStatus(resp, key)
+	if err != nil {
+		return domain.BlobInfo{}, err
+	}
+	return infoOf(key, resp), nil
+}
+
+func (s *s3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, 0, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = checkStatus(resp, key)
+	if err == domain.ErrItemNotFound {
+		return nil
+	}
+	return err
+}
+
+func (s *s3) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return s.presign(ctx, http.MethodGet, key, nil, ttl)
+}
+
+// PresignPut content type 은 서명하지 않으므로 올리는 쪽에서 헤더로 지정
+// checksum 은 x-amz-checksum-sha256 헤더(base64)로 서명하므로 올리는 쪽도 같은 헤더를 보내야 함
+func (s *s3) PresignPut(ctx context.Context, key, contentType, checksum string, ttl time.Duration) (rawURL string, headers map[string]string, err error) {
+	if checksum == "" {
+		rawURL, err = s.presign(ctx, http.MethodPut, key, nil, ttl)
+		return
+	}
+
+	sum, err := hex.DecodeString(checksum)
+	if err != nil || len(sum) != sha256.Size {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	creds, err := s.creds.Get(ctx)
+	if err != nil {
+		return
+	}
+
+	u, err := s.objectURL(key, nil)
+	if err != nil {
+		return
+	}
+
+	headers = map[string]string{checksumHeader: base64.StdEncoding.EncodeToString(sum)}
+	rawURL = s.signer.PresignHeaders(http.MethodPut, u, headers, creds, time.Now(), ttl)
+	return
+}
+
+func (s *s3) KeyOf(rawURL string) (string, bool) {
+	base, err := s.objectURL("", nil)
+	if err != nil {
+		return "", false
+	}
+	return keyOf(rawURL, base.String())
+}
+
+func (s *s3) presign(ctx context.Context, method, key string, query url.Values, ttl time.Duration) (string, error) {
+	creds, err := s.creds.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := s.objectURL(key, query)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.Presign(method, u, creds, time.Now(), ttl), nil
+}
+
+func checkStatus(resp *http.Response, key string) error {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return domain.ErrItemNotFound
+	case resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 %s: status %d: %s", key, resp.StatusCode, msg)
+	}
+	return nil
+}
+
+func infoOf(key string, resp *http.Response) domain.BlobInfo {
+	size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	modified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	var checksum string
+	if sum, err := base64.StdEncoding.DecodeString(resp.Header.Get(checksumHeader)); err == nil && len(sum) == sha256.Size {
+		checksum = hex.EncodeToString(sum)
+	}
+	return domain.BlobInfo{
+		Key:         key,
+		Size:        size,
+		ContentType: resp.Header.Get("Content-Type"),
+		ModifiedAt:  modified,
+		Checksum:    checksum,
+	}
+}
@@ -0,0 +1,124 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+type s3InitiateResult struct {
+	UploadId string `xml:"UploadId"`
+}
+
+type s3CompletePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3CompleteRequest struct {
+	XMLName xml.Name         `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletePart `xml:"Part"`
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (s *s3) CreateMultipart(ctx context.Context, key, contentType string) (string, error) {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, 0, header)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	err = checkStatus(resp, key)
+	if err != nil {
+		return "", err
+	}
+
+	var result s3InitiateResult
+	err = xml.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return "", err
+	}
+	if result.UploadId == "" {
+		return "", fmt.Errorf("s3 %s: empty upload id", key)
+	}
+	return result.UploadId, nil
+}
+
+func (s *s3) PresignPart(ctx context.Context, key, uploadId string, partNumber int, ttl time.Duration) (string, error) {
+	return s.presign(ctx, http.MethodPut, key, url.Values{
+		"partNumber": {strconv.Itoa(partNumber)},
+		"uploadId":   {uploadId},
+	}, ttl)
+}
+
+func (s *s3) CompleteMultipart(ctx context.Context, key, uploadId string, parts []domain.BlobPart) error {
+	req := s3CompleteRequest{Parts: make([]s3CompletePart, len(parts))}
+	for i, part := range parts {
+		req.Parts[i] = s3CompletePart{PartNumber: part.Number, ETag: part.ETag}
+	}
+
+	body, err := xml.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml")
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadId}},
+		bytes.NewReader(body), int64(len(body)), header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 조각 누락, ETag 불일치, 마지막이 아닌 조각이 5MB 미만인 경우
+	if resp.StatusCode == http.StatusBadRequest {
+		return domain.ErrWeirdData
+	}
+	err = checkStatus(resp, key)
+	if err != nil {
+		return err
+	}
+
+	// 이어 붙이는 중 실패하면 200 응답 본문에 에러가 옴
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	var result s3Error
+	if xml.Unmarshal(raw, &result) == nil && result.Code != "" {
+		return fmt.Errorf("s3 %s: complete multipart: %s: %s", key, result.Code, result.Message)
+	}
+	return nil
+}
+
+func (s *s3) AbortMultipart(ctx context.Context, key, uploadId string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadId}}, nil, 0, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = checkStatus(resp, key)
+	if err == domain.ErrItemNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package blob
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// keyOf prefix(저장소 주소 + 키 앞부분) 아래를 가리키는 URL 이면 키, scheme 과 쿼리는 비교하지 않음
+func keyOf(rawURL, prefix string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	base, err := url.Parse(prefix)
+	if err != nil {
+		return "", false
+	}
+
+	if !strings.EqualFold(u.Host, base.Host) || !strings.HasPrefix(u.Path, base.Path) {
+		return "", false
+	}
+
+	key := strings.TrimPrefix(u.Path, base.Path)
+	if key == "" || key != path.Clean(key) {
+		return "", false
+	}
+	return key, true
+}
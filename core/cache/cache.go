@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+)
+
+// Store 자주 안 바뀌는 조회 결과용 프로세스 메모리 캐시, TTL 이 지나거나 Invalidate 되면 다시 로드
+type Store struct {
+	name string
+	ttl  time.Duration
+	// limit 0 이 아니면 이 개수가 찼을 때 저장 전에 만료된 값을 지우고, 그래도 차 있으면 모두 비움
+	limit int
+
+	mu    sync.RWMutex
+	items map[string]item
+
+	hits   uint64
+	misses uint64
+}
+
+type item struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+var stores = struct {
+	sync.RWMutex
+	byName map[string]*Store
+}{byName: make(map[string]*Store)}
+
+// New 이름별로 하나만 생성, 진단 스냅샷에 적중률이 포함됨
+func New(name string, ttl time.Duration) *Store {
+	stores.Lock()
+	defer stores.Unlock()
+
+	if s, ok := stores.byName[name]; ok {
+		return s
+	}
+
+	s := &Store{
+		name:  name,
+		ttl:   ttl,
+		items: make(map[string]item),
+	}
+	stores.byName[name] = s
+	diagnostics.RegisterCache("memory."+name, s.stats)
+	return s
+}
+
+// NewWithLimit 검색어처럼 키가 계속 늘어나는 캐시용, 최대 limit 개까지만 보관
+func NewWithLimit(name string, ttl time.Duration, limit int) *Store {
+	s := New(name, ttl)
+	s.mu.Lock()
+	s.limit = limit
+	s.mu.Unlock()
+	return s
+}
+
+// GetOrLoad 캐시에 없으면 load 결과를 저장, load 에러는 저장하지 않음
+func (s *Store) GetOrLoad(key string, load func() (interface{}, error)) (interface{}, error) {
+	s.mu.RLock()
+	it, ok := s.items[key]
+	s.mu.RUnlock()
+
+	if ok && time.Now().Before(it.expiresAt) {
+		atomic.AddUint64(&s.hits, 1)
+		return it.value, nil
+	}
+	atomic.AddUint64(&s.misses, 1)
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	if s.limit > 0 && len(s.items) >= s.limit {
+		s.evict(now)
+	}
+	s.items[key] = item{value: value, expiresAt: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return value, nil
+}
+
+// evict mu 를 잡은 상태에서 호출
+func (s *Store) evict(now time.Time) {
+	for key, it := range s.items {
+		if !now.Before(it.expiresAt) {
+			delete(s.items, key)
+		}
+	}
+	if len(s.items) >= s.limit {
+		s.items = make(map[string]item)
+	}
+}
+
+func (s *Store) Invalidate(key string) {
+	s.mu.Lock()
+	delete(s.items, key)
+	s.mu.Unlock()
+}
+
+func (s *Store) InvalidateAll() {
+	s.mu.Lock()
+	s.items = make(map[string]item)
+	s.mu.Unlock()
+}
+
+func (s *Store) stats() diagnostics.CacheStats {
+	return diagnostics.CacheStats{
+		Hits:   atomic.LoadUint64(&s.hits),
+		Misses: atomic.LoadUint64(&s.misses),
+	}
+}
+
+// Invalidate 이름으로 캐시 비움, 데이터 변경 이벤트를 받은 쪽에서 호출, 없는 이름이면 false
+func Invalidate(name string) bool {
+	stores.RLock()
+	s, ok := stores.byName[name]
+	stores.RUnlock()
+
+	if ok {
+		s.InvalidateAll()
+	}
+	return ok
+}
+
+func Names() (names []string) {
+	stores.RLock()
+	for name := range stores.byName {
+		names = append(names, name)
+	}
+	stores.RUnlock()
+
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+func NewCacheController() *CacheController {
+	return &CacheController{}
+}
+
+// CacheController 다른 인스턴스나 운영 스크립트에서 데이터 변경 후 캐시를 비우는 용도
+type CacheController struct{}
+
+func (c *CacheController) Bind(e *echo.Echo) {
+	// INTERNAL
+	e.GET("/internal/cache", c.internalNames)
+	e.POST("/internal/cache/:name/invalidate", c.internalInvalidate)
+}
+
+func (c *CacheController) internalNames(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"names": Names(),
+	})
+}
+
+func (c *CacheController) internalInvalidate(ctx echo.Context) error {
+	if !Invalidate(ctx.Param("name")) {
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "cache not found"})
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,56 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// Seoul KST 는 서머타임이 없어서 고정 오프셋으로 충분, 배포 이미지(alpine)에 tzdata 가 없어도 동작
+var Seoul = time.FixedZone("KST", 9*60*60)
+
+// NewSeoulCalendar 서비스 기본 캘린더
+func NewSeoulCalendar(clock domain.Clock) domain.Calendar {
+	return New(Seoul, clock)
+}
+
+// New clock 을 바꿔서 특정 시각 기준으로 계산 가능
+func New(loc *time.Location, clock domain.Clock) domain.Calendar {
+	return &calendar{loc: loc, clock: clock}
+}
+
+type calendar struct {
+	loc   *time.Location
+	clock domain.Clock
+}
+
+func (c *calendar) Now() time.Time {
+	return c.clock.Now().In(c.loc)
+}
+
+func (c *calendar) Location() *time.Location {
+	return c.loc
+}
+
+func (c *calendar) DateOf(t time.Time) time.Time {
+	year, month, day := t.In(c.loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (c *calendar) EndOfDate(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, c.loc)
+}
+
+func (c *calendar) AddMonths(t time.Time, months int) time.Time {
+	t = t.In(c.loc)
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+
+	// 0일 = 전달 말일
+	lastDay := time.Date(year, month+time.Month(months)+1, 0, 0, 0, 0, 0, c.loc).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month+time.Month(months), day, hour, min, sec, t.Nanosecond(), c.loc)
+}
@@ -0,0 +1,68 @@
+package cipher
+
+import (
+	"crypto/aes"
+	stdcipher "crypto/cipher"
+	"crypto/rand"
+	"errors"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+var (
+	ErrKeyMissing = errors.New("credential master key missing")
+	ErrKeySize    = errors.New("credential master key must be 32 bytes")
+	ErrMalformed  = errors.New("sealed value malformed")
+)
+
+// NewAESGCM AES-256-GCM, 암호문 앞에 nonce 를 붙여서 저장
+func NewAESGCM(key []byte) (domain.CredentialCipher, error) {
+	if len(key) != 32 {
+		return nil, ErrKeySize
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := stdcipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesGCM{aead: aead}, nil
+}
+
+type aesGCM struct {
+	aead stdcipher.AEAD
+}
+
+func (a *aesGCM) Seal(plain, aad []byte) ([]byte, error) {
+	nonce := make([]byte, a.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return a.aead.Seal(nonce, nonce, plain, aad), nil
+}
+
+func (a *aesGCM) Open(sealed, aad []byte) ([]byte, error) {
+	size := a.aead.NonceSize()
+	if len(sealed) < size {
+		return nil, ErrMalformed
+	}
+	return a.aead.Open(nil, sealed[:size], sealed[size:], aad)
+}
+
+// Missing 키가 설정되지 않은 환경용, 사용할 때만 ErrKeyMissing
+var Missing domain.CredentialCipher = missing{}
+
+type missing struct{}
+
+func (missing) Seal([]byte, []byte) ([]byte, error) {
+	return nil, ErrKeyMissing
+}
+
+func (missing) Open([]byte, []byte) ([]byte, error) {
+	return nil, ErrKeyMissing
+}
@@ -0,0 +1,45 @@
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// System 실제 시계
+var System domain.Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewFixed 지정한 시각에 멈춘 시계, Set/Advance 로만 움직임
+func NewFixed(now time.Time) *Fixed {
+	return &Fixed{now: now}
+}
+
+type Fixed struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+func (f *Fixed) Now() time.Time {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.now
+}
+
+func (f *Fixed) Set(now time.Time) {
+	f.mu.Lock()
+	f.now = now
+	f.mu.Unlock()
+}
+
+func (f *Fixed) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	f.mu.Unlock()
+}
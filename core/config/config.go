@@ -6,18 +6,191 @@ import (
 	"net/url"
 	"os"
 	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
 )
 
 var (
 	IsDebug   = true
 	DBConn    = ""
 	JWTSecret = ""
+
+	DBHost = "localhost"
+	DBPort = uint16(3306)
+	DBUser = "root"
+	DBPass = "1234"
+	DBName = "editfolio"
+
+	// TenantDatabases 데이터를 따로 둬야 하는 대행사 테넌트별 DB, X-Tenant-Key 헤더로 선택, 없으면 기본 DB
+	TenantDatabases = map[string]TenantDatabase{}
+
+	// SecretProvider "vault", "aws" 또는 빈 값, DBPass, JWTSecret 에 "secret:<name>#<key>" 참조를 쓰려면 필요
+	SecretProvider = ""
+	// SecretRefresh 비밀 값 캐시 시간, 지난 후 처음 쓸 때 다시 조회해 교체(rotation)된 값 반영
+	SecretRefresh = 5 * time.Minute
+	VaultAddr     = ""
+	VaultToken    = ""
+	VaultMount    = "secret"
+	AWSRegion     = ""
+
+	// CredentialMasterKey 테넌트 자격 증명 암호화 키(base64, 32 bytes), 비밀 저장소 참조 권장
+	CredentialMasterKey = ""
+
+	// StorageDriver 파일 저장소 "local" 또는 "s3"
+	StorageDriver = "local"
+	StorageDir    = "storage"
+	// StorageBaseURL local 저장소 presigned URL 앞부분 (ex. https://api.editfolio.com), 비어있으면 상대 경로
+	StorageBaseURL = ""
+	// StorageSignKey local 저장소 presigned URL 서명 키, 비밀 저장소 참조 가능
+	StorageSignKey       = ""
+	StorageS3Endpoint    = ""
+	StorageS3Region      = ""
+	StorageS3Bucket      = ""
+	StorageS3PathStyle   = false
+	StorageMaxUploadSize = int64(500 << 20)
+
+	// FFmpegPath 납품 영상 미리보기 생성용, 비어있으면 PATH 에서 찾음
+	FFmpegPath = ""
+
+	// 실행 인자로만 지정, main 참고
+	MigrateAllowDestructive = false
+	MigrateDryRun           = false
+
+	RequestTimeout = 30 * time.Second
+
+	// ServerAddr 서비스 포트
+	ServerAddr = ":8000"
+	// ServerReadTimeout, ServerWriteTimeout 기본은 제한 없음, 큰 파일을 서버를 거쳐 올리고 내려받는 라우트(/file, /blob, /backup)가 있어 요청 제한 시간(RequestTimeout)으로 대신함
+	ServerReadHeaderTimeout = 10 * time.Second
+	ServerReadTimeout       = time.Duration(0)
+	ServerWriteTimeout      = time.Duration(0)
+	ServerIdleTimeout       = 120 * time.Second
+	// ServerShutdownTimeout 종료 신호 후 처리 중인 요청을 기다리는 시간, 배포 도구의 종료 유예 시간보다 짧아야함
+	ServerShutdownTimeout = 25 * time.Second
+
+	// IdVersion 새 아이디 UUID 버전, 4(랜덤) 또는 7(시간순)
+	IdVersion = 4
+
+	// PprofAddr 비어있으면 pprof 서버 안띄움
+	PprofAddr = ""
+
+	// YouTubeClientId 고객 채널 통계 연동 OAuth 클라이언트, 비어있으면 채널 연결 안됨
+	YouTubeClientId = ""
+	// YouTubeClientSecret 비밀 저장소 참조 가능
+	YouTubeClientSecret = ""
+	// YouTubeRedirectURL 동의 후 돌아올 이 서버 주소 (ex. https://api.editfolio.com/user/customer/channel/callback)
+	YouTubeRedirectURL = ""
+	// YouTubeReturnURL 연결을 마친 뒤 보낼 프론트 주소, channel 쿼리로 결과 전달
+	YouTubeReturnURL = ""
+
+	// ModusignEmail 모두싸인 API 키를 발급한 계정, 기업 고객 계약서 전자 서명용
+	ModusignEmail = ""
+	// ModusignApiKey 비밀 저장소 참조 가능, 비어있으면 계약서 발송 안됨
+	ModusignApiKey = ""
+	// ModusignTemplateId 계약서 템플릿, ModusignSignerRole 은 템플릿의 서명자 역할 이름
+	ModusignTemplateId = ""
+	ModusignSignerRole = ""
+	// ModusignWebhookToken webhook 주소의 token 쿼리로 확인, 비어있으면 webhook 받지 않음, 비밀 저장소 참조 가능
+	ModusignWebhookToken = ""
+
+	// EmailWebhookToken 메일 발송 서비스(SES, SendGrid) webhook 주소의 token 쿼리로 확인, 비어있으면 webhook 받지 않음, 비밀 저장소 참조 가능
+	EmailWebhookToken = ""
+
+	// SiemAddr, SiemURL 감사 로그를 보낼 보안 관제(SIEM) syslog 주소 또는 HTTPS 수집 주소, 둘 다 비어있으면 보내지 않음
+	SiemAddr = ""
+	SiemURL  = ""
+	// SiemToken HTTPS 수집 주소의 Bearer 토큰, 비밀 저장소 참조 가능
+	SiemToken = ""
+	// SiemFormat "json" 또는 "cef"
+	SiemFormat = "json"
+	// SiemBufferSize 보내지 못하고 쌓아둘 수 있는 기록 수, 넘으면 버림
+	SiemBufferSize    = 10000
+	SiemBatchSize     = 100
+	SiemFlushInterval = time.Second
+
+	// SmsAppKey NHN Cloud SMS 앱 키, 비어있으면 문자 발송 안됨 (디버그 모드에서는 로그로만 남김)
+	SmsAppKey = ""
+	// SmsSecretKey 비밀 저장소 참조 가능
+	SmsSecretKey = ""
+	// SmsSendNo 사전 등록한 발신 번호, 하이픈 없이
+	SmsSendNo = ""
+
+	// ShortLinkBaseURL 문자 메시지에 넣을 짧은 주소 앞부분 (ex. https://efol.io), 비어있으면 상대 경로
+	ShortLinkBaseURL = ""
+
+	// QRTargets QR 코드로 만들 수 있는 주소 앞부분, 종류(PAYMENT, ONBOARDING)별, 짧은 주소는 ShortLinkBaseURL 로 자동 허용
+	QRTargets = map[string][]string{}
+
+	// RetryPolicies 외부 연동 어댑터별 한 번 호출 안에서의 재시도, 설정 파일에 있는 값만 덮어씀
+	// 발행, 전송 작업 단위 재시도(OutboxMaxAttempts, HookMaxAttempts)와는 별개
+	RetryPolicies = map[string]retry.Policy{
+		"kafka":         {MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 2 * time.Second},
+		"webhook":       {MaxAttempts: 2, BaseDelay: 500 * time.Millisecond, MaxDelay: 2 * time.Second},
+		"google_sheets": {MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 4 * time.Second},
+		"notion":        {MaxAttempts: 4, BaseDelay: time.Second, MaxDelay: 8 * time.Second},
+		"youtube":       {MaxAttempts: 3, BaseDelay: 300 * time.Millisecond, MaxDelay: 3 * time.Second},
+		"modusign":      {MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 4 * time.Second},
+		"siem":          {MaxAttempts: 5, BaseDelay: 500 * time.Millisecond, MaxDelay: 8 * time.Second},
+		"sms":           {MaxAttempts: 2, BaseDelay: 300 * time.Millisecond, MaxDelay: 2 * time.Second},
+	}
+
+	// ConcurrencyLimits 무거운 라우트 분류(export, report)별 동시 실행 제한, 서버 한 대 기준, 설정 파일에 있는 값만 덮어씀
+	ConcurrencyLimits = map[string]workerpool.Limit{
+		"export": {Max: 2, Queue: 4, Wait: 3 * time.Second},
+		"report": {Max: 4, Queue: 8, Wait: 2 * time.Second},
+	}
+
+	KafkaRestProxy   = ""
+	KafkaTopicPrefix = "editfolio."
+	KafkaTopics      = map[string]string{}
+
+	BackupDir = "backup"
+
+	// SnapshotImportEnabled 고객 스냅샷 가져오기 허용, 스테이징에서만 켬
+	SnapshotImportEnabled = false
+
+	RetentionArchivePrefix = "archive"
+	RetentionDays          = map[string]uint16{
+		"analytics_event":     180,
+		"outbox_event":        30,
+		"inbox_message":       30,
+		"shadow_record":       7,
+		"api_usage":           35,
+		"refresh_token":       7,
+		"password_reset":      7,
+		"mobile_verification": 7,
+		"sign_in_failure":     7,
+	}
 )
 
 const (
 	mysqlDBConnFormat = "%s:%s@tcp(%s:%d)/%s?%s"
 )
 
+var dbConnParams string
+
+type TenantDatabase struct {
+	User string
+	// Pass 비밀 저장소 참조 가능
+	Pass string
+	Host string
+	Port uint16
+	Name string
+	// MaxConns 테넌트 연결 풀 크기, 테넌트마다 풀이 따로라 기본 DB 보다 작게
+	MaxConns int
+}
+
+// Conn pass 로 접속 문자열 생성, Pass 가 비밀 저장소 참조면 조회한 값을 넣음
+func (t TenantDatabase) Conn(pass string) string {
+	return fmt.Sprintf(mysqlDBConnFormat, t.User, pass, t.Host, t.Port, t.Name, dbConnParams)
+}
+
+// DBConnWithPass DBPass 가 비밀 저장소 참조일 때 조회한 비밀번호로 접속 문자열 생성
+func DBConnWithPass(pass string) string {
+	return fmt.Sprintf(mysqlDBConnFormat, DBUser, pass, DBHost, DBPort, DBName, dbConnParams)
+}
+
 func init() {
 	file, err := os.Open("config.json")
 	if err != nil {
@@ -28,20 +201,187 @@ func init() {
 	val.Add("charset", "utf8mb4")
 	val.Add("parseTime", "true")
 	val.Add("loc", time.UTC.String())
+	dbConnParams = val.Encode()
+
+	// 토큰은 설정 파일에 두지 않음
+	VaultToken = os.Getenv("VAULT_TOKEN")
 
 	err = json.NewDecoder(file).Decode(&c)
 
 	if err != nil {
 		IsDebug = true
 		DBConn = fmt.Sprintf(mysqlDBConnFormat,
-			"root", "1234", "localhost", 3306, "editfolio", val.Encode())
+			DBUser, DBPass, DBHost, DBPort, DBName, val.Encode())
 	} else {
 		var db = c.DB
+		DBHost, DBPort, DBUser, DBPass, DBName = db.Host, db.Port, db.User, db.Pass, db.Name
 
 		IsDebug = c.IsDebug
 		DBConn = fmt.Sprintf(mysqlDBConnFormat,
 			db.User, db.Pass, db.Host, db.Port, db.Name, val.Encode())
 
 		JWTSecret = c.JWT.Secret
+
+		SecretProvider = c.Secrets.Provider
+		if c.Secrets.RefreshSec > 0 {
+			SecretRefresh = time.Duration(c.Secrets.RefreshSec) * time.Second
+		}
+		VaultAddr = c.Secrets.Vault.Addr
+		if c.Secrets.Vault.Mount != "" {
+			VaultMount = c.Secrets.Vault.Mount
+		}
+		AWSRegion = c.Secrets.AWS.Region
+
+		CredentialMasterKey = c.Credential.MasterKey
+
+		if c.Storage.Driver != "" {
+			StorageDriver = c.Storage.Driver
+		}
+		if c.Storage.Local.Dir != "" {
+			StorageDir = c.Storage.Local.Dir
+		}
+		StorageBaseURL = c.Storage.Local.BaseURL
+		StorageSignKey = c.Storage.Local.SignKey
+		StorageS3Endpoint = c.Storage.S3.Endpoint
+		StorageS3Region = c.Storage.S3.Region
+		StorageS3Bucket = c.Storage.S3.Bucket
+		StorageS3PathStyle = c.Storage.S3.PathStyle
+		if c.Storage.MaxUploadMB > 0 {
+			StorageMaxUploadSize = int64(c.Storage.MaxUploadMB) << 20
+		}
+		FFmpegPath = c.Media.FFmpegPath
+
+		PprofAddr = c.Diagnostics.PprofAddr
+
+		if c.Id.Version != 0 {
+			IdVersion = c.Id.Version
+		}
+
+		if c.Server.RequestTimeoutMs > 0 {
+			RequestTimeout = time.Duration(c.Server.RequestTimeoutMs) * time.Millisecond
+		}
+		if c.Server.Addr != "" {
+			ServerAddr = c.Server.Addr
+		}
+		if c.Server.ReadHeaderTimeoutMs > 0 {
+			ServerReadHeaderTimeout = time.Duration(c.Server.ReadHeaderTimeoutMs) * time.Millisecond
+		}
+		if c.Server.ReadTimeoutMs > 0 {
+			ServerReadTimeout = time.Duration(c.Server.ReadTimeoutMs) * time.Millisecond
+		}
+		if c.Server.WriteTimeoutMs > 0 {
+			ServerWriteTimeout = time.Duration(c.Server.WriteTimeoutMs) * time.Millisecond
+		}
+		if c.Server.IdleTimeoutMs > 0 {
+			ServerIdleTimeout = time.Duration(c.Server.IdleTimeoutMs) * time.Millisecond
+		}
+		if c.Server.ShutdownTimeoutMs > 0 {
+			ServerShutdownTimeout = time.Duration(c.Server.ShutdownTimeoutMs) * time.Millisecond
+		}
+
+		YouTubeClientId = c.YouTube.ClientId
+		YouTubeClientSecret = c.YouTube.ClientSecret
+		YouTubeRedirectURL = c.YouTube.RedirectURL
+		YouTubeReturnURL = c.YouTube.ReturnURL
+
+		ModusignEmail = c.ESign.Modusign.Email
+		ModusignApiKey = c.ESign.Modusign.ApiKey
+		ModusignTemplateId = c.ESign.Modusign.TemplateId
+		ModusignSignerRole = c.ESign.Modusign.SignerRole
+		ModusignWebhookToken = c.ESign.Modusign.WebhookToken
+
+		EmailWebhookToken = c.Email.WebhookToken
+
+		SiemAddr = c.Siem.Addr
+		SiemURL = c.Siem.URL
+		SiemToken = c.Siem.Token
+		if c.Siem.Format != "" {
+			SiemFormat = c.Siem.Format
+		}
+		if c.Siem.BufferSize > 0 {
+			SiemBufferSize = c.Siem.BufferSize
+		}
+		if c.Siem.BatchSize > 0 {
+			SiemBatchSize = c.Siem.BatchSize
+		}
+		if c.Siem.FlushIntervalMs > 0 {
+			SiemFlushInterval = time.Duration(c.Siem.FlushIntervalMs) * time.Millisecond
+		}
+
+		SmsAppKey = c.SMS.NHNCloud.AppKey
+		SmsSecretKey = c.SMS.NHNCloud.SecretKey
+		SmsSendNo = c.SMS.NHNCloud.SendNo
+
+		ShortLinkBaseURL = c.ShortLink.BaseURL
+		if c.QR.Targets != nil {
+			QRTargets = c.QR.Targets
+		}
+
+		for name, p := range c.Retry.Policies {
+			policy := RetryPolicies[name]
+			if p.MaxAttempts > 0 {
+				policy.MaxAttempts = p.MaxAttempts
+			}
+			if p.BaseDelayMs > 0 {
+				policy.BaseDelay = time.Duration(p.BaseDelayMs) * time.Millisecond
+			}
+			if p.MaxDelayMs > 0 {
+				policy.MaxDelay = time.Duration(p.MaxDelayMs) * time.Millisecond
+			}
+			RetryPolicies[name] = policy
+		}
+
+		for name, l := range c.Concurrency.Classes {
+			limit := ConcurrencyLimits[name]
+			if l.Max > 0 {
+				limit.Max = l.Max
+			}
+			if l.Queue != nil {
+				limit.Queue = *l.Queue
+			}
+			if l.WaitMs > 0 {
+				limit.Wait = time.Duration(l.WaitMs) * time.Millisecond
+			}
+			ConcurrencyLimits[name] = limit
+		}
+
+		KafkaRestProxy = c.Kafka.RestProxy
+		if c.Kafka.TopicPrefix != "" {
+			KafkaTopicPrefix = c.Kafka.TopicPrefix
+		}
+		if c.Kafka.Topics != nil {
+			KafkaTopics = c.Kafka.Topics
+		}
+
+		if c.Backup.Dir != "" {
+			BackupDir = c.Backup.Dir
+		}
+
+		SnapshotImportEnabled = c.Snapshot.ImportEnabled
+
+		if c.Retention.ArchivePrefix != "" {
+			RetentionArchivePrefix = c.Retention.ArchivePrefix
+		}
+		for table, days := range c.Retention.Days {
+			RetentionDays[table] = days
+		}
+
+		for key, db := range c.Tenants.Databases {
+			tenantDB := TenantDatabase{
+				User:     db.User,
+				Pass:     db.Pass,
+				Host:     db.Host,
+				Port:     db.Port,
+				Name:     db.Name,
+				MaxConns: 5,
+			}
+			if tenantDB.Port == 0 {
+				tenantDB.Port = 3306
+			}
+			if db.MaxConns > 0 {
+				tenantDB.MaxConns = db.MaxConns
+			}
+			TenantDatabases[key] = tenantDB
+		}
 	}
 }
@@ -9,9 +9,156 @@ var c struct {
 		Name string `json:"name"`
 	} `json:"db"`
 
+	Tenants struct {
+		Databases map[string]struct {
+			User     string `json:"user"`
+			Pass     string `json:"pass"`
+			Host     string `json:"host"`
+			Port     uint16 `json:"port"`
+			Name     string `json:"name"`
+			MaxConns int    `json:"max_conns"`
+		} `json:"databases"`
+	} `json:"tenants"`
+
 	IsDebug bool `json:"is_debug"`
 
+	Server struct {
+		RequestTimeoutMs    uint32 `json:"request_timeout_ms"`
+		Addr                string `json:"addr"`
+		ReadHeaderTimeoutMs uint32 `json:"read_header_timeout_ms"`
+		ReadTimeoutMs       uint32 `json:"read_timeout_ms"`
+		WriteTimeoutMs      uint32 `json:"write_timeout_ms"`
+		IdleTimeoutMs       uint32 `json:"idle_timeout_ms"`
+		ShutdownTimeoutMs   uint32 `json:"shutdown_timeout_ms"`
+	} `json:"server"`
+
+	Id struct {
+		Version int `json:"version"`
+	} `json:"id"`
+
+	Diagnostics struct {
+		PprofAddr string `json:"pprof_addr"`
+	} `json:"diagnostics"`
+
 	JWT struct {
 		Secret string `json:"secret"`
 	} `json:"jwt"`
+
+	Secrets struct {
+		Provider   string `json:"provider"`
+		RefreshSec uint32 `json:"refresh_sec"`
+		Vault      struct {
+			Addr  string `json:"addr"`
+			Mount string `json:"mount"`
+		} `json:"vault"`
+		AWS struct {
+			Region string `json:"region"`
+		} `json:"aws"`
+	} `json:"secrets"`
+
+	Credential struct {
+		MasterKey string `json:"master_key"`
+	} `json:"credential"`
+
+	Storage struct {
+		Driver      string `json:"driver"`
+		MaxUploadMB uint32 `json:"max_upload_mb"`
+		Local       struct {
+			Dir     string `json:"dir"`
+			BaseURL string `json:"base_url"`
+			SignKey string `json:"sign_key"`
+		} `json:"local"`
+		S3 struct {
+			Endpoint  string `json:"endpoint"`
+			Region    string `json:"region"`
+			Bucket    string `json:"bucket"`
+			PathStyle bool   `json:"path_style"`
+		} `json:"s3"`
+	} `json:"storage"`
+
+	Media struct {
+		FFmpegPath string `json:"ffmpeg_path"`
+	} `json:"media"`
+
+	YouTube struct {
+		ClientId     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		RedirectURL  string `json:"redirect_url"`
+		ReturnURL    string `json:"return_url"`
+	} `json:"youtube"`
+
+	ESign struct {
+		Modusign struct {
+			Email        string `json:"email"`
+			ApiKey       string `json:"api_key"`
+			TemplateId   string `json:"template_id"`
+			SignerRole   string `json:"signer_role"`
+			WebhookToken string `json:"webhook_token"`
+		} `json:"modusign"`
+	} `json:"esign"`
+
+	Email struct {
+		WebhookToken string `json:"webhook_token"`
+	} `json:"email"`
+
+	Siem struct {
+		Addr            string `json:"addr"`
+		URL             string `json:"url"`
+		Token           string `json:"token"`
+		Format          string `json:"format"`
+		BufferSize      int    `json:"buffer_size"`
+		BatchSize       int    `json:"batch_size"`
+		FlushIntervalMs uint32 `json:"flush_interval_ms"`
+	} `json:"siem"`
+
+	SMS struct {
+		NHNCloud struct {
+			AppKey    string `json:"app_key"`
+			SecretKey string `json:"secret_key"`
+			SendNo    string `json:"send_no"`
+		} `json:"nhn_cloud"`
+	} `json:"sms"`
+
+	ShortLink struct {
+		BaseURL string `json:"base_url"`
+	} `json:"short_link"`
+
+	QR struct {
+		Targets map[string][]string `json:"targets"`
+	} `json:"qr"`
+
+	Retry struct {
+		Policies map[string]struct {
+			MaxAttempts int    `json:"max_attempts"`
+			BaseDelayMs uint32 `json:"base_delay_ms"`
+			MaxDelayMs  uint32 `json:"max_delay_ms"`
+		} `json:"policies"`
+	} `json:"retry"`
+
+	Concurrency struct {
+		Classes map[string]struct {
+			Max    int    `json:"max"`
+			Queue  *int   `json:"queue"`
+			WaitMs uint32 `json:"wait_ms"`
+		} `json:"classes"`
+	} `json:"concurrency"`
+
+	Kafka struct {
+		RestProxy   string            `json:"rest_proxy"`
+		TopicPrefix string            `json:"topic_prefix"`
+		Topics      map[string]string `json:"topics"`
+	} `json:"kafka"`
+
+	Backup struct {
+		Dir string `json:"dir"`
+	} `json:"backup"`
+
+	Snapshot struct {
+		ImportEnabled bool `json:"import_enabled"`
+	} `json:"snapshot"`
+
+	Retention struct {
+		ArchivePrefix string            `json:"archive_prefix"`
+		Days          map[string]uint16 `json:"days"`
+	} `json:"retention"`
 }
@@ -0,0 +1,50 @@
+package di
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// apiKeyRateLimit API 키(범위를 줄인 토큰)로 들어온 요청만 키별로 세서 X-RateLimit-* 헤더를 붙이고 한도를 넘으면 429
+// 사용량 저장이 실패해도 요청은 그대로 처리
+func apiKeyRateLimit(useCase domain.ApiUsageUseCase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			principal, ok := echox.PrincipalOf(ctx)
+			if !ok || principal.KeyId == uuid.Nil {
+				return next(ctx)
+			}
+
+			keyId := principal.KeyId
+			limit, err := useCase.Hit(ctx.Request().Context(), keyId, principal.UserId)
+			if err != nil {
+				echox.Log(ctx).WithError(err).WithField("keyId", keyId).Error("api key usage hit failed")
+				return next(ctx)
+			}
+
+			header := ctx.Response().Header()
+			header.Set(headerRateLimitLimit, strconv.FormatInt(limit.Limit, 10))
+			header.Set(headerRateLimitRemaining, strconv.FormatInt(limit.Remaining, 10))
+			header.Set(headerRateLimitReset, strconv.FormatInt(limit.Reset.Unix(), 10))
+
+			if limit.Exceeded {
+				retryAfter := int64(time.Until(limit.Reset)/time.Second) + 1
+				header.Set(echo.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+				return ctx.JSON(http.StatusTooManyRequests, domain.TooManyRequestsResponse)
+			}
+			return next(ctx)
+		}
+	}
+}
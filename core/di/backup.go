@@ -0,0 +1,24 @@
+package di
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/backup/adapter"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewBackupAdapter(db *gorm.DB, store *secret.Store) domain.BackupAdapter {
+	return adapter.NewMysqlDumpAdapter(db, adapter.MysqlOption{
+		Host: config.DBHost,
+		Port: config.DBPort,
+		User: config.DBUser,
+		Pass: func(ctx context.Context) (string, error) {
+			return store.Resolve(ctx, config.DBPass)
+		},
+		Name: config.DBName,
+		Dir:  config.BackupDir,
+	})
+}
@@ -0,0 +1,24 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/channel/adapter"
+	"github.com/stockfolioofficial/back-editfolio/channel/handler"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewYouTubeClient 고객 채널 통계용, 클라이언트 비밀 값은 비밀 저장소 참조 가능
+func NewYouTubeClient(store *secret.Store) domain.YouTubeClient {
+	return adapter.NewYouTubeClient(adapter.YouTubeOption{
+		ClientId:     config.YouTubeClientId,
+		ClientSecret: resolveSecret(store, config.YouTubeClientSecret),
+		RedirectURL:  config.YouTubeRedirectURL,
+		Retry:        config.RetryPolicies["youtube"],
+	})
+}
+
+// NewChannelController 연결을 마친 뒤 돌려보낼 주소는 설정값
+func NewChannelController(useCase domain.ChannelUseCase) *handler.ChannelController {
+	return handler.NewChannelController(useCase, config.YouTubeReturnURL)
+}
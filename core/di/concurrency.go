@@ -0,0 +1,55 @@
+package di
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// routeClassOf DB 를 오래 붙잡는 라우트 분류, 빈 값이면 제한 없음
+func routeClassOf(method, route string) string {
+	switch {
+	case method == http.MethodGet && route == "/customer/:userId/snapshot":
+		return "export"
+	case strings.HasPrefix(route, "/dashboard/widget/"), route == "/dashboard/layout":
+		// 위젯은 인덱스로 세는 가벼운 조회, 화면에서 한 번에 여러 개를 부르므로 제한하지 않음
+		return ""
+	case strings.HasPrefix(route, "/dashboard/"):
+		return "report"
+	}
+	return ""
+}
+
+// concurrencyLimit 라우트 분류별 동시 실행 수 제한, 자리가 다 차면 잠깐 기다리고 그래도 없으면 503
+func concurrencyLimit(limits map[string]workerpool.Limit) echo.MiddlewareFunc {
+	limiters := make(map[string]*workerpool.Limiter, len(limits))
+	for class, limit := range limits {
+		limiters[class] = workerpool.NewLimiter(limit)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			class := routeClassOf(ctx.Request().Method, ctx.Path())
+			limiter, ok := limiters[class]
+			if !ok {
+				return next(ctx)
+			}
+
+			release, ok := limiter.Acquire(ctx.Request().Context())
+			if !ok {
+				log.WithField("class", class).WithField("route", ctx.Path()).Warn("concurrency limit reached")
+				retryAfter := int64(limits[class].Wait/time.Second) + 1
+				ctx.Response().Header().Set(echo.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+				return ctx.JSON(http.StatusServiceUnavailable, domain.ServerBusyResponse)
+			}
+			defer release()
+			return next(ctx)
+		}
+	}
+}
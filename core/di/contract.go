@@ -0,0 +1,25 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/contract/adapter"
+	"github.com/stockfolioofficial/back-editfolio/contract/handler"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewESignAdapter 기업 고객 계약서 전자 서명, API 키는 비밀 저장소 참조 가능
+func NewESignAdapter(store *secret.Store) domain.ESignAdapter {
+	return adapter.NewModusign(adapter.ModusignOption{
+		Email:      config.ModusignEmail,
+		ApiKey:     resolveSecret(store, config.ModusignApiKey),
+		TemplateId: config.ModusignTemplateId,
+		SignerRole: config.ModusignSignerRole,
+		Retry:      config.RetryPolicies["modusign"],
+	})
+}
+
+// NewContractController webhook 토큰은 설정값, 비밀 저장소 참조 가능
+func NewContractController(useCase domain.ContractUseCase, store *secret.Store) *handler.ContractController {
+	return handler.NewContractController(useCase, resolveSecret(store, config.ModusignWebhookToken))
+}
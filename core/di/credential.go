@@ -0,0 +1,40 @@
+package di
+
+import (
+	"context"
+	"encoding/base64"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/cipher"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewCredentialCipher 테넌트 자격 증명 암호화 키(base64, 32 bytes), 비밀 저장소 참조 가능
+// 키가 없으면 자격 증명 저장/조회만 실패하고 서버는 뜸
+func NewCredentialCipher(store *secret.Store) domain.CredentialCipher {
+	if config.CredentialMasterKey == "" {
+		log.Warn("credential master key not configured, tenant credentials disabled")
+		return cipher.Missing
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+
+	encoded, err := store.Resolve(ctx, config.CredentialMasterKey)
+	if err != nil {
+		panic(err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := cipher.NewAESGCM(key)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
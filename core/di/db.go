@@ -1,23 +1,102 @@
 package di
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
-func NewDatabase() (db *gorm.DB) {
+// mysqlAccessDenied 비밀번호가 교체돼 캐시된 값이 틀렸을 때 받는 에러 번호
+const mysqlAccessDenied = 1045
+
+// tenantConnMaxIdleTime 요청이 뜸한 테넌트는 연결을 오래 잡고 있지 않음
+const tenantConnMaxIdleTime = 5 * time.Minute
+
+// NewTenantRouter 기본 DB 와 테넌트 DB 연결 풀, 테넌트가 없으면 기본 DB 만 사용
+func NewTenantRouter(store *secret.Store) *tenant.Router {
+	var fallback *sql.DB
+	if secret.IsRef(config.DBPass) {
+		fallback = openWithSecretPass(store, config.DBConnWithPass(""), config.DBPass)
+	} else {
+		fallback = openConn(config.DBConn)
+	}
+	fallback.SetMaxIdleConns(15)
+	fallback.SetMaxOpenConns(15)
+
+	pools := make(map[string]*sql.DB, len(config.TenantDatabases))
+	for key, db := range config.TenantDatabases {
+		if !domain.IsTenantKey(key) {
+			panic(fmt.Errorf("tenant %s: invalid tenant key", key))
+		}
+
+		var pool *sql.DB
+		if secret.IsRef(db.Pass) {
+			pool = openWithSecretPass(store, db.Conn(""), db.Pass)
+		} else {
+			pool = openConn(db.Conn(db.Pass))
+		}
+
+		err := pool.Ping()
+		if err != nil {
+			panic(fmt.Errorf("tenant %s: %w", key, err))
+		}
+
+		pool.SetMaxIdleConns(db.MaxConns)
+		pool.SetMaxOpenConns(db.MaxConns)
+		pool.SetConnMaxIdleTime(tenantConnMaxIdleTime)
+		pools[key] = pool
+	}
+	return tenant.NewRouter(fallback, pools)
+}
+
+func openConn(dsn string) *sql.DB {
+	cfg, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		panic(err)
+	}
+
+	connector, err := mysqldriver.NewConnector(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return sql.OpenDB(connector)
+}
+
+// NewDatabase 레포지토리는 이 DB 하나만 사용, 요청 context 의 테넌트로 router 가 연결 풀을 고름
+func NewDatabase(router *tenant.Router) (db *gorm.DB) {
 	var logLevel = logger.Info
 
 	if !config.IsDebug {
 		logLevel = logger.Warn
 	}
 
-	db, err := gorm.Open(mysql.Open(config.DBConn), &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
+	var base = mysql.New(mysql.Config{Conn: router})
+
+	dialector := gormx.SafeMigrate(base, gormx.MigrateOption{
+		AllowDestructive: config.MigrateAllowDestructive,
+		DryRun:           config.MigrateDryRun,
+		Out:              os.Stdout,
+		OnRefused: func(err error) {
+			panic(err)
+		},
+	})
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:                                   logger.Default.LogMode(logLevel),
 		DisableForeignKeyConstraintWhenMigrating: true,
 	})
 	if err != nil {
@@ -33,8 +112,66 @@ func NewDatabase() (db *gorm.DB) {
 	if err != nil {
 		panic(err)
 	}
-
-	sqlDB.SetMaxIdleConns(15)
-	sqlDB.SetMaxOpenConns(15)
 	return
-}
\ No newline at end of file
+}
+
+// migrateTenants 레포지토리 생성 때 기본 DB 에 실행한 스키마 변경, 초기 데이터를 테넌트 DB 마다 실행
+func migrateTenants(db *gorm.DB, router *tenant.Router) error {
+	for _, key := range router.Keys() {
+		ctx, err := router.With(context.Background(), key)
+		if err != nil {
+			return err
+		}
+
+		err = gormx.ReplayMigrations(db.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("tenant %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// openWithSecretPass 새 연결마다 저장소 캐시에서 비밀번호(ref)를 읽음, 접속 거부면 캐시를 비우고 한 번 더 시도
+func openWithSecretPass(store *secret.Store, dsn, ref string) *sql.DB {
+	cfg, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		panic(err)
+	}
+	return sql.OpenDB(&secretPassConnector{store: store, cfg: cfg, ref: ref})
+}
+
+type secretPassConnector struct {
+	store *secret.Store
+	cfg   *mysqldriver.Config
+	ref   string
+}
+
+func (c *secretPassConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connect(ctx)
+
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlAccessDenied {
+		c.store.Invalidate(c.ref)
+		conn, err = c.connect(ctx)
+	}
+	return conn, err
+}
+
+func (c *secretPassConnector) connect(ctx context.Context) (driver.Conn, error) {
+	pass, err := c.store.Resolve(ctx, c.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := c.cfg.Clone()
+	cfg.Passwd = pass
+	connector, err := mysqldriver.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (c *secretPassConnector) Driver() driver.Driver {
+	return mysqldriver.MySQLDriver{}
+}
@@ -0,0 +1,16 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	repository24 "github.com/stockfolioofficial/back-editfolio/hook/repository"
+	repository12 "github.com/stockfolioofficial/back-editfolio/outbox/repository"
+	"gorm.io/gorm"
+)
+
+// NewDeadLetterRepositories 보내지 못한 메시지 출처 등록, 테이블은 각 모듈 레포지토리가 만듦
+func NewDeadLetterRepositories(db *gorm.DB) domain.DeadLetterRepositories {
+	return domain.DeadLetterRepositories{
+		domain.DeadLetterKindOutbox: repository12.NewOutboxDeadLetterRepository(db),
+		domain.DeadLetterKindHook:   repository24.NewHookDeadLetterRepository(db),
+	}
+}
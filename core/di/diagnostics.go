@@ -0,0 +1,35 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+// NewDiagnosticsController 하위 시스템 상태 점검을 등록, DB 점검은 컨트롤러가 직접 등록
+func NewDiagnosticsController(db *gorm.DB, outboxUseCase domain.OutboxUseCase) *diagnostics.DiagnosticsController {
+	diagnostics.RegisterProbe("outbox", func(ctx context.Context) diagnostics.ProbeResult {
+		backlog, err := outboxUseCase.GetBacklog(ctx)
+		if err != nil {
+			return diagnostics.ProbeDown(err)
+		}
+
+		status := diagnostics.ProbeStatusUp
+		if backlog.Dead > 0 || backlog.IsStale(time.Now()) {
+			status = diagnostics.ProbeStatusDegraded
+		}
+		return diagnostics.ProbeResult{
+			Status: status,
+			Detail: map[string]interface{}{
+				"pending":         backlog.Pending,
+				"dead":            backlog.Dead,
+				"oldestPendingAt": backlog.OldestPendingAt,
+			},
+		}
+	})
+
+	return diagnostics.NewDiagnosticsController(db)
+}
@@ -1,9 +1,20 @@
 package di
 
 import (
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	auth "github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
 )
 
 type echoBindWithValidate struct {
@@ -35,18 +46,123 @@ func NewEcho() (e *echo.Echo) {
 	e = echo.New()
 	e.Binder = &echoBindWithValidate{}
 	e.Validator = &echoValidator{v: newValidator()}
+	e.JSONSerializer = echox.JSONSerializer{FieldPolicy: fieldPolicy}
 	return
 }
 
+func fieldPolicy(resource, role string) ([]string, bool) {
+	return domain.AllowedFields(resource, domain.UserRole(role))
+}
+
 type middlewares []echo.MiddlewareFunc
 
-func NewMiddleware() (m middlewares) {
+func NewMiddleware(
+	router *tenant.Router,
+	tokenParser domain.TokenParseAdapter,
+	tokenVersions domain.TokenVersionReader,
+	shadowUseCase domain.ShadowUseCase,
+	apiUsageUseCase domain.ApiUsageUseCase,
+) (m middlewares) {
 	m = append(m, middleware.CORSWithConfig(middleware.CORSConfig{
 		// todo debug 추후 production 모드일때 스크립트 형태로 외부에서 주입 받는 기능 추가 필요
 		AllowOrigins: []string{"*"},
 		AllowHeaders: []string{"*"},
 		AllowMethods: []string{"*"},
+		// 로컬 저장소 멀티파트 조각 응답의 ETag 를 브라우저에서 읽을 수 있게
+		ExposeHeaders: []string{"ETag"},
 	}))
 	m = append(m, middleware.Recover())
+	m = append(m, echox.RequestLogger())
+	m = append(m, echox.Compress(compressThreshold))
+	m = append(m, echox.UUIDParams(uuidParamNames...))
+	m = append(m, tenantScope(router))
+	m = append(m, auth.Authenticate(tokenParser, tokenVersions))
+	m = append(m, echox.LogPrincipal())
+	m = append(m, tokenScope())
+	m = append(m, apiKeyRateLimit(apiUsageUseCase))
+	m = append(m, concurrencyLimit(config.ConcurrencyLimits))
+	m = append(m, requestBudget(config.RequestTimeout))
+	m = append(m, shadowRecorder(shadowUseCase))
+	m = append(m, diagnostics.JobRunRecorder())
 	return
 }
+
+// compressThreshold 이보다 작은 응답은 압축하지 않음
+const compressThreshold = 1024
+
+// uuidParamNames UUID 여야 하는 경로 파라미터, 새 UUID 파라미터를 추가하면 여기도 추가
+var uuidParamNames = []string{
+	"userId",
+	"orderId",
+	"issueId",
+	"backupId",
+	"messageId",
+	"recordId",
+	"ruleId",
+	"viewId",
+	"fileId",
+	"uploadId",
+	"integrationId",
+	"hookId",
+	"letterId",
+	"snapshotId",
+	"reportId",
+	"termsId",
+	"contractId",
+	"leadId",
+	"taskId",
+}
+
+// tenantScope X-Tenant-Key 헤더의 테넌트 DB 를 쓰도록 context 에 넣음, 등록되지 않은 테넌트면 400
+// 토큰의 테넌트와 맞는지는 auth.Authenticate 에서 확인
+func tenantScope(router *tenant.Router) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			c, err := router.With(req.Context(), req.Header.Get(tenant.HeaderKey))
+			if err != nil {
+				return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+			}
+
+			ctx.SetRequest(req.WithContext(c))
+			return next(ctx)
+		}
+	}
+}
+
+// tokenScope 범위를 줄인 토큰이면 범위 밖 요청은 403
+func tokenScope() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			principal, _ := echox.PrincipalOf(ctx)
+			scopes := domain.ParseTokenScopes(principal.Scopes)
+			if scopes != nil && !domain.ScopesAllow(scopes, req.Method, req.URL.Path) {
+				return ctx.JSON(http.StatusForbidden, domain.OutOfScopeResponse)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// budgetSkipPrefixes 작업 트리거, 백업, 파일 전송처럼 오래 걸리는 요청은 유스케이스 timeout 만 적용
+var budgetSkipPrefixes = []string{"/internal/", "/backup", "/blob/", "/file"}
+
+// requestBudget 요청 전체 deadline 설정, 유스케이스/어댑터는 budget.Slice 로 남은 시간만 사용
+func requestBudget(total time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			path := ctx.Request().URL.Path
+			for _, prefix := range budgetSkipPrefixes {
+				if strings.HasPrefix(path, prefix) {
+					return next(ctx)
+				}
+			}
+
+			c, cancel := budget.WithBudget(ctx.Request().Context(), total)
+			defer cancel()
+			ctx.SetRequest(ctx.Request().WithContext(c))
+			return next(ctx)
+		}
+	}
+}
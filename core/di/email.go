@@ -0,0 +1,13 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/email/handler"
+)
+
+// NewEmailController webhook 토큰은 설정값, 비밀 저장소 참조 가능
+func NewEmailController(useCase domain.EmailUseCase, store *secret.Store) *handler.EmailController {
+	return handler.NewEmailController(useCase, resolveSecret(store, config.EmailWebhookToken))
+}
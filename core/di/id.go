@@ -0,0 +1,12 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/idgen"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewIdGenerator 설정된 UUID 버전의 발급기
+func NewIdGenerator() domain.IdGenerator {
+	return idgen.New(config.IdVersion)
+}
@@ -0,0 +1,45 @@
+package di
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	handler25 "github.com/stockfolioofficial/back-editfolio/hook/handler"
+	handler24 "github.com/stockfolioofficial/back-editfolio/integration/handler"
+	handler42 "github.com/stockfolioofficial/back-editfolio/notification/handler"
+	handler5 "github.com/stockfolioofficial/back-editfolio/orderTicket/handler"
+)
+
+// NewInboxHandlers 수신 메시지 토픽별 처리기 등록
+func NewInboxHandlers(
+	orderTicket domain.OrderTicketUseCase,
+	integration domain.IntegrationUseCase,
+	hook domain.HookUseCase,
+	notification domain.NotificationUseCase,
+) domain.InboxHandlers {
+	return domain.InboxHandlers{
+		domain.InboxTopicPaymentSettled: chainInbox(
+			handler5.NewPaymentSettledInboxHandler(orderTicket),
+			handler25.NewPaymentSettledHookInboxHandler(hook),
+		),
+		domain.InboxTopicOrderEvent: chainInbox(
+			handler24.NewOrderEventInboxHandler(integration),
+			handler25.NewEventHookInboxHandler(hook),
+			handler42.NewOrderEventNotificationInboxHandler(notification),
+		),
+		domain.InboxTopicUserEvent: handler25.NewEventHookInboxHandler(hook),
+	}
+}
+
+// chainInbox 한 토픽을 여러 처리기가 받음, 하나가 실패하면 메시지 전체를 다시 처리하므로 모든 처리기가 다시 처리해도 결과가 같아야 함
+func chainInbox(handlers ...domain.InboxHandler) domain.InboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		for _, handler := range handlers {
+			err := handler(ctx, payload)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
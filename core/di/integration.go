@@ -0,0 +1,15 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/integration/adapter"
+)
+
+// NewIntegrationExporters 의뢰 현황 내보내기 서비스 등록, 자격 증명은 설정마다 따로 저장
+func NewIntegrationExporters() domain.IntegrationExporters {
+	return domain.IntegrationExporters{
+		domain.IntegrationProviderGoogleSheets: adapter.NewGoogleSheetsExporter(config.RetryPolicies["google_sheets"]),
+		domain.IntegrationProviderNotion:       adapter.NewNotionExporter(config.RetryPolicies["notion"]),
+	}
+}
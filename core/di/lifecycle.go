@@ -1,26 +1,120 @@
 package di
 
 import (
+	"context"
+	"time"
+
 	"github.com/labstack/echo/v4"
 	log "github.com/sirupsen/logrus"
+	handler10 "github.com/stockfolioofficial/back-editfolio/analytics/handler"
+	handler30 "github.com/stockfolioofficial/back-editfolio/apiUsage/handler"
+	handler40 "github.com/stockfolioofficial/back-editfolio/auditLog/handler"
+	handler41 "github.com/stockfolioofficial/back-editfolio/availability/handler"
+	handler14 "github.com/stockfolioofficial/back-editfolio/backup/handler"
+	handler32 "github.com/stockfolioofficial/back-editfolio/billing/handler"
+	handler23 "github.com/stockfolioofficial/back-editfolio/channel/handler"
+	handler34 "github.com/stockfolioofficial/back-editfolio/contract/handler"
 	"github.com/stockfolioofficial/back-editfolio/core/app"
+	"github.com/stockfolioofficial/back-editfolio/core/blob"
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
 	"github.com/stockfolioofficial/back-editfolio/core/config"
 	"github.com/stockfolioofficial/back-editfolio/core/di/scope"
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/core/health"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	handler7 "github.com/stockfolioofficial/back-editfolio/credit/handler"
+	handler16 "github.com/stockfolioofficial/back-editfolio/customField/handler"
+	handler37 "github.com/stockfolioofficial/back-editfolio/dashboard/handler"
+	handler28 "github.com/stockfolioofficial/back-editfolio/deadLetter/handler"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	handler43 "github.com/stockfolioofficial/back-editfolio/email/handler"
+	handler9 "github.com/stockfolioofficial/back-editfolio/experiment/handler"
+	handler22 "github.com/stockfolioofficial/back-editfolio/file/handler"
+	handler29 "github.com/stockfolioofficial/back-editfolio/financeSnapshot/handler"
 	"github.com/stockfolioofficial/back-editfolio/helloworld/handler"
+	handler25 "github.com/stockfolioofficial/back-editfolio/hook/handler"
+	handler12 "github.com/stockfolioofficial/back-editfolio/inbox/handler"
+	handler24 "github.com/stockfolioofficial/back-editfolio/integration/handler"
+	handler6 "github.com/stockfolioofficial/back-editfolio/issue/handler"
+	handler35 "github.com/stockfolioofficial/back-editfolio/lead/handler"
+	handler42 "github.com/stockfolioofficial/back-editfolio/notification/handler"
+	handler39 "github.com/stockfolioofficial/back-editfolio/opsAlert/handler"
 	handler3 "github.com/stockfolioofficial/back-editfolio/order/handler"
 	handler4 "github.com/stockfolioofficial/back-editfolio/orderState/handler"
 	handler5 "github.com/stockfolioofficial/back-editfolio/orderTicket/handler"
+	handler11 "github.com/stockfolioofficial/back-editfolio/outbox/handler"
+	handler27 "github.com/stockfolioofficial/back-editfolio/qrCode/handler"
+	handler20 "github.com/stockfolioofficial/back-editfolio/recycleBin/handler"
+	handler8 "github.com/stockfolioofficial/back-editfolio/referral/handler"
+	handler31 "github.com/stockfolioofficial/back-editfolio/report/handler"
+	handler13 "github.com/stockfolioofficial/back-editfolio/retention/handler"
+	handler18 "github.com/stockfolioofficial/back-editfolio/savedView/handler"
+	handler38 "github.com/stockfolioofficial/back-editfolio/search/handler"
+	handler15 "github.com/stockfolioofficial/back-editfolio/setting/handler"
+	handler19 "github.com/stockfolioofficial/back-editfolio/shadow/handler"
+	handler26 "github.com/stockfolioofficial/back-editfolio/shortLink/handler"
+	handler17 "github.com/stockfolioofficial/back-editfolio/snapshot/handler"
+	handler44 "github.com/stockfolioofficial/back-editfolio/subscription/handler"
+	handler36 "github.com/stockfolioofficial/back-editfolio/task/handler"
+	handler21 "github.com/stockfolioofficial/back-editfolio/tenantCredential/handler"
+	handler33 "github.com/stockfolioofficial/back-editfolio/terms/handler"
 	handler2 "github.com/stockfolioofficial/back-editfolio/user/handler"
+	"gorm.io/gorm"
 )
 
 func OnStart(
 	e *echo.Echo,
+	db *gorm.DB,
+	router *tenant.Router,
 	mw middlewares,
 	helloWorld *handler.HelloWorldController,
 	user *handler2.UserController,
 	order *handler3.OrderController,
 	orderState *handler4.OrderStateController,
 	orderTicket *handler5.OrderTicketController,
+	issue *handler6.IssueController,
+	credit *handler7.CreditController,
+	referral *handler8.ReferralController,
+	experiment *handler9.ExperimentController,
+	analytics *handler10.AnalyticsController,
+	outbox *handler11.OutboxController,
+	inbox *handler12.InboxController,
+	retention *handler13.RetentionController,
+	backup *handler14.BackupController,
+	diagnosticsCtrl *diagnostics.DiagnosticsController,
+	cacheCtrl *cache.CacheController,
+	blobCtrl *blob.BlobController,
+	healthCtrl *health.HealthController,
+	setting *handler15.SettingController,
+	customField *handler16.CustomFieldController,
+	snapshot *handler17.CustomerSnapshotController,
+	savedView *handler18.SavedViewController,
+	shadow *handler19.ShadowController,
+	recycleBin *handler20.RecycleBinController,
+	tenantCredential *handler21.TenantCredentialController,
+	file *handler22.FileController,
+	channel *handler23.ChannelController,
+	integration *handler24.IntegrationController,
+	hook *handler25.HookController,
+	shortLink *handler26.ShortLinkController,
+	qrCode *handler27.QRCodeController,
+	deadLetter *handler28.DeadLetterController,
+	financeSnapshotController *handler29.FinanceSnapshotController,
+	apiUsage *handler30.ApiUsageController,
+	report *handler31.ReportController,
+	billingController *handler32.BillingController,
+	termsController *handler33.TermsController,
+	contractController *handler34.ContractController,
+	leadController *handler35.LeadController,
+	taskController *handler36.TaskController,
+	dashboardController *handler37.DashboardController,
+	searchController *handler38.SearchController,
+	opsAlertController *handler39.OpsAlertController,
+	auditLogController *handler40.AuditLogController,
+	availabilityController *handler41.ManagerAvailabilityController,
+	notificationController *handler42.NotificationController,
+	emailController *handler43.EmailController,
+	subscriptionController *handler44.SubscriptionController,
 ) app.OnStart {
 	return func() error {
 		logLevel := log.ErrorLevel
@@ -29,6 +123,16 @@ func OnStart(
 		}
 		log.SetLevel(logLevel)
 
+		if config.PprofAddr != "" {
+			diagnostics.ServePprof(config.PprofAddr)
+		}
+
+		// 모든 레포지토리가 기본 DB 에 스키마를 맞춘 뒤
+		err := migrateTenants(db, router)
+		if err != nil {
+			return err
+		}
+
 		// global middleware set
 		e.Use(mw...)
 
@@ -40,6 +144,49 @@ func OnStart(
 			order,
 			orderState,
 			orderTicket,
+			issue,
+			credit,
+			referral,
+			experiment,
+			analytics,
+			outbox,
+			inbox,
+			retention,
+			backup,
+			diagnosticsCtrl,
+			cacheCtrl,
+			blobCtrl,
+			healthCtrl,
+			setting,
+			customField,
+			snapshot,
+			savedView,
+			shadow,
+			recycleBin,
+			tenantCredential,
+			file,
+			channel,
+			integration,
+			hook,
+			shortLink,
+			qrCode,
+			deadLetter,
+			financeSnapshotController,
+			apiUsage,
+			report,
+			billingController,
+			termsController,
+			contractController,
+			leadController,
+			taskController,
+			dashboardController,
+			searchController,
+			opsAlertController,
+			auditLogController,
+			availabilityController,
+			notificationController,
+			emailController,
+			subscriptionController,
 		)
 		return nil
 	}
@@ -51,8 +198,22 @@ func bindEcho(e *echo.Echo, binders ...scope.EchoBinder) {
 	}
 }
 
-func OnClose() app.OnClose {
+// auditExportCloseTimeout 종료할 때 버퍼에 남은 감사 로그를 보내는 시간
+const auditExportCloseTimeout = 10 * time.Second
+
+func OnClose(router *tenant.Router, auditExporter domain.AuditExporter) app.OnClose {
 	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), auditExportCloseTimeout)
+		defer cancel()
+
+		err := auditExporter.Close(ctx)
+		if err != nil {
+			log.WithError(err).Warn("audit export flush failed")
+		}
 
+		err = router.Close()
+		if err != nil {
+			log.WithError(err).Warn("tenant database close failed")
+		}
 	}
 }
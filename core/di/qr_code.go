@@ -0,0 +1,26 @@
+package di
+
+import (
+	"strings"
+
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewQRTargets 설정에 있는 종류만 허용, 짧은 주소 기본 주소가 있으면 짧은 주소도 허용
+func NewQRTargets() domain.QRTargets {
+	targets := make(domain.QRTargets)
+	for targetType, prefixes := range config.QRTargets {
+		switch t := domain.QRTargetType(targetType); t {
+		case domain.QRTargetTypePayment, domain.QRTargetTypeOnboarding:
+			targets[t] = prefixes
+		}
+	}
+
+	if config.ShortLinkBaseURL != "" {
+		targets[domain.QRTargetTypeShortLink] = []string{
+			strings.TrimSuffix(config.ShortLinkBaseURL, "/") + domain.ShortLinkPath,
+		}
+	}
+	return targets
+}
@@ -0,0 +1,21 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/retention/adapter"
+)
+
+// NewRetentionPolicies 테이블별 보관 일수 설정
+func NewRetentionPolicies() domain.RetentionPolicies {
+	policies := make(domain.RetentionPolicies, len(config.RetentionDays))
+	for table, days := range config.RetentionDays {
+		policies[table] = days
+	}
+	return policies
+}
+
+// NewRetentionArchiver 삭제 전 행을 설정된 파일 저장소에 보관
+func NewRetentionArchiver(storage domain.BlobStorage) domain.RetentionArchiver {
+	return adapter.NewBlobArchiver(storage, config.RetentionArchivePrefix)
+}
@@ -0,0 +1,67 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/user/adapter"
+)
+
+// secretResolveTimeout 비밀 저장소 조회 제한 시간, 캐시가 만료됐을 때만 호출됨
+const secretResolveTimeout = 10 * time.Second
+
+// NewSecretStore 설정된 비밀 저장소, 없으면 참조가 아닌 값만 사용 가능
+func NewSecretStore() *secret.Store {
+	var provider secret.Provider
+	switch config.SecretProvider {
+	case "vault":
+		provider = secret.NewVault(config.VaultAddr, config.VaultToken, config.VaultMount)
+	case "aws":
+		provider = secret.NewAWSSecretsManager(config.AWSRegion)
+	case "":
+	default:
+		log.WithField("provider", config.SecretProvider).Fatal("unknown secret provider")
+	}
+	return secret.NewStore(provider, config.SecretRefresh)
+}
+
+// NewTokenGenerateAdapter 서명 키를 발급 때마다 저장소 캐시에서 읽으므로 키 교체 후 재시작 없이 반영
+func NewTokenGenerateAdapter(store *secret.Store, clock domain.Clock) domain.TokenGenerateAdapter {
+	return adapter.NewTokenGenerateAdapter(jwtSecret(store), clock)
+}
+
+// NewTokenParseAdapter 발급과 같은 키로 검증, 키 교체 후 재시작 없이 반영
+func NewTokenParseAdapter(store *secret.Store, clock domain.Clock) domain.TokenParseAdapter {
+	return adapter.NewTokenParseAdapter(jwtSecret(store), clock)
+}
+
+// jwtSecret 발급, 검증에 같은 키를 쓰도록 한 곳에서 읽음
+func jwtSecret(store *secret.Store) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+		defer cancel()
+
+		key, err := store.Resolve(ctx, config.JWTSecret)
+		return []byte(key), err
+	}
+}
+
+// resolveSecret 비어있으면 그대로, 못 읽으면 서버를 띄우지 않음
+func resolveSecret(store *secret.Store, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+
+	resolved, err := store.Resolve(ctx, ref)
+	if err != nil {
+		panic(err)
+	}
+	return resolved
+}
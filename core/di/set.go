@@ -2,12 +2,81 @@ package di
 
 import (
 	"github.com/google/wire"
+	handler10 "github.com/stockfolioofficial/back-editfolio/analytics/handler"
+	repository11 "github.com/stockfolioofficial/back-editfolio/analytics/repository"
+	usecase9 "github.com/stockfolioofficial/back-editfolio/analytics/usecase"
+	handler30 "github.com/stockfolioofficial/back-editfolio/apiUsage/handler"
+	repository27 "github.com/stockfolioofficial/back-editfolio/apiUsage/repository"
+	usecase28 "github.com/stockfolioofficial/back-editfolio/apiUsage/usecase"
+	handler40 "github.com/stockfolioofficial/back-editfolio/auditLog/handler"
+	repository36 "github.com/stockfolioofficial/back-editfolio/auditLog/repository"
+	usecase38 "github.com/stockfolioofficial/back-editfolio/auditLog/usecase"
+	handler41 "github.com/stockfolioofficial/back-editfolio/availability/handler"
+	repository37 "github.com/stockfolioofficial/back-editfolio/availability/repository"
+	usecase39 "github.com/stockfolioofficial/back-editfolio/availability/usecase"
+	handler14 "github.com/stockfolioofficial/back-editfolio/backup/handler"
+	repository15 "github.com/stockfolioofficial/back-editfolio/backup/repository"
+	usecase13 "github.com/stockfolioofficial/back-editfolio/backup/usecase"
+	handler32 "github.com/stockfolioofficial/back-editfolio/billing/handler"
+	usecase30 "github.com/stockfolioofficial/back-editfolio/billing/usecase"
+	repository22 "github.com/stockfolioofficial/back-editfolio/channel/repository"
+	usecase21 "github.com/stockfolioofficial/back-editfolio/channel/usecase"
+	repository30 "github.com/stockfolioofficial/back-editfolio/contract/repository"
+	usecase32 "github.com/stockfolioofficial/back-editfolio/contract/usecase"
 	"github.com/stockfolioofficial/back-editfolio/core/app"
+	"github.com/stockfolioofficial/back-editfolio/core/blob"
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/core/calendar"
+	"github.com/stockfolioofficial/back-editfolio/core/clock"
 	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/health"
+	handler7 "github.com/stockfolioofficial/back-editfolio/credit/handler"
+	repository8 "github.com/stockfolioofficial/back-editfolio/credit/repository"
+	usecase6 "github.com/stockfolioofficial/back-editfolio/credit/usecase"
+	handler16 "github.com/stockfolioofficial/back-editfolio/customField/handler"
+	repository17 "github.com/stockfolioofficial/back-editfolio/customField/repository"
+	usecase15 "github.com/stockfolioofficial/back-editfolio/customField/usecase"
 	repository3 "github.com/stockfolioofficial/back-editfolio/customer/repository"
+	handler37 "github.com/stockfolioofficial/back-editfolio/dashboard/handler"
+	repository33 "github.com/stockfolioofficial/back-editfolio/dashboard/repository"
+	usecase35 "github.com/stockfolioofficial/back-editfolio/dashboard/usecase"
+	handler28 "github.com/stockfolioofficial/back-editfolio/deadLetter/handler"
+	usecase26 "github.com/stockfolioofficial/back-editfolio/deadLetter/usecase"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	repository39 "github.com/stockfolioofficial/back-editfolio/email/repository"
+	usecase41 "github.com/stockfolioofficial/back-editfolio/email/usecase"
+	handler9 "github.com/stockfolioofficial/back-editfolio/experiment/handler"
+	repository10 "github.com/stockfolioofficial/back-editfolio/experiment/repository"
+	usecase8 "github.com/stockfolioofficial/back-editfolio/experiment/usecase"
+	repository21 "github.com/stockfolioofficial/back-editfolio/file/repository"
+	usecase20 "github.com/stockfolioofficial/back-editfolio/file/usecase"
+	handler29 "github.com/stockfolioofficial/back-editfolio/financeSnapshot/handler"
+	repository26 "github.com/stockfolioofficial/back-editfolio/financeSnapshot/repository"
+	usecase27 "github.com/stockfolioofficial/back-editfolio/financeSnapshot/usecase"
 	"github.com/stockfolioofficial/back-editfolio/helloworld/handler"
+	adapter4 "github.com/stockfolioofficial/back-editfolio/hook/adapter"
+	handler25 "github.com/stockfolioofficial/back-editfolio/hook/handler"
+	repository24 "github.com/stockfolioofficial/back-editfolio/hook/repository"
+	usecase23 "github.com/stockfolioofficial/back-editfolio/hook/usecase"
+	handler12 "github.com/stockfolioofficial/back-editfolio/inbox/handler"
+	repository13 "github.com/stockfolioofficial/back-editfolio/inbox/repository"
+	usecase11 "github.com/stockfolioofficial/back-editfolio/inbox/usecase"
+	handler24 "github.com/stockfolioofficial/back-editfolio/integration/handler"
+	repository23 "github.com/stockfolioofficial/back-editfolio/integration/repository"
+	usecase22 "github.com/stockfolioofficial/back-editfolio/integration/usecase"
+	handler6 "github.com/stockfolioofficial/back-editfolio/issue/handler"
+	repository7 "github.com/stockfolioofficial/back-editfolio/issue/repository"
+	usecase5 "github.com/stockfolioofficial/back-editfolio/issue/usecase"
+	handler35 "github.com/stockfolioofficial/back-editfolio/lead/handler"
+	repository31 "github.com/stockfolioofficial/back-editfolio/lead/repository"
+	usecase33 "github.com/stockfolioofficial/back-editfolio/lead/usecase"
 	repository2 "github.com/stockfolioofficial/back-editfolio/manager/repository"
+	handler42 "github.com/stockfolioofficial/back-editfolio/notification/handler"
+	repository38 "github.com/stockfolioofficial/back-editfolio/notification/repository"
+	usecase40 "github.com/stockfolioofficial/back-editfolio/notification/usecase"
+	handler39 "github.com/stockfolioofficial/back-editfolio/opsAlert/handler"
+	repository35 "github.com/stockfolioofficial/back-editfolio/opsAlert/repository"
+	usecase37 "github.com/stockfolioofficial/back-editfolio/opsAlert/usecase"
 	handler3 "github.com/stockfolioofficial/back-editfolio/order/handler"
 	repository4 "github.com/stockfolioofficial/back-editfolio/order/repository"
 	usecase2 "github.com/stockfolioofficial/back-editfolio/order/usecase"
@@ -17,7 +86,52 @@ import (
 	handler5 "github.com/stockfolioofficial/back-editfolio/orderTicket/handler"
 	repository6 "github.com/stockfolioofficial/back-editfolio/orderTicket/repository"
 	usecase4 "github.com/stockfolioofficial/back-editfolio/orderTicket/usecase"
-	"github.com/stockfolioofficial/back-editfolio/user/adapter"
+	adapter2 "github.com/stockfolioofficial/back-editfolio/outbox/adapter"
+	handler11 "github.com/stockfolioofficial/back-editfolio/outbox/handler"
+	repository12 "github.com/stockfolioofficial/back-editfolio/outbox/repository"
+	usecase10 "github.com/stockfolioofficial/back-editfolio/outbox/usecase"
+	adapter5 "github.com/stockfolioofficial/back-editfolio/qrCode/adapter"
+	handler27 "github.com/stockfolioofficial/back-editfolio/qrCode/handler"
+	usecase25 "github.com/stockfolioofficial/back-editfolio/qrCode/usecase"
+	handler20 "github.com/stockfolioofficial/back-editfolio/recycleBin/handler"
+	usecase18 "github.com/stockfolioofficial/back-editfolio/recycleBin/usecase"
+	handler8 "github.com/stockfolioofficial/back-editfolio/referral/handler"
+	repository9 "github.com/stockfolioofficial/back-editfolio/referral/repository"
+	usecase7 "github.com/stockfolioofficial/back-editfolio/referral/usecase"
+	adapter6 "github.com/stockfolioofficial/back-editfolio/report/adapter"
+	handler31 "github.com/stockfolioofficial/back-editfolio/report/handler"
+	repository28 "github.com/stockfolioofficial/back-editfolio/report/repository"
+	usecase29 "github.com/stockfolioofficial/back-editfolio/report/usecase"
+	handler13 "github.com/stockfolioofficial/back-editfolio/retention/handler"
+	repository14 "github.com/stockfolioofficial/back-editfolio/retention/repository"
+	usecase12 "github.com/stockfolioofficial/back-editfolio/retention/usecase"
+	handler18 "github.com/stockfolioofficial/back-editfolio/savedView/handler"
+	repository18 "github.com/stockfolioofficial/back-editfolio/savedView/repository"
+	usecase16 "github.com/stockfolioofficial/back-editfolio/savedView/usecase"
+	handler38 "github.com/stockfolioofficial/back-editfolio/search/handler"
+	repository34 "github.com/stockfolioofficial/back-editfolio/search/repository"
+	usecase36 "github.com/stockfolioofficial/back-editfolio/search/usecase"
+	handler15 "github.com/stockfolioofficial/back-editfolio/setting/handler"
+	repository16 "github.com/stockfolioofficial/back-editfolio/setting/repository"
+	usecase14 "github.com/stockfolioofficial/back-editfolio/setting/usecase"
+	handler19 "github.com/stockfolioofficial/back-editfolio/shadow/handler"
+	repository19 "github.com/stockfolioofficial/back-editfolio/shadow/repository"
+	usecase17 "github.com/stockfolioofficial/back-editfolio/shadow/usecase"
+	repository25 "github.com/stockfolioofficial/back-editfolio/shortLink/repository"
+	usecase24 "github.com/stockfolioofficial/back-editfolio/shortLink/usecase"
+	handler17 "github.com/stockfolioofficial/back-editfolio/snapshot/handler"
+	handler44 "github.com/stockfolioofficial/back-editfolio/subscription/handler"
+	repository40 "github.com/stockfolioofficial/back-editfolio/subscription/repository"
+	usecase42 "github.com/stockfolioofficial/back-editfolio/subscription/usecase"
+	handler36 "github.com/stockfolioofficial/back-editfolio/task/handler"
+	repository32 "github.com/stockfolioofficial/back-editfolio/task/repository"
+	usecase34 "github.com/stockfolioofficial/back-editfolio/task/usecase"
+	handler21 "github.com/stockfolioofficial/back-editfolio/tenantCredential/handler"
+	repository20 "github.com/stockfolioofficial/back-editfolio/tenantCredential/repository"
+	usecase19 "github.com/stockfolioofficial/back-editfolio/tenantCredential/usecase"
+	handler33 "github.com/stockfolioofficial/back-editfolio/terms/handler"
+	repository29 "github.com/stockfolioofficial/back-editfolio/terms/repository"
+	usecase31 "github.com/stockfolioofficial/back-editfolio/terms/usecase"
 	handler2 "github.com/stockfolioofficial/back-editfolio/user/handler"
 	"github.com/stockfolioofficial/back-editfolio/user/repository"
 	"github.com/stockfolioofficial/back-editfolio/user/usecase"
@@ -37,7 +151,14 @@ var DI = wire.NewSet(
 var infraSet = wire.NewSet(
 	NewEcho,
 	NewMiddleware,
+	NewSecretStore,
+	NewTenantRouter,
+	NewCredentialCipher,
+	NewBlobStorage,
 	NewDatabase,
+	wire.InterfaceValue(new(domain.Clock), clock.System),
+	NewIdGenerator,
+	calendar.NewSeoulCalendar,
 
 	// todo, 추후 별도로 config로 빼는게 좋을 듯
 	// useCase timeout 3min
@@ -45,23 +166,137 @@ var infraSet = wire.NewSet(
 )
 
 var adapterSet = wire.NewSet(
-	wire.InterfaceValue(new(domain.TokenGenerateAdapter), adapter.NewTokenGenerateAdapter([]byte(config.JWTSecret))),
+	NewTokenGenerateAdapter,
+	NewTokenParseAdapter,
+	wire.InterfaceValue(new(domain.EventPublisher), adapter2.NewEventPublisher(config.KafkaRestProxy, config.KafkaTopicPrefix, config.KafkaTopics, config.RetryPolicies["kafka"])),
+	NewRetentionArchiver,
+	NewBackupAdapter,
+	NewVideoPreviewer,
+	NewYouTubeClient,
+	NewESignAdapter,
+	NewSmsSender,
+	NewAuditExporter,
+	NewIntegrationExporters,
+	wire.InterfaceValue(new(domain.HookSender), adapter4.NewHookSender(config.RetryPolicies["webhook"])),
+	adapter5.NewQRCodeEncoder,
+	adapter6.NewReportEncoder,
 )
 
 var repositorySet = wire.NewSet(
 	repository.NewUserRepository,
+	repository.NewIdentityRepository,
+	repository.NewRefreshTokenRepository,
+	repository.NewPasswordResetRepository,
+	repository.NewSignInFailureRepository,
+	repository.NewMobileVerificationRepository,
+	repository.NewLoginAttemptRepository,
+	repository.NewPasswordHistoryRepository,
 	repository2.NewManagerRepository,
 	repository3.NewCustomerRepository,
 	repository4.NewOrderRepository,
+	repository4.NewOrderAssignmentRepository,
+	repository4.NewOrderHistoryRepository,
+	repository4.NewOrderWatcherRepository,
 	repository5.NewOrderStateRepository,
 	repository6.NewOrderTicketRepository,
+	repository7.NewIssueRepository,
+	repository8.NewCreditRepository,
+	repository9.NewReferralRepository,
+	repository10.NewExperimentRepository,
+	repository11.NewAnalyticsRepository,
+	repository12.NewOutboxRepository,
+	repository13.NewInboxRepository,
+	repository14.NewRetentionRepository,
+	repository15.NewBackupRepository,
+	repository16.NewSettingRepository,
+	repository17.NewCustomFieldRepository,
+	repository18.NewSavedViewRepository,
+	repository19.NewShadowRepository,
+	repository20.NewTenantCredentialRepository,
+	repository21.NewFileRepository,
+	repository21.NewFileUploadRepository,
+	repository21.NewFilePreviewRepository,
+	repository22.NewChannelRepository,
+	repository23.NewIntegrationRepository,
+	repository24.NewHookRepository,
+	repository25.NewShortLinkRepository,
+	NewDeadLetterRepositories,
+	repository26.NewFinanceSnapshotRepository,
+	repository27.NewApiUsageRepository,
+	repository28.NewReportJobRepository,
+	repository28.NewReportSource,
+	repository29.NewTermsRepository,
+	repository30.NewContractRepository,
+	repository31.NewLeadRepository,
+	repository32.NewTaskRepository,
+	repository33.NewDashboardRepository,
+	repository34.NewSearchRepository,
+	repository35.NewOpsAlertRepository,
+	repository36.NewAuditLogRepository,
+	repository37.NewManagerUnavailabilityRepository,
+	repository38.NewNotificationRepository,
+	repository38.NewMarketingConsentRepository,
+	repository39.NewEmailEventRepository,
+	repository40.NewSubscriptionPlanRepository,
 )
 
 var useCaseSet = wire.NewSet(
 	usecase.NewUserUseCase,
+	usecase.NewPasswordPolicy,
+	usecase.NewTokenVersionReader,
 	usecase2.NewOrderUseCase,
 	usecase3.NewOrderStateUseCase,
 	usecase4.NewOrderTicketUseCase,
+	usecase5.NewIssueUseCase,
+	usecase6.NewCreditUseCase,
+	usecase7.NewReferralUseCase,
+	usecase8.NewExperimentUseCase,
+	usecase9.NewAnalyticsUseCase,
+	usecase10.NewOutboxUseCase,
+	usecase11.NewInboxUseCase,
+	NewInboxHandlers,
+	usecase12.NewRetentionUseCase,
+	NewRetentionPolicies,
+	usecase13.NewBackupUseCase,
+	usecase14.NewSettingUseCase,
+	usecase14.NewSettingReader,
+	usecase15.NewCustomFieldUseCase,
+	NewCustomerSnapshotUseCase,
+	usecase16.NewSavedViewUseCase,
+	usecase17.NewShadowUseCase,
+	usecase18.NewRecycleBinUseCase,
+	usecase19.NewTenantCredentialUseCase,
+	usecase19.NewTenantCredentialResolver,
+	usecase20.NewFileUseCase,
+	usecase20.NewFileUploadUseCase,
+	usecase20.NewFilePreviewUseCase,
+	usecase20.NewStorageQuota,
+	usecase21.NewChannelUseCase,
+	usecase22.NewIntegrationUseCase,
+	usecase23.NewHookUseCase,
+	usecase24.NewShortLinkUseCase,
+	usecase25.NewQRCodeUseCase,
+	NewQRTargets,
+	usecase26.NewDeadLetterUseCase,
+	usecase27.NewFinanceSnapshotUseCase,
+	usecase28.NewApiUsageUseCase,
+	usecase29.NewReportUseCase,
+	usecase30.NewBillingUseCase,
+	usecase31.NewTermsUseCase,
+	usecase31.NewTermsGate,
+	usecase32.NewContractUseCase,
+	usecase32.NewContractGate,
+	usecase33.NewLeadUseCase,
+	usecase34.NewTaskUseCase,
+	usecase35.NewDashboardUseCase,
+	usecase36.NewSearchUseCase,
+	usecase37.NewOpsAlertUseCase,
+	usecase38.NewAuditLogUseCase,
+	usecase38.NewAuditLogger,
+	usecase39.NewManagerAvailabilityUseCase,
+	usecase40.NewNotificationUseCase,
+	usecase41.NewEmailUseCase,
+	usecase42.NewSubscriptionUseCase,
 )
 
 var controllerSet = wire.NewSet(
@@ -70,6 +305,49 @@ var controllerSet = wire.NewSet(
 	handler3.NewOrderController,
 	handler4.NewOrderStateController,
 	handler5.NewOrderTicketController,
+	handler6.NewIssueController,
+	handler7.NewCreditController,
+	handler8.NewReferralController,
+	handler9.NewExperimentController,
+	handler10.NewAnalyticsController,
+	handler11.NewOutboxController,
+	handler12.NewInboxController,
+	handler13.NewRetentionController,
+	handler14.NewBackupController,
+	NewDiagnosticsController,
+	cache.NewCacheController,
+	blob.NewBlobController,
+	health.NewHealthController,
+	handler15.NewSettingController,
+	handler16.NewCustomFieldController,
+	handler17.NewCustomerSnapshotController,
+	handler18.NewSavedViewController,
+	handler19.NewShadowController,
+	handler20.NewRecycleBinController,
+	handler21.NewTenantCredentialController,
+	NewFileController,
+	NewChannelController,
+	handler24.NewIntegrationController,
+	handler25.NewHookController,
+	NewShortLinkController,
+	handler27.NewQRCodeController,
+	handler28.NewDeadLetterController,
+	handler29.NewFinanceSnapshotController,
+	handler30.NewApiUsageController,
+	handler31.NewReportController,
+	handler32.NewBillingController,
+	handler33.NewTermsController,
+	NewContractController,
+	handler35.NewLeadController,
+	handler36.NewTaskController,
+	handler37.NewDashboardController,
+	handler38.NewSearchController,
+	handler39.NewOpsAlertController,
+	handler40.NewAuditLogController,
+	handler41.NewManagerAvailabilityController,
+	handler42.NewNotificationController,
+	NewEmailController,
+	handler44.NewSubscriptionController,
 )
 
 var lifecycleSet = wire.NewSet(
@@ -0,0 +1,100 @@
+package di
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+// shadowSkipPrefixes 기록 API 자체와 내부 API, 헬스 체크 프로브, 테넌트 자격 증명은 기록하지 않음
+var shadowSkipPrefixes = []string{"/shadow/", "/internal/", "/healthz", "/readyz", "/tenant/"}
+
+// shadowRecorder 규칙이 켜진 라우트의 요청 중 표본만 요청/응답을 잡아 비동기로 저장
+func shadowRecorder(useCase domain.ShadowUseCase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			for _, prefix := range shadowSkipPrefixes {
+				if strings.HasPrefix(req.URL.Path, prefix) {
+					return next(ctx)
+				}
+			}
+
+			ruleId, ok := useCase.Sample(req.Context(), req.Method, ctx.Path())
+			if !ok {
+				return next(ctx)
+			}
+
+			var reqBody []byte
+			if req.Body != nil {
+				reqBody, _ = ioutil.ReadAll(io.LimitReader(req.Body, domain.ShadowBodyLimit))
+				req.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(reqBody), req.Body))
+			}
+
+			res := ctx.Response()
+			capture := &shadowCapture{ResponseWriter: res.Writer}
+			res.Writer = capture
+
+			start := time.Now()
+			err := next(ctx)
+			if err != nil {
+				ctx.Error(err)
+			}
+			res.Writer = capture.ResponseWriter
+
+			record := domain.ShadowCapture{
+				Method:       req.Method,
+				Route:        ctx.Path(),
+				Path:         req.URL.Path,
+				Query:        req.URL.Query(),
+				RequestBody:  reqBody,
+				Status:       res.Status,
+				ResponseBody: capture.body.Bytes(),
+				Duration:     time.Since(start),
+			}
+			if principal, ok := echox.PrincipalOf(ctx); ok {
+				record.UserId = &principal.UserId
+			}
+
+			// 요청 context 는 응답 후 취소되므로 분리
+			go func() {
+				if err := useCase.Record(context.Background(), ruleId, record); err != nil {
+					log.WithError(err).WithField("route", record.Route).Error("shadow record save failed")
+				}
+			}()
+			return nil
+		}
+	}
+}
+
+// shadowCapture 응답을 그대로 쓰면서 ShadowBodyLimit 까지 복사
+type shadowCapture struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *shadowCapture) Write(b []byte) (int, error) {
+	if remain := domain.ShadowBodyLimit - w.body.Len(); remain > 0 {
+		if len(b) > remain {
+			w.body.Write(b[:remain])
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *shadowCapture) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,12 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/shortLink/handler"
+)
+
+// NewShortLinkController 짧은 주소 앞부분은 설정값
+func NewShortLinkController(useCase domain.ShortLinkUseCase) *handler.ShortLinkController {
+	return handler.NewShortLinkController(useCase, config.ShortLinkBaseURL)
+}
@@ -0,0 +1,26 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/auditLog/adapter"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewAuditExporter 주소가 둘 다 비어있으면 보내지 않음, 주소 형식이 틀리면 서버를 띄우지 않음
+func NewAuditExporter(store *secret.Store) domain.AuditExporter {
+	exporter, err := adapter.NewAuditExporter(adapter.SiemOption{
+		Addr:          config.SiemAddr,
+		URL:           config.SiemURL,
+		Token:         resolveSecret(store, config.SiemToken),
+		Format:        adapter.SiemFormat(config.SiemFormat),
+		BufferSize:    config.SiemBufferSize,
+		BatchSize:     config.SiemBatchSize,
+		FlushInterval: config.SiemFlushInterval,
+		Retry:         config.RetryPolicies["siem"],
+	})
+	if err != nil {
+		panic(err)
+	}
+	return exporter
+}
@@ -0,0 +1,21 @@
+package di
+
+import (
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/user/adapter"
+)
+
+// NewSmsSender 앱 키가 없으면 디버그 모드에서는 로그로만, 아니면 보낼 때 에러, 비밀 키는 비밀 저장소 참조 가능
+func NewSmsSender(store *secret.Store) domain.SmsSender {
+	if config.SmsAppKey == "" && config.IsDebug {
+		return adapter.NewLogSms()
+	}
+	return adapter.NewNHNCloudSms(adapter.NHNCloudSmsOption{
+		AppKey:    config.SmsAppKey,
+		SecretKey: resolveSecret(store, config.SmsSecretKey),
+		SendNo:    config.SmsSendNo,
+		Retry:     config.RetryPolicies["sms"],
+	})
+}
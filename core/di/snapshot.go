@@ -0,0 +1,23 @@
+package di
+
+import (
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/snapshot/usecase"
+)
+
+func NewCustomerSnapshotUseCase(
+	userRepo domain.UserRepository,
+	customerRepo domain.CustomerRepository,
+	managerRepo domain.ManagerRepository,
+	orderRepo domain.OrderRepository,
+	orderTicketRepo domain.OrderTicketRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.CustomerSnapshotUseCase {
+	return usecase.NewCustomerSnapshotUseCase(userRepo, customerRepo, managerRepo, orderRepo, orderTicketRepo,
+		config.SnapshotImportEnabled, ids, clock, timeout)
+}
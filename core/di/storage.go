@@ -0,0 +1,61 @@
+package di
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/blob"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+	"github.com/stockfolioofficial/back-editfolio/core/media"
+	"github.com/stockfolioofficial/back-editfolio/core/secret"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/file/handler"
+)
+
+// NewBlobStorage 설정된 파일 저장소, 로컬 개발은 local, 운영은 s3 (MinIO 등 S3 호환 포함)
+func NewBlobStorage(store *secret.Store) domain.BlobStorage {
+	switch config.StorageDriver {
+	case "s3":
+		return blob.NewS3(blob.S3Option{
+			Endpoint:  config.StorageS3Endpoint,
+			Region:    config.StorageS3Region,
+			Bucket:    config.StorageS3Bucket,
+			PathStyle: config.StorageS3PathStyle,
+		})
+	case "local":
+	default:
+		log.WithField("driver", config.StorageDriver).Fatal("unknown storage driver")
+	}
+
+	var signKey []byte
+	if config.StorageSignKey != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+		defer cancel()
+
+		key, err := store.Resolve(ctx, config.StorageSignKey)
+		if err != nil {
+			panic(err)
+		}
+		signKey = []byte(key)
+	}
+
+	return blob.NewLocal(blob.LocalOption{
+		Dir:     config.StorageDir,
+		BaseURL: config.StorageBaseURL,
+		SignKey: signKey,
+	})
+}
+
+// NewVideoPreviewer 납품 영상 미리보기용 ffmpeg
+func NewVideoPreviewer() domain.VideoPreviewer {
+	return media.NewFFmpeg(media.FFmpegOption{Path: config.FFmpegPath})
+}
+
+// NewFileController 업로드 크기 제한은 설정값
+func NewFileController(
+	useCase domain.FileUseCase,
+	uploadUseCase domain.FileUploadUseCase,
+	previewUseCase domain.FilePreviewUseCase,
+) *handler.FileController {
+	return handler.NewFileController(useCase, uploadUseCase, previewUseCase, config.StorageMaxUploadSize)
+}
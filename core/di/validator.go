@@ -5,12 +5,14 @@ import (
 	"regexp"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/stockfolioofficial/back-editfolio/domain"
 )
 
 func newValidator() (v *validator.Validate) {
 	v = validator.New()
 	v.RegisterValidation("sf_mobile", mobileValidation)
 	v.RegisterValidation("sf_password", passwordValidation)
+	v.RegisterValidation("sf_business_number", businessNumberValidation)
 	return
 }
 
@@ -41,3 +43,13 @@ func passwordValidation(fl validator.FieldLevel) bool {
 
 	return passwordRegex.MatchString(field.String()) && passwordRegex1.MatchString(field.String())
 }
+
+// businessNumberValidation 사업자등록번호, 하이픈은 있어도 되고 검증 번호까지 맞아야함
+func businessNumberValidation(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+
+	return domain.IsValidBusinessNumber(domain.NormalizeBusinessNumber(field.String()))
+}
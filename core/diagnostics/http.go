@@ -0,0 +1,138 @@
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+const (
+	tag = "[DIAGNOSTICS] "
+)
+
+func NewDiagnosticsController(db *gorm.DB) *DiagnosticsController {
+	c := &DiagnosticsController{db: db}
+	RegisterProbe("db", c.probeDB)
+	return c
+}
+
+type DiagnosticsController struct {
+	db *gorm.DB
+}
+
+func (c *DiagnosticsController) Bind(e *echo.Echo) {
+	// INTERNAL
+	e.GET("/internal/diagnostics", c.internalDiagnostics)
+	e.GET("/internal/status", c.internalStatus)
+}
+
+// probeDB 왕복 시간은 점검 공통의 latency 로 보고, 커넥션이 모자라 기다린 적이 있으면 DEGRADED
+func (c *DiagnosticsController) probeDB(ctx context.Context) ProbeResult {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return ProbeDown(err)
+	}
+
+	err = sqlDB.PingContext(ctx)
+	if err != nil {
+		return ProbeDown(err)
+	}
+
+	stats := sqlDB.Stats()
+	status := ProbeStatusUp
+	if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections {
+		status = ProbeStatusDegraded
+	}
+	return ProbeResult{
+		Status: status,
+		Detail: map[string]interface{}{
+			"openConnections": stats.OpenConnections,
+			"inUse":           stats.InUse,
+			"waitCount":       stats.WaitCount,
+		},
+	}
+}
+
+// internalStatus 운영 대시보드용, 하위 시스템이 DOWN 이어도 200 으로 응답하고 status 로 구분
+func (c *DiagnosticsController) internalStatus(ctx echo.Context) error {
+	report := CheckStatus(ctx.Request().Context())
+
+	probeList := make([]echo.Map, len(report.Probes))
+	for i, p := range report.Probes {
+		probeList[i] = echo.Map{
+			"name":      p.Name,
+			"status":    p.Status,
+			"latencyMs": p.Latency.Milliseconds(),
+			"detail":    p.Detail,
+		}
+	}
+
+	jobList := make([]echo.Map, len(report.Jobs))
+	for i, j := range report.Jobs {
+		jobList[i] = echo.Map{
+			"name":          j.Name,
+			"lastRunAt":     j.LastRunAt,
+			"lastElapsedMs": j.LastElapsed.Milliseconds(),
+			"lastSucceeded": j.LastSucceeded,
+			"lastSuccessAt": j.LastSuccessAt,
+			"runs":          j.Runs,
+			"failures":      j.Failures,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"status":        report.Status,
+		"checkedAt":     report.CheckedAt,
+		"uptimeSeconds": int64(time.Since(startedAt).Seconds()),
+		"subsystems":    probeList,
+		"jobs":          jobList,
+	})
+}
+
+func (c *DiagnosticsController) internalDiagnostics(ctx echo.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		log.WithError(err).Error(tag, "internalDiagnostics, unhandled error db.DB")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	s := Take(sqlDB)
+
+	caches := make([]echo.Map, len(s.Caches))
+	for i, cache := range s.Caches {
+		caches[i] = echo.Map{
+			"name":    cache.Name,
+			"hits":    cache.Hits,
+			"misses":  cache.Misses,
+			"hitRate": cache.HitRate,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"uptimeSeconds": int64(s.Uptime.Seconds()),
+		"goroutines":    s.Goroutines,
+		"memory": echo.Map{
+			"heapAlloc":    s.Memory.HeapAlloc,
+			"heapInuse":    s.Memory.HeapInuse,
+			"heapObjects":  s.Memory.HeapObjects,
+			"sys":          s.Memory.Sys,
+			"numGC":        s.Memory.NumGC,
+			"pauseTotalNs": s.Memory.PauseTotalNs,
+		},
+		"db": echo.Map{
+			"maxOpenConnections": s.DB.MaxOpenConnections,
+			"openConnections":    s.DB.OpenConnections,
+			"inUse":              s.DB.InUse,
+			"idle":               s.DB.Idle,
+			"waitCount":          s.DB.WaitCount,
+			"waitDurationMs":     s.DB.WaitDuration.Milliseconds(),
+		},
+		"caches":   caches,
+		"counters": s.Counters,
+	})
+}
@@ -0,0 +1,114 @@
+package diagnostics
+
+import (
+	"database/sql"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+var startedAt = time.Now()
+
+// CacheStats 캐시 적중 통계, 캐시 구현체가 RegisterCache 로 등록
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+var caches = struct {
+	sync.RWMutex
+	stats map[string]func() CacheStats
+}{stats: make(map[string]func() CacheStats)}
+
+// RegisterCache 진단 스냅샷에 포함할 캐시 등록, 같은 이름이면 덮어씀
+func RegisterCache(name string, stats func() CacheStats) {
+	caches.Lock()
+	defer caches.Unlock()
+	caches.stats[name] = stats
+}
+
+var counters = struct {
+	sync.Mutex
+	values map[string]uint64
+}{values: make(map[string]uint64)}
+
+// AddCounter 누적 카운터 증가 (ex. 정리 작업 삭제 건수), 재시작하면 0 부터
+func AddCounter(name string, delta uint64) {
+	counters.Lock()
+	defer counters.Unlock()
+	counters.values[name] += delta
+}
+
+type Snapshot struct {
+	Uptime     time.Duration
+	Goroutines int
+	Memory     MemorySnapshot
+	DB         sql.DBStats
+	Caches     []CacheSnapshot
+	Counters   map[string]uint64
+}
+
+type MemorySnapshot struct {
+	HeapAlloc    uint64
+	HeapInuse    uint64
+	HeapObjects  uint64
+	Sys          uint64
+	NumGC        uint32
+	PauseTotalNs uint64
+}
+
+type CacheSnapshot struct {
+	Name    string
+	Hits    uint64
+	Misses  uint64
+	HitRate float64
+}
+
+// Take 현재 런타임/DB 커넥션/캐시 상태, ReadMemStats 가 stop-the-world 라 자주 호출하지 않아야함
+func Take(db *sql.DB) (s Snapshot) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	s.Uptime = time.Since(startedAt)
+	s.Goroutines = runtime.NumGoroutine()
+	s.Memory = MemorySnapshot{
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		Sys:          mem.Sys,
+		NumGC:        mem.NumGC,
+		PauseTotalNs: mem.PauseTotalNs,
+	}
+	if db != nil {
+		s.DB = db.Stats()
+	}
+
+	caches.RLock()
+	for name, stats := range caches.stats {
+		st := stats()
+		var rate float64
+		if total := st.Hits + st.Misses; total > 0 {
+			rate = float64(st.Hits) / float64(total)
+		}
+		s.Caches = append(s.Caches, CacheSnapshot{
+			Name:    name,
+			Hits:    st.Hits,
+			Misses:  st.Misses,
+			HitRate: rate,
+		})
+	}
+	caches.RUnlock()
+
+	counters.Lock()
+	s.Counters = make(map[string]uint64, len(counters.values))
+	for name, value := range counters.values {
+		s.Counters[name] = value
+	}
+	counters.Unlock()
+
+	sort.Slice(s.Caches, func(i, j int) bool {
+		return s.Caches[i].Name < s.Caches[j].Name
+	})
+	return
+}
@@ -0,0 +1,201 @@
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ProbeStatus 하위 시스템 상태, 하나라도 DOWN 이면 전체 DOWN, 아니면 가장 나쁜 상태
+type ProbeStatus string
+
+const (
+	ProbeStatusUp       ProbeStatus = "UP"
+	ProbeStatusDegraded ProbeStatus = "DEGRADED"
+	ProbeStatusDown     ProbeStatus = "DOWN"
+)
+
+func (s ProbeStatus) worse(other ProbeStatus) bool {
+	rank := map[ProbeStatus]int{ProbeStatusUp: 0, ProbeStatusDegraded: 1, ProbeStatusDown: 2}
+	return rank[s] > rank[other]
+}
+
+type ProbeResult struct {
+	Status ProbeStatus
+	Detail map[string]interface{}
+}
+
+// ProbeDown 에러 메시지를 detail.error 로
+func ProbeDown(err error) ProbeResult {
+	return ProbeResult{
+		Status: ProbeStatusDown,
+		Detail: map[string]interface{}{"error": err.Error()},
+	}
+}
+
+// Probe 제한 시간(ProbeTimeout) 안에 끝나야 함, 넘기면 DOWN
+type Probe func(ctx context.Context) ProbeResult
+
+// ProbeTimeout 하위 시스템 하나의 점검 제한 시간, 모든 점검은 동시에 실행
+const ProbeTimeout = 2 * time.Second
+
+var probes = struct {
+	sync.RWMutex
+	values map[string]Probe
+}{values: make(map[string]Probe)}
+
+// RegisterProbe 상태 점검 등록, 같은 이름이면 덮어씀
+func RegisterProbe(name string, probe Probe) {
+	probes.Lock()
+	defer probes.Unlock()
+	probes.values[name] = probe
+}
+
+// JobRun 내부 작업(/internal POST) 마지막 실행, 재시작하면 비어있음
+type JobRun struct {
+	Name          string
+	LastRunAt     time.Time
+	LastElapsed   time.Duration
+	LastSucceeded bool
+	LastSuccessAt *time.Time
+	Runs          uint64
+	Failures      uint64
+}
+
+var jobs = struct {
+	sync.Mutex
+	values map[string]*JobRun
+}{values: make(map[string]*JobRun)}
+
+// RecordJobRun 스케줄러가 부르는 내부 작업의 실행 기록
+func RecordJobRun(name string, startedAt time.Time, elapsed time.Duration, succeeded bool) {
+	jobs.Lock()
+	defer jobs.Unlock()
+
+	run, ok := jobs.values[name]
+	if !ok {
+		run = &JobRun{Name: name}
+		jobs.values[name] = run
+	}
+
+	run.LastRunAt = startedAt
+	run.LastElapsed = elapsed
+	run.LastSucceeded = succeeded
+	run.Runs++
+	if succeeded {
+		at := startedAt
+		run.LastSuccessAt = &at
+	} else {
+		run.Failures++
+	}
+}
+
+// JobRunRecorder /internal 아래 POST 요청을 경로 패턴별 작업으로 기록, 5xx 또는 에러면 실패
+func JobRunRecorder() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			if req.Method != http.MethodPost || !strings.HasPrefix(req.URL.Path, "/internal/") {
+				return next(ctx)
+			}
+
+			startedAt := time.Now()
+			err := next(ctx)
+			succeeded := err == nil && ctx.Response().Status < http.StatusInternalServerError
+			RecordJobRun(ctx.Path(), startedAt, time.Since(startedAt), succeeded)
+			return err
+		}
+	}
+}
+
+type ProbeReport struct {
+	Name    string
+	Status  ProbeStatus
+	Latency time.Duration
+	Detail  map[string]interface{}
+}
+
+type StatusReport struct {
+	Status    ProbeStatus
+	Probes    []ProbeReport
+	Jobs      []JobRun
+	CheckedAt time.Time
+}
+
+// CheckStatus 등록된 점검을 동시에 실행, 제한 시간을 넘긴 점검은 기다리지 않고 DOWN
+func CheckStatus(ctx context.Context) (report StatusReport) {
+	report.CheckedAt = time.Now()
+	report.Status = ProbeStatusUp
+
+	probes.RLock()
+	names := make([]string, 0, len(probes.values))
+	list := make([]Probe, 0, len(probes.values))
+	for name, probe := range probes.values {
+		names = append(names, name)
+		list = append(list, probe)
+	}
+	probes.RUnlock()
+
+	report.Probes = make([]ProbeReport, len(list))
+	var wg sync.WaitGroup
+	for i := range list {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Probes[i] = runProbe(ctx, names[i], list[i])
+		}()
+	}
+	wg.Wait()
+
+	for _, p := range report.Probes {
+		if p.Status.worse(report.Status) {
+			report.Status = p.Status
+		}
+	}
+
+	jobs.Lock()
+	report.Jobs = make([]JobRun, 0, len(jobs.values))
+	for _, run := range jobs.values {
+		report.Jobs = append(report.Jobs, *run)
+	}
+	jobs.Unlock()
+
+	sort.Slice(report.Probes, func(i, j int) bool {
+		return report.Probes[i].Name < report.Probes[j].Name
+	})
+	sort.Slice(report.Jobs, func(i, j int) bool {
+		return report.Jobs[i].Name < report.Jobs[j].Name
+	})
+	return
+}
+
+func runProbe(ctx context.Context, name string, probe Probe) ProbeReport {
+	c, cancel := context.WithTimeout(ctx, ProbeTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	done := make(chan ProbeResult, 1)
+	go func() {
+		done <- probe(c)
+	}()
+
+	var res ProbeResult
+	select {
+	case res = <-done:
+	case <-c.Done():
+		res = ProbeDown(c.Err())
+	}
+
+	return ProbeReport{
+		Name:    name,
+		Status:  res.Status,
+		Latency: time.Since(startedAt),
+		Detail:  res.Detail,
+	}
+}
@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+	"gorm.io/gorm"
+)
+
+const (
+	tag = "[HEALTH] "
+
+	// checkTimeout 프로브 주기보다 짧게, 의존성이 멈춰 있어도 프로브가 같이 멈추지 않도록
+	checkTimeout = 2 * time.Second
+)
+
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+)
+
+// Check 준비 상태 점검, 연결이 안 되면 error
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+var (
+	checksMu sync.RWMutex
+	checks   []namedCheck
+)
+
+// RegisterCheck /readyz 에서 점검할 의존성 등록, Redis 등 외부 저장소를 붙이면 여기 추가
+func RegisterCheck(name string, check Check) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	checks = append(checks, namedCheck{name: name, check: check})
+}
+
+func NewHealthController(db *gorm.DB) *HealthController {
+	c := &HealthController{db: db}
+	RegisterCheck("db", c.checkDB)
+	return c
+}
+
+// HealthController 로드밸런서, 쿠버네티스 프로브 전용, 인증 없음
+type HealthController struct {
+	db *gorm.DB
+}
+
+func (c *HealthController) Bind(e *echo.Echo) {
+	// PROBE
+	e.GET("/healthz", c.liveness)
+	e.GET("/readyz", c.readiness)
+}
+
+func (c *HealthController) checkDB(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// liveness 프로세스가 요청을 받을 수 있는지만, 의존성 장애로 재시작되지 않게 점검하지 않음
+func (c *HealthController) liveness(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"status": StatusUp,
+	})
+}
+
+// readiness 등록한 의존성을 동시에 점검, 하나라도 실패하면 503 으로 트래픽에서 빠짐
+func (c *HealthController) readiness(ctx echo.Context) error {
+	checksMu.RLock()
+	list := make([]namedCheck, len(checks))
+	copy(list, checks)
+	checksMu.RUnlock()
+
+	cc, cancel := context.WithTimeout(ctx.Request().Context(), checkTimeout)
+	defer cancel()
+
+	results := make(map[string]string, len(list))
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, nc := range list {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+			status := StatusUp
+			err := nc.check(cc)
+			if err != nil {
+				logx.From(cc).WithError(err).WithField("check", nc.name).Warn(tag, "readiness check failed")
+				status = StatusDown
+			}
+
+			mu.Lock()
+			results[nc.name] = status
+			mu.Unlock()
+		}(nc)
+	}
+	wg.Wait()
+
+	code, status := http.StatusOK, StatusUp
+	for _, s := range results {
+		if s != StatusUp {
+			code, status = http.StatusServiceUnavailable, StatusDown
+			break
+		}
+	}
+	return ctx.JSON(code, echo.Map{
+		"status": status,
+		"checks": results,
+	})
+}
@@ -0,0 +1,77 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+const (
+	VersionRandom      = 4
+	VersionTimeOrdered = 7
+)
+
+// New version 에 맞는 발급기, 모르는 버전은 v4
+func New(version int) domain.IdGenerator {
+	if version == VersionTimeOrdered {
+		return NewTimeOrdered()
+	}
+	return Random
+}
+
+// Random UUIDv4
+var Random domain.IdGenerator = domain.IdGeneratorFunc(uuid.New)
+
+// NewTimeOrdered UUIDv7 (RFC 9562), 앞 48비트가 밀리초 시각이라 primary key 인덱스에 순서대로 쌓임
+// 같은 밀리초 안에서는 시각을 1ms 씩 당겨서 발급 순서를 유지
+func NewTimeOrdered() domain.IdGenerator {
+	return &timeOrdered{}
+}
+
+type timeOrdered struct {
+	mu   sync.Mutex
+	last int64
+}
+
+func (g *timeOrdered) NewId() (id uuid.UUID) {
+	g.mu.Lock()
+	ms := time.Now().UnixMilli()
+	if ms <= g.last {
+		ms = g.last + 1
+	}
+	g.last = ms
+	g.mu.Unlock()
+
+	if _, err := rand.Read(id[6:]); err != nil {
+		return uuid.New()
+	}
+
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(ms))
+	copy(id[:6], ts[2:])
+
+	id[6] = id[6]&0x0f | 0x70 // version 7
+	id[8] = id[8]&0x3f | 0x80 // variant RFC 4122
+	return
+}
+
+// Sequence 테스트용, 주어진 아이디를 순서대로 발급하고 다 쓰면 v4
+func Sequence(ids ...uuid.UUID) domain.IdGenerator {
+	var (
+		mu sync.Mutex
+		i  int
+	)
+	return domain.IdGeneratorFunc(func() uuid.UUID {
+		mu.Lock()
+		defer mu.Unlock()
+		if i < len(ids) {
+			i++
+			return ids[i-1]
+		}
+		return uuid.New()
+	})
+}
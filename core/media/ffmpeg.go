@@ -0,0 +1,110 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// thumbnailAt 첫 프레임은 검은 화면인 경우가 많아서 조금 뒤 프레임 사용, 더 짧은 영상은 첫 프레임
+	thumbnailAt    = "3"
+	thumbnailWidth = 640
+	gifDuration    = "3"
+	gifWidth       = 320
+	gifFPS         = 10
+
+	stderrLimit = 1000
+)
+
+var ErrEmptyOutput = errors.New("ffmpeg produced no output")
+
+// FFmpegOption ffmpeg 실행 설정
+type FFmpegOption struct {
+	// Path ffmpeg 실행 파일, 비어있으면 PATH 에서 찾음
+	Path string
+}
+
+// NewFFmpeg 원본을 URL 로 넘겨 ffmpeg 가 필요한 부분만 읽고, 결과는 stdout 으로 받음
+func NewFFmpeg(option FFmpegOption) *FFmpeg {
+	path := option.Path
+	if path == "" {
+		path = "ffmpeg"
+	}
+	return &FFmpeg{path: path}
+}
+
+type FFmpeg struct {
+	path string
+}
+
+func (f *FFmpeg) Thumbnail(ctx context.Context, sourceURL string, w io.Writer) error {
+	err := f.run(ctx, w,
+		"-ss", thumbnailAt, "-i", sourceURL,
+		"-frames:v", "1",
+		"-vf", fmt.Sprintf("scale=%d:-2", thumbnailWidth),
+		"-f", "image2", "-c:v", "mjpeg", "pipe:1",
+	)
+	if err != ErrEmptyOutput {
+		return err
+	}
+
+	// 영상이 thumbnailAt 보다 짧으면 출력이 없으므로 첫 프레임으로 다시 시도
+	return f.run(ctx, w,
+		"-i", sourceURL,
+		"-frames:v", "1",
+		"-vf", fmt.Sprintf("scale=%d:-2", thumbnailWidth),
+		"-f", "image2", "-c:v", "mjpeg", "pipe:1",
+	)
+}
+
+// Gif 팔레트를 만들어 적용해야 색이 깨지지 않음
+func (f *FFmpeg) Gif(ctx context.Context, sourceURL string, w io.Writer) error {
+	filter := fmt.Sprintf("fps=%d,scale=%d:-1:flags=lanczos,split[a][b];[a]palettegen[p];[b][p]paletteuse", gifFPS, gifWidth)
+	return f.run(ctx, w,
+		"-t", gifDuration, "-i", sourceURL,
+		"-vf", filter,
+		"-loop", "0",
+		"-f", "gif", "pipe:1",
+	)
+}
+
+func (f *FFmpeg) run(ctx context.Context, w io.Writer, args ...string) error {
+	args = append([]string{"-hide_banner", "-loglevel", "error", "-nostdin"}, args...)
+	cmd := exec.CommandContext(ctx, f.path, args...)
+
+	var (
+		stderr bytes.Buffer
+		out    = &countWriter{w: w}
+	)
+	cmd.Stdout = out
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > stderrLimit {
+			msg = msg[:stderrLimit]
+		}
+		return fmt.Errorf("ffmpeg: %w: %s", err, msg)
+	}
+	if out.n == 0 {
+		return ErrEmptyOutput
+	}
+	return nil
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const bearerPrefix = "bearer "
+
+// Authenticate Authorization 헤더의 JWT 를 확인하고 요청자를 context 에 넣음
+// 토큰이 없으면 그대로 통과, 인증이 필요한 경로는 RequireAuth, RequireRole 로 막음
+// 토큰 버전이 지금 버전과 다르면(비밀번호 변경 전 발급) 401, 삭제된 유저도 401
+// 토큰의 테넌트가 X-Tenant-Key 헤더의 테넌트와 다르면 401, tenantScope 다음에 등록해야 함
+func Authenticate(parser domain.TokenParseAdapter, versions domain.TokenVersionReader) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			raw := bearerToken(ctx.Request().Header.Get(echo.HeaderAuthorization))
+			if raw == "" {
+				return next(ctx)
+			}
+
+			claims, err := parser.Parse(raw)
+			switch err {
+			case nil:
+			case domain.ErrInvalidToken:
+				echox.Log(ctx).WithError(err).Trace("authenticate, invalid token")
+				return ctx.JSON(http.StatusUnauthorized, domain.InvalidateTokenResponse)
+			default:
+				echox.Log(ctx).WithError(err).Error("authenticate, jwt secret resolve failed")
+				return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+			}
+
+			if claims.Tenant != tenant.KeyOf(ctx.Request().Context()) {
+				echox.Log(ctx).WithField("userId", claims.Subject).Trace("authenticate, tenant mismatch")
+				return ctx.JSON(http.StatusUnauthorized, domain.InvalidateTokenResponse)
+			}
+
+			version, ok, err := versions.TokenVersion(ctx.Request().Context(), claims.Subject)
+			if err != nil {
+				echox.Log(ctx).WithError(err).Error("authenticate, token version read failed")
+				return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+			}
+
+			if !ok || version != claims.TokenVersion {
+				echox.Log(ctx).WithField("userId", claims.Subject).Trace("authenticate, revoked token")
+				return ctx.JSON(http.StatusUnauthorized, domain.InvalidateTokenResponse)
+			}
+
+			echox.SetPrincipal(ctx, principalOf(claims))
+			return next(ctx)
+		}
+	}
+}
+
+// RequireAuth 역할과 상관없이 인증된 요청만
+func RequireAuth() echo.MiddlewareFunc {
+	return RequireRole()
+}
+
+// RequireRole 인증되지 않았으면 401, role 중 하나가 아니면 403, role 이 없으면 인증만 확인
+func RequireRole(role ...domain.UserRole) echo.MiddlewareFunc {
+	allowed := make(map[string]bool, len(role))
+	for _, r := range role {
+		allowed[string(r)] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			principal, ok := echox.PrincipalOf(ctx)
+			if !ok {
+				return ctx.JSON(http.StatusUnauthorized, domain.InvalidateTokenResponse)
+			}
+
+			if len(allowed) > 0 && !allowed[principal.Role] {
+				return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// bearerToken "Bearer " 접두어는 있어도 없어도 됨
+func bearerToken(header string) string {
+	header = strings.TrimSpace(header)
+	if len(header) >= len(bearerPrefix) && strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		header = strings.TrimSpace(header[len(bearerPrefix):])
+	}
+	return header
+}
+
+func principalOf(claims domain.TokenClaims) echox.Principal {
+	scopes := make([]string, len(claims.Scopes))
+	for i, scope := range claims.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return echox.Principal{
+		UserId: claims.Subject,
+		Role:   string(claims.Role),
+		Scopes: strings.Join(scopes, ","),
+		KeyId:  claims.KeyId,
+	}
+}
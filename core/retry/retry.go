@@ -0,0 +1,140 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Policy 외부 연동 한 번 호출 안에서의 재시도, 어댑터마다 설정(retry.policies)으로 바꿀 수 있음
+type Policy struct {
+	// MaxAttempts 첫 시도 포함 최대 시도 횟수, 1 이하면 재시도 안함
+	MaxAttempts int
+	// BaseDelay 첫 재시도 전 대기, 실패할 때마다 두 배
+	BaseDelay time.Duration
+	// MaxDelay 대기 상한, 0 이면 상한 없음
+	MaxDelay time.Duration
+}
+
+// Backoff attempt 번째 실패 후 대기, 상한 이하에서 무작위(full jitter)로 골라 동시에 몰리지 않게 함
+func (p Policy) Backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 || attempt < 1 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+// StatusError 외부 서비스가 성공이 아닌 상태 코드로 응답, 408, 429, 5xx 만 재시도
+type StatusError struct {
+	Service string
+	Code    int
+	Body    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s, status=%d", e.Service, e.Code)
+	}
+	return fmt.Sprintf("%s, status=%d: %s", e.Service, e.Code, e.Body)
+}
+
+type permanent struct {
+	err error
+}
+
+func (p permanent) Error() string {
+	return p.err.Error()
+}
+
+func (p permanent) Unwrap() error {
+	return p.err
+}
+
+// Permanent 재시도할 수 있어 보여도 다시 보내면 안되는 에러 표시, Do 는 감싼 에러를 그대로 반환
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanent{err: err}
+}
+
+// Retryable 일시적인 실패인지, 네트워크 에러와 408, 429, 5xx 응답만
+// 도메인 에러(토큰 만료, 구독 해지 등)와 응답 해석 실패는 다시 보내도 같으므로 false
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var p permanent
+	if errors.As(err, &p) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code == http.StatusRequestTimeout ||
+			statusErr.Code == http.StatusTooManyRequests ||
+			statusErr.Code >= http.StatusInternalServerError
+	}
+
+	// url.Error 는 모든 에러를 net.Error 로 감싸므로 안쪽 에러로 판단
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// Do 재시도할 수 있는 에러면 policy 에 따라 fn 을 다시 부름, 마지막 에러 반환
+// 남은 예산(ctx deadline)으로 대기를 마칠 수 없으면 기다리지 않고 마지막 에러 반환
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) (err error) {
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt >= policy.MaxAttempts || !Retryable(err) {
+			break
+		}
+
+		delay := policy.Backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			break
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	if p, ok := err.(permanent); ok {
+		err = p.err
+	}
+	return
+}
@@ -0,0 +1,86 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/util/awsv4"
+)
+
+const (
+	awsService         = "secretsmanager"
+	awsJsonContentType = "application/x-amz-json-1.1"
+)
+
+// NewAWSSecretsManager SDK 없이 GetSecretValue 만 호출,
+// 자격 증명은 환경 변수(AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN) 또는 ECS 작업 역할
+func NewAWSSecretsManager(region string) Provider {
+	client := &http.Client{}
+	return &awsSecretsManager{
+		endpoint: fmt.Sprintf("https://%s.%s.amazonaws.com/", awsService, region),
+		signer:   awsv4.Signer{Region: region, Service: awsService},
+		creds:    awsv4.NewCredentialSource(client),
+		client:   client,
+	}
+}
+
+type awsSecretsManager struct {
+	endpoint string
+	signer   awsv4.Signer
+	creds    *awsv4.CredentialSource
+	client   *http.Client
+}
+
+type awsErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// Fetch 현재 버전(AWSCURRENT)의 SecretString
+func (a *awsSecretsManager) Fetch(ctx context.Context, name string) (string, error) {
+	creds, err := a.creds.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(map[string]string{"SecretId": name})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", awsJsonContentType)
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	a.signer.Sign(req, awsv4.HashPayload(payload), creds, time.Now())
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body awsErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return "", fmt.Errorf("secrets manager: status %d: %s %s", resp.StatusCode, body.Type, body.Message)
+	}
+
+	var body struct {
+		SecretString *string `json:"SecretString"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return "", err
+	}
+	if body.SecretString == nil {
+		return "", fmt.Errorf("secrets manager: %s has no SecretString", name)
+	}
+	return *body.SecretString, nil
+}
@@ -0,0 +1,111 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+)
+
+const (
+	// RefPrefix 설정 값이 이 접두어로 시작하면 비밀 저장소 참조, "secret:<name>#<key>"
+	RefPrefix = "secret:"
+
+	cacheName = "secret"
+)
+
+var (
+	ErrNoProvider = errors.New("secret provider not configured")
+	ErrKeyMissing = errors.New("secret key missing")
+)
+
+// Provider 비밀 저장소, name 은 저장소별 경로(Vault) 또는 이름/ARN(Secrets Manager)
+type Provider interface {
+	Fetch(ctx context.Context, name string) (string, error)
+}
+
+// Ref 설정 값에 적는 비밀 참조, Key 가 있으면 JSON 객체 값 중 해당 키
+type Ref struct {
+	Name string
+	Key  string
+}
+
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, RefPrefix)
+}
+
+// ParseRef 참조가 아니면 false
+func ParseRef(value string) (ref Ref, ok bool) {
+	if !IsRef(value) {
+		return
+	}
+
+	ref.Name = strings.TrimPrefix(value, RefPrefix)
+	if i := strings.LastIndex(ref.Name, "#"); i >= 0 {
+		ref.Name, ref.Key = ref.Name[:i], ref.Name[i+1:]
+	}
+	ok = ref.Name != ""
+	return
+}
+
+// Store 조회한 비밀을 TTL 동안 캐시, 만료 후 처음 쓸 때 다시 조회하므로 저장소에서 교체된 값이 자동 반영
+type Store struct {
+	provider Provider
+	cache    *cache.Store
+}
+
+// NewStore provider 가 nil 이면 참조가 아닌 값만 쓸 수 있음
+func NewStore(provider Provider, ttl time.Duration) *Store {
+	return &Store{
+		provider: provider,
+		cache:    cache.New(cacheName, ttl),
+	}
+}
+
+// Resolve 참조면 저장소 값, 아니면 value 그대로
+func (s *Store) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok := ParseRef(value)
+	if !ok {
+		return value, nil
+	}
+	if s.provider == nil {
+		return "", ErrNoProvider
+	}
+
+	raw, err := s.cache.GetOrLoad(ref.Name, func() (interface{}, error) {
+		return s.provider.Fetch(ctx, ref.Name)
+	})
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", ref.Name, err)
+	}
+
+	if ref.Key == "" {
+		return raw.(string), nil
+	}
+
+	var values map[string]interface{}
+	err = json.Unmarshal([]byte(raw.(string)), &values)
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", ref.Name, err)
+	}
+
+	v, ok := values[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s#%s: %w", ref.Name, ref.Key, ErrKeyMissing)
+	}
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// Invalidate 인증 실패처럼 값이 교체된 것이 확실할 때 TTL 전이라도 다시 조회하도록 비움
+func (s *Store) Invalidate(value string) {
+	if ref, ok := ParseRef(value); ok {
+		s.cache.Invalidate(ref.Name)
+	}
+}
@@ -0,0 +1,62 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NewVault Vault KV v2 저장소, mount 기본값 "secret"
+func NewVault(addr, token, mount string) Provider {
+	if mount == "" {
+		mount = "secret"
+	}
+	return &vault{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		mount:  strings.Trim(mount, "/"),
+		client: &http.Client{},
+	}
+}
+
+type vault struct {
+	addr   string
+	token  string
+	mount  string
+	client *http.Client
+}
+
+type vaultResponse struct {
+	Data struct {
+		Data json.RawMessage `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// Fetch 최신 버전의 키/값을 JSON 객체 문자열로 반환
+func (v *vault) Fetch(ctx context.Context, name string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", v.addr, v.mount, strings.TrimLeft(name, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body vaultResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("vault: status %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault: status %d: %s", resp.StatusCode, strings.Join(body.Errors, "; "))
+	}
+	return string(body.Data.Data), nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const tag = "[SERVER] "
+
+type Option struct {
+	Addr string
+
+	// ReadHeaderTimeout 요청 헤더를 다 받을 때까지
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout, WriteTimeout 요청 본문 읽기, 응답 쓰기 제한, 0 이면 제한 없음
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// IdleTimeout keep-alive 연결이 다음 요청을 기다리는 시간
+	IdleTimeout time.Duration
+
+	// ShutdownTimeout 종료 신호 후 처리 중인 요청을 기다리는 시간, 넘으면 남은 연결을 끊음
+	ShutdownTimeout time.Duration
+}
+
+// Run ctx 가 끝나거나 SIGINT, SIGTERM 을 받으면 새 요청을 받지 않고 처리 중인 요청이 끝날 때까지 기다린 뒤 반환
+// 신호로 정상 종료하면 nil
+func Run(ctx context.Context, e *echo.Echo, option Option) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := e.Server
+	s.Addr = option.Addr
+	s.ReadHeaderTimeout = option.ReadHeaderTimeout
+	s.ReadTimeout = option.ReadTimeout
+	s.WriteTimeout = option.WriteTimeout
+	s.IdleTimeout = option.IdleTimeout
+
+	served := make(chan error, 1)
+	go func() {
+		served <- e.StartServer(s)
+	}()
+
+	select {
+	case err := <-served:
+		// 주소를 못 여는 등 종료 신호 전에 멈춤
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+
+	log.WithField("timeout", option.ShutdownTimeout).Info(tag, "shutting down, draining in-flight requests")
+
+	sc, cancel := context.WithTimeout(context.Background(), option.ShutdownTimeout)
+	defer cancel()
+
+	err := e.Shutdown(sc)
+	if err != nil {
+		log.WithError(err).Warn(tag, "graceful shutdown timed out, closing remaining connections")
+		_ = e.Close()
+	}
+
+	if err := <-served; err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
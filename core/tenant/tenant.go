@@ -0,0 +1,110 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+)
+
+// HeaderKey 요청의 테넌트 키, 없으면 기본 DB
+const HeaderKey = "X-Tenant-Key"
+
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+type contextKey struct{}
+
+// with 없는 테넌트 키로 기본 DB 를 쓰지 않도록 Router.With 에서 확인한 키만 넣음
+func with(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// KeyOf 요청 테넌트 키, 기본 DB 면 빈 값
+func KeyOf(ctx context.Context) string {
+	key, _ := ctx.Value(contextKey{}).(string)
+	return key
+}
+
+// CacheKey 프로세스 메모리 캐시 키에 테넌트를 붙임, 테넌트 DB 에서 읽은 값이 다른 테넌트 요청에 섞이지 않게
+func CacheKey(ctx context.Context, key string) string {
+	if tenantKey := KeyOf(ctx); tenantKey != "" {
+		return tenantKey + "/" + key
+	}
+	return key
+}
+
+// Router 테넌트별 DB 연결 풀을 고르는 gorm.ConnPool, 요청 context 의 테넌트 키로 선택
+// 레포지토리는 기본 DB 하나만 알고 WithContext(ctx) 로 조회하면 됨
+type Router struct {
+	fallback *sql.DB
+	pools    map[string]*sql.DB
+}
+
+// NewRouter pools 는 테넌트 키별 연결 풀, 없는 키는 fallback(기본 DB) 사용
+func NewRouter(fallback *sql.DB, pools map[string]*sql.DB) *Router {
+	return &Router{fallback: fallback, pools: pools}
+}
+
+// With key 가 비어있으면 기본 DB, 등록되지 않은 키면 ErrUnknownTenant
+func (r *Router) With(ctx context.Context, key string) (context.Context, error) {
+	if key == "" {
+		return ctx, nil
+	}
+	if _, ok := r.pools[key]; !ok {
+		return ctx, ErrUnknownTenant
+	}
+	return with(ctx, key), nil
+}
+
+// Keys 등록된 테넌트 키, 이름 순
+func (r *Router) Keys() []string {
+	keys := make([]string, 0, len(r.pools))
+	for key := range r.pools {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *Router) pool(ctx context.Context) *sql.DB {
+	if pool, ok := r.pools[KeyOf(ctx)]; ok {
+		return pool
+	}
+	return r.fallback
+}
+
+func (r *Router) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.pool(ctx).PrepareContext(ctx, query)
+}
+
+func (r *Router) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.pool(ctx).ExecContext(ctx, query, args...)
+}
+
+func (r *Router) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.pool(ctx).QueryContext(ctx, query, args...)
+}
+
+func (r *Router) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.pool(ctx).QueryRowContext(ctx, query, args...)
+}
+
+func (r *Router) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.pool(ctx).BeginTx(ctx, opts)
+}
+
+// GetDBConn gorm.DB.DB() 는 기본 DB, 풀 설정, 백업처럼 DB 하나를 직접 다루는 곳에서 사용
+func (r *Router) GetDBConn() (*sql.DB, error) {
+	return r.fallback, nil
+}
+
+// Close 테넌트 풀만 닫음, 기본 DB 는 gorm 이 관리
+func (r *Router) Close() error {
+	var first error
+	for _, pool := range r.pools {
+		if err := pool.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
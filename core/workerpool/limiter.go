@@ -0,0 +1,64 @@
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Limit 동시 실행 제한
+type Limit struct {
+	// Max 동시에 실행할 수, 0 이하면 제한 없음
+	Max int
+	// Queue 자리가 날 때까지 기다릴 수 있는 수, 넘으면 바로 거절
+	Queue int
+	// Wait 자리가 날 때까지 기다리는 최대 시간
+	Wait time.Duration
+}
+
+// Limiter 요청처럼 밖에서 들어오는 작업의 동시 실행 수 제한, 오래 기다리게 하지 않고 거절
+type Limiter struct {
+	limit   Limit
+	sem     chan struct{}
+	waiting int32
+}
+
+func NewLimiter(limit Limit) *Limiter {
+	l := &Limiter{limit: limit}
+	if limit.Max > 0 {
+		l.sem = make(chan struct{}, limit.Max)
+	}
+	return l
+}
+
+// Acquire 자리를 얻으면 끝난 뒤 호출할 release 반환
+// 대기 중인 수가 Queue 를 넘었거나, Wait 안에 자리가 나지 않거나, ctx 가 끝나면 ok false
+func (l *Limiter) Acquire(ctx context.Context) (release func(), ok bool) {
+	if l.sem == nil {
+		return func() {}, true
+	}
+
+	release = func() { <-l.sem }
+	select {
+	case l.sem <- struct{}{}:
+		return release, true
+	default:
+	}
+
+	if atomic.AddInt32(&l.waiting, 1) > int32(l.limit.Queue) {
+		atomic.AddInt32(&l.waiting, -1)
+		return nil, false
+	}
+	defer atomic.AddInt32(&l.waiting, -1)
+
+	timer := time.NewTimer(l.limit.Wait)
+	defer timer.Stop()
+
+	select {
+	case l.sem <- struct{}{}:
+		return release, true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return nil, false
+}
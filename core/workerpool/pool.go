@@ -0,0 +1,122 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Option 풀 설정
+type Option struct {
+	// Size 동시에 실행할 작업 수, 0 이하면 1
+	Size int
+	// FailFast 첫 에러에서 나머지 작업 취소 (errgroup 과 같은 동작), false 면 끝까지 실행하고 에러를 모음
+	FailFast bool
+}
+
+// Pool 동시 실행 수가 제한된 작업 묶음, Wait 전까지 Go 로 작업 추가
+type Pool struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	failFast bool
+	sem      chan struct{}
+	wg       sync.WaitGroup
+
+	mu   sync.Mutex
+	errs Errors
+}
+
+// New ctx 가 취소되거나 FailFast 에서 에러가 나면 반환된 context 도 취소됨
+func New(ctx context.Context, option Option) (*Pool, context.Context) {
+	size := option.Size
+	if size <= 0 {
+		size = 1
+	}
+
+	c, cancel := context.WithCancel(ctx)
+	return &Pool{
+		ctx:      c,
+		cancel:   cancel,
+		failFast: option.FailFast,
+		sem:      make(chan struct{}, size),
+	}, c
+}
+
+// Go 빈 자리가 날 때까지 대기 후 작업 실행, 이미 취소된 경우 실행하지 않음
+func (p *Pool) Go(fn func(ctx context.Context) error) {
+	select {
+	case <-p.ctx.Done():
+		return
+	case p.sem <- struct{}{}:
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.sem }()
+
+		if err := p.run(fn); err != nil {
+			p.fail(err)
+		}
+	}()
+}
+
+// Wait 모든 작업 종료 대기, 작업 에러는 Errors 로 모아서 반환
+func (p *Pool) Wait() error {
+	p.wg.Wait()
+	p.cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.errs) == 0 {
+		return nil
+	}
+	return p.errs
+}
+
+// run 작업 하나의 panic 이 다른 작업이나 프로세스를 죽이지 않도록 에러로 바꿈
+func (p *Pool) run(fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(p.ctx)
+}
+
+func (p *Pool) fail(err error) {
+	p.mu.Lock()
+	p.errs = append(p.errs, err)
+	p.mu.Unlock()
+
+	if p.failFast {
+		p.cancel()
+	}
+}
+
+// Errors 작업 에러 목록
+type Errors []error
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	msgs := make([]string, len(e))
+	for i := range e {
+		msgs[i] = e[i].Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// PanicError 작업 중 발생한 panic
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("worker panic: %v", e.Value)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[CREDIT] "
+)
+
+func NewCreditController(useCase domain.CreditUseCase) *CreditController {
+	return &CreditController{useCase: useCase}
+}
+
+type CreditController struct {
+	useCase domain.CreditUseCase
+}
+
+type CreditEntryResponse struct {
+	TransactionId uuid.UUID `json:"transactionId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Amount        int64     `json:"amount" validate:"required" example:"3000"`
+	Kind          string    `json:"kind" validate:"required" example:"EARN" enums:"EARN,SPEND,EXPIRE,ADJUST"`
+	Reference     *string   `json:"reference" example:"issue:550e8400-e29b-41d4-a716-446655440000"`
+	Memo          *string   `json:"memo" example:"보상 지급"`
+	CreatedAt     time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name CreditEntryResponse
+
+type CreditInfoResponse struct {
+	CustomerId uuid.UUID             `json:"customerId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Balance    int64                 `json:"balance" validate:"required" example:"3000"`
+	History    []CreditEntryResponse `json:"history" validate:"required"`
+} // @name CreditInfoResponse
+
+func useCaseToCreditInfoResponse(src domain.CreditInfo) (res CreditInfoResponse) {
+	res = CreditInfoResponse{
+		CustomerId: src.CustomerId,
+		Balance:    src.Balance,
+		History:    make([]CreditEntryResponse, len(src.History)),
+	}
+
+	for i := range src.History {
+		entry := src.History[i]
+		res.History[i] = CreditEntryResponse{
+			TransactionId: entry.TransactionId,
+			Amount:        entry.Amount,
+			Kind:          string(entry.Kind),
+			Reference:     entry.Reference,
+			Memo:          entry.Memo,
+			CreatedAt:     entry.CreatedAt,
+		}
+	}
+	return
+}
+
+// @Tags (Credit) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 크레딧 정보
+// @Description 크레딧 잔액과 전체 거래 내역 가져오는 기능, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} CreditInfoResponse "성공"
+// @Router /credit/me [get]
+func (c *CreditController) getMyCredit(ctx echo.Context, userId uuid.UUID) error {
+	res, err := c.useCase.GetCreditInfo(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("in", userId).
+			Error(tag, "getMyCredit, unhandled error useCase.GetCreditInfo")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, useCaseToCreditInfoResponse(res))
+}
+
+func (c *CreditController) Bind(e *echo.Echo) {
+	// CUSTOMER
+	e.GET("/credit/me", echox.UserID(c.getMyCredit),
+		middleware.RequireRole(domain.CustomerUserRole))
+
+	// ADMIN
+	e.GET("/customer/:userId/credit", c.getCustomerCredit,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/customer/:userId/credit", echox.UserID(c.adjustCustomerCredit),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/credit/invoice", c.internalApplyToInvoice)
+	e.POST("/internal/credit/expire", c.internalExpireCredits)
+}
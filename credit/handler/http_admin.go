@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+// @Tags (Credit) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 크레딧 정보
+// @Description 고객 크레딧 잔액과 전체 거래 내역 가져오는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Success 200 {object} CreditInfoResponse "성공"
+// @Router /customer/{user_id}/credit [get]
+func (c *CreditController) getCustomerCredit(ctx echo.Context) error {
+	var req struct {
+		UserId uuid.UUID `json:"-" param:"userId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get customer credit, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.GetCreditInfo(ctx.Request().Context(), req.UserId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getCustomerCredit, unhandled error useCase.GetCreditInfo")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, useCaseToCreditInfoResponse(res))
+}
+
+type AdjustCreditRequest struct {
+	UserId uuid.UUID `json:"-" param:"userId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Amount, 조정 금액 (양수 적립, 음수 차감)
+	Amount int64 `json:"amount" validate:"required,ne=0" example:"5000"`
+
+	// Memo, 조정 사유
+	Memo string `json:"memo" validate:"required,max=500" example:"이벤트 보상"`
+
+	// ExpiresAt, 적립 시 만료 일시
+	ExpiresAt *time.Time `json:"expiresAt" example:"2022-10-27T04:44:18+00:00"`
+} // @name AdjustCreditRequest
+
+type AdjustCreditResponse struct {
+	Balance int64 `json:"balance" validate:"required" example:"8000"`
+} // @name AdjustCreditResponse
+
+// @Tags (Credit) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 크레딧 조정
+// @Description 고객 크레딧을 적립하거나 차감하는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Param requestBody body AdjustCreditRequest true "크레딧 조정 데이터 구조"
+// @Success 200 {object} AdjustCreditResponse "조정 완료"
+// @Router /customer/{user_id}/credit [post]
+func (c *CreditController) adjustCustomerCredit(ctx echo.Context, userId uuid.UUID) error {
+	var req AdjustCreditRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "adjust credit, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.AdjustCredit{
+		CustomerId: req.UserId,
+		AdminId:    userId,
+		Amount:     req.Amount,
+		Memo:       req.Memo,
+		ExpiresAt:  req.ExpiresAt,
+		Ip:         ctx.RealIP(),
+	}
+	balance, err := c.useCase.AdjustCredit(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, AdjustCreditResponse{Balance: balance})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "insufficient credit"})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "adjustCustomerCredit, unhandled error useCase.AdjustCredit")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
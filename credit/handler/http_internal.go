@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+func (c *CreditController) internalApplyToInvoice(ctx echo.Context) error {
+	var req struct {
+		ExInvoiceId string `json:"exInvoiceId" validate:"required"`
+		Username    string `json:"username" validate:"required,email"`
+		Amount      int64  `json:"amount" validate:"required,gt=0"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "internalApplyToInvoice data binding error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	}
+
+	applied, err := c.useCase.ApplyToInvoice(ctx.Request().Context(), domain.ApplyCreditToInvoice{
+		ExInvoiceId: req.ExInvoiceId,
+		Username:    req.Username,
+		Amount:      req.Amount,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, echo.Map{
+			"applied": applied,
+		})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: fmt.Sprintf("user=%s, not found", req.Username),
+		})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{
+			Message: fmt.Sprintf("ex_invoice_id=%s, exists", req.ExInvoiceId),
+		})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "internalApplyToInvoice, unhandled error useCase.ApplyToInvoice")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *CreditController) internalExpireCredits(ctx echo.Context) error {
+	count, err := c.useCase.ExpireCredits(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalExpireCredits, unhandled error useCase.ExpireCredits")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"expired": count,
+	})
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewCreditRepository(db *gorm.DB) domain.CreditRepository {
+	db.AutoMigrate(&domain.CreditEntry{}, &domain.CreditLot{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.CreditTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(creditRepo domain.CreditTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) SaveTransaction(ctx context.Context, tx *domain.CreditTransaction) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if len(tx.Entries) > 0 {
+			err := db.Create(&tx.Entries).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := range tx.Lots {
+			err := gormx.Upsert(ctx, db, &tx.Lots[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repo) GetBalance(ctx context.Context, customerId uuid.UUID) (balance int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.CreditEntry{}).
+		Select("COALESCE(SUM(`amount`), 0)").
+		Where("`account` = ?", domain.CustomerCreditAccount(customerId)).
+		Scan(&balance).Error
+	return
+}
+
+func (r *repo) FetchAvailableLots(ctx context.Context, customerId uuid.UUID, at time.Time) (list []domain.CreditLot, err error) {
+	err = r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("`created_at` asc").
+		Where("`customer_id` = ? AND `remaining` > 0", customerId).
+		Where("`expires_at` IS NULL OR `expires_at` > ?", at).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchExpiredLots(ctx context.Context, at time.Time) (list []domain.CreditLot, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`expires_at` asc").
+		Where("`remaining` > 0 AND `expires_at` <= ?", at).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchEntries(ctx context.Context, customerId uuid.UUID) (list []domain.CreditEntry, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`created_at` desc").
+		Where("`account` = ?", domain.CustomerCreditAccount(customerId)).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) ExistsReference(ctx context.Context, reference string) (exists bool, err error) {
+	var cnt int64
+	err = r.db.WithContext(ctx).
+		Model(&domain.CreditEntry{}).
+		Where("`reference` = ?", reference).
+		Count(&cnt).Error
+	exists = cnt > 0
+	return
+}
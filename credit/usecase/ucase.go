@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewCreditUseCase(
+	creditRepo domain.CreditRepository,
+	userRepo domain.UserRepository,
+	auditLogger domain.AuditLogger,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.CreditUseCase {
+	return &ucase{
+		creditRepo:  creditRepo,
+		userRepo:    userRepo,
+		auditLogger: auditLogger,
+		ids:         ids,
+		clock:       clock,
+		timeout:     timeout,
+	}
+}
+
+type ucase struct {
+	creditRepo  domain.CreditRepository
+	userRepo    domain.UserRepository
+	auditLogger domain.AuditLogger
+	ids         domain.IdGenerator
+	clock       domain.Clock
+	timeout     time.Duration
+}
+
+func (u *ucase) AdjustCredit(ctx context.Context, in domain.AdjustCredit) (balance int64, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetById(c, in.CustomerId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	reference := "admin:" + in.AdminId.String()
+	err = u.creditRepo.Transaction(c, func(cr domain.CreditTxRepository) (err error) {
+		var tx domain.CreditTransaction
+		if in.Amount > 0 {
+			tx = domain.EarnCredit(domain.EarnCreditOption{
+				Ids:        u.ids,
+				CustomerId: in.CustomerId,
+				Source:     domain.CreditAccountAdjustment,
+				Kind:       domain.CreditEntryKindAdjust,
+				Amount:     in.Amount,
+				ExpiresAt:  in.ExpiresAt,
+				Reference:  &reference,
+				Memo:       &in.Memo,
+				Now:        u.clock.Now(),
+			})
+		} else {
+			var lots []domain.CreditLot
+			lots, err = cr.FetchAvailableLots(c, in.CustomerId, u.clock.Now())
+			if err != nil {
+				return
+			}
+
+			var spent int64
+			tx, spent = domain.SpendCredit(domain.SpendCreditOption{
+				Ids:        u.ids,
+				CustomerId: in.CustomerId,
+				Target:     domain.CreditAccountAdjustment,
+				Kind:       domain.CreditEntryKindAdjust,
+				Amount:     -in.Amount,
+				Lots:       lots,
+				Reference:  &reference,
+				Memo:       &in.Memo,
+				Now:        u.clock.Now(),
+			})
+			if spent < -in.Amount {
+				return domain.ErrWeirdData
+			}
+		}
+
+		err = cr.SaveTransaction(c, &tx)
+		if err != nil {
+			return
+		}
+
+		balance, err = cr.GetBalance(c, in.CustomerId)
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.AdminId,
+		TargetId: in.CustomerId,
+		Action:   domain.AuditActionCreditAdjusted,
+		Ip:       in.Ip,
+		Detail:   strconv.FormatInt(in.Amount, 10),
+	})
+	return
+}
+
+func (u *ucase) ApplyToInvoice(ctx context.Context, in domain.ApplyCreditToInvoice) (applied int64, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByUsername(c, in.Username)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	reference := "invoice:" + in.ExInvoiceId
+	err = u.creditRepo.Transaction(c, func(cr domain.CreditTxRepository) (err error) {
+		exists, err := cr.ExistsReference(c, reference)
+		if err != nil {
+			return
+		}
+
+		if exists {
+			return domain.ErrItemAlreadyExist
+		}
+
+		lots, err := cr.FetchAvailableLots(c, user.Id, u.clock.Now())
+		if err != nil {
+			return
+		}
+
+		tx, spent := domain.SpendCredit(domain.SpendCreditOption{
+			Ids:        u.ids,
+			CustomerId: user.Id,
+			Target:     domain.CreditAccountInvoice,
+			Kind:       domain.CreditEntryKindSpend,
+			Amount:     in.Amount,
+			Lots:       lots,
+			Reference:  &reference,
+			Now:        u.clock.Now(),
+		})
+		if tx.IsEmpty() {
+			return
+		}
+
+		err = cr.SaveTransaction(c, &tx)
+		if err != nil {
+			return
+		}
+
+		applied = spent
+		return
+	})
+	return
+}
+
+func (u *ucase) ExpireCredits(ctx context.Context) (count int, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	lots, err := u.creditRepo.FetchExpiredLots(c, u.clock.Now())
+	if err != nil {
+		return
+	}
+
+	for i := range lots {
+		tx := domain.ExpireCreditLot(lots[i], u.ids, u.clock.Now())
+		if tx.IsEmpty() {
+			continue
+		}
+
+		err = u.creditRepo.SaveTransaction(c, &tx)
+		if err != nil {
+			return
+		}
+		count++
+	}
+	return
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func (u *ucase) GetCreditInfo(ctx context.Context, customerId uuid.UUID) (res domain.CreditInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	res.CustomerId = customerId
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		res.Balance, err = u.creditRepo.GetBalance(gc, customerId)
+		return
+	})
+	g.Go(func() (err error) {
+		list, err := u.creditRepo.FetchEntries(gc, customerId)
+		if err != nil {
+			return
+		}
+
+		res.History = make([]domain.CreditEntryInfo, len(list))
+		for i := range list {
+			src := list[i]
+			res.History[i] = domain.CreditEntryInfo{
+				TransactionId: src.TransactionId,
+				Amount:        src.Amount,
+				Kind:          src.Kind,
+				Reference:     src.Reference,
+				Memo:          src.Memo,
+				CreatedAt:     src.CreatedAt,
+			}
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		res = domain.CreditInfo{}
+	}
+	return
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[CUSTOM-FIELD] "
+)
+
+func NewCustomFieldController(useCase domain.CustomFieldUseCase) *CustomFieldController {
+	return &CustomFieldController{useCase: useCase}
+}
+
+type CustomFieldController struct {
+	useCase domain.CustomFieldUseCase
+}
+
+func (c *CustomFieldController) Bind(e *echo.Echo) {
+	// ADMIN
+	e.GET("/custom-field", c.fetchCustomFields,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/customer/:userId/custom-field", c.updateCustomerCustomFields,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== SUPER_ADMIN =====
+	e.POST("/custom-field", c.createCustomField,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.DELETE("/custom-field/:key", c.deleteCustomField,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type CustomFieldResponse struct {
+	Key      string   `json:"key" validate:"required" example:"contract_type"`
+	Name     string   `json:"name" validate:"required" example:"계약 형태"`
+	Type     string   `json:"type" validate:"required" example:"SELECT" enums:"TEXT,NUMBER,BOOL,DATE,SELECT"`
+	Required bool     `json:"required" validate:"required" example:"false"`
+	Options  []string `json:"options" example:"월간,연간"`
+} // @name CustomFieldResponse
+
+// @Tags (CustomField) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 추가 항목 목록
+// @Description 고객별로 입력하는 추가 항목 정의 목록, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} CustomFieldResponse "성공"
+// @Success 204 "정의된 항목 없음"
+// @Router /custom-field [get]
+func (c *CustomFieldController) fetchCustomFields(ctx echo.Context) error {
+	list, err := c.useCase.FetchCustomFields(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchCustomFields, unhandled error useCase.FetchCustomFields")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]CustomFieldResponse, len(list))
+	for i, src := range list {
+		res[i] = CustomFieldResponse{
+			Key:      src.Key,
+			Name:     src.Name,
+			Type:     string(src.Type),
+			Required: src.Required,
+			Options:  src.Options,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type CreateCustomFieldRequest struct {
+	// Key, 영문 소문자로 시작, 영문 소문자/숫자/_ 40자 이내
+	Key      string `json:"key" validate:"required,max=40" example:"contract_type"`
+	Name     string `json:"name" validate:"required,min=1,max=60" example:"계약 형태"`
+	Type     string `json:"type" validate:"required,eq=TEXT|eq=NUMBER|eq=BOOL|eq=DATE|eq=SELECT" example:"SELECT"`
+	Required bool   `json:"required" example:"false"`
+	// Options, SELECT 일 때 선택지
+	Options []string `json:"options" validate:"omitempty,max=50,dive,min=1,max=100" example:"월간,연간"`
+} // @name CreateCustomFieldRequest
+
+// @Tags (CustomField) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 고객 추가 항목 생성
+// @Description 고객 추가 항목 정의 생성, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateCustomFieldRequest true "추가 항목 정의"
+// @Success 201 "생성 성공"
+// @Failure 400 {object} domain.ErrorResponse "키 형식이 맞지 않거나 SELECT 인데 선택지가 없음"
+// @Failure 409 {object} domain.ErrorResponse "이미 있는 키"
+// @Router /custom-field [post]
+func (c *CustomFieldController) createCustomField(ctx echo.Context) error {
+	var req CreateCustomFieldRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create custom field, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.CreateCustomField(ctx.Request().Context(), domain.CreateCustomFieldInput{
+		Key:      req.Key,
+		Name:     req.Name,
+		Type:     domain.CustomFieldType(req.Type),
+		Required: req.Required,
+		Options:  req.Options,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusCreated)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ItemExist)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "createCustomField, unhandled error useCase.CreateCustomField")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (CustomField) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 고객 추가 항목 삭제
+// @Description 고객 추가 항목 정의 삭제, 고객에 저장된 값은 다음 수정 때 정리됨, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param key path string true "추가 항목 키"
+// @Success 204 "삭제 성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 항목"
+// @Router /custom-field/{key} [delete]
+func (c *CustomFieldController) deleteCustomField(ctx echo.Context) error {
+	err := c.useCase.DeleteCustomField(ctx.Request().Context(), ctx.Param("key"))
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "deleteCustomField, unhandled error useCase.DeleteCustomField")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateCustomerCustomFieldsRequest struct {
+	UserId uuid.UUID              `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Values map[string]interface{} `json:"values" validate:"required" swaggertype:"object"`
+} // @name UpdateCustomerCustomFieldsRequest
+
+// @Tags (CustomField) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 추가 항목 값 수정
+// @Description 고객의 추가 항목 값을 통째로 교체, 타입이 맞지 않거나 필수 항목이 빠지면 400, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Param requestBody body UpdateCustomerCustomFieldsRequest true "추가 항목 값 (key: 값)"
+// @Success 204 "수정 성공"
+// @Failure 400 {object} domain.ErrorResponse "값이 정의와 맞지 않음"
+// @Failure 404 {object} domain.ErrorResponse "고객 없음"
+// @Router /customer/{user_id}/custom-field [put]
+func (c *CustomFieldController) updateCustomerCustomFields(ctx echo.Context) error {
+	var req UpdateCustomerCustomFieldsRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update customer custom fields, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateCustomerCustomFields(ctx.Request().Context(), domain.UpdateCustomerCustomFields{
+		UserId: req.UserId,
+		Values: req.Values,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", req.UserId).
+			Error(tag, "updateCustomerCustomFields, unhandled error useCase.UpdateCustomerCustomFields")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewCustomFieldRepository(db *gorm.DB) domain.CustomFieldRepository {
+	db.AutoMigrate(&domain.CustomField{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, field *domain.CustomField) error {
+	return r.db.WithContext(ctx).Save(field).Error
+}
+
+func (r *repo) Delete(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).
+		Delete(&domain.CustomField{}, "`key` = ?", key).Error
+}
+
+func (r *repo) GetByKey(ctx context.Context, key string) (field *domain.CustomField, err error) {
+	var entity domain.CustomField
+	err = r.db.WithContext(ctx).
+		Where("`key` = ?", key).
+		First(&entity).Error
+	if err == nil {
+		field = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
+
+func (r *repo) FetchAll(ctx context.Context) (list []domain.CustomField, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`created_at` asc").
+		Find(&list).Error
+	return
+}
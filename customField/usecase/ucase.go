@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewCustomFieldUseCase(
+	customFieldRepo domain.CustomFieldRepository,
+	customerRepo domain.CustomerRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.CustomFieldUseCase {
+	return &ucase{
+		customFieldRepo: customFieldRepo,
+		customerRepo:    customerRepo,
+		ids:             ids,
+		clock:           clock,
+		timeout:         timeout,
+	}
+}
+
+type ucase struct {
+	customFieldRepo domain.CustomFieldRepository
+	customerRepo    domain.CustomerRepository
+	ids             domain.IdGenerator
+	clock           domain.Clock
+	timeout         time.Duration
+}
+
+func (u *ucase) CreateCustomField(ctx context.Context, in domain.CreateCustomFieldInput) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	field, err := domain.CreateCustomField(domain.CreateCustomFieldOption{
+		Id:       u.ids.NewId(),
+		Key:      in.Key,
+		Name:     in.Name,
+		Type:     in.Type,
+		Required: in.Required,
+		Options:  in.Options,
+		Now:      u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	exists, err := u.customFieldRepo.GetByKey(c, field.Key)
+	if err != nil {
+		return
+	}
+	if exists != nil {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	err = u.customFieldRepo.Save(c, &field)
+	return
+}
+
+// DeleteCustomField 정의만 지움, 고객에 저장된 값은 다음 저장 때 정리됨
+func (u *ucase) DeleteCustomField(ctx context.Context, key string) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	exists, err := u.customFieldRepo.GetByKey(c, key)
+	if err != nil {
+		return
+	}
+	if exists == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = u.customFieldRepo.Delete(c, key)
+	return
+}
+
+func (u *ucase) UpdateCustomerCustomFields(ctx context.Context, in domain.UpdateCustomerCustomFields) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		fields   []domain.CustomField
+		customer *domain.Customer
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		fields, err = u.customFieldRepo.FetchAll(gc)
+		return
+	})
+	g.Go(func() (err error) {
+		customer, err = u.customerRepo.GetById(gc, in.UserId)
+		if err != nil {
+			return
+		}
+		if customer == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	values, err := domain.ValidateCustomFieldValues(fields, in.Values)
+	if err != nil {
+		return
+	}
+
+	err = customer.SetCustomFieldValues(values)
+	if err != nil {
+		return
+	}
+
+	err = u.customerRepo.Save(c, customer)
+	return
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchCustomFields(ctx context.Context) (res []domain.CustomFieldInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.customFieldRepo.FetchAll(c)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.CustomFieldInfo, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = domain.CustomFieldInfo{
+			Key:      src.Key,
+			Name:     src.Name,
+			Type:     src.Type,
+			Required: src.Required,
+			Options:  src.OptionList(),
+		}
+	}
+	return
+}
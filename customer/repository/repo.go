@@ -46,3 +46,13 @@ func (r *repo) Save(ctx context.Context, customer *domain.Customer) error {
 func (r *repo) With(tx gormx.Tx) domain.CustomerTxRepository {
 	return &repo{db: tx.Get()}
 }
+
+func (r *repo) FetchByEmails(ctx context.Context, emails []string) (list []domain.Customer, err error) {
+	if len(emails) == 0 {
+		return
+	}
+	err = r.db.WithContext(ctx).
+		Where("LOWER(`email`) IN ?", emails).
+		Find(&list).Error
+	return
+}
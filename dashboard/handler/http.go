@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[DASHBOARD] "
+)
+
+func NewDashboardController(useCase domain.DashboardUseCase) *DashboardController {
+	return &DashboardController{useCase: useCase}
+}
+
+type DashboardController struct {
+	useCase domain.DashboardUseCase
+}
+
+func (c *DashboardController) Bind(e *echo.Echo) {
+	// ===== ADMIN =====
+	e.GET("/dashboard/layout", echox.UserID(c.getLayout),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/dashboard/layout", echox.UserID(c.updateLayout),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// 위젯마다 따로 불러옴, 화면에서 동시에 요청
+	e.GET("/dashboard/widget/order-backlog", c.getOrderBacklog,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/dashboard/widget/my-assignments", echox.UserID(c.getMyAssignments),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/dashboard/widget/sla-breaches", c.getSlaBreaches,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== SUPER ADMIN =====
+	e.GET("/dashboard/widget/revenue", c.getRevenue,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+func roleOf(ctx echo.Context) domain.UserRole {
+	principal, _ := echox.PrincipalOf(ctx)
+	return domain.UserRole(principal.Role)
+}
+
+type DashboardLayoutResponse struct {
+	Widgets []string `json:"widgets" validate:"required" example:"ORDER_BACKLOG,MY_ASSIGNMENTS" enums:"ORDER_BACKLOG,MY_ASSIGNMENTS,SLA_BREACHES,REVENUE"`
+	// UpdatedAt, 저장한 적 없으면 null, 기본 배치
+	UpdatedAt *time.Time `json:"updatedAt" example:"2024-05-01T10:00:00+09:00"`
+} // @name DashboardLayoutResponse
+
+func layoutResponseOf(src domain.DashboardLayoutInfo) DashboardLayoutResponse {
+	widgets := make([]string, len(src.Widgets))
+	for i := range src.Widgets {
+		widgets[i] = string(src.Widgets[i])
+	}
+	return DashboardLayoutResponse{
+		Widgets:   widgets,
+		UpdatedAt: src.UpdatedAt,
+	}
+}
+
+// @Tags (Dashboard) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 내 대시보드 위젯 배치
+// @Description 보여줄 위젯 순서, 저장한 적 없으면 볼 수 있는 모든 위젯, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} DashboardLayoutResponse "성공"
+// @Router /dashboard/layout [get]
+func (c *DashboardController) getLayout(ctx echo.Context, userId uuid.UUID) error {
+	layout, err := c.useCase.GetDashboardLayout(ctx.Request().Context(), userId, roleOf(ctx))
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("adminId", userId).
+			Error(tag, "getLayout, unhandled error useCase.GetDashboardLayout")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, layoutResponseOf(layout))
+}
+
+type UpdateDashboardLayoutRequest struct {
+	// Widgets, 보여줄 순서, 빈 배열이면 모두 숨김, REVENUE 는 슈퍼 어드민만
+	Widgets []string `json:"widgets" validate:"required,max=20" example:"SLA_BREACHES,MY_ASSIGNMENTS" enums:"ORDER_BACKLOG,MY_ASSIGNMENTS,SLA_BREACHES,REVENUE"`
+} // @name UpdateDashboardLayoutRequest
+
+// @Tags (Dashboard) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 내 대시보드 위젯 배치 저장
+// @Description 보낸 순서 그대로 저장, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body UpdateDashboardLayoutRequest true "위젯 순서"
+// @Success 200 {object} DashboardLayoutResponse "저장"
+// @Failure 400 {object} domain.ErrorResponse "없거나 볼 수 없는 위젯, 중복된 위젯"
+// @Router /dashboard/layout [put]
+func (c *DashboardController) updateLayout(ctx echo.Context, userId uuid.UUID) error {
+	var req UpdateDashboardLayoutRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update layout, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	widgets := make([]domain.DashboardWidget, len(req.Widgets))
+	for i := range req.Widgets {
+		widgets[i] = domain.DashboardWidget(req.Widgets[i])
+	}
+
+	layout, err := c.useCase.UpdateDashboardLayout(ctx.Request().Context(), domain.UpdateDashboardLayout{
+		AdminId: userId,
+		Role:    roleOf(ctx),
+		Widgets: widgets,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, layoutResponseOf(layout))
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("adminId", userId).
+			Error(tag, "updateLayout, unhandled error useCase.UpdateDashboardLayout")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type OrderBacklogResponse struct {
+	// Ready, 담당자 배정 전
+	Ready int64 `json:"ready" validate:"required" example:"4"`
+	// Processing, 담당자 배정 후 끝나지 않은 의뢰
+	Processing int64 `json:"processing" validate:"required" example:"17"`
+} // @name OrderBacklogResponse
+
+// @Tags (Dashboard) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 위젯, 밀린 의뢰
+// @Description 임시 의뢰 제외, 끝나지 않은 의뢰 수, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} OrderBacklogResponse "성공"
+// @Router /dashboard/widget/order-backlog [get]
+func (c *DashboardController) getOrderBacklog(ctx echo.Context) error {
+	backlog, err := c.useCase.GetOrderBacklog(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getOrderBacklog, unhandled error useCase.GetOrderBacklog")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, OrderBacklogResponse{
+		Ready:      backlog.Ready,
+		Processing: backlog.Processing,
+	})
+}
+
+type MyAssignmentsResponse struct {
+	OpenOrders int64 `json:"openOrders" validate:"required" example:"5"`
+	// OverdueOrders, 마감 날짜(KST)가 오늘 전인 의뢰
+	OverdueOrders int64 `json:"overdueOrders" validate:"required" example:"1"`
+	OpenTasks     int64 `json:"openTasks" validate:"required" example:"3"`
+	// OverdueTasks, 마감 시각이 지난 할 일
+	OverdueTasks int64 `json:"overdueTasks" validate:"required" example:"0"`
+} // @name MyAssignmentsResponse
+
+// @Tags (Dashboard) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 위젯, 내 담당
+// @Description 나에게 배정된 끝나지 않은 의뢰, 열린 할 일 수, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} MyAssignmentsResponse "성공"
+// @Router /dashboard/widget/my-assignments [get]
+func (c *DashboardController) getMyAssignments(ctx echo.Context, userId uuid.UUID) error {
+	assignments, err := c.useCase.GetMyAssignments(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("adminId", userId).
+			Error(tag, "getMyAssignments, unhandled error useCase.GetMyAssignments")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, MyAssignmentsResponse{
+		OpenOrders:    assignments.OpenOrders,
+		OverdueOrders: assignments.OverdueOrders,
+		OpenTasks:     assignments.OpenTasks,
+		OverdueTasks:  assignments.OverdueTasks,
+	})
+}
+
+type SlaBreachOrderResponse struct {
+	OrderId  uuid.UUID  `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Number   *string    `json:"number" example:"EF-2024-00123"`
+	DueDate  time.Time  `json:"dueDate" validate:"required" example:"2021-10-30T00:00:00+00:00"`
+	Assignee *uuid.UUID `json:"assignee" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name SlaBreachOrderResponse
+
+type SlaBreachesResponse struct {
+	Count int64 `json:"count" validate:"required" example:"2"`
+	// Orders, 마감이 오래된 순, 최대 10개
+	Orders []SlaBreachOrderResponse `json:"orders" validate:"required"`
+} // @name SlaBreachesResponse
+
+// @Tags (Dashboard) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 위젯, 마감 지난 의뢰
+// @Description 마감 날짜(KST)가 오늘 전인데 끝나지 않은 의뢰, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} SlaBreachesResponse "성공"
+// @Router /dashboard/widget/sla-breaches [get]
+func (c *DashboardController) getSlaBreaches(ctx echo.Context) error {
+	breaches, err := c.useCase.GetSlaBreaches(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getSlaBreaches, unhandled error useCase.GetSlaBreaches")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	orders := make([]SlaBreachOrderResponse, len(breaches.Orders))
+	for i, order := range breaches.Orders {
+		orders[i] = SlaBreachOrderResponse{
+			OrderId:  order.OrderId,
+			Number:   order.Number,
+			DueDate:  order.DueDate,
+			Assignee: order.Assignee,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, SlaBreachesResponse{
+		Count:  breaches.Count,
+		Orders: orders,
+	})
+}
+
+type RevenueResponse struct {
+	Month string `json:"month" validate:"required" example:"2024-05"`
+	// Amount, 이번 달 지금까지 만든 이용권 결제 금액 합 (원)
+	Amount    int64  `json:"amount" validate:"required" example:"1290000"`
+	Tickets   int64  `json:"tickets" validate:"required" example:"12"`
+	LastMonth string `json:"lastMonth" validate:"required" example:"2024-04"`
+	// LastAmount, 지난 달 전체
+	LastAmount  int64 `json:"lastAmount" validate:"required" example:"3480000"`
+	LastTickets int64 `json:"lastTickets" validate:"required" example:"31"`
+} // @name RevenueResponse
+
+// @Tags (Dashboard) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 위젯, 매출
+// @Description 이번 달(KST) 지금까지와 지난 달 이용권 결제 금액, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} RevenueResponse "성공"
+// @Router /dashboard/widget/revenue [get]
+func (c *DashboardController) getRevenue(ctx echo.Context) error {
+	revenue, err := c.useCase.GetRevenue(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getRevenue, unhandled error useCase.GetRevenue")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, RevenueResponse{
+		Month:       revenue.Month,
+		Amount:      revenue.Amount,
+		Tickets:     revenue.Tickets,
+		LastMonth:   revenue.LastMonth,
+		LastAmount:  revenue.LastAmount,
+		LastTickets: revenue.LastTickets,
+	})
+}
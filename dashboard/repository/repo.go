@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewDashboardRepository(db *gorm.DB) domain.DashboardRepository {
+	db.AutoMigrate(&domain.DashboardLayout{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) SaveLayout(ctx context.Context, layout *domain.DashboardLayout) error {
+	return gormx.Upsert(ctx, r.db, layout)
+}
+
+func (r *repo) GetLayoutByAdminId(ctx context.Context, adminId uuid.UUID) (layout *domain.DashboardLayout, err error) {
+	var entity domain.DashboardLayout
+	err = r.db.WithContext(ctx).First(&entity, adminId).Error
+	if err == nil {
+		layout = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
+
+func (r *repo) CountOrderBacklog(ctx context.Context) (ready, processing int64, err error) {
+	var res struct {
+		Ready      int64
+		Processing int64
+	}
+	err = r.db.WithContext(ctx).
+		Table("order").
+		Select("COALESCE(SUM(CASE WHEN `assignee` IS NULL THEN 1 END), 0) AS `ready`, "+
+			"COALESCE(SUM(CASE WHEN `assignee` IS NOT NULL THEN 1 END), 0) AS `processing`").
+		Where("`is_draft` = ? AND `done_at` IS NULL", false).
+		Scan(&res).Error
+	return res.Ready, res.Processing, err
+}
+
+func (r *repo) CountAssignedOrders(ctx context.Context, assignee uuid.UUID, today time.Time) (open, overdue int64, err error) {
+	var res struct {
+		Open    int64
+		Overdue int64
+	}
+	err = r.db.WithContext(ctx).
+		Table("order").
+		Select("COUNT(*) AS `open`, COALESCE(SUM(CASE WHEN `due_date` < ? THEN 1 END), 0) AS `overdue`", today).
+		Where("`assignee` = ? AND `is_draft` = ? AND `done_at` IS NULL", assignee, false).
+		Scan(&res).Error
+	return res.Open, res.Overdue, err
+}
+
+func (r *repo) CountAssignedTasks(ctx context.Context, assignee uuid.UUID, now time.Time) (open, overdue int64, err error) {
+	var res struct {
+		Open    int64
+		Overdue int64
+	}
+	err = r.db.WithContext(ctx).
+		Table("task").
+		Select("COUNT(*) AS `open`, COALESCE(SUM(CASE WHEN `due_at` < ? THEN 1 END), 0) AS `overdue`", now).
+		Where("`assignee_id` = ? AND `done_at` IS NULL", assignee).
+		Scan(&res).Error
+	return res.Open, res.Overdue, err
+}
+
+func (r *repo) slaBreaches(ctx context.Context, today time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&domain.Order{}).
+		Where("`is_draft` = ? AND `done_at` IS NULL AND `due_date` < ?", false, today)
+}
+
+func (r *repo) CountSlaBreaches(ctx context.Context, today time.Time) (count int64, err error) {
+	err = r.slaBreaches(ctx, today).Count(&count).Error
+	return
+}
+
+func (r *repo) FetchSlaBreaches(ctx context.Context, today time.Time, limit int) (list []domain.DashboardSlaBreach, err error) {
+	err = r.slaBreaches(ctx, today).
+		Select("`id` AS `order_id`, `number`, `due_date`, `assignee`").
+		Order("`due_date` asc").
+		Order("`ordered_at` asc").
+		Limit(limit).
+		Scan(&list).Error
+	return
+}
+
+func (r *repo) SumRevenue(ctx context.Context, from, to time.Time) (amount, tickets int64, err error) {
+	var res struct {
+		Amount  int64
+		Tickets int64
+	}
+	err = r.db.WithContext(ctx).
+		Table("order_ticket").
+		Select("COALESCE(SUM(`amount`), 0) AS `amount`, COUNT(*) AS `tickets`").
+		Where("`created_at` >= ? AND `created_at` < ?", from, to).
+		Scan(&res).Error
+	return res.Amount, res.Tickets, err
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewDashboardUseCase(
+	dashboardRepo domain.DashboardRepository,
+	calendar domain.Calendar,
+	timeout time.Duration,
+) domain.DashboardUseCase {
+	return &ucase{
+		dashboardRepo: dashboardRepo,
+		calendar:      calendar,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	dashboardRepo domain.DashboardRepository
+	calendar      domain.Calendar
+	timeout       time.Duration
+}
+
+func layoutInfoOf(layout domain.DashboardLayout, role domain.UserRole) domain.DashboardLayoutInfo {
+	updatedAt := layout.UpdatedAt
+	return domain.DashboardLayoutInfo{
+		Widgets:   domain.DashboardWidgetsFor(layout.ParseWidgets(), role),
+		UpdatedAt: &updatedAt,
+	}
+}
+
+func (u *ucase) UpdateDashboardLayout(ctx context.Context, in domain.UpdateDashboardLayout) (res domain.DashboardLayoutInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	layout := domain.DashboardLayout{AdminId: in.AdminId}
+	err = layout.Update(in.Widgets, in.Role, u.calendar.Now())
+	if err != nil {
+		return
+	}
+
+	err = u.dashboardRepo.SaveLayout(c, &layout)
+	if err != nil {
+		return
+	}
+
+	res = layoutInfoOf(layout, in.Role)
+	return
+}
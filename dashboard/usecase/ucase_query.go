@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func (u *ucase) GetDashboardLayout(ctx context.Context, adminId uuid.UUID, role domain.UserRole) (res domain.DashboardLayoutInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	layout, err := u.dashboardRepo.GetLayoutByAdminId(c, adminId)
+	if err != nil {
+		return
+	}
+
+	if layout == nil {
+		res.Widgets = domain.DashboardWidgetsFor(domain.DashboardWidgets, role)
+		return
+	}
+
+	res = layoutInfoOf(*layout, role)
+	return
+}
+
+func (u *ucase) GetOrderBacklog(ctx context.Context) (res domain.DashboardOrderBacklog, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	res.Ready, res.Processing, err = u.dashboardRepo.CountOrderBacklog(c)
+	return
+}
+
+// GetMyAssignments 의뢰는 마감 날짜(기준 시간대)가 오늘 전이면, 할 일은 마감 시각이 지나면 지난 것
+func (u *ucase) GetMyAssignments(ctx context.Context, adminId uuid.UUID) (res domain.DashboardMyAssignments, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	now := u.calendar.Now()
+	today := u.calendar.DateOf(now)
+
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		res.OpenOrders, res.OverdueOrders, err = u.dashboardRepo.CountAssignedOrders(gc, adminId, today)
+		return
+	})
+	g.Go(func() (err error) {
+		res.OpenTasks, res.OverdueTasks, err = u.dashboardRepo.CountAssignedTasks(gc, adminId, now)
+		return
+	})
+	err = g.Wait()
+	return
+}
+
+func (u *ucase) GetSlaBreaches(ctx context.Context) (res domain.DashboardSlaBreaches, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	today := u.calendar.DateOf(u.calendar.Now())
+
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		res.Count, err = u.dashboardRepo.CountSlaBreaches(gc, today)
+		return
+	})
+	g.Go(func() (err error) {
+		res.Orders, err = u.dashboardRepo.FetchSlaBreaches(gc, today, domain.DashboardSlaBreachLimit)
+		return
+	})
+	err = g.Wait()
+	return
+}
+
+// GetRevenue 이번 달은 지금까지, 지난 달은 한 달 전체
+func (u *ucase) GetRevenue(ctx context.Context) (res domain.DashboardRevenue, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	loc := u.calendar.Location()
+	start, end, err := domain.ParseFinanceMonth(u.calendar.Now().Format(domain.FinanceMonthLayout), loc)
+	if err != nil {
+		return
+	}
+	lastStart := start.AddDate(0, -1, 0)
+
+	res.Month = start.Format(domain.FinanceMonthLayout)
+	res.LastMonth = lastStart.Format(domain.FinanceMonthLayout)
+
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		res.Amount, res.Tickets, err = u.dashboardRepo.SumRevenue(gc, start, end)
+		return
+	})
+	g.Go(func() (err error) {
+		res.LastAmount, res.LastTickets, err = u.dashboardRepo.SumRevenue(gc, lastStart, start)
+		return
+	})
+	err = g.Wait()
+	return
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[DEAD_LETTER] "
+)
+
+func NewDeadLetterController(useCase domain.DeadLetterUseCase) *DeadLetterController {
+	return &DeadLetterController{useCase: useCase}
+}
+
+type DeadLetterController struct {
+	useCase domain.DeadLetterUseCase
+}
+
+func (c *DeadLetterController) Bind(e *echo.Echo) {
+	// ===== SUPER ADMIN =====
+	e.GET("/dead-letters/:kind", c.fetchDeadLetters,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.GET("/dead-letters/:kind/:letterId", c.getDeadLetter,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.POST("/dead-letters/:kind/retry", c.retryDeadLetters,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.POST("/dead-letters/:kind/purge", c.purgeDeadLetters,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type DeadLetterResponse struct {
+	Id   uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Kind string    `json:"kind" validate:"required" example:"HOOK" enums:"OUTBOX,HOOK"`
+	// Type, outbox 면 이벤트 종류, hook 이면 구독 이벤트
+	Type string `json:"type" validate:"required" example:"order.done"`
+	// Target, outbox 면 aggregate 종류/아이디, hook 이면 받는 주소
+	Target    string    `json:"target" validate:"required" example:"https://hooks.zapier.com/hooks/standard/123456/abcdef/"`
+	Attempts  uint16    `json:"attempts" validate:"required" example:"8"`
+	LastError *string   `json:"lastError" example:"unexpected status 500"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name DeadLetterResponse
+
+type DeadLetterDetailResponse struct {
+	DeadLetterResponse
+	// Payload 보내려던 본문(JSON) 그대로
+	Payload string `json:"payload" validate:"required" example:"{\"id\":\"order.done/550e8400-e29b-41d4-a716-446655440000\"}"`
+} // @name DeadLetterDetailResponse
+
+func responseOf(src domain.DeadLetter) DeadLetterResponse {
+	return DeadLetterResponse{
+		Id:        src.Id,
+		Kind:      string(src.Kind),
+		Type:      src.Type,
+		Target:    src.Target,
+		Attempts:  src.Attempts,
+		LastError: src.LastError,
+		CreatedAt: src.CreatedAt,
+	}
+}
+
+type FetchDeadLettersRequest struct {
+	Kind  string `param:"kind" validate:"required"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=500"`
+} // @name FetchDeadLettersRequest
+
+// @Tags (DeadLetter) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 보내지 못한 메시지 목록
+// @Description 재시도를 다 써서 더 이상 보내지 않는 outbox 이벤트, REST Hook 전송, 최근 순, 본문은 상세에서, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param kind path string true "출처" Enums(OUTBOX, HOOK)
+// @Param before query string false "이 시각(RFC3339)보다 먼저 만든 것만, 다음 쪽은 이전 목록의 마지막 createdAt"
+// @Param limit query int false "개수 (기본 50, 최대 500)"
+// @Success 200 {array} DeadLetterResponse "성공"
+// @Success 204 "없음"
+// @Failure 400 {object} domain.ErrorResponse "없는 출처, 잘못된 시각"
+// @Router /dead-letters/{kind} [get]
+func (c *DeadLetterController) fetchDeadLetters(ctx echo.Context) error {
+	var req FetchDeadLettersRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch dead letters, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	var before *time.Time
+	if raw := ctx.QueryParam("before"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		}
+		before = &parsed
+	}
+
+	list, err := c.useCase.FetchDeadLetters(ctx.Request().Context(), domain.FetchDeadLetters{
+		Kind:   domain.DeadLetterKind(req.Kind),
+		Before: before,
+		Limit:  req.Limit,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("kind", req.Kind).
+			Error(tag, "fetchDeadLetters, unhandled error useCase.FetchDeadLetters")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]DeadLetterResponse, len(list))
+	for i := range list {
+		res[i] = responseOf(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (DeadLetter) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 보내지 못한 메시지 상세
+// @Description 보내려던 본문 포함, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param kind path string true "출처" Enums(OUTBOX, HOOK)
+// @Param letter_id path string true "outbox 이벤트 또는 hook 전송 아이디(UUID)"
+// @Success 200 {object} DeadLetterDetailResponse "성공"
+// @Failure 400 {object} domain.ErrorResponse "없는 출처"
+// @Failure 404 {object} domain.ErrorResponse "없거나 아직 재시도 중인 메시지"
+// @Router /dead-letters/{kind}/{letter_id} [get]
+func (c *DeadLetterController) getDeadLetter(ctx echo.Context) error {
+	var req struct {
+		Kind     string    `param:"kind"`
+		LetterId uuid.UUID `param:"letterId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get dead letter, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	letter, err := c.useCase.GetDeadLetter(ctx.Request().Context(), domain.DeadLetterKind(req.Kind), req.LetterId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, DeadLetterDetailResponse{
+			DeadLetterResponse: responseOf(letter),
+			Payload:            letter.Payload,
+		})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("kind", req.Kind).
+			WithField("letterId", req.LetterId).
+			Error(tag, "getDeadLetter, unhandled error useCase.GetDeadLetter")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type HandleDeadLettersRequest struct {
+	Kind string `json:"-" param:"kind"`
+	// Ids, all 이 아니면 필수
+	Ids []uuid.UUID `json:"ids" validate:"omitempty,max=500" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// All, 출처의 보내지 못한 메시지 전부
+	All bool `json:"all" example:"false"`
+} // @name HandleDeadLettersRequest
+
+type HandleDeadLettersResponse struct {
+	// Affected, 재시도 예약 또는 삭제한 개수, 그 사이 다른 처리가 된 메시지는 제외
+	Affected int64 `json:"affected" validate:"required" example:"3"`
+} // @name HandleDeadLettersResponse
+
+// @Tags (DeadLetter) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 보내지 못한 메시지 재시도
+// @Description 시도 횟수를 되돌려 다음 발행/전송 작업(/internal/outbox/dispatch, /internal/hooks/deliver)에서 다시 보냄, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param kind path string true "출처" Enums(OUTBOX, HOOK)
+// @Param requestBody body HandleDeadLettersRequest true "아이디 목록 또는 전체"
+// @Success 200 {object} HandleDeadLettersResponse "재시도 예약"
+// @Failure 400 {object} domain.ErrorResponse "없는 출처, 아이디와 전체 둘 다 없음"
+// @Router /dead-letters/{kind}/retry [post]
+func (c *DeadLetterController) retryDeadLetters(ctx echo.Context) error {
+	return c.handleDeadLetters(ctx, "retry", c.useCase.RetryDeadLetters)
+}
+
+// @Tags (DeadLetter) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 보내지 못한 메시지 삭제
+// @Description 되돌릴 수 없음, 재시도 중인 메시지는 지우지 않음, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param kind path string true "출처" Enums(OUTBOX, HOOK)
+// @Param requestBody body HandleDeadLettersRequest true "아이디 목록 또는 전체"
+// @Success 200 {object} HandleDeadLettersResponse "삭제"
+// @Failure 400 {object} domain.ErrorResponse "없는 출처, 아이디와 전체 둘 다 없음"
+// @Router /dead-letters/{kind}/purge [post]
+func (c *DeadLetterController) purgeDeadLetters(ctx echo.Context) error {
+	return c.handleDeadLetters(ctx, "purge", c.useCase.PurgeDeadLetters)
+}
+
+func (c *DeadLetterController) handleDeadLetters(
+	ctx echo.Context,
+	action string,
+	fn func(context.Context, domain.HandleDeadLetters) (int64, error),
+) error {
+	var req HandleDeadLettersRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, action, " dead letters, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	affected, err := fn(ctx.Request().Context(), domain.HandleDeadLetters{
+		Kind: domain.DeadLetterKind(req.Kind),
+		Ids:  req.Ids,
+		All:  req.All,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, HandleDeadLettersResponse{Affected: affected})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("kind", req.Kind).
+			WithField("action", action).
+			Error(tag, "handleDeadLetters, unhandled error useCase")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[DEAD_LETTER] "
+
+func NewDeadLetterUseCase(repos domain.DeadLetterRepositories, timeout time.Duration) domain.DeadLetterUseCase {
+	return &ucase{
+		repos:   repos,
+		timeout: timeout,
+	}
+}
+
+type ucase struct {
+	repos   domain.DeadLetterRepositories
+	timeout time.Duration
+}
+
+// repoOf 없는 출처는 ErrWeirdData
+func (u *ucase) repoOf(kind domain.DeadLetterKind) (domain.DeadLetterRepository, error) {
+	repo, ok := u.repos[kind]
+	if !ok {
+		return nil, domain.ErrWeirdData
+	}
+	return repo, nil
+}
+
+func (u *ucase) RetryDeadLetters(ctx context.Context, in domain.HandleDeadLetters) (retried int64, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	repo, err := u.repoOf(in.Kind)
+	if err != nil {
+		return
+	}
+	if !in.All && len(in.Ids) == 0 {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	retried, err = repo.RetryDead(c, idsOf(in))
+	if err != nil {
+		return
+	}
+
+	logx.From(ctx).WithField("kind", in.Kind).
+		WithField("all", in.All).
+		WithField("retried", retried).
+		Info(tag, "retry dead letters")
+	return
+}
+
+func (u *ucase) PurgeDeadLetters(ctx context.Context, in domain.HandleDeadLetters) (purged int64, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	repo, err := u.repoOf(in.Kind)
+	if err != nil {
+		return
+	}
+	if !in.All && len(in.Ids) == 0 {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	purged, err = repo.PurgeDead(c, idsOf(in))
+	if err != nil {
+		return
+	}
+
+	logx.From(ctx).WithField("kind", in.Kind).
+		WithField("all", in.All).
+		WithField("purged", purged).
+		Info(tag, "purge dead letters")
+	return
+}
+
+// idsOf 전체면 nil, 저장소는 비어있는 ids 를 전체로 취급
+func idsOf(in domain.HandleDeadLetters) []uuid.UUID {
+	if in.All {
+		return nil
+	}
+	return in.Ids
+}
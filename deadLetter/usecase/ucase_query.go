@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchDeadLetters(ctx context.Context, in domain.FetchDeadLetters) (list []domain.DeadLetter, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	repo, err := u.repoOf(in.Kind)
+	if err != nil {
+		return
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = domain.DeadLetterDefaultLimit
+	}
+	list, err = repo.FetchDead(c, domain.FetchDeadLetterOption{
+		Before: in.Before,
+		Limit:  limit,
+	})
+	return
+}
+
+func (u *ucase) GetDeadLetter(ctx context.Context, kind domain.DeadLetterKind, id uuid.UUID) (letter domain.DeadLetter, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	repo, err := u.repoOf(kind)
+	if err != nil {
+		return
+	}
+
+	found, err := repo.GetDead(c, id)
+	if err != nil {
+		return
+	}
+	if found == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	letter = *found
+	return
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AnalyticsEventRateLimit 고객별 AnalyticsEventRateWindow 동안 수집 가능한 이벤트 수, 로그인하지 않은 요청은 IP 별
+	AnalyticsEventRateLimit  = 600
+	AnalyticsEventRateWindow = time.Minute
+)
+
+type AnalyticsPropertyType string
+
+const (
+	AnalyticsPropertyTypeString AnalyticsPropertyType = "string"
+	AnalyticsPropertyTypeNumber AnalyticsPropertyType = "number"
+	AnalyticsPropertyTypeBool   AnalyticsPropertyType = "bool"
+)
+
+func (t AnalyticsPropertyType) Match(value interface{}) bool {
+	switch value.(type) {
+	case string:
+		return t == AnalyticsPropertyTypeString
+	case float64:
+		return t == AnalyticsPropertyTypeNumber
+	case bool:
+		return t == AnalyticsPropertyTypeBool
+	default:
+		return false
+	}
+}
+
+// AnalyticsEventSchema 이벤트 이름별 필수 속성과 타입
+type AnalyticsEventSchema map[string]AnalyticsPropertyType
+
+// AnalyticsEventSchemas 수집 가능한 이벤트 목록, 등록되지 않은 이벤트는 거절
+var AnalyticsEventSchemas = map[string]AnalyticsEventSchema{
+	"page_view": {
+		"path": AnalyticsPropertyTypeString,
+	},
+	"plan_view": {
+		"planKey": AnalyticsPropertyTypeString,
+	},
+	"order_request_click": {
+		"remainingOrderCount": AnalyticsPropertyTypeNumber,
+	},
+	"video_preview": {
+		"orderId":  AnalyticsPropertyTypeString,
+		"complete": AnalyticsPropertyTypeBool,
+	},
+}
+
+func ValidateAnalyticsEvent(name string, properties map[string]interface{}) error {
+	schema, ok := AnalyticsEventSchemas[name]
+	if !ok {
+		return fmt.Errorf("unknown event name=%s", name)
+	}
+
+	for key, typ := range schema {
+		value, ok := properties[key]
+		if !ok {
+			return fmt.Errorf("event name=%s, property=%s required", name, key)
+		}
+
+		if !typ.Match(value) {
+			return fmt.Errorf("event name=%s, property=%s must be %s", name, key, typ)
+		}
+	}
+	return nil
+}
+
+// AnalyticsEvent 제품 이벤트 로그, 추가만 가능, Ip 는 로그인하지 않은 요청의 수집 한도 확인용
+type AnalyticsEvent struct {
+	Id         uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	Name       string     `gorm:"size:60;index;not null"`
+	CustomerId *uuid.UUID `gorm:"type:char(36);index"`
+	Ip         string     `gorm:"size:45;index;not null"`
+	Properties string     `gorm:"type:json;not null"`
+	OccurredAt time.Time  `gorm:"type:datetime(6);not null"`
+	CreatedAt  time.Time  `gorm:"type:datetime(6);index;not null"`
+}
+
+func (AnalyticsEvent) TableName() string {
+	return "analytics_event"
+}
+
+type AnalyticsRepository interface {
+	SaveAll(ctx context.Context, events []AnalyticsEvent) error
+
+	CountByCustomerIdSince(ctx context.Context, customerId uuid.UUID, since time.Time) (int64, error)
+	// CountAnonymousByIpSince 고객 없이 ip 에서 수집한 이벤트 수
+	CountAnonymousByIpSince(ctx context.Context, ip string, since time.Time) (int64, error)
+}
+
+type TrackAnalyticsEvent struct {
+	Name       string
+	Properties map[string]interface{}
+	OccurredAt *time.Time
+}
+
+type TrackAnalyticsEvents struct {
+	UserId *uuid.UUID
+	Ip     string
+	Events []TrackAnalyticsEvent
+}
+
+type AnalyticsUseCase interface {
+	TrackEvents(ctx context.Context, in TrackAnalyticsEvents) error
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ApiKeyRateLimit API 키(범위를 줄인 토큰)별 ApiKeyRateWindow 동안 허용 요청 수, 넘으면 429
+	ApiKeyRateLimit  = 600
+	ApiKeyRateWindow = time.Minute
+
+	// ApiUsageMaxPeriod 사용량 조회 최대 기간, 토큰 최대 유효 기간과 같음
+	ApiUsageMaxPeriod = ScopedTokenMaxTTL
+)
+
+// ApiUsage API 키의 ApiKeyRateWindow 한 구간 요청 수, 서버가 여러 대여도 같은 행에 더함
+type ApiUsage struct {
+	Id          uuid.UUID `gorm:"type:char(36);primaryKey"`
+	KeyId       uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_api_usage_key_window;not null"`
+	WindowStart time.Time `gorm:"type:datetime;uniqueIndex:idx_api_usage_key_window;index;not null"`
+	UserId      uuid.UUID `gorm:"type:char(36);index;not null"`
+	// Requests 한도를 넘어 429 로 거절한 요청도 포함
+	Requests int64 `gorm:"not null"`
+}
+
+func (ApiUsage) TableName() string {
+	return "api_usage"
+}
+
+// RateLimit 응답 X-RateLimit-* 헤더 값
+type RateLimit struct {
+	Limit     int64
+	Remaining int64
+	// Reset 현재 구간이 끝나는 시각
+	Reset    time.Time
+	Exceeded bool
+}
+
+// ApiKeyUsage 기간 동안 키 하나의 사용량
+type ApiKeyUsage struct {
+	KeyId    uuid.UUID
+	Requests int64
+	// Throttled 한도를 넘어 429 로 거절한 요청 수
+	Throttled  int64
+	LastUsedAt time.Time
+}
+
+type ApiUsageRepository interface {
+	// Hit keyId 의 windowStart 구간 요청 수를 하나 올리고 올린 뒤 값 반환
+	Hit(ctx context.Context, id, keyId, userId uuid.UUID, windowStart time.Time) (int64, error)
+	// SumByUser userId 가 발급한 키별 since 이후 합계, limit 을 넘은 만큼은 Throttled
+	SumByUser(ctx context.Context, userId uuid.UUID, since time.Time, limit int64) ([]ApiKeyUsage, error)
+}
+
+type ApiKeyUsageInfo struct {
+	ApiKeyUsage
+	// Current 지금 구간의 한도 상태
+	Current RateLimit
+}
+
+type ApiUsageUseCase interface {
+	// Hit 요청 하나를 기록하고 한도 상태 반환
+	Hit(ctx context.Context, keyId, userId uuid.UUID) (RateLimit, error)
+
+	// FetchMyApiUsage 요청자가 발급한 키별 period 동안 사용량, period 는 ApiUsageMaxPeriod 까지
+	FetchMyApiUsage(ctx context.Context, userId uuid.UUID, period time.Duration) ([]ApiKeyUsageInfo, error)
+}
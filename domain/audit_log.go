@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AuditLogDateLayout 조회 기간 날짜 형식 (ex. 2024-05-01)
+	AuditLogDateLayout = "2006-01-02"
+	// AuditLogMaxPeriod 한 번에 조회할 수 있는 기간
+	AuditLogMaxPeriod = 93 * 24 * time.Hour
+	// AuditLogDefaultLimit 목록 기본 개수
+	AuditLogDefaultLimit = 50
+)
+
+// AuditAction 감사 로그에 남기는 관리자 변경 작업
+type AuditAction string
+
+const (
+	AuditActionAdminCreated              AuditAction = "ADMIN_CREATED"
+	AuditActionAdminDeleted              AuditAction = "ADMIN_DELETED"
+	AuditActionAdminInfoForceUpdated     AuditAction = "ADMIN_INFO_FORCE_UPDATED"
+	AuditActionAdminPasswordForceUpdated AuditAction = "ADMIN_PASSWORD_FORCE_UPDATED"
+	AuditActionCustomerDeleted           AuditAction = "CUSTOMER_DELETED"
+	// AuditActionOrdersReassigned 대상은 의뢰를 넘긴 담당자
+	AuditActionOrdersReassigned AuditAction = "ORDERS_REASSIGNED"
+	// AuditActionSubscriptionAttached 대상은 구독 상품을 붙인 고객
+	AuditActionSubscriptionAttached AuditAction = "SUBSCRIPTION_ATTACHED"
+	AuditActionUserRestored         AuditAction = "USER_RESTORED"
+	// AuditActionCustomerMerged 대상은 합쳐져 삭제된 고객, Detail 은 남은 고객 Id
+	AuditActionCustomerMerged AuditAction = "CUSTOMER_MERGED"
+	// AuditActionPasswordRotationForced 대상 없음, Detail 은 역할
+	AuditActionPasswordRotationForced AuditAction = "PASSWORD_ROTATION_FORCED"
+	// AuditActionSettingUpdated, AuditActionSettingReset 대상 없음, Detail 은 설정 키
+	AuditActionSettingUpdated AuditAction = "SETTING_UPDATED"
+	AuditActionSettingReset   AuditAction = "SETTING_RESET"
+	// AuditActionTenantCredentialSet, AuditActionTenantCredentialDeleted 대상 없음, Detail 은 테넌트/연동 (값은 남기지 않음)
+	AuditActionTenantCredentialSet     AuditAction = "TENANT_CREDENTIAL_SET"
+	AuditActionTenantCredentialDeleted AuditAction = "TENANT_CREDENTIAL_DELETED"
+	// AuditActionCreditAdjusted 대상은 고객, Detail 은 조정한 크레딧
+	AuditActionCreditAdjusted AuditAction = "CREDIT_ADJUSTED"
+)
+
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionAdminCreated,
+		AuditActionAdminDeleted,
+		AuditActionAdminInfoForceUpdated,
+		AuditActionAdminPasswordForceUpdated,
+		AuditActionCustomerDeleted,
+		AuditActionOrdersReassigned,
+		AuditActionSubscriptionAttached,
+		AuditActionUserRestored,
+		AuditActionCustomerMerged,
+		AuditActionPasswordRotationForced,
+		AuditActionSettingUpdated,
+		AuditActionSettingReset,
+		AuditActionTenantCredentialSet,
+		AuditActionTenantCredentialDeleted,
+		AuditActionCreditAdjusted:
+		return true
+	}
+	return false
+}
+
+// AuditLog 관리자 변경 작업 기록, 수정, 삭제하지 않음
+type AuditLog struct {
+	Id       uuid.UUID   `gorm:"type:char(36);primaryKey"`
+	ActorId  uuid.UUID   `gorm:"type:char(36);index;not null"`
+	TargetId uuid.UUID   `gorm:"type:char(36);index;not null"`
+	Action   AuditAction `gorm:"size:40;index;not null"`
+	// Ip 요청한 관리자 IP, IPv6 포함
+	Ip string `gorm:"size:45;not null"`
+	// Detail 대상이 유저가 아닌 작업의 대상 (설정 키 등), 대상이 없으면 TargetId 는 uuid.Nil
+	Detail    string    `gorm:"size:200;not null;default:''"`
+	CreatedAt time.Time `gorm:"type:datetime(6);index;not null"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+// AuditEntry 유스케이스가 남기는 한 건
+type AuditEntry struct {
+	ActorId  uuid.UUID
+	TargetId uuid.UUID
+	Action   AuditAction
+	Ip       string
+	Detail   string
+}
+
+// AuditLogger 관리자 변경 작업을 마친 뒤 모든 유스케이스가 호출
+// 이미 끝난 작업은 되돌리지 않으므로 에러를 돌려주지 않고 실패는 로그로만 남김
+type AuditLogger interface {
+	Record(ctx context.Context, entry AuditEntry)
+}
+
+// AuditExporter 남긴 감사 로그를 보안 관제(SIEM)로 보냄, 호출을 막지 않고 버퍼가 차면 버림
+type AuditExporter interface {
+	Export(log AuditLog)
+	// Close 버퍼에 남은 기록을 ctx 가 끝날 때까지 보냄
+	Close(ctx context.Context) error
+}
+
+type FetchAuditLogOption struct {
+	// From, To [From, To) 기간
+	From     time.Time
+	To       time.Time
+	ActorId  *uuid.UUID
+	TargetId *uuid.UUID
+	Action   *AuditAction
+	// Before 이 시각보다 먼저 남긴 것만, 다음 쪽을 가져올 때 이전 목록의 마지막 CreatedAt
+	Before *time.Time
+	Limit  int
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *AuditLog) error
+	// Fetch 최근 기록부터
+	Fetch(ctx context.Context, option FetchAuditLogOption) ([]AuditLog, error)
+}
+
+type FetchAuditLogs struct {
+	// From, To 기준 시간대(KST) 날짜 (AuditLogDateLayout), 둘 다 포함
+	From     string
+	To       string
+	ActorId  *uuid.UUID
+	TargetId *uuid.UUID
+	Action   *AuditAction
+	Before   *time.Time
+	Limit    int
+}
+
+type AuditLogInfo struct {
+	Id        uuid.UUID
+	ActorId   uuid.UUID
+	TargetId  uuid.UUID
+	Action    AuditAction
+	Ip        string
+	Detail    string
+	CreatedAt time.Time
+}
+
+type AuditLogUseCase interface {
+	// FetchAuditLogs 잘못된 날짜, 기간, 작업은 ErrWeirdData
+	FetchAuditLogs(ctx context.Context, in FetchAuditLogs) ([]AuditLogInfo, error)
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupCheckTables 복원 검증 시 확인하는 핵심 테이블
+var BackupCheckTables = []string{"user", "customer", "manager", "order", "order_tickets"}
+
+type BackupStatus string
+
+const (
+	BackupStatusRunning   BackupStatus = "RUNNING"
+	BackupStatusCompleted BackupStatus = "COMPLETED"
+	BackupStatusFailed    BackupStatus = "FAILED"
+)
+
+type BackupVerifyStatus string
+
+const (
+	BackupVerifyStatusPending BackupVerifyStatus = "PENDING"
+	BackupVerifyStatusPassed  BackupVerifyStatus = "PASSED"
+	BackupVerifyStatusFailed  BackupVerifyStatus = "FAILED"
+)
+
+func CreateBackup(id uuid.UUID, requestedBy *uuid.UUID, now time.Time) Backup {
+	return Backup{
+		Id:           id,
+		Status:       BackupStatusRunning,
+		VerifyStatus: BackupVerifyStatusPending,
+		RequestedBy:  requestedBy,
+		StartedAt:    now,
+	}
+}
+
+type Backup struct {
+	Id           uuid.UUID          `gorm:"type:char(36);primaryKey"`
+	Status       BackupStatus       `gorm:"size:20;index;not null"`
+	Location     *string            `gorm:"size:500"`
+	SizeBytes    int64              `gorm:"not null"`
+	Error        *string            `gorm:"size:1000"`
+	RequestedBy  *uuid.UUID         `gorm:"type:char(36)"`
+	StartedAt    time.Time          `gorm:"type:datetime(6);index;not null"`
+	FinishedAt   *time.Time         `gorm:"type:datetime(6)"`
+	VerifyStatus BackupVerifyStatus `gorm:"size:20;index;not null"`
+	VerifyError  *string            `gorm:"size:1000"`
+	VerifiedAt   *time.Time         `gorm:"type:datetime(6)"`
+}
+
+func (Backup) TableName() string {
+	return "backup"
+}
+
+func (b *Backup) Complete(location string, size int64, now time.Time) {
+	b.Status = BackupStatusCompleted
+	b.Location = &location
+	b.SizeBytes = size
+	b.FinishedAt = &now
+}
+
+func (b *Backup) Fail(err error, now time.Time) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	b.Status = BackupStatusFailed
+	b.Error = &msg
+	b.FinishedAt = &now
+}
+
+func (b *Backup) Verified(err error, now time.Time) {
+	b.VerifiedAt = &now
+	if err == nil {
+		b.VerifyStatus = BackupVerifyStatusPassed
+		b.VerifyError = nil
+		return
+	}
+
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	b.VerifyStatus = BackupVerifyStatusFailed
+	b.VerifyError = &msg
+}
+
+func (b Backup) IsVerifiable() bool {
+	return b.Status == BackupStatusCompleted && b.Location != nil
+}
+
+type BackupRepository interface {
+	Save(ctx context.Context, backup *Backup) error
+
+	GetById(ctx context.Context, id uuid.UUID) (*Backup, error)
+	GetLatestCompleted(ctx context.Context) (*Backup, error)
+	FetchRecent(ctx context.Context, limit int) ([]Backup, error)
+}
+
+// BackupAdapter 논리 백업 생성과 임시 스키마 복원
+type BackupAdapter interface {
+	Dump(ctx context.Context, name string) (location string, size int64, err error)
+	Restore(ctx context.Context, location, schema string) error
+	DropSchema(ctx context.Context, schema string) error
+
+	// CountRows schema 가 비어있으면 운영 스키마, 테이블이 없으면 ErrItemNotFound
+	CountRows(ctx context.Context, schema, table string) (int64, error)
+}
+
+type BackupInfo struct {
+	Id           uuid.UUID
+	Status       BackupStatus
+	Location     *string
+	SizeBytes    int64
+	Error        *string
+	StartedAt    time.Time
+	FinishedAt   *time.Time
+	VerifyStatus BackupVerifyStatus
+	VerifyError  *string
+	VerifiedAt   *time.Time
+}
+
+type BackupUseCase interface {
+	// StartBackup 백업 기록을 만들고 백그라운드에서 덤프 진행
+	StartBackup(ctx context.Context, requestedBy *uuid.UUID) (uuid.UUID, error)
+	VerifyBackup(ctx context.Context, id *uuid.UUID) (BackupInfo, error)
+
+	FetchRecentBackups(ctx context.Context) ([]BackupInfo, error)
+}
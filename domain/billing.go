@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// BillingInvoiceLimit 결제 화면에 보여줄 최근 결제 수
+	BillingInvoiceLimit = 12
+
+	// BillingUpgradeUsageRate 현재 이용권 의뢰 횟수를 이 비율 이상 쓰면 상위 상품 안내
+	BillingUpgradeUsageRate = 0.8
+)
+
+// BillingUpgradeReason 상위 상품 안내 이유
+type BillingUpgradeReason string
+
+const (
+	// BillingUpgradeReasonNoPlan 이용 중인 이용권 없음
+	BillingUpgradeReasonNoPlan BillingUpgradeReason = "NO_PLAN"
+	// BillingUpgradeReasonOrderLimitNear 의뢰 횟수를 BillingUpgradeUsageRate 이상 사용
+	BillingUpgradeReasonOrderLimitNear BillingUpgradeReason = "ORDER_LIMIT_NEAR"
+	// BillingUpgradeReasonOrderLimitReached 의뢰 횟수를 모두 사용
+	BillingUpgradeReasonOrderLimitReached BillingUpgradeReason = "ORDER_LIMIT_REACHED"
+)
+
+// BillingPlan 지금 이용 중인 이용권
+type BillingPlan struct {
+	TicketId            uuid.UUID
+	Name                *string
+	TotalOrderCount     uint8
+	RemainingOrderCount uint8
+	EditCount           uint8
+	StartAt             *time.Time
+	EndAt               *time.Time
+}
+
+// BillingInvoice 결제 한 건, 이용권 하나가 결제 한 건
+type BillingInvoice struct {
+	TicketId  uuid.UUID
+	ExOrderId string
+	PlanName  *string
+	Amount    *int64
+	// CreditApplied 이 결제에 사용한 크레딧 (양수)
+	CreditApplied int64
+	OrderCount    uint8
+	StartAt       *time.Time
+	EndAt         *time.Time
+	PaidAt        time.Time
+	// ReceiptUrl 영수증 다운로드 주소, 결제 메시지에 없었으면 nil
+	ReceiptUrl *string
+}
+
+// BillingPaymentMethod 마지막 결제에 쓴 결제 수단 요약
+type BillingPaymentMethod struct {
+	Summary    string
+	LastUsedAt time.Time
+}
+
+type BillingUpgrade struct {
+	Recommended bool
+	Reason      *BillingUpgradeReason
+}
+
+// BillingInfo 고객 결제 화면에 필요한 값을 한 번에
+type BillingInfo struct {
+	CurrentPlan *BillingPlan
+	// NextBillingAt 결제한 기간이 끝나는 시각, 미리 결제한 이용권까지 포함, 없으면 nil
+	NextBillingAt *time.Time
+	Invoices      []BillingInvoice
+	PaymentMethod *BillingPaymentMethod
+	CreditBalance int64
+	Upgrade       BillingUpgrade
+}
+
+type BillingUseCase interface {
+	// GetMyBilling 이용권, 크레딧 내역으로 결제 화면 정보를 모음, 저장하는 값은 없음
+	GetMyBilling(ctx context.Context, customerId uuid.UUID) (BillingInfo, error)
+}
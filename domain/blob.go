@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobInfo 저장소에 올라간 파일 정보
+type BlobInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
+	// Checksum 저장소가 확인한 SHA-256 (hex), 올릴 때 체크섬을 넘기지 않았거나 저장소가 주지 않으면 비어있음
+	Checksum string
+}
+
+// BlobPart 멀티파트 업로드 조각, ETag 는 조각을 올린 PUT 응답의 ETag 헤더 값
+type BlobPart struct {
+	Number int
+	ETag   string
+}
+
+// BlobStorage 파일 저장소, S3 호환 저장소와 로컬 디스크 구현이 있고 설정으로 선택
+// 없는 키는 ErrItemNotFound
+type BlobStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error)
+	Stat(ctx context.Context, key string) (BlobInfo, error)
+	// Delete 없는 키도 에러 아님
+	Delete(ctx context.Context, key string) error
+
+	// PresignGet 인증 없이 ttl 동안 내려받을 수 있는 URL
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// PresignPut 인증 없이 ttl 동안 올릴 수 있는 URL
+	// checksum(SHA-256 hex)을 주면 서명에 넣어 내용이 다르면 저장소가 거부, 비어있으면 검사 없음
+	// headers 는 PUT 할 때 같이 보내야 하는 헤더
+	PresignPut(ctx context.Context, key, contentType, checksum string, ttl time.Duration) (url string, headers map[string]string, err error)
+	// KeyOf 이 저장소를 가리키는 URL 이면 키, 다른 곳을 가리키면 false (쿼리는 무시)
+	KeyOf(rawURL string) (key string, ok bool)
+
+	// CreateMultipart 큰 파일을 조각으로 나눠 올리는 업로드 시작, 저장소의 업로드 아이디 반환
+	CreateMultipart(ctx context.Context, key, contentType string) (uploadId string, err error)
+	// PresignPart 인증 없이 ttl 동안 조각 하나를 올릴 수 있는 URL, 응답 ETag 헤더를 완료할 때 넘김
+	PresignPart(ctx context.Context, key, uploadId string, partNumber int, ttl time.Duration) (string, error)
+	// CompleteMultipart parts 는 조각 번호 순, 조각을 이어 붙여 key 에 저장
+	CompleteMultipart(ctx context.Context, key, uploadId string, parts []BlobPart) error
+	// AbortMultipart 올린 조각 삭제, 없는 업로드도 에러 아님
+	AbortMultipart(ctx context.Context, key, uploadId string) error
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "strings"
+
+// BusinessNumberLength 사업자등록번호 자리 수, 하이픈 없이 저장
+const BusinessNumberLength = 10
+
+var businessNumberWeights = [...]int{1, 3, 7, 1, 3, 7, 1, 3, 5}
+
+// NormalizeBusinessNumber 하이픈, 공백 제거 (ex. 123-45-67890 -> 1234567890)
+func NormalizeBusinessNumber(number string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(number)
+}
+
+// IsValidBusinessNumber 하이픈 없는 10자리 숫자이고 마지막 자리가 국세청 검증 번호와 맞는지
+func IsValidBusinessNumber(number string) bool {
+	if len(number) != BusinessNumberLength {
+		return false
+	}
+
+	var digits [BusinessNumberLength]int
+	for i := range number {
+		if number[i] < '0' || '9' < number[i] {
+			return false
+		}
+		digits[i] = int(number[i] - '0')
+	}
+
+	sum := 0
+	for i, w := range businessNumberWeights {
+		sum += digits[i] * w
+	}
+	// 9번째 자리는 가중치 5를 곱한 값의 십의 자리를 한 번 더 더함
+	sum += digits[8] * 5 / 10
+
+	return (10-sum%10)%10 == digits[9]
+}
+
+// BusinessInfo 세금계산서 발행에 필요한 사업자 정보
+type BusinessInfo struct {
+	Number         string
+	Name           string
+	Representative string
+}
+
+// Validate 사업자등록번호 검증 번호가 틀리거나 상호, 대표자가 비었으면 ErrWeirdData
+func (b BusinessInfo) Validate() error {
+	if !IsValidBusinessNumber(b.Number) ||
+		strings.TrimSpace(b.Name) == "" ||
+		strings.TrimSpace(b.Representative) == "" {
+		return ErrWeirdData
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Calendar 서비스 기준 시간대(Asia/Seoul) 날짜 계산, 서버 TZ 와 무관하게 같은 결과
+type Calendar interface {
+	// Now 기준 시간대로 표현한 현재 시각
+	Now() time.Time
+	Location() *time.Location
+
+	// DateOf t 가 기준 시간대로 속한 날짜, 날짜 전용 컬럼(type:date) 저장용으로 UTC 0시로 표현
+	// DB 연결이 loc=UTC 라서 그대로 저장/조회해도 날짜가 바뀌지 않음
+	DateOf(t time.Time) time.Time
+	// EndOfDate DateOf 로 만든 날짜가 기준 시간대로 끝나는 시각 (다음날 0시), 마감 비교용
+	EndOfDate(date time.Time) time.Time
+	// AddMonths 기준 시간대로 months 개월 뒤, 다음 달에 같은 날이 없으면 그 달의 말일 (1/31 + 1달 = 2/28)
+	AddMonths(t time.Time, months int) time.Time
+}
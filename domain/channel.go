@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/pointer"
+)
+
+const (
+	// ChannelConnectTTL 채널 연결 동의 화면에서 돌아와야 하는 시간
+	ChannelConnectTTL = 10 * time.Minute
+	// ChannelStatsSyncInterval 이 시간이 지난 연결만 통계 다시 조회
+	ChannelStatsSyncInterval = 12 * time.Hour
+	// ChannelStatsSyncBatch 한 번 실행에 조회할 최대 채널 수, 남은 채널은 다음 실행에서 조회
+	ChannelStatsSyncBatch = 100
+	// ChannelStatsSyncConcurrency 동시에 호출할 YouTube API 수
+	ChannelStatsSyncConcurrency = 4
+	// ChannelStatsHistoryDays 통계 조회 시 같이 주는 일별 기록 일수
+	ChannelStatsHistoryDays = 30
+)
+
+// ChannelConnection 고객 YouTube 채널 연결, refresh token 은 CredentialCipher 로 암호화해서 저장
+// 연결 시작 후 동의 전에는 PendingState 만 있음
+type ChannelConnection struct {
+	CustomerId   uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	ChannelId    *string    `gorm:"size:64;index"`
+	ChannelTitle *string    `gorm:"size:200"`
+	Sealed       []byte     `gorm:"type:blob"`
+	ConnectedAt  *time.Time `gorm:"type:datetime(6)"`
+	LastSyncedAt *time.Time `gorm:"type:datetime(6);index"`
+	LastError    *string    `gorm:"size:1000"`
+
+	// PendingState 동의 화면에 넘긴 state 의 sha256, 원본은 redirect URL 로만 전달
+	PendingState          *string    `gorm:"size:64;index"`
+	PendingStateExpiresAt *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (ChannelConnection) TableName() string {
+	return "channel_connection"
+}
+
+// AAD 암호문을 고객에 묶음
+func (c ChannelConnection) AAD() []byte {
+	return []byte("channel/" + c.CustomerId.String())
+}
+
+func (c ChannelConnection) IsConnected() bool {
+	return c.Sealed != nil
+}
+
+// RequestConnect 동의 화면에 넘길 state 발급, 기존 연결은 동의를 마칠 때까지 유지
+func (c *ChannelConnection) RequestConnect(now time.Time) (state string, err error) {
+	raw := make([]byte, 32)
+	_, err = rand.Read(raw)
+	if err != nil {
+		return
+	}
+	state = hex.EncodeToString(raw)
+
+	hashed := HashChannelState(state)
+	c.PendingState = &hashed
+	c.PendingStateExpiresAt = pointer.Time(now.Add(ChannelConnectTTL))
+	return
+}
+
+func (c ChannelConnection) IsPendingExpired(now time.Time) bool {
+	return c.PendingStateExpiresAt == nil || !now.Before(*c.PendingStateExpiresAt)
+}
+
+// ConfirmConnect 만료됐으면 ErrTokenExpired
+func (c *ChannelConnection) ConfirmConnect(channel YouTubeChannel, sealed []byte, now time.Time) error {
+	if c.IsPendingExpired(now) {
+		return ErrTokenExpired
+	}
+
+	c.ChannelId = &channel.Id
+	c.ChannelTitle = &channel.Title
+	c.Sealed = sealed
+	c.ConnectedAt = &now
+	c.LastError = nil
+	c.PendingState = nil
+	c.PendingStateExpiresAt = nil
+	return nil
+}
+
+// Disconnect 통계 기록은 남김
+func (c *ChannelConnection) Disconnect() {
+	c.Sealed = nil
+	c.ConnectedAt = nil
+	c.PendingState = nil
+	c.PendingStateExpiresAt = nil
+}
+
+func (c *ChannelConnection) Synced(channel YouTubeChannel, now time.Time) {
+	c.ChannelTitle = &channel.Title
+	c.LastSyncedAt = &now
+	c.LastError = nil
+}
+
+// SyncFailed 권한이 취소된 경우는 연결 해제, 다시 연결해야 함
+func (c *ChannelConnection) SyncFailed(err error, now time.Time) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	c.LastSyncedAt = &now
+	c.LastError = &msg
+	if err == ErrChannelRevoked {
+		c.Disconnect()
+	}
+}
+
+func HashChannelState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChannelStats 일별 채널 통계, 하루에 여러 번 조회하면 마지막 값
+type ChannelStats struct {
+	CustomerId  uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Date        time.Time `gorm:"type:date;primaryKey"`
+	ChannelId   string    `gorm:"size:64;not null"`
+	Subscribers int64     `gorm:"not null"`
+	Views       int64     `gorm:"not null"`
+	Videos      int64     `gorm:"not null"`
+	SyncedAt    time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (ChannelStats) TableName() string {
+	return "channel_stats"
+}
+
+type ChannelRepository interface {
+	Save(ctx context.Context, connection *ChannelConnection) error
+	SaveStats(ctx context.Context, stats *ChannelStats) error
+
+	GetByCustomerId(ctx context.Context, customerId uuid.UUID) (*ChannelConnection, error)
+	GetByPendingState(ctx context.Context, hashedState string) (*ChannelConnection, error)
+	// FetchSyncTargets 연결된 채널 중 before 전에 조회했거나 조회한 적 없는 채널, 오래된 순
+	FetchSyncTargets(ctx context.Context, before time.Time, limit int) ([]ChannelConnection, error)
+	// FetchStats since 이후 일별 통계, 날짜 순
+	FetchStats(ctx context.Context, customerId uuid.UUID, since time.Time) ([]ChannelStats, error)
+}
+
+type YouTubeToken struct {
+	AccessToken string
+	// RefreshToken 처음 동의할 때만 옴
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+type YouTubeChannel struct {
+	Id          string
+	Title       string
+	Subscribers int64
+	Views       int64
+	Videos      int64
+}
+
+// YouTubeClient Google OAuth, YouTube Data API, 권한이 취소된 토큰은 ErrChannelRevoked
+type YouTubeClient interface {
+	// AuthCodeURL 동의 화면 주소, 돌아올 때 state 를 그대로 넘겨줌
+	AuthCodeURL(state string) (string, error)
+	Exchange(ctx context.Context, code string) (YouTubeToken, error)
+	Refresh(ctx context.Context, refreshToken string) (YouTubeToken, error)
+	// MyChannel 토큰 주인의 채널, 채널이 없는 계정이면 ErrItemNotFound
+	MyChannel(ctx context.Context, accessToken string) (YouTubeChannel, error)
+}
+
+type ConnectChannel struct {
+	State string
+	Code  string
+}
+
+type ChannelStatsPoint struct {
+	Date        time.Time
+	Subscribers int64
+	Views       int64
+	Videos      int64
+}
+
+type ChannelStatsInfo struct {
+	Connected    bool
+	ChannelId    *string
+	ChannelTitle *string
+	LastSyncedAt *time.Time
+	LastError    *string
+	// Latest 가장 최근 통계, 조회한 적 없으면 nil
+	Latest  *ChannelStatsPoint
+	History []ChannelStatsPoint
+}
+
+type ChannelStatsSync struct {
+	Synced int64
+	Failed int64
+}
+
+type ChannelStatsAccess struct {
+	CustomerId  uuid.UUID
+	RequesterId uuid.UUID
+}
+
+type ChannelUseCase interface {
+	// RequestChannelConnect 동의 화면 주소, 고객만 가능
+	RequestChannelConnect(ctx context.Context, customerId uuid.UUID) (string, error)
+	// ConnectChannel 동의 화면에서 돌아온 state, code 로 연결하고 바로 통계 조회
+	ConnectChannel(ctx context.Context, in ConnectChannel) error
+	DisconnectChannel(ctx context.Context, customerId uuid.UUID) error
+	// SyncChannelStats 스케줄러가 주기적으로 호출
+	SyncChannelStats(ctx context.Context) (ChannelStatsSync, error)
+
+	// GetChannelStats 고객 본인 또는 관리자만, 아니면 ErrNoPermission
+	GetChannelStats(ctx context.Context, in ChannelStatsAccess) (ChannelStatsInfo, error)
+}
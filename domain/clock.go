@@ -0,0 +1,8 @@
+package domain
+
+import "time"
+
+// Clock 현재 시각, 테스트에서 고정/이동 가능한 시계로 바꿔 만료, 마감, 이용 기간 계산을 재현
+type Clock interface {
+	Now() time.Time
+}
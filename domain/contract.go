@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+// ContractDownloadTTL 서명된 계약서 내려받기 URL 유효 시간
+const ContractDownloadTTL = 10 * time.Minute
+
+// ESignProvider 전자 서명 서비스
+type ESignProvider string
+
+const (
+	ESignProviderModusign ESignProvider = "MODUSIGN"
+)
+
+// ESignStatus 전자 서명 서비스의 문서 상태, 서비스마다 다른 값을 어댑터에서 맞춤
+type ESignStatus string
+
+const (
+	ESignStatusPending  ESignStatus = "PENDING"
+	ESignStatusSigned   ESignStatus = "SIGNED"
+	ESignStatusDeclined ESignStatus = "DECLINED"
+)
+
+type ESignRequest struct {
+	Title       string
+	SignerName  string
+	SignerEmail string
+}
+
+// ESignAdapter 전자 서명 서비스, 계약서 양식은 서비스에 등록한 템플릿 사용
+type ESignAdapter interface {
+	Provider() ESignProvider
+	// Send 서명 요청 메일 발송, 서비스의 문서 아이디 반환
+	Send(ctx context.Context, req ESignRequest) (documentId string, err error)
+	// Status webhook 내용은 믿지 않고 서비스에 다시 물어봄
+	Status(ctx context.Context, documentId string) (ESignStatus, error)
+	// DownloadSigned 모두 서명한 PDF, 서명 전이면 ErrItemNotFound
+	DownloadSigned(ctx context.Context, documentId string) (io.ReadCloser, error)
+}
+
+type ContractStatus string
+
+const (
+	ContractStatusSent     ContractStatus = "SENT"
+	ContractStatusSigned   ContractStatus = "SIGNED"
+	ContractStatusDeclined ContractStatus = "DECLINED"
+)
+
+type CreateContractOption struct {
+	Id          uuid.UUID
+	CustomerId  uuid.UUID
+	Title       string
+	Provider    ESignProvider
+	DocumentId  string
+	SignerName  string
+	SignerEmail string
+	SentBy      uuid.UUID
+	Now         time.Time
+}
+
+func CreateContract(option CreateContractOption) Contract {
+	return Contract{
+		Id:          option.Id,
+		CustomerId:  option.CustomerId,
+		Title:       option.Title,
+		Provider:    option.Provider,
+		DocumentId:  option.DocumentId,
+		Status:      ContractStatusSent,
+		SignerName:  option.SignerName,
+		SignerEmail: option.SignerEmail,
+		SentBy:      option.SentBy,
+		SentAt:      option.Now,
+		UpdatedAt:   option.Now,
+	}
+}
+
+// Contract 기업 고객 계약서, 서명을 마쳐야 구독권이 생성됨
+type Contract struct {
+	Id         uuid.UUID      `gorm:"type:char(36);primaryKey"`
+	CustomerId uuid.UUID      `gorm:"type:char(36);index;not null"`
+	Title      string         `gorm:"size:200;not null"`
+	Provider   ESignProvider  `gorm:"size:20;uniqueIndex:idx_contract_provider_document;not null"`
+	DocumentId string         `gorm:"size:100;uniqueIndex:idx_contract_provider_document;not null"`
+	Status     ContractStatus `gorm:"size:20;index;not null"`
+
+	SignerName  string `gorm:"size:60;not null"`
+	SignerEmail string `gorm:"size:320;not null"`
+
+	SentBy      uuid.UUID  `gorm:"type:char(36);not null"`
+	SentAt      time.Time  `gorm:"type:datetime(6);index;not null"`
+	CompletedAt *time.Time `gorm:"type:datetime(6)"`
+	// SignedFileKey 서명된 PDF 의 파일 저장소 키
+	SignedFileKey *string   `gorm:"size:300"`
+	UpdatedAt     time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (Contract) TableName() string {
+	return "contract"
+}
+
+func (c Contract) IsSigned() bool {
+	return c.Status == ContractStatusSigned
+}
+
+// IsCompleted 서명, 거절 모두 더 바뀌지 않음
+func (c Contract) IsCompleted() bool {
+	return c.Status != ContractStatusSent
+}
+
+func (c *Contract) Sign(fileKey string, now time.Time) {
+	c.Status = ContractStatusSigned
+	c.SignedFileKey = &fileKey
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+func (c *Contract) Decline(now time.Time) {
+	c.Status = ContractStatusDeclined
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// SignedContractKey 서명된 계약서 저장 위치
+func SignedContractKey(contractId uuid.UUID) string {
+	return "contract/" + contractId.String() + ".pdf"
+}
+
+type ContractRepository interface {
+	Save(ctx context.Context, contract *Contract) error
+	Transaction(ctx context.Context, fn func(contractRepo ContractTxRepository) error) error
+	With(tx gormx.Tx) ContractTxRepository
+
+	GetById(ctx context.Context, id uuid.UUID) (*Contract, error)
+	GetByDocumentId(ctx context.Context, provider ESignProvider, documentId string) (*Contract, error)
+	// GetLatestByCustomerId 가장 최근에 보낸 계약서
+	GetLatestByCustomerId(ctx context.Context, customerId uuid.UUID) (*Contract, error)
+	// FetchByCustomerId 최근에 보낸 계약서부터
+	FetchByCustomerId(ctx context.Context, customerId uuid.UUID) ([]Contract, error)
+}
+
+type ContractTxRepository interface {
+	ContractRepository
+	gormx.Tx
+}
+
+// ContractGate 구독권 생성처럼 계약 서명이 필요한 기능 앞에서 확인
+type ContractGate interface {
+	// RequireSigned 계약서를 보낸 적 있는 고객(기업 고객)인데 가장 최근 계약서에 서명하지 않았으면 ErrContractNotSigned
+	RequireSigned(ctx context.Context, customerId uuid.UUID) error
+}
+
+type SendContract struct {
+	CustomerId uuid.UUID
+	Title      string
+	// SignerName, SignerEmail 비어있으면 고객 이름, 이메일
+	SignerName  string
+	SignerEmail string
+	SentBy      uuid.UUID
+}
+
+type ContractInfo struct {
+	Id            uuid.UUID
+	CustomerId    uuid.UUID
+	Title         string
+	Provider      ESignProvider
+	Status        ContractStatus
+	SignerName    string
+	SignerEmail   string
+	SentBy        uuid.UUID
+	SentAt        time.Time
+	CompletedAt   *time.Time
+	HasSignedFile bool
+}
+
+type ContractUseCase interface {
+	SendContract(ctx context.Context, in SendContract) (ContractInfo, error)
+	// SyncContract 서명 서비스 webhook 을 받으면 호출, 없는 문서는 ErrItemNotFound, 이미 끝난 계약은 그대로 둠
+	SyncContract(ctx context.Context, provider ESignProvider, documentId string) error
+
+	FetchCustomerContracts(ctx context.Context, customerId uuid.UUID) ([]ContractInfo, error)
+	// GetSignedContractURL ContractDownloadTTL 동안 인증 없이 내려받을 수 있는 URL, 서명 전이면 ErrItemNotFound
+	GetSignedContractURL(ctx context.Context, contractId uuid.UUID) (string, error)
+}
@@ -0,0 +1,289 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+// CreditAccount 복식부기 계정
+// 고객 지갑 계정과 시스템 계정(상대 계정)으로 구성, 한 거래의 모든 분개 합은 0
+type CreditAccount string
+
+const (
+	CreditAccountCompensation CreditAccount = "system:compensation"
+	CreditAccountReferral     CreditAccount = "system:referral"
+	CreditAccountAdjustment   CreditAccount = "system:adjustment"
+	CreditAccountInvoice      CreditAccount = "system:invoice"
+	CreditAccountExpire       CreditAccount = "system:expire"
+	CreditAccountMerge        CreditAccount = "system:merge"
+)
+
+func CustomerCreditAccount(customerId uuid.UUID) CreditAccount {
+	return CreditAccount("customer:" + customerId.String())
+}
+
+type CreditEntryKind string
+
+const (
+	CreditEntryKindEarn   CreditEntryKind = "EARN"
+	CreditEntryKindSpend  CreditEntryKind = "SPEND"
+	CreditEntryKindExpire CreditEntryKind = "EXPIRE"
+	CreditEntryKindAdjust CreditEntryKind = "ADJUST"
+	CreditEntryKindMerge  CreditEntryKind = "MERGE"
+)
+
+// CreditEntry 원장 분개, 추가만 가능
+type CreditEntry struct {
+	Id            uuid.UUID       `gorm:"type:char(36);primaryKey"`
+	TransactionId uuid.UUID       `gorm:"type:char(36);index;not null"`
+	CustomerId    uuid.UUID       `gorm:"type:char(36);index;not null"`
+	Account       CreditAccount   `gorm:"size:60;index;not null"`
+	Amount        int64           `gorm:"not null"`
+	Kind          CreditEntryKind `gorm:"size:20;index;not null"`
+	Reference     *string         `gorm:"size:120;index"`
+	Memo          *string         `gorm:"size:500"`
+	CreatedAt     time.Time       `gorm:"type:datetime(6);index;not null"`
+}
+
+func (CreditEntry) TableName() string {
+	return "credit_entry"
+}
+
+// CreditLot 적립 단위, 만료일이 빠른 순으로 차감
+type CreditLot struct {
+	Id         uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	CustomerId uuid.UUID  `gorm:"type:char(36);index;not null"`
+	Amount     int64      `gorm:"not null"`
+	Remaining  int64      `gorm:"index;not null"`
+	ExpiresAt  *time.Time `gorm:"type:datetime(6);index"`
+	CreatedAt  time.Time  `gorm:"type:datetime(6);index;not null"`
+}
+
+func (CreditLot) TableName() string {
+	return "credit_lot"
+}
+
+func (l CreditLot) IsExpired(at time.Time) bool {
+	return l.ExpiresAt != nil && !l.ExpiresAt.After(at)
+}
+
+type CreditTransaction struct {
+	Id      uuid.UUID
+	Entries []CreditEntry
+	Lots    []CreditLot
+
+	ids IdGenerator
+}
+
+// newCreditTransaction 거래, 기록, 적립분 아이디 모두 ids 로 발급
+func newCreditTransaction(ids IdGenerator) CreditTransaction {
+	return CreditTransaction{Id: ids.NewId(), ids: ids}
+}
+
+func (t *CreditTransaction) post(customerId uuid.UUID, account CreditAccount, amount int64, kind CreditEntryKind, reference, memo *string, now time.Time) {
+	t.Entries = append(t.Entries, CreditEntry{
+		Id:            t.ids.NewId(),
+		TransactionId: t.Id,
+		CustomerId:    customerId,
+		Account:       account,
+		Amount:        amount,
+		Kind:          kind,
+		Reference:     reference,
+		Memo:          memo,
+		CreatedAt:     now,
+	})
+}
+
+// transfer 고객 계정과 상대 계정에 같은 금액을 반대 부호로 기록
+func (t *CreditTransaction) transfer(customerId uuid.UUID, counter CreditAccount, amount int64, kind CreditEntryKind, reference, memo *string, now time.Time) {
+	t.post(customerId, CustomerCreditAccount(customerId), amount, kind, reference, memo, now)
+	t.post(customerId, counter, -amount, kind, reference, memo, now)
+}
+
+func (t CreditTransaction) IsEmpty() bool {
+	return len(t.Entries) == 0
+}
+
+type EarnCreditOption struct {
+	Ids        IdGenerator
+	CustomerId uuid.UUID
+	Source     CreditAccount
+	Kind       CreditEntryKind
+	Amount     int64
+	ExpiresAt  *time.Time
+	Reference  *string
+	Memo       *string
+	Now        time.Time
+}
+
+func EarnCredit(option EarnCreditOption) (tx CreditTransaction) {
+	tx = newCreditTransaction(option.Ids)
+	if option.Amount <= 0 {
+		return
+	}
+
+	tx.transfer(option.CustomerId, option.Source, option.Amount, option.Kind, option.Reference, option.Memo, option.Now)
+	tx.Lots = append(tx.Lots, CreditLot{
+		Id:         tx.ids.NewId(),
+		CustomerId: option.CustomerId,
+		Amount:     option.Amount,
+		Remaining:  option.Amount,
+		ExpiresAt:  option.ExpiresAt,
+		CreatedAt:  option.Now,
+	})
+	return
+}
+
+type SpendCreditOption struct {
+	Ids        IdGenerator
+	CustomerId uuid.UUID
+	Target     CreditAccount
+	Kind       CreditEntryKind
+	Amount     int64
+	Lots       []CreditLot
+	Reference  *string
+	Memo       *string
+	Now        time.Time
+}
+
+// SpendCredit 만료일이 빠른 적립분부터 차감, 잔액이 부족하면 가능한 만큼만 차감
+func SpendCredit(option SpendCreditOption) (tx CreditTransaction, spent int64) {
+	tx = newCreditTransaction(option.Ids)
+	now := option.Now
+
+	lots := make([]CreditLot, 0, len(option.Lots))
+	for i := range option.Lots {
+		if option.Lots[i].Remaining > 0 && !option.Lots[i].IsExpired(now) {
+			lots = append(lots, option.Lots[i])
+		}
+	}
+	sort.SliceStable(lots, func(i, j int) bool {
+		a, b := lots[i].ExpiresAt, lots[j].ExpiresAt
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.Before(*b)
+	})
+
+	for i := range lots {
+		if spent >= option.Amount {
+			break
+		}
+
+		lot := lots[i]
+		use := option.Amount - spent
+		if lot.Remaining < use {
+			use = lot.Remaining
+		}
+		lot.Remaining -= use
+		spent += use
+		tx.Lots = append(tx.Lots, lot)
+	}
+
+	if spent > 0 {
+		tx.transfer(option.CustomerId, option.Target, -spent, option.Kind, option.Reference, option.Memo, option.Now)
+	}
+	return
+}
+
+// ExpireCreditLot 만료된 적립분의 남은 금액 소멸
+func ExpireCreditLot(lot CreditLot, ids IdGenerator, now time.Time) (tx CreditTransaction) {
+	tx = newCreditTransaction(ids)
+	if lot.Remaining <= 0 {
+		return
+	}
+
+	reference := "lot:" + lot.Id.String()
+	tx.transfer(lot.CustomerId, CreditAccountExpire, -lot.Remaining, CreditEntryKindExpire, &reference, nil, now)
+	lot.Remaining = 0
+	tx.Lots = append(tx.Lots, lot)
+	return
+}
+
+type CreditRepository interface {
+	SaveTransaction(ctx context.Context, tx *CreditTransaction) error
+	Transaction(ctx context.Context, fn func(creditRepo CreditTxRepository) error) error
+	With(tx gormx.Tx) CreditTxRepository
+
+	GetBalance(ctx context.Context, customerId uuid.UUID) (int64, error)
+	FetchAvailableLots(ctx context.Context, customerId uuid.UUID, at time.Time) ([]CreditLot, error)
+	FetchExpiredLots(ctx context.Context, at time.Time) ([]CreditLot, error)
+	FetchEntries(ctx context.Context, customerId uuid.UUID) ([]CreditEntry, error)
+	ExistsReference(ctx context.Context, reference string) (bool, error)
+}
+
+type CreditTxRepository interface {
+	CreditRepository
+	gormx.Tx
+}
+
+type AdjustCredit struct {
+	CustomerId uuid.UUID
+	AdminId    uuid.UUID
+	Amount     int64
+	Memo       string
+	ExpiresAt  *time.Time
+	Ip         string
+}
+
+type ApplyCreditToInvoice struct {
+	ExInvoiceId string
+	Username    string
+	Amount      int64
+}
+
+type CreditEntryInfo struct {
+	TransactionId uuid.UUID
+	Amount        int64
+	Kind          CreditEntryKind
+	Reference     *string
+	Memo          *string
+	CreatedAt     time.Time
+}
+
+type CreditInfo struct {
+	CustomerId uuid.UUID
+	Balance    int64
+	History    []CreditEntryInfo
+}
+
+type CreditUseCase interface {
+	AdjustCredit(ctx context.Context, in AdjustCredit) (int64, error)
+	ApplyToInvoice(ctx context.Context, in ApplyCreditToInvoice) (int64, error)
+	ExpireCredits(ctx context.Context) (int, error)
+
+	GetCreditInfo(ctx context.Context, customerId uuid.UUID) (CreditInfo, error)
+}
+
+// MoveCredit 계정 병합 시 from 의 남은 적립분을 만료일 그대로 to 로 옮김
+// from 에서 병합 계정으로, 병합 계정에서 to 로 각각 기록해서 양쪽 원장 합계가 맞음
+func MoveCredit(from, to uuid.UUID, lots []CreditLot, ids IdGenerator, now time.Time) (tx CreditTransaction, moved int64) {
+	tx = newCreditTransaction(ids)
+	reference := "merge:" + from.String()
+
+	for _, lot := range lots {
+		if lot.Remaining <= 0 {
+			continue
+		}
+
+		amount := lot.Remaining
+		tx.transfer(from, CreditAccountMerge, -amount, CreditEntryKindMerge, &reference, nil, now)
+		tx.transfer(to, CreditAccountMerge, amount, CreditEntryKindMerge, &reference, nil, now)
+
+		lot.Remaining = 0
+		tx.Lots = append(tx.Lots, lot, CreditLot{
+			Id:         ids.NewId(),
+			CustomerId: to,
+			Amount:     amount,
+			Remaining:  amount,
+			ExpiresAt:  lot.ExpiresAt,
+			CreatedAt:  now,
+		})
+		moved += amount
+	}
+	return
+}
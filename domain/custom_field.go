@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	customFieldTextMaxLength = 1000
+	customFieldDateLayout    = "2006-01-02"
+)
+
+// customFieldKeyPattern JSON 경로에 그대로 쓰므로 영문 소문자, 숫자, _ 만 허용
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText   CustomFieldType = "TEXT"
+	CustomFieldTypeNumber CustomFieldType = "NUMBER"
+	CustomFieldTypeBool   CustomFieldType = "BOOL"
+	CustomFieldTypeDate   CustomFieldType = "DATE"
+	CustomFieldTypeSelect CustomFieldType = "SELECT"
+)
+
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeBool, CustomFieldTypeDate, CustomFieldTypeSelect:
+		return true
+	}
+	return false
+}
+
+// CustomFieldValues 고객별 추가 항목 값, key 는 CustomField.Key
+type CustomFieldValues map[string]interface{}
+
+type CreateCustomFieldOption struct {
+	Id       uuid.UUID
+	Key      string
+	Name     string
+	Type     CustomFieldType
+	Required bool
+	Options  []string
+	Now      time.Time
+}
+
+func CreateCustomField(option CreateCustomFieldOption) (field CustomField, err error) {
+	if !customFieldKeyPattern.MatchString(option.Key) || !option.Type.IsValid() {
+		err = ErrWeirdData
+		return
+	}
+
+	field = CustomField{
+		Id:        option.Id,
+		Key:       option.Key,
+		Name:      option.Name,
+		Type:      option.Type,
+		Required:  option.Required,
+		CreatedAt: option.Now,
+	}
+
+	if option.Type == CustomFieldTypeSelect {
+		if len(option.Options) == 0 {
+			err = ErrWeirdData
+			return
+		}
+
+		var raw []byte
+		raw, err = json.Marshal(option.Options)
+		if err != nil {
+			return
+		}
+		options := string(raw)
+		field.Options = &options
+	}
+	return
+}
+
+// CustomField 고객 추가 항목 정의 (ex. 담당 PD, 계약 형태)
+type CustomField struct {
+	Id        uuid.UUID       `gorm:"type:char(36);primaryKey"`
+	Key       string          `gorm:"size:40;unique;not null"`
+	Name      string          `gorm:"size:60;not null"`
+	Type      CustomFieldType `gorm:"size:10;not null"`
+	Required  bool            `gorm:"not null"`
+	Options   *string         `gorm:"type:json"`
+	CreatedAt time.Time       `gorm:"type:datetime(6);not null"`
+}
+
+func (CustomField) TableName() string {
+	return "custom_field"
+}
+
+func (f CustomField) OptionList() (list []string) {
+	if f.Options != nil {
+		_ = json.Unmarshal([]byte(*f.Options), &list)
+	}
+	return
+}
+
+// normalize 타입에 맞는 값으로 변환, 맞지 않으면 ErrWeirdData
+func (f CustomField) normalize(value interface{}) (interface{}, error) {
+	switch f.Type {
+	case CustomFieldTypeText:
+		if s, ok := value.(string); ok && len(s) <= customFieldTextMaxLength {
+			return s, nil
+		}
+	case CustomFieldTypeNumber:
+		if n, ok := value.(float64); ok {
+			return n, nil
+		}
+	case CustomFieldTypeBool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case CustomFieldTypeDate:
+		if s, ok := value.(string); ok {
+			if _, err := time.Parse(customFieldDateLayout, s); err == nil {
+				return s, nil
+			}
+		}
+	case CustomFieldTypeSelect:
+		if s, ok := value.(string); ok {
+			for _, option := range f.OptionList() {
+				if option == s {
+					return s, nil
+				}
+			}
+		}
+	}
+	return nil, ErrWeirdData
+}
+
+// ValidateCustomFieldValues 정의에 없는 키, 타입이 맞지 않는 값, 필수 항목 누락은 ErrWeirdData
+// 정의된 항목만 남긴 값을 반환하므로 삭제된 항목의 값은 다음 저장 때 정리됨
+func ValidateCustomFieldValues(fields []CustomField, values CustomFieldValues) (res CustomFieldValues, err error) {
+	byKey := make(map[string]CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	for key := range values {
+		if _, ok := byKey[key]; !ok {
+			err = ErrWeirdData
+			return
+		}
+	}
+
+	res = make(CustomFieldValues, len(values))
+	for _, field := range fields {
+		value, ok := values[field.Key]
+		if !ok || value == nil {
+			if field.Required {
+				err = ErrWeirdData
+				return
+			}
+			continue
+		}
+
+		res[field.Key], err = field.normalize(value)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// IsCustomFieldKey 목록 필터에 쓰는 키인지 확인
+func IsCustomFieldKey(key string) bool {
+	return customFieldKeyPattern.MatchString(key)
+}
+
+type CustomFieldRepository interface {
+	Save(ctx context.Context, field *CustomField) error
+	Delete(ctx context.Context, key string) error
+
+	GetByKey(ctx context.Context, key string) (*CustomField, error)
+	FetchAll(ctx context.Context) ([]CustomField, error)
+}
+
+type CreateCustomFieldInput struct {
+	Key      string
+	Name     string
+	Type     CustomFieldType
+	Required bool
+	Options  []string
+}
+
+type UpdateCustomerCustomFields struct {
+	UserId uuid.UUID
+	Values CustomFieldValues
+}
+
+type CustomFieldInfo struct {
+	Key      string
+	Name     string
+	Type     CustomFieldType
+	Required bool
+	Options  []string
+}
+
+type CustomFieldUseCase interface {
+	CreateCustomField(ctx context.Context, in CreateCustomFieldInput) error
+	DeleteCustomField(ctx context.Context, key string) error
+	UpdateCustomerCustomFields(ctx context.Context, in UpdateCustomerCustomFields) error
+
+	FetchCustomFields(ctx context.Context) ([]CustomFieldInfo, error)
+}
@@ -2,8 +2,11 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"strings"
+	"time"
 )
 
 type CustomerCreateOption struct {
@@ -32,18 +35,144 @@ type Customer struct {
 	PersonaLink  string    `gorm:"size:2048;not null"`
 	OnedriveLink string    `gorm:"size:2048;not null"`
 	Memo         string    `gorm:"type:text"`
+
+	// CustomFields 추가 항목 값, CustomFieldValues 를 JSON 으로 저장
+	CustomFields *string `gorm:"type:json"`
+
+	// BusinessNumber 사업자등록번호, 하이픈 없이, 세금계산서 발행 전에 있어야함
+	BusinessNumber         *string `gorm:"size:10;index"`
+	BusinessName           *string `gorm:"size:100"`
+	BusinessRepresentative *string `gorm:"size:60"`
+
+	// EmailUndeliverableAt 메일 발송 서비스가 영구 반송, 수신 거부 신고를 알린 시간, 받을 수 있는 주소면 nil
+	EmailUndeliverableAt     *time.Time `gorm:"type:datetime(6)"`
+	EmailUndeliverableReason *string    `gorm:"size:1000"`
 }
 
 func (Customer) TableName() string {
 	return "customer"
 }
 
+func (c Customer) CustomFieldValues() (values CustomFieldValues) {
+	if c.CustomFields != nil {
+		_ = json.Unmarshal([]byte(*c.CustomFields), &values)
+	}
+	return
+}
+
+func (c *Customer) SetCustomFieldValues(values CustomFieldValues) error {
+	if len(values) == 0 {
+		c.CustomFields = nil
+		return nil
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	fields := string(raw)
+	c.CustomFields = &fields
+	return nil
+}
+
+// BusinessInfo 사업자 정보, 셋 중 하나라도 없으면 false
+func (c Customer) BusinessInfo() (info BusinessInfo, ok bool) {
+	if c.BusinessNumber == nil || c.BusinessName == nil || c.BusinessRepresentative == nil {
+		return
+	}
+
+	info = BusinessInfo{
+		Number:         *c.BusinessNumber,
+		Name:           *c.BusinessName,
+		Representative: *c.BusinessRepresentative,
+	}
+	return info, true
+}
+
+// UpdateBusinessInfo 검증에 실패하면 ErrWeirdData, 바꾸지 않음
+func (c *Customer) UpdateBusinessInfo(info BusinessInfo) error {
+	info.Number = NormalizeBusinessNumber(info.Number)
+	info.Name = strings.TrimSpace(info.Name)
+	info.Representative = strings.TrimSpace(info.Representative)
+	err := info.Validate()
+	if err != nil {
+		return err
+	}
+
+	c.BusinessNumber = &info.Number
+	c.BusinessName = &info.Name
+	c.BusinessRepresentative = &info.Representative
+	return nil
+}
+
+// MergeFrom 중복 계정의 메모와 추가 항목을 합침, 같은 추가 항목은 남는 계정 값 유지
+// 사업자 정보는 남는 계정에 없을 때만 가져옴
+func (c *Customer) MergeFrom(duplicate Customer) error {
+	if _, ok := c.BusinessInfo(); !ok {
+		if info, ok := duplicate.BusinessInfo(); ok {
+			c.BusinessNumber = &info.Number
+			c.BusinessName = &info.Name
+			c.BusinessRepresentative = &info.Representative
+		}
+	}
+
+	if duplicate.Memo != "" {
+		if c.Memo == "" {
+			c.Memo = duplicate.Memo
+		} else {
+			c.Memo += "\n\n" + duplicate.Memo
+		}
+	}
+
+	values := c.CustomFieldValues()
+	if values == nil {
+		values = make(CustomFieldValues)
+	}
+	for key, value := range duplicate.CustomFieldValues() {
+		if _, ok := values[key]; !ok {
+			values[key] = value
+		}
+	}
+	return c.SetCustomFieldValues(values)
+}
+
+func (c Customer) EmailUndeliverable() bool {
+	return c.EmailUndeliverableAt != nil
+}
+
+// ApplyEmailEvent 받을 수 없는 주소 표시 갱신, 표시보다 먼저 일어난 전달은 무시, 바뀌었으면 true
+func (c *Customer) ApplyEmailEvent(event EmailEvent) bool {
+	if event.Undeliverable() {
+		if c.EmailUndeliverableAt != nil && !event.OccurredAt.After(*c.EmailUndeliverableAt) {
+			return false
+		}
+		at := event.OccurredAt
+		c.EmailUndeliverableAt = &at
+		c.EmailUndeliverableReason = event.Reason
+		return true
+	}
+
+	if event.Type == EmailEventTypeDelivered && c.EmailUndeliverableAt != nil &&
+		event.OccurredAt.After(*c.EmailUndeliverableAt) {
+		c.clearEmailUndeliverable()
+		return true
+	}
+	return false
+}
+
+func (c *Customer) clearEmailUndeliverable() {
+	c.EmailUndeliverableAt = nil
+	c.EmailUndeliverableReason = nil
+}
+
 type CustomerRepository interface {
 	Save(ctx context.Context, customer *Customer) error
 	With(tx gormx.Tx) CustomerTxRepository
 
 	GetById(ctx context.Context, userId uuid.UUID) (*Customer, error)
 	FetchByIds(ctx context.Context, ids []uuid.UUID) ([]Customer, error)
+	// FetchByEmails 대소문자 구분 없이
+	FetchByEmails(ctx context.Context, emails []string) ([]Customer, error)
 }
 
 type CustomerTxRepository interface {
@@ -0,0 +1,57 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	// CustomerImportMaxRows 한 번에 만들 수 있는 최대 고객 수
+	CustomerImportMaxRows = 500
+	// CustomerImportMaxSize 올릴 수 있는 CSV 크기
+	CustomerImportMaxSize = 1 << 20
+)
+
+// CustomerImportColumn CSV 머리글, 대소문자 구분 안 함
+type CustomerImportColumn string
+
+const (
+	CustomerImportColumnName   CustomerImportColumn = "name"
+	CustomerImportColumnEmail  CustomerImportColumn = "email"
+	CustomerImportColumnMobile CustomerImportColumn = "mobile"
+)
+
+// CustomerImportFailure 만들지 못한 행의 사유
+type CustomerImportFailure string
+
+const (
+	CustomerImportRequired CustomerImportFailure = "REQUIRED"
+	// CustomerImportInvalid 고객 생성 API 와 같은 형식 검사에 실패
+	CustomerImportInvalid CustomerImportFailure = "INVALID"
+	// CustomerImportDuplicated 같은 파일의 앞 행과 이메일이 같음
+	CustomerImportDuplicated CustomerImportFailure = "DUPLICATED"
+	// CustomerImportAlreadyExist 이미 있는 아이디(이메일)
+	CustomerImportAlreadyExist CustomerImportFailure = "ALREADY_EXIST"
+)
+
+// CustomerImportRow 형식 검사를 통과한 CSV 한 행
+type CustomerImportRow struct {
+	// Line CSV 줄 번호, 머리글이 1
+	Line   int
+	Name   string
+	Email  string
+	Mobile string
+}
+
+// CustomerImportResult 행마다 만든 고객 아이디 또는 실패 사유 중 하나
+type CustomerImportResult struct {
+	Line    int
+	UserId  *uuid.UUID
+	Column  CustomerImportColumn
+	Failure CustomerImportFailure
+}
+
+func (r CustomerImportResult) IsSuccess() bool {
+	return r.UserId != nil
+}
+
+type ImportCustomers struct {
+	Rows []CustomerImportRow
+}
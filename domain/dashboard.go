@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DashboardSlaBreachLimit 마감 지난 의뢰 위젯에 함께 보여줄 의뢰 수
+	DashboardSlaBreachLimit = 10
+)
+
+// DashboardWidget 관리자 대시보드에 올릴 수 있는 통계 위젯, 위젯마다 데이터 API 가 따로 있어 화면에서 동시에 불러옴
+type DashboardWidget string
+
+const (
+	// DashboardWidgetOrderBacklog 담당자 배정 전, 진행 중인 의뢰 수
+	DashboardWidgetOrderBacklog DashboardWidget = "ORDER_BACKLOG"
+	// DashboardWidgetMyAssignments 나에게 배정된 의뢰, 할 일
+	DashboardWidgetMyAssignments DashboardWidget = "MY_ASSIGNMENTS"
+	// DashboardWidgetSlaBreaches 마감 날짜가 지났는데 끝나지 않은 의뢰
+	DashboardWidgetSlaBreaches DashboardWidget = "SLA_BREACHES"
+	// DashboardWidgetRevenue 이번 달, 지난 달 이용권 결제 금액, 슈퍼 어드민만
+	DashboardWidgetRevenue DashboardWidget = "REVENUE"
+)
+
+// DashboardWidgets 설정이 없는 관리자의 기본 배치
+var DashboardWidgets = []DashboardWidget{
+	DashboardWidgetOrderBacklog,
+	DashboardWidgetMyAssignments,
+	DashboardWidgetSlaBreaches,
+	DashboardWidgetRevenue,
+}
+
+func (w DashboardWidget) IsValid() bool {
+	for i := range DashboardWidgets {
+		if DashboardWidgets[i] == w {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedFor 매출은 월 마감 스냅샷과 같이 슈퍼 어드민만
+func (w DashboardWidget) AllowedFor(role UserRole) bool {
+	if w == DashboardWidgetRevenue {
+		return role == SuperAdminUserRole
+	}
+	return w.IsValid()
+}
+
+// DashboardWidgetsFor role 이 볼 수 있는 위젯만 순서대로
+func DashboardWidgetsFor(widgets []DashboardWidget, role UserRole) []DashboardWidget {
+	res := make([]DashboardWidget, 0, len(widgets))
+	for _, widget := range widgets {
+		if widget.AllowedFor(role) {
+			res = append(res, widget)
+		}
+	}
+	return res
+}
+
+// DashboardLayout 관리자별 대시보드 위젯 배치, 순서대로 보여줌
+type DashboardLayout struct {
+	AdminId uuid.UUID `gorm:"type:char(36);primaryKey"`
+	// Widgets JSON 배열
+	Widgets   string    `gorm:"type:json;not null"`
+	UpdatedAt time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (DashboardLayout) TableName() string {
+	return "dashboard_layout"
+}
+
+// Update 없거나 role 이 볼 수 없는 위젯, 중복된 위젯은 ErrWeirdData, 빈 배치는 모든 위젯을 숨긴 것
+func (l *DashboardLayout) Update(widgets []DashboardWidget, role UserRole, now time.Time) error {
+	seen := make(map[DashboardWidget]bool, len(widgets))
+	for _, widget := range widgets {
+		if !widget.AllowedFor(role) || seen[widget] {
+			return ErrWeirdData
+		}
+		seen[widget] = true
+	}
+
+	if widgets == nil {
+		widgets = []DashboardWidget{}
+	}
+	raw, err := json.Marshal(widgets)
+	if err != nil {
+		return err
+	}
+
+	l.Widgets = string(raw)
+	l.UpdatedAt = now
+	return nil
+}
+
+// ParseWidgets 위젯이 없어진 뒤에 남은 값은 건너뜀
+func (l DashboardLayout) ParseWidgets() (widgets []DashboardWidget) {
+	var raw []DashboardWidget
+	_ = json.Unmarshal([]byte(l.Widgets), &raw)
+
+	widgets = make([]DashboardWidget, 0, len(raw))
+	for _, widget := range raw {
+		if widget.IsValid() {
+			widgets = append(widgets, widget)
+		}
+	}
+	return
+}
+
+// DashboardSlaBreach 마감 지난 의뢰 한 건
+type DashboardSlaBreach struct {
+	OrderId  uuid.UUID
+	Number   *string
+	DueDate  time.Time
+	Assignee *uuid.UUID
+}
+
+type DashboardRepository interface {
+	SaveLayout(ctx context.Context, layout *DashboardLayout) error
+	GetLayoutByAdminId(ctx context.Context, adminId uuid.UUID) (*DashboardLayout, error)
+
+	// CountOrderBacklog 임시 의뢰 제외, 끝나지 않은 의뢰 중 담당자 배정 전, 배정 후 수
+	CountOrderBacklog(ctx context.Context) (ready, processing int64, err error)
+	// CountAssignedOrders 담당자의 끝나지 않은 의뢰 수, 그 중 마감 날짜가 today 전인 수
+	CountAssignedOrders(ctx context.Context, assignee uuid.UUID, today time.Time) (open, overdue int64, err error)
+	// CountAssignedTasks 담당자의 열린 할 일 수, 그 중 마감이 now 전인 수
+	CountAssignedTasks(ctx context.Context, assignee uuid.UUID, now time.Time) (open, overdue int64, err error)
+	// CountSlaBreaches 끝나지 않은 의뢰 중 마감 날짜가 today 전인 수
+	CountSlaBreaches(ctx context.Context, today time.Time) (int64, error)
+	// FetchSlaBreaches 마감 날짜가 오래된 순
+	FetchSlaBreaches(ctx context.Context, today time.Time, limit int) ([]DashboardSlaBreach, error)
+	// SumRevenue [from, to) 기간에 만든 이용권의 결제 금액 합, 이용권 수
+	SumRevenue(ctx context.Context, from, to time.Time) (amount, tickets int64, err error)
+}
+
+type DashboardLayoutInfo struct {
+	Widgets []DashboardWidget
+	// UpdatedAt 저장한 적 없으면 nil
+	UpdatedAt *time.Time
+}
+
+type UpdateDashboardLayout struct {
+	AdminId uuid.UUID
+	Role    UserRole
+	Widgets []DashboardWidget
+}
+
+type DashboardOrderBacklog struct {
+	Ready      int64
+	Processing int64
+}
+
+type DashboardMyAssignments struct {
+	OpenOrders    int64
+	OverdueOrders int64
+	OpenTasks     int64
+	OverdueTasks  int64
+}
+
+type DashboardSlaBreaches struct {
+	Count  int64
+	Orders []DashboardSlaBreach
+}
+
+type DashboardRevenue struct {
+	// Month 이번 달, FinanceMonthLayout
+	Month       string
+	Amount      int64
+	Tickets     int64
+	LastMonth   string
+	LastAmount  int64
+	LastTickets int64
+}
+
+type DashboardUseCase interface {
+	// GetDashboardLayout 저장한 적 없으면 DashboardWidgets, role 이 볼 수 없는 위젯은 뺌
+	GetDashboardLayout(ctx context.Context, adminId uuid.UUID, role UserRole) (DashboardLayoutInfo, error)
+	// UpdateDashboardLayout 없거나 role 이 볼 수 없는 위젯, 중복된 위젯은 ErrWeirdData
+	UpdateDashboardLayout(ctx context.Context, in UpdateDashboardLayout) (DashboardLayoutInfo, error)
+
+	GetOrderBacklog(ctx context.Context) (DashboardOrderBacklog, error)
+	GetMyAssignments(ctx context.Context, adminId uuid.UUID) (DashboardMyAssignments, error)
+	GetSlaBreaches(ctx context.Context) (DashboardSlaBreaches, error)
+	GetRevenue(ctx context.Context) (DashboardRevenue, error)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DeadLetterDefaultLimit 목록 기본 개수
+	DeadLetterDefaultLimit = 50
+)
+
+// DeadLetterKind 재시도를 다 써서 더 이상 보내지 않는 메시지의 출처
+type DeadLetterKind string
+
+const (
+	// DeadLetterKindOutbox 메시지 브로커 발행에 OutboxMaxAttempts 번 실패한 이벤트
+	DeadLetterKindOutbox DeadLetterKind = "OUTBOX"
+	// DeadLetterKindHook REST Hook 전송에 HookMaxAttempts 번 실패한 전송
+	DeadLetterKindHook DeadLetterKind = "HOOK"
+)
+
+// DeadLetter 출처마다 다른 레코드를 같은 모양으로, Target 은 outbox 면 aggregate, hook 이면 받는 주소
+type DeadLetter struct {
+	Id        uuid.UUID
+	Kind      DeadLetterKind
+	Type      string
+	Target    string
+	Attempts  uint16
+	LastError *string
+	Payload   string
+	CreatedAt time.Time
+}
+
+type FetchDeadLetterOption struct {
+	// Before 이 시각보다 먼저 만든 것만, 다음 쪽을 가져올 때 이전 목록의 마지막 CreatedAt
+	Before *time.Time
+	Limit  int
+}
+
+// DeadLetterRepository 출처별 구현, ids 가 비어있으면 전체
+// 재시도는 시도 횟수를 되돌려 다음 발행/전송 작업이 다시 보내게 함
+type DeadLetterRepository interface {
+	FetchDead(ctx context.Context, option FetchDeadLetterOption) ([]DeadLetter, error)
+	GetDead(ctx context.Context, id uuid.UUID) (*DeadLetter, error)
+	RetryDead(ctx context.Context, ids []uuid.UUID) (int64, error)
+	PurgeDead(ctx context.Context, ids []uuid.UUID) (int64, error)
+}
+
+type DeadLetterRepositories map[DeadLetterKind]DeadLetterRepository
+
+type FetchDeadLetters struct {
+	Kind   DeadLetterKind
+	Before *time.Time
+	Limit  int
+}
+
+// HandleDeadLetters All 이 아니면 Ids 가 있어야 함, 실수로 전체를 지우지 않도록
+type HandleDeadLetters struct {
+	Kind DeadLetterKind
+	Ids  []uuid.UUID
+	All  bool
+}
+
+type DeadLetterUseCase interface {
+	FetchDeadLetters(ctx context.Context, in FetchDeadLetters) ([]DeadLetter, error)
+	GetDeadLetter(ctx context.Context, kind DeadLetterKind, id uuid.UUID) (DeadLetter, error)
+
+	// RetryDeadLetters 재시도 예약한 개수
+	RetryDeadLetters(ctx context.Context, in HandleDeadLetters) (int64, error)
+	// PurgeDeadLetters 지운 개수
+	PurgeDeadLetters(ctx context.Context, in HandleDeadLetters) (int64, error)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailProvider 메일 발송 서비스
+type EmailProvider string
+
+const (
+	// EmailProviderSes Amazon SES, SNS 구독으로 이벤트 수신
+	EmailProviderSes EmailProvider = "SES"
+	// EmailProviderSendGrid SendGrid Event Webhook
+	EmailProviderSendGrid EmailProvider = "SENDGRID"
+)
+
+// EmailEventType 발송 서비스가 알려준 메일 하나의 결과
+type EmailEventType string
+
+const (
+	EmailEventTypeDelivered  EmailEventType = "DELIVERED"
+	EmailEventTypeBounced    EmailEventType = "BOUNCED"
+	EmailEventTypeComplained EmailEventType = "COMPLAINED"
+)
+
+// EmailEvent 메일(메시지 아이디) 받는 사람 하나의 결과, 같은 이벤트가 다시 와도 한 번만 기록
+type EmailEvent struct {
+	Id        uuid.UUID      `gorm:"type:char(36);primaryKey"`
+	Provider  EmailProvider  `gorm:"size:20;uniqueIndex:idx_email_event_message;not null"`
+	MessageId string         `gorm:"size:255;uniqueIndex:idx_email_event_message;not null"`
+	Email     string         `gorm:"size:320;uniqueIndex:idx_email_event_message;index;not null"`
+	Type      EmailEventType `gorm:"size:20;uniqueIndex:idx_email_event_message;not null"`
+	// Permanent 영구 반송(없는 주소 등), 일시 반송은 false
+	Permanent  bool      `gorm:"not null"`
+	Reason     *string   `gorm:"size:1000"`
+	OccurredAt time.Time `gorm:"type:datetime(6);not null"`
+	CreatedAt  time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (EmailEvent) TableName() string {
+	return "email_event"
+}
+
+// Undeliverable 영구 반송이나 수신 거부 신고면 더 이상 보내면 안되는 주소
+func (e EmailEvent) Undeliverable() bool {
+	return e.Type == EmailEventTypeComplained || (e.Type == EmailEventTypeBounced && e.Permanent)
+}
+
+type EmailEventRepository interface {
+	// Create 같은 발송 서비스, 메시지, 주소, 결과가 이미 있으면 그대로 둠
+	Create(ctx context.Context, list []EmailEvent) error
+}
+
+// RecordEmailEvent 발송 서비스 webhook 에서 꺼낸 이벤트 하나
+type RecordEmailEvent struct {
+	MessageId  string
+	Email      string
+	Type       EmailEventType
+	Permanent  bool
+	Reason     string
+	OccurredAt time.Time
+}
+
+type EmailEventRun struct {
+	Recorded int64
+	// Flagged 이번에 받을 수 없는 주소로 표시된 고객 수
+	Flagged int64
+	// Cleared 다시 받은 메일이 있어 표시를 푼 고객 수
+	Cleared int64
+}
+
+type EmailUseCase interface {
+	// RecordEmailEvents 이벤트를 기록하고, 같은 이메일 고객의 받을 수 없는 주소 표시를 갱신
+	// 영구 반송, 수신 거부 신고면 표시하고, 그 뒤에 전달된 메일이 있으면 표시를 풂
+	RecordEmailEvents(ctx context.Context, provider EmailProvider, list []RecordEmailEvent) (EmailEventRun, error)
+}
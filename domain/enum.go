@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownEnumValue 등록하지 않은 값, DB 에서 읽거나 요청을 풀 때 빈 값으로 넘기지 않고 에러
+var ErrUnknownEnumValue = errors.New("unknown enum value")
+
+// Enum 문자열로 저장하는 값 목록, 디코딩 검증과 DB CHECK 제약(gormx.MigrateEnum)에 같이 사용
+// 새 값은 Values 에 넣기만 하면 다음 시작 때 제약을 넓힘, 값을 빼는 건 파괴적 변경
+type Enum struct {
+	Name   string
+	Table  string
+	Column string
+	Values []string
+}
+
+func newEnum(name, table, column string, values ...string) Enum {
+	return Enum{Name: name, Table: table, Column: column, Values: values}
+}
+
+func (e Enum) Has(value string) bool {
+	for _, v := range e.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Check 없는 값이면 어떤 타입의 어떤 값인지 담은 ErrUnknownEnumValue
+func (e Enum) Check(value string) error {
+	if !e.Has(value) {
+		return fmt.Errorf("%w: %s %q", ErrUnknownEnumValue, e.Name, value)
+	}
+	return nil
+}
+
+// scan DB 값(string, []byte)을 검증해서 반환, NULL(LEFT JOIN 등)은 빈 값
+func (e Enum) scan(src interface{}) (string, error) {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return "", fmt.Errorf("%w: %s from %T", ErrUnknownEnumValue, e.Name, src)
+	}
+	return value, e.Check(value)
+}
+
+func (e Enum) value(value string) (driver.Value, error) {
+	return value, e.Check(value)
+}
+
+var (
+	UserRoleEnum = newEnum("UserRole", "user", "role",
+		string(SuperAdminUserRole), string(AdminUserRole), string(CustomerUserRole))
+	OrderStateCodeEnum = newEnum("OrderStateCode", "order_state", "code",
+		string(OrderStateCodeNone), string(OrderStateCodeDefault), string(OrderStateCodeTake),
+		string(OrderStateCodeRequestEdit), string(OrderStateCodeEditDone), string(OrderStateCodeDone),
+		string(OrderStateCodeCancel))
+)
+
+func (r *UserRole) Scan(src interface{}) error {
+	value, err := UserRoleEnum.scan(src)
+	*r = UserRole(value)
+	return err
+}
+
+func (r UserRole) Value() (driver.Value, error) {
+	return UserRoleEnum.value(string(r))
+}
+
+// UnmarshalText JSON 요청, 쿼리 파라미터에서 없는 역할은 에러
+func (r *UserRole) UnmarshalText(text []byte) error {
+	err := UserRoleEnum.Check(string(text))
+	if err != nil {
+		return err
+	}
+	*r = UserRole(text)
+	return nil
+}
+
+func (c *OrderStateCode) Scan(src interface{}) error {
+	value, err := OrderStateCodeEnum.scan(src)
+	*c = OrderStateCode(value)
+	return err
+}
+
+func (c OrderStateCode) Value() (driver.Value, error) {
+	return OrderStateCodeEnum.value(string(c))
+}
+
+func (c *OrderStateCode) UnmarshalText(text []byte) error {
+	err := OrderStateCodeEnum.Check(string(text))
+	if err != nil {
+		return err
+	}
+	*c = OrderStateCode(text)
+	return nil
+}
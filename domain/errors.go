@@ -16,7 +16,59 @@ var (
 	ErrItemAlreadyExist = errors.New("item already exsits")
 
 	ErrUserNotCustomer = errors.New("not customer")
-	ErrWeirdData = errors.New("request weird data")
+	// ErrUserMerged 다른 고객으로 합쳐져 삭제된 계정, 의뢰와 크레딧이 이미 옮겨져 복구할 수 없음
+	ErrUserMerged = errors.New("user merged into another account")
+	ErrWeirdData  = errors.New("request weird data")
+
+	ErrOrderNotCancelable = errors.New("order not cancelable")
+	// ErrOrderAlreadyCanceled 이미 취소된 의뢰, 이용권 복구는 처음 취소할 때만
+	ErrOrderAlreadyCanceled = errors.New("order already canceled")
+
+	// ErrManagerAtCapacity 담당자가 이미 동시 진행 한도만큼 의뢰를 맡음
+	ErrManagerAtCapacity = errors.New("manager at capacity")
+	// ErrSkillMismatch 담당자가 의뢰에 필요한 작업을 모두 할 수 없음
+	ErrSkillMismatch = errors.New("manager skill mismatch")
+
+	ErrReferralNotAllowed = errors.New("referral not allowed")
+
+	ErrTooManyRequests = errors.New("too many requests")
+
+	ErrInboxHandleFailed = errors.New("inbox message handle failed")
+
+	ErrTokenExpired = errors.New("token expired")
+
+	// ErrInvalidToken 서명, 만료, 내용이 맞지 않는 접근 토큰
+	ErrInvalidToken = errors.New("invalid token")
+
+	ErrPasswordChangeRequired = errors.New("password change required")
+
+	// ErrPasswordTooWeak 비밀번호 규칙(길이, 문자 종류, 흔한 비밀번호)에 맞지 않음
+	ErrPasswordTooWeak = errors.New("password too weak")
+	// ErrPasswordReused 최근에 쓴 비밀번호
+	ErrPasswordReused = errors.New("password recently used")
+
+	// ErrUserLocked 비밀번호를 연속으로 틀려 잠긴 계정, 잠금이 풀릴 때까지 맞는 비밀번호도 거절
+	ErrUserLocked = errors.New("user locked")
+
+	ErrUploadClosed = errors.New("upload completed or aborted")
+
+	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+	ErrUploadMismatch = errors.New("uploaded file size or checksum mismatch")
+
+	ErrChannelRevoked = errors.New("channel access revoked")
+
+	ErrShortLinkExpired = errors.New("short link expired")
+
+	ErrBusinessInfoRequired = errors.New("business info required")
+
+	ErrTermsNotAccepted = errors.New("terms not accepted")
+
+	// ErrContractNotSigned 기업 고객이 가장 최근 계약서에 서명하지 않음
+	ErrContractNotSigned = errors.New("contract not signed")
+
+	// ErrVerificationCodeMismatch 인증 번호가 틀렸거나 인증을 요청한 번호가 아님
+	ErrVerificationCodeMismatch = errors.New("verification code mismatch")
 
 	InvalidateTokenResponse = ErrorResponse{
 		ErrorCode: pointer.String("A-1"),
@@ -28,6 +80,11 @@ var (
 		Message:   ErrNoPermission.Error(),
 	}
 
+	OutOfScopeResponse = ErrorResponse{
+		ErrorCode: pointer.String("A-3"),
+		Message:   "out of token scope",
+	}
+
 	UserSignInFailedResponse = ErrorResponse{
 		ErrorCode: pointer.String("U-1"),
 		Message:   "unauthorized",
@@ -48,6 +105,102 @@ var (
 		Message:   "email exists",
 	}
 
+	OrderNotCancelableResponse = ErrorResponse{
+		ErrorCode: pointer.String("O-1"),
+		Message:   ErrOrderNotCancelable.Error(),
+	}
+
+	OrderAlreadyCanceledResponse = ErrorResponse{
+		ErrorCode: pointer.String("O-2"),
+		Message:   ErrOrderAlreadyCanceled.Error(),
+	}
+
+	ReferralNotAllowedResponse = ErrorResponse{
+		ErrorCode: pointer.String("R-1"),
+		Message:   ErrReferralNotAllowed.Error(),
+	}
+
+	UsernameChangeExpiredResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-5"),
+		Message:   ErrTokenExpired.Error(),
+	}
+
+	PasswordChangeRequiredResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-6"),
+		Message:   ErrPasswordChangeRequired.Error(),
+	}
+
+	PasswordResetExpiredResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-7"),
+		Message:   ErrTokenExpired.Error(),
+	}
+
+	BusinessInfoRequiredResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-8"),
+		Message:   ErrBusinessInfoRequired.Error(),
+	}
+
+	TermsNotAcceptedResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-9"),
+		Message:   ErrTermsNotAccepted.Error(),
+	}
+
+	ContractNotSignedResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-10"),
+		Message:   ErrContractNotSigned.Error(),
+	}
+
+	VerificationCodeMismatchResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-11"),
+		Message:   ErrVerificationCodeMismatch.Error(),
+	}
+
+	VerificationCodeExpiredResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-12"),
+		Message:   ErrTokenExpired.Error(),
+	}
+
+	UserLockedResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-13"),
+		Message:   ErrUserLocked.Error(),
+	}
+
+	PasswordTooWeakResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-14"),
+		Message:   ErrPasswordTooWeak.Error(),
+	}
+
+	PasswordReusedResponse = ErrorResponse{
+		ErrorCode: pointer.String("U-15"),
+		Message:   ErrPasswordReused.Error(),
+	}
+
+	UploadClosedResponse = ErrorResponse{
+		ErrorCode: pointer.String("F-1"),
+		Message:   ErrUploadClosed.Error(),
+	}
+
+	StorageQuotaExceededResponse = ErrorResponse{
+		ErrorCode: pointer.String("F-2"),
+		Message:   ErrStorageQuotaExceeded.Error(),
+	}
+
+	UploadMismatchResponse = ErrorResponse{
+		ErrorCode: pointer.String("F-3"),
+		Message:   ErrUploadMismatch.Error(),
+	}
+
+	TooManyRequestsResponse = ErrorResponse{
+		ErrorCode: pointer.String("T-1"),
+		Message:   ErrTooManyRequests.Error(),
+	}
+
+	// ServerBusyResponse 무거운 요청(내보내기, 리포트)의 동시 실행 수가 다 차서 거절
+	ServerBusyResponse = ErrorResponse{
+		ErrorCode: pointer.String("T-2"),
+		Message:   "server busy",
+	}
+
 	ServerInternalErrorResponse = ErrorResponse{
 		Message: "server internal error",
 	}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateExperimentOption struct {
+	Id       uuid.UUID
+	Key      string
+	Name     string
+	Variants []ExperimentVariant
+	Now      time.Time
+}
+
+func CreateExperiment(option CreateExperimentOption) Experiment {
+	variants := make([]ExperimentVariant, len(option.Variants))
+	for i := range option.Variants {
+		variants[i] = option.Variants[i]
+		variants[i].ExperimentId = option.Id
+	}
+
+	return Experiment{
+		Id:        option.Id,
+		Key:       option.Key,
+		Name:      option.Name,
+		Active:    true,
+		Variants:  variants,
+		CreatedAt: option.Now,
+	}
+}
+
+// Experiment 가격 실험, 고객별로 항상 같은 변형(variant)에 배정
+type Experiment struct {
+	Id        uuid.UUID           `gorm:"type:char(36);primaryKey"`
+	Key       string              `gorm:"size:60;unique;not null"`
+	Name      string              `gorm:"size:120;not null"`
+	Active    bool                `gorm:"index;not null"`
+	Variants  []ExperimentVariant `gorm:"foreignKey:ExperimentId"`
+	CreatedAt time.Time           `gorm:"type:datetime(6);not null"`
+	UpdatedAt time.Time           `gorm:"type:datetime(6);not null"`
+}
+
+func (Experiment) TableName() string {
+	return "experiment"
+}
+
+func (e *Experiment) SetActive(active bool, now time.Time) {
+	e.Active = active
+	e.UpdatedAt = now
+}
+
+// AssignVariant 실험 키와 고객 아이디 해시로 가중치 구간을 골라 배정
+// 구간은 변형 키 순으로 나눔, 조회 순서가 바뀌어도 같은 고객은 같은 변형
+func (e Experiment) AssignVariant(customerId uuid.UUID) *ExperimentVariant {
+	order := make([]int, len(e.Variants))
+	var total uint32
+	for i, v := range e.Variants {
+		order[i] = i
+		total += uint32(v.Weight)
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return e.Variants[order[a]].Key < e.Variants[order[b]].Key
+	})
+
+	h := fnv.New32a()
+	h.Write([]byte(e.Key + ":" + customerId.String()))
+	bucket := h.Sum32() % total
+	for _, i := range order {
+		w := uint32(e.Variants[i].Weight)
+		if bucket < w {
+			return &e.Variants[i]
+		}
+		bucket -= w
+	}
+	return nil
+}
+
+type ExperimentVariant struct {
+	ExperimentId uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Key          string    `gorm:"size:60;primaryKey"`
+	Weight       uint8     `gorm:"not null"`
+	Price        uint32    `gorm:"not null"`
+}
+
+func (ExperimentVariant) TableName() string {
+	return "experiment_variant"
+}
+
+type ExperimentConversion struct {
+	Id           uuid.UUID `gorm:"type:char(36);primaryKey"`
+	ExperimentId uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_experiment_conversion_reference;not null"`
+	CustomerId   uuid.UUID `gorm:"type:char(36);index;not null"`
+	Variant      string    `gorm:"size:60;index;not null"`
+	Reference    string    `gorm:"size:120;uniqueIndex:idx_experiment_conversion_reference;not null"`
+	Amount       uint32    `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"type:datetime(6);index;not null"`
+}
+
+func (ExperimentConversion) TableName() string {
+	return "experiment_conversion"
+}
+
+type ExperimentRepository interface {
+	Save(ctx context.Context, experiment *Experiment) error
+	SaveConversion(ctx context.Context, conversion *ExperimentConversion) error
+
+	GetByKey(ctx context.Context, key string) (*Experiment, error)
+	FetchActive(ctx context.Context) ([]Experiment, error)
+	ExistsConversion(ctx context.Context, experimentId uuid.UUID, reference string) (bool, error)
+	FetchConversions(ctx context.Context, experimentId uuid.UUID) ([]ExperimentConversion, error)
+}
+
+type CreateExperimentVariant struct {
+	Key    string
+	Weight uint8
+	Price  uint32
+}
+
+type CreateExperimentInput struct {
+	Key      string
+	Name     string
+	Variants []CreateExperimentVariant
+}
+
+type ExperimentAssignment struct {
+	ExperimentKey string
+	Variant       string
+	Price         uint32
+}
+
+type RecordConversion struct {
+	ExperimentKey string
+	Username      string
+	Reference     string
+	Amount        uint32
+}
+
+type ExperimentUseCase interface {
+	CreateExperiment(ctx context.Context, in CreateExperimentInput) (uuid.UUID, error)
+	SetExperimentActive(ctx context.Context, key string, active bool) error
+	RecordConversion(ctx context.Context, in RecordConversion) error
+
+	GetMyAssignments(ctx context.Context, customerId uuid.UUID) ([]ExperimentAssignment, error)
+	FetchConversions(ctx context.Context, key string) ([]ExperimentConversion, error)
+}
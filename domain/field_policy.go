@@ -0,0 +1,82 @@
+package domain
+
+// FieldPolicy 역할별로 응답에 내보낼 수 있는 JSON 필드 목록
+// FieldPolicyAllFields 는 전체, 중첩 필드는 "assignee.assigneeNickname" 처럼 점으로 구분
+// 목록에 없는 필드는 내보내지 않으므로 응답에 필드를 추가하면 여기서 역할별로 열어줘야 함
+type FieldPolicy map[UserRole][]string
+
+const (
+	FieldPolicyAllFields = "*"
+
+	// FieldPolicyAnyRole 정책에 없는 역할, 역할을 알 수 없는 요청에 적용
+	FieldPolicyAnyRole UserRole = "*"
+)
+
+const (
+	FieldResourceCustomerList   = "customer.list"
+	FieldResourceCustomerDetail = "customer.detail"
+	FieldResourceCustomerSelf   = "customer.self"
+	FieldResourceAdmin          = "admin"
+	FieldResourceOrderDetail    = "order.detail"
+	FieldResourceOrderRecent    = "order.recent"
+	FieldResourceOrderHistory   = "order.history"
+)
+
+var allFields = []string{FieldPolicyAllFields}
+
+// FieldPolicies 리소스별 정책, 응답 타입은 FieldResource() 로 리소스 이름을 알려줌
+var FieldPolicies = map[string]FieldPolicy{
+	FieldResourceCustomerList: {
+		SuperAdminUserRole: allFields,
+		AdminUserRole:      allFields,
+	},
+	// 고객에게는 내부 메모, 추가 항목, 반송 사유를 보여주지 않음
+	FieldResourceCustomerDetail: {
+		SuperAdminUserRole: allFields,
+		AdminUserRole:      allFields,
+		CustomerUserRole: {"userId", "name", "channelName", "channelLink", "email", "mobile",
+			"personaLink", "onedriveLink", "storageUsed", "storageQuota", "emailUndeliverable"},
+	},
+	FieldResourceCustomerSelf: {
+		SuperAdminUserRole: allFields,
+		AdminUserRole:      allFields,
+		CustomerUserRole:   allFields,
+	},
+	// 어드민은 다른 어드민의 계정 정보(비밀번호 관련 등)를 볼 수 없고 프로필만 봄
+	FieldResourceAdmin: {
+		SuperAdminUserRole: allFields,
+		AdminUserRole:      {"userId", "name", "nickname", "email", "createdAt", "skills"},
+	},
+	// 고객에게는 담당자 닉네임만 보여줌
+	FieldResourceOrderDetail: {
+		SuperAdminUserRole: allFields,
+		AdminUserRole:      allFields,
+		CustomerUserRole: {"orderId", "number", "orderedAt", "dueDate", "assignee.assigneeNickname",
+			"orderState", "orderStateContent", "remainingEditCount", "requirement", "delivery", "requiredSkills"},
+	},
+	FieldResourceOrderRecent: {
+		SuperAdminUserRole: allFields,
+		AdminUserRole:      allFields,
+		CustomerUserRole:   allFields,
+	},
+	// 고객에게는 바꾼 사람 아이디를 보여주지 않고 담당자 닉네임만
+	FieldResourceOrderHistory: {
+		SuperAdminUserRole: allFields,
+		AdminUserRole:      allFields,
+		CustomerUserRole: {"fromState", "fromStateContent", "toState", "toStateContent",
+			"actorNickname", "memo", "changedAt"},
+	},
+}
+
+// AllowedFields 리소스에 정책이 없으면 false (필터링 안함)
+func AllowedFields(resource string, role UserRole) ([]string, bool) {
+	policy, ok := FieldPolicies[resource]
+	if !ok {
+		return nil, false
+	}
+
+	if fields, ok := policy[role]; ok {
+		return fields, true
+	}
+	return policy[FieldPolicyAnyRole], true
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// FileDownloadTTL 다운로드 URL 유효 시간
+	FileDownloadTTL = 15 * time.Minute
+	// FileCleanupBatch 한 번 실행에 정리할 최대 파일 수, 남은 파일은 다음 실행에서 정리
+	FileCleanupBatch = 500
+	// FileOrphanReportDefaultLimit 정리 대상 미리보기 기본 파일 수
+	FileOrphanReportDefaultLimit = 50
+
+	fileNameMaxLength = 255
+)
+
+type CreateFileOption struct {
+	Id          uuid.UUID
+	OwnerId     uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	Now         time.Time
+}
+
+func CreateFile(option CreateFileOption) File {
+	name := cleanFileName(option.Name)
+	return File{
+		Id:          option.Id,
+		OwnerId:     option.OwnerId,
+		Key:         "file/" + option.Id.String() + path.Ext(name),
+		Name:        name,
+		ContentType: option.ContentType,
+		Size:        option.Size,
+		CreatedAt:   option.Now,
+	}
+}
+
+// cleanFileName 경로를 떼고 길이 제한, 저장소 키에는 확장자만 씀
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		name = "file"
+	}
+	if len(name) > fileNameMaxLength {
+		name = name[len(name)-fileNameMaxLength:]
+	}
+	return name
+}
+
+// File 저장소에 올린 파일 메타데이터, 실제 파일은 BlobStorage 의 Key 위치
+// 의뢰(OrderId)에 연결되지 않은 채로 보관 일수가 지나면 정리 대상
+type File struct {
+	Id          uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	OwnerId     uuid.UUID  `gorm:"type:char(36);index;not null"`
+	Key         string     `gorm:"size:300;unique;not null"`
+	Name        string     `gorm:"size:255;not null"`
+	ContentType string     `gorm:"size:100;not null"`
+	Size        int64      `gorm:"not null"`
+	OrderId     *uuid.UUID `gorm:"type:char(36);index"`
+	// Checksum SHA-256 (hex), presigned PUT 으로 올린 파일만 올린 쪽이 알려줌
+	Checksum  string     `gorm:"size:64;not null;default:''"`
+	CreatedAt time.Time  `gorm:"type:datetime(6);index;not null"`
+	DeletedAt *time.Time `gorm:"type:datetime(6);index"`
+}
+
+func (File) TableName() string {
+	return "file"
+}
+
+func (f File) IsDeleted() bool {
+	return f.DeletedAt != nil
+}
+
+func (f *File) AttachToOrder(orderId uuid.UUID) {
+	f.OrderId = &orderId
+}
+
+func (f *File) Delete(now time.Time) {
+	f.DeletedAt = &now
+}
+
+type FileRepository interface {
+	Save(ctx context.Context, file *File) error
+	Transaction(ctx context.Context, fn func(fileRepo FileTxRepository) error) error
+	With(tx gormx.Tx) FileTxRepository
+
+	GetById(ctx context.Context, fileId uuid.UUID) (*File, error)
+	GetByKey(ctx context.Context, key string) (*File, error)
+	// SumSizeByOwnerId 삭제되지 않은 파일 크기 합계
+	SumSizeByOwnerId(ctx context.Context, ownerId uuid.UUID) (int64, error)
+	// GetTotalUsage 삭제되지 않은 전체 파일 수, 크기 합계 (OwnerId 없음)
+	GetTotalUsage(ctx context.Context) (FileOwnerUsage, error)
+	// FetchCustomerUsage 고객별 파일 수, 크기 합계를 크기가 큰 순으로
+	FetchCustomerUsage(ctx context.Context, limit int) ([]FileOwnerUsage, error)
+	// GetOrphanUsage before 전에 올렸고 의뢰에 연결되지 않은 파일 수, 크기 합계
+	GetOrphanUsage(ctx context.Context, before time.Time) (FileOwnerUsage, error)
+	// FetchOrphans before 전에 올렸고 의뢰에 연결되지 않은 파일, 오래된 순
+	FetchOrphans(ctx context.Context, before time.Time, limit int) ([]File, error)
+}
+
+type FileTxRepository interface {
+	FileRepository
+	gormx.Tx
+}
+
+type UploadFile struct {
+	OwnerId     uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+type FileAccess struct {
+	FileId      uuid.UUID
+	RequesterId uuid.UUID
+}
+
+type AttachFileToOrder struct {
+	FileAccess
+	OrderId uuid.UUID
+}
+
+type FileInfo struct {
+	Id          uuid.UUID
+	OwnerId     uuid.UUID
+	OrderId     *uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	Checksum    string
+	CreatedAt   time.Time
+}
+
+// OrphanFileReport 정리하지 않고 대상만 확인 (dry-run)
+type OrphanFileReport struct {
+	// Cutoff 이 시각 전에 올린 파일이 대상, 정리를 끈 경우 nil
+	Cutoff *time.Time
+	Files  int64
+	Bytes  int64
+	// Oldest 오래된 순 일부
+	Oldest []FileInfo
+}
+
+type OrphanFileCleanup struct {
+	Cutoff  *time.Time
+	Deleted int64
+	Bytes   int64
+	Failed  int64
+}
+
+type FileDownload struct {
+	FileInfo
+	URL       string
+	ExpiresAt time.Time
+}
+
+type FileUseCase interface {
+	// UploadFile 고객 저장 한도를 넘으면 ErrStorageQuotaExceeded
+	UploadFile(ctx context.Context, in UploadFile) (FileInfo, error)
+	// DeleteFile 메타데이터는 삭제 표시, 저장소 파일은 바로 삭제
+	DeleteFile(ctx context.Context, in FileAccess) error
+	// AttachFileToOrder 파일을 볼 수 있고 의뢰한 고객 본인이거나 관리자만, 아니면 ErrNoPermission
+	AttachFileToOrder(ctx context.Context, in AttachFileToOrder) error
+	// CleanupOrphanFiles 스케줄러가 주기적으로 호출, 의뢰에 연결되지 않은 채 보관 일수가 지난 파일 삭제
+	CleanupOrphanFiles(ctx context.Context) (OrphanFileCleanup, error)
+
+	// GetFileDownload 올린 사람 또는 관리자만, 아니면 ErrNoPermission
+	GetFileDownload(ctx context.Context, in FileAccess) (FileDownload, error)
+	// GetStorageReport 고객별 사용량, 사용량 상위 limit 명
+	GetStorageReport(ctx context.Context, limit int) (StorageReport, error)
+	GetOrphanFileReport(ctx context.Context, limit int) (OrphanFileReport, error)
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// FilePreviewBatch 한 번 실행에 만들 최대 미리보기 수, 남은 파일은 다음 실행에서 처리
+	FilePreviewBatch = 10
+	// FilePreviewConcurrency 동시에 실행할 ffmpeg 수
+	FilePreviewConcurrency = 2
+	// FilePreviewMaxAttempts 실패 허용 횟수, 넘으면 더 이상 시도 안함
+	FilePreviewMaxAttempts = 3
+	// FilePreviewURLTTL 미리보기 URL 유효 시간
+	FilePreviewURLTTL = time.Hour
+	// FilePreviewSourceTTL ffmpeg 가 원본을 읽을 presigned URL 유효 시간
+	FilePreviewSourceTTL = 30 * time.Minute
+)
+
+type FilePreviewStatus string
+
+const (
+	FilePreviewStatusPending FilePreviewStatus = "PENDING"
+	FilePreviewStatusDone    FilePreviewStatus = "DONE"
+	FilePreviewStatusFailed  FilePreviewStatus = "FAILED"
+)
+
+// IsVideo 미리보기를 만들 수 있는 파일인지
+func (f File) IsVideo() bool {
+	return strings.HasPrefix(f.ContentType, "video/")
+}
+
+func CreateFilePreview(file File, now time.Time) FilePreview {
+	return FilePreview{
+		FileId:    file.Id,
+		Status:    FilePreviewStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// FilePreview 납품 영상 미리보기(썸네일, 짧은 GIF), 파일 하나당 하나
+type FilePreview struct {
+	FileId       uuid.UUID         `gorm:"type:char(36);primaryKey"`
+	Status       FilePreviewStatus `gorm:"size:10;index;not null"`
+	ThumbnailKey *string           `gorm:"size:300"`
+	GifKey       *string           `gorm:"size:300"`
+	Attempts     uint8             `gorm:"not null"`
+	LastError    *string           `gorm:"size:1000"`
+	CreatedAt    time.Time         `gorm:"type:datetime(6);index;not null"`
+	UpdatedAt    time.Time         `gorm:"type:datetime(6);not null"`
+}
+
+func (FilePreview) TableName() string {
+	return "file_preview"
+}
+
+// Keys 저장소 키, 파일 키와 겹치지 않도록 preview/ 아래
+func (p FilePreview) Keys() (thumbnail, gif string) {
+	prefix := "preview/" + p.FileId.String()
+	return prefix + ".jpg", prefix + ".gif"
+}
+
+func (p FilePreview) IsDone() bool {
+	return p.Status == FilePreviewStatusDone
+}
+
+func (p *FilePreview) Succeed(now time.Time) {
+	thumbnail, gif := p.Keys()
+	p.Status = FilePreviewStatusDone
+	p.ThumbnailKey = &thumbnail
+	p.GifKey = &gif
+	p.Attempts++
+	p.LastError = nil
+	p.UpdatedAt = now
+}
+
+// Fail 실패 허용 횟수를 넘으면 FAILED, 아니면 다음 실행에서 다시 시도
+func (p *FilePreview) Fail(err error, now time.Time) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	p.Attempts++
+	p.LastError = &msg
+	if p.Attempts >= FilePreviewMaxAttempts {
+		p.Status = FilePreviewStatusFailed
+	}
+	p.UpdatedAt = now
+}
+
+// Retry 실패한 미리보기를 다시 대기 상태로
+func (p *FilePreview) Retry(now time.Time) {
+	p.Status = FilePreviewStatusPending
+	p.Attempts = 0
+	p.LastError = nil
+	p.UpdatedAt = now
+}
+
+type FilePreviewRepository interface {
+	Save(ctx context.Context, preview *FilePreview) error
+
+	GetByFileId(ctx context.Context, fileId uuid.UUID) (*FilePreview, error)
+	// FetchPending 대기 중인 미리보기, 오래된 순
+	FetchPending(ctx context.Context, limit int) ([]FilePreview, error)
+}
+
+// VideoPreviewer 영상에서 미리보기 이미지 생성 (ffmpeg), 원본은 URL 로 읽음
+type VideoPreviewer interface {
+	// Thumbnail JPEG 한 장
+	Thumbnail(ctx context.Context, sourceURL string, w io.Writer) error
+	// Gif 앞부분 몇 초짜리 반복 GIF
+	Gif(ctx context.Context, sourceURL string, w io.Writer) error
+}
+
+type FilePreviewInfo struct {
+	Status       FilePreviewStatus
+	ThumbnailURL *string
+	GifURL       *string
+	ExpiresAt    *time.Time
+}
+
+type FilePreviewRun struct {
+	Generated int64
+	Failed    int64
+}
+
+type FilePreviewUseCase interface {
+	// GenerateFilePreviews 스케줄러가 주기적으로 호출, 대기 중인 미리보기 생성
+	GenerateFilePreviews(ctx context.Context) (FilePreviewRun, error)
+}
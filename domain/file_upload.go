@@ -0,0 +1,282 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// FileUploadPartSize 기본 조각 크기, 조각 수가 FileUploadMaxParts 를 넘으면 늘림
+	FileUploadPartSize = int64(16 << 20)
+	// FileUploadMaxParts, FileUploadMaxSize S3 멀티파트 제한
+	FileUploadMaxParts = 10000
+	FileUploadMaxSize  = int64(5 << 40)
+	// FileUploadSingleMaxSize presigned PUT 한 번으로 올릴 수 있는 최대 크기 (S3 제한)
+	FileUploadSingleMaxSize = int64(5 << 30)
+	// FileUploadPartURLTTL 조각 업로드 URL 유효 시간
+	FileUploadPartURLTTL = time.Hour
+	// FileUploadStaleAfter 시작 후 이 시간 안에 완료하지 않은 업로드는 스케줄러가 중단
+	FileUploadStaleAfter = 24 * time.Hour
+	// FileUploadAbortBatch 한 번 실행에 중단할 최대 업로드 수
+	FileUploadAbortBatch = 100
+)
+
+type FileUploadStatus string
+
+const (
+	FileUploadStatusUploading FileUploadStatus = "UPLOADING"
+	FileUploadStatusCompleted FileUploadStatus = "COMPLETED"
+	FileUploadStatusAborted   FileUploadStatus = "ABORTED"
+)
+
+type CreateFileUploadOption struct {
+	// Id 업로드, 완료 후 만들어지는 File 이 같이 쓰는 아이디
+	Id          uuid.UUID
+	OwnerId     uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	// Single 조각으로 나누지 않고 presigned PUT 한 번으로 올림
+	Single bool
+	// Checksum Single 일 때만, 올릴 파일의 SHA-256 (hex)
+	Checksum string
+	Now      time.Time
+}
+
+// CreateFileUpload 파일 아이디, 저장소 키는 완료 후 만들어지는 File 과 같음
+func CreateFileUpload(option CreateFileUploadOption) (upload FileUpload, err error) {
+	maxSize := FileUploadMaxSize
+	if option.Single {
+		maxSize = FileUploadSingleMaxSize
+	}
+	if option.Size <= 0 || option.Size > maxSize {
+		err = ErrWeirdData
+		return
+	}
+
+	file := CreateFile(CreateFileOption{
+		Id:          option.Id,
+		OwnerId:     option.OwnerId,
+		Name:        option.Name,
+		ContentType: option.ContentType,
+		Size:        option.Size,
+		Now:         option.Now,
+	})
+
+	partSize := option.Size
+	if !option.Single {
+		partSize = uploadPartSize(option.Size)
+	}
+	upload = FileUpload{
+		Id:          file.Id,
+		OwnerId:     file.OwnerId,
+		Key:         file.Key,
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		PartSize:    partSize,
+		PartCount:   int((option.Size + partSize - 1) / partSize),
+		Single:      option.Single,
+		Checksum:    option.Checksum,
+		Status:      FileUploadStatusUploading,
+		CreatedAt:   file.CreatedAt,
+		ExpiresAt:   file.CreatedAt.Add(FileUploadStaleAfter),
+	}
+	return
+}
+
+// uploadPartSize 조각 수 제한을 넘지 않도록 MB 단위로 올림
+func uploadPartSize(size int64) int64 {
+	min := (size + FileUploadMaxParts - 1) / FileUploadMaxParts
+	if min <= FileUploadPartSize {
+		return FileUploadPartSize
+	}
+	return (min + 1<<20 - 1) / (1 << 20) * (1 << 20)
+}
+
+// FileUpload 진행 중인 멀티파트 업로드, 완료되면 같은 아이디로 File 생성
+// Single 이면 조각 하나(PartCount 1)를 presigned PUT 으로 올리고 StorageUploadId 는 비어있음
+// Checksum 은 presigned PUT 에 서명해 넣은 SHA-256 (hex), 완료할 때 저장소 값과 대조
+type FileUpload struct {
+	Id              uuid.UUID        `gorm:"type:char(36);primaryKey"`
+	OwnerId         uuid.UUID        `gorm:"type:char(36);index;not null"`
+	Key             string           `gorm:"size:300;not null"`
+	Name            string           `gorm:"size:255;not null"`
+	ContentType     string           `gorm:"size:100;not null"`
+	Size            int64            `gorm:"not null"`
+	PartSize        int64            `gorm:"not null"`
+	PartCount       int              `gorm:"not null"`
+	StorageUploadId string           `gorm:"size:1024;not null"`
+	Single          bool             `gorm:"not null;default:false"`
+	Checksum        string           `gorm:"size:64;not null;default:''"`
+	Status          FileUploadStatus `gorm:"size:10;index:idx_file_upload_status_expires;not null"`
+	CreatedAt       time.Time        `gorm:"type:datetime(6);not null"`
+	ExpiresAt       time.Time        `gorm:"type:datetime(6);index:idx_file_upload_status_expires;not null"`
+	ClosedAt        *time.Time       `gorm:"type:datetime(6)"`
+}
+
+func (FileUpload) TableName() string {
+	return "file_upload"
+}
+
+func (u FileUpload) IsOpen(now time.Time) bool {
+	return u.Status == FileUploadStatusUploading && now.Before(u.ExpiresAt)
+}
+
+func (u FileUpload) HasPart(number int) bool {
+	return number >= 1 && number <= u.PartCount
+}
+
+// PartLength 조각 크기, 마지막 조각만 작을 수 있음
+func (u FileUpload) PartLength(number int) int64 {
+	if number == u.PartCount {
+		return u.Size - u.PartSize*int64(u.PartCount-1)
+	}
+	return u.PartSize
+}
+
+// SortedParts 조각 번호 순으로 정렬, 모든 조각이 한 번씩 있어야 하고 아니면 ErrWeirdData
+func (u FileUpload) SortedParts(parts []BlobPart) ([]BlobPart, error) {
+	if len(parts) != u.PartCount {
+		return nil, ErrWeirdData
+	}
+
+	sorted := append([]BlobPart(nil), parts...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Number < sorted[j].Number
+	})
+	for i, part := range sorted {
+		if part.Number != i+1 || part.ETag == "" {
+			return nil, ErrWeirdData
+		}
+	}
+	return sorted, nil
+}
+
+func (u *FileUpload) Complete(now time.Time) File {
+	u.Status = FileUploadStatusCompleted
+	u.ClosedAt = &now
+	return File{
+		Id:          u.Id,
+		OwnerId:     u.OwnerId,
+		Key:         u.Key,
+		Name:        u.Name,
+		ContentType: u.ContentType,
+		Size:        u.Size,
+		Checksum:    u.Checksum,
+		CreatedAt:   now,
+	}
+}
+
+func (u *FileUpload) Abort(now time.Time) {
+	u.Status = FileUploadStatusAborted
+	u.ClosedAt = &now
+}
+
+// FileUploadPart URL 을 발급한 조각, 이어 올리기할 때 참고용
+type FileUploadPart struct {
+	UploadId uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Number   int       `gorm:"primaryKey;autoIncrement:false"`
+	SignedAt time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (FileUploadPart) TableName() string {
+	return "file_upload_part"
+}
+
+type FileUploadRepository interface {
+	Save(ctx context.Context, upload *FileUpload) error
+	SavePart(ctx context.Context, part *FileUploadPart) error
+	With(tx gormx.Tx) FileUploadRepository
+
+	GetById(ctx context.Context, uploadId uuid.UUID) (*FileUpload, error)
+	FetchParts(ctx context.Context, uploadId uuid.UUID) ([]FileUploadPart, error)
+	// FetchStale 기한이 지났는데 진행 중인 업로드, 오래된 순
+	FetchStale(ctx context.Context, now time.Time, limit int) ([]FileUpload, error)
+	// SumOpenSizeByOwnerId 기한 안에 진행 중인 업로드 크기 합계
+	SumOpenSizeByOwnerId(ctx context.Context, ownerId uuid.UUID, now time.Time) (int64, error)
+}
+
+type InitiateFileUpload struct {
+	OwnerId     uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	// Checksum presigned PUT 에만 쓰는 SHA-256 (hex)
+	Checksum string
+}
+
+type FileUploadAccess struct {
+	UploadId    uuid.UUID
+	RequesterId uuid.UUID
+}
+
+type SignFileUploadPart struct {
+	FileUploadAccess
+	PartNumber int
+}
+
+type CompleteFileUpload struct {
+	FileUploadAccess
+	Parts []BlobPart
+}
+
+type FileUploadInfo struct {
+	Id          uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	PartSize    int64
+	PartCount   int
+	Status      FileUploadStatus
+	// SignedParts URL 을 발급한 조각 번호
+	SignedParts []int
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// PresignedFileUpload URL 에 Content-Type 헤더를 ContentType 으로, Headers 도 그대로 지정해 PUT
+type PresignedFileUpload struct {
+	Id          uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	URL         string
+	// Headers 체크섬처럼 서명에 들어간 헤더
+	Headers map[string]string
+	// URLExpiresAt 이때까지 PUT 을 시작해야함, ExpiresAt 이때까지 완료하지 않으면 자동 중단
+	URLExpiresAt time.Time
+	ExpiresAt    time.Time
+}
+
+type FileUploadPartURL struct {
+	PartNumber int
+	Size       int64
+	URL        string
+	ExpiresAt  time.Time
+}
+
+type FileUploadUseCase interface {
+	// InitiateFileUpload 고객 저장 한도를 넘으면 ErrStorageQuotaExceeded
+	InitiateFileUpload(ctx context.Context, in InitiateFileUpload) (FileUploadInfo, error)
+	// SignFileUploadPart 끝났거나 기한이 지난 업로드는 ErrUploadClosed, presigned PUT 업로드면 ErrWeirdData
+	SignFileUploadPart(ctx context.Context, in SignFileUploadPart) (FileUploadPartURL, error)
+	// CompleteFileUpload 조각이 맞지 않으면 ErrWeirdData, 올라간 크기가 시작할 때와 다르면 업로드를 중단하고 ErrUploadMismatch
+	CompleteFileUpload(ctx context.Context, in CompleteFileUpload) (FileInfo, error)
+
+	// PresignFileUpload 5GB 까지는 나누지 않고 presigned PUT 한 번으로 올림, 고객 저장 한도를 넘으면 ErrStorageQuotaExceeded
+	PresignFileUpload(ctx context.Context, in InitiateFileUpload) (PresignedFileUpload, error)
+	// CompletePresignedFileUpload 올라가지 않았으면 ErrItemNotFound, 나눠 올리기 업로드면 ErrWeirdData
+	// 크기나 체크섬이 다르면 업로드를 중단하고 ErrUploadMismatch
+	CompletePresignedFileUpload(ctx context.Context, in FileUploadAccess) (FileInfo, error)
+
+	AbortFileUpload(ctx context.Context, in FileUploadAccess) error
+	// AbortStaleFileUploads 스케줄러가 주기적으로 호출, 중단한 업로드 수 반환
+	AbortStaleFileUploads(ctx context.Context) (int, error)
+
+	GetFileUpload(ctx context.Context, in FileUploadAccess) (FileUploadInfo, error)
+}
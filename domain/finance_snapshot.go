@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FinanceMonthLayout 마감 월 형식 (ex. 2024-05), 기준 시간대(KST) 월
+const FinanceMonthLayout = "2006-01"
+
+// ParseFinanceMonth 기준 시간대로 월의 시작과 다음 달 시작, 형식이 틀리면 ErrWeirdData
+func ParseFinanceMonth(month string, loc *time.Location) (from, to time.Time, err error) {
+	from, err = time.ParseInLocation(FinanceMonthLayout, month, loc)
+	if err != nil {
+		err = ErrWeirdData
+		return
+	}
+	to = from.AddDate(0, 1, 0)
+	return
+}
+
+// FinanceAggregates 월 마감 숫자, 스냅샷을 만든 시점의 데이터로 계산하고 이후 정정은 반영하지 않음
+type FinanceAggregates struct {
+	// TicketsSold 그 달에 만든 이용권 수
+	TicketsSold int64 `gorm:"not null"`
+	// OrderSlotsSold 그 달에 만든 이용권의 의뢰 가능 횟수 합
+	OrderSlotsSold int64 `gorm:"not null"`
+	// ConversionRevenue 가격 실험 전환으로 기록된 결제 금액 합 (원)
+	ConversionRevenue int64 `gorm:"not null"`
+
+	OrdersRequested int64 `gorm:"not null"`
+	OrdersDone      int64 `gorm:"not null"`
+	OrdersCanceled  int64 `gorm:"not null"`
+	// ActiveCustomers 그 달에 의뢰한 고객 수
+	ActiveCustomers int64 `gorm:"not null"`
+
+	// CreditEarned 고객 지갑에 적립된 크레딧 합, 병합으로 옮긴 크레딧은 제외
+	CreditEarned int64 `gorm:"not null"`
+	// CreditSpent 고객 지갑에서 사용된 크레딧 합 (양수)
+	CreditSpent int64 `gorm:"not null"`
+}
+
+// FinanceSnapshot 월 마감 스냅샷, 같은 달을 다시 만들면 Version 을 올려 새로 저장하고 이전 것은 그대로 둠
+type FinanceSnapshot struct {
+	Id      uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Month   string    `gorm:"size:7;uniqueIndex:idx_finance_snapshot_month_version;not null"`
+	Version uint16    `gorm:"uniqueIndex:idx_finance_snapshot_month_version;not null"`
+
+	FinanceAggregates `gorm:"embedded"`
+
+	TakenBy uuid.UUID `gorm:"type:char(36);not null"`
+	TakenAt time.Time `gorm:"type:datetime(6);index;not null"`
+}
+
+func (FinanceSnapshot) TableName() string {
+	return "finance_snapshot"
+}
+
+type FinanceSnapshotRepository interface {
+	// Aggregate [from, to) 기간의 숫자를 한 읽기 전용 트랜잭션 안에서 계산, 테이블마다 다른 시점이 섞이지 않음
+	Aggregate(ctx context.Context, from, to time.Time) (FinanceAggregates, error)
+	// Create 같은 달의 마지막 Version + 1 로 저장
+	Create(ctx context.Context, snapshot *FinanceSnapshot) error
+
+	GetById(ctx context.Context, id uuid.UUID) (*FinanceSnapshot, error)
+	// FetchByMonth 최신 Version 부터
+	FetchByMonth(ctx context.Context, month string) ([]FinanceSnapshot, error)
+}
+
+type TakeFinanceSnapshot struct {
+	Month   string
+	TakenBy uuid.UUID
+}
+
+type FinanceSnapshotInfo struct {
+	Id      uuid.UUID
+	Month   string
+	Version uint16
+	FinanceAggregates
+	TakenBy uuid.UUID
+	TakenAt time.Time
+}
+
+type FinanceSnapshotUseCase interface {
+	// TakeFinanceSnapshot 아직 시작하지 않은 달은 ErrWeirdData
+	TakeFinanceSnapshot(ctx context.Context, in TakeFinanceSnapshot) (FinanceSnapshotInfo, error)
+
+	GetFinanceSnapshot(ctx context.Context, id uuid.UUID) (FinanceSnapshotInfo, error)
+	FetchFinanceSnapshots(ctx context.Context, month string) ([]FinanceSnapshotInfo, error)
+}
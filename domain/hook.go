@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// HookDeliverBatch 한 번 실행에 보낼 최대 전송 수, 남은 전송은 다음 실행에서 보냄
+	HookDeliverBatch = 100
+	// HookDeliverConcurrency 동시에 보낼 전송 수
+	HookDeliverConcurrency = 8
+	// HookMaxAttempts 전송 실패 허용 횟수, 넘으면 더 이상 보내지 않음
+	HookMaxAttempts = 8
+	// HookRetryBase 첫 재시도 간격, 실패할 때마다 두 배
+	HookRetryBase = time.Minute
+
+	hookTargetURLMaxLength = 1000
+)
+
+// ErrHookGone 받는 쪽이 410 으로 응답, REST Hooks 규약에 따라 구독 해지
+var ErrHookGone = errors.New("hook target gone")
+
+// ErrHookTargetBlocked 받는 주소가 내부망(사설, 루프백, 링크 로컬)으로 해석됨
+var ErrHookTargetBlocked = errors.New("hook target blocked")
+
+// HookEvent 구독할 수 있는 이벤트, 개인정보나 토큰이 들어있는 이벤트는 넣지 않음
+type HookEvent string
+
+const (
+	HookEventOrderRequested    = HookEvent(OutboxEventTypeOrderRequested)
+	HookEventOrderStateChanged = HookEvent(OutboxEventTypeOrderStateChanged)
+	HookEventOrderDone         = HookEvent(OutboxEventTypeOrderDone)
+	HookEventOrderCanceled     = HookEvent(OutboxEventTypeOrderCanceled)
+	HookEventCustomerCreated   = HookEvent(OutboxEventTypeCustomerCreated)
+	HookEventCustomerMerged    = HookEvent(OutboxEventTypeCustomerMerged)
+	HookEventPaymentSettled    = HookEvent(InboxTopicPaymentSettled)
+)
+
+var hookEvents = []HookEvent{
+	HookEventOrderRequested,
+	HookEventOrderStateChanged,
+	HookEventOrderDone,
+	HookEventOrderCanceled,
+	HookEventCustomerCreated,
+	HookEventCustomerMerged,
+	HookEventPaymentSettled,
+}
+
+func (e HookEvent) IsValid() bool {
+	for _, event := range hookEvents {
+		if event == e {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateHookTargetURL https 주소만, 내부망 IP 를 직접 적은 주소도 안됨
+// 도메인이 내부망으로 해석되는지는 보낼 때 HookSender 가 확인
+func ValidateHookTargetURL(raw string) error {
+	if len(raw) > hookTargetURLMaxLength {
+		return ErrWeirdData
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return ErrWeirdData
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !HookTargetIPAllowed(ip) {
+		return ErrWeirdData
+	}
+	return nil
+}
+
+// HookTargetIPAllowed 공인 IP 만, 사설, 루프백, 링크 로컬(클라우드 메타데이터 포함), 멀티캐스트는 안됨
+func HookTargetIPAllowed(ip net.IP) bool {
+	return !(ip.IsPrivate() ||
+		ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified())
+}
+
+type CreateHookSubscriptionOption struct {
+	Id        uuid.UUID
+	OwnerId   uuid.UUID
+	Event     HookEvent
+	TargetUrl string
+	Now       time.Time
+}
+
+func CreateHookSubscription(option CreateHookSubscriptionOption) (subscription HookSubscription, err error) {
+	if !option.Event.IsValid() {
+		err = ErrWeirdData
+		return
+	}
+
+	err = ValidateHookTargetURL(option.TargetUrl)
+	if err != nil {
+		return
+	}
+
+	subscription = HookSubscription{
+		Id:        option.Id,
+		OwnerId:   option.OwnerId,
+		Event:     option.Event,
+		TargetUrl: option.TargetUrl,
+		CreatedAt: option.Now,
+	}
+	return
+}
+
+// HookSubscription Zapier 같은 자동화 도구의 REST Hooks 구독
+type HookSubscription struct {
+	Id        uuid.UUID `gorm:"type:char(36);primaryKey"`
+	OwnerId   uuid.UUID `gorm:"type:char(36);index;not null"`
+	Event     HookEvent `gorm:"size:60;index;not null"`
+	TargetUrl string    `gorm:"size:1000;not null"`
+	CreatedAt time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (HookSubscription) TableName() string {
+	return "hook_subscription"
+}
+
+// HookMessage 받는 쪽으로 보내는 본문, 같은 이벤트는 Id 가 같으므로 받는 쪽에서 중복 제거 가능
+type HookMessage struct {
+	Id         string          `json:"id"`
+	Event      HookEvent       `json:"event"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func CreateHookDeliveries(message HookMessage, subscriptions []HookSubscription, ids IdGenerator, now time.Time) (list []HookDelivery, err error) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+
+	list = make([]HookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		list[i] = HookDelivery{
+			Id:            ids.NewId(),
+			HookId:        subscription.Id,
+			EventId:       message.Id,
+			Event:         message.Event,
+			TargetUrl:     subscription.TargetUrl,
+			Payload:       string(payload),
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		}
+	}
+	return
+}
+
+// HookDelivery 구독 하나에 이벤트 하나를 보내는 작업, 같은 이벤트가 다시 와도 한 번만 만듦
+type HookDelivery struct {
+	Id        uuid.UUID `gorm:"type:char(36);primaryKey"`
+	HookId    uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_hook_delivery_hook_event;not null"`
+	EventId   string    `gorm:"size:120;uniqueIndex:idx_hook_delivery_hook_event;not null"`
+	Event     HookEvent `gorm:"size:60;not null"`
+	TargetUrl string    `gorm:"size:1000;not null"`
+	Payload   string    `gorm:"type:json;not null"`
+	Attempts  uint16    `gorm:"not null"`
+	LastError *string   `gorm:"size:1000"`
+	// NextAttemptAt 보내고 나거나 포기하면 nil
+	NextAttemptAt *time.Time `gorm:"type:datetime(6);index"`
+	DeliveredAt   *time.Time `gorm:"type:datetime(6)"`
+	CreatedAt     time.Time  `gorm:"type:datetime(6);index;not null"`
+}
+
+func (HookDelivery) TableName() string {
+	return "hook_delivery"
+}
+
+func (d *HookDelivery) Delivered(now time.Time) {
+	d.Attempts++
+	d.DeliveredAt = &now
+	d.NextAttemptAt = nil
+	d.LastError = nil
+}
+
+// Failed 재시도 간격을 늘리고, 허용 횟수를 넘기면 포기
+func (d *HookDelivery) Failed(err error, now time.Time) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	d.Attempts++
+	d.LastError = &msg
+
+	if d.Attempts >= HookMaxAttempts {
+		d.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(HookRetryBase << (d.Attempts - 1))
+	d.NextAttemptAt = &next
+}
+
+type HookRepository interface {
+	Save(ctx context.Context, subscription *HookSubscription) error
+	// Delete 남은 전송도 같이 지움
+	Delete(ctx context.Context, hookId uuid.UUID) (bool, error)
+	// SaveDeliveries 이미 만든 전송(구독, 이벤트 아이디가 같은)은 건너뜀
+	SaveDeliveries(ctx context.Context, list []HookDelivery) error
+	SaveDelivery(ctx context.Context, delivery *HookDelivery) error
+
+	GetById(ctx context.Context, hookId uuid.UUID) (*HookSubscription, error)
+	FetchByOwnerId(ctx context.Context, ownerId uuid.UUID) ([]HookSubscription, error)
+	FetchByEvent(ctx context.Context, event HookEvent) ([]HookSubscription, error)
+	// FetchDueDeliveries now 까지 보내야 하는 전송, 오래된 순
+	FetchDueDeliveries(ctx context.Context, now time.Time, limit int) ([]HookDelivery, error)
+}
+
+// HookSender 2xx 가 아니면 에러, 410 은 ErrHookGone
+type HookSender interface {
+	Send(ctx context.Context, targetURL string, event HookEvent, payload []byte) error
+}
+
+type SubscribeHook struct {
+	OwnerId   uuid.UUID
+	Event     HookEvent
+	TargetUrl string
+}
+
+type UnsubscribeHook struct {
+	HookId      uuid.UUID
+	RequesterId uuid.UUID
+}
+
+type HookInfo struct {
+	Id        uuid.UUID
+	Event     HookEvent
+	TargetUrl string
+	CreatedAt time.Time
+}
+
+type HookDeliveryRun struct {
+	Delivered    int64
+	Failed       int64
+	Unsubscribed int64
+}
+
+type HookUseCase interface {
+	Subscribe(ctx context.Context, in SubscribeHook) (uuid.UUID, error)
+	// Unsubscribe 구독한 본인 또는 최고 관리자만, 아니면 ErrNoPermission
+	Unsubscribe(ctx context.Context, in UnsubscribeHook) error
+	// Fanout 이벤트를 구독마다 전송으로 만듦, 구독할 수 없는 이벤트는 무시
+	Fanout(ctx context.Context, message HookMessage) error
+	// DeliverHooks 스케줄러가 주기적으로 호출
+	DeliverHooks(ctx context.Context) (HookDeliveryRun, error)
+
+	FetchHooks(ctx context.Context, ownerId uuid.UUID) ([]HookInfo, error)
+}
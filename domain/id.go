@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// IdGenerator 새 엔티티 아이디 발급, 테스트에서 정해진 아이디를 내도록 바꾸거나 UUID 버전 전환에 사용
+// Create* 생성자는 아이디를 직접 발급하지 않고 유스케이스가 발급한 아이디(또는 발급기)를 받음
+type IdGenerator interface {
+	NewId() uuid.UUID
+}
+
+type IdGeneratorFunc func() uuid.UUID
+
+func (f IdGeneratorFunc) NewId() uuid.UUID {
+	return f()
+}
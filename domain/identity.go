@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"github.com/stockfolioofficial/back-editfolio/util/pointer"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserRole string
+
+const (
+	SuperAdminUserRole UserRole = "SUPER_ADMIN"
+	AdminUserRole      UserRole = "ADMIN"
+	CustomerUserRole   UserRole = "CUSTOMER"
+)
+
+// UsernameChangeTTL 아이디(이메일) 변경 확인 토큰 유효 시간
+const UsernameChangeTTL = 24 * time.Hour
+
+// IdentityStatus 로그인 가능 여부, 저장하지 않고 삭제, 비밀번호 변경 필요 여부로 판단
+type IdentityStatus string
+
+const (
+	IdentityStatusActive                 IdentityStatus = "ACTIVE"
+	IdentityStatusPasswordChangeRequired IdentityStatus = "PASSWORD_CHANGE_REQUIRED"
+	IdentityStatusDeleted                IdentityStatus = "DELETED"
+)
+
+type CreateIdentityOption struct {
+	Id       uuid.UUID
+	Role     UserRole
+	Username string
+	Now      time.Time
+}
+
+func CreateIdentity(option CreateIdentityOption) Identity {
+	return Identity{
+		Id:        option.Id,
+		Role:      option.Role,
+		Username:  option.Username,
+		CreatedAt: option.Now,
+		UpdatedAt: option.Now,
+	}
+}
+
+func CheckIdentityAlive(i *Identity, scope ...func(identity Identity) bool) bool {
+	if i == nil || i.IsDeleted() {
+		return false
+	}
+
+	if len(scope) == 0 {
+		return true
+	}
+
+	for _, allow := range scope {
+		if allow(*i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Identity 인증 계정, 아이디(이메일), 비밀번호, 역할만 가짐
+// 고객, 어드민 프로필은 같은 아이디로 따로 저장, 프로필까지 필요하면 User
+type Identity struct {
+	Id        uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	Role      UserRole   `gorm:"size:30;index;not null"`
+	Username  string     `gorm:"size:320;unique;not null"`
+	Password  string     `gorm:"size:60;not null"`
+	CreatedAt time.Time  `gorm:"type:datetime(6);not null"`
+	UpdatedAt time.Time  `gorm:"type:datetime(6);not null"`
+	DeletedAt *time.Time `gorm:"type:datetime(6);index"`
+	DeletedBy *uuid.UUID `gorm:"type:char(36)"`
+	// MergedInto 다른 고객으로 합쳐져 삭제됐으면 남은 고객 Id
+	MergedInto *uuid.UUID `gorm:"type:char(36)"`
+
+	// PendingUsername 확인 대기 중인 새 아이디(이메일), 확인 전까지 기존 아이디로 로그인
+	PendingUsername *string `gorm:"size:320;index"`
+	// PendingUsernameToken 확인 토큰의 sha256, 원본은 메일로만 전달
+	PendingUsernameToken     *string    `gorm:"size:64;index"`
+	PendingUsernameExpiresAt *time.Time `gorm:"type:datetime(6)"`
+
+	// PasswordChangeRequired 다음 로그인 때 비밀번호 변경 강제
+	PasswordChangeRequired bool       `gorm:"not null;default:false"`
+	PasswordChangedAt      *time.Time `gorm:"type:datetime(6)"`
+	// TokenVersion 비밀번호를 바꿀 때마다 올림, 접근 토큰에 담아 이전 버전 토큰은 거부
+	TokenVersion uint32 `gorm:"not null;default:0"`
+}
+
+func (Identity) TableName() string {
+	return "user"
+}
+
+func (i Identity) Status() IdentityStatus {
+	switch {
+	case i.DeletedAt != nil:
+		return IdentityStatusDeleted
+	case i.PasswordChangeRequired:
+		return IdentityStatusPasswordChangeRequired
+	}
+	return IdentityStatusActive
+}
+
+func (i *Identity) UpdateUsername(username string, now time.Time) {
+	i.Username = username
+	i.stampUpdate(now)
+}
+
+// RequestUsernameChange 새 아이디는 확인 전까지 대기시키고 확인 토큰 발급
+// 현재 아이디와 같으면 대기 중인 변경을 취소하고 빈 토큰 반환
+func (i *Identity) RequestUsernameChange(username string, now time.Time) (token string, err error) {
+	if username == i.Username {
+		i.clearPendingUsername()
+		return
+	}
+
+	raw := make([]byte, 32)
+	_, err = rand.Read(raw)
+	if err != nil {
+		return
+	}
+	token = hex.EncodeToString(raw)
+
+	hashed := HashUsernameChangeToken(token)
+	i.PendingUsername = &username
+	i.PendingUsernameToken = &hashed
+	i.PendingUsernameExpiresAt = pointer.Time(now.Add(UsernameChangeTTL))
+	i.stampUpdate(now)
+	return
+}
+
+// ConfirmUsernameChange 대기 중인 아이디로 변경, 만료됐으면 ErrTokenExpired
+func (i *Identity) ConfirmUsernameChange(now time.Time) error {
+	if i.PendingUsername == nil {
+		return ErrItemNotFound
+	}
+	if i.PendingUsernameExpiresAt == nil || !now.Before(*i.PendingUsernameExpiresAt) {
+		return ErrTokenExpired
+	}
+
+	i.UpdateUsername(*i.PendingUsername, now)
+	i.clearPendingUsername()
+	return nil
+}
+
+func (i *Identity) clearPendingUsername() {
+	i.PendingUsername = nil
+	i.PendingUsernameToken = nil
+	i.PendingUsernameExpiresAt = nil
+}
+
+func HashUsernameChangeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (i *Identity) ComparePassword(plainPass string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(i.Password), []byte(plainPass)) == nil
+}
+
+func (i Identity) IsCustomer() bool {
+	return i.HasRole(CustomerUserRole)
+}
+
+func (i Identity) IsAdmin() bool {
+	return i.HasRole(AdminUserRole)
+}
+
+func (i Identity) IsSuperAdmin() bool {
+	return i.HasRole(SuperAdminUserRole)
+}
+
+func (i Identity) HasRole(role UserRole) bool {
+	return i.Role == role
+}
+
+func (i *Identity) IsDeleted() bool {
+	return i.DeletedAt != nil
+}
+
+func (i *Identity) UpdatePassword(plainPass string, now time.Time) {
+	generated, _ := bcrypt.GenerateFromPassword([]byte(plainPass), bcrypt.DefaultCost+2)
+	i.Password = string(generated)
+	i.PasswordChangeRequired = false
+	i.PasswordChangedAt = &now
+	i.TokenVersion++
+	i.stampUpdate(now)
+}
+
+// NeedPasswordRotation 강제 변경 대상이거나 마지막 변경 후 rotationDays 가 지났으면 true,
+// rotationDays 가 0 이하면 주기 변경은 검사 안함, 고객은 비밀번호가 연락처라 대상 아님
+func (i Identity) NeedPasswordRotation(now time.Time, rotationDays int64) bool {
+	if i.IsCustomer() {
+		return false
+	}
+	if i.PasswordChangeRequired {
+		return true
+	}
+	if rotationDays <= 0 {
+		return false
+	}
+
+	changedAt := i.CreatedAt
+	if i.PasswordChangedAt != nil {
+		changedAt = *i.PasswordChangedAt
+	}
+	return !now.Before(changedAt.AddDate(0, 0, int(rotationDays)))
+}
+
+func (i *Identity) StampUpdate(now time.Time) {
+	i.stampUpdate(now)
+}
+
+func (i *Identity) stampUpdate(now time.Time) {
+	i.UpdatedAt = now
+}
+
+func (i *Identity) Delete(by uuid.UUID, now time.Time) {
+	i.DeletedAt = &now
+	i.DeletedBy = &by
+}
+
+// MergeInto 고객 병합으로 삭제, 복구할 수 없음
+func (i *Identity) MergeInto(survivorId, by uuid.UUID, now time.Time) {
+	i.Delete(by, now)
+	i.MergedInto = &survivorId
+}
+
+// Restore 휴지통에서 복구
+func (i *Identity) Restore(now time.Time) {
+	defer i.stampUpdate(now)
+	i.DeletedAt = nil
+	i.DeletedBy = nil
+}
+
+// IdentityRepository 로그인, 비밀번호, 토큰 발급용, 프로필은 읽지 않음
+type IdentityRepository interface {
+	Save(ctx context.Context, identity *Identity) error
+	With(tx gormx.Tx) IdentityRepository
+
+	GetById(ctx context.Context, id uuid.UUID) (*Identity, error)
+	GetByUsername(ctx context.Context, username string) (*Identity, error)
+
+	// RequirePasswordChange role 의 삭제되지 않은 계정 모두 다음 로그인 때 비밀번호 변경하도록 표시
+	RequirePasswordChange(ctx context.Context, role UserRole) (int64, error)
+}
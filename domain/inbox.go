@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// InboxMaxAttempts 처리 실패 허용 횟수, 넘으면 dead letter 로 이동
+	InboxMaxAttempts = 5
+
+	InboxTopicPaymentSettled = "payment.settled"
+	// InboxTopicOrderEvent 이 서버가 발행한 order 토픽 이벤트를 다시 받음, 외부 연동 갱신, REST Hooks 전달용
+	InboxTopicOrderEvent = "order.event"
+	// InboxTopicUserEvent 이 서버가 발행한 user 토픽 이벤트를 다시 받음, REST Hooks 전달용
+	InboxTopicUserEvent = "user.event"
+)
+
+type InboxMessageStatus string
+
+const (
+	InboxMessageStatusProcessed InboxMessageStatus = "PROCESSED"
+	InboxMessageStatusFailed    InboxMessageStatus = "FAILED"
+	InboxMessageStatusDead      InboxMessageStatus = "DEAD"
+)
+
+// InboxHandler 토픽별 메시지 처리기, 같은 메시지가 다시 와도 결과가 같아야 함
+type InboxHandler func(ctx context.Context, payload []byte) error
+
+// InboxHandlers 토픽 이름별 처리기 등록
+type InboxHandlers map[string]InboxHandler
+
+type CreateInboxMessageOption struct {
+	Id        uuid.UUID
+	Topic     string
+	MessageId string
+	Payload   string
+	Now       time.Time
+}
+
+func CreateInboxMessage(option CreateInboxMessageOption) InboxMessage {
+	return InboxMessage{
+		Id:         option.Id,
+		Topic:      option.Topic,
+		MessageId:  option.MessageId,
+		Payload:    option.Payload,
+		Status:     InboxMessageStatusFailed,
+		ReceivedAt: option.Now,
+	}
+}
+
+type InboxMessage struct {
+	Id          uuid.UUID          `gorm:"type:char(36);primaryKey"`
+	Topic       string             `gorm:"size:60;uniqueIndex:idx_inbox_message_topic_message;not null"`
+	MessageId   string             `gorm:"size:120;uniqueIndex:idx_inbox_message_topic_message;not null"`
+	Payload     string             `gorm:"type:json;not null"`
+	Status      InboxMessageStatus `gorm:"size:20;index;not null"`
+	Attempts    uint16             `gorm:"not null"`
+	LastError   *string            `gorm:"size:1000"`
+	ReceivedAt  time.Time          `gorm:"type:datetime(6);index;not null"`
+	ProcessedAt *time.Time         `gorm:"type:datetime(6)"`
+}
+
+func (InboxMessage) TableName() string {
+	return "inbox_message"
+}
+
+// IsDone 이미 처리했거나 dead letter 로 옮긴 메시지는 다시 처리하지 않음
+func (m InboxMessage) IsDone() bool {
+	return m.Status == InboxMessageStatusProcessed || m.Status == InboxMessageStatusDead
+}
+
+func (m *InboxMessage) Processed(now time.Time) {
+	m.Status = InboxMessageStatusProcessed
+	m.ProcessedAt = &now
+	m.LastError = nil
+}
+
+func (m *InboxMessage) Failed(err error) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	m.Attempts++
+	m.LastError = &msg
+	m.Status = InboxMessageStatusFailed
+	if m.Attempts >= InboxMaxAttempts {
+		m.Status = InboxMessageStatusDead
+	}
+}
+
+// Revive dead letter 재처리, 한 번 더 실패하면 다시 dead letter 로 이동
+func (m *InboxMessage) Revive() {
+	m.Status = InboxMessageStatusFailed
+	m.Attempts = InboxMaxAttempts - 1
+}
+
+type InboxRepository interface {
+	Save(ctx context.Context, message *InboxMessage) error
+	Transaction(ctx context.Context, fn func(inboxRepo InboxTxRepository) error) error
+	// Claim 고유 인덱스(topic, message_id)로 먼저 넣고, 이미 있으면 그 행을 잠가서 돌려줌
+	// 트랜잭션 안에서 불러야 같은 메시지를 동시에 받아도 하나만 처리
+	Claim(ctx context.Context, message *InboxMessage) (*InboxMessage, error)
+
+	GetById(ctx context.Context, id uuid.UUID) (*InboxMessage, error)
+	GetByTopicAndMessageId(ctx context.Context, topic, messageId string) (*InboxMessage, error)
+	FetchByStatus(ctx context.Context, status InboxMessageStatus) ([]InboxMessage, error)
+}
+
+type InboxTxRepository interface {
+	InboxRepository
+	gormx.Tx
+}
+
+type ConsumeInboxMessage struct {
+	Topic     string
+	MessageId string
+	Payload   []byte
+}
+
+type InboxMessageInfo struct {
+	Id         uuid.UUID
+	Topic      string
+	MessageId  string
+	Payload    string
+	Attempts   uint16
+	LastError  *string
+	ReceivedAt time.Time
+}
+
+type InboxUseCase interface {
+	// Consume 처리에 실패하면 에러를 돌려줘 발신 측이 다시 보내도록 함, dead letter 로 옮긴 경우 에러 없음
+	Consume(ctx context.Context, in ConsumeInboxMessage) error
+	RetryDeadLetter(ctx context.Context, id uuid.UUID) error
+
+	FetchDeadLetters(ctx context.Context) ([]InboxMessageInfo, error)
+}
@@ -0,0 +1,353 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// IntegrationSnapshotDays 주기 내보내기 대상, 이 기간 안에 요청했거나 아직 끝나지 않은 의뢰
+	IntegrationSnapshotDays = 90
+	// IntegrationSnapshotLimit 한 번에 내보낼 최대 의뢰 수
+	IntegrationSnapshotLimit = 5000
+
+	integrationNameMaxLength   = 100
+	integrationTargetMaxLength = 200
+	integrationColumnMaxLength = 100
+	integrationDateLayout      = "2006-01-02"
+)
+
+// IntegrationProvider 의뢰 현황을 내보낼 외부 서비스
+type IntegrationProvider string
+
+const (
+	// IntegrationProviderGoogleSheets Target 은 "<spreadsheetId>/<시트 이름>", 자격 증명은 서비스 계정 키(JSON)
+	IntegrationProviderGoogleSheets IntegrationProvider = "GOOGLE_SHEETS"
+	// IntegrationProviderNotion Target 은 데이터베이스 아이디, 자격 증명은 내부 통합 토큰
+	IntegrationProviderNotion IntegrationProvider = "NOTION"
+)
+
+func (p IntegrationProvider) IsValid() bool {
+	switch p {
+	case IntegrationProviderGoogleSheets, IntegrationProviderNotion:
+		return true
+	}
+	return false
+}
+
+// IntegrationField 내보낼 수 있는 의뢰 항목
+type IntegrationField string
+
+const (
+	// IntegrationFieldOrderId 행을 찾는 키, 매핑에 반드시 포함
+	IntegrationFieldOrderId            IntegrationField = "orderId"
+	IntegrationFieldOrderedAt          IntegrationField = "orderedAt"
+	IntegrationFieldOrdererName        IntegrationField = "ordererName"
+	IntegrationFieldChannelName        IntegrationField = "channelName"
+	IntegrationFieldAssignee           IntegrationField = "assignee"
+	IntegrationFieldState              IntegrationField = "state"
+	IntegrationFieldDueDate            IntegrationField = "dueDate"
+	IntegrationFieldDoneAt             IntegrationField = "doneAt"
+	IntegrationFieldCanceledAt         IntegrationField = "canceledAt"
+	IntegrationFieldRemainingEditCount IntegrationField = "remainingEditCount"
+)
+
+var integrationFields = []IntegrationField{
+	IntegrationFieldOrderId,
+	IntegrationFieldOrderedAt,
+	IntegrationFieldOrdererName,
+	IntegrationFieldChannelName,
+	IntegrationFieldAssignee,
+	IntegrationFieldState,
+	IntegrationFieldDueDate,
+	IntegrationFieldDoneAt,
+	IntegrationFieldCanceledAt,
+	IntegrationFieldRemainingEditCount,
+}
+
+func (f IntegrationField) IsValid() bool {
+	for _, field := range integrationFields {
+		if field == f {
+			return true
+		}
+	}
+	return false
+}
+
+// IntegrationColumn 의뢰 항목을 넣을 시트 열 이름 또는 Notion 속성 이름
+type IntegrationColumn struct {
+	Field  IntegrationField `json:"field"`
+	Column string           `json:"column"`
+}
+
+type IntegrationMapping []IntegrationColumn
+
+// Validate 없는 항목, 빈/중복 열 이름, orderId 누락은 ErrWeirdData
+func (m IntegrationMapping) Validate() error {
+	var (
+		hasKey  bool
+		columns = make(map[string]bool, len(m))
+	)
+	for _, c := range m {
+		if !c.Field.IsValid() || c.Column == "" || len(c.Column) > integrationColumnMaxLength || columns[c.Column] {
+			return ErrWeirdData
+		}
+		columns[c.Column] = true
+		if c.Field == IntegrationFieldOrderId {
+			hasKey = true
+		}
+	}
+	if !hasKey {
+		return ErrWeirdData
+	}
+	return nil
+}
+
+// Table 의뢰 스냅샷을 매핑한 열 이름으로, 날짜는 ISO 8601 문자열
+func (m IntegrationMapping) Table(list []OrderSnapshot) IntegrationTable {
+	table := IntegrationTable{
+		Columns: make([]string, len(m)),
+		Rows:    make([]map[string]string, len(list)),
+	}
+	for i, c := range m {
+		table.Columns[i] = c.Column
+		if c.Field == IntegrationFieldOrderId {
+			table.KeyColumn = c.Column
+		}
+	}
+
+	for i, snapshot := range list {
+		values := snapshot.values()
+		row := make(map[string]string, len(m))
+		for _, c := range m {
+			row[c.Column] = values[c.Field]
+		}
+		table.Rows[i] = row
+	}
+	return table
+}
+
+// IntegrationTable 내보낼 표, KeyColumn 값이 같은 행은 갱신하고 없으면 추가
+type IntegrationTable struct {
+	KeyColumn string
+	Columns   []string
+	Rows      []map[string]string
+}
+
+// OrderSnapshot 내보내는 시점의 의뢰 현황
+type OrderSnapshot struct {
+	OrderId            uuid.UUID
+	OrderedAt          time.Time
+	OrdererName        string
+	ChannelName        string
+	AssigneeNickname   *string
+	StateContent       string
+	DueDate            *time.Time
+	DoneAt             *time.Time
+	CanceledAt         *time.Time
+	RemainingEditCount uint8
+}
+
+func (s OrderSnapshot) values() map[IntegrationField]string {
+	values := map[IntegrationField]string{
+		IntegrationFieldOrderId:            s.OrderId.String(),
+		IntegrationFieldOrderedAt:          s.OrderedAt.UTC().Format(time.RFC3339),
+		IntegrationFieldOrdererName:        s.OrdererName,
+		IntegrationFieldChannelName:        s.ChannelName,
+		IntegrationFieldState:              s.StateContent,
+		IntegrationFieldRemainingEditCount: strconv.Itoa(int(s.RemainingEditCount)),
+	}
+	if s.AssigneeNickname != nil {
+		values[IntegrationFieldAssignee] = *s.AssigneeNickname
+	}
+	if s.DueDate != nil {
+		values[IntegrationFieldDueDate] = s.DueDate.Format(integrationDateLayout)
+	}
+	if s.DoneAt != nil && s.CanceledAt == nil {
+		values[IntegrationFieldDoneAt] = s.DoneAt.UTC().Format(time.RFC3339)
+	}
+	if s.CanceledAt != nil {
+		values[IntegrationFieldCanceledAt] = s.CanceledAt.UTC().Format(time.RFC3339)
+	}
+	return values
+}
+
+type CreateIntegrationOption struct {
+	Id         uuid.UUID
+	Name       string
+	Provider   IntegrationProvider
+	Target     string
+	Mapping    IntegrationMapping
+	OnSchedule bool
+	OnEvent    bool
+	CreatedBy  uuid.UUID
+	Now        time.Time
+}
+
+func CreateIntegration(option CreateIntegrationOption) (integration Integration, err error) {
+	now := option.Now
+	integration = Integration{
+		Id:        option.Id,
+		Provider:  option.Provider,
+		CreatedBy: option.CreatedBy,
+		CreatedAt: now,
+	}
+	err = integration.Update(option.Name, option.Target, option.Mapping, option.OnSchedule, option.OnEvent, now)
+	return
+}
+
+// Integration 운영용 의뢰 현황 내보내기 설정, 자격 증명은 CredentialCipher 로 암호화해서 저장
+type Integration struct {
+	Id         uuid.UUID           `gorm:"type:char(36);primaryKey"`
+	Name       string              `gorm:"size:100;not null"`
+	Provider   IntegrationProvider `gorm:"size:20;not null"`
+	Target     string              `gorm:"size:200;not null"`
+	Mapping    string              `gorm:"type:json;not null"`
+	OnSchedule bool                `gorm:"not null;index"`
+	OnEvent    bool                `gorm:"not null;index"`
+	Enabled    bool                `gorm:"not null;default:true;index"`
+	Sealed     []byte              `gorm:"type:blob;not null"`
+
+	LastPushedAt *time.Time `gorm:"type:datetime(6)"`
+	LastError    *string    `gorm:"size:1000"`
+	CreatedBy    uuid.UUID  `gorm:"type:char(36);not null"`
+	CreatedAt    time.Time  `gorm:"type:datetime(6);not null"`
+	UpdatedAt    time.Time  `gorm:"type:datetime(6);not null"`
+}
+
+func (Integration) TableName() string {
+	return "integration"
+}
+
+// AAD 암호문을 설정 하나에 묶음
+func (i Integration) AAD() []byte {
+	return []byte("integration/" + i.Id.String())
+}
+
+func (i Integration) MappingColumns() (mapping IntegrationMapping) {
+	_ = json.Unmarshal([]byte(i.Mapping), &mapping)
+	return
+}
+
+// Update 자격 증명은 따로 교체
+func (i *Integration) Update(name, target string, mapping IntegrationMapping, onSchedule, onEvent bool, now time.Time) error {
+	if !i.Provider.IsValid() || name == "" || len(name) > integrationNameMaxLength ||
+		target == "" || len(target) > integrationTargetMaxLength {
+		return ErrWeirdData
+	}
+
+	err := mapping.Validate()
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+
+	i.Name = name
+	i.Target = target
+	i.Mapping = string(raw)
+	i.OnSchedule = onSchedule
+	i.OnEvent = onEvent
+	i.UpdatedAt = now
+	return nil
+}
+
+func (i *Integration) SetEnabled(enabled bool, now time.Time) {
+	i.Enabled = enabled
+	i.UpdatedAt = now
+}
+
+func (i *Integration) Pushed(now time.Time) {
+	i.LastPushedAt = &now
+	i.LastError = nil
+}
+
+func (i *Integration) PushFailed(err error) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	i.LastError = &msg
+}
+
+type IntegrationRepository interface {
+	Save(ctx context.Context, integration *Integration) error
+	Delete(ctx context.Context, integrationId uuid.UUID) (bool, error)
+
+	GetById(ctx context.Context, integrationId uuid.UUID) (*Integration, error)
+	FetchAll(ctx context.Context) ([]Integration, error)
+	// FetchEnabled onEvent 가 true 면 상태 변경 때, false 면 주기적으로 내보내는 설정
+	FetchEnabled(ctx context.Context, onEvent bool) ([]Integration, error)
+}
+
+// IntegrationExporter 외부 서비스별 내보내기, 같은 표를 여러 번 보내도 결과가 같아야 함
+type IntegrationExporter interface {
+	// ValidateCredential 저장 전에 자격 증명 형식 확인, 맞지 않으면 ErrWeirdData
+	ValidateCredential(credential []byte) error
+	Push(ctx context.Context, target string, credential []byte, table IntegrationTable) error
+}
+
+// IntegrationExporters 외부 서비스별 내보내기 등록
+type IntegrationExporters map[IntegrationProvider]IntegrationExporter
+
+type CreateIntegrationInput struct {
+	Name       string
+	Provider   IntegrationProvider
+	Target     string
+	Mapping    IntegrationMapping
+	OnSchedule bool
+	OnEvent    bool
+	Credential string
+	CreatedBy  uuid.UUID
+}
+
+type UpdateIntegration struct {
+	IntegrationId uuid.UUID
+	Name          string
+	Target        string
+	Mapping       IntegrationMapping
+	OnSchedule    bool
+	OnEvent       bool
+	Enabled       bool
+	// Credential 비어있으면 기존 자격 증명 유지
+	Credential string
+}
+
+type IntegrationInfo struct {
+	Id           uuid.UUID
+	Name         string
+	Provider     IntegrationProvider
+	Target       string
+	Mapping      IntegrationMapping
+	OnSchedule   bool
+	OnEvent      bool
+	Enabled      bool
+	LastPushedAt *time.Time
+	LastError    *string
+	CreatedBy    uuid.UUID
+	CreatedAt    time.Time
+}
+
+type IntegrationPushRun struct {
+	Pushed int64
+	Failed int64
+}
+
+type IntegrationUseCase interface {
+	CreateIntegration(ctx context.Context, in CreateIntegrationInput) (uuid.UUID, error)
+	UpdateIntegration(ctx context.Context, in UpdateIntegration) error
+	DeleteIntegration(ctx context.Context, integrationId uuid.UUID) error
+	// PushIntegrations 스케줄러가 주기적으로 호출, 주기 내보내기 설정마다 의뢰 현황 전체를 보냄
+	PushIntegrations(ctx context.Context) (IntegrationPushRun, error)
+	// PushOrder 의뢰 상태 변경 이벤트로 호출, 상태 변경 내보내기 설정에 의뢰 하나를 보냄
+	PushOrder(ctx context.Context, orderId uuid.UUID) error
+
+	FetchIntegrations(ctx context.Context) ([]IntegrationInfo, error)
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+type IssueCategory string
+
+const (
+	// IssueCategoryQuality 품질
+	IssueCategoryQuality IssueCategory = "QUALITY"
+
+	// IssueCategoryDelay 지연
+	IssueCategoryDelay IssueCategory = "DELAY"
+
+	// IssueCategoryEtc 기타
+	IssueCategoryEtc IssueCategory = "ETC"
+)
+
+type IssueStatus string
+
+const (
+	IssueStatusOpen          IssueStatus = "OPEN"
+	IssueStatusInvestigating IssueStatus = "INVESTIGATING"
+	IssueStatusResolved      IssueStatus = "RESOLVED"
+)
+
+type IssueCompensation string
+
+const (
+	IssueCompensationNone IssueCompensation = "NONE"
+
+	// IssueCompensationFreeRevision 무료 수정 1회
+	IssueCompensationFreeRevision IssueCompensation = "FREE_REVISION"
+
+	// IssueCompensationCredit 크레딧 지급
+	IssueCompensationCredit IssueCompensation = "CREDIT"
+)
+
+type CreateIssueOption struct {
+	Id       uuid.UUID
+	OrderId  uuid.UUID
+	Reporter uuid.UUID
+	Category IssueCategory
+	Content  string
+	Now      time.Time
+}
+
+func CreateIssue(option CreateIssueOption) Issue {
+	now := option.Now
+	return Issue{
+		Id:        option.Id,
+		OrderId:   option.OrderId,
+		Reporter:  option.Reporter,
+		Category:  option.Category,
+		Status:    IssueStatusOpen,
+		Content:   option.Content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+type Issue struct {
+	Id                 uuid.UUID          `gorm:"type:char(36);primaryKey"`
+	OrderId            uuid.UUID          `gorm:"type:char(36);index;not null"`
+	Reporter           uuid.UUID          `gorm:"type:char(36);index;not null"`
+	Category           IssueCategory      `gorm:"size:20;index;not null"`
+	Status             IssueStatus        `gorm:"size:20;index;not null"`
+	Content            string             `gorm:"size:2000;not null"`
+	EscalatedAt        *time.Time         `gorm:"type:datetime(6);index"`
+	Compensation       *IssueCompensation `gorm:"size:20"`
+	CompensationCredit uint32             `gorm:"not null"`
+	Resolution         *string            `gorm:"size:2000"`
+	Resolver           *uuid.UUID         `gorm:"type:char(36)"`
+	CreatedAt          time.Time          `gorm:"type:datetime(6);index;not null"`
+	UpdatedAt          time.Time          `gorm:"type:datetime(6);not null"`
+	ResolvedAt         *time.Time         `gorm:"type:datetime(6);index"`
+}
+
+func (Issue) TableName() string {
+	return "issue"
+}
+
+func (i *Issue) IsEscalated() bool {
+	return i.EscalatedAt != nil
+}
+
+func (i *Issue) IsResolved() bool {
+	return i.Status == IssueStatusResolved
+}
+
+// Escalate 슈퍼 어드민에게 이관
+func (i *Issue) Escalate(now time.Time) {
+	i.EscalatedAt = &now
+	i.stampUpdate(now)
+}
+
+func (i *Issue) UpdateStatus(status IssueStatus, now time.Time) {
+	i.Status = status
+	i.stampUpdate(now)
+}
+
+func (i *Issue) Resolve(resolver uuid.UUID, resolution string, compensation IssueCompensation, credit uint32, now time.Time) {
+	i.Status = IssueStatusResolved
+	i.Resolver = &resolver
+	i.Resolution = &resolution
+	i.Compensation = &compensation
+	if compensation == IssueCompensationCredit {
+		i.CompensationCredit = credit
+	}
+	i.ResolvedAt = &now
+	i.stampUpdate(now)
+}
+
+func (i *Issue) stampUpdate(now time.Time) {
+	i.UpdatedAt = now
+}
+
+type FetchIssueOption struct {
+	Status    *IssueStatus
+	Category  *IssueCategory
+	Escalated *bool
+	OrderId   *uuid.UUID
+}
+
+type IssueCount struct {
+	Status    IssueStatus
+	Category  IssueCategory
+	Escalated bool
+	Count     int64
+}
+
+type IssueRepository interface {
+	Save(ctx context.Context, issue *Issue) error
+	With(tx gormx.Tx) IssueTxRepository
+
+	GetById(ctx context.Context, id uuid.UUID) (*Issue, error)
+	Fetch(ctx context.Context, option FetchIssueOption) ([]Issue, error)
+
+	CountUnresolved(ctx context.Context) ([]IssueCount, error)
+}
+
+type IssueTxRepository interface {
+	IssueRepository
+	gormx.Tx
+}
+
+type ReportIssue struct {
+	OrderId  uuid.UUID
+	UserId   uuid.UUID
+	Category IssueCategory
+	Content  string
+}
+
+type UpdateIssueStatus struct {
+	IssueId uuid.UUID
+	Status  IssueStatus
+}
+
+type EscalateIssue struct {
+	IssueId uuid.UUID
+}
+
+type ResolveIssue struct {
+	IssueId      uuid.UUID
+	UserId       uuid.UUID
+	Resolution   string
+	Compensation IssueCompensation
+	Credit       uint32
+}
+
+type IssueInfo struct {
+	IssueId            uuid.UUID
+	OrderId            uuid.UUID
+	Reporter           uuid.UUID
+	Category           IssueCategory
+	Status             IssueStatus
+	Content            string
+	Escalated          bool
+	Compensation       *IssueCompensation
+	CompensationCredit uint32
+	Resolution         *string
+	CreatedAt          time.Time
+	ResolvedAt         *time.Time
+}
+
+type IssueDashboard struct {
+	Open          int64
+	Investigating int64
+	Escalated     int64
+	ByCategory    map[IssueCategory]int64
+}
+
+type IssueUseCase interface {
+	ReportIssue(ctx context.Context, in ReportIssue) (uuid.UUID, error)
+	UpdateIssueStatus(ctx context.Context, in UpdateIssueStatus) error
+	EscalateIssue(ctx context.Context, in EscalateIssue) error
+	ResolveIssue(ctx context.Context, in ResolveIssue) error
+
+	GetIssue(ctx context.Context, issueId uuid.UUID) (IssueInfo, error)
+	Fetch(ctx context.Context, option FetchIssueOption) ([]IssueInfo, error)
+	GetDashboard(ctx context.Context) (IssueDashboard, error)
+}
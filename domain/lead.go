@@ -0,0 +1,271 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// LeadDefaultLimit 목록 기본 개수
+	LeadDefaultLimit = 50
+
+	// LeadFunnelMaxMonths 전환율 조회 최대 기간
+	LeadFunnelMaxMonths = 24
+
+	// LeadChannelDirect utm_source 없이 들어온 리드의 유입 채널
+	LeadChannelDirect = "direct"
+)
+
+// LeadStage 리드 → 상담 → 체험 → 결제 순서로만 진행, 건너뛴 단계는 같은 시각에 지난 것으로 기록
+type LeadStage string
+
+const (
+	LeadStageLead    LeadStage = "LEAD"
+	LeadStageConsult LeadStage = "CONSULT"
+	LeadStageTrial   LeadStage = "TRIAL"
+	LeadStagePaid    LeadStage = "PAID"
+)
+
+// LeadStages 진행 순서
+var LeadStages = []LeadStage{LeadStageLead, LeadStageConsult, LeadStageTrial, LeadStagePaid}
+
+func (s LeadStage) order() int {
+	for i := range LeadStages {
+		if LeadStages[i] == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s LeadStage) IsValid() bool {
+	return s.order() >= 0
+}
+
+// LeadUtm 리드 폼에서 받은 UTM 파라미터
+type LeadUtm struct {
+	Source   string `gorm:"size:100;index;not null"`
+	Medium   string `gorm:"size:100;not null"`
+	Campaign string `gorm:"size:100;not null"`
+	Term     string `gorm:"size:100;not null"`
+	Content  string `gorm:"size:100;not null"`
+}
+
+// Channel 유입 채널, utm_source 가 없으면 LeadChannelDirect
+func (u LeadUtm) Channel() string {
+	if u.Source == "" {
+		return LeadChannelDirect
+	}
+	return u.Source
+}
+
+type CreateLeadOption struct {
+	Id      uuid.UUID
+	Name    string
+	Email   string
+	Mobile  string
+	Company string
+	Message string
+	Utm     LeadUtm
+	Now     time.Time
+}
+
+func CreateLead(option CreateLeadOption) Lead {
+	return Lead{
+		Id:        option.Id,
+		Name:      option.Name,
+		Email:     option.Email,
+		Mobile:    option.Mobile,
+		Company:   option.Company,
+		Message:   option.Message,
+		Utm:       option.Utm,
+		Stage:     LeadStageLead,
+		CreatedAt: option.Now,
+		UpdatedAt: option.Now,
+	}
+}
+
+// Lead 가입 전 상담 문의, 결제하면 고객 계정과 연결
+type Lead struct {
+	Id      uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Name    string    `gorm:"size:60;not null"`
+	Email   string    `gorm:"size:320;index;not null"`
+	Mobile  string    `gorm:"size:20;not null"`
+	Company string    `gorm:"size:100;not null"`
+	Message string    `gorm:"size:2000;not null"`
+	Utm     LeadUtm   `gorm:"embedded;embeddedPrefix:utm_"`
+	Stage   LeadStage `gorm:"size:20;index;not null"`
+
+	// CustomerId 결제 단계로 바꿀 때 연결한 고객
+	CustomerId *uuid.UUID `gorm:"type:char(36);index"`
+
+	CreatedAt   time.Time  `gorm:"type:datetime(6);index;not null"`
+	ConsultedAt *time.Time `gorm:"type:datetime(6)"`
+	TrialAt     *time.Time `gorm:"type:datetime(6)"`
+	PaidAt      *time.Time `gorm:"type:datetime(6)"`
+	UpdatedAt   time.Time  `gorm:"type:datetime(6);not null"`
+
+	// StageChangedBy 마지막으로 단계를 바꾼 관리자
+	StageChangedBy *uuid.UUID `gorm:"type:char(36)"`
+}
+
+func (Lead) TableName() string {
+	return "lead"
+}
+
+// Reached stage 단계까지 왔는지
+func (l Lead) Reached(stage LeadStage) bool {
+	return l.Stage.order() >= stage.order()
+}
+
+// ChangeStage 앞 단계로만 진행, 같거나 이전 단계면 ErrWeirdData
+func (l *Lead) ChangeStage(stage LeadStage, changedBy uuid.UUID, now time.Time) error {
+	if !stage.IsValid() || stage.order() <= l.Stage.order() {
+		return ErrWeirdData
+	}
+
+	for _, s := range LeadStages[l.Stage.order()+1 : stage.order()+1] {
+		switch s {
+		case LeadStageConsult:
+			l.ConsultedAt = &now
+		case LeadStageTrial:
+			l.TrialAt = &now
+		case LeadStagePaid:
+			l.PaidAt = &now
+		}
+	}
+
+	l.Stage = stage
+	l.StageChangedBy = &changedBy
+	l.UpdatedAt = now
+	return nil
+}
+
+type FetchLeadOption struct {
+	Stage *LeadStage
+	// Before 이 시각보다 먼저 들어온 것만, 다음 쪽을 가져올 때 이전 목록의 마지막 CreatedAt
+	Before *time.Time
+	Limit  int
+}
+
+type LeadRepository interface {
+	Save(ctx context.Context, lead *Lead) error
+
+	GetById(ctx context.Context, id uuid.UUID) (*Lead, error)
+	// Fetch 최근에 들어온 리드부터
+	Fetch(ctx context.Context, option FetchLeadOption) ([]Lead, error)
+	// FetchByCreatedAt from 이상 to 미만에 들어온 리드
+	FetchByCreatedAt(ctx context.Context, from, to time.Time) ([]Lead, error)
+}
+
+type SubmitLead struct {
+	Name    string
+	Email   string
+	Mobile  string
+	Company string
+	Message string
+	Utm     LeadUtm
+}
+
+type ChangeLeadStage struct {
+	LeadId uuid.UUID
+	Stage  LeadStage
+	// CustomerId 결제 단계일 때만, 가입한 고객 계정과 연결
+	CustomerId *uuid.UUID
+	ChangedBy  uuid.UUID
+}
+
+type FetchLeads struct {
+	Stage  *LeadStage
+	Before *time.Time
+	Limit  int
+}
+
+type LeadInfo struct {
+	Id          uuid.UUID
+	Name        string
+	Email       string
+	Mobile      string
+	Company     string
+	Message     string
+	Utm         LeadUtm
+	Stage       LeadStage
+	CustomerId  *uuid.UUID
+	CreatedAt   time.Time
+	ConsultedAt *time.Time
+	TrialAt     *time.Time
+	PaidAt      *time.Time
+}
+
+// LeadFunnelStat 들어온 리드 중 각 단계까지 온 수, 비율은 바로 앞 단계 대비, 분모가 0 이면 0
+type LeadFunnelStat struct {
+	Leads     int64
+	Consulted int64
+	Trial     int64
+	Paid      int64
+
+	ConsultRate float64
+	TrialRate   float64
+	PaidRate    float64
+	// ConversionRate 리드 대비 결제
+	ConversionRate float64
+}
+
+func (s *LeadFunnelStat) Add(lead Lead) {
+	s.Leads++
+	if lead.Reached(LeadStageConsult) {
+		s.Consulted++
+	}
+	if lead.Reached(LeadStageTrial) {
+		s.Trial++
+	}
+	if lead.Reached(LeadStagePaid) {
+		s.Paid++
+	}
+}
+
+// Rate 더한 뒤 비율 계산
+func (s *LeadFunnelStat) Rate() {
+	s.ConsultRate = rateOf(s.Consulted, s.Leads)
+	s.TrialRate = rateOf(s.Trial, s.Consulted)
+	s.PaidRate = rateOf(s.Paid, s.Trial)
+	s.ConversionRate = rateOf(s.Paid, s.Leads)
+}
+
+func rateOf(n, d int64) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+type LeadFunnelChannel struct {
+	Channel string
+	Stat    LeadFunnelStat
+}
+
+// LeadFunnelMonth 그 달(기준 시간대)에 들어온 리드의 현재 단계 기준
+type LeadFunnelMonth struct {
+	Month    string
+	Stat     LeadFunnelStat
+	Channels []LeadFunnelChannel
+}
+
+type LeadFunnelInfo struct {
+	Total    LeadFunnelStat
+	Months   []LeadFunnelMonth
+	Channels []LeadFunnelChannel
+}
+
+type LeadUseCase interface {
+	SubmitLead(ctx context.Context, in SubmitLead) (uuid.UUID, error)
+	// ChangeLeadStage 없는 리드, 고객이 아닌 CustomerId 는 ErrItemNotFound, 뒤로 가면 ErrWeirdData
+	ChangeLeadStage(ctx context.Context, in ChangeLeadStage) (LeadInfo, error)
+
+	GetLead(ctx context.Context, id uuid.UUID) (LeadInfo, error)
+	FetchLeads(ctx context.Context, in FetchLeads) ([]LeadInfo, error)
+	// GetLeadFunnel from, to 는 FinanceMonthLayout, 최대 LeadFunnelMaxMonths 개월
+	GetLeadFunnel(ctx context.Context, from, to string) (LeadFunnelInfo, error)
+}
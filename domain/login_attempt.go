@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginAttemptLimit 연속으로 이만큼 틀리면 설정(security.sign_in_lock_minutes) 동안 잠금
+const LoginAttemptLimit = 5
+
+// LoginAttempt 계정별 연속 로그인 실패, 로그인에 성공하면 지움
+type LoginAttempt struct {
+	UserId uuid.UUID `gorm:"type:char(36);primaryKey"`
+	// Failures 마지막 잠금 뒤로 틀린 횟수
+	Failures     uint8      `gorm:"not null"`
+	LastFailedAt time.Time  `gorm:"type:datetime(6);not null"`
+	LockedUntil  *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempt"
+}
+
+func (a LoginAttempt) IsLocked(at time.Time) bool {
+	return a.LockedUntil != nil && at.Before(*a.LockedUntil)
+}
+
+// Fail 틀린 횟수 1 증가, LoginAttemptLimit 번째면 lockFor 동안 잠그고 처음부터 다시 셈, 잠갔으면 true
+// lockFor 가 0 이면 잠그지 않음
+func (a *LoginAttempt) Fail(now time.Time, lockFor time.Duration) bool {
+	a.Failures++
+	a.LastFailedAt = now
+	if lockFor <= 0 || a.Failures < LoginAttemptLimit {
+		return false
+	}
+
+	until := now.Add(lockFor)
+	a.LockedUntil = &until
+	a.Failures = 0
+	return true
+}
+
+type LoginAttemptRepository interface {
+	// Fail 행을 잠그고 LoginAttempt.Fail 을 적용해 저장, 동시에 틀려도 횟수가 빠지지 않음
+	// 이미 잠겨 있으면 세지 않고 locked 는 true
+	Fail(ctx context.Context, userId uuid.UUID, now time.Time, lockFor time.Duration) (attempt LoginAttempt, locked bool, err error)
+	// Delete 없어도 에러 아님
+	Delete(ctx context.Context, userId uuid.UUID) error
+
+	// GetByUserId 없으면 nil
+	GetByUserId(ctx context.Context, userId uuid.UUID) (*LoginAttempt, error)
+}
@@ -25,12 +25,26 @@ type Manager struct {
 	Id       uuid.UUID `gorm:"type:char(36);primaryKey"`
 	Name     string    `gorm:"size:60;index;not null"`
 	Nickname string    `gorm:"size:60;index;not null"`
+	// Capacity 동시에 맡을 수 있는 끝나지 않은 의뢰 수, nil 이면 설정(order.manager_capacity) 값, 0 이면 제한 없음
+	Capacity *uint16
+	// Skills 할 수 있는 작업, SkillTag JSON 배열
+	Skills *string `gorm:"type:json"`
 }
 
 func (Manager) TableName() string {
 	return "manager"
 }
 
+func (m Manager) SkillTags() []SkillTag {
+	return decodeSkillTags(m.Skills)
+}
+
+// SetSkillTags 없는 태그는 ErrWeirdData, 빈 목록이면 아무 작업도 자동 배정 받지 않음
+func (m *Manager) SetSkillTags(tags []SkillTag) (err error) {
+	m.Skills, err = encodeSkillTags(tags)
+	return
+}
+
 type ManagerRepository interface {
 	Save(ctx context.Context, manager *Manager) error
 	With(tx gormx.Tx) ManagerTxRepository
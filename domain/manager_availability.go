@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ManagerAvailabilityDateLayout 쉬는 날 입력, 조회 날짜 형식, 기준 시간대(KST)
+	ManagerAvailabilityDateLayout = "2006-01-02"
+	// ManagerUnavailableMaxDays 한 번에 등록, 삭제할 수 있는 기간
+	ManagerUnavailableMaxDays = 60
+	// ManagerAvailabilityOverviewDays 팀 현황은 오늘부터 2주
+	ManagerAvailabilityOverviewDays = 14
+)
+
+// ManagerUnavailability 담당자가 쉬는 날, 그 날은 자동 배정에서 빠지고 배정된 의뢰 마감일은 쉬는 날만큼 미룸
+type ManagerUnavailability struct {
+	Id        uuid.UUID `gorm:"type:char(36);primaryKey"`
+	ManagerId uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_manager_unavailability_manager_date;not null"`
+	// Date Calendar.DateOf 로 정규화한 날짜
+	Date      time.Time `gorm:"type:date;uniqueIndex:idx_manager_unavailability_manager_date;index;not null"`
+	Reason    *string   `gorm:"size:200"`
+	CreatedAt time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (ManagerUnavailability) TableName() string {
+	return "manager_unavailability"
+}
+
+// SkipUnavailableDays from 부터 due 까지 쉬는 날 수만큼 마감일을 미룸, 미룬 날도 쉬는 날이면 한 번 더
+// 모든 날짜는 Calendar.DateOf 로 정규화한 값
+func SkipUnavailableDays(from, due time.Time, unavailable []time.Time) time.Time {
+	if len(unavailable) == 0 {
+		return due
+	}
+
+	off := make(map[int64]bool, len(unavailable))
+	for _, date := range unavailable {
+		off[date.Unix()] = true
+	}
+
+	for date := from; !date.After(due); date = date.AddDate(0, 0, 1) {
+		if off[date.Unix()] {
+			due = due.AddDate(0, 0, 1)
+		}
+	}
+	return due
+}
+
+type ManagerUnavailabilityRepository interface {
+	// Add 이미 등록한 날은 그대로 둠
+	Add(ctx context.Context, list []ManagerUnavailability) error
+	// Delete from, to 날짜 포함, 지운 수 반환
+	Delete(ctx context.Context, managerId uuid.UUID, from, to time.Time) (int64, error)
+
+	// FetchByManagerId from 이후 쉬는 날, 날짜 순
+	FetchByManagerId(ctx context.Context, managerId uuid.UUID, from time.Time) ([]ManagerUnavailability, error)
+	// FetchBetween from, to 날짜 포함, 모든 담당자
+	FetchBetween(ctx context.Context, from, to time.Time) ([]ManagerUnavailability, error)
+	// FetchManagers 삭제되지 않은 어드민, 슈퍼 어드민, 이름 순
+	FetchManagers(ctx context.Context) ([]Manager, error)
+}
+
+type SetManagerUnavailable struct {
+	ManagerId uuid.UUID
+	// From, To 기준 시간대(KST) 날짜 (ManagerAvailabilityDateLayout), 둘 다 포함
+	From   string
+	To     string
+	Reason *string
+}
+
+type ClearManagerUnavailable struct {
+	ManagerId uuid.UUID
+	From      string
+	To        string
+}
+
+type ManagerUnavailableDate struct {
+	Date   time.Time
+	Reason *string
+}
+
+type ManagerAvailabilityInfo struct {
+	ManagerId uuid.UUID
+	Name      string
+	Nickname  string
+	// UnavailableDates 기간 안에 쉬는 날, 날짜 순
+	UnavailableDates []ManagerUnavailableDate
+}
+
+type ManagerAvailabilityUseCase interface {
+	// SetUnavailable 오늘 이후만, 기간이 ManagerUnavailableMaxDays 를 넘거나 날짜가 틀리면 ErrWeirdData
+	SetUnavailable(ctx context.Context, in SetManagerUnavailable) error
+	// ClearUnavailable 기간 안의 쉬는 날 삭제, 날짜가 틀리면 ErrWeirdData
+	ClearUnavailable(ctx context.Context, in ClearManagerUnavailable) error
+
+	// FetchMyUnavailable 오늘부터 등록한 쉬는 날
+	FetchMyUnavailable(ctx context.Context, managerId uuid.UUID) ([]ManagerUnavailableDate, error)
+	// FetchTeamAvailability 모든 담당자의 오늘부터 ManagerAvailabilityOverviewDays 일 동안 쉬는 날, 쉬는 날이 없는 담당자 포함
+	FetchTeamAvailability(ctx context.Context) ([]ManagerAvailabilityInfo, error)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MarketingConsentChannels 광고성 정보 수신 동의(수신동의)를 받는 채널
+var MarketingConsentChannels = []NotificationChannel{NotificationChannelSms, NotificationChannelEmail}
+
+// MarketingConsent 고객 한 명의 채널 하나 광고성 정보 수신 동의, 동의, 철회 시각과 요청 정보를 남김
+type MarketingConsent struct {
+	UserId  uuid.UUID           `gorm:"type:char(36);primaryKey"`
+	Channel NotificationChannel `gorm:"size:20;primaryKey"`
+	Agreed  bool                `gorm:"index;not null"`
+	// AgreedAt 마지막으로 동의한 시각, WithdrawnAt 마지막으로 철회한 시각
+	AgreedAt    *time.Time `gorm:"type:datetime(6)"`
+	WithdrawnAt *time.Time `gorm:"type:datetime(6)"`
+	// Ip, UserAgent 마지막으로 바꾼 요청
+	Ip        string    `gorm:"size:45;not null"`
+	UserAgent string    `gorm:"size:500;not null"`
+	UpdatedAt time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (MarketingConsent) TableName() string {
+	return "marketing_consent"
+}
+
+// Update 같은 값이면 false, 바꾸지 않음
+func (c *MarketingConsent) Update(agreed bool, ip, userAgent string, now time.Time) bool {
+	if c.Agreed == agreed && (c.AgreedAt != nil || c.WithdrawnAt != nil) {
+		return false
+	}
+
+	c.Agreed = agreed
+	if agreed {
+		c.AgreedAt = &now
+	} else {
+		c.WithdrawnAt = &now
+	}
+	if len(userAgent) > 500 {
+		userAgent = userAgent[:500]
+	}
+	c.Ip = ip
+	c.UserAgent = userAgent
+	c.UpdatedAt = now
+	return true
+}
+
+type MarketingConsentRepository interface {
+	Save(ctx context.Context, consent *MarketingConsent) error
+
+	GetByUserIdAndChannel(ctx context.Context, userId uuid.UUID, channel NotificationChannel) (*MarketingConsent, error)
+	FetchByUserId(ctx context.Context, userId uuid.UUID) ([]MarketingConsent, error)
+	// FetchAgreedUserIds userIds 중 channel 에 동의한 사용자
+	FetchAgreedUserIds(ctx context.Context, userIds []uuid.UUID, channel NotificationChannel) ([]uuid.UUID, error)
+	// Summarize 채널별 동의, 철회 수, 삭제된 사용자 제외
+	Summarize(ctx context.Context) ([]MarketingConsentSummary, error)
+}
+
+type UpdateMarketingConsent struct {
+	UserId    uuid.UUID
+	Channel   NotificationChannel
+	Agreed    bool
+	Ip        string
+	UserAgent string
+}
+
+type MarketingConsentSummary struct {
+	Channel   NotificationChannel
+	Agreed    int64
+	Withdrawn int64
+}
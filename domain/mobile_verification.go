@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// MobileVerificationCodeLength 문자로 보내는 인증 번호 자리 수
+	MobileVerificationCodeLength = 6
+	// MobileVerificationTTL 인증 번호 유효 시간
+	MobileVerificationTTL = 5 * time.Minute
+	// MobileVerificationMaxAttempts 인증 번호 하나로 틀릴 수 있는 횟수, 넘으면 다시 요청해야함
+	MobileVerificationMaxAttempts = 5
+
+	// MobileVerificationRequestLimit 계정 하나에 MobileVerificationRequestWindow 동안 보낼 인증 문자 수
+	MobileVerificationRequestLimit  = 5
+	MobileVerificationRequestWindow = time.Hour
+)
+
+// MobileVerification 휴대폰 번호 변경 인증, 휴대폰 번호가 초기 비밀번호라 본인 번호인지 확인한 뒤에만 변경
+// 인증 번호는 문자로만 전달하고 아이디를 섞은 sha256 만 저장
+type MobileVerification struct {
+	Id     uuid.UUID `gorm:"type:char(36);primaryKey"`
+	UserId uuid.UUID `gorm:"type:char(36);index;not null"`
+	// Mobile 바꿀 번호, 인증한 번호로만 변경
+	Mobile    string    `gorm:"size:24;not null"`
+	CodeHash  string    `gorm:"size:64;not null"`
+	Attempts  uint8     `gorm:"not null"`
+	CreatedAt time.Time `gorm:"type:datetime(6);index;not null"`
+	ExpiresAt time.Time `gorm:"type:datetime(6);index;not null"`
+	// UsedAt 변경에 쓴 시각, 새 인증 번호를 요청하면 이전 번호도 같이 채움
+	UsedAt *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (MobileVerification) TableName() string {
+	return "mobile_verification"
+}
+
+// NewMobileVerification 원본 인증 번호와 저장할 엔티티
+func NewMobileVerification(id, userId uuid.UUID, mobile string, now time.Time) (code string, entity MobileVerification, err error) {
+	max := big.NewInt(1)
+	for i := 0; i < MobileVerificationCodeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return
+	}
+	code = fmt.Sprintf("%0*d", MobileVerificationCodeLength, n)
+
+	entity = MobileVerification{
+		Id:        id,
+		UserId:    userId,
+		Mobile:    mobile,
+		CodeHash:  hashMobileVerificationCode(id, code),
+		CreatedAt: now,
+		ExpiresAt: now.Add(MobileVerificationTTL),
+	}
+	return
+}
+
+// hashMobileVerificationCode 여섯 자리는 경우의 수가 적어 아이디를 섞음
+func hashMobileVerificationCode(id uuid.UUID, code string) string {
+	sum := sha256.Sum256([]byte(id.String() + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+func (v MobileVerification) Match(code string) bool {
+	hash := hashMobileVerificationCode(v.Id, code)
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(v.CodeHash)) == 1
+}
+
+func (v MobileVerification) IsExpired(at time.Time) bool {
+	return !at.Before(v.ExpiresAt)
+}
+
+func (v MobileVerification) IsExhausted() bool {
+	return v.Attempts >= MobileVerificationMaxAttempts
+}
+
+// MobileVerificationMessage 인증 문자 내용
+func MobileVerificationMessage(code string) string {
+	return fmt.Sprintf("[에디트폴리오] 인증번호 [%s]를 입력해주세요. %d분 안에 입력해야 합니다.",
+		code, int(MobileVerificationTTL/time.Minute))
+}
+
+// SmsSender 문자 발송, 받는 번호는 하이픈 없이
+type SmsSender interface {
+	Send(ctx context.Context, to, text string) error
+}
+
+type MobileVerificationRepository interface {
+	// Create 아직 쓰지 않은 같은 유저의 이전 인증은 사용 처리
+	Create(ctx context.Context, verification *MobileVerification) error
+	With(tx gormx.Tx) MobileVerificationRepository
+
+	// GetPending 아직 쓰지 않은 가장 최근 인증, 없으면 nil
+	GetPending(ctx context.Context, userId uuid.UUID) (*MobileVerification, error)
+	// CountByUserSince since 이후 만든 인증 수
+	CountByUserSince(ctx context.Context, userId uuid.UUID, since time.Time) (int64, error)
+	// AddAttempt 틀린 횟수 1 증가
+	AddAttempt(ctx context.Context, id uuid.UUID) error
+	// Use 아직 쓰지 않았으면 사용 처리하고 true
+	Use(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
+
+type RequestMobileVerification struct {
+	UserId uuid.UUID
+	Mobile string
+}
+
+type UpdateCustomerMobile struct {
+	UserId uuid.UUID
+	Mobile string
+	Code   string
+}
@@ -0,0 +1,329 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// NotificationDispatchBatch 한 번 실행에 확인할 최대 알림 수, 남은 알림은 다음 실행에서 보냄
+	NotificationDispatchBatch = 500
+	// NotificationDispatchConcurrency 동시에 보낼 묶음 수
+	NotificationDispatchConcurrency = 8
+	// NotificationMaxAttempts 보내기 실패 허용 횟수, 넘으면 더 이상 보내지 않음
+	NotificationMaxAttempts = 5
+	// NotificationRetryBase 첫 재시도 간격, 실패할 때마다 두 배
+	NotificationRetryBase = time.Minute
+)
+
+var (
+	// ErrNotificationNoRecipient 받는 사용자가 삭제됐거나 채널 연락처가 없음, 다시 보내지 않음
+	ErrNotificationNoRecipient = errors.New("notification recipient not reachable")
+	// ErrNotificationNoConsent 광고성 알림인데 채널 수신 동의가 없음, 다시 보내지 않음
+	ErrNotificationNoConsent = errors.New("notification marketing consent missing")
+)
+
+// NotificationChannel 알림을 보내는 수단
+type NotificationChannel string
+
+const (
+	// NotificationChannelSms 고객 휴대폰 문자
+	NotificationChannelSms NotificationChannel = "SMS"
+	// NotificationChannelEmail 아이디(이메일)로 메일, 메일 발송 서비스가 outbox 이벤트를 받아 발송
+	NotificationChannelEmail NotificationChannel = "EMAIL"
+)
+
+// NotificationDigestSettings 채널별 묶음 시간(분) 설정
+var NotificationDigestSettings = map[NotificationChannel]SettingKey{
+	NotificationChannelSms:   SettingKeyNotificationDigestSmsMinutes,
+	NotificationChannelEmail: SettingKeyNotificationDigestEmailMinutes,
+}
+
+// NotificationCategory 알림 분류, 분류마다 보낼 수 있는 시간이 다름
+type NotificationCategory string
+
+const (
+	// NotificationCategoryTransactional 의뢰 진행 안내 같은 정보성 알림, 기본은 시간 제한 없이 바로 보냄
+	NotificationCategoryTransactional NotificationCategory = "TRANSACTIONAL"
+	// NotificationCategoryMarketing 광고성 알림, 야간(21시~8시) 발송 제한이 있어 보낼 수 있는 시간까지 기다림
+	NotificationCategoryMarketing NotificationCategory = "MARKETING"
+)
+
+// NotificationSendWindowSettings 분류별 보낼 수 있는 시간 설정
+var NotificationSendWindowSettings = map[NotificationCategory]SettingKey{
+	NotificationCategoryTransactional: SettingKeyNotificationSendWindowTransactional,
+	NotificationCategoryMarketing:     SettingKeyNotificationSendWindowMarketing,
+}
+
+// NotificationSendWindow 보낼 수 있는 시간(시작 포함, 끝 미포함), 끝이 시작보다 작으면 자정을 넘김 (ex. 22-6)
+type NotificationSendWindow struct {
+	StartHour int
+	EndHour   int
+	// Always 제한 없음
+	Always bool
+}
+
+// ParseNotificationSendWindow "시작시-끝시" (ex. 8-21), 비어있으면 제한 없음, 형식이 틀리면 ErrWeirdData
+func ParseNotificationSendWindow(value string) (window NotificationSendWindow, err error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		window.Always = true
+		return
+	}
+
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		err = ErrWeirdData
+		return
+	}
+	window.StartHour, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		err = ErrWeirdData
+		return
+	}
+	window.EndHour, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		err = ErrWeirdData
+		return
+	}
+
+	if window.StartHour < 0 || window.StartHour > 23 || window.EndHour < 0 || window.EndHour > 24 ||
+		window.StartHour == window.EndHour {
+		err = ErrWeirdData
+	}
+	return
+}
+
+// Allows t 는 기준 시간대(KST)로 표현한 시각
+func (w NotificationSendWindow) Allows(t time.Time) bool {
+	if w.Always {
+		return true
+	}
+
+	hour := t.Hour()
+	if w.StartHour < w.EndHour {
+		return hour >= w.StartHour && hour < w.EndHour
+	}
+	return hour >= w.StartHour || hour < w.EndHour
+}
+
+// NextOpen t 이후 처음 보낼 수 있는 시각, 지금 보낼 수 있으면 t
+func (w NotificationSendWindow) NextOpen(t time.Time) time.Time {
+	if w.Allows(t) {
+		return t
+	}
+
+	open := time.Date(t.Year(), t.Month(), t.Day(), w.StartHour, 0, 0, 0, t.Location())
+	if !open.After(t) {
+		open = open.AddDate(0, 0, 1)
+	}
+	return open
+}
+
+// notificationEventLabels 알림 문구에 쓰는 이벤트 이름, 여기 없는 이벤트는 알리지 않음
+var notificationEventLabels = map[OutboxEventType]string{
+	OutboxEventTypeOrderRequested:    "의뢰 접수",
+	OutboxEventTypeOrderStateChanged: "진행 상태 변경",
+	OutboxEventTypeOrderDone:         "완료",
+	OutboxEventTypeOrderCanceled:     "취소",
+	OutboxEventTypeOrderReassigned:   "담당자 변경",
+}
+
+func IsNotifiableEvent(event OutboxEventType) bool {
+	_, ok := notificationEventLabels[event]
+	return ok
+}
+
+// NotificationCategoryOf 의뢰 이벤트 알림은 모두 정보성
+func NotificationCategoryOf(event OutboxEventType) NotificationCategory {
+	return NotificationCategoryTransactional
+}
+
+type CreateNotificationOption struct {
+	Id       uuid.UUID
+	UserId   uuid.UUID
+	Channel  NotificationChannel
+	Category NotificationCategory
+	EventId  string
+	Event    OutboxEventType
+	OrderId  uuid.UUID
+	DueAt    time.Time
+	Now      time.Time
+}
+
+func CreateNotification(option CreateNotificationOption) Notification {
+	return Notification{
+		Id:        option.Id,
+		UserId:    option.UserId,
+		Channel:   option.Channel,
+		Category:  option.Category,
+		EventId:   option.EventId,
+		Event:     option.Event,
+		OrderId:   option.OrderId,
+		DueAt:     &option.DueAt,
+		CreatedAt: option.Now,
+	}
+}
+
+// Notification 사용자 한 명에게 채널 하나로 알릴 이벤트 하나, 같은 사용자, 의뢰, 채널에서 보내기 전인 알림은 한 메시지로 묶어 보냄
+type Notification struct {
+	Id      uuid.UUID           `gorm:"type:char(36);primaryKey"`
+	UserId  uuid.UUID           `gorm:"type:char(36);uniqueIndex:idx_notification_user_channel_event;index:idx_notification_digest;not null"`
+	Channel NotificationChannel `gorm:"size:20;uniqueIndex:idx_notification_user_channel_event;index:idx_notification_digest;not null"`
+	EventId string              `gorm:"size:120;uniqueIndex:idx_notification_user_channel_event;not null"`
+	Event   OutboxEventType     `gorm:"size:60;not null"`
+	OrderId uuid.UUID           `gorm:"type:char(36);index:idx_notification_digest;not null"`
+	// Category 분류가 다른 알림은 묶지 않음
+	Category NotificationCategory `gorm:"size:20;not null;default:TRANSACTIONAL"`
+	// DueAt 묶음 시간이 끝나 보낼 시간, 같은 묶음은 값이 같음, 보냈거나 포기하면 nil
+	DueAt     *time.Time `gorm:"type:datetime(6);index"`
+	Attempts  uint16     `gorm:"not null"`
+	LastError *string    `gorm:"size:1000"`
+	SentAt    *time.Time `gorm:"type:datetime(6)"`
+	CreatedAt time.Time  `gorm:"type:datetime(6);not null"`
+}
+
+func (Notification) TableName() string {
+	return "notification"
+}
+
+func (n *Notification) Sent(now time.Time) {
+	n.Attempts++
+	n.SentAt = &now
+	n.DueAt = nil
+	n.LastError = nil
+}
+
+// Defer 보낼 수 있는 시간이 아니라 until 까지 미룸, 보내기 시도로 세지 않음
+func (n *Notification) Defer(until time.Time) {
+	n.DueAt = &until
+}
+
+// Failed 재시도 간격을 늘리고, 허용 횟수를 넘기거나 받을 사람이 없으면 포기
+func (n *Notification) Failed(err error, now time.Time) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	n.Attempts++
+	n.LastError = &msg
+
+	if n.Attempts >= NotificationMaxAttempts || err == ErrNotificationNoRecipient || err == ErrNotificationNoConsent {
+		n.DueAt = nil
+		return
+	}
+	next := now.Add(NotificationRetryBase << (n.Attempts - 1))
+	n.DueAt = &next
+}
+
+// NotificationDigest 한 메시지로 보낼 알림 묶음
+type NotificationDigest struct {
+	UserId   uuid.UUID
+	OrderId  uuid.UUID
+	Channel  NotificationChannel
+	Category NotificationCategory
+	Items    []*Notification
+}
+
+// DigestNotifications 사용자, 의뢰, 채널, 분류별로 묶음, 처음 나온 순서 유지
+func DigestNotifications(list []Notification) []NotificationDigest {
+	type key struct {
+		userId   uuid.UUID
+		orderId  uuid.UUID
+		channel  NotificationChannel
+		category NotificationCategory
+	}
+
+	var res []NotificationDigest
+	index := make(map[key]int)
+	for i := range list {
+		k := key{userId: list[i].UserId, orderId: list[i].OrderId, channel: list[i].Channel, category: list[i].Category}
+		idx, ok := index[k]
+		if !ok {
+			idx = len(res)
+			index[k] = idx
+			res = append(res, NotificationDigest{UserId: k.userId, OrderId: k.orderId, Channel: k.channel, Category: k.category})
+		}
+		res[idx].Items = append(res[idx].Items, &list[i])
+	}
+	return res
+}
+
+// Text 이벤트 이름을 나온 순서대로, 같은 이벤트는 횟수로 (ex. [에디트폴리오] 의뢰 EF-2021-00123 알림: 진행 상태 변경 2건, 완료)
+func (d NotificationDigest) Text(orderNumber *string) string {
+	var (
+		labels []string
+		counts = make(map[string]int)
+	)
+	for _, item := range d.Items {
+		label := notificationEventLabels[item.Event]
+		if counts[label] == 0 {
+			labels = append(labels, label)
+		}
+		counts[label]++
+	}
+
+	for i, label := range labels {
+		if counts[label] > 1 {
+			labels[i] = fmt.Sprintf("%s %d건", label, counts[label])
+		}
+	}
+
+	subject := "의뢰"
+	if orderNumber != nil {
+		subject = "의뢰 " + *orderNumber
+	}
+	return fmt.Sprintf("[에디트폴리오] %s 알림: %s", subject, strings.Join(labels, ", "))
+}
+
+type NotificationRepository interface {
+	// Create 같은 사용자, 채널에 같은 이벤트가 이미 있으면 그대로 둠
+	Create(ctx context.Context, list []Notification) error
+	SaveAll(ctx context.Context, list []Notification) error
+
+	// GetOpenDueAt 같은 사용자, 의뢰, 채널, 분류의 보내기 전 묶음이 보낼 시간, 없으면 nil
+	GetOpenDueAt(ctx context.Context, userId, orderId uuid.UUID, channel NotificationChannel, category NotificationCategory) (*time.Time, error)
+	// FetchDue now 까지 보낼 알림, 보낼 시간 순
+	FetchDue(ctx context.Context, now time.Time, limit int) ([]Notification, error)
+}
+
+// EnqueueOrderNotification 이 서버가 발행한 의뢰 이벤트 하나의 알림 대상
+type EnqueueOrderNotification struct {
+	// EventId 이벤트 봉투 아이디, 같은 이벤트가 다시 와도 한 번만 알림
+	EventId   string
+	Event     OutboxEventType
+	OrderId   uuid.UUID
+	OrdererId uuid.UUID
+	// Managers 담당자, 지켜보는 관리자
+	Managers []uuid.UUID
+}
+
+type NotificationDispatchRun struct {
+	Sent   int64
+	Failed int64
+	// Deferred 보낼 수 있는 시간이 아니라 미룬 묶음 수
+	Deferred int64
+}
+
+type NotificationUseCase interface {
+	// EnqueueOrderNotification 고객은 문자, 메일, 관리자는 메일, 알리지 않는 이벤트는 무시
+	// 채널별 묶음 시간(notification.digest_minutes.*) 안에 온 같은 의뢰 알림은 처음 알림의 보낼 시간에 같이 보냄
+	EnqueueOrderNotification(ctx context.Context, in EnqueueOrderNotification) error
+	// DispatchNotifications 스케줄러가 주기적으로 호출, 묶음마다 메시지 하나
+	// 분류별 보낼 수 있는 시간(notification.send_window.*) 밖이면 보내지 않고 시간이 열릴 때로 미룸
+	// 광고성 알림은 받는 사용자가 그 채널에 수신 동의했을 때만 보냄
+	DispatchNotifications(ctx context.Context) (NotificationDispatchRun, error)
+
+	// FetchMyMarketingConsents 채널마다 하나, 기록이 없는 채널은 동의 안함
+	FetchMyMarketingConsents(ctx context.Context, userId uuid.UUID) ([]MarketingConsent, error)
+	// UpdateMarketingConsent 같은 값이면 시간을 바꾸지 않음
+	UpdateMarketingConsent(ctx context.Context, in UpdateMarketingConsent) (MarketingConsent, error)
+	// MarketingConsentReport 채널별 동의, 철회 고객 수, 삭제된 고객 제외
+	MarketingConsentReport(ctx context.Context) ([]MarketingConsentSummary, error)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// OpsAlertSignInFailureWindow 로그인 실패 급증을 세는 기간
+	OpsAlertSignInFailureWindow = 10 * time.Minute
+)
+
+// OpsAlertRule 운영 지표 이상 규칙, 기준값은 설정(alert.*)에서 읽고 0 이면 끔
+type OpsAlertRule string
+
+const (
+	// OpsAlertRuleOrderIdle 평일 업무 시간에 의뢰 요청이 alert.order_idle_minutes 동안 없음
+	OpsAlertRuleOrderIdle OpsAlertRule = "ORDER_IDLE"
+	// OpsAlertRuleSignInFailureSpike 최근 OpsAlertSignInFailureWindow 동안 로그인 실패가 alert.sign_in_failure_limit 이상
+	OpsAlertRuleSignInFailureSpike OpsAlertRule = "SIGN_IN_FAILURE_SPIKE"
+	// OpsAlertRulePaymentSilence 결제 완료 메시지(payment.settled)가 alert.payment_silence_minutes 동안 없음
+	OpsAlertRulePaymentSilence OpsAlertRule = "PAYMENT_SILENCE"
+)
+
+var OpsAlertRules = []OpsAlertRule{
+	OpsAlertRuleOrderIdle,
+	OpsAlertRuleSignInFailureSpike,
+	OpsAlertRulePaymentSilence,
+}
+
+// OpsAlert 규칙별 지금 상태, 울리기 시작하거나 풀릴 때만 알림 이벤트 발행
+type OpsAlert struct {
+	Rule OpsAlertRule `gorm:"size:40;primaryKey"`
+	// IncidentId 울릴 때마다 새로 발급, 풀림 이벤트도 같은 아이디로 발행
+	IncidentId *uuid.UUID `gorm:"type:char(36)"`
+	Firing     bool       `gorm:"not null"`
+	// Detail 마지막으로 울렸을 때 측정값 설명
+	Detail     string     `gorm:"size:500;not null"`
+	FiredAt    *time.Time `gorm:"type:datetime(6)"`
+	ResolvedAt *time.Time `gorm:"type:datetime(6)"`
+	CheckedAt  time.Time  `gorm:"type:datetime(6);not null"`
+}
+
+func (OpsAlert) TableName() string {
+	return "ops_alert"
+}
+
+// Check 평가 결과 반영, 상태가 바뀌었으면 true
+func (a *OpsAlert) Check(firing bool, detail string, incidentId uuid.UUID, now time.Time) bool {
+	a.CheckedAt = now
+	if a.Firing == firing {
+		return false
+	}
+
+	a.Firing = firing
+	if firing {
+		a.IncidentId = &incidentId
+		a.Detail = detail
+		a.FiredAt = &now
+		a.ResolvedAt = nil
+	} else {
+		a.ResolvedAt = &now
+	}
+	return true
+}
+
+type OpsAlertRepository interface {
+	Save(ctx context.Context, alert *OpsAlert) error
+	Transaction(ctx context.Context, fn func(alertRepo OpsAlertTxRepository) error) error
+	With(tx gormx.Tx) OpsAlertTxRepository
+
+	FetchAll(ctx context.Context) ([]OpsAlert, error)
+
+	// CountOrdersSince 임시 의뢰 제외, since 이후 요청된 의뢰 수
+	CountOrdersSince(ctx context.Context, since time.Time) (int64, error)
+	// LastInboxReceivedAt topic 메시지를 마지막으로 받은 시각, 받은 적 없으면 nil
+	LastInboxReceivedAt(ctx context.Context, topic string) (*time.Time, error)
+}
+
+type OpsAlertTxRepository interface {
+	OpsAlertRepository
+	gormx.Tx
+}
+
+type OpsAlertInfo struct {
+	Rule       OpsAlertRule
+	Firing     bool
+	Detail     string
+	FiredAt    *time.Time
+	ResolvedAt *time.Time
+	CheckedAt  *time.Time
+}
+
+type OpsAlertUseCase interface {
+	// EvaluateOpsAlerts 모든 규칙 평가, 상태가 바뀐 규칙마다 알림 이벤트 발행, 바뀐 수 반환
+	EvaluateOpsAlerts(ctx context.Context) (int, error)
+
+	// FetchOpsAlerts 평가한 적 없는 규칙도 포함, OpsAlertRules 순서
+	FetchOpsAlerts(ctx context.Context) ([]OpsAlertInfo, error)
+}
@@ -11,40 +11,92 @@ import (
 )
 
 type CreateOrderOption struct {
+	Id          uuid.UUID
 	Orderer     uuid.UUID
+	TicketId    *uuid.UUID
 	EditCount   uint8
 	State       uint8
 	Requirement *string
+	DueDate     *time.Time
+	Now         time.Time
 }
 
 func CreateOrder(option CreateOrderOption) Order {
 	return Order{
-		Id:             uuid.New(),
-		OrderedAt:      time.Now(),
+		Id:             option.Id,
+		OrderedAt:      option.Now,
 		Orderer:        option.Orderer,
+		TicketId:       option.TicketId,
 		TotalEditCount: option.EditCount,
 		State:          option.State,
 		Requirement:    option.Requirement,
+		DueDate:        option.DueDate,
 	}
 }
 
 type Order struct {
-	Id             uuid.UUID  `gorm:"type:char(36);primaryKey"`
-	OrderedAt      time.Time  `gorm:"type:datetime(6);index;not null"`
-	Orderer        uuid.UUID  `gorm:"type:char(36);index;not null"`
-	EditCount      uint8      `gorm:"not null"`
-	TotalEditCount uint8      `gorm:"not null"`
-	State          uint8      `gorm:"not null"`
-	DueDate        *time.Time `gorm:"type:date"`
-	Assignee       *uuid.UUID `gorm:"type:char(36);index"`
-	Requirement    *string    `gorm:"size:2000"`
-	DoneAt         *time.Time `gorm:"type:datetime(6);index"`
+	Id uuid.UUID `gorm:"type:char(36);primaryKey"`
+	// Number 고객 상담용 의뢰 번호, 임시 의뢰와 번호 도입 전 의뢰는 없음
+	Number         *string   `gorm:"size:30;uniqueIndex"`
+	OrderedAt      time.Time `gorm:"type:datetime(6);index;not null"`
+	Orderer        uuid.UUID `gorm:"type:char(36);index;not null"`
+	EditCount      uint8     `gorm:"not null"`
+	TotalEditCount uint8     `gorm:"not null"`
+	State          uint8     `gorm:"not null"`
+	// DueDate KST 기준 마감 날짜, Calendar.DateOf 로 정규화한 값 (UTC 0시)
+	DueDate      *time.Time       `gorm:"type:date"`
+	Assignee     *uuid.UUID       `gorm:"type:char(36);index"`
+	Requirement  *string          `gorm:"size:2000"`
+	DoneAt       *time.Time       `gorm:"type:datetime(6);index"`
+	TicketId     *uuid.UUID       `gorm:"type:char(36);index"`
+	CanceledAt   *time.Time       `gorm:"type:datetime(6);index"`
+	CancelReason *string          `gorm:"size:500"`
+	RefundType   *OrderRefundType `gorm:"size:20"`
+
+	// IsDraft 복제로 만든 임시 의뢰, 이용권을 쓰지 않고 목록/진행중 의뢰에서 제외
+	IsDraft        bool       `gorm:"not null;default:false;index"`
+	DuplicatedFrom *uuid.UUID `gorm:"type:char(36);index"`
+
+	// DeliveryUrl 편집본 납품 주소, 우리 저장소의 영상이면 미리보기 생성
+	DeliveryUrl *string `gorm:"size:1000"`
+
+	// ArchivedAt 끝난 의뢰를 목록에서 숨긴 시각, 삭제하지 않고 보관 목록에서 조회
+	ArchivedAt *time.Time `gorm:"type:datetime(6);index"`
+
+	// RequiredSkills 의뢰에 필요한 작업, SkillTag JSON 배열, 없으면 아무 담당자나
+	RequiredSkills *string `gorm:"type:json"`
 }
 
 func (Order) TableName() string {
 	return "order"
 }
 
+// Duplicate 요구사항, 필요한 작업, 수정 횟수만 복사한 임시 의뢰, 담당자/마감/상태 이력/완료 정보는 복사하지 않음
+func (o Order) Duplicate(id uuid.UUID, state uint8, now time.Time) Order {
+	draft := CreateOrder(CreateOrderOption{
+		Id:        id,
+		Orderer:   o.Orderer,
+		EditCount: o.TotalEditCount,
+		State:     state,
+		Now:       now,
+	})
+	if o.Requirement != nil {
+		draft.Requirement = pointer.String(*o.Requirement)
+	}
+	if o.RequiredSkills != nil {
+		draft.RequiredSkills = pointer.String(*o.RequiredSkills)
+	}
+	draft.IsDraft = true
+	draft.DuplicatedFrom = &o.Id
+	return draft
+}
+
+// AssignNumber 연도는 요청 시각의 기준 시간대(KST) 연도
+func (o *Order) AssignNumber(year int, seq int64) {
+	number := FormatOrderNumber(OrderNumberPrefix, year, seq)
+	o.Number = &number
+}
+
 func (o *Order) IsEmptyEditCount() bool {
 	return o.RemainingEditCount() == 0
 }
@@ -57,14 +109,125 @@ func (o *Order) UseEdit() {
 	o.EditCount++
 }
 
-func (o *Order) Done() {
-	o.DoneAt = pointer.Time(time.Now())
+// AddFreeRevision 보상으로 수정 횟수 1회 추가
+func (o *Order) AddFreeRevision() {
+	o.TotalEditCount++
+}
+
+func (o Order) RequiredSkillTags() []SkillTag {
+	return decodeSkillTags(o.RequiredSkills)
+}
+
+// SetRequiredSkills 없는 태그는 ErrWeirdData
+func (o *Order) SetRequiredSkills(tags []SkillTag) (err error) {
+	o.RequiredSkills, err = encodeSkillTags(tags)
+	return
+}
+
+func (o *Order) Deliver(url string) {
+	o.DeliveryUrl = &url
+}
+
+func (o *Order) Done(now time.Time) {
+	o.DoneAt = &now
 }
 
 func (o *Order) IsDone() bool {
 	return o.DoneAt != nil
 }
 
+// Cancel 의뢰 취소, 취소된 의뢰는 완료된 의뢰로 취급, 이미 끝난 의뢰는 완료 시각 유지
+func (o *Order) Cancel(reason string, refundType OrderRefundType, now time.Time) {
+	o.CanceledAt = &now
+	if o.DoneAt == nil {
+		o.DoneAt = &now
+	}
+	o.CancelReason = &reason
+	o.RefundType = &refundType
+}
+
+func (o *Order) IsCanceled() bool {
+	return o.CanceledAt != nil
+}
+
+// Archive 끝난(완료, 취소) 의뢰만 보관 가능, 끝나지 않았으면 false
+func (o *Order) Archive(now time.Time) bool {
+	if !o.IsDone() {
+		return false
+	}
+	if o.ArchivedAt == nil {
+		o.ArchivedAt = &now
+	}
+	return true
+}
+
+func (o *Order) Unarchive() {
+	o.ArchivedAt = nil
+}
+
+func (o *Order) IsArchived() bool {
+	return o.ArchivedAt != nil
+}
+
+// CancelRefundPolicy 취소 시 환불 정책
+// 편집자 배정 전 전액 환불, 배정 후 부분 환불
+func (o *Order) CancelRefundPolicy() OrderRefundType {
+	if o.Assignee == nil {
+		return OrderRefundTypeFull
+	}
+	return OrderRefundTypePartial
+}
+
+// OrderStateTransitionFailure 일괄 상태 변경에서 건너뛴 사유
+type OrderStateTransitionFailure string
+
+const (
+	OrderStateTransitionNotFound    OrderStateTransitionFailure = "NOT_FOUND"
+	OrderStateTransitionDone        OrderStateTransitionFailure = "ALREADY_DONE"
+	OrderStateTransitionNotAssigned OrderStateTransitionFailure = "NOT_ASSIGNED"
+	OrderStateTransitionNotAllowed  OrderStateTransitionFailure = "NOT_ALLOWED"
+)
+
+// CheckStateTransition 상태만 바꾸는 변경 가능 여부, 가능하면 빈 값
+// 완료/취소는 이용권, 환불 처리가 있어서 전용 기능으로만 가능
+func (o Order) CheckStateTransition(to OrderState) OrderStateTransitionFailure {
+	if o.IsDraft {
+		return OrderStateTransitionNotFound
+	}
+	if o.IsDone() {
+		return OrderStateTransitionDone
+	}
+
+	switch to.Code {
+	case OrderStateCodeNone, OrderStateCodeDone, OrderStateCodeCancel:
+		return OrderStateTransitionNotAllowed
+	case OrderStateCodeDefault:
+		return ""
+	}
+
+	if o.Assignee == nil {
+		return OrderStateTransitionNotAssigned
+	}
+	return ""
+}
+
+type OrderRefundType string
+
+const (
+	// OrderRefundTypeFull 전액 환불, 의뢰 횟수 복구
+	OrderRefundTypeFull OrderRefundType = "FULL"
+
+	// OrderRefundTypePartial 부분 환불, 의뢰 횟수 복구 없음
+	OrderRefundTypePartial OrderRefundType = "PARTIAL"
+
+	// OrderRefundTypeNone 환불 없음
+	OrderRefundTypeNone OrderRefundType = "NONE"
+)
+
+func (t OrderRefundType) IsRestoreQuota() bool {
+	return t == OrderRefundTypeFull
+}
+
 type OrderGeneralState uint8
 
 const (
@@ -80,8 +243,11 @@ const (
 
 type FetchOrderOption struct {
 	OrderState OrderGeneralState
-	Query      string
-	Assignee   *uuid.UUID
+	// Query 의뢰 번호(EF-2024-00123) 형식이면 번호로 검색
+	Query    string
+	Assignee *uuid.UUID
+	// Archived 보관된 의뢰만, false 면 보관된 의뢰 제외
+	Archived bool
 	//TODO Sort OrderedAt, Name, Assignee, State
 	//TODO Pagination
 	//Limit    int64
@@ -93,9 +259,26 @@ type OrderRepository interface {
 	With(tx gormx.Tx) OrderTxRepository
 
 	GetById(ctx context.Context, orderId uuid.UUID) (*Order, error)
+	// GetByIdForUpdate 트랜잭션 안에서 부르면 커밋할 때까지 잠금
+	GetByIdForUpdate(ctx context.Context, orderId uuid.UUID) (*Order, error)
 	GetRecentByOrdererId(ctx context.Context, ordererId uuid.UUID) (*Order, error)
+	FetchByIds(ctx context.Context, ids []uuid.UUID) ([]Order, error)
+	FetchByOrdererId(ctx context.Context, ordererId uuid.UUID) ([]Order, error)
+
+	// FetchActiveByAssignee 임시 의뢰 제외, 끝나지 않은 배정 의뢰, 트랜잭션 안에서 부르면 커밋할 때까지 잠금
+	FetchActiveByAssignee(ctx context.Context, assignee uuid.UUID) ([]Order, error)
+	// ReassignOrderer from 의 모든 의뢰(임시 포함)를 to 로 이동, 이동한 개수 반환
+	ReassignOrderer(ctx context.Context, from, to uuid.UUID) (int64, error)
 
 	Fetch(ctx context.Context, option FetchOrderOption) ([]Order, error)
+	// NextNumber 접두어, 연도별 다음 순번, 트랜잭션 안에서 부르면 커밋할 때까지 순번을 잠금
+	NextNumber(ctx context.Context, prefix string, year int) (int64, error)
+
+	// FetchSnapshot 임시 의뢰 제외, since 이후 요청했거나 아직 끝나지 않은 의뢰, 최근 요청 순
+	FetchSnapshot(ctx context.Context, since time.Time, limit int) ([]Order, error)
+
+	// ArchiveDoneBefore doneBefore 전에 끝났고 보관되지 않은 의뢰를 now 로 보관, 보관한 개수 반환
+	ArchiveDoneBefore(ctx context.Context, doneBefore, now time.Time) (int64, error)
 }
 
 type OrderTxRepository interface {
@@ -106,6 +289,8 @@ type OrderTxRepository interface {
 type RequestOrder struct {
 	UserId      uuid.UUID
 	Requirement string
+	// Skills 필요한 작업, 자동 배정은 모두 가진 담당자 중에서
+	Skills []SkillTag
 }
 
 type RequestEditOrder struct {
@@ -116,11 +301,33 @@ type OrderDone struct {
 	UserId uuid.UUID
 }
 
+type DuplicateOrder struct {
+	OrderId uuid.UUID
+	UserId  uuid.UUID
+}
+
+type BatchUpdateOrderState struct {
+	OrderIds   []uuid.UUID
+	OrderState uint8
+	// UpdatedBy, Memo 변경 기록에 남김
+	UpdatedBy uuid.UUID
+	Memo      *string
+}
+
+type OrderStateTransitionResult struct {
+	OrderId uuid.UUID
+	Failure OrderStateTransitionFailure
+}
+
 type UpdateOrderInfo struct {
 	OrderId    uuid.UUID
 	DueDate    time.Time
 	Assignee   uuid.UUID
 	OrderState uint8
+	// UpdatedBy 담당자가 바뀌면 배정 기록, 상태가 바뀌면 변경 기록에 남김
+	UpdatedBy uuid.UUID
+	// Memo 상태가 바뀔 때만 변경 기록에 남김
+	Memo *string
 }
 
 type OrderAssignSelf struct {
@@ -128,8 +335,14 @@ type OrderAssignSelf struct {
 	Assignee uuid.UUID
 }
 
+type UpdateOrderSkills struct {
+	OrderId uuid.UUID
+	Skills  []SkillTag
+}
+
 type OrderInfo struct {
 	OrderId            uuid.UUID
+	Number             *string
 	OrderedAt          time.Time
 	OrdererName        string
 	OrdererChannelName string
@@ -143,6 +356,7 @@ type OrderInfo struct {
 
 type RecentOrderInfo struct {
 	OrderId            uuid.UUID
+	Number             *string
 	OrderedAt          time.Time
 	DueDate            *time.Time
 	AssigneeNickname   *string
@@ -150,6 +364,63 @@ type RecentOrderInfo struct {
 	OrderStateContent  string
 	OrderStateEmoji    string
 	RemainingEditCount uint8
+	Delivery           *OrderDeliveryInfo
+}
+
+// MyOrderInfo 고객 본인 의뢰 목록
+type MyOrderInfo struct {
+	OrderId           uuid.UUID
+	Number            *string
+	OrderedAt         time.Time
+	DueDate           *time.Time
+	OrderState        uint8
+	OrderStateContent string
+	OrderStateEmoji   string
+	DoneAt            *time.Time
+	CanceledAt        *time.Time
+}
+
+// ReassignOrders 휴가, 퇴사 등으로 담당자의 끝나지 않은 의뢰를 모두 넘김
+type ReassignOrders struct {
+	From uuid.UUID
+	To   uuid.UUID
+	// ReassignedBy, Ip 감사 로그에 남김
+	ReassignedBy uuid.UUID
+	Ip           string
+}
+
+type ReassignOrdersResult struct {
+	OrderIds []uuid.UUID
+}
+
+type CancelOrder struct {
+	OrderId    uuid.UUID
+	UserId     uuid.UUID
+	Reason     string
+	RefundType *OrderRefundType
+}
+
+type CancelOrderResult struct {
+	OrderId       uuid.UUID
+	RefundType    OrderRefundType
+	QuotaRestored bool
+}
+
+type DeliverOrder struct {
+	OrderId uuid.UUID
+	Url     string
+}
+
+type ArchiveOrder struct {
+	OrderId uuid.UUID
+	// Archived false 면 보관 해제
+	Archived bool
+}
+
+// OrderDeliveryInfo Preview 는 우리 저장소의 영상일 때만
+type OrderDeliveryInfo struct {
+	Url     string
+	Preview *FilePreviewInfo
 }
 
 type OrderAssigneeInfo struct {
@@ -160,6 +431,7 @@ type OrderAssigneeInfo struct {
 
 type OrderDetailInfo struct {
 	OrderId            uuid.UUID
+	Number             *string
 	OrderedAt          time.Time
 	Orderer            uuid.UUID
 	DueDate            *time.Time
@@ -168,6 +440,8 @@ type OrderDetailInfo struct {
 	OrderStateContent  string
 	RemainingEditCount uint8
 	Requirement        string
+	Delivery           *OrderDeliveryInfo
+	RequiredSkills     []SkillTag
 }
 
 type OrderUseCase interface {
@@ -175,12 +449,42 @@ type OrderUseCase interface {
 	RequestEditOrder(ctx context.Context, in RequestEditOrder) error
 
 	OrderDone(ctx context.Context, in OrderDone) (uuid.UUID, error)
+	CancelOrder(ctx context.Context, in CancelOrder) (CancelOrderResult, error)
+	DuplicateOrder(ctx context.Context, in DuplicateOrder) (uuid.UUID, error)
 
 	UpdateOrderInfo(ctx context.Context, in UpdateOrderInfo) error
+	BatchUpdateOrderState(ctx context.Context, in BatchUpdateOrderState) ([]OrderStateTransitionResult, error)
+	// OrderAssignSelf 동시 진행 한도만큼 맡고 있으면 ErrManagerAtCapacity, 의뢰에 필요한 작업을 모두 할 수 없으면 ErrSkillMismatch
 	OrderAssignSelf(ctx context.Context, in OrderAssignSelf) error
+	// ReassignOrders 슈퍼 어드민만, 한 트랜잭션으로 넘기고 고객마다 담당자 변경 알림, 한도, 작업은 확인하지 않음
+	// 받을 담당자가 삭제됐거나 어드민이 아니면 ErrWeirdData, 같은 담당자면 ErrWeirdData
+	ReassignOrders(ctx context.Context, in ReassignOrders) (ReassignOrdersResult, error)
+	// UpdateOrderSkills 의뢰에 필요한 작업 변경, 이미 배정된 담당자는 그대로
+	UpdateOrderSkills(ctx context.Context, in UpdateOrderSkills) error
+	// DeliverOrder 납품 주소 등록, 우리 저장소의 영상이면 의뢰에 연결하고 미리보기 생성 예약
+	DeliverOrder(ctx context.Context, in DeliverOrder) error
+	// ImportOrders 모든 행을 확인하고 오류가 없을 때만 한 트랜잭션으로 저장, 이벤트는 발행하지 않음
+	ImportOrders(ctx context.Context, in ImportOrders) (ImportOrdersResult, error)
+	// ArchiveOrder 끝나지 않은 의뢰 보관은 ErrWeirdData
+	ArchiveOrder(ctx context.Context, in ArchiveOrder) error
+	// ArchiveDoneOrders 스케줄러가 주기적으로 호출, 끝난 지 order.archive_after_days 가 지난 의뢰 보관, 보관한 개수 반환
+	ArchiveDoneOrders(ctx context.Context) (int64, error)
 
 	GetRecentProcessingOrder(ctx context.Context, userId uuid.UUID) (RecentOrderInfo, error)
 	GetOrderDetailInfo(ctx context.Context, orderId uuid.UUID) (OrderDetailInfo, error)
+	// GetMyOrderDetailInfo 고객 본인 의뢰만, 다른 고객의 의뢰는 ErrItemNotFound
+	GetMyOrderDetailInfo(ctx context.Context, userId, orderId uuid.UUID) (OrderDetailInfo, error)
+	FetchMyOrders(ctx context.Context, userId uuid.UUID) ([]MyOrderInfo, error)
+	// FetchOrderAssignments 담당자 배정 기록, 오래된 순
+	FetchOrderAssignments(ctx context.Context, orderId uuid.UUID) ([]OrderAssignmentInfo, error)
+	// FetchOrderHistory 상태 변경 기록, 오래된 순, 고객은 자기 의뢰만, 다른 고객의 의뢰는 ErrItemNotFound
+	FetchOrderHistory(ctx context.Context, in FetchOrderHistory) ([]OrderHistoryInfo, error)
+
+	// WatchOrder 담당이 아니어도 상태 변경 알림을 받음, 임시 의뢰나 없는 의뢰는 ErrItemNotFound
+	WatchOrder(ctx context.Context, in WatchOrder) error
+	UnwatchOrder(ctx context.Context, in UnwatchOrder) error
+	// FetchWatchedOrders 지켜보는 의뢰, 최근에 지켜보기 시작한 순
+	FetchWatchedOrders(ctx context.Context, userId uuid.UUID) ([]OrderInfo, error)
 
 	Fetch(ctx context.Context, option FetchOrderOption) ([]OrderInfo, error)
-}
\ No newline at end of file
+}
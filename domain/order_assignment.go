@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+// OrderAssignStrategy 의뢰 요청 때 담당자 자동 배정 방법, 설정(order.assign_strategy)에서 고름
+type OrderAssignStrategy string
+
+const (
+	// OrderAssignStrategyNone 자동 배정 안 함, 관리자가 가져가거나 직접 배정
+	OrderAssignStrategyNone OrderAssignStrategy = "NONE"
+	// OrderAssignStrategyLeastLoaded 끝나지 않은 배정 의뢰가 가장 적은 담당자, 같으면 자동 배정을 오래 전에 받은 담당자
+	OrderAssignStrategyLeastLoaded OrderAssignStrategy = "LEAST_LOADED"
+	// OrderAssignStrategyRoundRobin 자동 배정을 가장 오래 전에 받은 담당자부터 차례로
+	OrderAssignStrategyRoundRobin OrderAssignStrategy = "ROUND_ROBIN"
+)
+
+func (s OrderAssignStrategy) IsValid() bool {
+	switch s {
+	case OrderAssignStrategyNone,
+		OrderAssignStrategyLeastLoaded,
+		OrderAssignStrategyRoundRobin:
+		return true
+	}
+	return false
+}
+
+// prefers a 가 b 보다 먼저 배정 받아야 하면 true
+func (s OrderAssignStrategy) prefers(a, b ManagerLoad) bool {
+	if s == OrderAssignStrategyLeastLoaded && a.Open != b.Open {
+		return a.Open < b.Open
+	}
+	if a.LastAutoAssignedAt == nil || b.LastAutoAssignedAt == nil {
+		return a.LastAutoAssignedAt == nil && b.LastAutoAssignedAt != nil
+	}
+	return a.LastAutoAssignedAt.Before(*b.LastAutoAssignedAt)
+}
+
+// PickAssignee 한도가 남은 담당자 중 strategy 로 고른 담당자, 자동 배정을 끄거나 자리가 없으면 nil
+func PickAssignee(strategy OrderAssignStrategy, loads []ManagerLoad, defaultCapacity int64) *uuid.UUID {
+	if strategy == OrderAssignStrategyNone || !strategy.IsValid() {
+		return nil
+	}
+
+	var picked *ManagerLoad
+	for i := range loads {
+		load := &loads[i]
+		if !load.HasRoom(defaultCapacity) {
+			continue
+		}
+		if picked == nil || strategy.prefers(*load, *picked) {
+			picked = load
+		}
+	}
+
+	if picked == nil {
+		return nil
+	}
+	return &picked.ManagerId
+}
+
+// ManagerLoad 담당자별 지금 맡은 의뢰 수
+type ManagerLoad struct {
+	ManagerId uuid.UUID
+	// Capacity 담당자에게 지정한 동시 진행 한도, nil 이면 설정(order.manager_capacity) 값
+	Capacity *uint16
+	// Open 배정된 끝나지 않은 의뢰 수, 임시 의뢰 제외
+	Open int64
+	// LastAutoAssignedAt 마지막으로 자동 배정 받은 시각, 받은 적 없으면 nil
+	LastAutoAssignedAt *time.Time
+	// Skills 할 수 있는 작업, SkillTag JSON 배열
+	Skills *string
+}
+
+// HasSkills need 를 모두 할 수 있으면 true
+func (l ManagerLoad) HasSkills(need []SkillTag) bool {
+	return HasSkillTags(decodeSkillTags(l.Skills), need)
+}
+
+// HasRoom 한도가 0 이면 제한 없음
+func (l ManagerLoad) HasRoom(defaultCapacity int64) bool {
+	capacity := defaultCapacity
+	if l.Capacity != nil {
+		capacity = int64(*l.Capacity)
+	}
+	return capacity <= 0 || l.Open < capacity
+}
+
+// OrderAssignmentSource 담당자가 정해진 방법
+type OrderAssignmentSource string
+
+const (
+	// OrderAssignmentSourceAuto 의뢰 요청 때 자동 배정
+	OrderAssignmentSourceAuto OrderAssignmentSource = "AUTO"
+	// OrderAssignmentSourceSelf 관리자가 직접 가져감, 한도 확인
+	OrderAssignmentSourceSelf OrderAssignmentSource = "SELF"
+	// OrderAssignmentSourceManual 관리자가 의뢰 정보 수정으로 배정, 한도를 넘어도 가능
+	OrderAssignmentSourceManual OrderAssignmentSource = "MANUAL"
+)
+
+type CreateOrderAssignmentOption struct {
+	Id         uuid.UUID
+	Order      Order
+	Previous   *uuid.UUID
+	Source     OrderAssignmentSource
+	Strategy   *OrderAssignStrategy
+	AssignedBy *uuid.UUID
+	Now        time.Time
+}
+
+func CreateOrderAssignment(option CreateOrderAssignmentOption) OrderAssignment {
+	return OrderAssignment{
+		Id:         option.Id,
+		OrderId:    option.Order.Id,
+		Previous:   option.Previous,
+		Assignee:   *option.Order.Assignee,
+		Source:     option.Source,
+		Strategy:   option.Strategy,
+		AssignedBy: option.AssignedBy,
+		AssignedAt: option.Now,
+	}
+}
+
+// OrderAssignment 담당자 배정 기록, 수정, 삭제하지 않음
+type OrderAssignment struct {
+	Id      uuid.UUID `gorm:"type:char(36);primaryKey"`
+	OrderId uuid.UUID `gorm:"type:char(36);index;not null"`
+	// Previous 이전 담당자, 처음 배정이면 nil
+	Previous *uuid.UUID            `gorm:"type:char(36)"`
+	Assignee uuid.UUID             `gorm:"type:char(36);index;not null"`
+	Source   OrderAssignmentSource `gorm:"size:20;not null"`
+	// Strategy 자동 배정일 때 사용한 방법
+	Strategy *OrderAssignStrategy `gorm:"size:20"`
+	// AssignedBy 가져가거나 배정한 관리자, 자동 배정이면 nil
+	AssignedBy *uuid.UUID `gorm:"type:char(36)"`
+	AssignedAt time.Time  `gorm:"type:datetime(6);index;not null"`
+}
+
+func (OrderAssignment) TableName() string {
+	return "order_assignment"
+}
+
+type OrderAssignmentRepository interface {
+	Create(ctx context.Context, assignment *OrderAssignment) error
+	With(tx gormx.Tx) OrderAssignmentTxRepository
+
+	// FetchByOrderId 오래된 순
+	FetchByOrderId(ctx context.Context, orderId uuid.UUID) ([]OrderAssignment, error)
+
+	// FetchManagerLoads 자동 배정 후보인 삭제되지 않은 어드민 중 skills 를 모두 가지고 date 에 쉬지 않는 어드민, 슈퍼 어드민 제외
+	FetchManagerLoads(ctx context.Context, skills []SkillTag, date time.Time) ([]ManagerLoad, error)
+	// GetManagerLoad 삭제되지 않은 어드민, 슈퍼 어드민, 없으면 nil
+	GetManagerLoad(ctx context.Context, managerId uuid.UUID) (*ManagerLoad, error)
+}
+
+type OrderAssignmentTxRepository interface {
+	OrderAssignmentRepository
+	gormx.Tx
+}
+
+type OrderAssignmentInfo struct {
+	Previous   *uuid.UUID
+	Assignee   uuid.UUID
+	Source     OrderAssignmentSource
+	Strategy   *OrderAssignStrategy
+	AssignedBy *uuid.UUID
+	AssignedAt time.Time
+}
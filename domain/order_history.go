@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+type CreateOrderHistoryOption struct {
+	Id      uuid.UUID
+	OrderId uuid.UUID
+	// From 바뀌기 전 상태, 처음 만든 의뢰면 nil
+	From  *uint8
+	To    uint8
+	Actor *uuid.UUID
+	Memo  *string
+	Now   time.Time
+}
+
+func CreateOrderHistory(option CreateOrderHistoryOption) OrderHistory {
+	return OrderHistory{
+		Id:        option.Id,
+		OrderId:   option.OrderId,
+		FromState: option.From,
+		ToState:   option.To,
+		ActorId:   option.Actor,
+		Memo:      option.Memo,
+		ChangedAt: option.Now,
+	}
+}
+
+// OrderHistory 의뢰 상태 변경 기록, 상태를 바꾸는 트랜잭션에서 같이 저장, 수정, 삭제하지 않음
+type OrderHistory struct {
+	Id      uuid.UUID `gorm:"type:char(36);primaryKey"`
+	OrderId uuid.UUID `gorm:"type:char(36);index;not null"`
+	// FromState 이전 상태, 의뢰 요청(등록) 기록이면 nil
+	FromState *uint8
+	ToState   uint8 `gorm:"not null"`
+	// ActorId 상태를 바꾼 고객, 관리자, 일괄 등록 등 시스템이면 nil
+	ActorId *uuid.UUID `gorm:"type:char(36)"`
+	// Memo 취소 사유, 관리자 메모
+	Memo      *string   `gorm:"size:500"`
+	ChangedAt time.Time `gorm:"type:datetime(6);index;not null"`
+}
+
+func (OrderHistory) TableName() string {
+	return "order_history"
+}
+
+type OrderHistoryRepository interface {
+	// Create 여러 의뢰의 기록을 한 번에 저장
+	Create(ctx context.Context, histories []OrderHistory) error
+	With(tx gormx.Tx) OrderHistoryTxRepository
+
+	// FetchByOrderId 오래된 순
+	FetchByOrderId(ctx context.Context, orderId uuid.UUID) ([]OrderHistory, error)
+}
+
+type OrderHistoryTxRepository interface {
+	OrderHistoryRepository
+	gormx.Tx
+}
+
+type FetchOrderHistory struct {
+	OrderId uuid.UUID
+	// UserId 고객이면 자기 의뢰만
+	UserId uuid.UUID
+}
+
+type OrderHistoryInfo struct {
+	FromState        *uint8
+	FromStateContent *string
+	ToState          uint8
+	ToStateContent   string
+	ActorId          *uuid.UUID
+	// ActorNickname 관리자가 바꿨을 때만
+	ActorNickname *string
+	Memo          *string
+	ChangedAt     time.Time
+}
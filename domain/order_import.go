@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// OrderImportMaxRows 한 번에 가져올 최대 의뢰 수
+	OrderImportMaxRows = 1000
+	// OrderImportMaxSize 올릴 수 있는 CSV 크기
+	OrderImportMaxSize = 2 << 20
+
+	// OrderImportTitleMaxLength 의뢰 요구사항 길이 제한과 같음
+	OrderImportTitleMaxLength = 2000
+)
+
+// OrderImportColumn CSV 머리글, 대소문자 구분 안 함
+type OrderImportColumn string
+
+const (
+	OrderImportColumnEmail     OrderImportColumn = "email"
+	OrderImportColumnTitle     OrderImportColumn = "title"
+	OrderImportColumnState     OrderImportColumn = "state"
+	OrderImportColumnOrderedAt OrderImportColumn = "ordered_at"
+	OrderImportColumnDueDate   OrderImportColumn = "due_date"
+	OrderImportColumnDoneAt    OrderImportColumn = "done_at"
+)
+
+// OrderImportFailure 가져오지 못한 행의 사유
+type OrderImportFailure string
+
+const (
+	OrderImportRequired         OrderImportFailure = "REQUIRED"
+	OrderImportTooLong          OrderImportFailure = "TOO_LONG"
+	OrderImportCustomerNotFound OrderImportFailure = "CUSTOMER_NOT_FOUND"
+	OrderImportInvalidState     OrderImportFailure = "INVALID_STATE"
+	OrderImportInvalidDate      OrderImportFailure = "INVALID_DATE"
+	// OrderImportDoneAtMismatch 완료/취소 상태인데 완료 시각이 없거나, 진행 중인데 완료 시각이 있음
+	OrderImportDoneAtMismatch OrderImportFailure = "DONE_AT_MISMATCH"
+	// OrderImportDoneBeforeOrdered 완료 시각이 요청 시각보다 이름
+	OrderImportDoneBeforeOrdered OrderImportFailure = "DONE_BEFORE_ORDERED"
+)
+
+// orderImportTimeLayouts 날짜만 있으면 기준 시간대 0시
+var orderImportTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006.01.02",
+	"2006/01/02",
+}
+
+// ParseOrderImportTime 시간대가 없는 값은 loc 기준
+func ParseOrderImportTime(value string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range orderImportTimeLayouts {
+		t, err := time.ParseInLocation(layout, value, loc)
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// MatchOrderImportState 상태 코드(DONE 등) 또는 상태 이름, 없음(NONE) 상태는 쓸 수 없음
+func MatchOrderImportState(states []OrderState, value string) *OrderState {
+	for i := range states {
+		state := &states[i]
+		if state.Code == OrderStateCodeNone {
+			continue
+		}
+		if strings.EqualFold(string(state.Code), value) || state.Content == value {
+			return state
+		}
+	}
+	return nil
+}
+
+// OrderImportRow CSV 한 행, 값은 앞뒤 공백을 뺀 원본
+type OrderImportRow struct {
+	// Line CSV 줄 번호, 머리글이 1
+	Line          int
+	CustomerEmail string
+	Title         string
+	State         string
+	OrderedAt     string
+	DueDate       string
+	DoneAt        string
+}
+
+// ImportOrder 이용권, 수정 횟수 없이 만든 지난 의뢰, 취소 상태면 완료 시각을 취소 시각으로 씀
+func ImportOrder(id, customerId uuid.UUID, title string, state OrderState, orderedAt time.Time, dueDate, doneAt *time.Time) Order {
+	order := CreateOrder(CreateOrderOption{
+		Id:          id,
+		Orderer:     customerId,
+		State:       state.Id,
+		Requirement: &title,
+		DueDate:     dueDate,
+		Now:         orderedAt,
+	})
+	order.DoneAt = doneAt
+	if state.Code == OrderStateCodeCancel {
+		order.CanceledAt = doneAt
+	}
+	return order
+}
+
+type OrderImportRowError struct {
+	Line    int
+	Column  OrderImportColumn
+	Failure OrderImportFailure
+}
+
+type ImportOrders struct {
+	Rows []OrderImportRow
+	// DryRun 확인만 하고 저장하지 않음
+	DryRun bool
+}
+
+type ImportOrdersResult struct {
+	// Imported 저장한(DryRun 이면 저장할 수 있는) 의뢰 수, 오류가 하나라도 있으면 0
+	Imported int
+	Errors   []OrderImportRowError
+}
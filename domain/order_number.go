@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// OrderNumberPrefix 고객 상담용 의뢰 번호 접두어, EF-2024-00123
+// 화이트라벨 대행사(테넌트)별 접두어를 쓰게 되면 접두어마다 순번을 따로 매김
+const OrderNumberPrefix = "EF"
+
+var orderNumberPattern = regexp.MustCompile(`^[A-Z]{2,10}-\d{4}-\d{5,}$`)
+
+// FormatOrderNumber 순번은 5자리까지 0으로 채우고 넘으면 그대로 늘어남
+func FormatOrderNumber(prefix string, year int, seq int64) string {
+	return fmt.Sprintf("%s-%04d-%05d", prefix, year, seq)
+}
+
+// ParseOrderNumber 검색어가 의뢰 번호 형식이면 대문자로 맞춘 값, 앞뒤 공백과 대소문자는 무시
+func ParseOrderNumber(query string) (string, bool) {
+	number := strings.ToUpper(strings.TrimSpace(query))
+	if !orderNumberPattern.MatchString(number) {
+		return "", false
+	}
+	return number, true
+}
+
+// OrderNumberSequence 접두어, 연도별 마지막 순번, 행 잠금으로 순번을 하나씩 올림
+type OrderNumberSequence struct {
+	Prefix string `gorm:"size:10;primaryKey"`
+	Year   int    `gorm:"primaryKey;autoIncrement:false"`
+	Value  int64  `gorm:"not null"`
+}
+
+func (OrderNumberSequence) TableName() string {
+	return "order_number_sequence"
+}
@@ -1,16 +1,29 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+const (
+	// OrderStateCacheName 제작 상태 목록은 거의 안 바뀌어서 메모리 캐시 사용
+	OrderStateCacheName = "order_state"
+	OrderStateCacheTTL  = time.Hour
+
+	// OrderStateMaxAge 클라이언트 캐시 시간
+	OrderStateMaxAge = 10 * time.Minute
+)
 
 type OrderStateCode string
 
 const (
-	OrderStateCodeNone OrderStateCode = "NONE"
-	OrderStateCodeDefault OrderStateCode = "DEFAULT"
-	OrderStateCodeTake OrderStateCode = "TAKE"
+	OrderStateCodeNone        OrderStateCode = "NONE"
+	OrderStateCodeDefault     OrderStateCode = "DEFAULT"
+	OrderStateCodeTake        OrderStateCode = "TAKE"
 	OrderStateCodeRequestEdit OrderStateCode = "REQUEST_EDIT"
-	OrderStateCodeEditDone OrderStateCode = "EDIT_DONE"
-	OrderStateCodeDone OrderStateCode = "DONE"
+	OrderStateCodeEditDone    OrderStateCode = "EDIT_DONE"
+	OrderStateCodeDone        OrderStateCode = "DONE"
+	OrderStateCodeCancel      OrderStateCode = "CANCEL"
 )
 
 type OrderState struct {
@@ -48,4 +61,4 @@ type OrderStateInfo struct {
 type OrderStateUseCase interface {
 	FetchFull(ctx context.Context) ([]OrderStateInfo, error)
 	FetchByParentId(ctx context.Context, parentId uint8) ([]OrderStateInfo, error)
-}
\ No newline at end of file
+}
@@ -9,47 +9,99 @@ import (
 )
 
 type CreateOrderTicketOption struct {
+	Id              uuid.UUID
 	ExOrderId       string
 	OwnerId         uuid.UUID
 	TotalOrderCount uint8
 	EditCount       uint8
 	StartAt         *time.Time
 	EndAt           *time.Time
+
+	// PaymentFingerprint 결제 수단 식별 값(카드 번호 해시 등), 추천 부정 사용 확인용
+	PaymentFingerprint *string
+
+	PlanId        *uuid.UUID
+	PlanName      *string
+	Amount        *int64
+	PaymentMethod *string
+	ReceiptUrl    *string
+	Now           time.Time
 }
 
 func CreateOrderTicket(option CreateOrderTicketOption) OrderTicket {
 	return OrderTicket{
-		Id:              uuid.New(),
+		Id:              option.Id,
 		ExOrderId:       option.ExOrderId,
 		OwnerId:         option.OwnerId,
 		TotalOrderCount: option.TotalOrderCount,
 		EditCount:       option.EditCount,
-		CreatedAt:       time.Now(),
+		CreatedAt:       option.Now,
 		StartAt:         option.StartAt,
 		EndAt:           option.EndAt,
+
+		PaymentFingerprint: option.PaymentFingerprint,
+
+		PlanId:        option.PlanId,
+		PlanName:      option.PlanName,
+		Amount:        option.Amount,
+		PaymentMethod: option.PaymentMethod,
+		ReceiptUrl:    option.ReceiptUrl,
 	}
 }
 
 type OrderTicket struct {
-	Id              uuid.UUID  `gorm:"type:char(36);primaryKey"`
-	ExOrderId       string     `gorm:"size:90;unique;not null"`
-	OwnerId         uuid.UUID  `gorm:"type:char(36);index;not null"`
-	OrderCount      uint8      `gorm:"not null"`
-	TotalOrderCount uint8      `gorm:"not null"`
-	EditCount       uint8      `gorm:"not null"`
-	CreatedAt       time.Time  `gorm:"size:datetime(6);index;not null"`
-	StartAt         *time.Time `gorm:"size:datetime(6);index"`
-	EndAt           *time.Time `gorm:"type:datetime(6);index"`
+	Id              uuid.UUID `gorm:"type:char(36);primaryKey"`
+	ExOrderId       string    `gorm:"size:90;unique;not null"`
+	OwnerId         uuid.UUID `gorm:"type:char(36);index;not null"`
+	OrderCount      uint8     `gorm:"not null"`
+	TotalOrderCount uint8     `gorm:"not null"`
+	// DoneOrderCount 완료된 의뢰 수, OrderCount 는 맡길 때 늘어남
+	DoneOrderCount uint8      `gorm:"not null;default:0"`
+	EditCount      uint8      `gorm:"not null"`
+	CreatedAt      time.Time  `gorm:"size:datetime(6);index;not null"`
+	StartAt        *time.Time `gorm:"size:datetime(6);index"`
+	EndAt          *time.Time `gorm:"type:datetime(6);index"`
+
+	PaymentFingerprint *string `gorm:"size:128;index"`
+
+	// PlanId 관리자가 구독 상품(SubscriptionPlan)으로 붙인 이용권만
+	PlanId *uuid.UUID `gorm:"type:char(36);index"`
+	// PlanName 결제한 상품 이름, 결제 메시지에 있을 때만
+	PlanName *string `gorm:"size:60"`
+	// Amount 결제 금액 (원)
+	Amount *int64
+	// PaymentMethod 고객에게 보여줄 결제 수단 요약 (ex. 신한카드 ****1234), 카드 번호 원본은 받지 않음
+	PaymentMethod *string `gorm:"size:60"`
+	// ReceiptUrl 결제 대행사 영수증 주소
+	ReceiptUrl *string `gorm:"size:1000"`
 }
 
 func (o *OrderTicket) UseOrder() {
 	o.OrderCount++
 }
 
+func (o *OrderTicket) RestoreOrder() {
+	if o.OrderCount > 0 {
+		o.OrderCount--
+	}
+}
+
 func (o OrderTicket) RemainingOrderCount() uint8 {
 	return o.TotalOrderCount - o.OrderCount
 }
 
+// CompleteOrder 이 이용권으로 맡긴 의뢰가 완료됨
+func (o *OrderTicket) CompleteOrder() {
+	if o.DoneOrderCount < o.TotalOrderCount {
+		o.DoneOrderCount++
+	}
+}
+
+// RemainingEditCount 아직 완료되지 않은 편집 횟수, 진행 중인 의뢰 포함
+func (o OrderTicket) RemainingEditCount() uint8 {
+	return o.TotalOrderCount - o.DoneOrderCount
+}
+
 func (o OrderTicket) IsEmptyOrderCount() bool {
 	return o.RemainingOrderCount() == 0
 }
@@ -57,11 +109,17 @@ func (o OrderTicket) IsEmptyOrderCount() bool {
 type OrderTicketRepository interface {
 	Save(ctx context.Context, orderTicket *OrderTicket) error
 	Transaction(ctx context.Context, fn func(orderTicketRepo OrderTicketTxRepository) error, options ...*sql.TxOptions) error
+	With(tx gormx.Tx) OrderTicketTxRepository
 
 	GetById(ctx context.Context, id uuid.UUID) (*OrderTicket, error)
 	GetByExOrderId(ctx context.Context, exId string) (*OrderTicket, error)
 	GetEndByOwnerId(ctx context.Context, id uuid.UUID) (*OrderTicket, error)
 	GetByOwnerIdBetweenStartAndEnd(ctx context.Context, id uuid.UUID, at time.Time) (*OrderTicket, error)
+	ExistsByOwnerIdAndPaymentFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) (bool, error)
+	FetchByOwnerId(ctx context.Context, id uuid.UUID) ([]OrderTicket, error)
+
+	// ReassignOwner from 의 모든 이용권을 to 로 이동, 이동한 개수 반환
+	ReassignOwner(ctx context.Context, from, to uuid.UUID) (int64, error)
 }
 
 type OrderTicketTxRepository interface {
@@ -73,7 +131,7 @@ type SubscribeUnit string
 
 const (
 	SubscribeUnitMonth SubscribeUnit = "M"
-	SubscribeUnitDay SubscribeUnit = "D"
+	SubscribeUnitDay   SubscribeUnit = "D"
 )
 
 type CreateSubscribeTicket struct {
@@ -83,8 +141,15 @@ type CreateSubscribeTicket struct {
 	Unit       SubscribeUnit
 	OrderCount uint8
 	EditCount  uint8
+
+	PaymentFingerprint *string
+
+	PlanName      *string
+	Amount        *int64
+	PaymentMethod *string
+	ReceiptUrl    *string
 }
 
 type OrderTicketUseCase interface {
 	CreateSubscribeTicket(ctx context.Context, in CreateSubscribeTicket) (uuid.UUID, error)
-}
\ No newline at end of file
+}
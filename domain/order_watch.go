@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderWatcher 담당자가 아닌 관리자가 지켜보는 의뢰, 상태 변경 이벤트의 watchers 로 알림 서비스가 같이 알림
+type OrderWatcher struct {
+	OrderId   uuid.UUID `gorm:"type:char(36);primaryKey"`
+	UserId    uuid.UUID `gorm:"type:char(36);primaryKey;index"`
+	CreatedAt time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (OrderWatcher) TableName() string {
+	return "order_watcher"
+}
+
+// WatcherIdsByOrder 의뢰별 지켜보는 관리자
+func WatcherIdsByOrder(list []OrderWatcher) map[uuid.UUID][]uuid.UUID {
+	res := make(map[uuid.UUID][]uuid.UUID, len(list))
+	for _, w := range list {
+		res[w.OrderId] = append(res[w.OrderId], w.UserId)
+	}
+	return res
+}
+
+type OrderWatcherRepository interface {
+	// Watch 이미 지켜보는 의뢰면 그대로 둠
+	Watch(ctx context.Context, watcher OrderWatcher) error
+	Unwatch(ctx context.Context, orderId, userId uuid.UUID) error
+
+	FetchByOrderIds(ctx context.Context, orderIds []uuid.UUID) ([]OrderWatcher, error)
+	// FetchByUserId 최근에 지켜보기 시작한 순
+	FetchByUserId(ctx context.Context, userId uuid.UUID) ([]OrderWatcher, error)
+}
+
+type WatchOrder struct {
+	OrderId uuid.UUID
+	UserId  uuid.UUID
+}
+
+type UnwatchOrder struct {
+	OrderId uuid.UUID
+	UserId  uuid.UUID
+}
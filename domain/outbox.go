@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// OutboxDispatchBatchSize 한 번에 발행할 이벤트 수
+	OutboxDispatchBatchSize = 100
+
+	// OutboxDispatchConcurrency 동시에 발행할 aggregate 수
+	OutboxDispatchConcurrency = 8
+
+	// OutboxMaxAttempts 발행 실패 허용 횟수, 넘으면 더 이상 발행 시도 안함
+	OutboxMaxAttempts = 10
+
+	// OutboxBacklogStaleAfter 상태 점검에서 발행 지연으로 보는 시간
+	OutboxBacklogStaleAfter = 5 * time.Minute
+)
+
+type OutboxAggregateType string
+
+const (
+	OutboxAggregateTypeUser   OutboxAggregateType = "user"
+	OutboxAggregateTypeOrder  OutboxAggregateType = "order"
+	OutboxAggregateTypeReport OutboxAggregateType = "report"
+	OutboxAggregateTypeTask   OutboxAggregateType = "task"
+	OutboxAggregateTypeOps    OutboxAggregateType = "ops"
+	// OutboxAggregateTypeNotification 묶어서 보내는 알림, aggregate 아이디는 받는 사용자
+	OutboxAggregateTypeNotification OutboxAggregateType = "notification"
+)
+
+type OutboxEventType string
+
+const (
+	OutboxEventTypeCustomerCreated OutboxEventType = "user.customer_created"
+	OutboxEventTypeCustomerMerged  OutboxEventType = "user.customer_merged"
+	// OutboxEventTypeUsernameChangeRequested 메일 발송 서비스가 새 주소로 확인 링크, 기존 주소로 변경 알림 발송
+	OutboxEventTypeUsernameChangeRequested OutboxEventType = "user.username_change_requested"
+	// OutboxEventTypePasswordResetRequested 메일 발송 서비스가 아이디(이메일)로 재설정 링크 발송
+	OutboxEventTypePasswordResetRequested OutboxEventType = "user.password_reset_requested"
+	// OutboxEventTypeContractSigned 결제 시스템이 서명 전이라 보류한 구독 결제를 다시 보냄
+	OutboxEventTypeContractSigned OutboxEventType = "user.contract_signed"
+	OutboxEventTypeOrderRequested OutboxEventType = "order.requested"
+	OutboxEventTypeOrderDone      OutboxEventType = "order.done"
+	OutboxEventTypeOrderCanceled  OutboxEventType = "order.canceled"
+	// OutboxEventTypeOrderStateChanged 담당자 배정, 진행 상태 변경, 완료/취소는 각 이벤트로
+	OutboxEventTypeOrderStateChanged OutboxEventType = "order.state_changed"
+	// OutboxEventTypeOrderReassigned 담당자 휴가 등으로 한꺼번에 넘긴 의뢰, 알림 서비스가 고객에게 담당자 변경 안내 발송
+	OutboxEventTypeOrderReassigned OutboxEventType = "order.reassigned"
+	// OutboxEventTypeReportCompleted 알림 서비스가 요청자에게 내려받기 링크 발송
+	OutboxEventTypeReportCompleted OutboxEventType = "report.completed"
+	OutboxEventTypeReportFailed    OutboxEventType = "report.failed"
+	// OutboxEventTypeTaskReminderDue 알림 서비스가 담당 관리자에게 마감 임박, 지난 일 알림 발송
+	OutboxEventTypeTaskReminderDue OutboxEventType = "task.reminder_due"
+	// OutboxEventTypeOpsAlertFired 알림 서비스가 운영 채널(Slack), 당번 관리자(알림톡)에 발송
+	OutboxEventTypeOpsAlertFired    OutboxEventType = "ops.alert_fired"
+	OutboxEventTypeOpsAlertResolved OutboxEventType = "ops.alert_resolved"
+	// OutboxEventTypeNotificationEmailRequested 메일 발송 서비스가 묶은 알림 문구를 그대로 발송
+	OutboxEventTypeNotificationEmailRequested OutboxEventType = "notification.email_requested"
+)
+
+type CustomerCreatedEvent struct {
+	UserId uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+	Email  string    `json:"email"`
+}
+
+type CustomerMergedEvent struct {
+	SurvivorId   uuid.UUID `json:"survivorId"`
+	DuplicateId  uuid.UUID `json:"duplicateId"`
+	MergedBy     uuid.UUID `json:"mergedBy"`
+	MovedOrders  int64     `json:"movedOrders"`
+	MovedTickets int64     `json:"movedTickets"`
+	MovedCredit  int64     `json:"movedCredit"`
+}
+
+type UsernameChangeRequestedEvent struct {
+	UserId      uuid.UUID `json:"userId"`
+	OldUsername string    `json:"oldUsername"`
+	NewUsername string    `json:"newUsername"`
+	Token       string    `json:"token"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+type PasswordResetRequestedEvent struct {
+	UserId    uuid.UUID `json:"userId"`
+	Username  string    `json:"username"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type ContractSignedEvent struct {
+	ContractId uuid.UUID `json:"contractId"`
+	CustomerId uuid.UUID `json:"customerId"`
+	Username   string    `json:"username"`
+	SignedAt   time.Time `json:"signedAt"`
+}
+
+type OrderRequestedEvent struct {
+	OrderId   uuid.UUID  `json:"orderId"`
+	OrdererId uuid.UUID  `json:"ordererId"`
+	TicketId  *uuid.UUID `json:"ticketId"`
+	// Assignee 자동 배정된 담당자
+	Assignee *uuid.UUID `json:"assignee"`
+}
+
+type OrderDoneEvent struct {
+	OrderId   uuid.UUID `json:"orderId"`
+	OrdererId uuid.UUID `json:"ordererId"`
+	// Watchers 의뢰를 지켜보는 관리자
+	Watchers []uuid.UUID `json:"watchers"`
+}
+
+type OrderCanceledEvent struct {
+	OrderId    uuid.UUID       `json:"orderId"`
+	OrdererId  uuid.UUID       `json:"ordererId"`
+	RefundType OrderRefundType `json:"refundType"`
+	Watchers   []uuid.UUID     `json:"watchers"`
+}
+
+// ReportFinishedEvent 완료면 DownloadURL 이 있고 ExpiresAt 까지 인증 없이 내려받기 가능
+type ReportFinishedEvent struct {
+	ReportId    uuid.UUID    `json:"reportId"`
+	RequestedBy uuid.UUID    `json:"requestedBy"`
+	Type        ReportType   `json:"type"`
+	Format      ReportFormat `json:"format"`
+	Rows        int64        `json:"rows"`
+	DownloadURL *string      `json:"downloadUrl"`
+	ExpiresAt   *time.Time   `json:"expiresAt"`
+}
+
+type TaskReminderDueEvent struct {
+	TaskId     uuid.UUID  `json:"taskId"`
+	AssigneeId uuid.UUID  `json:"assigneeId"`
+	Title      string     `json:"title"`
+	DueAt      time.Time  `json:"dueAt"`
+	CustomerId *uuid.UUID `json:"customerId"`
+	OrderId    *uuid.UUID `json:"orderId"`
+}
+
+type OpsAlertEvent struct {
+	IncidentId uuid.UUID    `json:"incidentId"`
+	Rule       OpsAlertRule `json:"rule"`
+	Detail     string       `json:"detail"`
+	FiredAt    time.Time    `json:"firedAt"`
+	ResolvedAt *time.Time   `json:"resolvedAt"`
+}
+
+type OrderReassignedEvent struct {
+	OrderId   uuid.UUID   `json:"orderId"`
+	OrdererId uuid.UUID   `json:"ordererId"`
+	Previous  uuid.UUID   `json:"previous"`
+	Assignee  uuid.UUID   `json:"assignee"`
+	Watchers  []uuid.UUID `json:"watchers"`
+}
+
+type OrderStateChangedEvent struct {
+	OrderId   uuid.UUID  `json:"orderId"`
+	OrdererId uuid.UUID  `json:"ordererId"`
+	State     uint8      `json:"state"`
+	Assignee  *uuid.UUID `json:"assignee"`
+	// Memo 상태를 바꾸며 남긴 관리자 메모, 지켜보는 관리자 알림에 같이 보냄
+	Memo     *string     `json:"memo"`
+	Watchers []uuid.UUID `json:"watchers"`
+}
+
+type NotificationEmailRequestedEvent struct {
+	UserId  uuid.UUID `json:"userId"`
+	Email   string    `json:"email"`
+	OrderId uuid.UUID `json:"orderId"`
+	Text    string    `json:"text"`
+}
+
+type CreateOutboxEventOption struct {
+	Id            uuid.UUID
+	AggregateType OutboxAggregateType
+	AggregateId   uuid.UUID
+	EventType     OutboxEventType
+	Data          interface{}
+	Now           time.Time
+}
+
+// CreateOutboxEvent 도메인 이벤트를 같은 트랜잭션에서 저장하기 위한 outbox 레코드 생성
+func CreateOutboxEvent(option CreateOutboxEventOption) (event OutboxEvent, err error) {
+	payload, err := json.Marshal(option.Data)
+	if err != nil {
+		return
+	}
+
+	event = OutboxEvent{
+		Id:            option.Id,
+		AggregateType: option.AggregateType,
+		AggregateId:   option.AggregateId,
+		EventType:     option.EventType,
+		Payload:       string(payload),
+		CreatedAt:     option.Now,
+	}
+	return
+}
+
+type OutboxEvent struct {
+	Id            uuid.UUID           `gorm:"type:char(36);primaryKey"`
+	AggregateType OutboxAggregateType `gorm:"size:30;index;not null"`
+	AggregateId   uuid.UUID           `gorm:"type:char(36);index;not null"`
+	EventType     OutboxEventType     `gorm:"size:60;index;not null"`
+	Payload       string              `gorm:"type:json;not null"`
+	Attempts      uint16              `gorm:"not null"`
+	LastError     *string             `gorm:"size:1000"`
+	CreatedAt     time.Time           `gorm:"type:datetime(6);index;not null"`
+	PublishedAt   *time.Time          `gorm:"type:datetime(6);index"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_event"
+}
+
+func (e *OutboxEvent) Published(now time.Time) {
+	e.PublishedAt = &now
+	e.LastError = nil
+}
+
+func (e *OutboxEvent) Failed(err error) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	e.Attempts++
+	e.LastError = &msg
+}
+
+type OutboxRepository interface {
+	Save(ctx context.Context, event *OutboxEvent) error
+	Transaction(ctx context.Context, fn func(outboxRepo OutboxTxRepository) error) error
+	With(tx gormx.Tx) OutboxTxRepository
+
+	// FetchPending 발행 대기 이벤트를 생성 순으로 잠금, 다른 인스턴스가 잠근 행은 건너뜀
+	FetchPending(ctx context.Context, limit int) ([]OutboxEvent, error)
+	// GetBacklog 잠그지 않고 셈, 상태 점검용
+	GetBacklog(ctx context.Context) (OutboxBacklog, error)
+}
+
+// OutboxBacklog Dead 는 발행 시도를 다 써서 더 이상 발행하지 않는 이벤트
+type OutboxBacklog struct {
+	Pending         int64
+	Dead            int64
+	OldestPendingAt *time.Time
+}
+
+// IsStale 가장 오래된 대기 이벤트가 OutboxBacklogStaleAfter 보다 오래됨, 발행 작업이 멈췄을 가능성
+func (b OutboxBacklog) IsStale(now time.Time) bool {
+	return b.OldestPendingAt != nil && now.Sub(*b.OldestPendingAt) > OutboxBacklogStaleAfter
+}
+
+type OutboxTxRepository interface {
+	OutboxRepository
+	gormx.Tx
+}
+
+// EventPublisher 외부 메시지 브로커로 이벤트 발행
+// 최소 한 번 전송을 보장, 소비자는 이벤트 Id 로 중복 제거
+type EventPublisher interface {
+	Publish(ctx context.Context, event OutboxEvent) error
+}
+
+type OutboxUseCase interface {
+	DispatchOutbox(ctx context.Context) (int, error)
+	GetBacklog(ctx context.Context) (OutboxBacklog, error)
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// PasswordHistoryMax 재사용 금지 설정의 최대값, 비밀번호마다 bcrypt 비교를 하므로 제한
+	PasswordHistoryMax = 12
+	// PasswordCharClassMax 소문자, 대문자, 숫자, 특수문자
+	PasswordCharClassMax = 4
+)
+
+// commonPasswords 유출 목록 상위의 흔한 비밀번호, 대소문자 무시
+var commonPasswords = map[string]struct{}{
+	"password1": {}, "password12": {}, "password123": {}, "password1!": {}, "passw0rd": {},
+	"p@ssw0rd": {}, "p@ssword1": {}, "qwer1234": {}, "qwer1234!": {}, "qwer1234!@": {},
+	"1234qwer": {}, "1234qwer!": {}, "1234qwer!@": {}, "qwerty123": {}, "qwerty12": {},
+	"asdf1234": {}, "asdf1234!": {}, "zxcv1234": {}, "1q2w3e4r": {}, "1q2w3e4r!": {},
+	"1q2w3e4r5t": {}, "q1w2e3r4": {}, "a1234567": {}, "a12345678": {}, "abcd1234": {},
+	"abcd1234!": {}, "abc12345": {}, "abc123456": {}, "admin123": {}, "admin1234": {},
+	"admin1234!": {}, "welcome1": {}, "iloveyou1": {}, "letmein1": {}, "sunshine1": {},
+	"editfolio1": {}, "editfolio123": {}, "stockfolio1": {}, "test1234": {}, "test1234!": {},
+}
+
+// PasswordRule 설정으로 바꾸는 관리자 비밀번호 규칙, 요청 형식(sf_password)은 그대로 먼저 검사
+type PasswordRule struct {
+	MinLength int
+	// CharClasses 소문자, 대문자, 숫자, 특수문자 중 섞어야 하는 종류 수
+	CharClasses int
+	// HistoryCount 지금 비밀번호를 포함해 최근 몇 개를 다시 쓸 수 없는지, 0 이면 검사 안함
+	HistoryCount int
+	// MaxAgeDays 마지막 변경 후 이 일수가 지나면 로그인 때 변경 강제, 0 이면 안함
+	MaxAgeDays int64
+}
+
+// Check 길이, 문자 종류, 흔한 비밀번호 검사, 맞지 않으면 ErrPasswordTooWeak
+func (r PasswordRule) Check(plain string) error {
+	if len([]rune(plain)) < r.MinLength {
+		return ErrPasswordTooWeak
+	}
+
+	if passwordCharClasses(plain) < r.CharClasses {
+		return ErrPasswordTooWeak
+	}
+
+	if _, ok := commonPasswords[strings.ToLower(plain)]; ok {
+		return ErrPasswordTooWeak
+	}
+	return nil
+}
+
+func passwordCharClasses(plain string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range plain {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, has := range []bool{lower, upper, digit, symbol} {
+		if has {
+			count++
+		}
+	}
+	return count
+}
+
+// PasswordHistory 바꾸기 전 비밀번호 해시, 재사용 검사용
+type PasswordHistory struct {
+	Id        uuid.UUID `gorm:"type:char(36);primaryKey"`
+	UserId    uuid.UUID `gorm:"type:char(36);index:idx_password_history_user_created;not null"`
+	Hash      string    `gorm:"size:60;not null"`
+	CreatedAt time.Time `gorm:"type:datetime(6);index:idx_password_history_user_created;not null"`
+}
+
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
+
+type PasswordHistoryRepository interface {
+	Save(ctx context.Context, history *PasswordHistory) error
+	With(tx gormx.Tx) PasswordHistoryRepository
+
+	// FetchRecentByUserId 최근 순
+	FetchRecentByUserId(ctx context.Context, userId uuid.UUID, limit int) ([]PasswordHistory, error)
+}
+
+// PasswordPolicy 관리자 비밀번호 변경 때 규칙, 재사용 검사, 고객은 비밀번호가 연락처라 대상 아님
+type PasswordPolicy interface {
+	Rule(ctx context.Context) (PasswordRule, error)
+
+	// Check 규칙에 맞지 않으면 ErrPasswordTooWeak, 최근에 쓴 비밀번호면 ErrPasswordReused
+	Check(ctx context.Context, identity Identity, plain string) error
+	// Remember 바꾸기 전에 호출, 지금 비밀번호 해시를 tx 안에서 기록
+	Remember(ctx context.Context, tx gormx.Tx, identity Identity) error
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// PasswordResetTTL 비밀번호 재설정 메일 토큰 유효 시간
+	PasswordResetTTL = 30 * time.Minute
+
+	// PasswordResetRequestLimit 계정 하나에 PasswordResetRequestWindow 동안 보낼 재설정 메일 수, 넘으면 조용히 무시
+	PasswordResetRequestLimit  = 3
+	PasswordResetRequestWindow = time.Hour
+)
+
+// PasswordReset 비밀번호 재설정 토큰, 원본은 메일로만 전달하고 sha256 만 저장, 한 번 쓰면 끝
+type PasswordReset struct {
+	Id        uuid.UUID `gorm:"type:char(36);primaryKey"`
+	UserId    uuid.UUID `gorm:"type:char(36);index;not null"`
+	TokenHash string    `gorm:"size:64;unique;not null"`
+	CreatedAt time.Time `gorm:"type:datetime(6);index;not null"`
+	ExpiresAt time.Time `gorm:"type:datetime(6);index;not null"`
+	// UsedAt 재설정에 쓴 시각, 새 토큰으로 재설정하면 이전 토큰도 같이 채움
+	UsedAt *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (PasswordReset) TableName() string {
+	return "password_reset"
+}
+
+// NewPasswordReset 원본 토큰과 저장할 엔티티
+func NewPasswordReset(id, userId uuid.UUID, now time.Time) (token string, entity PasswordReset, err error) {
+	raw := make([]byte, 32)
+	_, err = rand.Read(raw)
+	if err != nil {
+		return
+	}
+	token = hex.EncodeToString(raw)
+
+	entity = PasswordReset{
+		Id:        id,
+		UserId:    userId,
+		TokenHash: HashPasswordResetToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(PasswordResetTTL),
+	}
+	return
+}
+
+func HashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (p PasswordReset) IsExpired(at time.Time) bool {
+	return !at.Before(p.ExpiresAt)
+}
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *PasswordReset) error
+	With(tx gormx.Tx) PasswordResetRepository
+
+	GetByHash(ctx context.Context, tokenHash string) (*PasswordReset, error)
+	// CountByUserSince since 이후 만든 토큰 수
+	CountByUserSince(ctx context.Context, userId uuid.UUID, since time.Time) (int64, error)
+	// Use 아직 쓰지 않은 userId 의 토큰을 모두 사용 처리, id 를 이번에 사용 처리했으면 true
+	Use(ctx context.Context, id, userId uuid.UUID, at time.Time) (bool, error)
+}
+
+type ResetPassword struct {
+	Token    string
+	Password string
+}
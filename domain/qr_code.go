@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+const (
+	// QRCodeSize 모든 QR 코드는 같은 크기(px), 인쇄물/화면에서 같은 모양이 되도록 요청으로 바꿀 수 없음
+	QRCodeSize = 512
+
+	qrCodeTargetMaxLength = 1000
+)
+
+// QRTargetType QR 코드로 만들 수 있는 주소 종류
+type QRTargetType string
+
+const (
+	QRTargetTypePayment    QRTargetType = "PAYMENT"
+	QRTargetTypeOnboarding QRTargetType = "ONBOARDING"
+	QRTargetTypeShortLink  QRTargetType = "SHORT_LINK"
+)
+
+// QRTargets 종류별 허용 주소 앞부분, scheme, host 는 같아야 하고 path 는 앞부분이 같아야 함
+type QRTargets map[QRTargetType][]string
+
+// Match 허용 목록에 없는 주소, http(s) 가 아닌 주소는 false
+func (t QRTargets) Match(target string) (QRTargetType, bool) {
+	if len(target) > qrCodeTargetMaxLength {
+		return "", false
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return "", false
+	}
+
+	for targetType, prefixes := range t {
+		for _, prefix := range prefixes {
+			p, err := url.Parse(prefix)
+			if err != nil {
+				continue
+			}
+
+			if p.Scheme == u.Scheme && strings.EqualFold(p.Host, u.Host) && strings.HasPrefix(u.Path, p.Path) {
+				return targetType, true
+			}
+		}
+	}
+	return "", false
+}
+
+// QRCodeEncoder 브랜드 색, 여백을 적용한 PNG
+type QRCodeEncoder interface {
+	PNG(content string, size int) ([]byte, error)
+}
+
+type QRCodeUseCase interface {
+	// Generate PNG 이미지, 허용 목록에 없는 주소는 ErrWeirdData
+	Generate(ctx context.Context, target string) ([]byte, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RecycleBinDefaultDays 휴지통 기본 조회 기간
+	RecycleBinDefaultDays = 30
+	// RecycleBinMaxDays 휴지통 최대 조회 기간
+	RecycleBinMaxDays = 365
+)
+
+type RecycleBinItemType string
+
+const (
+	RecycleBinItemTypeAdmin    RecycleBinItemType = "ADMIN"
+	RecycleBinItemTypeCustomer RecycleBinItemType = "CUSTOMER"
+)
+
+// RecycleBinItemTypeOf 삭제된 유저의 휴지통 분류
+func RecycleBinItemTypeOf(user User) RecycleBinItemType {
+	if user.IsCustomer() {
+		return RecycleBinItemTypeCustomer
+	}
+	return RecycleBinItemTypeAdmin
+}
+
+type RecycleBinItem struct {
+	Type          RecycleBinItemType
+	Id            uuid.UUID
+	Name          string
+	Username      string
+	DeletedAt     time.Time
+	DeletedBy     *uuid.UUID
+	DeletedByName *string
+}
+
+// RecycleBin 종류별 삭제 목록, 최근 삭제 순
+type RecycleBin struct {
+	Admins    []RecycleBinItem
+	Customers []RecycleBinItem
+}
+
+type FetchRecycleBinOption struct {
+	Days uint16
+}
+
+// RecycleBinUseCase 복구는 UserUseCase.RestoreUser
+type RecycleBinUseCase interface {
+	FetchRecycleBin(ctx context.Context, option FetchRecycleBinOption) (RecycleBin, error)
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// ReferralRewardCredit 추천인 보상 크레딧
+	ReferralRewardCredit int64 = 5000
+
+	referralCodeLength   = 8
+	referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+func CreateReferralCode(customerId uuid.UUID, ip string, now time.Time) ReferralCode {
+	buf := make([]byte, referralCodeLength)
+	rand.Read(buf)
+	for i := range buf {
+		buf[i] = referralCodeAlphabet[int(buf[i])%len(referralCodeAlphabet)]
+	}
+
+	return ReferralCode{
+		CustomerId: customerId,
+		Code:       string(buf),
+		CreatedIp:  ip,
+		CreatedAt:  now,
+	}
+}
+
+type ReferralCode struct {
+	CustomerId uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Code       string    `gorm:"size:20;unique;not null"`
+	CreatedIp  string    `gorm:"size:45;not null"`
+	CreatedAt  time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (ReferralCode) TableName() string {
+	return "referral_code"
+}
+
+type ReferralStatus string
+
+const (
+	// ReferralStatusPending 피추천인 결제 대기
+	ReferralStatusPending ReferralStatus = "PENDING"
+
+	// ReferralStatusRewarded 보상 지급 완료
+	ReferralStatusRewarded ReferralStatus = "REWARDED"
+
+	// ReferralStatusRejected 부정 추천으로 판단
+	ReferralStatusRejected ReferralStatus = "REJECTED"
+)
+
+type ReferralRejectReason string
+
+const (
+	ReferralRejectReasonSameIp            ReferralRejectReason = "SAME_IP"
+	ReferralRejectReasonSamePaymentMethod ReferralRejectReason = "SAME_PAYMENT_METHOD"
+)
+
+type CreateReferralOption struct {
+	Id        uuid.UUID
+	Code      ReferralCode
+	RefereeId uuid.UUID
+	SignupIp  string
+	Now       time.Time
+}
+
+// CreateReferral 추천 등록, 추천 코드 생성 IP 와 같은 IP 에서 등록하면 거절 상태로 생성
+func CreateReferral(option CreateReferralOption) Referral {
+	referral := Referral{
+		Id:         option.Id,
+		ReferrerId: option.Code.CustomerId,
+		RefereeId:  option.RefereeId,
+		Code:       option.Code.Code,
+		SignupIp:   option.SignupIp,
+		Status:     ReferralStatusPending,
+		CreatedAt:  option.Now,
+	}
+
+	if option.SignupIp != "" && option.SignupIp == option.Code.CreatedIp {
+		referral.Reject(ReferralRejectReasonSameIp, option.Now)
+	}
+	return referral
+}
+
+type Referral struct {
+	Id           uuid.UUID             `gorm:"type:char(36);primaryKey"`
+	ReferrerId   uuid.UUID             `gorm:"type:char(36);index;not null"`
+	RefereeId    uuid.UUID             `gorm:"type:char(36);unique;not null"`
+	Code         string                `gorm:"size:20;index;not null"`
+	SignupIp     string                `gorm:"size:45;not null"`
+	Status       ReferralStatus        `gorm:"size:20;index;not null"`
+	RejectReason *ReferralRejectReason `gorm:"size:30"`
+	RewardCredit int64                 `gorm:"not null"`
+	CreatedAt    time.Time             `gorm:"type:datetime(6);index;not null"`
+	ClosedAt     *time.Time            `gorm:"type:datetime(6)"`
+}
+
+func (Referral) TableName() string {
+	return "referral"
+}
+
+func (r *Referral) IsPending() bool {
+	return r.Status == ReferralStatusPending
+}
+
+func (r *Referral) Reject(reason ReferralRejectReason, now time.Time) {
+	r.Status = ReferralStatusRejected
+	r.RejectReason = &reason
+	r.ClosedAt = &now
+}
+
+func (r *Referral) Reward(credit int64, now time.Time) {
+	r.Status = ReferralStatusRewarded
+	r.RewardCredit = credit
+	r.ClosedAt = &now
+}
+
+type ReferralCount struct {
+	ReferrerId uuid.UUID
+	Status     ReferralStatus
+	Count      int64
+	Credit     int64
+}
+
+type ReferralRepository interface {
+	SaveCode(ctx context.Context, code *ReferralCode) error
+	Save(ctx context.Context, referral *Referral) error
+	With(tx gormx.Tx) ReferralTxRepository
+
+	GetCodeByCustomerId(ctx context.Context, customerId uuid.UUID) (*ReferralCode, error)
+	GetCodeByCode(ctx context.Context, code string) (*ReferralCode, error)
+	GetByRefereeId(ctx context.Context, refereeId uuid.UUID) (*Referral, error)
+
+	CountByReferrerId(ctx context.Context, referrerId *uuid.UUID) ([]ReferralCount, error)
+}
+
+type ReferralTxRepository interface {
+	ReferralRepository
+	gormx.Tx
+}
+
+type AttributeReferral struct {
+	UserId uuid.UUID
+	Code   string
+	Ip     string
+}
+
+type ReferralStatData struct {
+	Total    int64
+	Pending  int64
+	Rewarded int64
+	Rejected int64
+	Credit   int64
+}
+
+type MyReferralInfo struct {
+	// Code 아직 발급하지 않았으면 nil
+	Code *string
+	Stat ReferralStatData
+}
+
+type ReferrerStatData struct {
+	ReferrerId uuid.UUID
+	Stat       ReferralStatData
+}
+
+type ReferralStatsInfo struct {
+	Total     ReferralStatData
+	Referrers []ReferrerStatData
+}
+
+type ReferralUseCase interface {
+	GetMyReferral(ctx context.Context, userId uuid.UUID) (MyReferralInfo, error)
+	// IssueReferralCode 추천 코드가 없으면 발급, 있으면 그 코드
+	IssueReferralCode(ctx context.Context, userId uuid.UUID, ip string) (code string, err error)
+	AttributeReferral(ctx context.Context, in AttributeReferral) error
+
+	GetReferralStats(ctx context.Context) (ReferralStatsInfo, error)
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokenTTL 마지막 갱신 후 이 기간 안에 다시 갱신하지 않으면 다시 로그인
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// RefreshToken 접근 토큰(jwt) 재발급용 토큰, 원본은 응답으로만 전달하고 sha256 만 저장
+// 한 번 쓰면 새 토큰으로 바뀌고(회전), 이미 바뀐 토큰이 다시 오면 탈취로 보고 같은 Family 전체 폐기
+type RefreshToken struct {
+	Id     uuid.UUID `gorm:"type:char(36);primaryKey"`
+	UserId uuid.UUID `gorm:"type:char(36);index;not null"`
+	// FamilyId 로그인 한 번에서 회전으로 이어진 토큰 묶음
+	FamilyId  uuid.UUID `gorm:"type:char(36);index;not null"`
+	TokenHash string    `gorm:"size:64;unique;not null"`
+	CreatedAt time.Time `gorm:"type:datetime(6);not null"`
+	ExpiresAt time.Time `gorm:"type:datetime(6);index;not null"`
+	// RotatedAt 새 토큰으로 바꾼 시각
+	RotatedAt *time.Time `gorm:"type:datetime(6)"`
+	// RevokedAt 비밀번호 변경, 삭제, 재사용 감지로 폐기한 시각
+	RevokedAt *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_token"
+}
+
+// NewRefreshToken 원본 토큰과 저장할 엔티티
+func NewRefreshToken(id, userId, familyId uuid.UUID, now time.Time) (token string, entity RefreshToken, err error) {
+	raw := make([]byte, 32)
+	_, err = rand.Read(raw)
+	if err != nil {
+		return
+	}
+	token = hex.EncodeToString(raw)
+
+	entity = RefreshToken{
+		Id:        id,
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: HashRefreshToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(RefreshTokenTTL),
+	}
+	return
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// Rotate 아직 바뀌지 않았고 폐기되지 않은 토큰만 바뀐 것으로 표시, 동시에 두 번 오면 하나만 true
+	Rotate(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyId uuid.UUID, at time.Time) error
+	// RevokeByUser 유저의 살아있는 토큰 모두 폐기
+	RevokeByUser(ctx context.Context, userId uuid.UUID, at time.Time) error
+}
+
+// TokenPair 로그인, 갱신 결과
+type TokenPair struct {
+	Token            string
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
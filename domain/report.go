@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// ReportBatch 한 번 실행에 만들 최대 리포트 수, 남은 리포트는 다음 실행에서 처리
+	ReportBatch = 5
+	// ReportConcurrency 동시에 만들 리포트 수, 리포트마다 DB 를 오래 읽음
+	ReportConcurrency = 2
+	// ReportMaxAttempts 실패 허용 횟수, 넘으면 더 이상 시도 안함
+	ReportMaxAttempts = 3
+	// ReportRunTimeout 만드는 중인 채로 이보다 오래되면 서버가 죽은 것으로 보고 다시 시도
+	ReportRunTimeout = 30 * time.Minute
+	// ReportURLTTL 내려받기 URL 유효 시간
+	ReportURLTTL = 24 * time.Hour
+	// ReportMaxPeriod 한 번에 뽑을 수 있는 최대 기간
+	ReportMaxPeriod = 366 * 24 * time.Hour
+
+	// ReportDateLayout 기간 날짜 형식 (ex. 2024-05-01)
+	ReportDateLayout = "2006-01-02"
+)
+
+// ReportType 리포트 종류, 종류마다 기간을 적용하는 시각이 다름
+type ReportType string
+
+const (
+	// ReportTypeOrders 의뢰 일시 기준, 임시 의뢰 제외
+	ReportTypeOrders ReportType = "ORDERS"
+	// ReportTypeCustomers 가입 일시 기준, 삭제된 고객 제외
+	ReportTypeCustomers ReportType = "CUSTOMERS"
+	// ReportTypeCreditEntries 크레딧 원장 기록 일시 기준
+	ReportTypeCreditEntries ReportType = "CREDIT_ENTRIES"
+)
+
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportTypeOrders, ReportTypeCustomers, ReportTypeCreditEntries:
+		return true
+	}
+	return false
+}
+
+type ReportFormat string
+
+const (
+	ReportFormatCSV  ReportFormat = "CSV"
+	ReportFormatXLSX ReportFormat = "XLSX"
+)
+
+func (f ReportFormat) IsValid() bool {
+	return f == ReportFormatCSV || f == ReportFormatXLSX
+}
+
+func (f ReportFormat) Ext() string {
+	if f == ReportFormatXLSX {
+		return "xlsx"
+	}
+	return "csv"
+}
+
+func (f ReportFormat) ContentType() string {
+	if f == ReportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusPending ReportStatus = "PENDING"
+	ReportStatusRunning ReportStatus = "RUNNING"
+	ReportStatusDone    ReportStatus = "DONE"
+	ReportStatusFailed  ReportStatus = "FAILED"
+)
+
+type CreateReportJobOption struct {
+	Type        ReportType
+	Format      ReportFormat
+	From        time.Time
+	To          time.Time
+	RequestedBy uuid.UUID
+	Now         time.Time
+}
+
+func CreateReportJob(id uuid.UUID, option CreateReportJobOption) ReportJob {
+	now := option.Now
+	return ReportJob{
+		Id:          id,
+		Type:        option.Type,
+		Format:      option.Format,
+		Status:      ReportStatusPending,
+		From:        option.From,
+		To:          option.To,
+		RequestedBy: option.RequestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// ReportJob 비동기 리포트, 스케줄러가 /internal/report/generate 로 만들고 결과 파일은 저장소에 올림
+type ReportJob struct {
+	Id     uuid.UUID    `gorm:"type:char(36);primaryKey"`
+	Type   ReportType   `gorm:"size:30;not null"`
+	Format ReportFormat `gorm:"size:10;not null"`
+	Status ReportStatus `gorm:"size:10;index;not null"`
+	// From, To [From, To) 기간
+	From        time.Time `gorm:"type:datetime(6);not null"`
+	To          time.Time `gorm:"type:datetime(6);not null"`
+	RequestedBy uuid.UUID `gorm:"type:char(36);index;not null"`
+
+	ResultKey  *string    `gorm:"size:300"`
+	Rows       int64      `gorm:"not null"`
+	Attempts   uint8      `gorm:"not null"`
+	LastError  *string    `gorm:"size:1000"`
+	CreatedAt  time.Time  `gorm:"type:datetime(6);index;not null"`
+	UpdatedAt  time.Time  `gorm:"type:datetime(6);not null"`
+	StartedAt  *time.Time `gorm:"type:datetime(6)"`
+	FinishedAt *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (ReportJob) TableName() string {
+	return "report_job"
+}
+
+// Key 결과 파일 저장소 키, 파일 키와 겹치지 않도록 report/ 아래
+func (j ReportJob) Key() string {
+	return "report/" + j.Id.String() + "." + j.Format.Ext()
+}
+
+func (j ReportJob) IsDone() bool {
+	return j.Status == ReportStatusDone
+}
+
+// IsFinished 더 이상 시도하지 않음
+func (j ReportJob) IsFinished() bool {
+	return j.Status == ReportStatusDone || j.Status == ReportStatusFailed
+}
+
+func (j *ReportJob) Succeed(rows int64, now time.Time) {
+	key := j.Key()
+	j.Status = ReportStatusDone
+	j.ResultKey = &key
+	j.Rows = rows
+	j.Attempts++
+	j.LastError = nil
+	j.UpdatedAt = now
+	j.FinishedAt = &now
+}
+
+// Fail 실패 허용 횟수를 넘으면 FAILED, 아니면 다음 실행에서 다시 시도
+func (j *ReportJob) Fail(err error, now time.Time) {
+	msg := err.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	j.Attempts++
+	j.LastError = &msg
+	j.Status = ReportStatusPending
+	if j.Attempts >= ReportMaxAttempts {
+		j.Status = ReportStatusFailed
+		j.FinishedAt = &now
+	}
+	j.UpdatedAt = now
+}
+
+type ReportJobRepository interface {
+	Save(ctx context.Context, job *ReportJob) error
+	Transaction(ctx context.Context, fn func(reportJobRepo ReportJobTxRepository) error) error
+	With(tx gormx.Tx) ReportJobTxRepository
+
+	GetById(ctx context.Context, id uuid.UUID) (*ReportJob, error)
+	// FetchPending 대기 중이거나 ReportRunTimeout 이 지나도록 만드는 중인 리포트, 오래된 순
+	FetchPending(ctx context.Context, now time.Time, limit int) ([]ReportJob, error)
+	// Claim 다른 서버가 먼저 가져가지 않았으면 만드는 중으로 바꾸고 true
+	Claim(ctx context.Context, job *ReportJob, now time.Time) (bool, error)
+}
+
+type ReportJobTxRepository interface {
+	ReportJobRepository
+	gormx.Tx
+}
+
+// ReportRowWriter 결과 파일 형식별 작성기, 첫 줄은 제목, Close 로 마무리
+type ReportRowWriter interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+// ReportEncoder 형식별 작성기 생성
+type ReportEncoder interface {
+	NewWriter(format ReportFormat, w io.Writer) (ReportRowWriter, error)
+}
+
+// ReportSource 종류별 행을 읽어 w 에 씀, 제목 줄 제외 행 수 반환
+type ReportSource interface {
+	Export(ctx context.Context, job ReportJob, w ReportRowWriter) (int64, error)
+}
+
+type RequestReport struct {
+	Type   ReportType
+	Format ReportFormat
+	// From, To 기준 시간대(KST) 날짜 (ReportDateLayout), 둘 다 포함
+	From        string
+	To          string
+	RequestedBy uuid.UUID
+}
+
+type ReportJobInfo struct {
+	Id          uuid.UUID
+	Type        ReportType
+	Format      ReportFormat
+	Status      ReportStatus
+	From        time.Time
+	To          time.Time
+	Rows        int64
+	LastError   *string
+	CreatedAt   time.Time
+	FinishedAt  *time.Time
+	DownloadURL *string
+	ExpiresAt   *time.Time
+}
+
+type ReportRun struct {
+	Generated int64
+	Failed    int64
+}
+
+type ReportUseCase interface {
+	// RequestReport 대기열에 넣기만 함
+	RequestReport(ctx context.Context, in RequestReport) (ReportJobInfo, error)
+	// GetReport 요청한 사람만 조회, 다른 사람 리포트는 ErrItemNotFound
+	GetReport(ctx context.Context, id, requesterId uuid.UUID) (ReportJobInfo, error)
+	// GenerateReports 스케줄러가 주기적으로 호출, 대기 중인 리포트 생성 후 완료/실패 이벤트 발행
+	GenerateReports(ctx context.Context) (ReportRun, error)
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RetentionBatchSize 한 번에 보관 후 삭제할 행 수
+	RetentionBatchSize = 1000
+)
+
+// RetentionTarget 보관 기간 정책 적용 대상 테이블, 기본 키는 `id` 컬럼
+type RetentionTarget struct {
+	Table      string
+	TimeColumn string
+	Condition  string
+}
+
+// RetentionTargets 계속 쌓이기만 하는 로그성 테이블 목록
+var RetentionTargets = []RetentionTarget{
+	{Table: "analytics_event", TimeColumn: "created_at"},
+	{Table: "outbox_event", TimeColumn: "published_at", Condition: "`published_at` IS NOT NULL"},
+	{Table: "inbox_message", TimeColumn: "received_at", Condition: "`status` = 'PROCESSED'"},
+	{Table: "shadow_record", TimeColumn: "recorded_at"},
+	{Table: "api_usage", TimeColumn: "window_start"},
+	{Table: "refresh_token", TimeColumn: "expires_at"},
+	{Table: "password_reset", TimeColumn: "expires_at"},
+	{Table: "mobile_verification", TimeColumn: "expires_at"},
+	{Table: "sign_in_failure", TimeColumn: "failed_at"},
+}
+
+// RetentionPolicies 테이블별 보관 일수, 0 이면 정리하지 않음
+type RetentionPolicies map[string]uint16
+
+type RetentionRun struct {
+	Id         uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	Table      string     `gorm:"size:60;index;not null"`
+	Cutoff     time.Time  `gorm:"type:datetime(6);not null"`
+	Archived   int64      `gorm:"not null"`
+	Purged     int64      `gorm:"not null"`
+	Archives   *string    `gorm:"size:2000"`
+	Error      *string    `gorm:"size:1000"`
+	StartedAt  time.Time  `gorm:"type:datetime(6);index;not null"`
+	FinishedAt *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (RetentionRun) TableName() string {
+	return "retention_run"
+}
+
+type RetentionRepository interface {
+	SaveRun(ctx context.Context, run *RetentionRun) error
+
+	FetchExpiredRows(ctx context.Context, target RetentionTarget, cutoff time.Time, limit int) ([]map[string]interface{}, error)
+	DeleteRows(ctx context.Context, table string, ids []interface{}) (int64, error)
+	FetchRecentRuns(ctx context.Context, limit int) ([]RetentionRun, error)
+}
+
+// RetentionArchiver 삭제 전 행을 외부 저장소에 보관, 보관 위치 반환
+type RetentionArchiver interface {
+	Archive(ctx context.Context, name string, rows []map[string]interface{}) (string, error)
+}
+
+type RetentionRunInfo struct {
+	Table      string
+	Cutoff     time.Time
+	Archived   int64
+	Purged     int64
+	Error      *string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+type RetentionUseCase interface {
+	RunRetention(ctx context.Context) ([]RetentionRunInfo, error)
+
+	FetchRecentRuns(ctx context.Context) ([]RetentionRunInfo, error)
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedViewTarget 저장된 보기를 적용할 목록
+type SavedViewTarget string
+
+const (
+	SavedViewTargetCustomer SavedViewTarget = "CUSTOMER"
+)
+
+func (t SavedViewTarget) IsValid() bool {
+	return t == SavedViewTargetCustomer
+}
+
+type CustomerSortKey string
+
+const (
+	CustomerSortKeyCreatedAt CustomerSortKey = "createdAt"
+	CustomerSortKeyName      CustomerSortKey = "name"
+)
+
+func (k CustomerSortKey) IsValid() bool {
+	return k == CustomerSortKeyCreatedAt || k == CustomerSortKeyName
+}
+
+// SavedViewDefinition 목록 필터/정렬, JSON 으로 저장
+type SavedViewDefinition struct {
+	Query        string            `json:"q,omitempty"`
+	CustomFields map[string]string `json:"customFields,omitempty"`
+	Sort         string            `json:"sort,omitempty"`
+	Desc         bool              `json:"desc,omitempty"`
+}
+
+// Validate 대상 목록에서 쓸 수 없는 정렬, 추가 항목 키는 ErrWeirdData
+func (d SavedViewDefinition) Validate(target SavedViewTarget) error {
+	switch target {
+	case SavedViewTargetCustomer:
+		if d.Sort != "" && !CustomerSortKey(d.Sort).IsValid() {
+			return ErrWeirdData
+		}
+		for key := range d.CustomFields {
+			if !IsCustomFieldKey(key) {
+				return ErrWeirdData
+			}
+		}
+		return nil
+	}
+	return ErrWeirdData
+}
+
+type CreateSavedViewOption struct {
+	Id         uuid.UUID
+	OwnerId    uuid.UUID
+	Target     SavedViewTarget
+	Name       string
+	Definition SavedViewDefinition
+	Now        time.Time
+}
+
+func CreateSavedView(option CreateSavedViewOption) (view SavedView, err error) {
+	now := option.Now
+	view = SavedView{
+		Id:        option.Id,
+		OwnerId:   option.OwnerId,
+		Target:    option.Target,
+		CreatedAt: now,
+	}
+	err = view.Update(option.Name, option.Definition, now)
+	return
+}
+
+// SavedView 어드민별 목록 보기 (이름 + 필터/정렬)
+type SavedView struct {
+	Id         uuid.UUID       `gorm:"type:char(36);primaryKey"`
+	OwnerId    uuid.UUID       `gorm:"type:char(36);index;not null"`
+	Target     SavedViewTarget `gorm:"size:20;index;not null"`
+	Name       string          `gorm:"size:60;not null"`
+	Definition string          `gorm:"type:json;not null"`
+	CreatedAt  time.Time       `gorm:"type:datetime(6);not null"`
+	UpdatedAt  time.Time       `gorm:"type:datetime(6);not null"`
+}
+
+func (SavedView) TableName() string {
+	return "saved_view"
+}
+
+func (v *SavedView) Update(name string, definition SavedViewDefinition, now time.Time) error {
+	if !v.Target.IsValid() {
+		return ErrWeirdData
+	}
+	err := definition.Validate(v.Target)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(definition)
+	if err != nil {
+		return err
+	}
+
+	v.Name = name
+	v.Definition = string(raw)
+	v.UpdatedAt = now
+	return nil
+}
+
+func (v SavedView) ParseDefinition() (definition SavedViewDefinition) {
+	_ = json.Unmarshal([]byte(v.Definition), &definition)
+	return
+}
+
+// ApplyToCustomerOption 요청에 직접 지정한 값이 있으면 그 값을 우선
+func (v SavedView) ApplyToCustomerOption(option *FetchCustomerOption) {
+	definition := v.ParseDefinition()
+	if option.Query == "" {
+		option.Query = definition.Query
+	}
+	if len(definition.CustomFields) > 0 {
+		fields := make(map[string]string, len(definition.CustomFields)+len(option.CustomFields))
+		for key, value := range definition.CustomFields {
+			fields[key] = value
+		}
+		for key, value := range option.CustomFields {
+			fields[key] = value
+		}
+		option.CustomFields = fields
+	}
+	if option.Sort == "" {
+		option.Sort = CustomerSortKey(definition.Sort)
+		option.SortDesc = definition.Desc
+	}
+}
+
+type SavedViewRepository interface {
+	Save(ctx context.Context, view *SavedView) error
+	Delete(ctx context.Context, view *SavedView) error
+
+	GetById(ctx context.Context, id uuid.UUID) (*SavedView, error)
+	FetchByOwnerId(ctx context.Context, ownerId uuid.UUID, target SavedViewTarget) ([]SavedView, error)
+}
+
+type CreateSavedViewInput struct {
+	OwnerId    uuid.UUID
+	Target     SavedViewTarget
+	Name       string
+	Definition SavedViewDefinition
+}
+
+type UpdateSavedView struct {
+	ViewId     uuid.UUID
+	OwnerId    uuid.UUID
+	Name       string
+	Definition SavedViewDefinition
+}
+
+type DeleteSavedView struct {
+	ViewId  uuid.UUID
+	OwnerId uuid.UUID
+}
+
+type SavedViewInfo struct {
+	Id         uuid.UUID
+	Target     SavedViewTarget
+	Name       string
+	Definition SavedViewDefinition
+	UpdatedAt  time.Time
+}
+
+type SavedViewUseCase interface {
+	CreateSavedView(ctx context.Context, in CreateSavedViewInput) (uuid.UUID, error)
+	UpdateSavedView(ctx context.Context, in UpdateSavedView) error
+	DeleteSavedView(ctx context.Context, in DeleteSavedView) error
+
+	FetchSavedViews(ctx context.Context, ownerId uuid.UUID, target SavedViewTarget) ([]SavedViewInfo, error)
+}
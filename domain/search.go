@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// SearchSuggestLimit 빠른 검색 결과 수, 고객과 의뢰를 합쳐서
+	SearchSuggestLimit = 5
+	// SearchSuggestMinLength 이보다 짧은 검색어는 찾지 않음
+	SearchSuggestMinLength = 2
+
+	// SearchSuggestCacheName 입력할 때마다 부르므로 같은 검색어는 잠깐 메모리 캐시 사용
+	SearchSuggestCacheName  = "search_suggest"
+	SearchSuggestCacheTTL   = 30 * time.Second
+	SearchSuggestCacheLimit = 10000
+)
+
+type SearchSuggestionType string
+
+const (
+	SearchSuggestionTypeCustomer SearchSuggestionType = "CUSTOMER"
+	SearchSuggestionTypeOrder    SearchSuggestionType = "ORDER"
+)
+
+// SearchSuggestion 검색창 자동 완성 한 줄, Label 만 보여주고 Type, Id 로 상세 화면 이동
+type SearchSuggestion struct {
+	Type  SearchSuggestionType
+	Id    uuid.UUID
+	Label string
+}
+
+// NormalizeSearchQuery 앞뒤 공백을 빼고 소문자로, 캐시 키로도 사용
+func NormalizeSearchQuery(query string) (string, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	return query, utf8.RuneCountInString(query) >= SearchSuggestMinLength
+}
+
+// LooksLikeOrderNumber 의뢰 번호 앞부분(EF-, ef-2024 등)을 입력 중인지, 그러면 의뢰를 먼저 보여줌
+func LooksLikeOrderNumber(query string) bool {
+	return strings.HasPrefix(strings.ToUpper(query), OrderNumberPrefix+"-")
+}
+
+type SearchRepository interface {
+	// SuggestCustomers 삭제되지 않은 고객 중 이름, 이메일, 채널 이름, 휴대폰 번호가 prefix 로 시작하는 고객, 이름 순
+	SuggestCustomers(ctx context.Context, prefix string, limit int) ([]SearchSuggestion, error)
+	// SuggestOrders 의뢰 번호가 prefix 로 시작하는 의뢰, 최근 번호부터
+	SuggestOrders(ctx context.Context, prefix string, limit int) ([]SearchSuggestion, error)
+}
+
+type SearchUseCase interface {
+	// Suggest 고객, 의뢰를 합쳐 최대 SearchSuggestLimit 개, 검색어가 짧으면 빈 목록
+	Suggest(ctx context.Context, query string) ([]SearchSuggestion, error)
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// SettingCacheName 설정은 요청마다 읽으므로 메모리 캐시 사용, 변경 시 비움
+	SettingCacheName = "setting"
+	SettingCacheTTL  = 5 * time.Minute
+)
+
+type SettingKey string
+
+const (
+	// SettingKeyOrderSlaHours 주문 요청 후 기본 마감까지 시간, 0 이면 마감일 지정 안함
+	SettingKeyOrderSlaHours SettingKey = "order.sla_hours"
+	// SettingKeyOrderRevisionLimit 이용권에 수정 횟수가 없을 때 적용할 기본 수정 횟수
+	SettingKeyOrderRevisionLimit SettingKey = "order.revision_limit"
+	// SettingKeyOrderAssignStrategy 의뢰 요청 때 담당자 자동 배정 방법 (OrderAssignStrategy), NONE 이면 자동 배정 안함
+	SettingKeyOrderAssignStrategy SettingKey = "order.assign_strategy"
+	// SettingKeyOrderManagerCapacity 담당자에게 따로 지정하지 않은 동시 진행 의뢰 한도, 0 이면 제한 없음
+	SettingKeyOrderManagerCapacity SettingKey = "order.manager_capacity"
+	// SettingKeyOrderArchiveAfterDays 끝난 의뢰를 자동 보관하기까지 일수, 0 이면 자동 보관 안함
+	SettingKeyOrderArchiveAfterDays SettingKey = "order.archive_after_days"
+	// SettingKeyReminderLeadHours 마감 몇 시간 전에 알림을 보낼지
+	SettingKeyReminderLeadHours SettingKey = "notification.reminder_lead_hours"
+	// SettingKeyNotificationDigestSmsMinutes, SettingKeyNotificationDigestEmailMinutes 같은 의뢰 알림을 묶어 보내는 시간(분), 0 이면 바로 보냄
+	SettingKeyNotificationDigestSmsMinutes   SettingKey = "notification.digest_minutes.sms"
+	SettingKeyNotificationDigestEmailMinutes SettingKey = "notification.digest_minutes.email"
+	// SettingKeyNotificationSendWindowTransactional, SettingKeyNotificationSendWindowMarketing 분류별 알림을 보낼 수 있는 시간(KST, ex. 8-21), 비어있으면 제한 없음
+	SettingKeyNotificationSendWindowTransactional SettingKey = "notification.send_window.transactional"
+	SettingKeyNotificationSendWindowMarketing     SettingKey = "notification.send_window.marketing"
+	// SettingKeyPasswordRotationDays 관리자 비밀번호 변경 주기(일), 0 이면 주기 변경 안함
+	SettingKeyPasswordRotationDays SettingKey = "security.password_rotation_days"
+	// SettingKeyPasswordMinLength, SettingKeyPasswordCharClasses 관리자 비밀번호 최소 길이, 섞어야 하는 문자 종류 수(1~4)
+	SettingKeyPasswordMinLength   SettingKey = "security.password_min_length"
+	SettingKeyPasswordCharClasses SettingKey = "security.password_char_classes"
+	// SettingKeyPasswordHistory 다시 쓸 수 없는 최근 비밀번호 수(지금 비밀번호 포함), 0 이면 검사 안함
+	SettingKeyPasswordHistory SettingKey = "security.password_history"
+	// SettingKeySignInLockMinutes 비밀번호를 LoginAttemptLimit 번 연속으로 틀린 계정 잠금 시간(분), 0 이면 잠그지 않음
+	SettingKeySignInLockMinutes SettingKey = "security.sign_in_lock_minutes"
+	// SettingKeyStorageQuotaMBPerOrder 이용권 주문 횟수 1회당 고객 파일 저장 한도(MB)
+	SettingKeyStorageQuotaMBPerOrder SettingKey = "storage.quota_mb_per_order"
+	// SettingKeyStorageQuotaMBFree 사용 중인 이용권이 없는 고객의 파일 저장 한도(MB)
+	SettingKeyStorageQuotaMBFree SettingKey = "storage.quota_mb_free"
+	// SettingKeyFileOrphanDays 의뢰에 연결되지 않은 파일을 지우기까지 일수, 0 이면 정리 안함
+	SettingKeyFileOrphanDays SettingKey = "storage.orphan_days"
+	// SettingKeyAlertOrderIdleMinutes 업무 시간에 이 시간(분) 동안 의뢰 요청이 없으면 알림, 0 이면 끔
+	SettingKeyAlertOrderIdleMinutes SettingKey = "alert.order_idle_minutes"
+	// SettingKeyAlertBusinessStartHour, SettingKeyAlertBusinessEndHour 평일 업무 시간(KST, 시작 포함, 끝 미포함)
+	SettingKeyAlertBusinessStartHour SettingKey = "alert.business_start_hour"
+	SettingKeyAlertBusinessEndHour   SettingKey = "alert.business_end_hour"
+	// SettingKeyAlertSignInFailureLimit 10분 동안 로그인 실패가 이 수 이상이면 알림, 0 이면 끔
+	SettingKeyAlertSignInFailureLimit SettingKey = "alert.sign_in_failure_limit"
+	// SettingKeyAlertPaymentSilenceMinutes 결제 완료 메시지가 이 시간(분) 동안 없으면 알림, 0 이면 끔
+	SettingKeyAlertPaymentSilenceMinutes SettingKey = "alert.payment_silence_minutes"
+)
+
+type SettingType string
+
+const (
+	SettingTypeInt    SettingType = "INT"
+	SettingTypeBool   SettingType = "BOOL"
+	SettingTypeString SettingType = "STRING"
+)
+
+type SettingDefinition struct {
+	Key         SettingKey
+	Type        SettingType
+	Default     string
+	Description string
+}
+
+// SettingDefinitions 저장할 수 있는 설정 목록, 여기 없는 키는 저장 불가
+var SettingDefinitions = []SettingDefinition{
+	{Key: SettingKeyOrderSlaHours, Type: SettingTypeInt, Default: "72", Description: "주문 기본 마감 시간(시간)"},
+	{Key: SettingKeyOrderRevisionLimit, Type: SettingTypeInt, Default: "2", Description: "기본 수정 횟수"},
+	{Key: SettingKeyOrderAssignStrategy, Type: SettingTypeString, Default: string(OrderAssignStrategyNone), Description: "의뢰 자동 배정 방법(NONE, LEAST_LOADED, ROUND_ROBIN)"},
+	{Key: SettingKeyOrderManagerCapacity, Type: SettingTypeInt, Default: "10", Description: "담당자 기본 동시 진행 의뢰 한도"},
+	{Key: SettingKeyOrderArchiveAfterDays, Type: SettingTypeInt, Default: "90", Description: "끝난 의뢰 자동 보관 일수"},
+	{Key: SettingKeyReminderLeadHours, Type: SettingTypeInt, Default: "24", Description: "마감 알림 시점(마감 전 시간)"},
+	{Key: SettingKeyNotificationDigestSmsMinutes, Type: SettingTypeInt, Default: "10", Description: "의뢰 문자 알림 묶음 시간(분)"},
+	{Key: SettingKeyNotificationDigestEmailMinutes, Type: SettingTypeInt, Default: "30", Description: "의뢰 메일 알림 묶음 시간(분)"},
+	{Key: SettingKeyNotificationSendWindowTransactional, Type: SettingTypeString, Default: "", Description: "정보성 알림 발송 가능 시간(시작시-끝시, 비우면 항상)"},
+	{Key: SettingKeyNotificationSendWindowMarketing, Type: SettingTypeString, Default: "8-21", Description: "광고성 알림 발송 가능 시간(시작시-끝시, 비우면 항상)"},
+	{Key: SettingKeyPasswordRotationDays, Type: SettingTypeInt, Default: "0", Description: "관리자 비밀번호 변경 주기(일)"},
+	{Key: SettingKeyPasswordMinLength, Type: SettingTypeInt, Default: "8", Description: "관리자 비밀번호 최소 길이"},
+	{Key: SettingKeyPasswordCharClasses, Type: SettingTypeInt, Default: "2", Description: "관리자 비밀번호 문자 종류 수(소문자, 대문자, 숫자, 특수문자)"},
+	{Key: SettingKeyPasswordHistory, Type: SettingTypeInt, Default: "3", Description: "다시 쓸 수 없는 최근 관리자 비밀번호 수"},
+	{Key: SettingKeySignInLockMinutes, Type: SettingTypeInt, Default: "15", Description: "로그인 연속 실패 계정 잠금 시간(분)"},
+	{Key: SettingKeyStorageQuotaMBPerOrder, Type: SettingTypeInt, Default: "20480", Description: "이용권 주문 1회당 파일 저장 한도(MB)"},
+	{Key: SettingKeyStorageQuotaMBFree, Type: SettingTypeInt, Default: "1024", Description: "이용권 없는 고객 파일 저장 한도(MB)"},
+	{Key: SettingKeyFileOrphanDays, Type: SettingTypeInt, Default: "14", Description: "의뢰에 연결되지 않은 파일 보관 일수"},
+	{Key: SettingKeyAlertOrderIdleMinutes, Type: SettingTypeInt, Default: "120", Description: "업무 시간 의뢰 요청 없음 알림 기준(분)"},
+	{Key: SettingKeyAlertBusinessStartHour, Type: SettingTypeInt, Default: "10", Description: "운영 알림 업무 시작 시각(시)"},
+	{Key: SettingKeyAlertBusinessEndHour, Type: SettingTypeInt, Default: "19", Description: "운영 알림 업무 종료 시각(시)"},
+	{Key: SettingKeyAlertSignInFailureLimit, Type: SettingTypeInt, Default: "50", Description: "10분 로그인 실패 급증 알림 기준(회)"},
+	{Key: SettingKeyAlertPaymentSilenceMinutes, Type: SettingTypeInt, Default: "720", Description: "결제 완료 메시지 끊김 알림 기준(분)"},
+}
+
+func GetSettingDefinition(key SettingKey) (SettingDefinition, bool) {
+	for _, def := range SettingDefinitions {
+		if def.Key == key {
+			return def, true
+		}
+	}
+	return SettingDefinition{}, false
+}
+
+func (d SettingDefinition) Validate(value string) (err error) {
+	switch d.Type {
+	case SettingTypeInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case SettingTypeBool:
+		_, err = strconv.ParseBool(value)
+	case SettingTypeString:
+		if len(value) > 1000 {
+			err = ErrWeirdData
+		}
+	}
+
+	if d.Key == SettingKeyOrderAssignStrategy && !OrderAssignStrategy(value).IsValid() {
+		err = ErrWeirdData
+	}
+
+	if d.Key == SettingKeyNotificationSendWindowTransactional || d.Key == SettingKeyNotificationSendWindowMarketing {
+		_, err = ParseNotificationSendWindow(value)
+	}
+
+	if err == nil && (d.Key == SettingKeyPasswordMinLength || d.Key == SettingKeyPasswordCharClasses || d.Key == SettingKeyPasswordHistory) {
+		n, _ := strconv.ParseInt(value, 10, 64)
+		switch {
+		case d.Key == SettingKeyPasswordMinLength && (n < 8 || n > 32):
+			// 요청 형식(sf_password) 범위 안에서만
+			err = ErrWeirdData
+		case d.Key == SettingKeyPasswordCharClasses && (n < 1 || n > PasswordCharClassMax):
+			err = ErrWeirdData
+		case d.Key == SettingKeyPasswordHistory && (n < 0 || n > PasswordHistoryMax):
+			err = ErrWeirdData
+		}
+	}
+
+	if err != nil {
+		err = ErrWeirdData
+	}
+	return
+}
+
+type Setting struct {
+	Key       SettingKey `gorm:"size:100;primaryKey"`
+	Value     string     `gorm:"size:1000;not null"`
+	UpdatedBy *uuid.UUID `gorm:"type:char(36)"`
+	UpdatedAt time.Time  `gorm:"type:datetime(6);not null"`
+}
+
+func (Setting) TableName() string {
+	return "setting"
+}
+
+type SettingRepository interface {
+	Save(ctx context.Context, setting *Setting) error
+	Delete(ctx context.Context, key SettingKey) error
+
+	FetchAll(ctx context.Context) ([]Setting, error)
+}
+
+// SettingReader 타입별 설정 조회, 저장된 값이 없으면 기본값
+type SettingReader interface {
+	Int(ctx context.Context, key SettingKey) (int64, error)
+	Bool(ctx context.Context, key SettingKey) (bool, error)
+	String(ctx context.Context, key SettingKey) (string, error)
+}
+
+type SettingInfo struct {
+	Key         SettingKey
+	Type        SettingType
+	Value       string
+	Default     string
+	Description string
+	UpdatedBy   *uuid.UUID
+	UpdatedAt   *time.Time
+}
+
+type UpdateSetting struct {
+	Key       SettingKey
+	Value     string
+	UpdatedBy uuid.UUID
+	Ip        string
+}
+
+type ResetSetting struct {
+	Key     SettingKey
+	ResetBy uuid.UUID
+	Ip      string
+}
+
+type SettingUseCase interface {
+	UpdateSetting(ctx context.Context, in UpdateSetting) error
+	ResetSetting(ctx context.Context, in ResetSetting) error
+
+	FetchSettings(ctx context.Context) ([]SettingInfo, error)
+}
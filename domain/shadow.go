@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ShadowRuleCacheName 요청마다 규칙을 확인하므로 메모리 캐시 사용, 규칙 변경 시 비움
+	ShadowRuleCacheName = "shadow_rule"
+	ShadowRuleCacheTTL  = 30 * time.Second
+
+	// ShadowBodyLimit 요청/응답 본문은 이 크기까지만 저장
+	ShadowBodyLimit = 64 << 10
+
+	// ShadowRuleMaxDuration 규칙은 반드시 만료됨, 계속 켜두지 않도록 최대 하루
+	ShadowRuleMaxDuration = 24 * time.Hour
+)
+
+type CreateShadowRuleOption struct {
+	Id         uuid.UUID
+	Method     string
+	Route      string
+	SampleRate uint8
+	Duration   time.Duration
+	CreatedBy  uuid.UUID
+	Now        time.Time
+}
+
+func CreateShadowRule(option CreateShadowRuleOption) (rule ShadowRule, err error) {
+	if option.SampleRate == 0 || option.SampleRate > 100 ||
+		option.Duration <= 0 || option.Duration > ShadowRuleMaxDuration {
+		err = ErrWeirdData
+		return
+	}
+
+	now := option.Now
+	rule = ShadowRule{
+		Id:         option.Id,
+		Method:     option.Method,
+		Route:      option.Route,
+		SampleRate: option.SampleRate,
+		CreatedBy:  option.CreatedBy,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(option.Duration),
+	}
+	return
+}
+
+// ShadowRule 라우트별 요청/응답 기록 규칙, Route 는 echo 라우트 패턴 (ex. /order/:orderId)
+type ShadowRule struct {
+	Id         uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Method     string    `gorm:"size:10;not null"`
+	Route      string    `gorm:"size:200;not null"`
+	SampleRate uint8     `gorm:"not null"`
+	CreatedBy  uuid.UUID `gorm:"type:char(36);not null"`
+	CreatedAt  time.Time `gorm:"type:datetime(6);not null"`
+	ExpiresAt  time.Time `gorm:"type:datetime(6);index;not null"`
+}
+
+func (ShadowRule) TableName() string {
+	return "shadow_rule"
+}
+
+func (r ShadowRule) IsActive(now time.Time) bool {
+	return now.Before(r.ExpiresAt)
+}
+
+// ShadowRecord 개인정보를 가린 요청/응답 한 쌍
+type ShadowRecord struct {
+	Id           uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	RuleId       uuid.UUID  `gorm:"type:char(36);index;not null"`
+	Method       string     `gorm:"size:10;not null"`
+	Route        string     `gorm:"size:200;index;not null"`
+	Path         string     `gorm:"size:2048;not null"`
+	Query        string     `gorm:"type:text;not null"`
+	UserId       *uuid.UUID `gorm:"type:char(36)"`
+	RequestBody  string     `gorm:"type:mediumtext;not null"`
+	Status       int        `gorm:"not null"`
+	ResponseBody string     `gorm:"type:mediumtext;not null"`
+	DurationMs   int64      `gorm:"not null"`
+	RecordedAt   time.Time  `gorm:"type:datetime(6);index;not null"`
+}
+
+func (ShadowRecord) TableName() string {
+	return "shadow_record"
+}
+
+type FetchShadowRecordOption struct {
+	Method string
+	Route  string
+	Limit  int
+}
+
+type ShadowRepository interface {
+	SaveRule(ctx context.Context, rule *ShadowRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) (bool, error)
+	FetchRules(ctx context.Context) ([]ShadowRule, error)
+	FetchActiveRules(ctx context.Context, now time.Time) ([]ShadowRule, error)
+
+	SaveRecord(ctx context.Context, record *ShadowRecord) error
+	GetRecordById(ctx context.Context, id uuid.UUID) (*ShadowRecord, error)
+	FetchRecords(ctx context.Context, option FetchShadowRecordOption) ([]ShadowRecord, error)
+}
+
+// ShadowCapture 미들웨어에서 잡은 원본 요청/응답, 저장 전 개인정보를 가림
+type ShadowCapture struct {
+	Method       string
+	Route        string
+	Path         string
+	Query        map[string][]string
+	UserId       *uuid.UUID
+	RequestBody  []byte
+	Status       int
+	ResponseBody []byte
+	Duration     time.Duration
+}
+
+type CreateShadowRuleInput struct {
+	Method     string
+	Route      string
+	SampleRate uint8
+	Duration   time.Duration
+	CreatedBy  uuid.UUID
+}
+
+type ShadowRuleInfo struct {
+	Id         uuid.UUID
+	Method     string
+	Route      string
+	SampleRate uint8
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	Active     bool
+}
+
+type ShadowRecordInfo struct {
+	Id           uuid.UUID
+	Method       string
+	Route        string
+	Path         string
+	Query        string
+	UserId       *uuid.UUID
+	RequestBody  string
+	Status       int
+	ResponseBody string
+	DurationMs   int64
+	RecordedAt   time.Time
+}
+
+type ShadowUseCase interface {
+	// Sample 라우트에 켜진 규칙이 있고 표본으로 뽑히면 규칙 아이디 반환
+	Sample(ctx context.Context, method, route string) (uuid.UUID, bool)
+	Record(ctx context.Context, ruleId uuid.UUID, capture ShadowCapture) error
+
+	CreateRule(ctx context.Context, in CreateShadowRuleInput) (uuid.UUID, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	FetchRules(ctx context.Context) ([]ShadowRuleInfo, error)
+
+	GetRecord(ctx context.Context, id uuid.UUID) (ShadowRecordInfo, error)
+	FetchRecords(ctx context.Context, option FetchShadowRecordOption) ([]ShadowRecordInfo, error)
+}
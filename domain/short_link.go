@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ShortLinkPath 짧은 주소 경로, 문자 메시지에는 설정한 기본 주소 + /l/{code}
+	ShortLinkPath = "/l/"
+
+	// ShortLinkCodeRetry 코드가 겹치면 다시 만드는 횟수
+	ShortLinkCodeRetry = 3
+
+	shortLinkCodeLength      = 7
+	shortLinkCodeAlphabet    = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	shortLinkTargetMaxLength = 1000
+)
+
+// ShortLinkKind 짧은 주소로 보내는 곳, 클릭 통계 구분용
+type ShortLinkKind string
+
+const (
+	ShortLinkKindDelivery ShortLinkKind = "DELIVERY"
+	ShortLinkKindPayment  ShortLinkKind = "PAYMENT"
+	ShortLinkKindSurvey   ShortLinkKind = "SURVEY"
+)
+
+func (k ShortLinkKind) IsValid() bool {
+	switch k {
+	case ShortLinkKindDelivery, ShortLinkKindPayment, ShortLinkKindSurvey:
+		return true
+	}
+	return false
+}
+
+// ValidateShortLinkTarget http, https 절대 주소만
+func ValidateShortLinkTarget(target string) error {
+	if len(target) > shortLinkTargetMaxLength {
+		return ErrWeirdData
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrWeirdData
+	}
+	return nil
+}
+
+type CreateShortLinkOption struct {
+	Kind      ShortLinkKind
+	TargetUrl string
+	CreatorId uuid.UUID
+	ExpiresAt *time.Time
+	Now       time.Time
+}
+
+func CreateShortLink(option CreateShortLinkOption) (link ShortLink, err error) {
+	if !option.Kind.IsValid() {
+		err = ErrWeirdData
+		return
+	}
+	err = ValidateShortLinkTarget(option.TargetUrl)
+	if err != nil {
+		return
+	}
+
+	now := option.Now
+	if option.ExpiresAt != nil && !option.ExpiresAt.After(now) {
+		err = ErrWeirdData
+		return
+	}
+
+	buf := make([]byte, shortLinkCodeLength)
+	rand.Read(buf)
+	for i := range buf {
+		buf[i] = shortLinkCodeAlphabet[int(buf[i])%len(shortLinkCodeAlphabet)]
+	}
+
+	link = ShortLink{
+		Code:      string(buf),
+		Kind:      option.Kind,
+		TargetUrl: option.TargetUrl,
+		CreatorId: option.CreatorId,
+		ExpiresAt: option.ExpiresAt,
+		CreatedAt: now,
+	}
+	return
+}
+
+// ShortLink 문자, 알림톡 길이 제한용 짧은 주소, 코드는 대소문자 구분
+type ShortLink struct {
+	Code          string        `gorm:"size:16;primaryKey"`
+	Kind          ShortLinkKind `gorm:"size:20;index;not null"`
+	TargetUrl     string        `gorm:"size:1000;not null"`
+	CreatorId     uuid.UUID     `gorm:"type:char(36);index;not null"`
+	ExpiresAt     *time.Time    `gorm:"type:datetime(6)"`
+	ClickCount    int64         `gorm:"not null;default:0"`
+	LastClickedAt *time.Time    `gorm:"type:datetime(6)"`
+	CreatedAt     time.Time     `gorm:"type:datetime(6);not null"`
+}
+
+func (ShortLink) TableName() string {
+	return "short_link"
+}
+
+func (l ShortLink) IsExpired(at time.Time) bool {
+	return l.ExpiresAt != nil && !l.ExpiresAt.After(at)
+}
+
+type ShortLinkRepository interface {
+	// Create 코드가 이미 있으면 false
+	Create(ctx context.Context, link *ShortLink) (bool, error)
+	GetByCode(ctx context.Context, code string) (*ShortLink, error)
+	// RecordClick 클릭 수를 DB 에서 올림, 동시에 눌러도 빠지지 않음
+	RecordClick(ctx context.Context, code string, at time.Time) error
+}
+
+type CreateShortLinkInput struct {
+	Kind      ShortLinkKind
+	TargetUrl string
+	CreatorId uuid.UUID
+	ExpiresAt *time.Time
+}
+
+type ShortLinkInfo struct {
+	Code          string
+	Kind          ShortLinkKind
+	TargetUrl     string
+	CreatorId     uuid.UUID
+	ExpiresAt     *time.Time
+	ClickCount    int64
+	LastClickedAt *time.Time
+	CreatedAt     time.Time
+}
+
+type ShortLinkUseCase interface {
+	// CreateShortLink 만든 코드 반환, 다른 기능에서 문자 본문을 만들 때도 사용
+	CreateShortLink(ctx context.Context, in CreateShortLinkInput) (string, error)
+	// Follow 클릭을 기록하고 원래 주소 반환, 만료되면 ErrShortLinkExpired
+	Follow(ctx context.Context, code string) (string, error)
+
+	GetShortLink(ctx context.Context, code string) (ShortLinkInfo, error)
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignInFailure 비밀번호가 틀렸거나 없는 아이디로 실패한 로그인, 운영 알림에서 급증 여부 확인용
+type SignInFailure struct {
+	Id       uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Username string    `gorm:"size:320;index;not null"`
+	FailedAt time.Time `gorm:"type:datetime(6);index;not null"`
+}
+
+func (SignInFailure) TableName() string {
+	return "sign_in_failure"
+}
+
+type SignInFailureRepository interface {
+	Create(ctx context.Context, failure *SignInFailure) error
+	// CountSince since 이후 실패 수
+	CountSince(ctx context.Context, since time.Time) (int64, error)
+}
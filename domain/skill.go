@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"encoding/json"
+)
+
+// SkillTag 담당자가 할 수 있는 편집 작업, 의뢰는 필요한 작업을 적고 자동 배정, 가져가기는 모두 가진 담당자만
+type SkillTag string
+
+const (
+	// SkillTagMotionGraphics 모션그래픽
+	SkillTagMotionGraphics SkillTag = "MOTION_GRAPHICS"
+	// SkillTagShorts 쇼츠
+	SkillTagShorts SkillTag = "SHORTS"
+	// SkillTagColorGrading 색보정
+	SkillTagColorGrading SkillTag = "COLOR_GRADING"
+)
+
+var SkillTags = []SkillTag{
+	SkillTagMotionGraphics,
+	SkillTagShorts,
+	SkillTagColorGrading,
+}
+
+func (t SkillTag) IsValid() bool {
+	for i := range SkillTags {
+		if SkillTags[i] == t {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeSkillTags 없는 태그는 ErrWeirdData, 중복은 하나로, SkillTags 순서
+func NormalizeSkillTags(tags []SkillTag) ([]SkillTag, error) {
+	has := make(map[SkillTag]bool, len(tags))
+	for _, tag := range tags {
+		if !tag.IsValid() {
+			return nil, ErrWeirdData
+		}
+		has[tag] = true
+	}
+
+	res := make([]SkillTag, 0, len(has))
+	for _, tag := range SkillTags {
+		if has[tag] {
+			res = append(res, tag)
+		}
+	}
+	return res, nil
+}
+
+// encodeSkillTags JSON 배열, 빈 목록이면 nil
+func encodeSkillTags(tags []SkillTag) (*string, error) {
+	tags, err := NormalizeSkillTags(tags)
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(tags)
+	if err != nil {
+		return nil, err
+	}
+	encoded := string(raw)
+	return &encoded, nil
+}
+
+// decodeSkillTags 태그가 없어진 뒤에 남은 값은 건너뜀
+func decodeSkillTags(raw *string) []SkillTag {
+	if raw == nil {
+		return []SkillTag{}
+	}
+
+	var tags []SkillTag
+	_ = json.Unmarshal([]byte(*raw), &tags)
+
+	res := make([]SkillTag, 0, len(tags))
+	for _, tag := range tags {
+		if tag.IsValid() {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// EncodeSkillFilter 저장한 JSON 배열과 같은 형식, JSON_CONTAINS 조건 값
+func EncodeSkillFilter(tags []SkillTag) string {
+	raw, _ := json.Marshal(tags)
+	return string(raw)
+}
+
+// HasSkillTags need 를 모두 가졌으면 true, need 가 비어있으면 항상 true
+func HasSkillTags(have, need []SkillTag) bool {
+	for _, n := range need {
+		found := false
+		for _, h := range have {
+			if h == n {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomerSnapshotVersion 번들 형식이 바뀌면 올림, 다른 버전은 가져오기 거부
+const CustomerSnapshotVersion = 1
+
+// CustomerSnapshot 고객 한 명과 의뢰, 이용권을 환경 간에 옮기기 위한 번들
+type CustomerSnapshot struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exportedAt"`
+	Masked     bool          `json:"masked"`
+	User       SnapshotUser  `json:"user"`
+	Customer   Customer      `json:"customer"`
+	Tickets    []OrderTicket `json:"tickets"`
+	Orders     []Order       `json:"orders"`
+}
+
+// SnapshotUser 비밀번호 해시는 내보내지 않음
+type SnapshotUser struct {
+	Id        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Mask 연락처, 메모 등 개인정보를 식별 아이디 기반 값으로 바꿈
+func (s *CustomerSnapshot) Mask() {
+	short := s.User.Id.String()[:8]
+	s.User.Username = "customer-" + short + "@snapshot.invalid"
+	s.Customer.Name = "고객-" + short
+	s.Customer.Email = s.User.Username
+	s.Customer.Mobile = "01000000000"
+	s.Customer.Memo = ""
+	if s.Customer.BusinessRepresentative != nil {
+		s.Customer.BusinessRepresentative = &s.Customer.Name
+	}
+	for i := range s.Tickets {
+		s.Tickets[i].PaymentFingerprint = nil
+		s.Tickets[i].PaymentMethod = nil
+		s.Tickets[i].ReceiptUrl = nil
+	}
+	s.Masked = true
+}
+
+type ExportCustomerSnapshot struct {
+	UserId uuid.UUID
+	Mask   bool
+}
+
+type ImportCustomerSnapshot struct {
+	Snapshot CustomerSnapshot
+	// Username 가져온 고객의 아이디, 비어있으면 번들 아이디 앞에 새 식별 아이디를 붙여 사용
+	Username string
+}
+
+type CustomerSnapshotImportResult struct {
+	UserId uuid.UUID
+	// IdMap 번들의 식별 아이디 -> 새로 만든 식별 아이디 (고객, 이용권, 의뢰)
+	IdMap map[uuid.UUID]uuid.UUID
+	// DroppedAssignees 가져온 환경에 없는 담당자라 배정을 비운 의뢰 수
+	DroppedAssignees int
+}
+
+type CustomerSnapshotUseCase interface {
+	ExportCustomer(ctx context.Context, in ExportCustomerSnapshot) (CustomerSnapshot, error)
+	ImportCustomer(ctx context.Context, in ImportCustomerSnapshot) (CustomerSnapshotImportResult, error)
+}
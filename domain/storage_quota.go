@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StorageReportDefaultLimit 저장 용량 리포트 기본 고객 수
+	StorageReportDefaultLimit = 50
+	// StorageReportConcurrency 리포트에서 한도를 동시에 조회할 고객 수
+	StorageReportConcurrency = 8
+)
+
+// StorageUsage 고객 파일 저장 사용량(bytes)
+type StorageUsage struct {
+	// Used 올린 파일 합계, 삭제한 파일 제외
+	Used int64
+	// Reserved 진행 중인 나눠 올리기 크기, 완료 전에도 한도에 포함
+	Reserved int64
+	Quota    int64
+}
+
+func (s StorageUsage) Allows(size int64) bool {
+	return s.Used+s.Reserved+size <= s.Quota
+}
+
+// StorageQuotaOf 사용 중인 이용권의 주문 횟수만큼 한도 증가, 이용권이 없으면 무료 한도
+func StorageQuotaOf(ticket *OrderTicket, perOrderMB, freeMB int64) int64 {
+	quota := freeMB
+	if ticket != nil {
+		if byTicket := int64(ticket.TotalOrderCount) * perOrderMB; byTicket > quota {
+			quota = byTicket
+		}
+	}
+	return quota << 20
+}
+
+// FileOwnerUsage 올린 사람별 파일 합계
+type FileOwnerUsage struct {
+	OwnerId uuid.UUID
+	Files   int64
+	Bytes   int64
+}
+
+// StorageQuota 고객 파일 저장 한도, 관리자는 제한 없음
+type StorageQuota interface {
+	Usage(ctx context.Context, customerId uuid.UUID) (StorageUsage, error)
+	Quota(ctx context.Context, customerId uuid.UUID) (int64, error)
+	// Check 고객이 size 만큼 더 올리면 한도를 넘는 경우 ErrStorageQuotaExceeded
+	Check(ctx context.Context, ownerId uuid.UUID, size int64) error
+}
+
+type CustomerStorageInfo struct {
+	UserId uuid.UUID
+	Name   string
+	Files  int64
+	Used   int64
+	Quota  int64
+}
+
+type StorageReport struct {
+	TotalFiles int64
+	TotalBytes int64
+	// Customers 사용량이 많은 고객 순
+	Customers []CustomerStorageInfo
+}
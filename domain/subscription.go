@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateSubscriptionPlanOption struct {
+	Id         uuid.UUID
+	Name       string
+	OrderCount uint8
+	Months     uint8
+	Now        time.Time
+}
+
+func CreateSubscriptionPlan(option CreateSubscriptionPlanOption) SubscriptionPlan {
+	return SubscriptionPlan{
+		Id:         option.Id,
+		Name:       option.Name,
+		OrderCount: option.OrderCount,
+		Months:     option.Months,
+		CreatedAt:  option.Now,
+	}
+}
+
+// SubscriptionPlan 관리자가 고객에게 붙이는 구독 상품, 붙이면 상품 내용대로 이용권(OrderTicket) 하나를 만듦
+type SubscriptionPlan struct {
+	Id   uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Name string    `gorm:"size:60;unique;not null"`
+	// OrderCount 편집 횟수, 구독 기간 동안 맡길 수 있는 의뢰 수
+	OrderCount uint8 `gorm:"not null"`
+	// Months 월 구독 기간
+	Months    uint8     `gorm:"not null"`
+	CreatedAt time.Time `gorm:"type:datetime(6);not null"`
+}
+
+func (SubscriptionPlan) TableName() string {
+	return "subscription_plan"
+}
+
+type SubscriptionPlanRepository interface {
+	Save(ctx context.Context, plan *SubscriptionPlan) error
+
+	GetById(ctx context.Context, planId uuid.UUID) (*SubscriptionPlan, error)
+	GetByName(ctx context.Context, name string) (*SubscriptionPlan, error)
+	// FetchAll 만든 순
+	FetchAll(ctx context.Context) ([]SubscriptionPlan, error)
+}
+
+type CreateSubscriptionPlanInput struct {
+	Name       string
+	OrderCount uint8
+	Months     uint8
+}
+
+type AttachSubscriptionPlan struct {
+	CustomerId uuid.UUID
+	PlanId     uuid.UUID
+
+	// AttachedBy, Ip 감사 로그에 남김
+	AttachedBy uuid.UUID
+	Ip         string
+}
+
+type CustomerSubscriptionAccess struct {
+	CustomerId  uuid.UUID
+	RequesterId uuid.UUID
+}
+
+// CustomerSubscription 지금 이용 중인 이용권의 편집 횟수
+type CustomerSubscription struct {
+	TicketId uuid.UUID
+	// PlanId 관리자가 구독 상품으로 붙인 이용권만, 결제로 만든 이용권은 nil
+	PlanId   *uuid.UUID
+	PlanName *string
+	StartAt  *time.Time
+	EndAt    *time.Time
+
+	TotalEditCount uint8
+	// DoneEditCount 완료된 의뢰 수, RemainingEditCount 는 완료될 때 줄어듦
+	DoneEditCount      uint8
+	RemainingEditCount uint8
+	// RequestableEditCount 더 맡길 수 있는 의뢰 수, 진행 중인 의뢰도 뺌
+	RequestableEditCount uint8
+}
+
+type SubscriptionUseCase interface {
+	// CreatePlan 같은 이름의 상품이 있으면 ErrItemAlreadyExist
+	CreatePlan(ctx context.Context, in CreateSubscriptionPlanInput) (uuid.UUID, error)
+	FetchPlans(ctx context.Context) ([]SubscriptionPlan, error)
+
+	// AttachPlan 상품 내용대로 이용권을 만듦, 이용 중인 이용권이 있으면 끝나는 때부터 시작
+	// 없는 고객이나 상품이면 ErrItemNotFound, 계약서 서명 전인 기업 고객이면 ErrContractNotSigned
+	AttachPlan(ctx context.Context, in AttachSubscriptionPlan) (uuid.UUID, error)
+
+	// GetCustomerSubscription 고객 본인 또는 관리자만, 아니면 ErrNoPermission, 이용 중인 이용권이 없으면 nil
+	GetCustomerSubscription(ctx context.Context, in CustomerSubscriptionAccess) (*CustomerSubscription, error)
+}
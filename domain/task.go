@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+const (
+	// TaskRemindBefore 마감 이만큼 전부터 담당자에게 알림, 한 번만
+	TaskRemindBefore = time.Hour
+
+	// TaskRemindBatchSize 알림 한 번 실행에서 처리할 최대 개수
+	TaskRemindBatchSize = 200
+)
+
+type CreateTaskOption struct {
+	Id         uuid.UUID
+	Title      string
+	Memo       string
+	DueAt      time.Time
+	AssigneeId uuid.UUID
+	CustomerId *uuid.UUID
+	OrderId    *uuid.UUID
+	CreatedBy  uuid.UUID
+	Now        time.Time
+}
+
+func CreateTask(option CreateTaskOption) Task {
+	return Task{
+		Id:         option.Id,
+		Title:      option.Title,
+		Memo:       option.Memo,
+		DueAt:      option.DueAt,
+		AssigneeId: option.AssigneeId,
+		CustomerId: option.CustomerId,
+		OrderId:    option.OrderId,
+		CreatedBy:  option.CreatedBy,
+		CreatedAt:  option.Now,
+		UpdatedAt:  option.Now,
+	}
+}
+
+// Task 운영 후속 조치, 고객이나 의뢰에 연결 가능
+type Task struct {
+	Id    uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Title string    `gorm:"size:200;not null"`
+	Memo  string    `gorm:"size:2000;not null"`
+	DueAt time.Time `gorm:"type:datetime(6);index;not null"`
+
+	// AssigneeId 담당 관리자
+	AssigneeId uuid.UUID  `gorm:"type:char(36);index;not null"`
+	CustomerId *uuid.UUID `gorm:"type:char(36);index"`
+	OrderId    *uuid.UUID `gorm:"type:char(36);index"`
+
+	CreatedBy uuid.UUID  `gorm:"type:char(36);not null"`
+	CreatedAt time.Time  `gorm:"type:datetime(6);not null"`
+	UpdatedAt time.Time  `gorm:"type:datetime(6);not null"`
+	DoneAt    *time.Time `gorm:"type:datetime(6);index"`
+	DoneBy    *uuid.UUID `gorm:"type:char(36)"`
+
+	// RemindedAt 마감 알림을 보낸 시각, 마감을 바꾸면 다시 보냄
+	RemindedAt *time.Time `gorm:"type:datetime(6)"`
+}
+
+func (Task) TableName() string {
+	return "task"
+}
+
+func (t Task) IsDone() bool {
+	return t.DoneAt != nil
+}
+
+func (t Task) IsOverdue(now time.Time) bool {
+	return !t.IsDone() && t.DueAt.Before(now)
+}
+
+type UpdateTaskOption struct {
+	Title      string
+	Memo       string
+	DueAt      time.Time
+	AssigneeId uuid.UUID
+	Now        time.Time
+}
+
+// Update 마감이나 담당자가 바뀌면 알림을 다시 보내도록 초기화
+func (t *Task) Update(option UpdateTaskOption) {
+	if !t.DueAt.Equal(option.DueAt) || t.AssigneeId != option.AssigneeId {
+		t.RemindedAt = nil
+	}
+
+	t.Title = option.Title
+	t.Memo = option.Memo
+	t.DueAt = option.DueAt
+	t.AssigneeId = option.AssigneeId
+	t.UpdatedAt = option.Now
+}
+
+func (t *Task) Done(by uuid.UUID, now time.Time) {
+	t.DoneAt = &now
+	t.DoneBy = &by
+	t.UpdatedAt = now
+}
+
+func (t *Task) Reopen(now time.Time) {
+	t.DoneAt = nil
+	t.DoneBy = nil
+	t.UpdatedAt = now
+}
+
+func (t *Task) Remind(now time.Time) {
+	t.RemindedAt = &now
+}
+
+type TaskStatusFilter string
+
+const (
+	TaskStatusFilterOpen TaskStatusFilter = "OPEN"
+	TaskStatusFilterDone TaskStatusFilter = "DONE"
+	TaskStatusFilterAll  TaskStatusFilter = "ALL"
+)
+
+type FetchTaskOption struct {
+	AssigneeId *uuid.UUID
+	CustomerId *uuid.UUID
+	OrderId    *uuid.UUID
+	Status     TaskStatusFilter
+	// OverdueAt 이 시각 전에 마감인 열린 일만, Status 무시
+	OverdueAt *time.Time
+}
+
+type TaskRepository interface {
+	Save(ctx context.Context, task *Task) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Transaction(ctx context.Context, fn func(taskRepo TaskTxRepository) error) error
+	With(tx gormx.Tx) TaskTxRepository
+
+	GetById(ctx context.Context, id uuid.UUID) (*Task, error)
+	// Fetch 마감이 빠른 순
+	Fetch(ctx context.Context, option FetchTaskOption) ([]Task, error)
+	// FetchToRemind 열린 일 중 마감이 until 전이고 알림을 보내지 않은 것, 마감이 빠른 순
+	FetchToRemind(ctx context.Context, until time.Time, limit int) ([]Task, error)
+}
+
+type TaskTxRepository interface {
+	TaskRepository
+	gormx.Tx
+}
+
+type RegisterTask struct {
+	Title string
+	Memo  string
+	DueAt time.Time
+	// AssigneeId 비어있으면 만든 관리자
+	AssigneeId *uuid.UUID
+	CustomerId *uuid.UUID
+	OrderId    *uuid.UUID
+	CreatedBy  uuid.UUID
+}
+
+type UpdateTask struct {
+	TaskId     uuid.UUID
+	Title      string
+	Memo       string
+	DueAt      time.Time
+	AssigneeId uuid.UUID
+}
+
+type FetchTasks struct {
+	AssigneeId *uuid.UUID
+	CustomerId *uuid.UUID
+	OrderId    *uuid.UUID
+	Status     TaskStatusFilter
+	Overdue    bool
+}
+
+type TaskInfo struct {
+	Id         uuid.UUID
+	Title      string
+	Memo       string
+	DueAt      time.Time
+	AssigneeId uuid.UUID
+	CustomerId *uuid.UUID
+	OrderId    *uuid.UUID
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+	DoneAt     *time.Time
+	DoneBy     *uuid.UUID
+	Overdue    bool
+}
+
+type TaskUseCase interface {
+	// RegisterTask 담당자가 관리자가 아니거나, 연결한 고객, 의뢰가 없으면 ErrItemNotFound
+	RegisterTask(ctx context.Context, in RegisterTask) (TaskInfo, error)
+	UpdateTask(ctx context.Context, in UpdateTask) (TaskInfo, error)
+	DoneTask(ctx context.Context, taskId, doneBy uuid.UUID) (TaskInfo, error)
+	ReopenTask(ctx context.Context, taskId uuid.UUID) (TaskInfo, error)
+	DeleteTask(ctx context.Context, taskId uuid.UUID) error
+
+	// RemindTasks 마감이 TaskRemindBefore 안으로 들어온 열린 일마다 담당자 알림 이벤트 발행, 보낸 수 반환
+	RemindTasks(ctx context.Context) (int, error)
+
+	GetTask(ctx context.Context, taskId uuid.UUID) (TaskInfo, error)
+	FetchTasks(ctx context.Context, in FetchTasks) ([]TaskInfo, error)
+}
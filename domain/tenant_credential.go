@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TenantCredentialCacheName 복호화한 자격 증명 캐시, 변경 시 비움
+	TenantCredentialCacheName = "tenant_credential"
+	TenantCredentialCacheTTL  = 5 * time.Minute
+
+	tenantCredentialValueMaxLength = 1000
+)
+
+// tenantKeyPattern 화이트라벨 대행사 식별 키
+var tenantKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,39}$`)
+
+func IsTenantKey(key string) bool {
+	return tenantKeyPattern.MatchString(key)
+}
+
+// CredentialProvider 테넌트별 자격 증명을 쓰는 외부 연동
+type CredentialProvider string
+
+const (
+	// CredentialProviderPG 결제 대행사
+	CredentialProviderPG CredentialProvider = "PG"
+	// CredentialProviderAlimtalk 카카오 알림톡 발신 프로필
+	CredentialProviderAlimtalk CredentialProvider = "ALIMTALK"
+)
+
+// credentialFields 연동별 필수 항목, 여기 없는 항목은 저장 불가
+var credentialFields = map[CredentialProvider][]string{
+	CredentialProviderPG:       {"merchantId", "secretKey"},
+	CredentialProviderAlimtalk: {"senderKey", "apiKey", "profileId"},
+}
+
+func (p CredentialProvider) IsValid() bool {
+	_, ok := credentialFields[p]
+	return ok
+}
+
+// ValidateCredentialValues 필수 항목 누락, 정의에 없는 항목, 너무 긴 값은 ErrWeirdData
+func ValidateCredentialValues(provider CredentialProvider, values map[string]string) error {
+	fields, ok := credentialFields[provider]
+	if !ok || len(values) != len(fields) {
+		return ErrWeirdData
+	}
+
+	for _, field := range fields {
+		v, ok := values[field]
+		if !ok || v == "" || len(v) > tenantCredentialValueMaxLength {
+			return ErrWeirdData
+		}
+	}
+	return nil
+}
+
+// CredentialCipher 자격 증명 암복호화, aad 로 테넌트/연동을 묶어 다른 행으로 옮긴 암호문은 복호화 실패
+type CredentialCipher interface {
+	Seal(plain, aad []byte) ([]byte, error)
+	Open(sealed, aad []byte) ([]byte, error)
+}
+
+// TenantCredential 테넌트별 외부 연동 자격 증명, 값은 암호화해서 저장하고 조회 API 로는 항목 이름만 노출
+type TenantCredential struct {
+	TenantKey string             `gorm:"size:40;primaryKey"`
+	Provider  CredentialProvider `gorm:"size:20;primaryKey"`
+	Sealed    []byte             `gorm:"type:blob;not null"`
+	UpdatedBy uuid.UUID          `gorm:"type:char(36);not null"`
+	UpdatedAt time.Time          `gorm:"type:datetime(6);not null"`
+}
+
+func (TenantCredential) TableName() string {
+	return "tenant_credential"
+}
+
+// AAD 암호문을 묶을 테넌트/연동 정보
+func (c TenantCredential) AAD() []byte {
+	return []byte(c.TenantKey + "/" + string(c.Provider))
+}
+
+// Fields 저장된 항목 이름, 값은 노출하지 않음
+func (c TenantCredential) Fields() []string {
+	fields := append([]string(nil), credentialFields[c.Provider]...)
+	sort.Strings(fields)
+	return fields
+}
+
+type TenantCredentialRepository interface {
+	Save(ctx context.Context, credential *TenantCredential) error
+	Delete(ctx context.Context, tenantKey string, provider CredentialProvider) (bool, error)
+
+	Get(ctx context.Context, tenantKey string, provider CredentialProvider) (*TenantCredential, error)
+	FetchByTenant(ctx context.Context, tenantKey string) ([]TenantCredential, error)
+}
+
+// PGCredential 결제 대행사 연동 값
+type PGCredential struct {
+	MerchantId string
+	SecretKey  string
+}
+
+// AlimtalkCredential 알림톡 발신 프로필
+type AlimtalkCredential struct {
+	SenderKey string
+	ApiKey    string
+	ProfileId string
+}
+
+// TenantCredentialResolver 연동 adapter 가 호출할 때마다 테넌트 자격 증명을 읽음, 없으면 ErrItemNotFound
+type TenantCredentialResolver interface {
+	PG(ctx context.Context, tenantKey string) (PGCredential, error)
+	Alimtalk(ctx context.Context, tenantKey string) (AlimtalkCredential, error)
+}
+
+type SetTenantCredential struct {
+	TenantKey string
+	Provider  CredentialProvider
+	Values    map[string]string
+	UpdatedBy uuid.UUID
+	Ip        string
+}
+
+type DeleteTenantCredential struct {
+	TenantKey string
+	Provider  CredentialProvider
+	DeletedBy uuid.UUID
+	Ip        string
+}
+
+type TenantCredentialInfo struct {
+	Provider  CredentialProvider
+	Fields    []string
+	UpdatedBy uuid.UUID
+	UpdatedAt time.Time
+}
+
+type TenantCredentialUseCase interface {
+	SetTenantCredential(ctx context.Context, in SetTenantCredential) error
+	DeleteTenantCredential(ctx context.Context, in DeleteTenantCredential) error
+
+	FetchTenantCredentials(ctx context.Context, tenantKey string) ([]TenantCredentialInfo, error)
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TermsKind string
+
+const (
+	// TermsKindService 이용약관
+	TermsKindService TermsKind = "TERMS_OF_SERVICE"
+	// TermsKindAgreement 편집 서비스 계약서
+	TermsKindAgreement TermsKind = "SERVICE_AGREEMENT"
+)
+
+func (k TermsKind) IsValid() bool {
+	return k == TermsKindService || k == TermsKindAgreement
+}
+
+type CreateTermsDocumentOption struct {
+	Id        uuid.UUID
+	Kind      TermsKind
+	Title     string
+	Body      string
+	Required  bool
+	CreatedBy uuid.UUID
+	Now       time.Time
+}
+
+// CreateTermsDocument Version 은 저장할 때 같은 종류의 마지막 Version + 1
+func CreateTermsDocument(option CreateTermsDocumentOption) TermsDocument {
+	return TermsDocument{
+		Id:          option.Id,
+		Kind:        option.Kind,
+		Title:       option.Title,
+		Body:        option.Body,
+		Required:    option.Required,
+		PublishedAt: option.Now,
+		CreatedBy:   option.CreatedBy,
+	}
+}
+
+// TermsDocument 약관 한 버전, 고객이 동의한 내용이 남아야 하므로 수정, 삭제 없이 새 버전으로만 바꿈
+type TermsDocument struct {
+	Id      uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Kind    TermsKind `gorm:"size:30;uniqueIndex:idx_terms_document_kind_version;not null"`
+	Version uint16    `gorm:"uniqueIndex:idx_terms_document_kind_version;not null"`
+	Title   string    `gorm:"size:200;not null"`
+	Body    string    `gorm:"type:mediumtext;not null"`
+	// Required 다시 동의해야 의뢰할 수 있는 버전, 오탈자 수정처럼 다시 동의가 필요 없으면 false
+	Required    bool      `gorm:"not null"`
+	PublishedAt time.Time `gorm:"type:datetime(6);not null"`
+	CreatedBy   uuid.UUID `gorm:"type:char(36);not null"`
+}
+
+func (TermsDocument) TableName() string {
+	return "terms_document"
+}
+
+// TermsAcceptance 고객이 약관 한 버전에 동의한 기록, 추가만 가능
+type TermsAcceptance struct {
+	Id         uuid.UUID `gorm:"type:char(36);primaryKey"`
+	UserId     uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_terms_acceptance_user_document;not null"`
+	DocumentId uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_terms_acceptance_user_document;not null"`
+	Kind       TermsKind `gorm:"size:30;not null"`
+	Version    uint16    `gorm:"not null"`
+	AcceptedAt time.Time `gorm:"type:datetime(6);index;not null"`
+	Ip         string    `gorm:"size:45;not null"`
+	UserAgent  string    `gorm:"size:500;not null"`
+}
+
+func (TermsAcceptance) TableName() string {
+	return "terms_acceptance"
+}
+
+type TermsRepository interface {
+	// CreateDocument 같은 종류의 마지막 Version + 1 로 저장
+	CreateDocument(ctx context.Context, document *TermsDocument) error
+	GetDocumentById(ctx context.Context, id uuid.UUID) (*TermsDocument, error)
+	// FetchLatestDocuments 종류별 가장 최근 버전
+	FetchLatestDocuments(ctx context.Context) ([]TermsDocument, error)
+	// FetchLatestRequiredDocuments 종류별 다시 동의가 필요한 가장 최근 버전
+	FetchLatestRequiredDocuments(ctx context.Context) ([]TermsDocument, error)
+
+	// SaveAcceptances 이미 동의한 버전은 건너뜀
+	SaveAcceptances(ctx context.Context, list []TermsAcceptance) error
+	// FetchAcceptancesByUser 최근 동의부터
+	FetchAcceptancesByUser(ctx context.Context, userId uuid.UUID) ([]TermsAcceptance, error)
+}
+
+// TermsGate 의뢰처럼 약관 동의가 필요한 기능 앞에서 확인
+type TermsGate interface {
+	// RequireAccepted 종류별 다시 동의가 필요한 최근 버전 이상에 동의하지 않았으면 ErrTermsNotAccepted
+	RequireAccepted(ctx context.Context, userId uuid.UUID) error
+}
+
+type PublishTerms struct {
+	Kind        TermsKind
+	Title       string
+	Body        string
+	Required    bool
+	PublishedBy uuid.UUID
+}
+
+type AcceptTerms struct {
+	UserId      uuid.UUID
+	DocumentIds []uuid.UUID
+	Ip          string
+	UserAgent   string
+}
+
+type TermsDocumentInfo struct {
+	Id          uuid.UUID
+	Kind        TermsKind
+	Version     uint16
+	Title       string
+	Body        string
+	Required    bool
+	PublishedAt time.Time
+}
+
+// MyTermsInfo 최근 버전과 요청자의 동의 여부
+type MyTermsInfo struct {
+	TermsDocumentInfo
+	Accepted bool
+	// Pending 이 종류에 동의해야 의뢰할 수 있음
+	Pending bool
+}
+
+type TermsAcceptanceInfo struct {
+	DocumentId uuid.UUID
+	Kind       TermsKind
+	Version    uint16
+	AcceptedAt time.Time
+	Ip         string
+	UserAgent  string
+}
+
+type TermsUseCase interface {
+	PublishTerms(ctx context.Context, in PublishTerms) (TermsDocumentInfo, error)
+	// AcceptTerms 종류별 최근 버전만 동의 가능, 아니면 ErrWeirdData
+	AcceptTerms(ctx context.Context, in AcceptTerms) error
+
+	GetTermsDocument(ctx context.Context, id uuid.UUID) (TermsDocumentInfo, error)
+	FetchLatestTerms(ctx context.Context) ([]TermsDocumentInfo, error)
+	FetchMyTerms(ctx context.Context, userId uuid.UUID) ([]MyTermsInfo, error)
+	FetchTermsAcceptances(ctx context.Context, customerId uuid.UUID) ([]TermsAcceptanceInfo, error)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TokenVersionCacheName 접근 토큰 버전은 요청마다 확인하므로 메모리 캐시 사용, 비밀번호 변경 시 비움
+	// 다른 서버는 TTL 동안 이전 토큰을 받을 수 있음
+	TokenVersionCacheName = "token_version"
+	TokenVersionCacheTTL  = 30 * time.Second
+)
+
+// TokenClaims 접근 토큰 내용, 발급과 검증이 같은 구조를 사용
+type TokenClaims struct {
+	// Subject 유저 아이디 (sub)
+	Subject  uuid.UUID
+	Role     UserRole
+	IssuedAt time.Time
+	// TokenVersion 발급 당시 Identity.TokenVersion, 지금 값과 다르면 이전 비밀번호로 받은 토큰
+	TokenVersion uint32
+	// KeyId 범위를 줄인 토큰(API 키)이면 토큰 아이디(jti), 아니면 uuid.Nil
+	KeyId  uuid.UUID
+	Scopes []TokenScope
+	// ExpiresAt 범위를 줄인 토큰만, 아니면 nil
+	ExpiresAt *time.Time
+	// Tenant 발급한 테넌트 키, 기본 DB 면 빈 값
+	Tenant string
+}
+
+// TokenParseAdapter 서명(HS256), 만료 확인 후 내용 반환, 버전은 TokenVersionReader 로 따로 확인
+type TokenParseAdapter interface {
+	Parse(raw string) (TokenClaims, error)
+}
+
+// TokenVersionReader 유저의 지금 토큰 버전, 없거나 삭제된 유저면 ok false
+type TokenVersionReader interface {
+	TokenVersion(ctx context.Context, userId uuid.UUID) (version uint32, ok bool, err error)
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ScopedTokenDefaultTTL 유효 기간을 지정하지 않았을 때
+	ScopedTokenDefaultTTL = 7 * 24 * time.Hour
+	// ScopedTokenMaxTTL TV 대시보드처럼 오래 켜두는 화면도 한 달에 한 번은 새로 발급
+	ScopedTokenMaxTTL = 30 * 24 * time.Hour
+)
+
+// TokenScope 권한을 줄인 토큰의 허용 범위, 범위가 없는 토큰은 역할의 모든 권한
+type TokenScope string
+
+const (
+	// TokenScopeRead 조회(GET, HEAD)만 허용
+	TokenScopeRead TokenScope = "read"
+	// TokenScopeDashboard 대시보드 조회만 허용
+	TokenScopeDashboard TokenScope = "dashboard"
+)
+
+func (s TokenScope) IsValid() bool {
+	switch s {
+	case TokenScopeRead, TokenScopeDashboard:
+		return true
+	}
+	return false
+}
+
+// Allows 요청 method, path 가 범위 안인지
+func (s TokenScope) Allows(method, path string) bool {
+	readOnly := method == http.MethodGet || method == http.MethodHead
+	switch s {
+	case TokenScopeRead:
+		return readOnly
+	case TokenScopeDashboard:
+		return readOnly && strings.HasPrefix(path, "/dashboard/")
+	}
+	return false
+}
+
+// ParseTokenScopes 인증 미들웨어가 토큰 scopes 클레임으로 채운 Principal.Scopes(쉼표 구분) 파싱, 빈 값이면 nil
+func ParseTokenScopes(raw string) (scopes []TokenScope) {
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			scopes = append(scopes, TokenScope(part))
+		}
+	}
+	return
+}
+
+// ScopesAllow 범위 중 하나라도 허용하면 true
+func ScopesAllow(scopes []TokenScope, method, path string) bool {
+	for _, scope := range scopes {
+		if scope.Allows(method, path) {
+			return true
+		}
+	}
+	return false
+}
+
+type IssueScopedToken struct {
+	UserId uuid.UUID
+	Scopes []TokenScope
+	// TTL 0 이면 ScopedTokenDefaultTTL
+	TTL time.Duration
+}
+
+type ScopedToken struct {
+	// KeyId 토큰 아이디(jti), API 키 사용량 집계 단위
+	KeyId     uuid.UUID
+	Token     string
+	Scopes    []TokenScope
+	ExpiresAt time.Time
+}
@@ -7,21 +7,13 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stockfolioofficial/back-editfolio/util/gormx"
-	"github.com/stockfolioofficial/back-editfolio/util/pointer"
-	"golang.org/x/crypto/bcrypt"
-)
-
-type UserRole string
-
-const (
-	SuperAdminUserRole UserRole = "SUPER_ADMIN"
-	AdminUserRole      UserRole = "ADMIN"
-	CustomerUserRole   UserRole = "CUSTOMER"
 )
 
 type UserCreateOption struct {
+	Id       uuid.UUID
 	Role     UserRole
 	Username string
+	Now      time.Time
 }
 
 func CheckUserAlive(u *User, scope ...func(user User) bool) bool {
@@ -47,37 +39,36 @@ func CheckUserAlive(u *User, scope ...func(user User) bool) bool {
 }
 
 func CreateUser(option UserCreateOption) User {
-	return User{
-		Id:        uuid.New(),
-		Role:      option.Role,
-		Username:  option.Username,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		DeletedAt: nil,
-	}
+	return User{Identity: CreateIdentity(CreateIdentityOption(option))}
 }
 
+// User 인증 계정(Identity)과 프로필(고객, 어드민)을 같이 다룰 때, 로그인, 토큰 발급은 Identity 만 사용
 type User struct {
-	Id        uuid.UUID  `gorm:"type:char(36);primaryKey"`
-	Role      UserRole   `gorm:"size:30;index;not null"`
-	Username  string     `gorm:"size:320;unique;not null"`
-	Password  string     `gorm:"size:60;not null"`
-	CreatedAt time.Time  `gorm:"type:datetime(6);not null"`
-	UpdatedAt time.Time  `gorm:"type:datetime(6);not null"`
-	DeletedAt *time.Time `gorm:"type:datetime(6);index"`
-	Customer  *Customer  `gorm:"foreignKey:Id"`
-	Manager   *Manager   `gorm:"foreignKey:Id"`
-	MyJob     []Order    `gorm:"foreignKey:Orderer"`
-	Ticket    []Order    `gorm:"foreignKey:Assignee"`
+	Identity
+
+	Customer *Customer `gorm:"foreignKey:Id"`
+	Manager  *Manager  `gorm:"foreignKey:Id"`
+	MyJob    []Order   `gorm:"foreignKey:Orderer"`
+	Ticket   []Order   `gorm:"foreignKey:Assignee"`
 }
 
 func (User) TableName() string {
 	return "user"
 }
 
-func (u *User) UpdateUsername(username string) {
-	u.Username = username
-	u.stampUpdate()
+// ConfirmUsernameChange 고객이면 프로필 이메일도 새 아이디로
+func (u *User) ConfirmUsernameChange(now time.Time) error {
+	err := u.Identity.ConfirmUsernameChange(now)
+	if err != nil {
+		return err
+	}
+
+	// 새 주소는 받을 수 있는지 아직 모름
+	if u.Customer != nil {
+		u.Customer.Email = u.Username
+		u.Customer.clearEmailUndeliverable()
+	}
+	return nil
 }
 
 func (u *User) LoadManagerInfo(ctx context.Context, repo ManagerRepository) (err error) {
@@ -92,30 +83,6 @@ func (u *User) LoadManagerInfo(ctx context.Context, repo ManagerRepository) (err
 	return
 }
 
-func (u *User) ComparePassword(plainPass string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(plainPass)) == nil
-}
-
-func (u User) IsCustomer() bool {
-	return u.HasRole(CustomerUserRole)
-}
-
-func (u User) IsAdmin() bool {
-	return u.HasRole(AdminUserRole)
-}
-
-func (u User) IsSuperAdmin() bool {
-	return u.HasRole(SuperAdminUserRole)
-}
-
-func (u User) HasRole(role UserRole) bool {
-	return u.Role == role
-}
-
-func (u *User) IsDeleted() bool {
-	return u.DeletedAt != nil
-}
-
 func (u *User) LoadCustomerInfo(ctx context.Context, repo CustomerRepository) (err error) {
 	u.Customer, err = repo.GetById(ctx, u.Id)
 	if err != nil {
@@ -128,19 +95,9 @@ func (u *User) LoadCustomerInfo(ctx context.Context, repo CustomerRepository) (e
 	return
 }
 
-func (u *User) UpdatePassword(plainPass string) {
-	generated, _ := bcrypt.GenerateFromPassword([]byte(plainPass), bcrypt.DefaultCost+2)
-	u.Password = string(generated)
-	u.stampUpdate()
-}
-
-func (u *User) StampUpdate() {
-	u.stampUpdate()
-}
-
-func (u *User) UpdateManagerInfo(username, name, nickname string) {
-	defer u.stampUpdate()
-	u.UpdateUsername(username)
+// UpdateManagerInfo 아이디 변경은 RequestUsernameChange 로 따로 확인
+func (u *User) UpdateManagerInfo(name, nickname string, now time.Time) {
+	defer u.stampUpdate(now)
 	if u.Manager == nil {
 		return
 	}
@@ -148,18 +105,9 @@ func (u *User) UpdateManagerInfo(username, name, nickname string) {
 	u.Manager.Nickname = nickname
 }
 
-func (u *User) stampUpdate() {
-	u.UpdatedAt = time.Now()
-}
-
-func (u *User) Delete() {
-	u.DeletedAt = pointer.Time(time.Now())
-}
-
-func (u *User) UpdateCustomerInfo(name, channelName, channelLink, email, mobile, personaLink, onedriveLink, memo string) {
-	defer u.stampUpdate()
-	u.UpdateUsername(email)
-	u.UpdatePassword(mobile)
+// UpdateCustomerInfo 이메일(아이디) 변경은 RequestUsernameChange, 휴대폰 번호 변경은 UpdateCustomerMobile 로 따로 확인
+func (u *User) UpdateCustomerInfo(name, channelName, channelLink, personaLink, onedriveLink, memo string, now time.Time) {
+	defer u.stampUpdate(now)
 
 	var customer = u.Customer
 	if customer == nil {
@@ -169,35 +117,124 @@ func (u *User) UpdateCustomerInfo(name, channelName, channelLink, email, mobile,
 	customer.Name = name
 	customer.ChannelName = channelName
 	customer.ChannelLink = channelLink
-	customer.Email = email
-	customer.Mobile = mobile
 	customer.PersonaLink = personaLink
 	customer.OnedriveLink = onedriveLink
 	customer.Memo = memo
 }
 
+// UpdateCustomerProfile 채널, 페르소나, 메모만 변경, 고객 프로필이 없던 계정이면 아이디를 이메일로 새로 만듦
+func (u *User) UpdateCustomerProfile(channelName, channelLink, personaLink, memo string, now time.Time) {
+	defer u.stampUpdate(now)
+	if u.Customer == nil {
+		customer := CreateCustomer(CustomerCreateOption{
+			User:  u,
+			Email: u.Username,
+		})
+		u.Customer = &customer
+	}
+
+	u.Customer.ChannelName = channelName
+	u.Customer.ChannelLink = channelLink
+	u.Customer.PersonaLink = personaLink
+	u.Customer.Memo = memo
+}
+
+// UpdateCustomerMobile 고객이 인증한 번호로만 변경, 이미 바꾼 비밀번호일 수 있어 비밀번호는 그대로
+func (u *User) UpdateCustomerMobile(mobile string, now time.Time) {
+	defer u.stampUpdate(now)
+	if u.Customer == nil {
+		return
+	}
+	u.Customer.Mobile = mobile
+}
+
+// AdminStatusFilter 어드민 목록 삭제 여부 조건, 빈 값이면 AdminStatusFilterActive
+type AdminStatusFilter string
+
+const (
+	AdminStatusFilterActive  AdminStatusFilter = "ACTIVE"
+	AdminStatusFilterDeleted AdminStatusFilter = "DELETED"
+	AdminStatusFilterAll     AdminStatusFilter = "ALL"
+)
+
 type FetchAdminOption struct {
-	Query string
+	// Query 이름, 닉네임, 이메일(아이디) 검색어
+	Query  string
+	Status AdminStatusFilter
+	// Skills 모두 할 수 있는 어드민만
+	Skills []SkillTag
 }
 
 type FetchCustomerOption struct {
 	Query string
+
+	// CustomFields 추가 항목 key = 값 으로 일치하는 고객만
+	CustomFields map[string]string
+
+	Sort     CustomerSortKey
+	SortDesc bool
+
+	// ViewId 저장된 보기, ViewerId 의 보기만 적용
+	ViewId   *uuid.UUID
+	ViewerId uuid.UUID
+}
+
+const (
+	CustomerPageSizeDefault = 20
+	CustomerPageSizeMax     = 100
+)
+
+// FetchCustomers 페이지 단위 고객 목록, Page 는 1 부터
+type FetchCustomers struct {
+	FetchCustomerOption
+
+	Page int
+	Size int
+}
+
+// Offset Page, Size 가 0 이하면 첫 페이지, 기본 크기로 맞춤
+func (f *FetchCustomers) Offset() int {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Size < 1 {
+		f.Size = CustomerPageSizeDefault
+	}
+	if f.Size > CustomerPageSizeMax {
+		f.Size = CustomerPageSizeMax
+	}
+	return (f.Page - 1) * f.Size
 }
 
 type UserRepository interface {
 	Save(ctx context.Context, user *User) error
+	// Create 새 유저만 저장, 이미 있는 아이디(이메일)면 덮어쓰지 않고 ErrItemAlreadyExist
+	Create(ctx context.Context, user *User) error
 	Transaction(ctx context.Context, fn func(userRepo UserTxRepository) error, options ...*sql.TxOptions) error
 
 	ExistsSuperUser(ctx context.Context) (bool, error)
 
 	GetByUsername(ctx context.Context, username string) (*User, error)
+	FetchByUsernames(ctx context.Context, usernames []string) ([]User, error)
+	// GetByPendingUsernameToken 토큰 해시로 아이디 변경 대기 중인 유저 조회
+	GetByPendingUsernameToken(ctx context.Context, hashedToken string) (*User, error)
 	GetById(ctx context.Context, userId uuid.UUID) (*User, error)
 
 	FetchAllAdmin(ctx context.Context, option FetchAdminOption) ([]User, error)
 	FetchAllCustomer(ctx context.Context, option FetchCustomerOption) ([]User, error)
+	// FetchCustomerPage offset 부터 limit 개와 조건에 맞는 전체 수
+	FetchCustomerPage(ctx context.Context, option FetchCustomerOption, offset, limit int) ([]User, int64, error)
 
 	GetByIdWithCustomer(ctx context.Context, id uuid.UUID) (*User, error)
+	// GetByIdWithCustomerForUpdate 트랜잭션 안에서 부르면 유저와 고객 정보를 커밋할 때까지 잠금
+	GetByIdWithCustomerForUpdate(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByIdWithManager(ctx context.Context, id uuid.UUID) (*User, error)
+
+	// GetDeletedById 삭제된 유저만 조회, 고객/어드민 정보 포함
+	GetDeletedById(ctx context.Context, id uuid.UUID) (*User, error)
+
+	// FetchDeleted since 이후 삭제된 유저, 최근 삭제 순
+	FetchDeleted(ctx context.Context, since time.Time) ([]User, error)
 }
 
 type UserTxRepository interface {
@@ -210,6 +247,19 @@ type SignInUser struct {
 	Password string
 }
 
+// RotatePassword 변경이 필요해 로그인이 막힌 관리자가 아이디, 기존 비밀번호로 직접 변경
+type RotatePassword struct {
+	Username    string
+	OldPassword string
+	NewPassword string
+}
+
+type ForcePasswordRotation struct {
+	Role        UserRole
+	RequestedBy uuid.UUID
+	Ip          string
+}
+
 type CreateSuperAdminUser struct {
 	Name     string
 	Email    string
@@ -224,10 +274,13 @@ type CreateCustomerUser struct {
 }
 
 type CreateAdminUser struct {
-	Name     string
-	Email    string
-	Password string
-	Nickname string
+	Name      string
+	Email     string
+	Password  string
+	Nickname  string
+	CreatedBy uuid.UUID
+	// Ip 요청한 슈퍼 어드민 IP, 감사 로그용
+	Ip string
 }
 
 type UpdateCustomerUser struct {
@@ -236,12 +289,24 @@ type UpdateCustomerUser struct {
 	ChannelName  string
 	ChannelLink  string
 	Email        string
-	Mobile       string
 	PersonaLink  string
 	OnedriveLink string
 	Memo         string
 }
 
+type UpdateCustomerProfile struct {
+	UserId      uuid.UUID
+	ChannelName string
+	ChannelLink string
+	PersonaLink string
+	Memo        string
+}
+
+type UpdateCustomerBusinessInfo struct {
+	UserId uuid.UUID
+	BusinessInfo
+}
+
 type UpdateAdminInfo struct {
 	UserId   uuid.UUID
 	Name     string
@@ -256,23 +321,62 @@ type UpdateAdminPassword struct {
 }
 
 type ForceUpdateAdminInfo struct {
-	UserId   uuid.UUID
-	Name     string
-	Username string
-	Nickname string
+	UserId    uuid.UUID
+	Name      string
+	Username  string
+	Nickname  string
+	UpdatedBy uuid.UUID
+	Ip        string
 }
 
 type ForceUpdateAdminPassword struct {
-	UserId   uuid.UUID
-	Password string
+	UserId    uuid.UUID
+	Password  string
+	UpdatedBy uuid.UUID
+	Ip        string
 }
 
-type DeleteCustomerUser struct {
+type UpdateManagerCapacity struct {
 	UserId uuid.UUID
+	// Capacity nil 이면 설정(order.manager_capacity) 값, 0 이면 제한 없음
+	Capacity *uint16
 }
 
-type DeleteAdminUser struct {
+type UpdateManagerSkills struct {
 	UserId uuid.UUID
+	Skills []SkillTag
+}
+
+type DeleteCustomerUser struct {
+	UserId    uuid.UUID
+	DeletedBy uuid.UUID
+	Ip        string
+}
+
+type RestoreUser struct {
+	UserId     uuid.UUID
+	RestoredBy uuid.UUID
+	Ip         string
+}
+
+type DeleteAdminUser struct {
+	UserId    uuid.UUID
+	DeletedBy uuid.UUID
+	Ip        string
+}
+
+type MergeCustomerUser struct {
+	SurvivorId  uuid.UUID
+	DuplicateId uuid.UUID
+	MergedBy    uuid.UUID
+	Ip          string
+}
+
+type CustomerMergeResult struct {
+	SurvivorId   uuid.UUID
+	MovedOrders  int64
+	MovedTickets int64
+	MovedCredit  int64
 }
 
 type AdminInfoDetailData struct {
@@ -285,25 +389,36 @@ type AdminInfoDetailData struct {
 }
 
 type CustomerInfoDetailData struct {
-	UserId         uuid.UUID
-	Name           string
-	ChannelName    string
-	ChannelLink    string
-	Email          string
-	Mobile         string
-	PersonaLink    string
-	OnedriveLink   string
-	Memo           string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	UserId       uuid.UUID
+	Name         string
+	ChannelName  string
+	ChannelLink  string
+	Email        string
+	Mobile       string
+	PersonaLink  string
+	OnedriveLink string
+	Memo         string
+	CustomFields CustomFieldValues
+	// Business 사업자 정보, 등록 전이면 nil
+	Business *BusinessInfo
+	// EmailUndeliverableAt 메일 발송 서비스가 받을 수 없는 주소로 알린 시간, 받을 수 있으면 nil
+	EmailUndeliverableAt     *time.Time
+	EmailUndeliverableReason *string
+	StorageUsage             StorageUsage
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
 }
 
 type AdminInfoData struct {
 	UserId    uuid.UUID
+	Role      UserRole
+	Status    IdentityStatus
 	Name      string
 	Nickname  string
 	Email     string
+	Skills    []SkillTag
 	CreatedAt time.Time
+	DeletedAt *time.Time
 }
 
 type CustomerInfoData struct {
@@ -314,41 +429,100 @@ type CustomerInfoData struct {
 	Email       string
 	Mobile      string
 	CreatedAt   time.Time
+
+	CustomFields CustomFieldValues
+}
+
+type CustomerInfoPage struct {
+	List  []CustomerInfoData
+	Total int64
+	Page  int
+	Size  int
 }
 
 type CustomerSubscribeInfoData struct {
-	UserId              uuid.UUID  `json:"userId"`
-	Name                string     `json:"name"`
-	SubscribeStart      *time.Time `json:"subscribeStart"`
-	SubscribeEnd        *time.Time `json:"subscribeEnd"`
-	RemainingOrderCount uint8      `json:"remainingOrderCount"`
-	OnedriveLink        string     `json:"onedriveLink"`
+	UserId              uuid.UUID    `json:"userId"`
+	Name                string       `json:"name"`
+	SubscribeStart      *time.Time   `json:"subscribeStart"`
+	SubscribeEnd        *time.Time   `json:"subscribeEnd"`
+	RemainingOrderCount uint8        `json:"remainingOrderCount"`
+	OnedriveLink        string       `json:"onedriveLink"`
+	StorageUsage        StorageUsage `json:"-"`
 }
 
 type UserUseCase interface {
-	SignInUser(ctx context.Context, in SignInUser) (string, error)
+	SignInUser(ctx context.Context, in SignInUser) (TokenPair, error)
+	// RotatePassword 비밀번호 변경 후 토큰 발급, 이전에 발급한 갱신 토큰은 폐기
+	// 비밀번호 규칙에 맞지 않으면 ErrPasswordTooWeak, 최근에 쓴 비밀번호면 ErrPasswordReused
+	RotatePassword(ctx context.Context, in RotatePassword) (TokenPair, error)
+	// RefreshToken 갱신 토큰으로 접근 토큰 재발급, 갱신 토큰도 새로 바뀜
+	RefreshToken(ctx context.Context, token string) (TokenPair, error)
+	// ForcePasswordRotation role 의 모든 유저에게 비밀번호 변경 강제, 대상 수 반환
+	ForcePasswordRotation(ctx context.Context, in ForcePasswordRotation) (int64, error)
+	// IssueScopedToken 요청자 역할 그대로, 범위만 줄인 토큰 발급
+	IssueScopedToken(ctx context.Context, in IssueScopedToken) (ScopedToken, error)
 
 	CreateSuperAdminUser(ctx context.Context, in CreateSuperAdminUser) (uuid.UUID, error)
 	CreateCustomerUser(ctx context.Context, in CreateCustomerUser) (uuid.UUID, error)
+	// ImportCustomers 이미 있거나 파일 안에서 겹치는 이메일은 건너뛰고 나머지를 한 트랜잭션으로 생성, 행 순서대로 결과 반환
+	ImportCustomers(ctx context.Context, in ImportCustomers) ([]CustomerImportResult, error)
 	CreateAdminUser(ctx context.Context, in CreateAdminUser) (uuid.UUID, error)
 
 	UpdateCustomerUser(ctx context.Context, in UpdateCustomerUser) error
+	// UpdateCustomerProfile 고객 프로필(customer) 이 없으면 만들고 있으면 채널, 페르소나, 메모 변경, 없는 고객은 ErrItemNotFound
+	UpdateCustomerProfile(ctx context.Context, in UpdateCustomerProfile) error
+	// UpdateCustomerBusinessInfo 사업자등록번호 검증 번호가 틀리면 ErrWeirdData
+	UpdateCustomerBusinessInfo(ctx context.Context, in UpdateCustomerBusinessInfo) error
+	// UpdateAdminPassword, ForceUpdateAdminPassword 비밀번호 규칙에 맞지 않으면 ErrPasswordTooWeak, 최근에 쓴 비밀번호면 ErrPasswordReused
 	UpdateAdminPassword(ctx context.Context, in UpdateAdminPassword) error
 	UpdateAdminInfo(ctx context.Context, in UpdateAdminInfo) error
 	ForceUpdateAdminInfo(ctx context.Context, in ForceUpdateAdminInfo) error
 	ForceUpdateAdminPassword(ctx context.Context, in ForceUpdateAdminPassword) error
+	// UpdateManagerCapacity 삭제되지 않은 어드민, 슈퍼 어드민만
+	UpdateManagerCapacity(ctx context.Context, in UpdateManagerCapacity) error
+	// UpdateManagerSkills 삭제되지 않은 어드민, 슈퍼 어드민만, 없는 태그는 ErrWeirdData
+	UpdateManagerSkills(ctx context.Context, in UpdateManagerSkills) error
 
 	DeleteCustomerUser(ctx context.Context, in DeleteCustomerUser) error
 	DeleteAdminUser(ctx context.Context, in DeleteAdminUser) error
+	// RestoreUser 삭제된 고객/어드민 복구, 다시 로그인 가능
+	// 삭제되지 않았으면 ErrItemNotFound, 삭제 후 같은 이메일을 다른 계정이 쓰고 있으면 ErrItemAlreadyExist,
+	// 병합으로 삭제된 고객은 ErrUserMerged
+	RestoreUser(ctx context.Context, in RestoreUser) error
+	// RestoreCustomerUser RestoreUser 와 같지만 고객이 아니면 ErrItemNotFound
+	RestoreCustomerUser(ctx context.Context, in RestoreUser) error
+
+	// ConfirmUsernameChange 메일로 받은 토큰으로 아이디(이메일) 변경 확정
+	ConfirmUsernameChange(ctx context.Context, token string) error
+
+	// RequestPasswordReset 재설정 메일 발송 이벤트 생성, 없는 아이디, 삭제된 계정, 요청이 너무 잦은 경우도 에러 없이 끝냄
+	RequestPasswordReset(ctx context.Context, username string) error
+	// ResetPassword 메일로 받은 토큰으로 비밀번호 변경, 모든 refresh 토큰 폐기
+	ResetPassword(ctx context.Context, in ResetPassword) error
+
+	// RequestMobileVerification 바꿀 번호로 인증 번호 문자 발송, 요청이 너무 잦으면 ErrTooManyRequests
+	RequestMobileVerification(ctx context.Context, in RequestMobileVerification) error
+	// UpdateCustomerMobile 인증 번호가 맞을 때만 고객 휴대폰 번호 변경
+	// 요청한 인증이 없으면 ErrItemNotFound, 만료는 ErrTokenExpired, 너무 많이 틀렸으면 ErrTooManyRequests
+	UpdateCustomerMobile(ctx context.Context, in UpdateCustomerMobile) error
+
+	// MergeCustomerUser 중복 고객의 의뢰, 이용권, 크레딧, 메모를 남는 고객으로 옮기고 중복 고객 삭제
+	MergeCustomerUser(ctx context.Context, in MergeCustomerUser) (CustomerMergeResult, error)
 
 	GetAdminInfoDetailByUserId(ctx context.Context, userId uuid.UUID) (AdminInfoDetailData, error)
 	GetCustomerInfoDetailByUserId(ctx context.Context, userId uuid.UUID) (CustomerInfoDetailData, error)
 	FetchAllAdmin(ctx context.Context, option FetchAdminOption) ([]AdminInfoData, error)
 	FetchAllCustomer(ctx context.Context, option FetchCustomerOption) ([]CustomerInfoData, error)
+	FetchCustomers(ctx context.Context, in FetchCustomers) (CustomerInfoPage, error)
 
 	CustomerSubscribeInfoByUserId(ctx context.Context, userId uuid.UUID) (CustomerSubscribeInfoData, error)
+	// GetTaxInvoiceInfo 세금계산서 발행용 사업자 정보, 등록 전이면 ErrBusinessInfoRequired
+	GetTaxInvoiceInfo(ctx context.Context, username string) (BusinessInfo, error)
 }
 
 type TokenGenerateAdapter interface {
-	Generate(User) (string, error)
-}
\ No newline at end of file
+	// Generate ctx 의 테넌트 키를 토큰에 넣음, 다른 테넌트 요청에는 쓸 수 없음
+	Generate(ctx context.Context, identity Identity) (string, error)
+	// GenerateScoped scopes 범위만 허용하고 expiresAt 에 만료되는 토큰, keyId 는 jti
+	GenerateScoped(ctx context.Context, identity Identity, keyId uuid.UUID, scopes []TokenScope, expiresAt time.Time) (string, error)
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[EMAIL] "
+)
+
+// NewEmailController webhookToken 이 비어있으면 메일 발송 서비스 webhook 을 받지 않음
+func NewEmailController(useCase domain.EmailUseCase, webhookToken string) *EmailController {
+	return &EmailController{useCase: useCase, webhookToken: webhookToken}
+}
+
+type EmailController struct {
+	useCase      domain.EmailUseCase
+	webhookToken string
+}
+
+func (c *EmailController) Bind(e *echo.Echo) {
+	// ===== PROVIDER =====
+	// 메일 발송 서비스가 호출, 인증 대신 주소에 넣은 토큰 확인
+	e.POST("/email/webhook/sendgrid", c.sendGridWebhook)
+	e.POST("/email/webhook/ses", c.sesWebhook)
+}
+
+func (c *EmailController) validToken(ctx echo.Context) bool {
+	token := ctx.QueryParam("token")
+	return c.webhookToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(c.webhookToken)) == 1
+}
+
+// record 2xx 가 아니면 발송 서비스가 다시 보내므로 처리 실패만 5xx
+func (c *EmailController) record(ctx echo.Context, provider domain.EmailProvider, list []domain.RecordEmailEvent) error {
+	res, err := c.useCase.RecordEmailEvents(ctx.Request().Context(), provider, list)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("provider", provider).
+			Error(tag, "record, unhandled error useCase.RecordEmailEvents")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("provider", provider).
+		WithField("recorded", res.Recorded).
+		WithField("flagged", res.Flagged).
+		WithField("cleared", res.Cleared).
+		Debug(tag, "email webhook")
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// sendGridEvent SendGrid Event Webhook 이벤트 하나, 여러 개를 배열로 보냄
+type sendGridEvent struct {
+	Email     string `json:"email"`
+	Timestamp int64  `json:"timestamp"`
+	Event     string `json:"event"`
+	MessageId string `json:"sg_message_id"`
+	Reason    string `json:"reason"`
+	// Type bounce 이벤트에서 bounce 면 영구 반송, blocked 면 일시 반송
+	Type string `json:"type"`
+}
+
+// sendGridWebhook 전달, 반송, 스팸 신고 이벤트 수신, 그 외 이벤트(열람, 클릭 등)는 무시
+func (c *EmailController) sendGridWebhook(ctx echo.Context) error {
+	if !c.validToken(ctx) {
+		echox.Log(ctx).WithField("ip", ctx.RealIP()).Warn(tag, "sendgrid webhook, invalid token")
+		return ctx.JSON(http.StatusUnauthorized, domain.InvalidateTokenResponse)
+	}
+
+	var req []sendGridEvent
+	err := json.NewDecoder(ctx.Request().Body).Decode(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "sendgrid webhook, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list := make([]domain.RecordEmailEvent, 0, len(req))
+	for _, src := range req {
+		event := domain.RecordEmailEvent{
+			// sg_message_id 는 발송 때 받은 메시지 아이디 뒤에 ".filter..." 가 붙어 옴
+			MessageId:  strings.SplitN(src.MessageId, ".", 2)[0],
+			Email:      src.Email,
+			Reason:     src.Reason,
+			OccurredAt: time.Unix(src.Timestamp, 0),
+		}
+		switch src.Event {
+		case "delivered":
+			event.Type = domain.EmailEventTypeDelivered
+		case "bounce":
+			event.Type = domain.EmailEventTypeBounced
+			event.Permanent = src.Type != "blocked"
+		case "spamreport":
+			event.Type = domain.EmailEventTypeComplained
+		default:
+			continue
+		}
+		list = append(list, event)
+	}
+
+	return c.record(ctx, domain.EmailProviderSendGrid, list)
+}
+
+// snsMessage SES 이벤트를 전달하는 SNS 메시지, Content-Type 이 text/plain 이라 직접 읽음
+type snsMessage struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+// sesNotification SES 알림(notificationType) 또는 이벤트 게시(eventType) 내용
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		MessageId string `json:"messageId"`
+	} `json:"mail"`
+	Bounce *struct {
+		BounceType        string    `json:"bounceType"`
+		BounceSubType     string    `json:"bounceSubType"`
+		Timestamp         time.Time `json:"timestamp"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string    `json:"complaintFeedbackType"`
+		Timestamp             time.Time `json:"timestamp"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Delivery *struct {
+		Timestamp  time.Time `json:"timestamp"`
+		Recipients []string  `json:"recipients"`
+	} `json:"delivery"`
+}
+
+// sesWebhook SNS 구독 주소, 구독 확인 요청은 주소만 로그로 남기니 운영자가 직접 확인해야함
+func (c *EmailController) sesWebhook(ctx echo.Context) error {
+	if !c.validToken(ctx) {
+		echox.Log(ctx).WithField("ip", ctx.RealIP()).Warn(tag, "ses webhook, invalid token")
+		return ctx.JSON(http.StatusUnauthorized, domain.InvalidateTokenResponse)
+	}
+
+	var req snsMessage
+	err := json.NewDecoder(ctx.Request().Body).Decode(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "ses webhook, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	switch req.Type {
+	case "Notification":
+	case "SubscriptionConfirmation":
+		echox.Log(ctx).WithField("subscribeURL", req.SubscribeURL).Warn(tag, "ses webhook, subscription confirmation required")
+		return ctx.NoContent(http.StatusNoContent)
+	default:
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	var notification sesNotification
+	err = json.Unmarshal([]byte(req.Message), &notification)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "ses webhook, message parse error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	messageId := notification.Mail.MessageId
+	var list []domain.RecordEmailEvent
+	switch {
+	case notification.Bounce != nil:
+		bounce := notification.Bounce
+		for _, recipient := range bounce.BouncedRecipients {
+			reason := recipient.DiagnosticCode
+			if reason == "" {
+				reason = bounce.BounceType + "/" + bounce.BounceSubType
+			}
+			list = append(list, domain.RecordEmailEvent{
+				MessageId:  messageId,
+				Email:      recipient.EmailAddress,
+				Type:       domain.EmailEventTypeBounced,
+				Permanent:  bounce.BounceType == "Permanent",
+				Reason:     reason,
+				OccurredAt: bounce.Timestamp,
+			})
+		}
+	case notification.Complaint != nil:
+		complaint := notification.Complaint
+		for _, recipient := range complaint.ComplainedRecipients {
+			list = append(list, domain.RecordEmailEvent{
+				MessageId:  messageId,
+				Email:      recipient.EmailAddress,
+				Type:       domain.EmailEventTypeComplained,
+				Reason:     complaint.ComplaintFeedbackType,
+				OccurredAt: complaint.Timestamp,
+			})
+		}
+	case notification.Delivery != nil:
+		delivery := notification.Delivery
+		for _, recipient := range delivery.Recipients {
+			list = append(list, domain.RecordEmailEvent{
+				MessageId:  messageId,
+				Email:      recipient,
+				Type:       domain.EmailEventTypeDelivered,
+				OccurredAt: delivery.Timestamp,
+			})
+		}
+	}
+
+	return c.record(ctx, domain.EmailProviderSes, list)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewEmailEventRepository(db *gorm.DB) domain.EmailEventRepository {
+	db.AutoMigrate(&domain.EmailEvent{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Create(ctx context.Context, list []domain.EmailEvent) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&list).Error
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[EMAIL] "
+
+func NewEmailUseCase(
+	emailEventRepo domain.EmailEventRepository,
+	customerRepo domain.CustomerRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.EmailUseCase {
+	return &ucase{
+		emailEventRepo: emailEventRepo,
+		customerRepo:   customerRepo,
+		ids:            ids,
+		clock:          clock,
+		timeout:        timeout,
+	}
+}
+
+type ucase struct {
+	emailEventRepo domain.EmailEventRepository
+	customerRepo   domain.CustomerRepository
+	ids            domain.IdGenerator
+	clock          domain.Clock
+	timeout        time.Duration
+}
+
+func (u *ucase) RecordEmailEvents(ctx context.Context, provider domain.EmailProvider, in []domain.RecordEmailEvent) (res domain.EmailEventRun, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	now := u.clock.Now()
+	list := make([]domain.EmailEvent, 0, len(in))
+	for _, src := range in {
+		email := strings.ToLower(strings.TrimSpace(src.Email))
+		if email == "" || src.MessageId == "" {
+			continue
+		}
+
+		event := domain.EmailEvent{
+			Id:         u.ids.NewId(),
+			Provider:   provider,
+			MessageId:  src.MessageId,
+			Email:      email,
+			Type:       src.Type,
+			Permanent:  src.Permanent,
+			OccurredAt: src.OccurredAt,
+			CreatedAt:  now,
+		}
+		if reason := strings.TrimSpace(src.Reason); reason != "" {
+			if len(reason) > 1000 {
+				reason = reason[:1000]
+			}
+			event.Reason = &reason
+		}
+		list = append(list, event)
+	}
+	if len(list) == 0 {
+		return
+	}
+
+	err = u.emailEventRepo.Create(c, list)
+	if err != nil {
+		return
+	}
+	res.Recorded = int64(len(list))
+
+	// 발송 서비스는 순서를 보장하지 않아 일어난 순서로 적용
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].OccurredAt.Before(list[j].OccurredAt)
+	})
+
+	byEmail := make(map[string][]domain.EmailEvent)
+	emails := make([]string, 0, len(list))
+	for _, event := range list {
+		if _, ok := byEmail[event.Email]; !ok {
+			emails = append(emails, event.Email)
+		}
+		byEmail[event.Email] = append(byEmail[event.Email], event)
+	}
+
+	customers, err := u.customerRepo.FetchByEmails(c, emails)
+	if err != nil {
+		return
+	}
+
+	for i := range customers {
+		customer := &customers[i]
+		before := customer.EmailUndeliverable()
+
+		changed := false
+		for _, event := range byEmail[strings.ToLower(customer.Email)] {
+			if customer.ApplyEmailEvent(event) {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		err = u.customerRepo.Save(c, customer)
+		if err != nil {
+			return
+		}
+
+		switch after := customer.EmailUndeliverable(); {
+		case after && !before:
+			res.Flagged++
+			logx.From(ctx).WithField("userId", customer.Id).
+				WithField("provider", provider).
+				Info(tag, "customer email flagged undeliverable")
+		case !after && before:
+			res.Cleared++
+		}
+	}
+	return
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[EXPERIMENT] "
+)
+
+func NewExperimentController(useCase domain.ExperimentUseCase) *ExperimentController {
+	return &ExperimentController{useCase: useCase}
+}
+
+type ExperimentController struct {
+	useCase domain.ExperimentUseCase
+}
+
+type ExperimentAssignmentResponse struct {
+	ExperimentKey string `json:"experimentKey" validate:"required" example:"price-2021-11"`
+	Variant       string `json:"variant" validate:"required" example:"B"`
+	Price         uint32 `json:"price" validate:"required" example:"99000"`
+} // @name ExperimentAssignmentResponse
+
+// @Tags (Experiment) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 가격 실험 배정 정보
+// @Description 진행 중인 가격 실험별로 배정된 변형(variant)과 가격을 가져오는 기능, 요금제 목록 표시 시 사용, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} ExperimentAssignmentResponse "성공"
+// @Success 204 "진행 중인 실험 없음"
+// @Router /experiment/me [get]
+func (c *ExperimentController) getMyAssignments(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.GetMyAssignments(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("in", userId).
+			Error(tag, "getMyAssignments, unhandled error useCase.GetMyAssignments")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]ExperimentAssignmentResponse, len(list))
+	for i := range list {
+		res[i] = ExperimentAssignmentResponse{
+			ExperimentKey: list[i].ExperimentKey,
+			Variant:       list[i].Variant,
+			Price:         list[i].Price,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ExperimentController) Bind(e *echo.Echo) {
+	// CUSTOMER
+	e.GET("/experiment/me", echox.UserID(c.getMyAssignments),
+		middleware.RequireRole(domain.CustomerUserRole))
+
+	// ADMIN
+	e.POST("/experiment", c.createExperiment,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PATCH("/experiment/:key/active", c.setExperimentActive,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/experiment/:key/conversion", c.exportConversions,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/experiment/conversion", c.internalRecordConversion)
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type CreateExperimentVariantRequest struct {
+	// Key, 변형 키
+	Key string `json:"key" validate:"required,max=60" example:"B"`
+
+	// Weight, 배정 가중치
+	Weight uint8 `json:"weight" validate:"required,min=1" example:"50"`
+
+	// Price, 변형 가격
+	Price uint32 `json:"price" validate:"required" example:"99000"`
+} // @name CreateExperimentVariantRequest
+
+type CreateExperimentRequest struct {
+	// Key, 실험 키
+	Key string `json:"key" validate:"required,max=60" example:"price-2021-11"`
+
+	// Name, 실험 이름
+	Name string `json:"name" validate:"required,max=120" example:"11월 구독 가격 실험"`
+
+	// Variants, 변형 목록
+	Variants []CreateExperimentVariantRequest `json:"variants" validate:"required,min=2,dive"`
+} // @name CreateExperimentRequest
+
+type CreateExperimentResponse struct {
+	Id string `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name CreateExperimentResponse
+
+// @Tags (Experiment) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 가격 실험 생성
+// @Description 변형별 가중치와 가격으로 가격 실험을 생성하는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateExperimentRequest true "가격 실험 데이터 구조"
+// @Success 201 {object} CreateExperimentResponse "생성 완료"
+// @Failure 409 {object} domain.ErrorResponse "실험 키 중복"
+// @Router /experiment [post]
+func (c *ExperimentController) createExperiment(ctx echo.Context) error {
+	var req CreateExperimentRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create experiment, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.CreateExperimentInput{
+		Key:      req.Key,
+		Name:     req.Name,
+		Variants: make([]domain.CreateExperimentVariant, len(req.Variants)),
+	}
+	for i, v := range req.Variants {
+		in.Variants[i] = domain.CreateExperimentVariant{
+			Key:    v.Key,
+			Weight: v.Weight,
+			Price:  v.Price,
+		}
+	}
+	newId, err := c.useCase.CreateExperiment(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, CreateExperimentResponse{Id: newId.String()})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ItemExist)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "duplicated variant key"})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "createExperiment, unhandled error useCase.CreateExperiment")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type SetExperimentActiveRequest struct {
+	Key string `json:"-" param:"key" validate:"required" example:"price-2021-11"`
+
+	// Active, 진행 여부
+	Active *bool `json:"active" validate:"required" example:"false"`
+} // @name SetExperimentActiveRequest
+
+// @Tags (Experiment) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 가격 실험 진행 여부 변경
+// @Description 가격 실험을 시작하거나 종료하는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param key path string true "실험 키"
+// @Param requestBody body SetExperimentActiveRequest true "진행 여부 데이터 구조"
+// @Success 204 "변경 완료"
+// @Failure 404 {object} domain.ErrorResponse "실험 없음"
+// @Router /experiment/{key}/active [patch]
+func (c *ExperimentController) setExperimentActive(ctx echo.Context) error {
+	var req SetExperimentActiveRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "set experiment active, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.SetExperimentActive(ctx.Request().Context(), req.Key, *req.Active)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", req).
+			Error(tag, "setExperimentActive, unhandled error useCase.SetExperimentActive")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Experiment) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 가격 실험 전환 기록 내보내기
+// @Description 가격 실험 전환 기록을 분석용 CSV 로 내보내는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce text/csv
+// @Param key path string true "실험 키"
+// @Success 200 {string} string "CSV"
+// @Failure 404 {object} domain.ErrorResponse "실험 없음"
+// @Router /experiment/{key}/conversion [get]
+func (c *ExperimentController) exportConversions(ctx echo.Context) error {
+	key := ctx.Param("key")
+	list, err := c.useCase.FetchConversions(ctx.Request().Context(), key)
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("key", key).
+			Error(tag, "exportConversions, unhandled error useCase.FetchConversions")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+key+`.csv"`)
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	w.Write([]string{"customer_id", "variant", "reference", "amount", "created_at"})
+	for _, conversion := range list {
+		w.Write([]string{
+			conversion.CustomerId.String(),
+			conversion.Variant,
+			conversion.Reference,
+			strconv.FormatUint(uint64(conversion.Amount), 10),
+			conversion.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+func (c *ExperimentController) internalRecordConversion(ctx echo.Context) error {
+	var req struct {
+		ExperimentKey string `json:"experimentKey" validate:"required"`
+		Username      string `json:"username" validate:"required,email"`
+		Reference     string `json:"reference" validate:"required,max=120"`
+		Amount        uint32 `json:"amount"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "internalRecordConversion data binding error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	}
+
+	err = c.useCase.RecordConversion(ctx.Request().Context(), domain.RecordConversion{
+		ExperimentKey: req.ExperimentKey,
+		Username:      req.Username,
+		Reference:     req.Reference,
+		Amount:        req.Amount,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "internalRecordConversion, unhandled error useCase.RecordConversion")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewExperimentRepository(db *gorm.DB) domain.ExperimentRepository {
+	db.AutoMigrate(&domain.Experiment{}, &domain.ExperimentVariant{}, &domain.ExperimentConversion{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, experiment *domain.Experiment) error {
+	return gormx.Upsert(ctx, r.db, experiment)
+}
+
+func (r *repo) SaveConversion(ctx context.Context, conversion *domain.ExperimentConversion) error {
+	return r.db.WithContext(ctx).Create(conversion).Error
+}
+
+func (r *repo) GetByKey(ctx context.Context, key string) (res *domain.Experiment, err error) {
+	var entity domain.Experiment
+	err = r.db.WithContext(ctx).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB {
+			return db.Order("`key` asc")
+		}).
+		Where("`key` = ?", key).
+		First(&entity).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchActive(ctx context.Context) (list []domain.Experiment, err error) {
+	err = r.db.WithContext(ctx).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB {
+			return db.Order("`key` asc")
+		}).
+		Order("`created_at` asc").
+		Where("`active` = ?", true).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) ExistsConversion(ctx context.Context, experimentId uuid.UUID, reference string) (exists bool, err error) {
+	var cnt int64
+	err = r.db.WithContext(ctx).
+		Model(&domain.ExperimentConversion{}).
+		Where("`experiment_id` = ? AND `reference` = ?", experimentId, reference).
+		Count(&cnt).Error
+	exists = cnt > 0
+	return
+}
+
+func (r *repo) FetchConversions(ctx context.Context, experimentId uuid.UUID) (list []domain.ExperimentConversion, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`created_at` asc").
+		Where("`experiment_id` = ?", experimentId).
+		Find(&list).Error
+	return
+}
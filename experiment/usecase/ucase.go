@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewExperimentUseCase(
+	experimentRepo domain.ExperimentRepository,
+	userRepo domain.UserRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.ExperimentUseCase {
+	return &ucase{
+		experimentRepo: experimentRepo,
+		userRepo:       userRepo,
+		ids:            ids,
+		clock:          clock,
+		timeout:        timeout,
+	}
+}
+
+type ucase struct {
+	experimentRepo domain.ExperimentRepository
+	userRepo       domain.UserRepository
+	ids            domain.IdGenerator
+	clock          domain.Clock
+	timeout        time.Duration
+}
+
+func (u *ucase) CreateExperiment(ctx context.Context, in domain.CreateExperimentInput) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	exists, err := u.experimentRepo.GetByKey(c, in.Key)
+	if err != nil {
+		return
+	}
+
+	if exists != nil {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	variants := make([]domain.ExperimentVariant, len(in.Variants))
+	keys := make(map[string]bool, len(in.Variants))
+	for i, v := range in.Variants {
+		if keys[v.Key] {
+			err = domain.ErrWeirdData
+			return
+		}
+		keys[v.Key] = true
+
+		variants[i] = domain.ExperimentVariant{
+			Key:    v.Key,
+			Weight: v.Weight,
+			Price:  v.Price,
+		}
+	}
+
+	experiment := domain.CreateExperiment(domain.CreateExperimentOption{
+		Id:       u.ids.NewId(),
+		Key:      in.Key,
+		Name:     in.Name,
+		Variants: variants,
+		Now:      u.clock.Now(),
+	})
+
+	err = u.experimentRepo.Save(c, &experiment)
+	if err != nil {
+		return
+	}
+
+	newId = experiment.Id
+	return
+}
+
+func (u *ucase) SetExperimentActive(ctx context.Context, key string, active bool) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	experiment, err := u.experimentRepo.GetByKey(c, key)
+	if err != nil {
+		return
+	}
+
+	if experiment == nil {
+		return domain.ErrItemNotFound
+	}
+
+	experiment.SetActive(active, u.clock.Now())
+	return u.experimentRepo.Save(c, experiment)
+}
+
+// RecordConversion 결제 완료 시 배정된 변형으로 전환 기록, 같은 reference 는 한 번만 기록
+func (u *ucase) RecordConversion(ctx context.Context, in domain.RecordConversion) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		experiment *domain.Experiment
+		user       *domain.User
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		experiment, err = u.experimentRepo.GetByKey(gc, in.ExperimentKey)
+		if err != nil {
+			return
+		}
+
+		if experiment == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		user, err = u.userRepo.GetByUsername(gc, in.Username)
+		if err != nil {
+			return
+		}
+
+		if user == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	exists, err := u.experimentRepo.ExistsConversion(c, experiment.Id, in.Reference)
+	if err != nil || exists {
+		return
+	}
+
+	variant := experiment.AssignVariant(user.Id)
+	if variant == nil {
+		return domain.ErrWeirdData
+	}
+
+	conversion := domain.ExperimentConversion{
+		Id:           u.ids.NewId(),
+		ExperimentId: experiment.Id,
+		CustomerId:   user.Id,
+		Variant:      variant.Key,
+		Reference:    in.Reference,
+		Amount:       in.Amount,
+		CreatedAt:    u.clock.Now(),
+	}
+	return u.experimentRepo.SaveConversion(c, &conversion)
+}
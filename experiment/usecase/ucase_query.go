@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) GetMyAssignments(ctx context.Context, customerId uuid.UUID) (list []domain.ExperimentAssignment, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	experiments, err := u.experimentRepo.FetchActive(c)
+	if err != nil {
+		return
+	}
+
+	for _, experiment := range experiments {
+		variant := experiment.AssignVariant(customerId)
+		if variant == nil {
+			continue
+		}
+
+		list = append(list, domain.ExperimentAssignment{
+			ExperimentKey: experiment.Key,
+			Variant:       variant.Key,
+			Price:         variant.Price,
+		})
+	}
+	return
+}
+
+func (u *ucase) FetchConversions(ctx context.Context, key string) (list []domain.ExperimentConversion, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	experiment, err := u.experimentRepo.GetByKey(c, key)
+	if err != nil {
+		return
+	}
+
+	if experiment == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	return u.experimentRepo.FetchConversions(c, experiment.Id)
+}
@@ -0,0 +1,420 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[FILE] "
+)
+
+// NewFileController maxUploadSize 서버를 거쳐 올리는 파일 크기 제한(bytes)
+func NewFileController(
+	useCase domain.FileUseCase,
+	uploadUseCase domain.FileUploadUseCase,
+	previewUseCase domain.FilePreviewUseCase,
+	maxUploadSize int64,
+) *FileController {
+	return &FileController{
+		useCase:        useCase,
+		uploadUseCase:  uploadUseCase,
+		previewUseCase: previewUseCase,
+		maxUploadSize:  maxUploadSize,
+	}
+}
+
+type FileController struct {
+	useCase        domain.FileUseCase
+	uploadUseCase  domain.FileUploadUseCase
+	previewUseCase domain.FilePreviewUseCase
+	maxUploadSize  int64
+}
+
+func (c *FileController) Bind(e *echo.Echo) {
+	e.POST("/file", echox.UserID(c.uploadFile), middleware.RequireAuth())
+	e.GET("/file/:fileId", echox.UserID(c.getFileDownload), middleware.RequireAuth())
+	e.DELETE("/file/:fileId", echox.UserID(c.deleteFile), middleware.RequireAuth())
+	e.PUT("/file/:fileId/order", echox.UserID(c.attachFileToOrder), middleware.RequireAuth())
+
+	e.POST("/file/upload", echox.UserID(c.initiateUpload), middleware.RequireAuth())
+	e.GET("/file/upload/:uploadId", echox.UserID(c.getUpload), middleware.RequireAuth())
+	e.POST("/file/upload/:uploadId/part/:partNumber", echox.UserID(c.signUploadPart), middleware.RequireAuth())
+	e.POST("/file/upload/:uploadId/complete", echox.UserID(c.completeUpload), middleware.RequireAuth())
+	e.DELETE("/file/upload/:uploadId", echox.UserID(c.abortUpload), middleware.RequireAuth())
+
+	e.POST("/upload/presign", echox.UserID(c.presignUpload), middleware.RequireAuth())
+	e.POST("/upload/complete", echox.UserID(c.completePresignedUpload), middleware.RequireAuth())
+
+	// ===== ADMIN =====
+	e.GET("/dashboard/storage", c.getStorageReport,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/dashboard/file/orphan", c.getOrphanFileReport,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/file/upload/abort-stale", c.internalAbortStaleUploads)
+	e.POST("/internal/file/orphan/cleanup", c.internalCleanupOrphanFiles)
+	e.POST("/internal/file/preview/generate", c.internalGenerateFilePreviews)
+}
+
+type FileResponse struct {
+	Id      uuid.UUID `json:"fileId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OwnerId uuid.UUID `json:"ownerId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// OrderId, 연결된 의뢰, 연결하지 않으면 보관 일수가 지난 뒤 자동 삭제
+	OrderId     *uuid.UUID `json:"orderId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string     `json:"name" validate:"required" example:"source.mp4"`
+	ContentType string     `json:"contentType" validate:"required" example:"video/mp4"`
+	Size        int64      `json:"size" validate:"required" example:"10485760"`
+	// Checksum, SHA-256 (hex), /upload/presign 으로 올린 파일만, 나머지는 빈 문자열
+	Checksum  string    `json:"checksum" validate:"required" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name FileResponse
+
+func fileResponseOf(info domain.FileInfo) FileResponse {
+	return FileResponse{
+		Id:          info.Id,
+		OwnerId:     info.OwnerId,
+		OrderId:     info.OrderId,
+		Name:        info.Name,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		Checksum:    info.Checksum,
+		CreatedAt:   info.CreatedAt,
+	}
+}
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 파일 올리기
+// @Description multipart/form-data 의 file 필드로 파일을 올림, 크기 제한을 넘으면 413 (큰 파일은 /file/upload 사용)
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "올릴 파일"
+// @Success 201 {object} FileResponse "업로드 완료"
+// @Failure 403 {object} domain.ErrorResponse "고객 저장 한도 초과 (F-2)"
+// @Failure 413 {object} domain.ErrorResponse "파일 크기 초과"
+// @Router /file [post]
+func (c *FileController) uploadFile(ctx echo.Context, userId uuid.UUID) error {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "uploadFile, form file error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if header.Size > c.maxUploadSize {
+		return ctx.JSON(http.StatusRequestEntityTooLarge, domain.ErrorResponse{Message: "file too large"})
+	}
+
+	body, err := header.Open()
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "uploadFile, form file open error")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+	defer body.Close()
+
+	contentType := header.Header.Get(echo.HeaderContentType)
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+
+	info, err := c.useCase.UploadFile(ctx.Request().Context(), domain.UploadFile{
+		OwnerId:     userId,
+		Name:        header.Filename,
+		ContentType: contentType,
+		Size:        header.Size,
+		Body:        body,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, fileResponseOf(info))
+	case domain.ErrStorageQuotaExceeded:
+		return ctx.JSON(http.StatusForbidden, domain.StorageQuotaExceededResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "uploadFile, unhandled error useCase.UploadFile")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type FileDownloadResponse struct {
+	FileResponse
+	URL       string    `json:"url" validate:"required" example:"https://bucket.s3.ap-northeast-2.amazonaws.com/file/550e8400-e29b-41d4-a716-446655440000.mp4?X-Amz-Signature=..."`
+	ExpiresAt time.Time `json:"expiresAt" validate:"required" example:"2021-10-27T04:59:18+00:00"`
+} // @name FileDownloadResponse
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 파일 다운로드 URL
+// @Description 올린 사람 또는 관리자만, 인증 없이 15분 동안 쓸 수 있는 URL 반환
+// @Accept json
+// @Produce json
+// @Param fileId path string true "파일 아이디(UUID)"
+// @Success 200 {object} FileDownloadResponse "성공"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없거나 삭제된 파일"
+// @Router /file/{fileId} [get]
+func (c *FileController) getFileDownload(ctx echo.Context, userId uuid.UUID) error {
+	fileId, _ := uuid.Parse(ctx.Param("fileId"))
+	res, err := c.useCase.GetFileDownload(ctx.Request().Context(), domain.FileAccess{
+		FileId:      fileId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, FileDownloadResponse{
+			FileResponse: fileResponseOf(res.FileInfo),
+			URL:          res.URL,
+			ExpiresAt:    res.ExpiresAt,
+		})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "file not found"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "getFileDownload, unhandled error useCase.GetFileDownload")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 파일 삭제
+// @Description 올린 사람 또는 관리자만
+// @Accept json
+// @Produce json
+// @Param fileId path string true "파일 아이디(UUID)"
+// @Success 204 "삭제 완료"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없거나 삭제된 파일"
+// @Router /file/{fileId} [delete]
+func (c *FileController) deleteFile(ctx echo.Context, userId uuid.UUID) error {
+	fileId, _ := uuid.Parse(ctx.Param("fileId"))
+	err := c.useCase.DeleteFile(ctx.Request().Context(), domain.FileAccess{
+		FileId:      fileId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "file not found"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "deleteFile, unhandled error useCase.DeleteFile")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type StorageReportRequest struct {
+	Limit int `query:"limit" validate:"omitempty,min=1,max=500"`
+}
+
+type CustomerStorageResponse struct {
+	UserId uuid.UUID `json:"userId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name   string    `json:"name" validate:"required" example:"(대충 고객 이름)"`
+	Files  int64     `json:"files" validate:"required" example:"12"`
+	// Used, 사용량(bytes)
+	Used int64 `json:"used" validate:"required" example:"53687091200"`
+	// Quota, 한도(bytes), 사용 중인 이용권 기준
+	Quota int64 `json:"quota" validate:"required" example:"85899345920"`
+} // @name CustomerStorageResponse
+
+type StorageReportResponse struct {
+	TotalFiles int64                     `json:"totalFiles" validate:"required" example:"1200"`
+	TotalBytes int64                     `json:"totalBytes" validate:"required" example:"5497558138880"`
+	Customers  []CustomerStorageResponse `json:"customers" validate:"required"`
+} // @name StorageReportResponse
+
+// @Tags (File) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 저장 용량 리포트
+// @Description 전체 파일 용량과 사용량이 많은 고객 순 사용량/한도, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param limit query int false "고객 수, 기본 50 최대 500"
+// @Success 200 {object} StorageReportResponse "성공"
+// @Router /dashboard/storage [get]
+func (c *FileController) getStorageReport(ctx echo.Context) error {
+	var req StorageReportRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get storage report, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+	if req.Limit == 0 {
+		req.Limit = domain.StorageReportDefaultLimit
+	}
+
+	report, err := c.useCase.GetStorageReport(ctx.Request().Context(), req.Limit)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getStorageReport, unhandled error useCase.GetStorageReport")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := StorageReportResponse{
+		TotalFiles: report.TotalFiles,
+		TotalBytes: report.TotalBytes,
+		Customers:  make([]CustomerStorageResponse, len(report.Customers)),
+	}
+	for i, customer := range report.Customers {
+		res.Customers[i] = CustomerStorageResponse{
+			UserId: customer.UserId,
+			Name:   customer.Name,
+			Files:  customer.Files,
+			Used:   customer.Used,
+			Quota:  customer.Quota,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type AttachFileToOrderRequest struct {
+	FileId uuid.UUID `param:"fileId" json:"-" validate:"required"`
+
+	// OrderId, 연결할 의뢰 아이디
+	OrderId uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name AttachFileToOrderRequest
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 파일을 의뢰에 연결
+// @Description 파일을 볼 수 있고 의뢰한 고객 본인이거나 관리자만, 연결하지 않은 파일은 보관 일수(storage.orphan_days)가 지나면 자동 삭제
+// @Accept json
+// @Produce json
+// @Param fileId path string true "파일 아이디(UUID)"
+// @Param requestBody body AttachFileToOrderRequest true "연결할 의뢰"
+// @Success 204 "연결 완료"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 파일 또는 의뢰"
+// @Router /file/{fileId}/order [put]
+func (c *FileController) attachFileToOrder(ctx echo.Context, userId uuid.UUID) error {
+	var req AttachFileToOrderRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "attach file to order, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.AttachFileToOrder(ctx.Request().Context(), domain.AttachFileToOrder{
+		FileAccess: domain.FileAccess{
+			FileId:      req.FileId,
+			RequesterId: userId,
+		},
+		OrderId: req.OrderId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "attachFileToOrder, unhandled error useCase.AttachFileToOrder")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type OrphanFileReportRequest struct {
+	Limit int `query:"limit" validate:"omitempty,min=1,max=500"`
+}
+
+type OrphanFileReportResponse struct {
+	// Cutoff, 이 시각 전에 올리고 의뢰에 연결하지 않은 파일이 삭제 대상, 정리를 끈 경우 null
+	Cutoff *time.Time     `json:"cutoff" example:"2021-10-13T04:44:18+00:00"`
+	Files  int64          `json:"files" validate:"required" example:"42"`
+	Bytes  int64          `json:"bytes" validate:"required" example:"10737418240"`
+	Oldest []FileResponse `json:"oldest" validate:"required"`
+} // @name OrphanFileReportResponse
+
+// @Tags (File) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 정리 대상 파일 미리보기
+// @Description 다음 정리 때 삭제될 파일 수, 용량과 오래된 순 일부 (삭제하지 않음), 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param limit query int false "파일 수, 기본 50 최대 500"
+// @Success 200 {object} OrphanFileReportResponse "성공"
+// @Router /dashboard/file/orphan [get]
+func (c *FileController) getOrphanFileReport(ctx echo.Context) error {
+	var req OrphanFileReportRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get orphan file report, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+	if req.Limit == 0 {
+		req.Limit = domain.FileOrphanReportDefaultLimit
+	}
+
+	report, err := c.useCase.GetOrphanFileReport(ctx.Request().Context(), req.Limit)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getOrphanFileReport, unhandled error useCase.GetOrphanFileReport")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := OrphanFileReportResponse{
+		Cutoff: report.Cutoff,
+		Files:  report.Files,
+		Bytes:  report.Bytes,
+		Oldest: make([]FileResponse, len(report.Oldest)),
+	}
+	for i := range report.Oldest {
+		res.Oldest[i] = fileResponseOf(report.Oldest[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+func (c *FileController) internalCleanupOrphanFiles(ctx echo.Context) error {
+	res, err := c.useCase.CleanupOrphanFiles(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalCleanupOrphanFiles, unhandled error useCase.CleanupOrphanFiles")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("deleted", res.Deleted).
+		WithField("bytes", res.Bytes).
+		WithField("failed", res.Failed).
+		Info(tag, "cleanup orphan files")
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"cutoff":  res.Cutoff,
+		"deleted": res.Deleted,
+		"bytes":   res.Bytes,
+		"failed":  res.Failed,
+	})
+}
+
+func (c *FileController) internalGenerateFilePreviews(ctx echo.Context) error {
+	res, err := c.previewUseCase.GenerateFilePreviews(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalGenerateFilePreviews, unhandled error previewUseCase.GenerateFilePreviews")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("generated", res.Generated).
+		WithField("failed", res.Failed).
+		Info(tag, "generate file previews")
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"generated": res.Generated,
+		"failed":    res.Failed,
+	})
+}
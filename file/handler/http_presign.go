@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type PresignUploadRequest struct {
+	// Name, 파일 이름
+	Name string `json:"name" validate:"required,max=255" example:"source.mp4"`
+
+	// ContentType, 파일 형식, PUT 할 때 Content-Type 헤더로 그대로 지정
+	ContentType string `json:"contentType" validate:"required,max=100" example:"video/mp4"`
+
+	// Size, 파일 크기(bytes), 최대 5GB
+	Size int64 `json:"size" validate:"required,min=1" example:"1073741824"`
+
+	// Checksum, 올릴 파일의 SHA-256 (hex), URL 서명에 들어가서 내용이 다르면 저장소가 PUT 을 거부
+	Checksum string `json:"checksum" validate:"required,len=64,hexadecimal" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+} // @name PresignUploadRequest
+
+type PresignUploadResponse struct {
+	Id          uuid.UUID `json:"uploadId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string    `json:"name" validate:"required" example:"source.mp4"`
+	ContentType string    `json:"contentType" validate:"required" example:"video/mp4"`
+	Size        int64     `json:"size" validate:"required" example:"1073741824"`
+
+	// URL, 파일을 PUT 할 URL
+	URL string `json:"url" validate:"required" example:"https://bucket.s3.ap-northeast-2.amazonaws.com/file/550e8400-e29b-41d4-a716-446655440000.mp4?X-Amz-Signature=..."`
+	// Headers, PUT 할 때 그대로 같이 보내야 하는 헤더
+	Headers map[string]string `json:"headers" example:"X-Amz-Checksum-Sha256:n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="`
+	// URLExpiresAt, 이때까지 PUT 을 시작해야함
+	URLExpiresAt time.Time `json:"urlExpiresAt" validate:"required" example:"2021-10-27T05:44:18+00:00"`
+	// ExpiresAt, 이때까지 완료하지 않으면 자동 중단
+	ExpiresAt time.Time `json:"expiresAt" validate:"required" example:"2021-10-28T04:44:18+00:00"`
+} // @name PresignUploadResponse
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 파일 바로 올리기 URL
+// @Description 원본 영상을 서버를 거치지 않고 저장소에 바로 올리는 1시간짜리 PUT URL, 5GB 까지 (더 크면 /file/upload 사용)
+// @Description PUT 이 끝나면 /upload/complete 로 완료 요청, 24시간 안에 완료하지 않으면 자동 중단
+// @Accept json
+// @Produce json
+// @Param requestBody body PresignUploadRequest true "올릴 파일 정보"
+// @Success 201 {object} PresignUploadResponse "발급됨"
+// @Failure 400 {object} domain.ErrorResponse "크기 초과 또는 잘못된 체크섬"
+// @Failure 403 {object} domain.ErrorResponse "고객 저장 한도 초과 (F-2), 진행 중인 업로드 크기 포함"
+// @Router /upload/presign [post]
+func (c *FileController) presignUpload(ctx echo.Context, userId uuid.UUID) error {
+	var req PresignUploadRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "presign upload, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.uploadUseCase.PresignFileUpload(ctx.Request().Context(), domain.InitiateFileUpload{
+		OwnerId:     userId,
+		Name:        req.Name,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		Checksum:    req.Checksum,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, PresignUploadResponse{
+			Id:           res.Id,
+			Name:         res.Name,
+			ContentType:  res.ContentType,
+			Size:         res.Size,
+			URL:          res.URL,
+			Headers:      res.Headers,
+			URLExpiresAt: res.URLExpiresAt,
+			ExpiresAt:    res.ExpiresAt,
+		})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "file too large or invalid checksum"})
+	case domain.ErrStorageQuotaExceeded:
+		return ctx.JSON(http.StatusForbidden, domain.StorageQuotaExceededResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "presignUpload, unhandled error useCase.PresignFileUpload")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type CompletePresignedUploadRequest struct {
+	UploadId uuid.UUID `json:"uploadId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name CompletePresignedUploadRequest
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 파일 바로 올리기 완료
+// @Description 저장소에 올라간 크기, 체크섬을 확인하고 파일로 등록, 반환된 fileId 는 uploadId 와 같음
+// @Accept json
+// @Produce json
+// @Param requestBody body CompletePresignedUploadRequest true "완료 데이터 구조"
+// @Success 201 {object} FileResponse "업로드 완료"
+// @Failure 400 {object} domain.ErrorResponse "나눠 올리기 업로드, 크기 또는 체크섬 불일치 (F-3, 업로드 중단됨)"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 업로드 또는 아직 올라가지 않음"
+// @Failure 409 {object} domain.ErrorResponse "완료, 중단되었거나 기한이 지난 업로드 (F-1)"
+// @Router /upload/complete [post]
+func (c *FileController) completePresignedUpload(ctx echo.Context, userId uuid.UUID) error {
+	var req CompletePresignedUploadRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "complete presigned upload, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	info, err := c.uploadUseCase.CompletePresignedFileUpload(ctx.Request().Context(), domain.FileUploadAccess{
+		UploadId:    req.UploadId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, fileResponseOf(info))
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "not a single upload"})
+	case domain.ErrUploadMismatch:
+		return ctx.JSON(http.StatusBadRequest, domain.UploadMismatchResponse)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "upload not found"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	case domain.ErrUploadClosed:
+		return ctx.JSON(http.StatusConflict, domain.UploadClosedResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "completePresignedUpload, unhandled error useCase.CompletePresignedFileUpload")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
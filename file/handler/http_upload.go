@@ -0,0 +1,321 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type InitiateUploadRequest struct {
+	// Name, 파일 이름
+	Name string `json:"name" validate:"required,max=255" example:"source.mp4"`
+
+	// ContentType, 파일 형식
+	ContentType string `json:"contentType" validate:"required,max=100" example:"video/mp4"`
+
+	// Size, 파일 크기(bytes), 최대 5TB
+	Size int64 `json:"size" validate:"required,min=1" example:"10737418240"`
+} // @name InitiateUploadRequest
+
+type UploadResponse struct {
+	Id          uuid.UUID `json:"uploadId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string    `json:"name" validate:"required" example:"source.mp4"`
+	ContentType string    `json:"contentType" validate:"required" example:"video/mp4"`
+	Size        int64     `json:"size" validate:"required" example:"10737418240"`
+
+	// PartSize, 조각 크기(bytes), 마지막 조각만 작음
+	PartSize int64 `json:"partSize" validate:"required" example:"16777216"`
+	// PartCount, 조각 수, 조각 번호는 1 부터
+	PartCount int `json:"partCount" validate:"required" example:"640"`
+
+	// Status, 상태
+	// * UPLOADING - 올리는 중
+	// * COMPLETED - 완료
+	// * ABORTED - 중단
+	Status string `json:"status" validate:"required" example:"UPLOADING" enums:"UPLOADING,COMPLETED,ABORTED"`
+
+	// SignedParts, URL 을 발급한 조각 번호 (이어 올리기용)
+	SignedParts []int     `json:"signedParts" validate:"required" example:"1,2,3"`
+	CreatedAt   time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+
+	// ExpiresAt, 이때까지 완료하지 않으면 자동 중단
+	ExpiresAt time.Time `json:"expiresAt" validate:"required" example:"2021-10-28T04:44:18+00:00"`
+} // @name UploadResponse
+
+func uploadResponseOf(info domain.FileUploadInfo) UploadResponse {
+	return UploadResponse{
+		Id:          info.Id,
+		Name:        info.Name,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		PartSize:    info.PartSize,
+		PartCount:   info.PartCount,
+		Status:      string(info.Status),
+		SignedParts: info.SignedParts,
+		CreatedAt:   info.CreatedAt,
+		ExpiresAt:   info.ExpiresAt,
+	}
+}
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 큰 파일 나눠 올리기 시작
+// @Description 원본 영상처럼 큰 파일을 조각으로 나눠 저장소에 바로 올리는 업로드 시작
+// @Description 조각마다 /file/upload/{uploadId}/part/{partNumber} 로 받은 URL 에 PUT 하고 응답 ETag 헤더를 모아서 완료 요청
+// @Description 24시간 안에 완료하지 않으면 자동 중단
+// @Accept json
+// @Produce json
+// @Param requestBody body InitiateUploadRequest true "업로드 시작 데이터 구조"
+// @Success 201 {object} UploadResponse "시작됨"
+// @Failure 400 {object} domain.ErrorResponse "크기 초과"
+// @Failure 403 {object} domain.ErrorResponse "고객 저장 한도 초과 (F-2), 진행 중인 업로드 크기 포함"
+// @Router /file/upload [post]
+func (c *FileController) initiateUpload(ctx echo.Context, userId uuid.UUID) error {
+	var req InitiateUploadRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "initiate upload, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	info, err := c.uploadUseCase.InitiateFileUpload(ctx.Request().Context(), domain.InitiateFileUpload{
+		OwnerId:     userId,
+		Name:        req.Name,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, uploadResponseOf(info))
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "file too large"})
+	case domain.ErrStorageQuotaExceeded:
+		return ctx.JSON(http.StatusForbidden, domain.StorageQuotaExceededResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "initiateUpload, unhandled error useCase.InitiateFileUpload")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 나눠 올리기 상태
+// @Description 시작한 사람만, 끊긴 업로드를 이어갈 때 사용
+// @Accept json
+// @Produce json
+// @Param uploadId path string true "업로드 아이디(UUID)"
+// @Success 200 {object} UploadResponse "성공"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 업로드"
+// @Router /file/upload/{uploadId} [get]
+func (c *FileController) getUpload(ctx echo.Context, userId uuid.UUID) error {
+	uploadId, _ := uuid.Parse(ctx.Param("uploadId"))
+	info, err := c.uploadUseCase.GetFileUpload(ctx.Request().Context(), domain.FileUploadAccess{
+		UploadId:    uploadId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, uploadResponseOf(info))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "upload not found"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "getUpload, unhandled error useCase.GetFileUpload")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type SignUploadPartRequest struct {
+	UploadId   uuid.UUID `param:"uploadId" json:"-" validate:"required"`
+	PartNumber int       `param:"partNumber" json:"-" validate:"required,min=1,max=10000"`
+}
+
+type UploadPartResponse struct {
+	PartNumber int `json:"partNumber" validate:"required" example:"1"`
+	// Size, 이 조각에 올릴 크기(bytes)
+	Size int64 `json:"size" validate:"required" example:"16777216"`
+	// URL, 조각을 PUT 할 URL, 응답의 ETag 헤더를 완료 요청에 넘김
+	URL       string    `json:"url" validate:"required" example:"https://bucket.s3.ap-northeast-2.amazonaws.com/file/550e8400-e29b-41d4-a716-446655440000.mp4?partNumber=1&uploadId=..."`
+	ExpiresAt time.Time `json:"expiresAt" validate:"required" example:"2021-10-27T05:44:18+00:00"`
+} // @name UploadPartResponse
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 조각 업로드 URL
+// @Description 조각 하나를 올릴 수 있는 1시간짜리 URL, 같은 조각을 다시 받으면 덮어씀
+// @Accept json
+// @Produce json
+// @Param uploadId path string true "업로드 아이디(UUID)"
+// @Param partNumber path int true "조각 번호 (1 ~ partCount)"
+// @Success 200 {object} UploadPartResponse "성공"
+// @Failure 400 {object} domain.ErrorResponse "조각 번호가 범위 밖"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 업로드"
+// @Failure 409 {object} domain.ErrorResponse "완료, 중단되었거나 기한이 지난 업로드 (F-1)"
+// @Router /file/upload/{uploadId}/part/{partNumber} [post]
+func (c *FileController) signUploadPart(ctx echo.Context, userId uuid.UUID) error {
+	var req SignUploadPartRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "sign upload part, request bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.uploadUseCase.SignFileUploadPart(ctx.Request().Context(), domain.SignFileUploadPart{
+		FileUploadAccess: domain.FileUploadAccess{
+			UploadId:    req.UploadId,
+			RequesterId: userId,
+		},
+		PartNumber: req.PartNumber,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, UploadPartResponse{
+			PartNumber: res.PartNumber,
+			Size:       res.Size,
+			URL:        res.URL,
+			ExpiresAt:  res.ExpiresAt,
+		})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "part number out of range"})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "upload not found"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	case domain.ErrUploadClosed:
+		return ctx.JSON(http.StatusConflict, domain.UploadClosedResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "signUploadPart, unhandled error useCase.SignFileUploadPart")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UploadPartRequest struct {
+	PartNumber int    `json:"partNumber" validate:"required,min=1,max=10000" example:"1"`
+	ETag       string `json:"etag" validate:"required,max=100" example:"\"b54357faf0632cce46e942fa68356b38\""`
+} // @name UploadPartRequest
+
+type CompleteUploadRequest struct {
+	UploadId uuid.UUID `param:"uploadId" json:"-" validate:"required"`
+
+	// Parts, 모든 조각의 번호와 PUT 응답 ETag
+	Parts []UploadPartRequest `json:"parts" validate:"required,min=1,max=10000,dive"`
+} // @name CompleteUploadRequest
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 나눠 올리기 완료
+// @Description 조각을 이어 붙여 파일로 등록, 반환된 fileId 는 uploadId 와 같음
+// @Accept json
+// @Produce json
+// @Param uploadId path string true "업로드 아이디(UUID)"
+// @Param requestBody body CompleteUploadRequest true "완료 데이터 구조"
+// @Success 201 {object} FileResponse "업로드 완료"
+// @Failure 400 {object} domain.ErrorResponse "조각 누락, ETag 불일치 또는 크기 불일치 (F-3, 업로드 중단됨)"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 업로드"
+// @Failure 409 {object} domain.ErrorResponse "완료, 중단되었거나 기한이 지난 업로드 (F-1)"
+// @Router /file/upload/{uploadId}/complete [post]
+func (c *FileController) completeUpload(ctx echo.Context, userId uuid.UUID) error {
+	var req CompleteUploadRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "complete upload, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	parts := make([]domain.BlobPart, len(req.Parts))
+	for i, part := range req.Parts {
+		parts[i] = domain.BlobPart{Number: part.PartNumber, ETag: part.ETag}
+	}
+
+	info, err := c.uploadUseCase.CompleteFileUpload(ctx.Request().Context(), domain.CompleteFileUpload{
+		FileUploadAccess: domain.FileUploadAccess{
+			UploadId:    req.UploadId,
+			RequesterId: userId,
+		},
+		Parts: parts,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, fileResponseOf(info))
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "invalid parts"})
+	case domain.ErrUploadMismatch:
+		return ctx.JSON(http.StatusBadRequest, domain.UploadMismatchResponse)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "upload not found"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	case domain.ErrUploadClosed:
+		return ctx.JSON(http.StatusConflict, domain.UploadClosedResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "completeUpload, unhandled error useCase.CompleteFileUpload")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (File) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 나눠 올리기 중단
+// @Description 올린 조각을 모두 삭제
+// @Accept json
+// @Produce json
+// @Param uploadId path string true "업로드 아이디(UUID)"
+// @Success 204 "중단됨"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 업로드"
+// @Failure 409 {object} domain.ErrorResponse "이미 완료, 중단된 업로드 (F-1)"
+// @Router /file/upload/{uploadId} [delete]
+func (c *FileController) abortUpload(ctx echo.Context, userId uuid.UUID) error {
+	uploadId, _ := uuid.Parse(ctx.Param("uploadId"))
+	err := c.uploadUseCase.AbortFileUpload(ctx.Request().Context(), domain.FileUploadAccess{
+		UploadId:    uploadId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "upload not found"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	case domain.ErrUploadClosed:
+		return ctx.JSON(http.StatusConflict, domain.UploadClosedResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "abortUpload, unhandled error useCase.AbortFileUpload")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type AbortStaleUploadsResponse struct {
+	Aborted int `json:"aborted"`
+}
+
+func (c *FileController) internalAbortStaleUploads(ctx echo.Context) error {
+	aborted, err := c.uploadUseCase.AbortStaleFileUploads(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalAbortStaleUploads, unhandled error useCase.AbortStaleFileUploads")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("aborted", aborted).Info(tag, "abort stale uploads")
+	return ctx.JSON(http.StatusOK, AbortStaleUploadsResponse{Aborted: aborted})
+}
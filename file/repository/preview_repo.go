@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewFilePreviewRepository(db *gorm.DB) domain.FilePreviewRepository {
+	db.AutoMigrate(&domain.FilePreview{})
+	return &previewRepo{db: db}
+}
+
+type previewRepo struct {
+	db *gorm.DB
+}
+
+func (r *previewRepo) Save(ctx context.Context, preview *domain.FilePreview) error {
+	return gormx.Upsert(ctx, r.db, preview)
+}
+
+func (r *previewRepo) GetByFileId(ctx context.Context, fileId uuid.UUID) (preview *domain.FilePreview, err error) {
+	var entity domain.FilePreview
+	err = r.db.WithContext(ctx).First(&entity, fileId).Error
+	if err == nil {
+		preview = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *previewRepo) FetchPending(ctx context.Context, limit int) (list []domain.FilePreview, err error) {
+	err = r.db.WithContext(ctx).
+		Where("status = ?", domain.FilePreviewStatusPending).
+		Order("created_at").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewFileRepository(db *gorm.DB) domain.FileRepository {
+	db.AutoMigrate(&domain.File{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.FileTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(fileRepo domain.FileTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) Save(ctx context.Context, file *domain.File) error {
+	return gormx.Upsert(ctx, r.db, file)
+}
+
+func (r *repo) GetById(ctx context.Context, fileId uuid.UUID) (file *domain.File, err error) {
+	var entity domain.File
+	err = r.db.WithContext(ctx).First(&entity, fileId).Error
+	if err == nil {
+		file = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetByKey(ctx context.Context, key string) (file *domain.File, err error) {
+	var entity domain.File
+	err = r.db.WithContext(ctx).Where("`key` = ?", key).First(&entity).Error
+	if err == nil {
+		file = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) SumSizeByOwnerId(ctx context.Context, ownerId uuid.UUID) (size int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.File{}).
+		Select("COALESCE(SUM(`size`), 0)").
+		Where("`owner_id` = ? AND `deleted_at` IS NULL", ownerId).
+		Scan(&size).Error
+	return
+}
+
+func (r *repo) GetTotalUsage(ctx context.Context) (res domain.FileOwnerUsage, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.File{}).
+		Select("COUNT(*) AS `files`, COALESCE(SUM(`size`), 0) AS `bytes`").
+		Where("`deleted_at` IS NULL").
+		Scan(&res).Error
+	return
+}
+
+func (r *repo) FetchCustomerUsage(ctx context.Context, limit int) (list []domain.FileOwnerUsage, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.File{}).
+		Select("`file`.`owner_id`, COUNT(*) AS `files`, SUM(`file`.`size`) AS `bytes`").
+		Joins("JOIN `customer` ON `customer`.`id` = `file`.`owner_id`").
+		Where("`file`.`deleted_at` IS NULL").
+		Group("`file`.`owner_id`").
+		Order("`bytes` DESC").
+		Limit(limit).
+		Scan(&list).Error
+	return
+}
+
+func (r *repo) orphans(ctx context.Context, before time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&domain.File{}).
+		Where("`order_id` IS NULL AND `deleted_at` IS NULL AND `created_at` < ?", before)
+}
+
+func (r *repo) GetOrphanUsage(ctx context.Context, before time.Time) (res domain.FileOwnerUsage, err error) {
+	err = r.orphans(ctx, before).
+		Select("COUNT(*) AS `files`, COALESCE(SUM(`size`), 0) AS `bytes`").
+		Scan(&res).Error
+	return
+}
+
+func (r *repo) FetchOrphans(ctx context.Context, before time.Time, limit int) (list []domain.File, err error) {
+	err = r.orphans(ctx, before).
+		Order("`created_at`").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewFileUploadRepository(db *gorm.DB) domain.FileUploadRepository {
+	db.AutoMigrate(&domain.FileUpload{}, &domain.FileUploadPart{})
+	return &uploadRepo{db: db}
+}
+
+type uploadRepo struct {
+	db *gorm.DB
+}
+
+func (r *uploadRepo) With(tx gormx.Tx) domain.FileUploadRepository {
+	return &uploadRepo{db: tx.Get()}
+}
+
+func (r *uploadRepo) Save(ctx context.Context, upload *domain.FileUpload) error {
+	return gormx.Upsert(ctx, r.db, upload)
+}
+
+func (r *uploadRepo) SavePart(ctx context.Context, part *domain.FileUploadPart) error {
+	return gormx.Upsert(ctx, r.db, part)
+}
+
+func (r *uploadRepo) GetById(ctx context.Context, uploadId uuid.UUID) (upload *domain.FileUpload, err error) {
+	var entity domain.FileUpload
+	err = r.db.WithContext(ctx).First(&entity, uploadId).Error
+	if err == nil {
+		upload = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *uploadRepo) FetchParts(ctx context.Context, uploadId uuid.UUID) (list []domain.FileUploadPart, err error) {
+	err = r.db.WithContext(ctx).
+		Where("upload_id = ?", uploadId).
+		Order("number").
+		Find(&list).Error
+	return
+}
+
+func (r *uploadRepo) FetchStale(ctx context.Context, now time.Time, limit int) (list []domain.FileUpload, err error) {
+	err = r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", domain.FileUploadStatusUploading, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
+
+func (r *uploadRepo) SumOpenSizeByOwnerId(ctx context.Context, ownerId uuid.UUID, now time.Time) (size int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.FileUpload{}).
+		Select("COALESCE(SUM(`size`), 0)").
+		Where("`owner_id` = ? AND `status` = ? AND `expires_at` > ?", ownerId, domain.FileUploadStatusUploading, now).
+		Scan(&size).Error
+	return
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+// NewFilePreviewUseCase 납품 영상 미리보기 생성, 원본은 presigned URL 로 ffmpeg 가 직접 읽음
+func NewFilePreviewUseCase(
+	fileRepo domain.FileRepository,
+	previewRepo domain.FilePreviewRepository,
+	storage domain.BlobStorage,
+	previewer domain.VideoPreviewer,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.FilePreviewUseCase {
+	return &previewUseCase{
+		fileRepo:    fileRepo,
+		previewRepo: previewRepo,
+		storage:     storage,
+		previewer:   previewer,
+		clock:       clock,
+		timeout:     timeout,
+	}
+}
+
+type previewUseCase struct {
+	fileRepo    domain.FileRepository
+	previewRepo domain.FilePreviewRepository
+	storage     domain.BlobStorage
+	previewer   domain.VideoPreviewer
+	clock       domain.Clock
+	timeout     time.Duration
+}
+
+func (u *previewUseCase) GenerateFilePreviews(ctx context.Context) (res domain.FilePreviewRun, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.previewRepo.FetchPending(c, domain.FilePreviewBatch)
+	if err != nil {
+		return
+	}
+
+	var (
+		ran     = make([]bool, len(list))
+		results = make([]error, len(list))
+	)
+	pool, _ := workerpool.New(c, workerpool.Option{Size: domain.FilePreviewConcurrency})
+	for i := range list {
+		i := i
+		pool.Go(func(ctx context.Context) error {
+			ran[i] = true
+			results[i] = u.generate(ctx, &list[i])
+			return nil
+		})
+	}
+	_ = pool.Wait()
+
+	// 하나가 실패해도 나머지는 계속, 실패 횟수를 기록하고 다음 실행에 다시 시도
+	// 시간이 다 되어 실행하지 못한 미리보기는 그대로 대기
+	for i := range list {
+		preview := &list[i]
+		if !ran[i] {
+			continue
+		}
+		if results[i] == nil {
+			preview.Succeed(u.clock.Now())
+			res.Generated++
+		} else {
+			logx.From(ctx).WithError(results[i]).WithField("fileId", preview.FileId).Warn(tag, "generate file preview failed")
+			preview.Fail(results[i], u.clock.Now())
+			res.Failed++
+		}
+
+		err = u.previewRepo.Save(c, preview)
+		if err != nil {
+			return
+		}
+	}
+
+	diagnostics.AddCounter("file.preview.generated", uint64(res.Generated))
+	diagnostics.AddCounter("file.preview.failed", uint64(res.Failed))
+	return
+}
+
+func (u *previewUseCase) generate(ctx context.Context, preview *domain.FilePreview) error {
+	file, err := u.fileRepo.GetById(ctx, preview.FileId)
+	if err != nil {
+		return err
+	}
+	if file == nil || file.IsDeleted() {
+		return domain.ErrItemNotFound
+	}
+
+	source, err := u.storage.PresignGet(ctx, file.Key, domain.FilePreviewSourceTTL)
+	if err != nil {
+		return err
+	}
+
+	thumbnailKey, gifKey := preview.Keys()
+	var buf bytes.Buffer
+	err = u.previewer.Thumbnail(ctx, source, &buf)
+	if err != nil {
+		return err
+	}
+	err = u.storage.Put(ctx, thumbnailKey, &buf, int64(buf.Len()), "image/jpeg")
+	if err != nil {
+		return err
+	}
+
+	buf.Reset()
+	err = u.previewer.Gif(ctx, source, &buf)
+	if err != nil {
+		return err
+	}
+	return u.storage.Put(ctx, gifKey, &buf, int64(buf.Len()), "image/gif")
+}
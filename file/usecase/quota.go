@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+// NewStorageQuota 한도는 사용 중인 이용권 기준, 요금제가 따로 생기면 여기만 바꿈
+func NewStorageQuota(
+	fileRepo domain.FileRepository,
+	uploadRepo domain.FileUploadRepository,
+	userRepo domain.UserRepository,
+	orderTicketRepo domain.OrderTicketRepository,
+	settingReader domain.SettingReader,
+	clock domain.Clock,
+) domain.StorageQuota {
+	return &quota{
+		fileRepo:        fileRepo,
+		uploadRepo:      uploadRepo,
+		userRepo:        userRepo,
+		orderTicketRepo: orderTicketRepo,
+		settingReader:   settingReader,
+		clock:           clock,
+	}
+}
+
+type quota struct {
+	fileRepo        domain.FileRepository
+	uploadRepo      domain.FileUploadRepository
+	userRepo        domain.UserRepository
+	orderTicketRepo domain.OrderTicketRepository
+	settingReader   domain.SettingReader
+	clock           domain.Clock
+}
+
+func (q *quota) Usage(ctx context.Context, customerId uuid.UUID) (res domain.StorageUsage, err error) {
+	g, gc := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		res.Used, err = q.fileRepo.SumSizeByOwnerId(gc, customerId)
+		return
+	})
+	g.Go(func() (err error) {
+		res.Reserved, err = q.uploadRepo.SumOpenSizeByOwnerId(gc, customerId, q.clock.Now())
+		return
+	})
+	g.Go(func() (err error) {
+		res.Quota, err = q.Quota(gc, customerId)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		res = domain.StorageUsage{}
+	}
+	return
+}
+
+func (q *quota) Quota(ctx context.Context, customerId uuid.UUID) (res int64, err error) {
+	ticket, err := q.orderTicketRepo.GetByOwnerIdBetweenStartAndEnd(ctx, customerId, q.clock.Now())
+	if err != nil {
+		return
+	}
+
+	perOrderMB, err := q.settingReader.Int(ctx, domain.SettingKeyStorageQuotaMBPerOrder)
+	if err != nil {
+		return
+	}
+
+	freeMB, err := q.settingReader.Int(ctx, domain.SettingKeyStorageQuotaMBFree)
+	if err != nil {
+		return
+	}
+
+	res = domain.StorageQuotaOf(ticket, perOrderMB, freeMB)
+	return
+}
+
+func (q *quota) Check(ctx context.Context, ownerId uuid.UUID, size int64) (err error) {
+	owner, err := q.userRepo.GetById(ctx, ownerId)
+	if err != nil {
+		return
+	}
+
+	if owner == nil || !owner.IsCustomer() {
+		return
+	}
+
+	usage, err := q.Usage(ctx, ownerId)
+	if err != nil {
+		return
+	}
+
+	if !usage.Allows(size) {
+		err = domain.ErrStorageQuotaExceeded
+	}
+	return
+}
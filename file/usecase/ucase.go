@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewFileUseCase(
+	fileRepo domain.FileRepository,
+	userRepo domain.UserRepository,
+	customerRepo domain.CustomerRepository,
+	orderRepo domain.OrderRepository,
+	storage domain.BlobStorage,
+	quota domain.StorageQuota,
+	settingReader domain.SettingReader,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.FileUseCase {
+	return &ucase{
+		fileRepo:      fileRepo,
+		userRepo:      userRepo,
+		customerRepo:  customerRepo,
+		orderRepo:     orderRepo,
+		storage:       storage,
+		quota:         quota,
+		settingReader: settingReader,
+		ids:           ids,
+		clock:         clock,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	fileRepo      domain.FileRepository
+	userRepo      domain.UserRepository
+	customerRepo  domain.CustomerRepository
+	orderRepo     domain.OrderRepository
+	storage       domain.BlobStorage
+	quota         domain.StorageQuota
+	settingReader domain.SettingReader
+	ids           domain.IdGenerator
+	clock         domain.Clock
+	timeout       time.Duration
+}
+
+func (u *ucase) UploadFile(ctx context.Context, in domain.UploadFile) (res domain.FileInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	err = u.quota.Check(c, in.OwnerId, in.Size)
+	if err != nil {
+		return
+	}
+
+	file := domain.CreateFile(domain.CreateFileOption{
+		Id:          u.ids.NewId(),
+		OwnerId:     in.OwnerId,
+		Name:        in.Name,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		Now:         u.clock.Now(),
+	})
+
+	err = u.storage.Put(c, file.Key, in.Body, file.Size, file.ContentType)
+	if err != nil {
+		return
+	}
+
+	err = u.fileRepo.Save(c, &file)
+	if err != nil {
+		// 메타데이터 없는 파일이 남지 않도록 정리
+		_ = u.storage.Delete(c, file.Key)
+		return
+	}
+
+	res = infoOf(file)
+	return
+}
+
+func (u *ucase) DeleteFile(ctx context.Context, in domain.FileAccess) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	file, err := u.accessible(c, in)
+	if err != nil {
+		return
+	}
+
+	return u.deleteFile(c, file)
+}
+
+func (u *ucase) AttachFileToOrder(ctx context.Context, in domain.AttachFileToOrder) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		file      *domain.File
+		order     *domain.Order
+		requester *domain.User
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		file, err = u.accessible(gc, in.FileAccess)
+		return
+	})
+	g.Go(func() (err error) {
+		order, err = u.orderRepo.GetById(gc, in.OrderId)
+		return
+	})
+	g.Go(func() (err error) {
+		requester, err = u.userRepo.GetById(gc, in.RequesterId)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	if order == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if order.Orderer != in.RequesterId && !domain.CheckUserAlive(requester,
+		domain.User.IsAdmin,
+		domain.User.IsSuperAdmin) {
+		err = domain.ErrNoPermission
+		return
+	}
+
+	file.AttachToOrder(order.Id)
+	return u.fileRepo.Save(c, file)
+}
+
+func (u *ucase) CleanupOrphanFiles(ctx context.Context) (res domain.OrphanFileCleanup, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	res.Cutoff, err = u.orphanCutoff(c)
+	if err != nil || res.Cutoff == nil {
+		return
+	}
+
+	list, err := u.fileRepo.FetchOrphans(c, *res.Cutoff, domain.FileCleanupBatch)
+	if err != nil {
+		return
+	}
+
+	// 하나가 실패해도 나머지는 계속, 실패한 파일은 다음 실행에 다시 시도
+	for i := range list {
+		file := &list[i]
+		if deleteErr := u.deleteFile(c, file); deleteErr != nil {
+			logx.From(ctx).WithError(deleteErr).WithField("fileId", file.Id).Warn(tag, "cleanup orphan file failed")
+			res.Failed++
+			continue
+		}
+		res.Deleted++
+		res.Bytes += file.Size
+	}
+
+	diagnostics.AddCounter("file.orphan.deleted", uint64(res.Deleted))
+	diagnostics.AddCounter("file.orphan.deleted_bytes", uint64(res.Bytes))
+	diagnostics.AddCounter("file.orphan.failed", uint64(res.Failed))
+	return
+}
+
+// orphanCutoff 이 시각 전에 올린 연결 안된 파일이 정리 대상, 정리를 끈 경우 nil
+func (u *ucase) orphanCutoff(ctx context.Context) (*time.Time, error) {
+	days, err := u.settingReader.Int(ctx, domain.SettingKeyFileOrphanDays)
+	if err != nil || days <= 0 {
+		return nil, err
+	}
+
+	cutoff := u.clock.Now().AddDate(0, 0, -int(days))
+	return &cutoff, nil
+}
+
+func (u *ucase) deleteFile(ctx context.Context, file *domain.File) error {
+	err := u.storage.Delete(ctx, file.Key)
+	if err != nil {
+		return err
+	}
+
+	file.Delete(u.clock.Now())
+	return u.fileRepo.Save(ctx, file)
+}
+
+// accessible 삭제되지 않은 파일이고 요청자가 올린 사람이거나 관리자면 반환
+func (u *ucase) accessible(ctx context.Context, in domain.FileAccess) (file *domain.File, err error) {
+	file, err = u.fileRepo.GetById(ctx, in.FileId)
+	if err != nil {
+		return
+	}
+
+	if file == nil || file.IsDeleted() {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if file.OwnerId == in.RequesterId {
+		return
+	}
+
+	requester, err := u.userRepo.GetById(ctx, in.RequesterId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(requester,
+		domain.User.IsAdmin,
+		domain.User.IsSuperAdmin) {
+		err = domain.ErrNoPermission
+	}
+	return
+}
+
+func infoOf(file domain.File) domain.FileInfo {
+	return domain.FileInfo{
+		Id:          file.Id,
+		OwnerId:     file.OwnerId,
+		OrderId:     file.OrderId,
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		Checksum:    file.Checksum,
+		CreatedAt:   file.CreatedAt,
+	}
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func (u *ucase) GetFileDownload(ctx context.Context, in domain.FileAccess) (res domain.FileDownload, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	file, err := u.accessible(c, in)
+	if err != nil {
+		return
+	}
+
+	res.FileInfo = infoOf(*file)
+	res.ExpiresAt = u.clock.Now().Add(domain.FileDownloadTTL)
+	res.URL, err = u.storage.PresignGet(c, file.Key, domain.FileDownloadTTL)
+	return
+}
+
+func (u *uploadUseCase) GetFileUpload(ctx context.Context, in domain.FileUploadAccess) (res domain.FileUploadInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	upload, err := u.ownedUpload(c, in)
+	if err != nil {
+		return
+	}
+
+	parts, err := u.uploadRepo.FetchParts(c, upload.Id)
+	if err != nil {
+		return
+	}
+
+	res = uploadInfoOf(*upload, parts)
+	return
+}
+
+func (u *ucase) GetStorageReport(ctx context.Context, limit int) (res domain.StorageReport, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	total, err := u.fileRepo.GetTotalUsage(c)
+	if err != nil {
+		return
+	}
+	res.TotalFiles, res.TotalBytes = total.Files, total.Bytes
+
+	usages, err := u.fileRepo.FetchCustomerUsage(c, limit)
+	if err != nil || len(usages) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, len(usages))
+	for i := range usages {
+		ids[i] = usages[i].OwnerId
+	}
+
+	customers, err := u.customerRepo.FetchByIds(c, ids)
+	if err != nil {
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(customers))
+	for _, customer := range customers {
+		names[customer.Id] = customer.Name
+	}
+
+	// 한도는 고객마다 이용권 조회가 필요해서 동시에 몇 명씩
+	list := make([]domain.CustomerStorageInfo, len(usages))
+	pool, _ := workerpool.New(c, workerpool.Option{Size: domain.StorageReportConcurrency, FailFast: true})
+	for i := range usages {
+		i := i
+		pool.Go(func(ctx context.Context) (err error) {
+			list[i] = domain.CustomerStorageInfo{
+				UserId: usages[i].OwnerId,
+				Name:   names[usages[i].OwnerId],
+				Files:  usages[i].Files,
+				Used:   usages[i].Bytes,
+			}
+			list[i].Quota, err = u.quota.Quota(ctx, usages[i].OwnerId)
+			return
+		})
+	}
+	err = pool.Wait()
+	if err == nil {
+		// 시간이 다 되면 남은 작업은 실행되지 않고 에러도 없음
+		err = c.Err()
+	}
+	if err != nil {
+		return
+	}
+
+	res.Customers = list
+	return
+}
+
+func (u *ucase) GetOrphanFileReport(ctx context.Context, limit int) (res domain.OrphanFileReport, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	res.Cutoff, err = u.orphanCutoff(c)
+	if err != nil || res.Cutoff == nil {
+		return
+	}
+
+	cutoff := *res.Cutoff
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() error {
+		usage, err := u.fileRepo.GetOrphanUsage(gc, cutoff)
+		res.Files, res.Bytes = usage.Files, usage.Bytes
+		return err
+	})
+	g.Go(func() error {
+		list, err := u.fileRepo.FetchOrphans(gc, cutoff, limit)
+		res.Oldest = make([]domain.FileInfo, len(list))
+		for i := range list {
+			res.Oldest[i] = infoOf(list[i])
+		}
+		return err
+	})
+	err = g.Wait()
+	return
+}
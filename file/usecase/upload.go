@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[FILE] "
+
+// NewFileUploadUseCase 큰 파일은 서버를 거치지 않고 조각별 presigned URL 로 저장소에 바로 올림
+func NewFileUploadUseCase(
+	fileRepo domain.FileRepository,
+	uploadRepo domain.FileUploadRepository,
+	storage domain.BlobStorage,
+	quota domain.StorageQuota,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.FileUploadUseCase {
+	return &uploadUseCase{
+		fileRepo:   fileRepo,
+		uploadRepo: uploadRepo,
+		storage:    storage,
+		quota:      quota,
+		ids:        ids,
+		clock:      clock,
+		timeout:    timeout,
+	}
+}
+
+type uploadUseCase struct {
+	fileRepo   domain.FileRepository
+	uploadRepo domain.FileUploadRepository
+	storage    domain.BlobStorage
+	quota      domain.StorageQuota
+	ids        domain.IdGenerator
+	clock      domain.Clock
+	timeout    time.Duration
+}
+
+func (u *uploadUseCase) InitiateFileUpload(ctx context.Context, in domain.InitiateFileUpload) (res domain.FileUploadInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	upload, err := domain.CreateFileUpload(domain.CreateFileUploadOption{
+		Id:          u.ids.NewId(),
+		OwnerId:     in.OwnerId,
+		Name:        in.Name,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		Now:         u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	// 진행 중인 업로드도 한도에 포함되므로 동시에 여러 개를 시작해도 넘지 않음
+	err = u.quota.Check(c, in.OwnerId, upload.Size)
+	if err != nil {
+		return
+	}
+
+	upload.StorageUploadId, err = u.storage.CreateMultipart(c, upload.Key, upload.ContentType)
+	if err != nil {
+		return
+	}
+
+	err = u.uploadRepo.Save(c, &upload)
+	if err != nil {
+		// 기록이 없으면 스케줄러가 중단하지 못하므로 바로 정리
+		_ = u.storage.AbortMultipart(c, upload.Key, upload.StorageUploadId)
+		return
+	}
+
+	res = uploadInfoOf(upload, nil)
+	return
+}
+
+func (u *uploadUseCase) SignFileUploadPart(ctx context.Context, in domain.SignFileUploadPart) (res domain.FileUploadPartURL, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	upload, err := u.openUpload(c, in.FileUploadAccess)
+	if err != nil {
+		return
+	}
+
+	if upload.Single || !upload.HasPart(in.PartNumber) {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	now := u.clock.Now()
+	err = u.uploadRepo.SavePart(c, &domain.FileUploadPart{
+		UploadId: upload.Id,
+		Number:   in.PartNumber,
+		SignedAt: now,
+	})
+	if err != nil {
+		return
+	}
+
+	res = domain.FileUploadPartURL{
+		PartNumber: in.PartNumber,
+		Size:       upload.PartLength(in.PartNumber),
+		ExpiresAt:  now.Add(domain.FileUploadPartURLTTL),
+	}
+	res.URL, err = u.storage.PresignPart(c, upload.Key, upload.StorageUploadId, in.PartNumber, domain.FileUploadPartURLTTL)
+	return
+}
+
+func (u *uploadUseCase) CompleteFileUpload(ctx context.Context, in domain.CompleteFileUpload) (res domain.FileInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	upload, err := u.openUpload(c, in.FileUploadAccess)
+	if err != nil {
+		return
+	}
+
+	if upload.Single {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	parts, err := upload.SortedParts(in.Parts)
+	if err != nil {
+		return
+	}
+
+	err = u.storage.CompleteMultipart(c, upload.Key, upload.StorageUploadId, parts)
+	if err != nil {
+		return
+	}
+
+	file, err := u.complete(c, upload)
+	if err != nil {
+		return
+	}
+
+	res = infoOf(file)
+	return
+}
+
+func (u *uploadUseCase) PresignFileUpload(ctx context.Context, in domain.InitiateFileUpload) (res domain.PresignedFileUpload, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	upload, err := domain.CreateFileUpload(domain.CreateFileUploadOption{
+		Id:          u.ids.NewId(),
+		OwnerId:     in.OwnerId,
+		Name:        in.Name,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		Single:      true,
+		Checksum:    in.Checksum,
+		Now:         u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	err = u.quota.Check(c, in.OwnerId, upload.Size)
+	if err != nil {
+		return
+	}
+
+	url, headers, err := u.storage.PresignPut(c, upload.Key, upload.ContentType, upload.Checksum, domain.FileUploadPartURLTTL)
+	if err != nil {
+		return
+	}
+
+	// 기록이 있어야 진행 중인 크기가 한도에 잡히고 스케줄러가 정리함
+	err = u.uploadRepo.Save(c, &upload)
+	if err != nil {
+		return
+	}
+
+	res = domain.PresignedFileUpload{
+		Id:           upload.Id,
+		Name:         upload.Name,
+		ContentType:  upload.ContentType,
+		Size:         upload.Size,
+		URL:          url,
+		Headers:      headers,
+		URLExpiresAt: u.clock.Now().Add(domain.FileUploadPartURLTTL),
+		ExpiresAt:    upload.ExpiresAt,
+	}
+	return
+}
+
+func (u *uploadUseCase) CompletePresignedFileUpload(ctx context.Context, in domain.FileUploadAccess) (res domain.FileInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	upload, err := u.openUpload(c, in)
+	if err != nil {
+		return
+	}
+
+	if !upload.Single {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	file, err := u.complete(c, upload)
+	if err != nil {
+		return
+	}
+
+	res = infoOf(file)
+	return
+}
+
+// complete 저장소에 올라간 크기, 체크섬을 확인하고 파일로 등록, 다르면 올라간 파일을 지우고 업로드 중단
+// 체크섬은 서명해서 저장소가 이미 확인했지만 저장소가 돌려준 값이 있으면 한 번 더 대조
+func (u *uploadUseCase) complete(ctx context.Context, upload *domain.FileUpload) (file domain.File, err error) {
+	blob, err := u.storage.Stat(ctx, upload.Key)
+	if err != nil {
+		return
+	}
+
+	now := u.clock.Now()
+	mismatch := blob.Size != upload.Size ||
+		(upload.Checksum != "" && blob.Checksum != "" && !strings.EqualFold(blob.Checksum, upload.Checksum))
+	if mismatch {
+		_ = u.storage.Delete(ctx, upload.Key)
+		upload.Abort(now)
+		if saveErr := u.uploadRepo.Save(ctx, upload); saveErr != nil {
+			err = saveErr
+			return
+		}
+		err = domain.ErrUploadMismatch
+		return
+	}
+
+	file = upload.Complete(now)
+	err = u.fileRepo.Transaction(ctx, func(fr domain.FileTxRepository) error {
+		err := fr.Save(ctx, &file)
+		if err != nil {
+			return err
+		}
+		return u.uploadRepo.With(fr).Save(ctx, upload)
+	})
+	return
+}
+
+func (u *uploadUseCase) AbortFileUpload(ctx context.Context, in domain.FileUploadAccess) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	upload, err := u.ownedUpload(c, in)
+	if err != nil {
+		return
+	}
+
+	if upload.Status != domain.FileUploadStatusUploading {
+		err = domain.ErrUploadClosed
+		return
+	}
+
+	return u.abort(c, upload)
+}
+
+func (u *uploadUseCase) AbortStaleFileUploads(ctx context.Context) (aborted int, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.uploadRepo.FetchStale(c, u.clock.Now(), domain.FileUploadAbortBatch)
+	if err != nil {
+		return
+	}
+
+	// 하나가 실패해도 나머지는 계속, 실패한 업로드는 다음 실행에 다시 시도
+	for i := range list {
+		upload := &list[i]
+		if abortErr := u.abort(c, upload); abortErr != nil {
+			logx.From(ctx).WithError(abortErr).WithField("uploadId", upload.Id).Warn(tag, "abort stale upload failed")
+			continue
+		}
+		aborted++
+	}
+	return
+}
+
+func (u *uploadUseCase) abort(ctx context.Context, upload *domain.FileUpload) error {
+	var err error
+	if upload.Single {
+		// 이미 PUT 했을 수도 있으니 올라간 파일 삭제
+		err = u.storage.Delete(ctx, upload.Key)
+	} else {
+		err = u.storage.AbortMultipart(ctx, upload.Key, upload.StorageUploadId)
+	}
+	if err != nil {
+		return err
+	}
+
+	upload.Abort(u.clock.Now())
+	return u.uploadRepo.Save(ctx, upload)
+}
+
+// ownedUpload 업로드는 시작한 사람만 이어갈 수 있음
+func (u *uploadUseCase) ownedUpload(ctx context.Context, in domain.FileUploadAccess) (upload *domain.FileUpload, err error) {
+	upload, err = u.uploadRepo.GetById(ctx, in.UploadId)
+	if err != nil {
+		return
+	}
+
+	if upload == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if upload.OwnerId != in.RequesterId {
+		err = domain.ErrNoPermission
+	}
+	return
+}
+
+// openUpload 끝났거나 기한이 지난 업로드는 ErrUploadClosed
+func (u *uploadUseCase) openUpload(ctx context.Context, in domain.FileUploadAccess) (upload *domain.FileUpload, err error) {
+	upload, err = u.ownedUpload(ctx, in)
+	if err != nil {
+		return
+	}
+
+	if !upload.IsOpen(u.clock.Now()) {
+		err = domain.ErrUploadClosed
+	}
+	return
+}
+
+func uploadInfoOf(upload domain.FileUpload, parts []domain.FileUploadPart) domain.FileUploadInfo {
+	signed := make([]int, len(parts))
+	for i := range parts {
+		signed[i] = parts[i].Number
+	}
+
+	return domain.FileUploadInfo{
+		Id:          upload.Id,
+		Name:        upload.Name,
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+		PartSize:    upload.PartSize,
+		PartCount:   upload.PartCount,
+		Status:      upload.Status,
+		SignedParts: signed,
+		CreatedAt:   upload.CreatedAt,
+		ExpiresAt:   upload.ExpiresAt,
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[FINANCE_SNAPSHOT] "
+)
+
+func NewFinanceSnapshotController(useCase domain.FinanceSnapshotUseCase) *FinanceSnapshotController {
+	return &FinanceSnapshotController{useCase: useCase}
+}
+
+type FinanceSnapshotController struct {
+	useCase domain.FinanceSnapshotUseCase
+}
+
+func (c *FinanceSnapshotController) Bind(e *echo.Echo) {
+	// ===== SUPER ADMIN =====
+	e.POST("/dashboard/snapshot", echox.UserID(c.takeSnapshot),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.GET("/dashboard/snapshot", c.fetchSnapshots,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.GET("/dashboard/snapshot/:snapshotId", c.getSnapshot,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type FinanceSnapshotResponse struct {
+	Id      uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Month   string    `json:"month" validate:"required" example:"2024-05"`
+	Version uint16    `json:"version" validate:"required" example:"1"`
+	// TicketsSold, 그 달에 만든 이용권 수
+	TicketsSold int64 `json:"ticketsSold" validate:"required" example:"42"`
+	// OrderSlotsSold, 그 달에 만든 이용권의 의뢰 가능 횟수 합
+	OrderSlotsSold int64 `json:"orderSlotsSold" validate:"required" example:"168"`
+	// ConversionRevenue, 가격 실험 전환으로 기록된 결제 금액 합 (원)
+	ConversionRevenue int64 `json:"conversionRevenue" validate:"required" example:"3980000"`
+	OrdersRequested   int64 `json:"ordersRequested" validate:"required" example:"150"`
+	OrdersDone        int64 `json:"ordersDone" validate:"required" example:"131"`
+	OrdersCanceled    int64 `json:"ordersCanceled" validate:"required" example:"4"`
+	// ActiveCustomers, 그 달에 의뢰한 고객 수
+	ActiveCustomers int64     `json:"activeCustomers" validate:"required" example:"37"`
+	CreditEarned    int64     `json:"creditEarned" validate:"required" example:"50000"`
+	CreditSpent     int64     `json:"creditSpent" validate:"required" example:"32000"`
+	TakenBy         uuid.UUID `json:"takenBy" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TakenAt         time.Time `json:"takenAt" validate:"required" example:"2024-06-01T01:00:00+09:00"`
+} // @name FinanceSnapshotResponse
+
+func responseOf(src domain.FinanceSnapshotInfo) FinanceSnapshotResponse {
+	return FinanceSnapshotResponse{
+		Id:                src.Id,
+		Month:             src.Month,
+		Version:           src.Version,
+		TicketsSold:       src.TicketsSold,
+		OrderSlotsSold:    src.OrderSlotsSold,
+		ConversionRevenue: src.ConversionRevenue,
+		OrdersRequested:   src.OrdersRequested,
+		OrdersDone:        src.OrdersDone,
+		OrdersCanceled:    src.OrdersCanceled,
+		ActiveCustomers:   src.ActiveCustomers,
+		CreditEarned:      src.CreditEarned,
+		CreditSpent:       src.CreditSpent,
+		TakenBy:           src.TakenBy,
+		TakenAt:           src.TakenAt,
+	}
+}
+
+type FinanceMonthRequest struct {
+	// Month, KST 기준 월
+	Month string `query:"month" validate:"required" example:"2024-05"`
+} // @name FinanceMonthRequest
+
+// @Tags (FinanceSnapshot) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 월 마감 스냅샷 만들기
+// @Description 지금 데이터로 그 달(KST)의 이용권, 결제, 의뢰, 크레딧 숫자를 계산해 저장, 이후 데이터를 정정해도 저장한 숫자는 바뀌지 않음, 같은 달을 다시 만들면 version 이 올라간 새 스냅샷, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param month query string true "마감 월 (ex. 2024-05)"
+// @Success 201 {object} FinanceSnapshotResponse "생성"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 월, 아직 시작하지 않은 달"
+// @Router /dashboard/snapshot [post]
+func (c *FinanceSnapshotController) takeSnapshot(ctx echo.Context, userId uuid.UUID) error {
+	var req FinanceMonthRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "take snapshot, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	snapshot, err := c.useCase.TakeFinanceSnapshot(ctx.Request().Context(), domain.TakeFinanceSnapshot{
+		Month:   req.Month,
+		TakenBy: userId,
+	})
+
+	switch err {
+	case nil:
+		echox.Log(ctx).WithField("month", snapshot.Month).
+			WithField("version", snapshot.Version).
+			WithField("takenBy", userId).
+			Info(tag, "finance snapshot taken")
+		return ctx.JSON(http.StatusCreated, responseOf(snapshot))
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("month", req.Month).
+			Error(tag, "takeSnapshot, unhandled error useCase.TakeFinanceSnapshot")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (FinanceSnapshot) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 월 마감 스냅샷 목록
+// @Description 그 달의 스냅샷, 최신 version 부터, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param month query string true "마감 월 (ex. 2024-05)"
+// @Success 200 {array} FinanceSnapshotResponse "성공"
+// @Success 204 "스냅샷 없음"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 월"
+// @Router /dashboard/snapshot [get]
+func (c *FinanceSnapshotController) fetchSnapshots(ctx echo.Context) error {
+	var req FinanceMonthRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch snapshots, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.FetchFinanceSnapshots(ctx.Request().Context(), req.Month)
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("month", req.Month).
+			Error(tag, "fetchSnapshots, unhandled error useCase.FetchFinanceSnapshots")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]FinanceSnapshotResponse, len(list))
+	for i := range list {
+		res[i] = responseOf(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (FinanceSnapshot) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 월 마감 스냅샷 상세
+// @Description 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param snapshot_id path string true "스냅샷 아이디(UUID)"
+// @Success 200 {object} FinanceSnapshotResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 스냅샷"
+// @Router /dashboard/snapshot/{snapshot_id} [get]
+func (c *FinanceSnapshotController) getSnapshot(ctx echo.Context) error {
+	var req struct {
+		SnapshotId uuid.UUID `param:"snapshotId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get snapshot, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	snapshot, err := c.useCase.GetFinanceSnapshot(ctx.Request().Context(), req.SnapshotId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, responseOf(snapshot))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("snapshotId", req.SnapshotId).
+			Error(tag, "getSnapshot, unhandled error useCase.GetFinanceSnapshot")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewFinanceSnapshotRepository(db *gorm.DB) domain.FinanceSnapshotRepository {
+	db.AutoMigrate(&domain.FinanceSnapshot{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Aggregate(ctx context.Context, from, to time.Time) (res domain.FinanceAggregates, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		var tickets struct {
+			TicketsSold    int64
+			OrderSlotsSold int64
+		}
+		err = tx.Table("order_ticket").
+			Select("COUNT(*) AS `tickets_sold`, COALESCE(SUM(`total_order_count`), 0) AS `order_slots_sold`").
+			Where("`created_at` >= ? AND `created_at` < ?", from, to).
+			Scan(&tickets).Error
+		if err != nil {
+			return
+		}
+		res.TicketsSold, res.OrderSlotsSold = tickets.TicketsSold, tickets.OrderSlotsSold
+
+		err = tx.Table("experiment_conversion").
+			Select("COALESCE(SUM(`amount`), 0)").
+			Where("`created_at` >= ? AND `created_at` < ?", from, to).
+			Scan(&res.ConversionRevenue).Error
+		if err != nil {
+			return
+		}
+
+		var orders struct {
+			OrdersRequested int64
+			ActiveCustomers int64
+		}
+		err = tx.Table("order").
+			Select("COUNT(*) AS `orders_requested`, COUNT(DISTINCT `orderer`) AS `active_customers`").
+			Where("`ordered_at` >= ? AND `ordered_at` < ? AND `is_draft` = ?", from, to, false).
+			Scan(&orders).Error
+		if err != nil {
+			return
+		}
+		res.OrdersRequested, res.ActiveCustomers = orders.OrdersRequested, orders.ActiveCustomers
+
+		err = tx.Table("order").
+			Where("`done_at` >= ? AND `done_at` < ?", from, to).
+			Count(&res.OrdersDone).Error
+		if err != nil {
+			return
+		}
+
+		err = tx.Table("order").
+			Where("`canceled_at` >= ? AND `canceled_at` < ?", from, to).
+			Count(&res.OrdersCanceled).Error
+		if err != nil {
+			return
+		}
+
+		// 고객 지갑 계정 분개만, 상대 계정(system:*) 분개는 부호만 반대인 같은 금액
+		var credit struct {
+			CreditEarned int64
+			CreditSpent  int64
+		}
+		err = tx.Table("credit_entry").
+			Select("COALESCE(SUM(CASE WHEN `kind` = ? THEN `amount` END), 0) AS `credit_earned`, "+
+				"COALESCE(-SUM(CASE WHEN `kind` = ? THEN `amount` END), 0) AS `credit_spent`",
+				domain.CreditEntryKindEarn, domain.CreditEntryKindSpend).
+			Where("`account` LIKE ? AND `created_at` >= ? AND `created_at` < ?", "customer:%", from, to).
+			Scan(&credit).Error
+		res.CreditEarned, res.CreditSpent = credit.CreditEarned, credit.CreditSpent
+		return
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	return
+}
+
+func (r *repo) Create(ctx context.Context, snapshot *domain.FinanceSnapshot) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last uint16
+		err := tx.Model(&domain.FinanceSnapshot{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("COALESCE(MAX(`version`), 0)").
+			Where("`month` = ?", snapshot.Month).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		snapshot.Version = last + 1
+		return tx.Create(snapshot).Error
+	})
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (snapshot *domain.FinanceSnapshot, err error) {
+	var entity domain.FinanceSnapshot
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		snapshot = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchByMonth(ctx context.Context, month string) (list []domain.FinanceSnapshot, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`month` = ?", month).
+		Order("`version` desc").
+		Find(&list).Error
+	return
+}
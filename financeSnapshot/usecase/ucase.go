@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewFinanceSnapshotUseCase(
+	snapshotRepo domain.FinanceSnapshotRepository,
+	ids domain.IdGenerator,
+	calendar domain.Calendar,
+	timeout time.Duration,
+) domain.FinanceSnapshotUseCase {
+	return &ucase{
+		snapshotRepo: snapshotRepo,
+		ids:          ids,
+		calendar:     calendar,
+		timeout:      timeout,
+	}
+}
+
+type ucase struct {
+	snapshotRepo domain.FinanceSnapshotRepository
+	ids          domain.IdGenerator
+	calendar     domain.Calendar
+	timeout      time.Duration
+}
+
+func toInfo(src domain.FinanceSnapshot) domain.FinanceSnapshotInfo {
+	return domain.FinanceSnapshotInfo{
+		Id:                src.Id,
+		Month:             src.Month,
+		Version:           src.Version,
+		FinanceAggregates: src.FinanceAggregates,
+		TakenBy:           src.TakenBy,
+		TakenAt:           src.TakenAt,
+	}
+}
+
+func (u *ucase) TakeFinanceSnapshot(ctx context.Context, in domain.TakeFinanceSnapshot) (res domain.FinanceSnapshotInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	from, to, err := domain.ParseFinanceMonth(in.Month, u.calendar.Location())
+	if err != nil {
+		return
+	}
+
+	now := u.calendar.Now()
+	if !from.Before(now) {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	aggregates, err := u.snapshotRepo.Aggregate(c, from, to)
+	if err != nil {
+		return
+	}
+
+	snapshot := domain.FinanceSnapshot{
+		Id:                u.ids.NewId(),
+		Month:             from.Format(domain.FinanceMonthLayout),
+		FinanceAggregates: aggregates,
+		TakenBy:           in.TakenBy,
+		TakenAt:           now,
+	}
+	err = u.snapshotRepo.Create(c, &snapshot)
+	if err != nil {
+		return
+	}
+
+	res = toInfo(snapshot)
+	return
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) GetFinanceSnapshot(ctx context.Context, id uuid.UUID) (res domain.FinanceSnapshotInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	snapshot, err := u.snapshotRepo.GetById(c, id)
+	if err != nil {
+		return
+	}
+	if snapshot == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = toInfo(*snapshot)
+	return
+}
+
+func (u *ucase) FetchFinanceSnapshots(ctx context.Context, month string) (res []domain.FinanceSnapshotInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	from, _, err := domain.ParseFinanceMonth(month, u.calendar.Location())
+	if err != nil {
+		return
+	}
+
+	list, err := u.snapshotRepo.FetchByMonth(c, from.Format(domain.FinanceMonthLayout))
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.FinanceSnapshotInfo, len(list))
+	for i := range list {
+		res[i] = toInfo(list[i])
+	}
+	return
+}
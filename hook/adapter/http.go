@@ -0,0 +1,108 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	sendTimeout    = 10 * time.Second
+	errorBodyLimit = 1024
+	eventHeader    = "X-Editfolio-Event"
+)
+
+// NewHookSender 리다이렉트는 따라가지 않음, 구독한 주소로만 보냄
+// DNS 로 해석한 주소가 내부망이면 연결하지 않음(ErrHookTargetBlocked), 프록시도 거치지 않음
+// policy 는 한 번 전송 안에서의 재시도, 다 실패하면 전송 작업이 HookRetryBase 간격으로 다시 보냄
+func NewHookSender(policy retry.Policy) domain.HookSender {
+	dialer := &net.Dialer{
+		Timeout: sendTimeout,
+		Control: publicOnly,
+	}
+
+	return &sender{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: sendTimeout,
+				MaxIdleConnsPerHost: domain.HookDeliverConcurrency,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		policy: policy,
+	}
+}
+
+// publicOnly 이름 해석이 끝난 뒤 실제로 연결할 주소를 확인, 해석 결과를 바꾸는 DNS rebinding 도 막음
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !domain.HookTargetIPAllowed(ip) {
+		return domain.ErrHookTargetBlocked
+	}
+	return nil
+}
+
+type sender struct {
+	client *http.Client
+	policy retry.Policy
+}
+
+func (s *sender) Send(ctx context.Context, targetURL string, event domain.HookEvent, payload []byte) error {
+	return retry.Do(ctx, s.policy, func(ctx context.Context) error {
+		return s.send(ctx, targetURL, event, payload)
+	})
+}
+
+func (s *sender) send(ctx context.Context, targetURL string, event domain.HookEvent, payload []byte) error {
+	c, cancel := budget.Slice(ctx, sendTimeout)
+	defer cancel()
+
+	u, err := url.Parse(targetURL)
+	if err != nil || u.Scheme != "https" {
+		return retry.Permanent(domain.ErrHookTargetBlocked)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, targetURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(eventHeader, string(event))
+
+	res, err := s.client.Do(req)
+	if errors.Is(err, domain.ErrHookTargetBlocked) {
+		return retry.Permanent(domain.ErrHookTargetBlocked)
+	}
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusGone:
+		return domain.ErrHookGone
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, errorBodyLimit))
+		return &retry.StatusError{Service: "hook target", Code: res.StatusCode, Body: string(raw)}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[HOOK] "
+)
+
+func NewHookController(useCase domain.HookUseCase) *HookController {
+	return &HookController{useCase: useCase}
+}
+
+type HookController struct {
+	useCase domain.HookUseCase
+}
+
+func (c *HookController) Bind(e *echo.Echo) {
+	// ADMIN
+	e.GET("/hooks", echox.UserID(c.fetchHooks),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/hooks/subscribe", echox.UserID(c.subscribeHook),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.DELETE("/hooks/:hookId", echox.UserID(c.unsubscribeHook),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/hooks/deliver", c.internalDeliverHooks)
+}
+
+type HookResponse struct {
+	Id        uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Event     string    `json:"event" validate:"required" example:"order.done"`
+	TargetUrl string    `json:"targetUrl" validate:"required" example:"https://hooks.zapier.com/hooks/standard/123456/abcdef/"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name HookResponse
+
+// @Tags (Hook) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 내 REST Hook 구독 목록
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} HookResponse "성공"
+// @Success 204 "구독 없음"
+// @Router /hooks [get]
+func (c *HookController) fetchHooks(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.FetchHooks(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchHooks, unhandled error useCase.FetchHooks")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]HookResponse, len(list))
+	for i, src := range list {
+		res[i] = HookResponse{
+			Id:        src.Id,
+			Event:     string(src.Event),
+			TargetUrl: src.TargetUrl,
+			CreatedAt: src.CreatedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type SubscribeHookRequest struct {
+	Event string `json:"event" validate:"required" example:"order.done" enums:"order.requested,order.state_changed,order.done,order.canceled,user.customer_created,user.customer_merged,payment.settled"`
+	// TargetUrl, 이벤트를 받을 https 주소, 410 으로 응답하면 구독 해지
+	TargetUrl string `json:"targetUrl" validate:"required,url" example:"https://hooks.zapier.com/hooks/standard/123456/abcdef/"`
+} // @name SubscribeHookRequest
+
+type SubscribeHookResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name SubscribeHookResponse
+
+// @Tags (Hook) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] REST Hook 구독
+// @Description Zapier 구독 방식, 이벤트가 생기면 {id, event, occurredAt, data} 를 POST 로 보냄, 실패하면 간격을 늘려 다시 보냄, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body SubscribeHookRequest true "구독할 이벤트, 받을 주소"
+// @Success 201 {object} SubscribeHookResponse "구독 성공"
+// @Failure 400 {object} domain.ErrorResponse "없는 이벤트, https 가 아닌 주소"
+// @Router /hooks/subscribe [post]
+func (c *HookController) subscribeHook(ctx echo.Context, userId uuid.UUID) error {
+	var req SubscribeHookRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "subscribe hook, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	newId, err := c.useCase.Subscribe(ctx.Request().Context(), domain.SubscribeHook{
+		OwnerId:   userId,
+		Event:     domain.HookEvent(req.Event),
+		TargetUrl: req.TargetUrl,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, SubscribeHookResponse{Id: newId})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "subscribeHook, unhandled error useCase.Subscribe")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Hook) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] REST Hook 구독 해지
+// @Description 구독한 본인 또는 최고 관리자만, 아직 보내지 못한 이벤트도 지움, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param hook_id path string true "구독 식별 아이디(UUID)"
+// @Success 204 "해지 성공"
+// @Failure 403 {object} domain.ErrorResponse "권한 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 구독"
+// @Router /hooks/{hook_id} [delete]
+func (c *HookController) unsubscribeHook(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		HookId uuid.UUID `param:"hookId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "unsubscribe hook, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.Unsubscribe(ctx.Request().Context(), domain.UnsubscribeHook{
+		HookId:      req.HookId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("hookId", req.HookId).
+			Error(tag, "unsubscribeHook, unhandled error useCase.Unsubscribe")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *HookController) internalDeliverHooks(ctx echo.Context) error {
+	res, err := c.useCase.DeliverHooks(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalDeliverHooks, unhandled error useCase.DeliverHooks")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("delivered", res.Delivered).
+		WithField("failed", res.Failed).
+		WithField("unsubscribed", res.Unsubscribed).
+		Info(tag, "deliver hooks")
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"delivered":    res.Delivered,
+		"failed":       res.Failed,
+		"unsubscribed": res.Unsubscribed,
+	})
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewEventHookInboxHandler 이 서버가 발행한 이벤트 봉투를 받아 구독마다 전송으로 만듦
+func NewEventHookInboxHandler(useCase domain.HookUseCase) domain.InboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var msg struct {
+			Id         string          `json:"id"`
+			Type       string          `json:"type"`
+			OccurredAt time.Time       `json:"occurredAt"`
+			Data       json.RawMessage `json:"data"`
+		}
+
+		err := json.Unmarshal(payload, &msg)
+		if err != nil {
+			return err
+		}
+
+		return useCase.Fanout(ctx, domain.HookMessage{
+			Id:         msg.Id,
+			Event:      domain.HookEvent(msg.Type),
+			OccurredAt: msg.OccurredAt,
+			Data:       msg.Data,
+		})
+	}
+}
+
+// NewPaymentSettledHookInboxHandler 결제 정산 메시지는 봉투가 없으므로 외부 주문 번호로 이벤트 아이디를 만듦
+func NewPaymentSettledHookInboxHandler(useCase domain.HookUseCase) domain.InboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var msg struct {
+			ExOrderId string `json:"exOrderId"`
+		}
+
+		err := json.Unmarshal(payload, &msg)
+		if err != nil {
+			return err
+		}
+
+		return useCase.Fanout(ctx, domain.HookMessage{
+			Id:         string(domain.HookEventPaymentSettled) + "/" + msg.ExOrderId,
+			Event:      domain.HookEventPaymentSettled,
+			OccurredAt: time.Now(),
+			Data:       payload,
+		})
+	}
+}
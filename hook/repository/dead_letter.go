@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+// NewHookDeadLetterRepository 테이블은 NewHookRepository 가 만듦
+func NewHookDeadLetterRepository(db *gorm.DB) domain.DeadLetterRepository {
+	return &deadLetterRepo{db: db}
+}
+
+type deadLetterRepo struct {
+	db *gorm.DB
+}
+
+// dead 보내지 못했고 다음 시도가 없는(포기한) 전송, ids 가 있으면 그 중에서만
+func (r *deadLetterRepo) dead(ctx context.Context, ids []uuid.UUID) *gorm.DB {
+	db := r.db.WithContext(ctx).
+		Where("`delivered_at` IS NULL AND `next_attempt_at` IS NULL")
+	if len(ids) > 0 {
+		db = db.Where("`id` IN ?", ids)
+	}
+	return db
+}
+
+func (r *deadLetterRepo) FetchDead(ctx context.Context, option domain.FetchDeadLetterOption) (list []domain.DeadLetter, err error) {
+	db := r.dead(ctx, nil).
+		Order("`created_at` desc").
+		Limit(option.Limit)
+	if option.Before != nil {
+		db = db.Where("`created_at` < ?", option.Before)
+	}
+
+	var deliveries []domain.HookDelivery
+	err = db.Find(&deliveries).Error
+	if err != nil {
+		return
+	}
+
+	list = make([]domain.DeadLetter, len(deliveries))
+	for i := range deliveries {
+		list[i] = deadLetterOf(deliveries[i])
+	}
+	return
+}
+
+func (r *deadLetterRepo) GetDead(ctx context.Context, id uuid.UUID) (letter *domain.DeadLetter, err error) {
+	var entity domain.HookDelivery
+	err = r.dead(ctx, []uuid.UUID{id}).First(&entity).Error
+	if err == nil {
+		dead := deadLetterOf(entity)
+		letter = &dead
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
+
+// RetryDead 시도 횟수를 되돌리고 다음 전송 작업에서 바로 보내도록 예약
+func (r *deadLetterRepo) RetryDead(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	res := r.dead(ctx, ids).
+		Model(&domain.HookDelivery{}).
+		Updates(map[string]interface{}{
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	return res.RowsAffected, res.Error
+}
+
+func (r *deadLetterRepo) PurgeDead(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	res := r.dead(ctx, ids).Delete(&domain.HookDelivery{})
+	return res.RowsAffected, res.Error
+}
+
+func deadLetterOf(delivery domain.HookDelivery) domain.DeadLetter {
+	return domain.DeadLetter{
+		Id:        delivery.Id,
+		Kind:      domain.DeadLetterKindHook,
+		Type:      string(delivery.Event),
+		Target:    delivery.TargetUrl,
+		Attempts:  delivery.Attempts,
+		LastError: delivery.LastError,
+		Payload:   delivery.Payload,
+		CreatedAt: delivery.CreatedAt,
+	}
+}
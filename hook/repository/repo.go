@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewHookRepository(db *gorm.DB) domain.HookRepository {
+	db.AutoMigrate(&domain.HookSubscription{}, &domain.HookDelivery{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, subscription *domain.HookSubscription) error {
+	return gormx.Upsert(ctx, r.db, subscription)
+}
+
+func (r *repo) Delete(ctx context.Context, hookId uuid.UUID) (deleted bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&domain.HookSubscription{}, hookId)
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected > 0
+
+		return tx.Where("hook_id = ? AND delivered_at IS NULL", hookId).
+			Delete(&domain.HookDelivery{}).Error
+	})
+	return
+}
+
+func (r *repo) SaveDeliveries(ctx context.Context, list []domain.HookDelivery) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&list).Error
+}
+
+func (r *repo) SaveDelivery(ctx context.Context, delivery *domain.HookDelivery) error {
+	return gormx.Upsert(ctx, r.db, delivery)
+}
+
+func (r *repo) GetById(ctx context.Context, hookId uuid.UUID) (subscription *domain.HookSubscription, err error) {
+	var entity domain.HookSubscription
+	err = r.db.WithContext(ctx).First(&entity, hookId).Error
+	if err == nil {
+		subscription = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchByOwnerId(ctx context.Context, ownerId uuid.UUID) (list []domain.HookSubscription, err error) {
+	err = r.db.WithContext(ctx).
+		Where("owner_id = ?", ownerId).
+		Order("created_at").
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchByEvent(ctx context.Context, event domain.HookEvent) (list []domain.HookSubscription, err error) {
+	err = r.db.WithContext(ctx).Where("event = ?", event).Find(&list).Error
+	return
+}
+
+func (r *repo) FetchDueDeliveries(ctx context.Context, now time.Time, limit int) (list []domain.HookDelivery, err error) {
+	err = r.db.WithContext(ctx).
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[HOOK] "
+
+func NewHookUseCase(
+	hookRepo domain.HookRepository,
+	userRepo domain.UserRepository,
+	sender domain.HookSender,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.HookUseCase {
+	return &ucase{
+		hookRepo: hookRepo,
+		userRepo: userRepo,
+		sender:   sender,
+		ids:      ids,
+		clock:    clock,
+		timeout:  timeout,
+	}
+}
+
+type ucase struct {
+	hookRepo domain.HookRepository
+	userRepo domain.UserRepository
+	sender   domain.HookSender
+	ids      domain.IdGenerator
+	clock    domain.Clock
+	timeout  time.Duration
+}
+
+func (u *ucase) Subscribe(ctx context.Context, in domain.SubscribeHook) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	subscription, err := domain.CreateHookSubscription(domain.CreateHookSubscriptionOption{
+		Id:        u.ids.NewId(),
+		OwnerId:   in.OwnerId,
+		Event:     in.Event,
+		TargetUrl: in.TargetUrl,
+		Now:       u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	err = u.hookRepo.Save(c, &subscription)
+	if err != nil {
+		return
+	}
+
+	newId = subscription.Id
+	return
+}
+
+func (u *ucase) Unsubscribe(ctx context.Context, in domain.UnsubscribeHook) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	subscription, err := u.hookRepo.GetById(c, in.HookId)
+	if err != nil {
+		return
+	}
+
+	if subscription == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if subscription.OwnerId != in.RequesterId {
+		user, err := u.userRepo.GetById(c, in.RequesterId)
+		if err != nil {
+			return err
+		}
+
+		if !domain.CheckUserAlive(user, domain.User.IsSuperAdmin) {
+			return domain.ErrNoPermission
+		}
+	}
+
+	_, err = u.hookRepo.Delete(c, in.HookId)
+	return
+}
+
+func (u *ucase) Fanout(ctx context.Context, message domain.HookMessage) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if !message.Event.IsValid() {
+		return
+	}
+
+	subscriptions, err := u.hookRepo.FetchByEvent(c, message.Event)
+	if err != nil || len(subscriptions) == 0 {
+		return
+	}
+
+	list, err := domain.CreateHookDeliveries(message, subscriptions, u.ids, u.clock.Now())
+	if err != nil {
+		return
+	}
+
+	err = u.hookRepo.SaveDeliveries(c, list)
+	return
+}
+
+func (u *ucase) DeliverHooks(ctx context.Context) (res domain.HookDeliveryRun, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.hookRepo.FetchDueDeliveries(c, u.clock.Now(), domain.HookDeliverBatch)
+	if err != nil {
+		return
+	}
+
+	var (
+		ran     = make([]bool, len(list))
+		results = make([]error, len(list))
+	)
+	pool, _ := workerpool.New(c, workerpool.Option{Size: domain.HookDeliverConcurrency})
+	for i := range list {
+		i := i
+		pool.Go(func(ctx context.Context) error {
+			delivery := list[i]
+			ran[i] = true
+			results[i] = u.sender.Send(ctx, delivery.TargetUrl, delivery.Event, []byte(delivery.Payload))
+			return nil
+		})
+	}
+	_ = pool.Wait()
+
+	// 시간이 다 되어 보내지 못한 전송은 다음 실행에서 보냄
+	unsubscribed := make(map[uuid.UUID]bool)
+	for i := range list {
+		delivery := &list[i]
+		if !ran[i] || unsubscribed[delivery.HookId] {
+			continue
+		}
+
+		switch results[i] {
+		case nil:
+			delivery.Delivered(u.clock.Now())
+			res.Delivered++
+		case domain.ErrHookGone:
+			unsubscribed[delivery.HookId] = true
+			res.Unsubscribed++
+
+			_, err = u.hookRepo.Delete(c, delivery.HookId)
+			if err != nil {
+				return
+			}
+			continue
+		default:
+			logx.From(ctx).WithError(results[i]).
+				WithField("hookId", delivery.HookId).
+				WithField("attempts", delivery.Attempts+1).
+				Warn(tag, "deliver hook failed")
+			delivery.Failed(results[i], u.clock.Now())
+			res.Failed++
+		}
+
+		err = u.hookRepo.SaveDelivery(c, delivery)
+		if err != nil {
+			return
+		}
+	}
+
+	diagnostics.AddCounter("hook.delivered", uint64(res.Delivered))
+	diagnostics.AddCounter("hook.failed", uint64(res.Failed))
+	diagnostics.AddCounter("hook.unsubscribed", uint64(res.Unsubscribed))
+	return
+}
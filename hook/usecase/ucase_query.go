@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchHooks(ctx context.Context, ownerId uuid.UUID) (res []domain.HookInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.hookRepo.FetchByOwnerId(c, ownerId)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.HookInfo, len(list))
+	for i, subscription := range list {
+		res[i] = domain.HookInfo{
+			Id:        subscription.Id,
+			Event:     subscription.Event,
+			TargetUrl: subscription.TargetUrl,
+			CreatedAt: subscription.CreatedAt,
+		}
+	}
+	return
+}
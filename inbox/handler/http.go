@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[INBOX] "
+)
+
+func NewInboxController(useCase domain.InboxUseCase) *InboxController {
+	return &InboxController{useCase: useCase}
+}
+
+type InboxController struct {
+	useCase domain.InboxUseCase
+}
+
+type DeadLetterResponse struct {
+	Id         uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Topic      string    `json:"topic" validate:"required" example:"payment.settled"`
+	MessageId  string    `json:"messageId" validate:"required" example:"settle-20211027-0001"`
+	Payload    string    `json:"payload" validate:"required" example:"{\"exOrderId\":\"20211027-0001\"}"`
+	Attempts   uint16    `json:"attempts" validate:"required" example:"5"`
+	LastError  *string   `json:"lastError" example:"user=a@b.c, not found"`
+	ReceivedAt time.Time `json:"receivedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name DeadLetterResponse
+
+// @Tags (Inbox) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 처리 실패 메시지 목록
+// @Description 재시도 횟수를 넘겨 dead letter 로 옮겨진 수신 메시지 목록, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} DeadLetterResponse "성공"
+// @Success 204 "처리 실패 메시지 없음"
+// @Router /inbox/dead [get]
+func (c *InboxController) fetchDeadLetters(ctx echo.Context) error {
+	list, err := c.useCase.FetchDeadLetters(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchDeadLetters, unhandled error useCase.FetchDeadLetters")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]DeadLetterResponse, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = DeadLetterResponse{
+			Id:         src.Id,
+			Topic:      src.Topic,
+			MessageId:  src.MessageId,
+			Payload:    src.Payload,
+			Attempts:   src.Attempts,
+			LastError:  src.LastError,
+			ReceivedAt: src.ReceivedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (Inbox) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 처리 실패 메시지 재처리
+// @Description dead letter 메시지를 한 번 더 처리하는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param message_id path string true "메시지 식별 아이디(UUID)"
+// @Success 204 "재처리 성공"
+// @Failure 404 {object} domain.ErrorResponse "처리 실패 메시지 없음"
+// @Failure 422 {object} domain.ErrorResponse "재처리 실패"
+// @Router /inbox/dead/{message_id}/retry [post]
+func (c *InboxController) retryDeadLetter(ctx echo.Context) error {
+	var req struct {
+		MessageId uuid.UUID `param:"messageId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "retry dead letter, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.RetryDeadLetter(ctx.Request().Context(), req.MessageId)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrInboxHandleFailed:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("id", req.MessageId).
+			Error(tag, "retryDeadLetter, unhandled error useCase.RetryDeadLetter")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *InboxController) Bind(e *echo.Echo) {
+	// ADMIN
+	e.GET("/inbox/dead", c.fetchDeadLetters,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/inbox/dead/:messageId/retry", c.retryDeadLetter,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/inbox/:topic", c.internalConsume)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+// internalConsume 브로커 브릿지(push 구독)가 호출, 2xx 가 아니면 같은 메시지를 다시 보냄
+func (c *InboxController) internalConsume(ctx echo.Context) error {
+	var req struct {
+		Topic     string          `json:"-" param:"topic" validate:"required"`
+		MessageId string          `json:"messageId" validate:"required,max=120"`
+		Payload   json.RawMessage `json:"payload" validate:"required"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "internalConsume data binding error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	}
+
+	err = c.useCase.Consume(ctx.Request().Context(), domain.ConsumeInboxMessage{
+		Topic:     req.Topic,
+		MessageId: req.MessageId,
+		Payload:   req.Payload,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "topic=" + req.Topic + ", no handler"})
+	case domain.ErrInboxHandleFailed:
+		return ctx.JSON(http.StatusServiceUnavailable, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "internalConsume, unhandled error useCase.Consume")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
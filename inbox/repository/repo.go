@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewInboxRepository(db *gorm.DB) domain.InboxRepository {
+	db.AutoMigrate(&domain.InboxMessage{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(inboxRepo domain.InboxTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) Claim(ctx context.Context, message *domain.InboxMessage) (res *domain.InboxMessage, err error) {
+	db := r.db.WithContext(ctx)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(message)
+	if result.Error != nil {
+		err = result.Error
+		return
+	}
+	if result.RowsAffected > 0 {
+		res = message
+		return
+	}
+
+	var entity domain.InboxMessage
+	err = db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("`topic` = ? AND `message_id` = ?", message.Topic, message.MessageId).
+		First(&entity).Error
+	if err == nil {
+		res = &entity
+	}
+	return
+}
+
+func (r *repo) Save(ctx context.Context, message *domain.InboxMessage) error {
+	return gormx.Upsert(ctx, r.db, message)
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (res *domain.InboxMessage, err error) {
+	var entity domain.InboxMessage
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetByTopicAndMessageId(ctx context.Context, topic, messageId string) (res *domain.InboxMessage, err error) {
+	var entity domain.InboxMessage
+	err = r.db.WithContext(ctx).
+		Where("`topic` = ? AND `message_id` = ?", topic, messageId).
+		First(&entity).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchByStatus(ctx context.Context, status domain.InboxMessageStatus) (list []domain.InboxMessage, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`received_at` desc").
+		Where("`status` = ?", status).
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewInboxUseCase(
+	inboxRepo domain.InboxRepository,
+	handlers domain.InboxHandlers,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.InboxUseCase {
+	return &ucase{
+		inboxRepo: inboxRepo,
+		handlers:  handlers,
+		ids:       ids,
+		clock:     clock,
+		timeout:   timeout,
+	}
+}
+
+type ucase struct {
+	inboxRepo domain.InboxRepository
+	handlers  domain.InboxHandlers
+	ids       domain.IdGenerator
+	clock     domain.Clock
+	timeout   time.Duration
+}
+
+func (u *ucase) handle(ctx context.Context, inboxRepo domain.InboxRepository, message *domain.InboxMessage) (err error) {
+	handler, ok := u.handlers[message.Topic]
+	if !ok {
+		return domain.ErrItemNotFound
+	}
+
+	handleErr := handler(ctx, []byte(message.Payload))
+	if handleErr != nil {
+		message.Failed(handleErr)
+	} else {
+		message.Processed(u.clock.Now())
+	}
+
+	err = inboxRepo.Save(ctx, message)
+	if err != nil {
+		return
+	}
+
+	if message.Status == domain.InboxMessageStatusFailed {
+		err = domain.ErrInboxHandleFailed
+	}
+	return
+}
+
+func (u *ucase) Consume(ctx context.Context, in domain.ConsumeInboxMessage) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if _, ok := u.handlers[in.Topic]; !ok {
+		return domain.ErrItemNotFound
+	}
+
+	newMessage := domain.CreateInboxMessage(domain.CreateInboxMessageOption{
+		Id:        u.ids.NewId(),
+		Topic:     in.Topic,
+		MessageId: in.MessageId,
+		Payload:   string(in.Payload),
+		Now:       u.clock.Now(),
+	})
+
+	// 처리 결과를 저장하고 커밋해야 실패 횟수가 남으므로 처리 실패는 트랜잭션 밖에서 돌려줌
+	var handleErr error
+	err = u.inboxRepo.Transaction(c, func(ir domain.InboxTxRepository) error {
+		message, err := ir.Claim(c, &newMessage)
+		if err != nil {
+			return err
+		}
+
+		if message.IsDone() {
+			return nil
+		}
+
+		err = u.handle(c, ir, message)
+		if err == domain.ErrInboxHandleFailed {
+			handleErr = err
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return
+	}
+	return handleErr
+}
+
+func (u *ucase) RetryDeadLetter(ctx context.Context, id uuid.UUID) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	message, err := u.inboxRepo.GetById(c, id)
+	if err != nil {
+		return
+	}
+
+	if message == nil || message.Status != domain.InboxMessageStatusDead {
+		return domain.ErrItemNotFound
+	}
+
+	message.Revive()
+	err = u.handle(c, u.inboxRepo, message)
+	if err == nil && message.Status == domain.InboxMessageStatusDead {
+		err = domain.ErrInboxHandleFailed
+	}
+	return
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchDeadLetters(ctx context.Context) (res []domain.InboxMessageInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.inboxRepo.FetchByStatus(c, domain.InboxMessageStatusDead)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.InboxMessageInfo, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = domain.InboxMessageInfo{
+			Id:         src.Id,
+			Topic:      src.Topic,
+			MessageId:  src.MessageId,
+			Payload:    src.Payload,
+			Attempts:   src.Attempts,
+			LastError:  src.LastError,
+			ReceivedAt: src.ReceivedAt,
+		}
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	requestTimeout = 15 * time.Second
+	errorBodyLimit = 1024
+)
+
+// doJSON body 가 nil 이 아니면 JSON 으로 보내고, out 이 nil 이 아니면 응답을 JSON 으로 읽음
+// 일시적인 실패(네트워크, 429, 5xx)는 policy 에 따라 다시 보냄, 시도마다 requestTimeout
+func doJSON(ctx context.Context, client *http.Client, policy retry.Policy, method, url string, header http.Header, body, out interface{}) error {
+	var raw []byte
+	if body != nil {
+		var err error
+		raw, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return retry.Do(ctx, policy, func(ctx context.Context) error {
+		c, cancel := budget.Slice(ctx, requestTimeout)
+		defer cancel()
+
+		var reader io.Reader
+		if raw != nil {
+			reader = bytes.NewReader(raw)
+		}
+
+		req, err := http.NewRequestWithContext(c, method, url, reader)
+		if err != nil {
+			return err
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		if raw != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			errBody, _ := io.ReadAll(io.LimitReader(res.Body, errorBodyLimit))
+			return &retry.StatusError{Service: method + " " + url, Code: res.StatusCode, Body: string(errBody)}
+		}
+
+		if out == nil {
+			return nil
+		}
+		return json.NewDecoder(res.Body).Decode(out)
+	})
+}
@@ -0,0 +1,210 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+const (
+	notionAPIURL  = "https://api.notion.com/v1"
+	notionVersion = "2022-06-28"
+	notionPage    = 100
+	// notionWriteInterval Notion API 는 통합 하나당 초당 3회 정도로 제한
+	notionWriteInterval = 350 * time.Millisecond
+)
+
+// NewNotionExporter 대상 데이터베이스를 내부 통합에 공유해야 함, 초당 호출 제한(429)은 policy 로 재시도
+func NewNotionExporter(policy retry.Policy) domain.IntegrationExporter {
+	return &notion{client: &http.Client{}, policy: policy}
+}
+
+type notion struct {
+	client *http.Client
+	policy retry.Policy
+}
+
+func (n *notion) ValidateCredential(credential []byte) error {
+	token := string(credential)
+	if token == "" || strings.ContainsAny(token, " \t\r\n") {
+		return domain.ErrWeirdData
+	}
+	return nil
+}
+
+type notionProperty struct {
+	Type string `json:"type"`
+}
+
+type notionText struct {
+	PlainText string `json:"plain_text"`
+}
+
+type notionQueryResponse struct {
+	Results []struct {
+		Id         string `json:"id"`
+		Properties map[string]struct {
+			Type     string       `json:"type"`
+			Title    []notionText `json:"title"`
+			RichText []notionText `json:"rich_text"`
+		} `json:"properties"`
+	} `json:"results"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// Push KeyColumn 속성(제목 또는 텍스트)으로 페이지를 찾아 갱신하고 없으면 추가
+// 데이터베이스에 없는 속성은 건너뜀, 값은 속성 종류에 맞춰 변환
+func (n *notion) Push(ctx context.Context, target string, credential []byte, table domain.IntegrationTable) error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+string(credential))
+	header.Set("Notion-Version", notionVersion)
+
+	var database struct {
+		Properties map[string]notionProperty `json:"properties"`
+	}
+	err := doJSON(ctx, n.client, n.policy, http.MethodGet, notionAPIURL+"/databases/"+target, header, nil, &database)
+	if err != nil {
+		return err
+	}
+
+	key, ok := database.Properties[table.KeyColumn]
+	if !ok || (key.Type != "title" && key.Type != "rich_text") {
+		return fmt.Errorf("notion database %s, key property %q must be title or rich_text", target, table.KeyColumn)
+	}
+
+	// 의뢰 하나만 보낼 때는 전체를 읽지 않고 키로 찾음
+	var filter interface{}
+	if len(table.Rows) == 1 {
+		filter = map[string]interface{}{
+			"property": table.KeyColumn,
+			key.Type:   map[string]string{"equals": table.Rows[0][table.KeyColumn]},
+		}
+	}
+	pages, err := n.pages(ctx, header, target, table.KeyColumn, filter)
+	if err != nil {
+		return err
+	}
+
+	for i, row := range table.Rows {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(notionWriteInterval):
+			}
+		}
+
+		properties := make(map[string]interface{}, len(table.Columns))
+		for _, name := range table.Columns {
+			property, ok := database.Properties[name]
+			if !ok {
+				continue
+			}
+			if value, ok := notionValue(property.Type, row[name]); ok {
+				properties[name] = map[string]interface{}{property.Type: value}
+			}
+		}
+
+		if pageId, ok := pages[row[table.KeyColumn]]; ok {
+			err = doJSON(ctx, n.client, n.policy, http.MethodPatch, notionAPIURL+"/pages/"+pageId, header, map[string]interface{}{
+				"properties": properties,
+			}, nil)
+		} else {
+			err = doJSON(ctx, n.client, n.policy, http.MethodPost, notionAPIURL+"/pages", header, map[string]interface{}{
+				"parent":     map[string]string{"database_id": target},
+				"properties": properties,
+			}, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pages 키 값별 페이지 아이디
+func (n *notion) pages(ctx context.Context, header http.Header, databaseId, keyColumn string, filter interface{}) (map[string]string, error) {
+	pages := make(map[string]string)
+
+	var cursor *string
+	for {
+		body := map[string]interface{}{"page_size": notionPage}
+		if filter != nil {
+			body["filter"] = filter
+		}
+		if cursor != nil {
+			body["start_cursor"] = *cursor
+		}
+
+		var res notionQueryResponse
+		err := doJSON(ctx, n.client, n.policy, http.MethodPost, notionAPIURL+"/databases/"+databaseId+"/query", header, body, &res)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range res.Results {
+			property := page.Properties[keyColumn]
+			texts := property.RichText
+			if property.Type == "title" {
+				texts = property.Title
+			}
+
+			var value strings.Builder
+			for _, text := range texts {
+				value.WriteString(text.PlainText)
+			}
+			if value.Len() > 0 {
+				pages[value.String()] = page.Id
+			}
+		}
+
+		if !res.HasMore || res.NextCursor == nil {
+			return pages, nil
+		}
+		cursor = res.NextCursor
+	}
+}
+
+// notionValue 빈 값은 속성을 비움, 지원하지 않는 속성 종류는 false
+func notionValue(propertyType, value string) (interface{}, bool) {
+	switch propertyType {
+	case "title", "rich_text":
+		texts := []interface{}{}
+		if value != "" {
+			texts = append(texts, map[string]interface{}{"text": map[string]string{"content": value}})
+		}
+		return texts, true
+	case "number":
+		if value == "" {
+			return nil, true
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, false
+		}
+		return number, true
+	case "date":
+		if value == "" {
+			return nil, true
+		}
+		return map[string]string{"start": value}, true
+	case "select", "status":
+		if value == "" {
+			return nil, true
+		}
+		return map[string]string{"name": value}, true
+	case "url", "email", "phone_number":
+		if value == "" {
+			return nil, true
+		}
+		return value, true
+	}
+	return nil, false
+}
@@ -0,0 +1,274 @@
+package adapter
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	sheetsAPIURL       = "https://sheets.googleapis.com/v4/spreadsheets"
+	sheetsScope        = "https://www.googleapis.com/auth/spreadsheets"
+	googleTokenURL     = "https://oauth2.googleapis.com/token"
+	serviceAccountTTL  = time.Hour
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+)
+
+// NewGoogleSheetsExporter 서비스 계정으로 인증, 대상 시트를 서비스 계정 이메일에 편집자로 공유해야 함
+func NewGoogleSheetsExporter(policy retry.Policy) domain.IntegrationExporter {
+	return &sheets{client: &http.Client{}, policy: policy}
+}
+
+type sheets struct {
+	client *http.Client
+	policy retry.Policy
+}
+
+// serviceAccountKey Google Cloud 콘솔에서 받은 서비스 계정 키(JSON) 중 필요한 값
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+func parseServiceAccountKey(credential []byte) (key serviceAccountKey, private *rsa.PrivateKey, err error) {
+	err = json.Unmarshal(credential, &key)
+	if err != nil || key.ClientEmail == "" {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			err = domain.ErrWeirdData
+		}
+		return
+	}
+
+	private, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		err = domain.ErrWeirdData
+	}
+	return
+}
+
+func (s *sheets) ValidateCredential(credential []byte) error {
+	_, _, err := parseServiceAccountKey(credential)
+	return err
+}
+
+// Push 시트 전체를 읽어 KeyColumn 으로 행을 찾아 매핑한 열만 덮어쓰고, 없는 행은 아래에 추가
+// 매핑하지 않은 열은 그대로 두므로 운영팀이 메모 열을 따로 둘 수 있음
+func (s *sheets) Push(ctx context.Context, target string, credential []byte, table domain.IntegrationTable) error {
+	spreadsheetId, sheetName, ok := splitSheetTarget(target)
+	if !ok {
+		return domain.ErrWeirdData
+	}
+
+	token, err := s.token(ctx, credential)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+
+	// 시트 이름에 공백이나 특수 문자가 있어도 되도록 작은따옴표로 감쌈
+	sheetRange := url.PathEscape("'" + strings.ReplaceAll(sheetName, "'", "''") + "'")
+	valuesURL := fmt.Sprintf("%s/%s/values/%s", sheetsAPIURL, url.PathEscape(spreadsheetId), sheetRange)
+
+	var current struct {
+		Values [][]string `json:"values"`
+	}
+	err = doJSON(ctx, s.client, s.policy, http.MethodGet, valuesURL+"?valueRenderOption=FORMATTED_VALUE", header, nil, &current)
+	if err != nil {
+		return err
+	}
+
+	grid := mergeSheet(current.Values, table)
+	return doJSON(ctx, s.client, s.policy, http.MethodPut, valuesURL+"?valueInputOption=RAW", header, struct {
+		MajorDimension string     `json:"majorDimension"`
+		Values         [][]string `json:"values"`
+	}{
+		MajorDimension: "ROWS",
+		Values:         grid,
+	}, nil)
+}
+
+func splitSheetTarget(target string) (spreadsheetId, sheetName string, ok bool) {
+	i := strings.Index(target, "/")
+	if i <= 0 || i == len(target)-1 {
+		return
+	}
+	return target[:i], target[i+1:], true
+}
+
+// mergeSheet 첫 행은 머리글, 없는 열은 오른쪽에 추가
+func mergeSheet(values [][]string, table domain.IntegrationTable) [][]string {
+	var head []string
+	if len(values) > 0 {
+		head = values[0]
+	}
+
+	columns := make(map[string]int, len(head))
+	for i, name := range head {
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	for _, name := range table.Columns {
+		if _, ok := columns[name]; !ok {
+			columns[name] = len(head)
+			head = append(head, name)
+		}
+	}
+
+	grid := make([][]string, 1, len(values)+len(table.Rows))
+	grid[0] = head
+	if len(values) > 1 {
+		grid = append(grid, values[1:]...)
+	}
+
+	keyIndex := columns[table.KeyColumn]
+	rows := make(map[string]int, len(grid))
+	for i := 1; i < len(grid); i++ {
+		if keyIndex < len(grid[i]) && grid[i][keyIndex] != "" {
+			rows[grid[i][keyIndex]] = i
+		}
+	}
+
+	for _, row := range table.Rows {
+		i, ok := rows[row[table.KeyColumn]]
+		if !ok {
+			i = len(grid)
+			rows[row[table.KeyColumn]] = i
+			grid = append(grid, nil)
+		}
+
+		cells := grid[i]
+		if len(cells) < len(head) {
+			cells = append(cells, make([]string, len(head)-len(cells))...)
+		}
+		for _, name := range table.Columns {
+			cells[columns[name]] = row[name]
+		}
+		grid[i] = cells
+	}
+
+	// 새로 추가한 열 때문에 짧아진 행도 머리글 길이에 맞춤
+	for i := range grid {
+		if len(grid[i]) < len(head) {
+			grid[i] = append(grid[i], make([]string, len(head)-len(grid[i]))...)
+		}
+	}
+	return grid
+}
+
+type googleTokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+// token 서비스 계정 JWT 로 access token 발급, 매 실행마다 새로 받음
+func (s *sheets) token(ctx context.Context, credential []byte) (token string, err error) {
+	key, private, err := parseServiceAccountKey(credential)
+	if err != nil {
+		return
+	}
+
+	tokenURL := key.TokenURI
+	if tokenURL == "" {
+		tokenURL = googleTokenURL
+	}
+
+	now := time.Now()
+	assertion, err := signJWT(private, map[string]interface{}{
+		"iss":   key.ClientEmail,
+		"scope": sheetsScope,
+		"aud":   tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(serviceAccountTTL).Unix(),
+	})
+	if err != nil {
+		return
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", jwtBearerGrantType)
+	form.Set("assertion", assertion)
+
+	var body googleTokenResponse
+	err = retry.Do(ctx, s.policy, func(ctx context.Context) error {
+		c, cancel := budget.Slice(ctx, requestTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(c, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		res, err := s.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return &retry.StatusError{Service: "google token", Code: res.StatusCode}
+		}
+		return json.NewDecoder(res.Body).Decode(&body)
+	})
+	if err != nil {
+		return
+	}
+	if body.AccessToken == "" {
+		err = errors.New("google token, empty access token")
+		return
+	}
+
+	token = body.AccessToken
+	return
+}
+
+func signJWT(private *rsa.PrivateKey, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[INTEGRATION] "
+)
+
+func NewIntegrationController(useCase domain.IntegrationUseCase) *IntegrationController {
+	return &IntegrationController{useCase: useCase}
+}
+
+type IntegrationController struct {
+	useCase domain.IntegrationUseCase
+}
+
+func (c *IntegrationController) Bind(e *echo.Echo) {
+	// ADMIN
+	e.GET("/integration", c.fetchIntegrations,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/integration", echox.UserID(c.createIntegration),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/integration/:integrationId", c.updateIntegration,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.DELETE("/integration/:integrationId", c.deleteIntegration,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/integration/push", c.internalPushIntegrations)
+}
+
+type IntegrationColumn struct {
+	Field  string `json:"field" validate:"required" example:"state" enums:"orderId,orderedAt,ordererName,channelName,assignee,state,dueDate,doneAt,canceledAt,remainingEditCount"`
+	Column string `json:"column" validate:"required" example:"진행 상태"`
+} // @name IntegrationColumn
+
+func mappingOf(list []IntegrationColumn) domain.IntegrationMapping {
+	mapping := make(domain.IntegrationMapping, len(list))
+	for i, src := range list {
+		mapping[i] = domain.IntegrationColumn{
+			Field:  domain.IntegrationField(src.Field),
+			Column: src.Column,
+		}
+	}
+	return mapping
+}
+
+type IntegrationResponse struct {
+	Id       uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name     string    `json:"name" validate:"required" example:"운영팀 의뢰 현황"`
+	Provider string    `json:"provider" validate:"required" example:"GOOGLE_SHEETS" enums:"GOOGLE_SHEETS,NOTION"`
+	// Target, GOOGLE_SHEETS 는 "<spreadsheetId>/<시트 이름>", NOTION 은 데이터베이스 아이디
+	Target       string              `json:"target" validate:"required" example:"1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms/의뢰"`
+	Mapping      []IntegrationColumn `json:"mapping" validate:"required"`
+	OnSchedule   bool                `json:"onSchedule" validate:"required" example:"true"`
+	OnEvent      bool                `json:"onEvent" validate:"required" example:"false"`
+	Enabled      bool                `json:"enabled" validate:"required" example:"true"`
+	LastPushedAt *time.Time          `json:"lastPushedAt" example:"2021-10-27T04:44:18+00:00"`
+	// LastError, 마지막 내보내기 실패 사유, 성공하면 null
+	LastError *string   `json:"lastError" example:"notion database ..., key property \"의뢰 번호\" must be title or rich_text"`
+	CreatedBy uuid.UUID `json:"createdBy" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name IntegrationResponse
+
+// @Tags (Integration) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 현황 내보내기 설정 목록
+// @Description Google Sheets, Notion 으로 의뢰 현황을 내보내는 설정, 자격 증명은 보여주지 않음, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} IntegrationResponse "성공"
+// @Success 204 "설정 없음"
+// @Router /integration [get]
+func (c *IntegrationController) fetchIntegrations(ctx echo.Context) error {
+	list, err := c.useCase.FetchIntegrations(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchIntegrations, unhandled error useCase.FetchIntegrations")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]IntegrationResponse, len(list))
+	for i, src := range list {
+		mapping := make([]IntegrationColumn, len(src.Mapping))
+		for j, column := range src.Mapping {
+			mapping[j] = IntegrationColumn{
+				Field:  string(column.Field),
+				Column: column.Column,
+			}
+		}
+
+		res[i] = IntegrationResponse{
+			Id:           src.Id,
+			Name:         src.Name,
+			Provider:     string(src.Provider),
+			Target:       src.Target,
+			Mapping:      mapping,
+			OnSchedule:   src.OnSchedule,
+			OnEvent:      src.OnEvent,
+			Enabled:      src.Enabled,
+			LastPushedAt: src.LastPushedAt,
+			LastError:    src.LastError,
+			CreatedBy:    src.CreatedBy,
+			CreatedAt:    src.CreatedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type CreateIntegrationRequest struct {
+	Name     string `json:"name" validate:"required,min=1,max=100" example:"운영팀 의뢰 현황"`
+	Provider string `json:"provider" validate:"required" example:"GOOGLE_SHEETS" enums:"GOOGLE_SHEETS,NOTION"`
+	// Target, GOOGLE_SHEETS 는 "<spreadsheetId>/<시트 이름>", NOTION 은 데이터베이스 아이디
+	Target string `json:"target" validate:"required,min=1,max=200" example:"1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms/의뢰"`
+	// Mapping, 내보낼 항목과 열(속성) 이름, orderId 는 행을 찾는 키라서 반드시 포함
+	Mapping []IntegrationColumn `json:"mapping" validate:"required"`
+	// OnSchedule, 주기적으로 의뢰 현황 전체를 내보냄
+	OnSchedule bool `json:"onSchedule" example:"true"`
+	// OnEvent, 의뢰 상태가 바뀔 때마다 해당 의뢰만 내보냄
+	OnEvent bool `json:"onEvent" example:"false"`
+	// Credential, GOOGLE_SHEETS 는 서비스 계정 키(JSON 문자열), NOTION 은 내부 통합 토큰
+	Credential string `json:"credential" validate:"required" example:"secret_..."`
+} // @name CreateIntegrationRequest
+
+type CreateIntegrationResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name CreateIntegrationResponse
+
+// @Tags (Integration) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 현황 내보내기 설정 추가
+// @Description 대상 시트는 서비스 계정 이메일에, Notion 데이터베이스는 내부 통합에 공유해야 함, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateIntegrationRequest true "내보내기 설정"
+// @Success 201 {object} CreateIntegrationResponse "추가 성공"
+// @Failure 400 {object} domain.ErrorResponse "없는 서비스, 항목, 중복 열 이름, orderId 누락, 잘못된 자격 증명"
+// @Router /integration [post]
+func (c *IntegrationController) createIntegration(ctx echo.Context, userId uuid.UUID) error {
+	var req CreateIntegrationRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create integration, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	newId, err := c.useCase.CreateIntegration(ctx.Request().Context(), domain.CreateIntegrationInput{
+		Name:       req.Name,
+		Provider:   domain.IntegrationProvider(req.Provider),
+		Target:     req.Target,
+		Mapping:    mappingOf(req.Mapping),
+		OnSchedule: req.OnSchedule,
+		OnEvent:    req.OnEvent,
+		Credential: req.Credential,
+		CreatedBy:  userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, CreateIntegrationResponse{Id: newId})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "createIntegration, unhandled error useCase.CreateIntegration")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateIntegrationRequest struct {
+	IntegrationId uuid.UUID           `param:"integrationId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name          string              `json:"name" validate:"required,min=1,max=100" example:"운영팀 의뢰 현황"`
+	Target        string              `json:"target" validate:"required,min=1,max=200" example:"1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms/의뢰"`
+	Mapping       []IntegrationColumn `json:"mapping" validate:"required"`
+	OnSchedule    bool                `json:"onSchedule" example:"true"`
+	OnEvent       bool                `json:"onEvent" example:"false"`
+	Enabled       bool                `json:"enabled" example:"true"`
+	// Credential, 비워두면 기존 자격 증명 유지
+	Credential string `json:"credential" example:""`
+} // @name UpdateIntegrationRequest
+
+// @Tags (Integration) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 현황 내보내기 설정 수정
+// @Description 서비스 종류는 바꿀 수 없음, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param integration_id path string true "설정 식별 아이디(UUID)"
+// @Param requestBody body UpdateIntegrationRequest true "수정할 설정"
+// @Success 204 "수정 성공"
+// @Failure 400 {object} domain.ErrorResponse "없는 항목, 중복 열 이름, orderId 누락, 잘못된 자격 증명"
+// @Failure 404 {object} domain.ErrorResponse "없는 설정"
+// @Router /integration/{integration_id} [put]
+func (c *IntegrationController) updateIntegration(ctx echo.Context) error {
+	var req UpdateIntegrationRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update integration, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateIntegration(ctx.Request().Context(), domain.UpdateIntegration{
+		IntegrationId: req.IntegrationId,
+		Name:          req.Name,
+		Target:        req.Target,
+		Mapping:       mappingOf(req.Mapping),
+		OnSchedule:    req.OnSchedule,
+		OnEvent:       req.OnEvent,
+		Enabled:       req.Enabled,
+		Credential:    req.Credential,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("integrationId", req.IntegrationId).
+			Error(tag, "updateIntegration, unhandled error useCase.UpdateIntegration")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Integration) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 현황 내보내기 설정 삭제
+// @Description 이미 내보낸 시트, 데이터베이스 내용은 그대로 둠, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param integration_id path string true "설정 식별 아이디(UUID)"
+// @Success 204 "삭제 성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 설정"
+// @Router /integration/{integration_id} [delete]
+func (c *IntegrationController) deleteIntegration(ctx echo.Context) error {
+	var req struct {
+		IntegrationId uuid.UUID `param:"integrationId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "delete integration, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.DeleteIntegration(ctx.Request().Context(), req.IntegrationId)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("integrationId", req.IntegrationId).
+			Error(tag, "deleteIntegration, unhandled error useCase.DeleteIntegration")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *IntegrationController) internalPushIntegrations(ctx echo.Context) error {
+	res, err := c.useCase.PushIntegrations(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalPushIntegrations, unhandled error useCase.PushIntegrations")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("pushed", res.Pushed).
+		WithField("failed", res.Failed).
+		Info(tag, "push integrations")
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"pushed": res.Pushed,
+		"failed": res.Failed,
+	})
+}
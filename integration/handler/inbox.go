@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewOrderEventInboxHandler order 토픽 이벤트 봉투의 aggregateId 로 의뢰 하나를 내보냄, 이벤트 종류는 구분하지 않음
+func NewOrderEventInboxHandler(useCase domain.IntegrationUseCase) domain.InboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var msg struct {
+			AggregateId uuid.UUID `json:"aggregateId"`
+		}
+
+		err := json.Unmarshal(payload, &msg)
+		if err != nil {
+			return err
+		}
+
+		return useCase.PushOrder(ctx, msg.AggregateId)
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewIntegrationRepository(db *gorm.DB) domain.IntegrationRepository {
+	db.AutoMigrate(&domain.Integration{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, integration *domain.Integration) error {
+	return gormx.Upsert(ctx, r.db, integration)
+}
+
+func (r *repo) Delete(ctx context.Context, integrationId uuid.UUID) (bool, error) {
+	res := r.db.WithContext(ctx).Delete(&domain.Integration{}, integrationId)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *repo) GetById(ctx context.Context, integrationId uuid.UUID) (integration *domain.Integration, err error) {
+	var entity domain.Integration
+	err = r.db.WithContext(ctx).First(&entity, integrationId).Error
+	if err == nil {
+		integration = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchAll(ctx context.Context) (list []domain.Integration, err error) {
+	err = r.db.WithContext(ctx).Order("created_at").Find(&list).Error
+	return
+}
+
+func (r *repo) FetchEnabled(ctx context.Context, onEvent bool) (list []domain.Integration, err error) {
+	column := "on_schedule"
+	if onEvent {
+		column = "on_event"
+	}
+	err = r.db.WithContext(ctx).
+		Where("enabled = ? AND "+column+" = ?", true, true).
+		Order("created_at").
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[INTEGRATION] "
+
+func NewIntegrationUseCase(
+	integrationRepo domain.IntegrationRepository,
+	orderRepo domain.OrderRepository,
+	customerRepo domain.CustomerRepository,
+	managerRepo domain.ManagerRepository,
+	orderStateRepo domain.OrderStateRepository,
+	exporters domain.IntegrationExporters,
+	cipher domain.CredentialCipher,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.IntegrationUseCase {
+	return &ucase{
+		integrationRepo: integrationRepo,
+		orderRepo:       orderRepo,
+		customerRepo:    customerRepo,
+		managerRepo:     managerRepo,
+		orderStateRepo:  orderStateRepo,
+		exporters:       exporters,
+		cipher:          cipher,
+		ids:             ids,
+		clock:           clock,
+		timeout:         timeout,
+	}
+}
+
+type ucase struct {
+	integrationRepo domain.IntegrationRepository
+	orderRepo       domain.OrderRepository
+	customerRepo    domain.CustomerRepository
+	managerRepo     domain.ManagerRepository
+	orderStateRepo  domain.OrderStateRepository
+	exporters       domain.IntegrationExporters
+	cipher          domain.CredentialCipher
+	ids             domain.IdGenerator
+	clock           domain.Clock
+	timeout         time.Duration
+}
+
+func (u *ucase) CreateIntegration(ctx context.Context, in domain.CreateIntegrationInput) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	exporter, ok := u.exporters[in.Provider]
+	if !ok {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	err = exporter.ValidateCredential([]byte(in.Credential))
+	if err != nil {
+		return
+	}
+
+	integration, err := domain.CreateIntegration(domain.CreateIntegrationOption{
+		Id:         u.ids.NewId(),
+		Name:       in.Name,
+		Provider:   in.Provider,
+		Target:     in.Target,
+		Mapping:    in.Mapping,
+		OnSchedule: in.OnSchedule,
+		OnEvent:    in.OnEvent,
+		CreatedBy:  in.CreatedBy,
+		Now:        u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	integration.Sealed, err = u.cipher.Seal([]byte(in.Credential), integration.AAD())
+	if err != nil {
+		return
+	}
+
+	err = u.integrationRepo.Save(c, &integration)
+	if err != nil {
+		return
+	}
+
+	newId = integration.Id
+	return
+}
+
+func (u *ucase) UpdateIntegration(ctx context.Context, in domain.UpdateIntegration) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	integration, err := u.integrationRepo.GetById(c, in.IntegrationId)
+	if err != nil {
+		return
+	}
+
+	if integration == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = integration.Update(in.Name, in.Target, in.Mapping, in.OnSchedule, in.OnEvent, u.clock.Now())
+	if err != nil {
+		return
+	}
+	integration.SetEnabled(in.Enabled, u.clock.Now())
+
+	if in.Credential != "" {
+		exporter, ok := u.exporters[integration.Provider]
+		if !ok {
+			err = domain.ErrWeirdData
+			return
+		}
+
+		err = exporter.ValidateCredential([]byte(in.Credential))
+		if err != nil {
+			return
+		}
+
+		integration.Sealed, err = u.cipher.Seal([]byte(in.Credential), integration.AAD())
+		if err != nil {
+			return
+		}
+	}
+
+	err = u.integrationRepo.Save(c, integration)
+	return
+}
+
+func (u *ucase) DeleteIntegration(ctx context.Context, integrationId uuid.UUID) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	deleted, err := u.integrationRepo.Delete(c, integrationId)
+	if err != nil {
+		return
+	}
+
+	if !deleted {
+		err = domain.ErrItemNotFound
+	}
+	return
+}
+
+func (u *ucase) PushIntegrations(ctx context.Context) (res domain.IntegrationPushRun, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.integrationRepo.FetchEnabled(c, false)
+	if err != nil || len(list) == 0 {
+		return
+	}
+
+	since := u.clock.Now().AddDate(0, 0, -domain.IntegrationSnapshotDays)
+	orders, err := u.orderRepo.FetchSnapshot(c, since, domain.IntegrationSnapshotLimit)
+	if err != nil {
+		return
+	}
+
+	snapshots, err := u.snapshotsOf(c, orders)
+	if err != nil {
+		return
+	}
+
+	// 하나가 실패해도 나머지는 계속, 실패한 설정은 다음 주기에 다시 보냄
+	for i := range list {
+		pushErr := u.push(c, &list[i], snapshots)
+		if pushErr == nil {
+			res.Pushed++
+		} else {
+			res.Failed++
+		}
+
+		err = u.integrationRepo.Save(c, &list[i])
+		if err != nil {
+			return
+		}
+	}
+
+	diagnostics.AddCounter("integration.pushed", uint64(res.Pushed))
+	diagnostics.AddCounter("integration.failed", uint64(res.Failed))
+	return
+}
+
+// PushOrder 하나라도 실패하면 에러를 돌려줘 inbox 가 다시 처리, 같은 의뢰 행을 덮어쓰므로 다시 보내도 괜찮음
+func (u *ucase) PushOrder(ctx context.Context, orderId uuid.UUID) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.integrationRepo.FetchEnabled(c, true)
+	if err != nil || len(list) == 0 {
+		return
+	}
+
+	order, err := u.orderRepo.GetById(c, orderId)
+	if err != nil {
+		return
+	}
+
+	// 임시 의뢰나 지워진 의뢰는 내보내지 않음
+	if order == nil || order.IsDraft {
+		return
+	}
+
+	snapshots, err := u.snapshotsOf(c, []domain.Order{*order})
+	if err != nil {
+		return
+	}
+
+	var failed error
+	for i := range list {
+		pushErr := u.push(c, &list[i], snapshots)
+		if pushErr != nil {
+			failed = pushErr
+		}
+
+		err = u.integrationRepo.Save(c, &list[i])
+		if err != nil {
+			return
+		}
+	}
+
+	err = failed
+	return
+}
+
+// push 결과는 설정에 기록, 저장은 호출 측에서
+func (u *ucase) push(ctx context.Context, integration *domain.Integration, snapshots []domain.OrderSnapshot) (err error) {
+	defer func() {
+		if err == nil {
+			integration.Pushed(u.clock.Now())
+			return
+		}
+
+		logx.From(ctx).WithError(err).
+			WithField("integrationId", integration.Id).
+			WithField("provider", integration.Provider).
+			Warn(tag, "push integration failed")
+		integration.PushFailed(err)
+	}()
+
+	exporter, ok := u.exporters[integration.Provider]
+	if !ok {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	credential, err := u.cipher.Open(integration.Sealed, integration.AAD())
+	if err != nil {
+		return
+	}
+
+	table := integration.MappingColumns().Table(snapshots)
+	err = exporter.Push(ctx, integration.Target, credential, table)
+	return
+}
+
+func (u *ucase) snapshotsOf(ctx context.Context, orders []domain.Order) (list []domain.OrderSnapshot, err error) {
+	var (
+		customerIds []uuid.UUID
+		managerIds  []uuid.UUID
+		stateIds    []uint8
+
+		customers = make(map[uuid.UUID]domain.Customer)
+		managers  = make(map[uuid.UUID]domain.Manager)
+		states    = make(map[uint8]domain.OrderState)
+	)
+	for _, order := range orders {
+		customerIds = append(customerIds, order.Orderer)
+		stateIds = append(stateIds, order.State)
+		if order.Assignee != nil {
+			managerIds = append(managerIds, *order.Assignee)
+		}
+	}
+
+	g, gc := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		cList, err := u.customerRepo.FetchByIds(gc, customerIds)
+		if err != nil {
+			return err
+		}
+
+		for i := range cList {
+			customers[cList[i].Id] = cList[i]
+		}
+		return nil
+	})
+	g.Go(func() error {
+		if len(managerIds) == 0 {
+			return nil
+		}
+
+		mList, err := u.managerRepo.FetchByIds(gc, managerIds)
+		if err != nil {
+			return err
+		}
+
+		for i := range mList {
+			managers[mList[i].Id] = mList[i]
+		}
+		return nil
+	})
+	g.Go(func() error {
+		sList, err := u.orderStateRepo.FetchByIds(gc, stateIds)
+		if err != nil {
+			return err
+		}
+
+		for i := range sList {
+			states[sList[i].Id] = sList[i]
+		}
+		return nil
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	list = make([]domain.OrderSnapshot, len(orders))
+	for i := range orders {
+		order := &orders[i]
+		customer := customers[order.Orderer]
+		list[i] = domain.OrderSnapshot{
+			OrderId:            order.Id,
+			OrderedAt:          order.OrderedAt,
+			OrdererName:        customer.Name,
+			ChannelName:        customer.ChannelName,
+			StateContent:       states[order.State].Content,
+			DueDate:            order.DueDate,
+			DoneAt:             order.DoneAt,
+			CanceledAt:         order.CanceledAt,
+			RemainingEditCount: order.RemainingEditCount(),
+		}
+		if order.Assignee != nil {
+			if manager, ok := managers[*order.Assignee]; ok {
+				list[i].AssigneeNickname = &manager.Nickname
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchIntegrations(ctx context.Context) (res []domain.IntegrationInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.integrationRepo.FetchAll(c)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.IntegrationInfo, len(list))
+	for i, integration := range list {
+		res[i] = domain.IntegrationInfo{
+			Id:           integration.Id,
+			Name:         integration.Name,
+			Provider:     integration.Provider,
+			Target:       integration.Target,
+			Mapping:      integration.MappingColumns(),
+			OnSchedule:   integration.OnSchedule,
+			OnEvent:      integration.OnEvent,
+			Enabled:      integration.Enabled,
+			LastPushedAt: integration.LastPushedAt,
+			LastError:    integration.LastError,
+			CreatedBy:    integration.CreatedBy,
+			CreatedAt:    integration.CreatedAt,
+		}
+	}
+	return
+}
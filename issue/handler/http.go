@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[ISSUE] "
+)
+
+func NewIssueController(useCase domain.IssueUseCase) *IssueController {
+	return &IssueController{useCase: useCase}
+}
+
+type IssueController struct {
+	useCase domain.IssueUseCase
+}
+
+type CreateIssueRequest struct {
+	// OrderId, 의뢰 식별 아이디
+	OrderId uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Category, 분류
+	// * QUALITY - 품질
+	// * DELAY - 지연
+	// * ETC - 기타
+	Category string `json:"category" validate:"required,eq=QUALITY|eq=DELAY|eq=ETC" example:"QUALITY" enums:"QUALITY,DELAY,ETC"`
+
+	// Content, 내용 길이 2000 제한
+	Content string `json:"content" validate:"required,max=2000" example:"자막이 누락되었습니다."`
+} // @name CreateIssueRequest
+
+type CreatedIssueResponse struct {
+	Id uuid.UUID `json:"issueId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name CreatedIssueResponse
+
+// @Tags (Issue) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 의뢰 이슈 등록
+// @Description 의뢰에 이슈(분쟁)를 등록하는 기능, 고객은 자기 의뢰에만 등록 가능
+// @Description 역할(role)이 'CUSTOMER', 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateIssueRequest true "이슈 등록 데이터 구조"
+// @Success 201 {object} CreatedIssueResponse "등록 완료"
+// @Router /issue [post]
+func (c *IssueController) createIssue(ctx echo.Context, userId uuid.UUID) error {
+	var req CreateIssueRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create issue, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	newId, err := c.useCase.ReportIssue(ctx.Request().Context(), domain.ReportIssue{
+		OrderId:  req.OrderId,
+		UserId:   userId,
+		Category: domain.IssueCategory(req.Category),
+		Content:  req.Content,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, CreatedIssueResponse{Id: newId})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "createIssue, unhandled error useCase.ReportIssue")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *IssueController) Bind(e *echo.Echo) {
+	// 이슈 등록
+	e.POST("/issue", echox.UserID(c.createIssue),
+		middleware.RequireRole(domain.CustomerUserRole, domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== ADMIN =====
+	e.GET("/issue", c.fetchIssue,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/issue/:issueId", c.getIssue,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PATCH("/issue/:issueId/status", c.updateIssueStatus,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	// 슈퍼 어드민에게 이관
+	e.POST("/issue/:issueId/escalate", c.escalateIssue,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/issue/:issueId/resolve", echox.UserID(c.resolveIssue),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// 미해결 이슈 현황
+	e.GET("/dashboard/issue", c.getIssueDashboard,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+}
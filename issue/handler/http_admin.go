@@ -0,0 +1,329 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type FetchIssueRequest struct {
+	Status    *string    `json:"-" query:"status" validate:"omitempty,eq=OPEN|eq=INVESTIGATING|eq=RESOLVED"`
+	Category  *string    `json:"-" query:"category" validate:"omitempty,eq=QUALITY|eq=DELAY|eq=ETC"`
+	Escalated *bool      `json:"-" query:"escalated"`
+	OrderId   *uuid.UUID `json:"-" query:"orderId"`
+}
+
+type IssueInfoResponse struct {
+	IssueId            uuid.UUID  `json:"issueId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderId            uuid.UUID  `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Reporter           uuid.UUID  `json:"reporter" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Category           string     `json:"category" validate:"required" example:"QUALITY" enums:"QUALITY,DELAY,ETC"`
+	Status             string     `json:"status" validate:"required" example:"OPEN" enums:"OPEN,INVESTIGATING,RESOLVED"`
+	Content            string     `json:"content" validate:"required" example:"자막이 누락되었습니다."`
+	Escalated          bool       `json:"escalated" validate:"required" example:"false"`
+	Compensation       *string    `json:"compensation" example:"FREE_REVISION" enums:"NONE,FREE_REVISION,CREDIT"`
+	CompensationCredit uint32     `json:"compensationCredit" example:"0"`
+	Resolution         *string    `json:"resolution" example:"재편집 진행"`
+	CreatedAt          time.Time  `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+	ResolvedAt         *time.Time `json:"resolvedAt" example:"2021-10-27T04:44:18+00:00"`
+} // @name IssueInfoResponse
+
+type IssueInfoListResponse []IssueInfoResponse
+
+func useCaseToIssueInfoResponse(src domain.IssueInfo) (res IssueInfoResponse) {
+	res = IssueInfoResponse{
+		IssueId:            src.IssueId,
+		OrderId:            src.OrderId,
+		Reporter:           src.Reporter,
+		Category:           string(src.Category),
+		Status:             string(src.Status),
+		Content:            src.Content,
+		Escalated:          src.Escalated,
+		CompensationCredit: src.CompensationCredit,
+		Resolution:         src.Resolution,
+		CreatedAt:          src.CreatedAt,
+		ResolvedAt:         src.ResolvedAt,
+	}
+
+	if src.Compensation != nil {
+		compensation := string(*src.Compensation)
+		res.Compensation = &compensation
+	}
+	return
+}
+
+// @Tags (Issue) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 이슈 목록
+// @Description 이슈 목록 가져오는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param status query string false "상태" Enums(OPEN, INVESTIGATING, RESOLVED)
+// @Param category query string false "분류" Enums(QUALITY, DELAY, ETC)
+// @Param escalated query boolean false "이관 여부"
+// @Param orderId query string false "의뢰 식별 아이디(UUID)"
+// @Success 200 {object} IssueInfoListResponse "성공"
+// @Success 204 "값이 없음"
+// @Router /issue [get]
+func (c *IssueController) fetchIssue(ctx echo.Context) error {
+	var req FetchIssueRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch issue, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	option := domain.FetchIssueOption{
+		Escalated: req.Escalated,
+		OrderId:   req.OrderId,
+	}
+	if req.Status != nil {
+		status := domain.IssueStatus(*req.Status)
+		option.Status = &status
+	}
+	if req.Category != nil {
+		category := domain.IssueCategory(*req.Category)
+		option.Category = &category
+	}
+
+	list, err := c.useCase.Fetch(ctx.Request().Context(), option)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetch issue, unhandled error useCase.Fetch")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make(IssueInfoListResponse, len(list))
+	for i := range list {
+		res[i] = useCaseToIssueInfoResponse(list[i])
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (Issue) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 이슈 상세 정보
+// @Description 이슈 상세 정보 가져오는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param issue_id path string true "이슈 식별 아이디(UUID)"
+// @Success 200 {object} IssueInfoResponse "성공"
+// @Router /issue/{issue_id} [get]
+func (c *IssueController) getIssue(ctx echo.Context) error {
+	var req struct {
+		IssueId uuid.UUID `json:"-" param:"issueId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get issue, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.GetIssue(ctx.Request().Context(), req.IssueId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, useCaseToIssueInfoResponse(res))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "getIssue, unhandled error useCase.GetIssue")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateIssueStatusRequest struct {
+	IssueId uuid.UUID `json:"-" param:"issueId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Status, 상태 (해결은 resolve 기능 사용)
+	Status string `json:"status" validate:"required,eq=OPEN|eq=INVESTIGATING" example:"INVESTIGATING" enums:"OPEN,INVESTIGATING"`
+} // @name UpdateIssueStatusRequest
+
+// @Tags (Issue) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 이슈 상태 변경
+// @Description 이슈 상태 변경 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param issue_id path string true "이슈 식별 아이디(UUID)"
+// @Param requestBody body UpdateIssueStatusRequest true "이슈 상태 변경 데이터 구조"
+// @Success 204 "변경 완료"
+// @Router /issue/{issue_id}/status [patch]
+func (c *IssueController) updateIssueStatus(ctx echo.Context) error {
+	var req UpdateIssueStatusRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update issue status, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateIssueStatus(ctx.Request().Context(), domain.UpdateIssueStatus{
+		IssueId: req.IssueId,
+		Status:  domain.IssueStatus(req.Status),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "already resolved"})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "updateIssueStatus, unhandled error useCase.UpdateIssueStatus")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Issue) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 이슈 슈퍼 어드민 이관
+// @Description 이슈를 슈퍼 어드민에게 이관하는 기능, 이관된 이슈는 슈퍼 어드민만 해결 가능
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param issue_id path string true "이슈 식별 아이디(UUID)"
+// @Success 204 "이관 완료"
+// @Router /issue/{issue_id}/escalate [post]
+func (c *IssueController) escalateIssue(ctx echo.Context) error {
+	var req struct {
+		IssueId uuid.UUID `json:"-" param:"issueId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "escalate issue, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.EscalateIssue(ctx.Request().Context(), domain.EscalateIssue{
+		IssueId: req.IssueId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: "already escalated"})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "already resolved"})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "escalateIssue, unhandled error useCase.EscalateIssue")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ResolveIssueRequest struct {
+	IssueId uuid.UUID `json:"-" param:"issueId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Resolution, 처리 내용
+	Resolution string `json:"resolution" validate:"required,max=2000" example:"재편집 진행"`
+
+	// Compensation, 보상
+	// * NONE - 없음
+	// * FREE_REVISION - 무료 수정 1회
+	// * CREDIT - 크레딧 지급
+	Compensation string `json:"compensation" validate:"required,eq=NONE|eq=FREE_REVISION|eq=CREDIT" example:"FREE_REVISION" enums:"NONE,FREE_REVISION,CREDIT"`
+
+	// Credit, 크레딧 지급 시 지급 크레딧
+	Credit uint32 `json:"credit" validate:"required_if=Compensation CREDIT" example:"0"`
+} // @name ResolveIssueRequest
+
+// @Tags (Issue) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 이슈 해결
+// @Description 이슈 해결 및 보상 지급 기능, 이관된 이슈는 'SUPER_ADMIN' 만 가능
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param issue_id path string true "이슈 식별 아이디(UUID)"
+// @Param requestBody body ResolveIssueRequest true "이슈 해결 데이터 구조"
+// @Success 204 "해결 완료"
+// @Router /issue/{issue_id}/resolve [post]
+func (c *IssueController) resolveIssue(ctx echo.Context, userId uuid.UUID) error {
+	var req ResolveIssueRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "resolve issue, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.ResolveIssue{
+		IssueId:      req.IssueId,
+		UserId:       userId,
+		Resolution:   req.Resolution,
+		Compensation: domain.IssueCompensation(req.Compensation),
+		Credit:       req.Credit,
+	}
+	err = c.useCase.ResolveIssue(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: "already resolved"})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "resolveIssue, unhandled error useCase.ResolveIssue")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type IssueDashboardResponse struct {
+	Open          int64            `json:"open" validate:"required" example:"3"`
+	Investigating int64            `json:"investigating" validate:"required" example:"1"`
+	Escalated     int64            `json:"escalated" validate:"required" example:"1"`
+	ByCategory    map[string]int64 `json:"byCategory" validate:"required"`
+} // @name IssueDashboardResponse
+
+// @Tags (Issue) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 미해결 이슈 현황
+// @Description 미해결 이슈 수를 상태, 분류별로 가져오는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} IssueDashboardResponse "성공"
+// @Router /dashboard/issue [get]
+func (c *IssueController) getIssueDashboard(ctx echo.Context) error {
+	res, err := c.useCase.GetDashboard(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getIssueDashboard, unhandled error useCase.GetDashboard")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	byCategory := make(map[string]int64, len(res.ByCategory))
+	for k, v := range res.ByCategory {
+		byCategory[string(k)] = v
+	}
+
+	return ctx.JSON(http.StatusOK, IssueDashboardResponse{
+		Open:          res.Open,
+		Investigating: res.Investigating,
+		Escalated:     res.Escalated,
+		ByCategory:    byCategory,
+	})
+}
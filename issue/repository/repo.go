@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewIssueRepository(db *gorm.DB) domain.IssueRepository {
+	db.AutoMigrate(&domain.Issue{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, issue *domain.Issue) error {
+	return gormx.Upsert(ctx, r.db, issue)
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.IssueTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (res *domain.Issue, err error) {
+	var entity domain.Issue
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) Fetch(ctx context.Context, option domain.FetchIssueOption) (list []domain.Issue, err error) {
+	db := r.db.WithContext(ctx).
+		Order("`created_at` desc")
+
+	if option.Status != nil {
+		db = db.Where("`status` = ?", *option.Status)
+	}
+
+	if option.Category != nil {
+		db = db.Where("`category` = ?", *option.Category)
+	}
+
+	if option.Escalated != nil {
+		if *option.Escalated {
+			db = db.Where("`escalated_at` IS NOT NULL")
+		} else {
+			db = db.Where("`escalated_at` IS NULL")
+		}
+	}
+
+	if option.OrderId != nil {
+		db = db.Where("`order_id` = ?", *option.OrderId)
+	}
+
+	err = db.Find(&list).Error
+	return
+}
+
+func (r *repo) CountUnresolved(ctx context.Context) (list []domain.IssueCount, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.Issue{}).
+		Select("`status`, `category`, `escalated_at` IS NOT NULL AS `escalated`, COUNT(*) AS `count`").
+		Where("`status` <> ?", domain.IssueStatusResolved).
+		Group("`status`, `category`, `escalated`").
+		Scan(&list).Error
+	return
+}
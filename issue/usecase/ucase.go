@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewIssueUseCase(
+	issueRepo domain.IssueRepository,
+	orderRepo domain.OrderRepository,
+	userRepo domain.UserRepository,
+	creditRepo domain.CreditRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.IssueUseCase {
+	return &ucase{
+		issueRepo:  issueRepo,
+		orderRepo:  orderRepo,
+		userRepo:   userRepo,
+		creditRepo: creditRepo,
+		ids:        ids,
+		clock:      clock,
+		timeout:    timeout,
+	}
+}
+
+type ucase struct {
+	issueRepo  domain.IssueRepository
+	orderRepo  domain.OrderRepository
+	userRepo   domain.UserRepository
+	creditRepo domain.CreditRepository
+	ids        domain.IdGenerator
+	clock      domain.Clock
+	timeout    time.Duration
+}
+
+func (u *ucase) ReportIssue(ctx context.Context, in domain.ReportIssue) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		user  *domain.User
+		order *domain.Order
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		user, err = u.userRepo.GetById(gc, in.UserId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		order, err = u.orderRepo.GetById(gc, in.OrderId)
+		if err != nil {
+			return
+		}
+
+		if order == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	// 고객은 자기 의뢰에만 이슈 등록 가능
+	if user.IsCustomer() && order.Orderer != user.Id {
+		err = domain.ErrNoPermission
+		return
+	}
+
+	issue := domain.CreateIssue(domain.CreateIssueOption{
+		Id:       u.ids.NewId(),
+		OrderId:  order.Id,
+		Reporter: user.Id,
+		Category: in.Category,
+		Content:  in.Content,
+		Now:      u.clock.Now(),
+	})
+
+	err = u.issueRepo.Save(c, &issue)
+	if err != nil {
+		return
+	}
+
+	newId = issue.Id
+	return
+}
+
+func (u *ucase) UpdateIssueStatus(ctx context.Context, in domain.UpdateIssueStatus) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	issue, err := u.issueRepo.GetById(c, in.IssueId)
+	if err != nil {
+		return
+	}
+
+	if issue == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	// 해결 처리는 보상 정보와 함께 ResolveIssue 로만 가능
+	if issue.IsResolved() || in.Status == domain.IssueStatusResolved {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	issue.UpdateStatus(in.Status, u.clock.Now())
+	return u.issueRepo.Save(c, issue)
+}
+
+func (u *ucase) EscalateIssue(ctx context.Context, in domain.EscalateIssue) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	issue, err := u.issueRepo.GetById(c, in.IssueId)
+	if err != nil {
+		return
+	}
+
+	if issue == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if issue.IsEscalated() {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	if issue.IsResolved() {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	issue.Escalate(u.clock.Now())
+	return u.issueRepo.Save(c, issue)
+}
+
+func (u *ucase) ResolveIssue(ctx context.Context, in domain.ResolveIssue) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		user  *domain.User
+		issue *domain.Issue
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		user, err = u.userRepo.GetById(gc, in.UserId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user,
+			domain.User.IsAdmin,
+			domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		issue, err = u.issueRepo.GetById(gc, in.IssueId)
+		if err != nil {
+			return
+		}
+
+		if issue == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	if issue.IsResolved() {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	// 이관된 이슈는 슈퍼 어드민만 해결 가능
+	if issue.IsEscalated() && !user.IsSuperAdmin() {
+		err = domain.ErrNoPermission
+		return
+	}
+
+	issue.Resolve(user.Id, in.Resolution, in.Compensation, in.Credit, u.clock.Now())
+
+	if in.Compensation == domain.IssueCompensationNone {
+		return u.issueRepo.Save(c, issue)
+	}
+
+	order, err := u.orderRepo.GetById(c, issue.OrderId)
+	if err != nil {
+		return
+	}
+
+	if order == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if in.Compensation == domain.IssueCompensationCredit {
+		reference := "issue:" + issue.Id.String()
+		tx := domain.EarnCredit(domain.EarnCreditOption{
+			Ids:        u.ids,
+			CustomerId: order.Orderer,
+			Source:     domain.CreditAccountCompensation,
+			Kind:       domain.CreditEntryKindEarn,
+			Amount:     int64(issue.CompensationCredit),
+			Reference:  &reference,
+			Memo:       issue.Resolution,
+			Now:        u.clock.Now(),
+		})
+		return u.creditRepo.Transaction(c, func(cr domain.CreditTxRepository) error {
+			err := cr.SaveTransaction(c, &tx)
+			if err != nil {
+				return err
+			}
+			return u.issueRepo.With(cr).Save(c, issue)
+		})
+	}
+
+	order.AddFreeRevision()
+	return u.orderRepo.Transaction(c, func(or domain.OrderTxRepository) error {
+		err := or.Save(c, order)
+		if err != nil {
+			return err
+		}
+		return u.issueRepo.With(or).Save(c, issue)
+	})
+}
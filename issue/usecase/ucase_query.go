@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) GetIssue(ctx context.Context, issueId uuid.UUID) (res domain.IssueInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	issue, err := u.issueRepo.GetById(c, issueId)
+	if err != nil {
+		return
+	}
+
+	if issue == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = domainToIssueInfo(*issue)
+	return
+}
+
+func (u *ucase) Fetch(ctx context.Context, option domain.FetchIssueOption) (res []domain.IssueInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.issueRepo.Fetch(c, option)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.IssueInfo, len(list))
+	for i := range list {
+		res[i] = domainToIssueInfo(list[i])
+	}
+	return
+}
+
+func (u *ucase) GetDashboard(ctx context.Context) (res domain.IssueDashboard, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.issueRepo.CountUnresolved(c)
+	if err != nil {
+		return
+	}
+
+	res.ByCategory = make(map[domain.IssueCategory]int64)
+	for i := range list {
+		src := list[i]
+		switch src.Status {
+		case domain.IssueStatusOpen:
+			res.Open += src.Count
+		case domain.IssueStatusInvestigating:
+			res.Investigating += src.Count
+		}
+
+		if src.Escalated {
+			res.Escalated += src.Count
+		}
+		res.ByCategory[src.Category] += src.Count
+	}
+	return
+}
+
+func domainToIssueInfo(src domain.Issue) domain.IssueInfo {
+	return domain.IssueInfo{
+		IssueId:            src.Id,
+		OrderId:            src.OrderId,
+		Reporter:           src.Reporter,
+		Category:           src.Category,
+		Status:             src.Status,
+		Content:            src.Content,
+		Escalated:          src.IsEscalated(),
+		Compensation:       src.Compensation,
+		CompensationCredit: src.CompensationCredit,
+		Resolution:         src.Resolution,
+		CreatedAt:          src.CreatedAt,
+		ResolvedAt:         src.ResolvedAt,
+	}
+}
@@ -0,0 +1,430 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[LEAD] "
+)
+
+func NewLeadController(useCase domain.LeadUseCase) *LeadController {
+	return &LeadController{useCase: useCase}
+}
+
+type LeadController struct {
+	useCase domain.LeadUseCase
+}
+
+func (c *LeadController) Bind(e *echo.Echo) {
+	// ===== ADMIN =====
+	e.GET("/lead", c.fetchLeads,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/lead/:leadId", c.getLead,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PATCH("/lead/:leadId/stage", echox.UserID(c.changeLeadStage),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// 월별, 유입 채널별 전환율
+	e.GET("/dashboard/lead", c.getLeadFunnel,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== ALL =====
+	// 가입 전 상담 문의 폼이라 인증 없음
+	e.POST("/lead", c.submitLead)
+}
+
+type LeadUtmResponse struct {
+	Source   string `json:"source" validate:"required" example:"instagram"`
+	Medium   string `json:"medium" validate:"required" example:"paid_social"`
+	Campaign string `json:"campaign" validate:"required" example:"2024_spring"`
+	Term     string `json:"term" validate:"required" example:""`
+	Content  string `json:"content" validate:"required" example:"reels_a"`
+} // @name LeadUtmResponse
+
+type LeadResponse struct {
+	Id      uuid.UUID       `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name    string          `json:"name" validate:"required" example:"홍길동"`
+	Email   string          `json:"email" validate:"required" example:"example@example.com"`
+	Mobile  string          `json:"mobile" validate:"required" example:"01012345678"`
+	Company string          `json:"company" validate:"required" example:"스톡폴리오"`
+	Message string          `json:"message" validate:"required" example:"유튜브 채널 영상 편집 문의드립니다."`
+	Utm     LeadUtmResponse `json:"utm" validate:"required"`
+	// Channel, 유입 채널 (utm source, 없으면 direct)
+	Channel    string     `json:"channel" validate:"required" example:"instagram"`
+	Stage      string     `json:"stage" validate:"required" example:"CONSULT" enums:"LEAD,CONSULT,TRIAL,PAID"`
+	CustomerId *uuid.UUID `json:"customerId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt  time.Time  `json:"createdAt" validate:"required" example:"2024-05-01T10:00:00+09:00"`
+	// ConsultedAt, TrialAt, PaidAt 각 단계로 바뀐 시각, 건너뛴 단계는 다음 단계와 같은 시각
+	ConsultedAt *time.Time `json:"consultedAt" example:"2024-05-02T14:00:00+09:00"`
+	TrialAt     *time.Time `json:"trialAt" example:"2024-05-03T09:00:00+09:00"`
+	PaidAt      *time.Time `json:"paidAt" example:"2024-05-10T18:00:00+09:00"`
+} // @name LeadResponse
+
+func responseOf(src domain.LeadInfo) LeadResponse {
+	return LeadResponse{
+		Id:      src.Id,
+		Name:    src.Name,
+		Email:   src.Email,
+		Mobile:  src.Mobile,
+		Company: src.Company,
+		Message: src.Message,
+		Utm: LeadUtmResponse{
+			Source:   src.Utm.Source,
+			Medium:   src.Utm.Medium,
+			Campaign: src.Utm.Campaign,
+			Term:     src.Utm.Term,
+			Content:  src.Utm.Content,
+		},
+		Channel:     src.Utm.Channel(),
+		Stage:       string(src.Stage),
+		CustomerId:  src.CustomerId,
+		CreatedAt:   src.CreatedAt,
+		ConsultedAt: src.ConsultedAt,
+		TrialAt:     src.TrialAt,
+		PaidAt:      src.PaidAt,
+	}
+}
+
+type SubmitLeadRequest struct {
+	Name    string `json:"name" validate:"required,min=1,max=60" example:"홍길동"`
+	Email   string `json:"email" validate:"required,email,max=320" example:"example@example.com"`
+	Mobile  string `json:"mobile" validate:"required,sf_mobile" example:"01012345678"`
+	Company string `json:"company" validate:"omitempty,max=100" example:"스톡폴리오"`
+	Message string `json:"message" validate:"omitempty,max=2000" example:"유튜브 채널 영상 편집 문의드립니다."`
+
+	// UtmSource 등, 폼이 열린 주소의 utm_* 쿼리 그대로
+	UtmSource   string `json:"utmSource" validate:"omitempty,max=100" example:"instagram"`
+	UtmMedium   string `json:"utmMedium" validate:"omitempty,max=100" example:"paid_social"`
+	UtmCampaign string `json:"utmCampaign" validate:"omitempty,max=100" example:"2024_spring"`
+	UtmTerm     string `json:"utmTerm" validate:"omitempty,max=100" example:""`
+	UtmContent  string `json:"utmContent" validate:"omitempty,max=100" example:"reels_a"`
+} // @name SubmitLeadRequest
+
+type SubmitLeadResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name SubmitLeadResponse
+
+// @Tags (Lead) 공용 기능
+// @Summary 상담 문의 (리드) 등록
+// @Description 가입 전 상담 문의 폼, 인증 없이 가능, 폼이 열린 주소의 UTM 파라미터를 함께 보내면 유입 채널별 전환율에 반영
+// @Accept json
+// @Produce json
+// @Param requestBody body SubmitLeadRequest true "문의 내용"
+// @Success 201 {object} SubmitLeadResponse "등록"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Router /lead [post]
+func (c *LeadController) submitLead(ctx echo.Context) error {
+	var req SubmitLeadRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "submit lead, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	id, err := c.useCase.SubmitLead(ctx.Request().Context(), domain.SubmitLead{
+		Name:    req.Name,
+		Email:   req.Email,
+		Mobile:  req.Mobile,
+		Company: req.Company,
+		Message: req.Message,
+		Utm: domain.LeadUtm{
+			Source:   req.UtmSource,
+			Medium:   req.UtmMedium,
+			Campaign: req.UtmCampaign,
+			Term:     req.UtmTerm,
+			Content:  req.UtmContent,
+		},
+	})
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "submitLead, unhandled error useCase.SubmitLead")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusCreated, SubmitLeadResponse{Id: id})
+}
+
+type FetchLeadsRequest struct {
+	Stage string `query:"stage" validate:"omitempty,oneof=LEAD CONSULT TRIAL PAID"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=500"`
+} // @name FetchLeadsRequest
+
+// @Tags (Lead) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 리드 목록
+// @Description 최근에 들어온 리드부터, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param stage query string false "단계" Enums(LEAD, CONSULT, TRIAL, PAID)
+// @Param before query string false "이 시각(RFC3339)보다 먼저 들어온 것만, 다음 쪽은 이전 목록의 마지막 createdAt"
+// @Param limit query int false "개수 (기본 50, 최대 500)"
+// @Success 200 {array} LeadResponse "성공"
+// @Success 204 "없음"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류, 잘못된 시각"
+// @Router /lead [get]
+func (c *LeadController) fetchLeads(ctx echo.Context) error {
+	var req FetchLeadsRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch leads, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	var before *time.Time
+	if raw := ctx.QueryParam("before"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		}
+		before = &parsed
+	}
+
+	var stage *domain.LeadStage
+	if req.Stage != "" {
+		s := domain.LeadStage(req.Stage)
+		stage = &s
+	}
+
+	list, err := c.useCase.FetchLeads(ctx.Request().Context(), domain.FetchLeads{
+		Stage:  stage,
+		Before: before,
+		Limit:  req.Limit,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "fetchLeads, unhandled error useCase.FetchLeads")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]LeadResponse, len(list))
+	for i := range list {
+		res[i] = responseOf(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (Lead) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 리드 조회
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param lead_id path string true "리드 아이디(UUID)"
+// @Success 200 {object} LeadResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 리드"
+// @Router /lead/{lead_id} [get]
+func (c *LeadController) getLead(ctx echo.Context) error {
+	var req struct {
+		LeadId uuid.UUID `param:"leadId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get lead, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	lead, err := c.useCase.GetLead(ctx.Request().Context(), req.LeadId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, responseOf(lead))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("leadId", req.LeadId).
+			Error(tag, "getLead, unhandled error useCase.GetLead")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ChangeLeadStageRequest struct {
+	LeadId uuid.UUID `json:"-" param:"leadId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Stage, 지금보다 뒤 단계만 가능
+	Stage string `json:"stage" validate:"required,oneof=CONSULT TRIAL PAID" example:"CONSULT" enums:"CONSULT,TRIAL,PAID"`
+	// CustomerId, 결제 단계일 때만, 가입한 고객 계정과 연결
+	CustomerId *uuid.UUID `json:"customerId" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name ChangeLeadStageRequest
+
+// @Tags (Lead) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 리드 단계 변경
+// @Description 리드 → 상담 → 체험 → 결제 순서로만 진행, 건너뛴 단계는 같은 시각에 지난 것으로 기록, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param lead_id path string true "리드 아이디(UUID)"
+// @Param requestBody body ChangeLeadStageRequest true "바꿀 단계"
+// @Success 200 {object} LeadResponse "변경 완료"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류, 같거나 이전 단계"
+// @Failure 404 {object} domain.ErrorResponse "없는 리드, 없는 고객"
+// @Router /lead/{lead_id}/stage [patch]
+func (c *LeadController) changeLeadStage(ctx echo.Context, userId uuid.UUID) error {
+	var req ChangeLeadStageRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "change lead stage, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	lead, err := c.useCase.ChangeLeadStage(ctx.Request().Context(), domain.ChangeLeadStage{
+		LeadId:     req.LeadId,
+		Stage:      domain.LeadStage(req.Stage),
+		CustomerId: req.CustomerId,
+		ChangedBy:  userId,
+	})
+
+	switch err {
+	case nil:
+		echox.Log(ctx).WithField("leadId", lead.Id).
+			WithField("stage", lead.Stage).
+			WithField("changedBy", userId).
+			Info(tag, "lead stage changed")
+		return ctx.JSON(http.StatusOK, responseOf(lead))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "stage can only move forward"})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("leadId", req.LeadId).
+			Error(tag, "changeLeadStage, unhandled error useCase.ChangeLeadStage")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type LeadFunnelRequest struct {
+	// From, To, KST 기준 월, 둘 다 포함
+	From string `query:"from" validate:"required" example:"2024-01"`
+	To   string `query:"to" validate:"required" example:"2024-06"`
+} // @name LeadFunnelRequest
+
+type LeadFunnelStatResponse struct {
+	Leads     int64 `json:"leads" validate:"required" example:"120"`
+	Consulted int64 `json:"consulted" validate:"required" example:"48"`
+	Trial     int64 `json:"trial" validate:"required" example:"20"`
+	Paid      int64 `json:"paid" validate:"required" example:"9"`
+	// ConsultRate, TrialRate, PaidRate, 바로 앞 단계 대비 (0 ~ 1)
+	ConsultRate float64 `json:"consultRate" validate:"required" example:"0.4"`
+	TrialRate   float64 `json:"trialRate" validate:"required" example:"0.4167"`
+	PaidRate    float64 `json:"paidRate" validate:"required" example:"0.45"`
+	// ConversionRate, 리드 대비 결제 (0 ~ 1)
+	ConversionRate float64 `json:"conversionRate" validate:"required" example:"0.075"`
+} // @name LeadFunnelStatResponse
+
+type LeadFunnelChannelResponse struct {
+	Channel string                 `json:"channel" validate:"required" example:"instagram"`
+	Stat    LeadFunnelStatResponse `json:"stat" validate:"required"`
+} // @name LeadFunnelChannelResponse
+
+type LeadFunnelMonthResponse struct {
+	Month    string                      `json:"month" validate:"required" example:"2024-05"`
+	Stat     LeadFunnelStatResponse      `json:"stat" validate:"required"`
+	Channels []LeadFunnelChannelResponse `json:"channels" validate:"required"`
+} // @name LeadFunnelMonthResponse
+
+type LeadFunnelResponse struct {
+	Total    LeadFunnelStatResponse      `json:"total" validate:"required"`
+	Months   []LeadFunnelMonthResponse   `json:"months" validate:"required"`
+	Channels []LeadFunnelChannelResponse `json:"channels" validate:"required"`
+} // @name LeadFunnelResponse
+
+func statResponseOf(src domain.LeadFunnelStat) LeadFunnelStatResponse {
+	return LeadFunnelStatResponse{
+		Leads:          src.Leads,
+		Consulted:      src.Consulted,
+		Trial:          src.Trial,
+		Paid:           src.Paid,
+		ConsultRate:    src.ConsultRate,
+		TrialRate:      src.TrialRate,
+		PaidRate:       src.PaidRate,
+		ConversionRate: src.ConversionRate,
+	}
+}
+
+func channelResponsesOf(src []domain.LeadFunnelChannel) []LeadFunnelChannelResponse {
+	res := make([]LeadFunnelChannelResponse, len(src))
+	for i := range src {
+		res[i] = LeadFunnelChannelResponse{
+			Channel: src[i].Channel,
+			Stat:    statResponseOf(src[i].Stat),
+		}
+	}
+	return res
+}
+
+// @Tags (Lead) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 리드 전환율
+// @Description 들어온 달(KST)별, 유입 채널(utm source)별로 지금 어느 단계까지 왔는지, 채널은 리드가 많은 순, 최대 24개월, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param from query string true "시작 월 (ex. 2024-01)"
+// @Param to query string true "끝 월, 포함 (ex. 2024-06)"
+// @Success 200 {object} LeadFunnelResponse "성공"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 월, 기간"
+// @Router /dashboard/lead [get]
+func (c *LeadController) getLeadFunnel(ctx echo.Context) error {
+	var req LeadFunnelRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get lead funnel, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	funnel, err := c.useCase.GetLeadFunnel(ctx.Request().Context(), req.From, req.To)
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "getLeadFunnel, unhandled error useCase.GetLeadFunnel")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	months := make([]LeadFunnelMonthResponse, len(funnel.Months))
+	for i, month := range funnel.Months {
+		months[i] = LeadFunnelMonthResponse{
+			Month:    month.Month,
+			Stat:     statResponseOf(month.Stat),
+			Channels: channelResponsesOf(month.Channels),
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, LeadFunnelResponse{
+		Total:    statResponseOf(funnel.Total),
+		Months:   months,
+		Channels: channelResponsesOf(funnel.Channels),
+	})
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewLeadRepository(db *gorm.DB) domain.LeadRepository {
+	db.AutoMigrate(&domain.Lead{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, lead *domain.Lead) error {
+	return gormx.Upsert(ctx, r.db, lead)
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (lead *domain.Lead, err error) {
+	var entity domain.Lead
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		lead = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) Fetch(ctx context.Context, option domain.FetchLeadOption) (list []domain.Lead, err error) {
+	query := r.db.WithContext(ctx)
+	if option.Stage != nil {
+		query = query.Where("`stage` = ?", *option.Stage)
+	}
+	if option.Before != nil {
+		query = query.Where("`created_at` < ?", *option.Before)
+	}
+
+	err = query.
+		Order("`created_at` desc").
+		Limit(option.Limit).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchByCreatedAt(ctx context.Context, from, to time.Time) (list []domain.Lead, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`created_at` >= ? AND `created_at` < ?", from, to).
+		Order("`created_at`").
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewLeadUseCase(
+	leadRepo domain.LeadRepository,
+	userRepo domain.UserRepository,
+	ids domain.IdGenerator,
+	calendar domain.Calendar,
+	timeout time.Duration,
+) domain.LeadUseCase {
+	return &ucase{
+		leadRepo: leadRepo,
+		userRepo: userRepo,
+		ids:      ids,
+		calendar: calendar,
+		timeout:  timeout,
+	}
+}
+
+type ucase struct {
+	leadRepo domain.LeadRepository
+	userRepo domain.UserRepository
+	ids      domain.IdGenerator
+	calendar domain.Calendar
+	timeout  time.Duration
+}
+
+func toInfo(src domain.Lead) domain.LeadInfo {
+	return domain.LeadInfo{
+		Id:          src.Id,
+		Name:        src.Name,
+		Email:       src.Email,
+		Mobile:      src.Mobile,
+		Company:     src.Company,
+		Message:     src.Message,
+		Utm:         src.Utm,
+		Stage:       src.Stage,
+		CustomerId:  src.CustomerId,
+		CreatedAt:   src.CreatedAt,
+		ConsultedAt: src.ConsultedAt,
+		TrialAt:     src.TrialAt,
+		PaidAt:      src.PaidAt,
+	}
+}
+
+func (u *ucase) SubmitLead(ctx context.Context, in domain.SubmitLead) (id uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	lead := domain.CreateLead(domain.CreateLeadOption{
+		Id:      u.ids.NewId(),
+		Name:    in.Name,
+		Email:   in.Email,
+		Mobile:  in.Mobile,
+		Company: in.Company,
+		Message: in.Message,
+		Utm:     in.Utm,
+		Now:     u.calendar.Now(),
+	})
+	err = u.leadRepo.Save(c, &lead)
+	if err != nil {
+		return
+	}
+
+	id = lead.Id
+	return
+}
+
+func (u *ucase) ChangeLeadStage(ctx context.Context, in domain.ChangeLeadStage) (res domain.LeadInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if in.CustomerId != nil && in.Stage != domain.LeadStagePaid {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	lead, err := u.leadRepo.GetById(c, in.LeadId)
+	if err != nil {
+		return
+	}
+
+	if lead == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if in.CustomerId != nil {
+		var user *domain.User
+		user, err = u.userRepo.GetById(c, *in.CustomerId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+			err = domain.ErrItemNotFound
+			return
+		}
+		lead.CustomerId = in.CustomerId
+	}
+
+	err = lead.ChangeStage(in.Stage, in.ChangedBy, u.calendar.Now())
+	if err != nil {
+		return
+	}
+
+	err = u.leadRepo.Save(c, lead)
+	if err != nil {
+		return
+	}
+
+	res = toInfo(*lead)
+	return
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) GetLead(ctx context.Context, id uuid.UUID) (res domain.LeadInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	lead, err := u.leadRepo.GetById(c, id)
+	if err != nil {
+		return
+	}
+
+	if lead == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = toInfo(*lead)
+	return
+}
+
+func (u *ucase) FetchLeads(ctx context.Context, in domain.FetchLeads) (res []domain.LeadInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if in.Stage != nil && !in.Stage.IsValid() {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = domain.LeadDefaultLimit
+	}
+
+	list, err := u.leadRepo.Fetch(c, domain.FetchLeadOption{
+		Stage:  in.Stage,
+		Before: in.Before,
+		Limit:  limit,
+	})
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.LeadInfo, len(list))
+	for i := range list {
+		res[i] = toInfo(list[i])
+	}
+	return
+}
+
+// GetLeadFunnel 들어온 달별로 묶어 지금 어느 단계까지 왔는지 셈, 빈 달도 포함
+func (u *ucase) GetLeadFunnel(ctx context.Context, from, to string) (res domain.LeadFunnelInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	loc := u.calendar.Location()
+	start, _, err := domain.ParseFinanceMonth(from, loc)
+	if err != nil {
+		return
+	}
+	last, end, err := domain.ParseFinanceMonth(to, loc)
+	if err != nil {
+		return
+	}
+
+	if last.Before(start) || !start.AddDate(0, domain.LeadFunnelMaxMonths, 0).After(last) {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	list, err := u.leadRepo.FetchByCreatedAt(c, start, end)
+	if err != nil {
+		return
+	}
+
+	var months []*monthFunnel
+	byMonth := map[string]*monthFunnel{}
+	for m := start; m.Before(end); m = m.AddDate(0, 1, 0) {
+		month := &monthFunnel{month: m.Format(domain.FinanceMonthLayout), channels: channelFunnel{}}
+		months = append(months, month)
+		byMonth[month.month] = month
+	}
+
+	channels := channelFunnel{}
+	for _, lead := range list {
+		month := byMonth[lead.CreatedAt.In(loc).Format(domain.FinanceMonthLayout)]
+		if month == nil {
+			continue
+		}
+
+		channel := lead.Utm.Channel()
+		res.Total.Add(lead)
+		month.stat.Add(lead)
+		month.channels.add(channel, lead)
+		channels.add(channel, lead)
+	}
+
+	res.Total.Rate()
+	res.Channels = channels.list()
+	res.Months = make([]domain.LeadFunnelMonth, len(months))
+	for i, month := range months {
+		month.stat.Rate()
+		res.Months[i] = domain.LeadFunnelMonth{
+			Month:    month.month,
+			Stat:     month.stat,
+			Channels: month.channels.list(),
+		}
+	}
+	return
+}
+
+type monthFunnel struct {
+	month    string
+	stat     domain.LeadFunnelStat
+	channels channelFunnel
+}
+
+type channelFunnel map[string]*domain.LeadFunnelStat
+
+func (f channelFunnel) add(channel string, lead domain.Lead) {
+	stat, ok := f[channel]
+	if !ok {
+		stat = &domain.LeadFunnelStat{}
+		f[channel] = stat
+	}
+	stat.Add(lead)
+}
+
+// list 리드가 많은 채널부터
+func (f channelFunnel) list() []domain.LeadFunnelChannel {
+	res := make([]domain.LeadFunnelChannel, 0, len(f))
+	for channel, stat := range f {
+		stat.Rate()
+		res = append(res, domain.LeadFunnelChannel{Channel: channel, Stat: *stat})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Stat.Leads != res[j].Stat.Leads {
+			return res[i].Stat.Leads > res[j].Stat.Leads
+		}
+		return res[i].Channel < res[j].Channel
+	})
+	return res
+}
@@ -1,5 +1,12 @@
 package main
 
+import (
+	"flag"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/config"
+)
+
 // @securityDefinitions.apikey Auth-Jwt-Bearer
 // @in header
 // @name Authorization
@@ -18,5 +25,18 @@ package main
 
 // @BasePath /
 func main() {
-	getApp().Start()
-}
\ No newline at end of file
+	flag.BoolVar(&config.MigrateAllowDestructive, "allow-destructive", false,
+		"allow destructive migrations (drop column, narrow type)")
+	flag.BoolVar(&config.MigrateDryRun, "migrate-dry-run", false,
+		"print migration SQL without executing it, then exit")
+	flag.Parse()
+
+	app := getApp()
+	if config.MigrateDryRun {
+		return
+	}
+	err := app.Start()
+	if err != nil {
+		log.WithError(err).Fatal("server stopped")
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[NOTIFICATION] "
+)
+
+func NewNotificationController(useCase domain.NotificationUseCase) *NotificationController {
+	return &NotificationController{useCase: useCase}
+}
+
+type NotificationController struct {
+	useCase domain.NotificationUseCase
+}
+
+func (c *NotificationController) Bind(e *echo.Echo) {
+	// ===== CUSTOMER =====
+	e.GET("/user/customer/me/marketing-consent", echox.UserID(c.fetchMyMarketingConsents),
+		middleware.RequireRole(domain.CustomerUserRole))
+	e.PUT("/user/customer/me/marketing-consent/:channel", echox.UserID(c.updateMarketingConsent),
+		middleware.RequireRole(domain.CustomerUserRole))
+
+	// ===== ADMIN =====
+	e.GET("/marketing-consent/report", c.marketingConsentReport,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/notifications/dispatch", c.internalDispatchNotifications)
+}
+
+func (c *NotificationController) internalDispatchNotifications(ctx echo.Context) error {
+	res, err := c.useCase.DispatchNotifications(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalDispatchNotifications, unhandled error useCase.DispatchNotifications")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("sent", res.Sent).
+		WithField("failed", res.Failed).
+		WithField("deferred", res.Deferred).
+		Info(tag, "dispatch notifications")
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"sent":     res.Sent,
+		"failed":   res.Failed,
+		"deferred": res.Deferred,
+	})
+}
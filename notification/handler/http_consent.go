@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type MarketingConsentResponse struct {
+	Channel string `json:"channel" validate:"required" example:"SMS" enums:"SMS,EMAIL"`
+	Agreed  bool   `json:"agreed" validate:"required" example:"true"`
+	// AgreedAt, 마지막으로 동의한 시각, WithdrawnAt 마지막으로 철회한 시각
+	AgreedAt    *time.Time `json:"agreedAt" example:"2021-10-27T04:44:18+00:00"`
+	WithdrawnAt *time.Time `json:"withdrawnAt"`
+} // @name MarketingConsentResponse
+
+func consentResponseOf(src domain.MarketingConsent) MarketingConsentResponse {
+	return MarketingConsentResponse{
+		Channel:     string(src.Channel),
+		Agreed:      src.Agreed,
+		AgreedAt:    src.AgreedAt,
+		WithdrawnAt: src.WithdrawnAt,
+	}
+}
+
+// @Tags (Notification) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 광고성 정보 수신 동의
+// @Description 채널마다 하나, 동의한 적 없는 채널은 agreed false, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} MarketingConsentResponse "성공"
+// @Router /user/customer/me/marketing-consent [get]
+func (c *NotificationController) fetchMyMarketingConsents(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.FetchMyMarketingConsents(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "fetchMyMarketingConsents, unhandled error useCase.FetchMyMarketingConsents")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := make([]MarketingConsentResponse, len(list))
+	for i, src := range list {
+		res[i] = consentResponseOf(src)
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type UpdateMarketingConsentRequest struct {
+	Channel string `param:"channel" json:"-" validate:"required,oneof=SMS EMAIL"`
+	Agreed  bool   `json:"agreed" example:"true"`
+} // @name UpdateMarketingConsentRequest
+
+// @Tags (Notification) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 광고성 정보 수신 동의 변경
+// @Description 채널별 동의, 철회, 시각과 요청 IP 를 남김, 같은 값이면 그대로, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Param channel path string true "채널" Enums(SMS, EMAIL)
+// @Param requestBody body UpdateMarketingConsentRequest true "동의 여부"
+// @Success 200 {object} MarketingConsentResponse "성공"
+// @Router /user/customer/me/marketing-consent/{channel} [put]
+func (c *NotificationController) updateMarketingConsent(ctx echo.Context, userId uuid.UUID) error {
+	var req UpdateMarketingConsentRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update marketing consent, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.UpdateMarketingConsent{
+		UserId:    userId,
+		Channel:   domain.NotificationChannel(req.Channel),
+		Agreed:    req.Agreed,
+		Ip:        ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+	consent, err := c.useCase.UpdateMarketingConsent(ctx.Request().Context(), in)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "updateMarketingConsent, unhandled error useCase.UpdateMarketingConsent")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, consentResponseOf(consent))
+}
+
+type MarketingConsentSummaryResponse struct {
+	Channel string `json:"channel" validate:"required" example:"SMS" enums:"SMS,EMAIL"`
+	// Agreed, 지금 동의한 고객 수
+	Agreed int64 `json:"agreed" validate:"required" example:"120"`
+	// Withdrawn, 철회했거나 동의하지 않겠다고 한 고객 수, 선택한 적 없는 고객은 세지 않음
+	Withdrawn int64 `json:"withdrawn" validate:"required" example:"15"`
+} // @name MarketingConsentSummaryResponse
+
+// @Tags (Notification) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 광고성 정보 수신 동의 현황
+// @Description 채널별 동의, 철회 고객 수, 삭제된 고객 제외, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} MarketingConsentSummaryResponse "성공"
+// @Router /marketing-consent/report [get]
+func (c *NotificationController) marketingConsentReport(ctx echo.Context) error {
+	list, err := c.useCase.MarketingConsentReport(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "marketingConsentReport, unhandled error useCase.MarketingConsentReport")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := make([]MarketingConsentSummaryResponse, len(list))
+	for i, src := range list {
+		res[i] = MarketingConsentSummaryResponse{
+			Channel:   string(src.Channel),
+			Agreed:    src.Agreed,
+			Withdrawn: src.Withdrawn,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewOrderEventNotificationInboxHandler 이 서버가 발행한 의뢰 이벤트 봉투를 받아 고객, 담당자, 지켜보는 관리자 알림으로 쌓음
+func NewOrderEventNotificationInboxHandler(useCase domain.NotificationUseCase) domain.InboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var msg struct {
+			Id   string                 `json:"id"`
+			Type domain.OutboxEventType `json:"type"`
+			// Data 의뢰 이벤트마다 있는 값만, 담당자 변경 이벤트의 assignee 도 같은 이름
+			Data struct {
+				OrderId   uuid.UUID   `json:"orderId"`
+				OrdererId uuid.UUID   `json:"ordererId"`
+				Assignee  *uuid.UUID  `json:"assignee"`
+				Watchers  []uuid.UUID `json:"watchers"`
+			} `json:"data"`
+		}
+
+		err := json.Unmarshal(payload, &msg)
+		if err != nil {
+			return err
+		}
+
+		if !domain.IsNotifiableEvent(msg.Type) {
+			return nil
+		}
+
+		managers := msg.Data.Watchers
+		if msg.Data.Assignee != nil {
+			managers = append([]uuid.UUID{*msg.Data.Assignee}, managers...)
+		}
+		return useCase.EnqueueOrderNotification(ctx, domain.EnqueueOrderNotification{
+			EventId:   msg.Id,
+			Event:     msg.Type,
+			OrderId:   msg.Data.OrderId,
+			OrdererId: msg.Data.OrdererId,
+			Managers:  managers,
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewMarketingConsentRepository(db *gorm.DB) domain.MarketingConsentRepository {
+	db.AutoMigrate(&domain.MarketingConsent{})
+	return &consentRepo{db: db}
+}
+
+type consentRepo struct {
+	db *gorm.DB
+}
+
+func (r *consentRepo) Save(ctx context.Context, consent *domain.MarketingConsent) error {
+	return gormx.Upsert(ctx, r.db, consent)
+}
+
+func (r *consentRepo) GetByUserIdAndChannel(ctx context.Context, userId uuid.UUID, channel domain.NotificationChannel) (consent *domain.MarketingConsent, err error) {
+	var entity domain.MarketingConsent
+	err = r.db.WithContext(ctx).
+		Where("`user_id` = ? AND `channel` = ?", userId, channel).
+		First(&entity).Error
+	if err == nil {
+		consent = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *consentRepo) FetchByUserId(ctx context.Context, userId uuid.UUID) (list []domain.MarketingConsent, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`user_id` = ?", userId).
+		Find(&list).Error
+	return
+}
+
+func (r *consentRepo) FetchAgreedUserIds(ctx context.Context, userIds []uuid.UUID, channel domain.NotificationChannel) (list []uuid.UUID, err error) {
+	if len(userIds) == 0 {
+		return
+	}
+	err = r.db.WithContext(ctx).
+		Model(&domain.MarketingConsent{}).
+		Where("`user_id` IN ? AND `channel` = ? AND `agreed` = ?", userIds, channel, true).
+		Pluck("`user_id`", &list).Error
+	return
+}
+
+func (r *consentRepo) Summarize(ctx context.Context) (list []domain.MarketingConsentSummary, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.MarketingConsent{}).
+		Select("`marketing_consent`.`channel` AS `channel`, " +
+			"SUM(`marketing_consent`.`agreed`) AS `agreed`, " +
+			"SUM(NOT `marketing_consent`.`agreed`) AS `withdrawn`").
+		Joins("JOIN `user` ON `user`.`id` = `marketing_consent`.`user_id` AND `user`.`deleted_at` IS NULL").
+		Group("`marketing_consent`.`channel`").
+		Order("`marketing_consent`.`channel`").
+		Scan(&list).Error
+	return
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewNotificationRepository(db *gorm.DB) domain.NotificationRepository {
+	db.AutoMigrate(&domain.Notification{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Create(ctx context.Context, list []domain.Notification) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&list).Error
+}
+
+func (r *repo) SaveAll(ctx context.Context, list []domain.Notification) error {
+	if len(list) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Save(&list).Error
+}
+
+// GetOpenDueAt 한 번도 보내지 않은 알림만, 재시도 중인 묶음에는 새 알림을 붙이지 않음
+func (r *repo) GetOpenDueAt(ctx context.Context, userId, orderId uuid.UUID, channel domain.NotificationChannel, category domain.NotificationCategory) (dueAt *time.Time, err error) {
+	var entity domain.Notification
+	err = r.db.WithContext(ctx).
+		Where("`user_id` = ? AND `order_id` = ? AND `channel` = ? AND `category` = ?", userId, orderId, channel, category).
+		Where("`due_at` IS NOT NULL AND `attempts` = 0").
+		Order("`due_at` asc").
+		First(&entity).Error
+	if err == nil {
+		dueAt = entity.DueAt
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchDue(ctx context.Context, now time.Time, limit int) (list []domain.Notification, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`due_at` <= ?", now).
+		Order("`due_at` asc").
+		Order("`created_at` asc").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
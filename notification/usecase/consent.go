@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchMyMarketingConsents(ctx context.Context, userId uuid.UUID) (res []domain.MarketingConsent, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.consentRepo.FetchByUserId(c, userId)
+	if err != nil {
+		return
+	}
+
+	byChannel := make(map[domain.NotificationChannel]domain.MarketingConsent, len(list))
+	for _, consent := range list {
+		byChannel[consent.Channel] = consent
+	}
+
+	res = make([]domain.MarketingConsent, len(domain.MarketingConsentChannels))
+	for i, channel := range domain.MarketingConsentChannels {
+		consent, ok := byChannel[channel]
+		if !ok {
+			consent = domain.MarketingConsent{UserId: userId, Channel: channel}
+		}
+		res[i] = consent
+	}
+	return
+}
+
+func (u *ucase) UpdateMarketingConsent(ctx context.Context, in domain.UpdateMarketingConsent) (res domain.MarketingConsent, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	consent, err := u.consentRepo.GetByUserIdAndChannel(c, in.UserId, in.Channel)
+	if err != nil {
+		return
+	}
+
+	if consent == nil {
+		consent = &domain.MarketingConsent{UserId: in.UserId, Channel: in.Channel}
+	}
+
+	if consent.Update(in.Agreed, in.Ip, in.UserAgent, u.clock.Now()) {
+		err = u.consentRepo.Save(c, consent)
+		if err != nil {
+			return
+		}
+	}
+
+	res = *consent
+	return
+}
+
+func (u *ucase) MarketingConsentReport(ctx context.Context) (res []domain.MarketingConsentSummary, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.consentRepo.Summarize(c)
+	if err != nil {
+		return
+	}
+
+	// 기록이 없는 채널도 0 으로
+	byChannel := make(map[domain.NotificationChannel]domain.MarketingConsentSummary, len(list))
+	for _, summary := range list {
+		byChannel[summary.Channel] = summary
+	}
+
+	res = make([]domain.MarketingConsentSummary, len(domain.MarketingConsentChannels))
+	for i, channel := range domain.MarketingConsentChannels {
+		summary, ok := byChannel[channel]
+		if !ok {
+			summary = domain.MarketingConsentSummary{Channel: channel}
+		}
+		res[i] = summary
+	}
+	return
+}
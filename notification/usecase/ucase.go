@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[NOTIFICATION] "
+
+func NewNotificationUseCase(
+	notificationRepo domain.NotificationRepository,
+	consentRepo domain.MarketingConsentRepository,
+	userRepo domain.UserRepository,
+	orderRepo domain.OrderRepository,
+	outboxRepo domain.OutboxRepository,
+	smsSender domain.SmsSender,
+	settingReader domain.SettingReader,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	calendar domain.Calendar,
+	timeout time.Duration,
+) domain.NotificationUseCase {
+	return &ucase{
+		notificationRepo: notificationRepo,
+		consentRepo:      consentRepo,
+		userRepo:         userRepo,
+		orderRepo:        orderRepo,
+		outboxRepo:       outboxRepo,
+		smsSender:        smsSender,
+		settingReader:    settingReader,
+		ids:              ids,
+		clock:            clock,
+		calendar:         calendar,
+		timeout:          timeout,
+	}
+}
+
+type ucase struct {
+	notificationRepo domain.NotificationRepository
+	consentRepo      domain.MarketingConsentRepository
+	userRepo         domain.UserRepository
+	orderRepo        domain.OrderRepository
+	outboxRepo       domain.OutboxRepository
+	smsSender        domain.SmsSender
+	settingReader    domain.SettingReader
+	ids              domain.IdGenerator
+	clock            domain.Clock
+	calendar         domain.Calendar
+	timeout          time.Duration
+}
+
+type notificationTarget struct {
+	userId  uuid.UUID
+	channel domain.NotificationChannel
+}
+
+func (u *ucase) EnqueueOrderNotification(ctx context.Context, in domain.EnqueueOrderNotification) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if !domain.IsNotifiableEvent(in.Event) {
+		return
+	}
+
+	targets := []notificationTarget{
+		{userId: in.OrdererId, channel: domain.NotificationChannelSms},
+		{userId: in.OrdererId, channel: domain.NotificationChannelEmail},
+	}
+	seen := map[uuid.UUID]bool{in.OrdererId: true}
+	for _, managerId := range in.Managers {
+		if seen[managerId] {
+			continue
+		}
+		seen[managerId] = true
+		targets = append(targets, notificationTarget{userId: managerId, channel: domain.NotificationChannelEmail})
+	}
+
+	windows := make(map[domain.NotificationChannel]time.Duration, len(domain.NotificationDigestSettings))
+	for channel, key := range domain.NotificationDigestSettings {
+		minutes, err := u.settingReader.Int(c, key)
+		if err != nil {
+			return err
+		}
+		windows[channel] = time.Duration(minutes) * time.Minute
+	}
+
+	now := u.clock.Now()
+	category := domain.NotificationCategoryOf(in.Event)
+	list := make([]domain.Notification, len(targets))
+	for i, target := range targets {
+		// 열려 있는 묶음이 있으면 그 묶음의 보낼 시간에 같이 보냄
+		dueAt, err := u.notificationRepo.GetOpenDueAt(c, target.userId, in.OrderId, target.channel, category)
+		if err != nil {
+			return err
+		}
+		if dueAt == nil {
+			next := now.Add(windows[target.channel])
+			dueAt = &next
+		}
+
+		list[i] = domain.CreateNotification(domain.CreateNotificationOption{
+			Id:       u.ids.NewId(),
+			UserId:   target.userId,
+			Channel:  target.channel,
+			Category: category,
+			EventId:  in.EventId,
+			Event:    in.Event,
+			OrderId:  in.OrderId,
+			DueAt:    *dueAt,
+			Now:      now,
+		})
+	}
+
+	return u.notificationRepo.Create(c, list)
+}
+
+func (u *ucase) DispatchNotifications(ctx context.Context) (res domain.NotificationDispatchRun, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.notificationRepo.FetchDue(c, u.clock.Now(), domain.NotificationDispatchBatch)
+	if err != nil || len(list) == 0 {
+		return
+	}
+
+	// 보낼 수 있는 시간이 아닌 묶음은 시간이 열릴 때로 미룸
+	windows, err := u.sendWindows(c)
+	if err != nil {
+		return
+	}
+	local := u.clock.Now().In(u.calendar.Location())
+
+	var digests []domain.NotificationDigest
+	for _, digest := range domain.DigestNotifications(list) {
+		window := windows[digest.Category]
+		if window.Allows(local) {
+			digests = append(digests, digest)
+			continue
+		}
+
+		next := window.NextOpen(local)
+		for _, item := range digest.Items {
+			item.Defer(next)
+		}
+		res.Deferred++
+	}
+
+	userIds := make([]uuid.UUID, 0, len(digests))
+	orderIds := make([]uuid.UUID, 0, len(digests))
+	for _, digest := range digests {
+		userIds = append(userIds, digest.UserId)
+		orderIds = append(orderIds, digest.OrderId)
+	}
+
+	var (
+		users     map[uuid.UUID]*domain.User
+		orders    map[uuid.UUID]*domain.Order
+		consented map[notificationTarget]bool
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		users, err = u.recipientsOf(gc, userIds)
+		return
+	})
+	g.Go(func() (err error) {
+		consented, err = u.consentedOf(gc, digests)
+		return
+	})
+	g.Go(func() (err error) {
+		list, err := u.orderRepo.FetchByIds(gc, orderIds)
+		if err != nil {
+			return
+		}
+
+		orders = make(map[uuid.UUID]*domain.Order, len(list))
+		for i := range list {
+			orders[list[i].Id] = &list[i]
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	var (
+		ran     = make([]bool, len(digests))
+		results = make([]error, len(digests))
+	)
+	pool, _ := workerpool.New(c, workerpool.Option{Size: domain.NotificationDispatchConcurrency})
+	for i := range digests {
+		i := i
+		pool.Go(func(ctx context.Context) error {
+			digest := digests[i]
+			var number *string
+			if order := orders[digest.OrderId]; order != nil {
+				number = order.Number
+			}
+
+			ran[i] = true
+			if digest.Category == domain.NotificationCategoryMarketing &&
+				!consented[notificationTarget{userId: digest.UserId, channel: digest.Channel}] {
+				results[i] = domain.ErrNotificationNoConsent
+				return nil
+			}
+			results[i] = u.send(ctx, digest, users[digest.UserId], digest.Text(number))
+			return nil
+		})
+	}
+	_ = pool.Wait()
+
+	// 시간이 다 되어 보내지 못한 묶음은 다음 실행에서 보냄
+	now := u.clock.Now()
+	for i, digest := range digests {
+		if !ran[i] {
+			continue
+		}
+
+		if results[i] == nil {
+			res.Sent++
+		} else {
+			logx.From(ctx).WithError(results[i]).
+				WithField("userId", digest.UserId).
+				WithField("orderId", digest.OrderId).
+				WithField("channel", digest.Channel).
+				Warn(tag, "send notification failed")
+			res.Failed++
+		}
+
+		for _, item := range digest.Items {
+			if results[i] == nil {
+				item.Sent(now)
+			} else {
+				item.Failed(results[i], now)
+			}
+		}
+	}
+
+	err = u.notificationRepo.SaveAll(c, list)
+	if err != nil {
+		return
+	}
+
+	diagnostics.AddCounter("notification.sent", uint64(res.Sent))
+	diagnostics.AddCounter("notification.failed", uint64(res.Failed))
+	diagnostics.AddCounter("notification.deferred", uint64(res.Deferred))
+	return
+}
+
+// sendWindows 분류별 보낼 수 있는 시간, 저장할 때 확인한 값이라 형식이 틀리면 오류
+func (u *ucase) sendWindows(ctx context.Context) (map[domain.NotificationCategory]domain.NotificationSendWindow, error) {
+	windows := make(map[domain.NotificationCategory]domain.NotificationSendWindow, len(domain.NotificationSendWindowSettings))
+	for category, key := range domain.NotificationSendWindowSettings {
+		value, err := u.settingReader.String(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		windows[category], err = domain.ParseNotificationSendWindow(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return windows, nil
+}
+
+// recipientsOf 삭제되지 않은 사용자만, 고객 정보 포함
+func (u *ucase) recipientsOf(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]*domain.User, error) {
+	users := make(map[uuid.UUID]*domain.User, len(userIds))
+	for _, userId := range userIds {
+		if _, ok := users[userId]; ok {
+			continue
+		}
+
+		user, err := u.userRepo.GetByIdWithCustomer(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		if !domain.CheckUserAlive(user) {
+			user = nil
+		}
+		users[userId] = user
+	}
+	return users, nil
+}
+
+// consentedOf 광고성 묶음을 받을 사용자 중 그 채널에 수신 동의한 사용자
+func (u *ucase) consentedOf(ctx context.Context, digests []domain.NotificationDigest) (map[notificationTarget]bool, error) {
+	byChannel := make(map[domain.NotificationChannel][]uuid.UUID)
+	for _, digest := range digests {
+		if digest.Category == domain.NotificationCategoryMarketing {
+			byChannel[digest.Channel] = append(byChannel[digest.Channel], digest.UserId)
+		}
+	}
+
+	consented := make(map[notificationTarget]bool)
+	for channel, userIds := range byChannel {
+		agreed, err := u.consentRepo.FetchAgreedUserIds(ctx, userIds, channel)
+		if err != nil {
+			return nil, err
+		}
+		for _, userId := range agreed {
+			consented[notificationTarget{userId: userId, channel: channel}] = true
+		}
+	}
+	return consented, nil
+}
+
+// send 문자는 바로 보내고, 메일은 메일 발송 서비스가 보내도록 outbox 이벤트로 저장
+func (u *ucase) send(ctx context.Context, digest domain.NotificationDigest, user *domain.User, text string) error {
+	if user == nil {
+		return domain.ErrNotificationNoRecipient
+	}
+
+	switch digest.Channel {
+	case domain.NotificationChannelSms:
+		if user.Customer == nil || user.Customer.Mobile == "" {
+			return domain.ErrNotificationNoRecipient
+		}
+		return u.smsSender.Send(ctx, user.Customer.Mobile, text)
+	case domain.NotificationChannelEmail:
+		// 아이디와 같은 프로필 이메일이 받을 수 없는 주소로 표시됐으면 보내지 않음
+		if user.Customer != nil && user.Customer.EmailUndeliverable() && strings.EqualFold(user.Customer.Email, user.Username) {
+			return domain.ErrNotificationNoRecipient
+		}
+		event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+			Id:            u.ids.NewId(),
+			AggregateType: domain.OutboxAggregateTypeNotification,
+			AggregateId:   user.Id,
+			EventType:     domain.OutboxEventTypeNotificationEmailRequested,
+			Data: domain.NotificationEmailRequestedEvent{
+				UserId:  user.Id,
+				Email:   user.Username,
+				OrderId: digest.OrderId,
+				Text:    text,
+			},
+			Now: u.clock.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.Save(ctx, &event)
+	}
+	return domain.ErrNotificationNoRecipient
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[OPS_ALERT] "
+)
+
+func NewOpsAlertController(useCase domain.OpsAlertUseCase) *OpsAlertController {
+	return &OpsAlertController{useCase: useCase}
+}
+
+type OpsAlertController struct {
+	useCase domain.OpsAlertUseCase
+}
+
+func (c *OpsAlertController) Bind(e *echo.Echo) {
+	// ===== SUPER ADMIN =====
+	e.GET("/ops-alert", c.fetchOpsAlerts,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+
+	// ===== INTERNAL =====
+	// 스케줄러가 주기적으로 호출
+	e.POST("/internal/ops-alert/evaluate", c.internalEvaluateOpsAlerts)
+}
+
+type OpsAlertResponse struct {
+	// Rule, ORDER_IDLE: 업무 시간 의뢰 요청 없음, SIGN_IN_FAILURE_SPIKE: 로그인 실패 급증, PAYMENT_SILENCE: 결제 완료 메시지 끊김
+	Rule   domain.OpsAlertRule `json:"rule" validate:"required" example:"ORDER_IDLE"`
+	Firing bool                `json:"firing" validate:"required" example:"true"`
+	// Detail, 마지막으로 울렸을 때 측정값
+	Detail     string     `json:"detail" validate:"required" example:"최근 120분 동안 의뢰 요청 없음"`
+	FiredAt    *time.Time `json:"firedAt" example:"2024-05-03T14:00:00+09:00"`
+	ResolvedAt *time.Time `json:"resolvedAt" example:"2024-05-03T14:30:00+09:00"`
+	// CheckedAt, 마지막 평가 시각, 평가한 적 없으면 null
+	CheckedAt *time.Time `json:"checkedAt" example:"2024-05-03T14:35:00+09:00"`
+} // @name OpsAlertResponse
+
+// @Tags (OpsAlert) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 운영 알림 상태
+// @Description 규칙별 지금 상태, 기준값은 설정(alert.*)에서 바꿈, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} OpsAlertResponse "성공"
+// @Router /ops-alert [get]
+func (c *OpsAlertController) fetchOpsAlerts(ctx echo.Context) error {
+	list, err := c.useCase.FetchOpsAlerts(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchOpsAlerts, unhandled error useCase.FetchOpsAlerts")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := make([]OpsAlertResponse, len(list))
+	for i, alert := range list {
+		res[i] = OpsAlertResponse{
+			Rule:       alert.Rule,
+			Firing:     alert.Firing,
+			Detail:     alert.Detail,
+			FiredAt:    alert.FiredAt,
+			ResolvedAt: alert.ResolvedAt,
+			CheckedAt:  alert.CheckedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+func (c *OpsAlertController) internalEvaluateOpsAlerts(ctx echo.Context) error {
+	count, err := c.useCase.EvaluateOpsAlerts(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("changed", count).
+			Error(tag, "internalEvaluateOpsAlerts, unhandled error useCase.EvaluateOpsAlerts")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"changed": count,
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewOpsAlertRepository(db *gorm.DB) domain.OpsAlertRepository {
+	db.AutoMigrate(&domain.OpsAlert{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.OpsAlertTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(alertRepo domain.OpsAlertTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) Save(ctx context.Context, alert *domain.OpsAlert) error {
+	return gormx.Upsert(ctx, r.db, alert)
+}
+
+func (r *repo) FetchAll(ctx context.Context) (list []domain.OpsAlert, err error) {
+	err = r.db.WithContext(ctx).Find(&list).Error
+	return
+}
+
+func (r *repo) CountOrdersSince(ctx context.Context, since time.Time) (count int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.Order{}).
+		Where("`is_draft` = ? AND `ordered_at` >= ?", false, since).
+		Count(&count).Error
+	return
+}
+
+func (r *repo) LastInboxReceivedAt(ctx context.Context, topic string) (at *time.Time, err error) {
+	var last sql.NullTime
+	err = r.db.WithContext(ctx).
+		Model(&domain.InboxMessage{}).
+		Select("MAX(`received_at`)").
+		Where("`topic` = ?", topic).
+		Scan(&last).Error
+	if err == nil && last.Valid {
+		at = &last.Time
+	}
+	return
+}
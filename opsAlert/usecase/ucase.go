@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewOpsAlertUseCase(
+	alertRepo domain.OpsAlertRepository,
+	signInFailureRepo domain.SignInFailureRepository,
+	outboxRepo domain.OutboxRepository,
+	settingReader domain.SettingReader,
+	ids domain.IdGenerator,
+	calendar domain.Calendar,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.OpsAlertUseCase {
+	return &ucase{
+		alertRepo:         alertRepo,
+		signInFailureRepo: signInFailureRepo,
+		outboxRepo:        outboxRepo,
+		settingReader:     settingReader,
+		ids:               ids,
+		calendar:          calendar,
+		clock:             clock,
+		timeout:           timeout,
+	}
+}
+
+type ucase struct {
+	alertRepo         domain.OpsAlertRepository
+	signInFailureRepo domain.SignInFailureRepository
+	outboxRepo        domain.OutboxRepository
+	settingReader     domain.SettingReader
+	ids               domain.IdGenerator
+	calendar          domain.Calendar
+	clock             domain.Clock
+	timeout           time.Duration
+}
+
+// EvaluateOpsAlerts 규칙마다 상태와 이벤트를 같은 트랜잭션으로 저장, 실패하면 그때까지 바뀐 수와 함께 반환
+func (u *ucase) EvaluateOpsAlerts(ctx context.Context) (count int, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	states, err := u.fetchStates(c)
+	if err != nil {
+		return
+	}
+
+	now := u.calendar.Now()
+	for _, rule := range domain.OpsAlertRules {
+		var firing bool
+		var detail string
+		firing, detail, err = u.evaluate(c, rule, now)
+		if err != nil {
+			return
+		}
+
+		alert, ok := states[rule]
+		if !ok {
+			alert = domain.OpsAlert{Rule: rule}
+		}
+
+		if !alert.Check(firing, detail, u.ids.NewId(), now) {
+			err = u.alertRepo.Save(c, &alert)
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		var event domain.OutboxEvent
+		event, err = u.eventOf(alert)
+		if err != nil {
+			return
+		}
+
+		err = u.alertRepo.Transaction(c, func(alertRepo domain.OpsAlertTxRepository) error {
+			err := alertRepo.Save(c, &alert)
+			if err != nil {
+				return err
+			}
+			return u.outboxRepo.With(alertRepo).Save(c, &event)
+		})
+		if err != nil {
+			return
+		}
+		count++
+	}
+	return
+}
+
+func (u *ucase) evaluate(ctx context.Context, rule domain.OpsAlertRule, now time.Time) (firing bool, detail string, err error) {
+	switch rule {
+	case domain.OpsAlertRuleOrderIdle:
+		return u.evaluateOrderIdle(ctx, now)
+	case domain.OpsAlertRuleSignInFailureSpike:
+		return u.evaluateSignInFailureSpike(ctx, now)
+	case domain.OpsAlertRulePaymentSilence:
+		return u.evaluatePaymentSilence(ctx, now)
+	}
+	return
+}
+
+// evaluateOrderIdle 평일 업무 시간, 그리고 지켜볼 기간 전체가 오늘 업무 시작 뒤일 때만 평가, 그 밖에는 풀림
+func (u *ucase) evaluateOrderIdle(ctx context.Context, now time.Time) (firing bool, detail string, err error) {
+	minutes, err := u.settingReader.Int(ctx, domain.SettingKeyAlertOrderIdleMinutes)
+	if err != nil || minutes <= 0 {
+		return
+	}
+	startHour, err := u.settingReader.Int(ctx, domain.SettingKeyAlertBusinessStartHour)
+	if err != nil {
+		return
+	}
+	endHour, err := u.settingReader.Int(ctx, domain.SettingKeyAlertBusinessEndHour)
+	if err != nil {
+		return
+	}
+
+	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+		return
+	}
+	if int64(now.Hour()) < startHour || int64(now.Hour()) >= endHour {
+		return
+	}
+
+	since := now.Add(-time.Duration(minutes) * time.Minute)
+	businessStart := time.Date(now.Year(), now.Month(), now.Day(), int(startHour), 0, 0, 0, now.Location())
+	if since.Before(businessStart) {
+		return
+	}
+
+	count, err := u.alertRepo.CountOrdersSince(ctx, since)
+	if err != nil {
+		return
+	}
+	return count == 0, fmt.Sprintf("최근 %d분 동안 의뢰 요청 없음", minutes), nil
+}
+
+func (u *ucase) evaluateSignInFailureSpike(ctx context.Context, now time.Time) (firing bool, detail string, err error) {
+	limit, err := u.settingReader.Int(ctx, domain.SettingKeyAlertSignInFailureLimit)
+	if err != nil || limit <= 0 {
+		return
+	}
+
+	count, err := u.signInFailureRepo.CountSince(ctx, now.Add(-domain.OpsAlertSignInFailureWindow))
+	if err != nil {
+		return
+	}
+	return count >= limit, fmt.Sprintf("최근 %d분 동안 로그인 실패 %d회", int(domain.OpsAlertSignInFailureWindow/time.Minute), count), nil
+}
+
+// evaluatePaymentSilence 결제 완료 메시지를 받은 적이 없으면 평가하지 않음
+func (u *ucase) evaluatePaymentSilence(ctx context.Context, now time.Time) (firing bool, detail string, err error) {
+	minutes, err := u.settingReader.Int(ctx, domain.SettingKeyAlertPaymentSilenceMinutes)
+	if err != nil || minutes <= 0 {
+		return
+	}
+
+	last, err := u.alertRepo.LastInboxReceivedAt(ctx, domain.InboxTopicPaymentSettled)
+	if err != nil || last == nil {
+		return
+	}
+
+	silence := now.Sub(*last)
+	return silence >= time.Duration(minutes)*time.Minute,
+		fmt.Sprintf("%d분 동안 결제 완료 메시지 없음, 마지막 %s", int64(silence/time.Minute), last.In(u.calendar.Location()).Format(time.RFC3339)), nil
+}
+
+func (u *ucase) eventOf(alert domain.OpsAlert) (domain.OutboxEvent, error) {
+	eventType := domain.OutboxEventTypeOpsAlertFired
+	if !alert.Firing {
+		eventType = domain.OutboxEventTypeOpsAlertResolved
+	}
+
+	return domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeOps,
+		AggregateId:   *alert.IncidentId,
+		EventType:     eventType,
+		Data: domain.OpsAlertEvent{
+			IncidentId: *alert.IncidentId,
+			Rule:       alert.Rule,
+			Detail:     alert.Detail,
+			FiredAt:    *alert.FiredAt,
+			ResolvedAt: alert.ResolvedAt,
+		},
+		Now: u.clock.Now(),
+	})
+}
+
+func (u *ucase) fetchStates(ctx context.Context) (map[domain.OpsAlertRule]domain.OpsAlert, error) {
+	list, err := u.alertRepo.FetchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[domain.OpsAlertRule]domain.OpsAlert, len(list))
+	for _, alert := range list {
+		states[alert.Rule] = alert
+	}
+	return states, nil
+}
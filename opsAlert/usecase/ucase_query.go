@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchOpsAlerts(ctx context.Context) (list []domain.OpsAlertInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	states, err := u.fetchStates(c)
+	if err != nil {
+		return
+	}
+
+	list = make([]domain.OpsAlertInfo, 0, len(domain.OpsAlertRules))
+	for _, rule := range domain.OpsAlertRules {
+		info := domain.OpsAlertInfo{Rule: rule}
+		if alert, ok := states[rule]; ok {
+			checkedAt := alert.CheckedAt
+			info.Firing = alert.Firing
+			info.Detail = alert.Detail
+			info.FiredAt = alert.FiredAt
+			info.ResolvedAt = alert.ResolvedAt
+			info.CheckedAt = &checkedAt
+		}
+		list = append(list, info)
+	}
+	return
+}
@@ -2,7 +2,7 @@ package handler
 
 import (
 	"github.com/labstack/echo/v4"
-	"github.com/stockfolioofficial/back-editfolio/core/debug"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
 	"github.com/stockfolioofficial/back-editfolio/domain"
 	"github.com/stockfolioofficial/back-editfolio/util/echox"
 )
@@ -23,29 +23,70 @@ func (c *OrderController) Bind(e *echo.Echo) {
 
 	//CUSTOMER
 	// 진행중인 주문 가져오기
-	e.GET("/order/recent-processing", echox.UserID(c.getRecentProcessingOrder), debug.JwtBypassOnDebugWithRole(domain.CustomerUserRole))
+	e.GET("/order/recent-processing", echox.UserID(c.getRecentProcessingOrder), middleware.RequireRole(domain.CustomerUserRole))
 	// 진행중인 주문 완료
-	e.POST("/order/recent-processing/done", echox.UserID(c.myOrderDone), debug.JwtBypassOnDebugWithRole(domain.CustomerUserRole))
+	e.POST("/order/recent-processing/done", echox.UserID(c.myOrderDone), middleware.RequireRole(domain.CustomerUserRole))
 	// 수정 접수
-	e.POST("/order/recent-processing/edit", echox.UserID(c.myOrderEdit), debug.JwtBypassOnDebugWithRole(domain.CustomerUserRole))
+	e.POST("/order/recent-processing/edit", echox.UserID(c.myOrderEdit), middleware.RequireRole(domain.CustomerUserRole))
 	// 주문 접수
-	e.POST("/order", echox.UserID(c.createOrder), debug.JwtBypassOnDebugWithRole(domain.CustomerUserRole))
+	e.POST("/order", echox.UserID(c.createOrder), middleware.RequireRole(domain.CustomerUserRole))
+	// 내 의뢰 목록, 상세
+	e.GET("/order/me", echox.UserID(c.fetchMyOrders), middleware.RequireRole(domain.CustomerUserRole))
+	e.GET("/order/me/:orderId", echox.UserID(c.getMyOrderDetailInfo), middleware.RequireRole(domain.CustomerUserRole))
+
+	//CUSTOMER, ADMIN
+	// 의뢰 취소
+	e.POST("/order/:orderId/cancel", echox.UserID(c.cancelOrder),
+		middleware.RequireRole(domain.CustomerUserRole, domain.SuperAdminUserRole, domain.AdminUserRole))
+	// 상태 변경 기록
+	e.GET("/order/:orderId/history", echox.UserID(c.fetchOrderHistory),
+		middleware.RequireRole(domain.CustomerUserRole, domain.SuperAdminUserRole, domain.AdminUserRole))
 
 	//ADMIN
 	e.GET("/order/:orderId", c.getOrderDetailInfo,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 	e.POST("/order/:orderId/assign-self", echox.UserID(c.orderAssignSelf),
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
-	e.PUT("/order/:orderId", c.updateOrderInfo,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/order/:orderId/assignment", c.fetchOrderAssignments,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/order/:orderId/skills", c.updateOrderSkills,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/order/bulk", c.importOrders,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PATCH("/order/batch/state", echox.UserID(c.batchUpdateOrderState),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/order/:orderId/duplicate", echox.UserID(c.duplicateOrder),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/order/:orderId", echox.UserID(c.updateOrderInfo),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/order/:orderId/delivery", c.deliverOrder,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PATCH("/order/:orderId/archive", c.archiveOrder,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	// 담당이 아닌 의뢰 지켜보기
+	e.POST("/order/:orderId/watch", echox.UserID(c.watchOrder),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.DELETE("/order/:orderId/watch", echox.UserID(c.unwatchOrder),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/order/watched", echox.UserID(c.fetchWatchedOrders),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 	e.POST("/order/:orderId/edit-done", nil,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole)) // 대기
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole)) // 대기
 
 	// v1 - fetch, todo refactor
 	e.GET("/order/ready", c.fetchOrderToReady,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 	e.GET("/order/processing", echox.UserID(c.fetchOrderToProcessing),
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 	e.GET("/order/done", c.fetchOrderToDone,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	//SUPER_ADMIN
+	// 담당자 휴가, 퇴사 때 의뢰 일괄 이관
+	e.POST("/order/reassign", echox.UserID(c.reassignOrders),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+
+	//INTERNAL
+	// 스케줄러가 주기적으로 호출
+	e.POST("/internal/order/archive", c.internalArchiveDoneOrders)
 }
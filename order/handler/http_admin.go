@@ -6,17 +6,21 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+	"github.com/stockfolioofficial/back-editfolio/util/pointer"
 )
 
 type OrderFetchRequest struct {
 	Query        string `json:"-" query:"q"`
 	ShowMyTicket bool   `json:"-" query:"smt" example:"false"`
+	// Archived, 보관된 의뢰만, 기본은 보관된 의뢰 제외
+	Archived bool `json:"-" query:"archived" example:"false"`
 } // @name OrderFetchRequest
 
 type OrderReadyInfoResponse struct {
 	OrderId            uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Number             *string   `json:"number" example:"EF-2021-00123"`
 	OrderedAt          time.Time `json:"orderedAt" validate:"required"`
 	OrdererName        string    `json:"ordererName" validate:"required"`
 	OrdererChannelName string    `json:"ordererChannelName" validate:"required"`
@@ -31,7 +35,7 @@ type OrderReadyInfoListResponse []OrderReadyInfoResponse
 // @Description 제작 의뢰 요청 목록 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
 // @Accept json
 // @Produce json
-// @Param q query string false "검색어"
+// @Param q query string false "검색어, 의뢰 번호(EF-2021-00123) 형식이면 번호로 검색"
 // @Success 200 {object} OrderReadyInfoListResponse true "의뢰 요청 목록"
 // @Router /order/ready [get]
 func (c *OrderController) fetchOrderToReady(ctx echo.Context) error {
@@ -46,6 +50,7 @@ func (c *OrderController) fetchOrderToReady(ctx echo.Context) error {
 		dst := &resp[i]
 		*dst = OrderReadyInfoResponse{
 			OrderId:            src.OrderId,
+			Number:             src.Number,
 			OrderedAt:          src.OrderedAt,
 			OrdererName:        src.OrdererName,
 			OrdererChannelName: src.OrdererChannelName,
@@ -58,6 +63,7 @@ func (c *OrderController) fetchOrderToReady(ctx echo.Context) error {
 
 type OrderProcessingInfoResponse struct {
 	OrderId            uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Number             *string   `json:"number" example:"EF-2021-00123"`
 	OrderedAt          time.Time `json:"orderedAt" validate:"required"`
 	OrdererName        string    `json:"ordererName" validate:"required"`
 	OrdererChannelName string    `json:"ordererChannelName" validate:"required"`
@@ -76,7 +82,7 @@ type OrderProcessingInfoListResponse []OrderProcessingInfoResponse
 // @Description 제작 의뢰 진행중 목록 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
 // @Accept json
 // @Produce json
-// @Param q query string false "검색어"
+// @Param q query string false "검색어, 의뢰 번호(EF-2021-00123) 형식이면 번호로 검색"
 // @Param smt query boolean false "자기 업무만 보기"
 // @Success 200 {object} OrderProcessingInfoListResponse true "진행중인 의뢰 목록"
 // @Router /order/processing [get]
@@ -92,6 +98,7 @@ func (c *OrderController) fetchOrderToProcessing(ctx echo.Context, userId uuid.U
 		dst := &resp[i]
 		*dst = OrderProcessingInfoResponse{
 			OrderId:            src.OrderId,
+			Number:             src.Number,
 			OrderedAt:          src.OrderedAt,
 			OrdererName:        src.OrdererName,
 			OrdererChannelName: src.OrdererChannelName,
@@ -118,6 +125,7 @@ func (c *OrderController) fetchOrderToProcessing(ctx echo.Context, userId uuid.U
 
 type OrderDoneInfoResponse struct {
 	OrderId            uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Number             *string   `json:"number" example:"EF-2021-00123"`
 	OrderedAt          time.Time `json:"orderedAt" validate:"required"`
 	OrdererName        string    `json:"ordererName" validate:"required"`
 	OrdererChannelName string    `json:"ordererChannelName" validate:"required"`
@@ -134,7 +142,8 @@ type OrderDoneInfoListResponse []OrderDoneInfoResponse
 // @Description 제작 의뢰 완료된 목록 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
 // @Accept json
 // @Produce json
-// @Param q query string false "검색어"
+// @Param q query string false "검색어, 의뢰 번호(EF-2021-00123) 형식이면 번호로 검색"
+// @Param archived query boolean false "보관된 의뢰만 보기, 기본은 보관된 의뢰 제외"
 // @Success 200 {object} OrderDoneInfoListResponse true "완료 의뢰 목록"
 // @Router /order/done [get]
 func (c *OrderController) fetchOrderToDone(ctx echo.Context) error {
@@ -150,6 +159,7 @@ func (c *OrderController) fetchOrderToDone(ctx echo.Context) error {
 		dst := &resp[i]
 		*dst = OrderDoneInfoResponse{
 			OrderId:            src.OrderId,
+			Number:             src.Number,
 			OrderedAt:          src.OrderedAt,
 			OrdererName:        src.OrdererName,
 			OrdererChannelName: src.OrdererChannelName,
@@ -167,7 +177,7 @@ func (c *OrderController) internalFetchOrder(ctx echo.Context, state domain.Orde
 	err = ctx.Bind(&req)
 	if err != nil {
 		alreadyResp = true
-		log.WithError(err).Trace(tag, "fetch order request, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch order request, request body bind error")
 		err = ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -181,11 +191,12 @@ func (c *OrderController) internalFetchOrder(ctx echo.Context, state domain.Orde
 		OrderState: state,
 		Query:      req.Query,
 		Assignee:   userId,
+		Archived:   req.Archived,
 	})
 
 	if err != nil {
 		alreadyResp = true
-		log.WithError(err).Error(tag, "fetch order, unhandled error useCase.Fetch")
+		echox.Log(ctx).WithError(err).Error(tag, "fetch order, unhandled error useCase.Fetch")
 		err = ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 		return
 	}
@@ -206,6 +217,7 @@ type orderDetailAssigneeInfoResponse struct {
 
 type OrderDetailInfoResponse struct {
 	OrderId            uuid.UUID                        `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Number             *string                          `json:"number" example:"EF-2021-00123"`
 	OrderedAt          time.Time                        `json:"orderedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
 	Orderer            uuid.UUID                        `json:"orderer" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
 	DueDate            *time.Time                       `json:"dueDate" example:"2021-10-30T00:00:00+00:00"`
@@ -214,8 +226,15 @@ type OrderDetailInfoResponse struct {
 	OrderStateContent  string                           `json:"orderStateContent" validate:"required" example:"이펙트 추가 중"`
 	RemainingEditCount uint8                            `json:"remainingEditCount" validate:"required" example:"2"`
 	Requirement        string                           `json:"requirement"`
+	Delivery           *OrderDeliveryResponse           `json:"delivery"`
+	// RequiredSkills, 필요한 작업, 없으면 빈 배열
+	RequiredSkills []domain.SkillTag `json:"requiredSkills" validate:"required" example:"SHORTS" enums:"MOTION_GRAPHICS,SHORTS,COLOR_GRADING"`
 } // @name OrderDetailInfoResponse
 
+func (OrderDetailInfoResponse) FieldResource() string {
+	return domain.FieldResourceOrderDetail
+}
+
 // @Tags (Order) 어드민 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [어드민] 의뢰 상세 정보
@@ -232,7 +251,7 @@ func (c *OrderController) getOrderDetailInfo(ctx echo.Context) error {
 	}
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "get order detail info, request data bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "get order detail info, request data bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -240,6 +259,10 @@ func (c *OrderController) getOrderDetailInfo(ctx echo.Context) error {
 
 	res, err := c.useCase.GetOrderDetailInfo(ctx.Request().Context(), req.OrderId)
 
+	return ctx.JSON(http.StatusOK, orderDetailResponseOf(res))
+}
+
+func orderDetailResponseOf(res domain.OrderDetailInfo) OrderDetailInfoResponse {
 	var assignee *orderDetailAssigneeInfoResponse
 	if res.AssigneeInfo != nil {
 		assignee = &orderDetailAssigneeInfoResponse{
@@ -249,8 +272,9 @@ func (c *OrderController) getOrderDetailInfo(ctx echo.Context) error {
 		}
 	}
 
-	return ctx.JSON(http.StatusOK, OrderDetailInfoResponse{
+	return OrderDetailInfoResponse{
 		OrderId:            res.OrderId,
+		Number:             res.Number,
 		OrderedAt:          res.OrderedAt,
 		Orderer:            res.Orderer,
 		DueDate:            res.DueDate,
@@ -259,31 +283,36 @@ func (c *OrderController) getOrderDetailInfo(ctx echo.Context) error {
 		OrderStateContent:  res.OrderStateContent,
 		RemainingEditCount: res.RemainingEditCount,
 		Requirement:        res.Requirement,
-	})
+		Delivery:           deliveryResponseOf(res.Delivery),
+		RequiredSkills:     res.RequiredSkills,
+	}
 }
 
 type UpdateOrderInfoRequest struct {
-	OrderId    uuid.UUID `json:"-" param:"orderId" validate:"required" example:"150e8400-p11y-41d4-a716-446655440000"`
+	OrderId uuid.UUID `json:"-" param:"orderId" validate:"required" example:"150e8400-p11y-41d4-a716-446655440000"`
+	// DueDate 완료 예정일, KST 기준 날짜만 사용 (2021-10-30T00:00:00+09:00 과 2021-10-30T00:00:00+00:00 모두 10/30)
 	DueDate    time.Time `json:"dueDate" validate:"required" example:"2021-10-30T00:00:00+00:00"`
 	Assignee   uuid.UUID `json:"assignee" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
 	OrderState uint8     `json:"orderState" validate:"required" example:"3"`
+	// Memo 상태가 바뀔 때만 상태 변경 기록에 남김
+	Memo *string `json:"memo" validate:"omitempty,max=500" example:"자막 파일 누락으로 보류"`
 } // @name UpdateOrderInfoRequest
 
 // @Tags (Order) 어드민 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [어드민] 의뢰 정보 수정
-// @Description 의뢰 정보 수정하는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Description 의뢰 정보 수정하는 기능, 담당자를 바꾸면 동시 진행 한도와 관계없이 배정하고 배정 기록을 남김, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
 // @Accept json
 // @Produce json
 // @Param order_id path string true "의뢰 식별 아이디(UUID)"
 // @Param requestBody body UpdateOrderInfoRequest true "편집 의뢰 요청 데이터 구조"
 // @Success 204 "정보 수정 완료"
 // @Router /order/{order_id} [put]
-func (c *OrderController) updateOrderInfo(ctx echo.Context) error {
+func (c *OrderController) updateOrderInfo(ctx echo.Context, userId uuid.UUID) error {
 	var req UpdateOrderInfoRequest
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "update order, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "update order, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -294,6 +323,8 @@ func (c *OrderController) updateOrderInfo(ctx echo.Context) error {
 		DueDate:    req.DueDate,
 		Assignee:   req.Assignee,
 		OrderState: req.OrderState,
+		UpdatedBy:  userId,
+		Memo:       req.Memo,
 	})
 
 	switch err {
@@ -320,6 +351,7 @@ type OrderAssignSelfResponse struct {
 // @Produce json
 // @Param order_id path string true "의뢰 식별 아이디(UUID)"
 // @Success 200 {object} OrderAssignSelfResponse true "수주 완료"
+// @Failure 409 {object} domain.ErrorResponse "이미 배정된 의뢰, 동시 진행 한도 초과 또는 의뢰에 필요한 작업을 할 수 없음"
 // @Router /order/{order_id}/assign-self [post]
 func (c *OrderController) orderAssignSelf(ctx echo.Context, userId uuid.UUID) error {
 	var req struct {
@@ -327,7 +359,7 @@ func (c *OrderController) orderAssignSelf(ctx echo.Context, userId uuid.UUID) er
 	}
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "orderAssignSelf data binding error")
+		echox.Log(ctx).WithError(err).Trace(tag, "orderAssignSelf data binding error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -346,12 +378,127 @@ func (c *OrderController) orderAssignSelf(ctx echo.Context, userId uuid.UUID) er
 		})
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: "assign conflict"})
+	case domain.ErrManagerAtCapacity, domain.ErrSkillMismatch:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
 	case domain.ErrNoPermission:
 		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
 	default:
-		log.WithError(err).
+		echox.Log(ctx).WithError(err).
 			WithField("in", in).
 			Error(tag, "orderAssignSelf / unhandled error useCase.OrderAssignSelf")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
-}
\ No newline at end of file
+}
+
+type DuplicateOrderResponse struct {
+	OrderId uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name DuplicateOrderResponse
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 복제
+// @Description 요구사항, 수정 횟수를 복사해 같은 고객의 임시 의뢰 생성, 담당자/상태 이력/완료 정보는 복사하지 않음, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Success 201 {object} DuplicateOrderResponse "복제 성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 의뢰"
+// @Router /order/{order_id}/duplicate [post]
+func (c *OrderController) duplicateOrder(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		OrderId uuid.UUID `json:"-" param:"orderId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "duplicateOrder data binding error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	var in = domain.DuplicateOrder{
+		OrderId: req.OrderId,
+		UserId:  userId,
+	}
+	newId, err := c.useCase.DuplicateOrder(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, DuplicateOrderResponse{
+			OrderId: newId,
+		})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "duplicateOrder / unhandled error useCase.DuplicateOrder")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type BatchUpdateOrderStateRequest struct {
+	OrderIds   []uuid.UUID `json:"orderIds" validate:"required,min=1,max=100" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderState uint8       `json:"orderState" validate:"required" example:"3"`
+	// Memo 바뀐 의뢰마다 상태 변경 기록에 남김
+	Memo *string `json:"memo" validate:"omitempty,max=500" example:"촬영 원본 재요청"`
+} // @name BatchUpdateOrderStateRequest
+
+type OrderStateTransitionResponse struct {
+	OrderId uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Success bool      `json:"success" validate:"required" example:"false"`
+	// Failure 실패 사유, 성공이면 없음
+	Failure *string `json:"failure" example:"NOT_ASSIGNED" enums:"NOT_FOUND,ALREADY_DONE,NOT_ASSIGNED,NOT_ALLOWED"`
+} // @name OrderStateTransitionResponse
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 상태 일괄 변경
+// @Description 여러 의뢰의 상태를 한 번에 변경, 의뢰별로 변경 가능 여부를 확인하고 가능한 의뢰만 한 트랜잭션으로 변경, 완료/취소 상태는 전용 기능 사용, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body BatchUpdateOrderStateRequest true "의뢰 아이디 목록(최대 100개)과 변경할 상태"
+// @Success 200 {array} OrderStateTransitionResponse "의뢰별 결과"
+// @Failure 400 {object} domain.ErrorResponse "없는 상태"
+// @Router /order/batch/state [patch]
+func (c *OrderController) batchUpdateOrderState(ctx echo.Context, userId uuid.UUID) error {
+	var req BatchUpdateOrderStateRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "batch update order state, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.BatchUpdateOrderState(ctx.Request().Context(), domain.BatchUpdateOrderState{
+		OrderIds:   req.OrderIds,
+		OrderState: req.OrderState,
+		UpdatedBy:  userId,
+		Memo:       req.Memo,
+	})
+
+	switch err {
+	case nil:
+		res := make([]OrderStateTransitionResponse, len(list))
+		for i, src := range list {
+			res[i] = OrderStateTransitionResponse{
+				OrderId: src.OrderId,
+				Success: src.Failure == "",
+			}
+			if src.Failure != "" {
+				res[i].Failure = pointer.String(string(src.Failure))
+			}
+		}
+		return ctx.JSON(http.StatusOK, res)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("orderState", req.OrderState).
+			Error(tag, "batchUpdateOrderState, unhandled error useCase.BatchUpdateOrderState")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type ArchiveOrderRequest struct {
+	OrderId uuid.UUID `json:"-" param:"orderId" validate:"required"`
+
+	// Archived, false 면 보관 해제
+	Archived bool `json:"archived" example:"true"`
+} // @name ArchiveOrderRequest
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 보관
+// @Description 끝난(완료, 취소) 의뢰를 목록에서 숨김, 삭제하지 않고 보관 목록(archived=true)에서 조회, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Param requestBody body ArchiveOrderRequest true "보관 여부"
+// @Success 204 "변경 완료"
+// @Failure 400 {object} domain.ErrorResponse "끝나지 않은 의뢰"
+// @Failure 404 {object} domain.ErrorResponse "없는 의뢰"
+// @Router /order/{order_id}/archive [patch]
+func (c *OrderController) archiveOrder(ctx echo.Context) error {
+	var req ArchiveOrderRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "archive order, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.ArchiveOrder(ctx.Request().Context(), domain.ArchiveOrder{
+		OrderId:  req.OrderId,
+		Archived: req.Archived,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("orderId", req.OrderId).
+			Error(tag, "archiveOrder, unhandled error useCase.ArchiveOrder")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *OrderController) internalArchiveDoneOrders(ctx echo.Context) error {
+	archived, err := c.useCase.ArchiveDoneOrders(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalArchiveDoneOrders, unhandled error useCase.ArchiveDoneOrders")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"archived": archived,
+	})
+}
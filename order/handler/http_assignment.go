@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type OrderAssignmentResponse struct {
+	// Previous, 이전 담당자, 처음 배정이면 null
+	Previous *uuid.UUID `json:"previous" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Assignee uuid.UUID  `json:"assignee" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Source, AUTO: 의뢰 요청 때 자동 배정, SELF: 직접 가져감, MANUAL: 의뢰 정보 수정으로 배정
+	Source domain.OrderAssignmentSource `json:"source" validate:"required" example:"AUTO"`
+	// Strategy, 자동 배정일 때 사용한 방법
+	Strategy *domain.OrderAssignStrategy `json:"strategy" example:"LEAST_LOADED"`
+	// AssignedBy, 가져가거나 배정한 관리자, 자동 배정이면 null
+	AssignedBy *uuid.UUID `json:"assignedBy" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AssignedAt time.Time  `json:"assignedAt" validate:"required" example:"2021-10-27T05:44:18+00:00"`
+} // @name OrderAssignmentResponse
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 담당자 배정 기록
+// @Description 자동 배정, 직접 가져감, 관리자 배정 기록, 오래된 순, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Success 200 {array} OrderAssignmentResponse "배정 기록"
+// @Success 204 "배정 기록 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 의뢰"
+// @Router /order/{order_id}/assignment [get]
+func (c *OrderController) fetchOrderAssignments(ctx echo.Context) error {
+	var req struct {
+		OrderId uuid.UUID `param:"orderId" validate:"required"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch order assignments, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.FetchOrderAssignments(ctx.Request().Context(), req.OrderId)
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("orderId", req.OrderId).
+			Error(tag, "fetchOrderAssignments, unhandled error useCase.FetchOrderAssignments")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]OrderAssignmentResponse, len(list))
+	for i, src := range list {
+		res[i] = OrderAssignmentResponse{
+			Previous:   src.Previous,
+			Assignee:   src.Assignee,
+			Source:     src.Source,
+			Strategy:   src.Strategy,
+			AssignedBy: src.AssignedBy,
+			AssignedAt: src.AssignedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type UpdateOrderSkillsRequest struct {
+	OrderId uuid.UUID `json:"-" param:"orderId" validate:"required"`
+	// Skills, 필요한 작업, 빈 배열이면 아무 담당자나
+	Skills []domain.SkillTag `json:"skills" validate:"required,max=3,dive,oneof=MOTION_GRAPHICS SHORTS COLOR_GRADING" example:"SHORTS,COLOR_GRADING" enums:"MOTION_GRAPHICS,SHORTS,COLOR_GRADING"`
+} // @name UpdateOrderSkillsRequest
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰에 필요한 작업 수정
+// @Description 자동 배정, 가져가기는 필요한 작업을 모두 할 수 있는 담당자만, 이미 배정된 담당자는 그대로, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Param requestBody body UpdateOrderSkillsRequest true "필요한 작업"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Failure 404 {object} domain.ErrorResponse "없는 의뢰"
+// @Router /order/{order_id}/skills [put]
+func (c *OrderController) updateOrderSkills(ctx echo.Context) error {
+	var req UpdateOrderSkillsRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "updateOrderSkills, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateOrderSkills(ctx.Request().Context(), domain.UpdateOrderSkills{
+		OrderId: req.OrderId,
+		Skills:  req.Skills,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("orderId", req.OrderId).
+			Error(tag, "updateOrderSkills, unhandled error useCase.UpdateOrderSkills")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ReassignOrdersRequest struct {
+	From uuid.UUID `query:"from" validate:"required"`
+	To   uuid.UUID `query:"to" validate:"required"`
+} // @name ReassignOrdersRequest
+
+type ReassignOrdersResponse struct {
+	// OrderIds, 넘긴 의뢰, 없으면 빈 배열
+	OrderIds []uuid.UUID `json:"orderIds" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name ReassignOrdersResponse
+
+// @Tags (Order) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 담당자 의뢰 일괄 이관
+// @Description 휴가, 퇴사 등으로 담당자의 끝나지 않은 의뢰를 모두 다른 담당자에게 한 번에 넘김, 동시 진행 한도와 작업은 확인하지 않음
+// @Description 넘긴 의뢰마다 배정 기록을 남기고 고객에게 담당자 변경 안내, 감사 로그(ORDERS_REASSIGNED)에 기록, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param from query string true "넘기는 담당자 아이디(UUID)"
+// @Param to query string true "받는 담당자 아이디(UUID)"
+// @Success 200 {object} ReassignOrdersResponse "이관 완료"
+// @Failure 400 {object} domain.ErrorResponse "받는 담당자가 없거나 같은 담당자"
+// @Router /order/reassign [post]
+func (c *OrderController) reassignOrders(ctx echo.Context, userId uuid.UUID) error {
+	var req ReassignOrdersRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "reassign orders, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.ReassignOrders{
+		From:         req.From,
+		To:           req.To,
+		ReassignedBy: userId,
+		Ip:           ctx.RealIP(),
+	}
+	res, err := c.useCase.ReassignOrders(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		orderIds := res.OrderIds
+		if orderIds == nil {
+			orderIds = []uuid.UUID{}
+		}
+		return ctx.JSON(http.StatusOK, ReassignOrdersResponse{OrderIds: orderIds})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "reassignOrders, unhandled error useCase.ReassignOrders")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type CancelOrderRequest struct {
+	OrderId uuid.UUID `json:"-" param:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Reason, 취소 사유
+	Reason string `json:"reason" validate:"required,max=500" example:"일정 변경"`
+
+	// RefundType, 환불 정책 강제 지정 (어드민만 적용)
+	// * FULL - 전액 환불, 의뢰 횟수 복구
+	// * PARTIAL - 부분 환불
+	// * NONE - 환불 없음
+	RefundType *string `json:"refundType" validate:"omitempty,eq=FULL|eq=PARTIAL|eq=NONE" example:"FULL" enums:"FULL,PARTIAL,NONE"`
+} // @name CancelOrderRequest
+
+type CancelOrderResponse struct {
+	OrderId       uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RefundType    string    `json:"refundType" validate:"required" example:"FULL" enums:"FULL,PARTIAL,NONE"`
+	QuotaRestored bool      `json:"quotaRestored" validate:"required" example:"true"`
+} // @name CancelOrderResponse
+
+// @Tags (Order) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 편집 의뢰 취소
+// @Description 편집 의뢰 취소 기능, 편집자 배정 전 전액 환불(의뢰 횟수 복구), 배정 후 부분 환불
+// @Description 완료된 의뢰는 취소 불가, 고객은 자기 의뢰만 취소 가능, 어드민은 환불 정책을 강제 지정 가능
+// @Description 역할(role)이 'CUSTOMER', 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Param requestBody body CancelOrderRequest true "의뢰 취소 데이터 구조"
+// @Success 200 {object} CancelOrderResponse true "취소 완료"
+// @Router /order/{order_id}/cancel [post]
+func (c *OrderController) cancelOrder(ctx echo.Context, userId uuid.UUID) error {
+	var req CancelOrderRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "cancel order, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.CancelOrder{
+		OrderId: req.OrderId,
+		UserId:  userId,
+		Reason:  req.Reason,
+	}
+	if req.RefundType != nil {
+		refundType := domain.OrderRefundType(*req.RefundType)
+		in.RefundType = &refundType
+	}
+
+	res, err := c.useCase.CancelOrder(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, CancelOrderResponse{
+			OrderId:       res.OrderId,
+			RefundType:    string(res.RefundType),
+			QuotaRestored: res.QuotaRestored,
+		})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrOrderAlreadyCanceled:
+		return ctx.JSON(http.StatusConflict, domain.OrderAlreadyCanceledResponse)
+	case domain.ErrOrderNotCancelable:
+		return ctx.JSON(http.StatusBadRequest, domain.OrderNotCancelableResponse)
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "cancelOrder, unhandled error useCase.CancelOrder")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -6,13 +6,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
 )
 
 type CreateOrderRequest struct {
 	// Requirement, 요청사항
 	Requirement string `json:"requirement" validate:"required,max=2000" example:"알잘딱깔센"`
+
+	// Skills, 필요한 작업, MOTION_GRAPHICS: 모션그래픽, SHORTS: 쇼츠, COLOR_GRADING: 색보정
+	Skills []domain.SkillTag `json:"skills" validate:"max=3,dive,oneof=MOTION_GRAPHICS SHORTS COLOR_GRADING" example:"SHORTS" enums:"MOTION_GRAPHICS,SHORTS,COLOR_GRADING"`
 } // @name CreateOrderRequest
 
 type CreateOrderResponse struct {
@@ -22,18 +25,20 @@ type CreateOrderResponse struct {
 // @Tags (Order) 고객 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [고객] 편집 의뢰 요청
-// @Description 고객이 편집 의뢰를 하는 기능, 역할(role)이 'CUSTOMER' 이여야함
+// @Description 고객이 편집 의뢰를 하는 기능, 자동 배정은 필요한 작업을 모두 할 수 있는 담당자 중에서, 역할(role)이 'CUSTOMER' 이여야함
 // @Accept json
 // @Produce json
 // @Param requestBody body CreateOrderRequest true "편집 의뢰 요청 데이터 구조"
 // @Success 201 {object} CreateOrderResponse true "의뢰 요청 성공"
+// @Failure 403 {object} domain.ErrorResponse "다시 동의해야 하는 약관이 있음, code: U-9"
+// @Failure 409 {object} domain.ErrorResponse "진행 중인 의뢰가 있음"
 // @Router /order [post]
 func (c *OrderController) createOrder(ctx echo.Context, userId uuid.UUID) error {
 	var req CreateOrderRequest
 	err := ctx.Bind(&req)
 
 	if err != nil {
-		log.WithError(err).Trace(tag, "create order request, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "create order request, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -42,6 +47,7 @@ func (c *OrderController) createOrder(ctx echo.Context, userId uuid.UUID) error
 	orderId, err := c.useCase.RequestOrder(ctx.Request().Context(), domain.RequestOrder{
 		UserId:      userId,
 		Requirement: req.Requirement,
+		Skills:      req.Skills,
 	})
 
 	switch err {
@@ -49,40 +55,52 @@ func (c *OrderController) createOrder(ctx echo.Context, userId uuid.UUID) error
 		return ctx.JSON(http.StatusCreated, CreateOrderResponse{OrderId: orderId})
 	case domain.ErrNoPermission:
 		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	case domain.ErrTermsNotAccepted:
+		return ctx.JSON(http.StatusForbidden, domain.TermsNotAcceptedResponse)
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
 	default:
-		log.WithError(err).Error(tag, "video requirement failed")
+		echox.Log(ctx).WithError(err).Error(tag, "video requirement failed")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
 
 type RecentOrderInfoResponse struct {
 	// OrderId 주문 식별아이디 (UUID)
-	OrderId            uuid.UUID  `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderId uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// Number 의뢰 번호, 고객 상담 시 사용
+	Number *string `json:"number" example:"EF-2021-00123"`
 
 	// OrderedAt 주문 일자 (Datetime) RFC3339 datetime format
-	OrderedAt          time.Time  `json:"orderedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+	OrderedAt time.Time `json:"orderedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
 
-	// DueDate 완료 예정일 (Date) RFC3339 datetime format
-	DueDate            *time.Time `json:"dueDate" example:"2021-10-30T00:00:00+00:00"`
+	// DueDate 완료 예정일 (Date, KST 기준 날짜를 UTC 0시로 표현) RFC3339 datetime format
+	DueDate *time.Time `json:"dueDate" example:"2021-10-30T00:00:00+00:00"`
 
 	// AssigneeNickname 담당 편집자 이름
-	AssigneeNickname   *string    `json:"assigneeNickname" example:"담당 편집자 닉네임"`
+	AssigneeNickname *string `json:"assigneeNickname" example:"담당 편집자 닉네임"`
 
 	// OrderState 주문 상태 식별 번호
-	OrderState         uint8      `json:"orderState" validate:"required" example:"3"`
+	OrderState uint8 `json:"orderState" validate:"required" example:"3"`
 
 	// OrderStateContent 주문 상태 명
-	OrderStateContent  string     `json:"orderStateContent" validate:"required" example:"아주 환상적인 이펙트를 입히는 중입니다."`
+	OrderStateContent string `json:"orderStateContent" validate:"required" example:"아주 환상적인 이펙트를 입히는 중입니다."`
 
 	// OrderStateEmoji 주문 상태 이모지
-	OrderStateEmoji  string     `json:"orderStateEmoji" validate:"required" example:"🎇"`
+	OrderStateEmoji string `json:"orderStateEmoji" validate:"required" example:"🎇"`
 
 	// RemainingEditCount 남은 수정 횟수
-	RemainingEditCount uint8      `json:"remainingEditCount" validate:"required" example:"2"`
+	RemainingEditCount uint8 `json:"remainingEditCount" validate:"required" example:"2"`
+
+	// Delivery 납품 주소와 미리보기, 납품 전이면 null
+	Delivery *OrderDeliveryResponse `json:"delivery"`
 } //@name RecentOrderInfoResponse
 
+func (RecentOrderInfoResponse) FieldResource() string {
+	return domain.FieldResourceOrderRecent
+}
+
 // @Tags (Order) 고객 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [고객] 진행중인 최근 편집 의뢰 정보
@@ -97,6 +115,7 @@ func (c *OrderController) getRecentProcessingOrder(ctx echo.Context, userId uuid
 	case nil:
 		return ctx.JSON(http.StatusOK, RecentOrderInfoResponse{
 			OrderId:            res.OrderId,
+			Number:             res.Number,
 			OrderedAt:          res.OrderedAt,
 			DueDate:            res.DueDate,
 			AssigneeNickname:   res.AssigneeNickname,
@@ -104,11 +123,12 @@ func (c *OrderController) getRecentProcessingOrder(ctx echo.Context, userId uuid
 			OrderStateContent:  res.OrderStateContent,
 			OrderStateEmoji:    res.OrderStateEmoji,
 			RemainingEditCount: res.RemainingEditCount,
+			Delivery:           deliveryResponseOf(res.Delivery),
 		})
 	case domain.ErrItemNotFound:
 		return ctx.NoContent(http.StatusNoContent)
 	default:
-		log.WithError(err).Error(tag, "order done requirement failed")
+		echox.Log(ctx).WithError(err).Error(tag, "order done requirement failed")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -137,14 +157,13 @@ func (c *OrderController) myOrderEdit(ctx echo.Context, userId uuid.UUID) error
 	case domain.ErrNoPermission:
 		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
 	default:
-		log.WithError(err).
+		echox.Log(ctx).WithError(err).
 			WithField("in", userId).
 			Error(tag, "myOrderEdit, unhandled error useCase.RequestEditOrder")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
 
-
 type DoneOrderResponse struct {
 	// OrderId 주문 식별아이디 (UUID)
 	OrderId uuid.UUID `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -171,9 +190,100 @@ func (c *OrderController) myOrderDone(ctx echo.Context, userId uuid.UUID) error
 	case domain.ErrItemNotFound:
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "not exists order"})
 	default:
-		log.WithError(err).
+		echox.Log(ctx).WithError(err).
 			WithField("in", userId).
 			Error(tag, "myOrderDone, unhandled error useCase.OrderDone")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
+
+type MyOrderInfoResponse struct {
+	OrderId   uuid.UUID  `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Number    *string    `json:"number" example:"EF-2021-00123"`
+	OrderedAt time.Time  `json:"orderedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+	DueDate   *time.Time `json:"dueDate" example:"2021-10-30T00:00:00+00:00"`
+	// OrderState 주문 상태 식별 번호
+	OrderState        uint8  `json:"orderState" validate:"required" example:"3"`
+	OrderStateContent string `json:"orderStateContent" validate:"required" example:"아주 환상적인 이펙트를 입히는 중입니다."`
+	OrderStateEmoji   string `json:"orderStateEmoji" validate:"required" example:"🎇"`
+	// DoneAt 완료 일시, 취소도 완료로 취급
+	DoneAt     *time.Time `json:"doneAt" example:"2021-10-30T04:44:18+00:00"`
+	CanceledAt *time.Time `json:"canceledAt" example:"2021-10-28T04:44:18+00:00"`
+} // @name MyOrderInfoResponse
+
+// @Tags (Order) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 편집 의뢰 목록
+// @Description 끝난 의뢰 포함, 최근 요청 순, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} MyOrderInfoResponse "성공"
+// @Success 204 "의뢰 없음"
+// @Router /order/me [get]
+func (c *OrderController) fetchMyOrders(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.FetchMyOrders(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "fetchMyOrders, unhandled error useCase.FetchMyOrders")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]MyOrderInfoResponse, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = MyOrderInfoResponse{
+			OrderId:           src.OrderId,
+			Number:            src.Number,
+			OrderedAt:         src.OrderedAt,
+			DueDate:           src.DueDate,
+			OrderState:        src.OrderState,
+			OrderStateContent: src.OrderStateContent,
+			OrderStateEmoji:   src.OrderStateEmoji,
+			DoneAt:            src.DoneAt,
+			CanceledAt:        src.CanceledAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (Order) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 편집 의뢰 상세 정보
+// @Description 본인 의뢰만, 담당자는 닉네임만 보임, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Success 200 {object} OrderDetailInfoResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없거나 다른 고객의 의뢰"
+// @Router /order/me/{order_id} [get]
+func (c *OrderController) getMyOrderDetailInfo(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		OrderId uuid.UUID `json:"-" param:"orderId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get my order detail info, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.GetMyOrderDetailInfo(ctx.Request().Context(), userId, req.OrderId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, orderDetailResponseOf(res))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("orderId", req.OrderId).
+			Error(tag, "getMyOrderDetailInfo, unhandled error useCase.GetMyOrderDetailInfo")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type OrderPreviewResponse struct {
+	// Status, 미리보기 생성 상태 PENDING, DONE, FAILED
+	Status domain.FilePreviewStatus `json:"status" validate:"required" example:"DONE"`
+
+	// ThumbnailUrl, 썸네일(JPEG) 주소, 생성 완료 후에만
+	ThumbnailUrl *string `json:"thumbnailUrl" example:"https://cdn.editfolio.com/preview/550e8400-e29b-41d4-a716-446655440000.jpg"`
+
+	// GifUrl, 앞부분 몇 초짜리 GIF 주소, 생성 완료 후에만
+	GifUrl *string `json:"gifUrl" example:"https://cdn.editfolio.com/preview/550e8400-e29b-41d4-a716-446655440000.gif"`
+
+	// ExpiresAt, 미리보기 주소 만료 시각
+	ExpiresAt *time.Time `json:"expiresAt" example:"2021-10-27T05:44:18+00:00"`
+} // @name OrderPreviewResponse
+
+type OrderDeliveryResponse struct {
+	// Url, 납품 주소
+	Url string `json:"url" validate:"required" example:"https://cdn.editfolio.com/file/550e8400-e29b-41d4-a716-446655440000.mp4"`
+
+	// Preview, 우리 저장소에 올린 영상일 때만
+	Preview *OrderPreviewResponse `json:"preview"`
+} // @name OrderDeliveryResponse
+
+func deliveryResponseOf(info *domain.OrderDeliveryInfo) *OrderDeliveryResponse {
+	if info == nil {
+		return nil
+	}
+
+	res := &OrderDeliveryResponse{Url: info.Url}
+	if info.Preview != nil {
+		res.Preview = &OrderPreviewResponse{
+			Status:       info.Preview.Status,
+			ThumbnailUrl: info.Preview.ThumbnailURL,
+			GifUrl:       info.Preview.GifURL,
+			ExpiresAt:    info.Preview.ExpiresAt,
+		}
+	}
+	return res
+}
+
+type DeliverOrderRequest struct {
+	OrderId uuid.UUID `json:"-" param:"orderId" validate:"required"`
+
+	// Url, 납품 주소, 우리 저장소에 올린 영상이면 미리보기(썸네일, GIF) 생성
+	Url string `json:"url" validate:"required,url,max=1000" example:"https://cdn.editfolio.com/file/550e8400-e29b-41d4-a716-446655440000.mp4"`
+} // @name DeliverOrderRequest
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 납품 주소 등록
+// @Description 편집본 납품 주소 등록, 우리 저장소에 올린 영상이면 의뢰에 연결하고 미리보기 생성 예약, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Param requestBody body DeliverOrderRequest true "납품 주소"
+// @Success 204 "등록 완료"
+// @Failure 400 {object} domain.ErrorResponse "취소된 의뢰 또는 저장소에 없는 파일"
+// @Failure 404 {object} domain.ErrorResponse "없는 의뢰"
+// @Router /order/{order_id}/delivery [put]
+func (c *OrderController) deliverOrder(ctx echo.Context) error {
+	var req DeliverOrderRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "deliver order, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.DeliverOrder(ctx.Request().Context(), domain.DeliverOrder{
+		OrderId: req.OrderId,
+		Url:     req.Url,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("orderId", req.OrderId).
+			Error(tag, "deliverOrder, unhandled error useCase.DeliverOrder")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type OrderHistoryResponse struct {
+	// FromState, 이전 상태, 의뢰 요청(등록) 기록이면 null
+	FromState        *uint8  `json:"fromState" example:"1"`
+	FromStateContent *string `json:"fromStateContent" example:"접수 완료"`
+	ToState          uint8   `json:"toState" validate:"required" example:"3"`
+	ToStateContent   string  `json:"toStateContent" validate:"required" example:"편집자 배정"`
+	// ActorId, 상태를 바꾼 고객, 관리자, 일괄 등록 등 시스템이면 null, 고객에게는 보여주지 않음
+	ActorId *uuid.UUID `json:"actorId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// ActorNickname, 관리자가 바꿨을 때만
+	ActorNickname *string `json:"actorNickname" example:"편집왕"`
+	// Memo, 취소 사유, 관리자 메모
+	Memo      *string   `json:"memo" example:"자막 파일 누락으로 보류"`
+	ChangedAt time.Time `json:"changedAt" validate:"required" example:"2021-10-27T05:44:18+00:00"`
+} // @name OrderHistoryResponse
+
+func (OrderHistoryResponse) FieldResource() string {
+	return domain.FieldResourceOrderHistory
+}
+
+// @Tags (Order) 공용 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 의뢰 상태 변경 기록
+// @Description 의뢰 요청부터 상태가 바뀔 때마다 남긴 기록, 오래된 순, 고객은 자기 의뢰만 볼 수 있고 바꾼 사람 아이디(actorId)는 보이지 않음
+// @Description 역할(role)이 'CUSTOMER', 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Success 200 {array} OrderHistoryResponse "변경 기록"
+// @Success 204 "변경 기록 없음"
+// @Failure 404 {object} domain.ErrorResponse "없는 의뢰"
+// @Router /order/{order_id}/history [get]
+func (c *OrderController) fetchOrderHistory(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		OrderId uuid.UUID `param:"orderId" validate:"required"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch order history, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.FetchOrderHistory{
+		OrderId: req.OrderId,
+		UserId:  userId,
+	}
+	list, err := c.useCase.FetchOrderHistory(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "fetchOrderHistory, unhandled error useCase.FetchOrderHistory")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]OrderHistoryResponse, len(list))
+	for i, src := range list {
+		res[i] = OrderHistoryResponse{
+			FromState:        src.FromState,
+			FromStateContent: src.FromStateContent,
+			ToState:          src.ToState,
+			ToStateContent:   src.ToStateContent,
+			ActorId:          src.ActorId,
+			ActorNickname:    src.ActorNickname,
+			Memo:             src.Memo,
+			ChangedAt:        src.ChangedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+var (
+	errImportMissingColumn = errors.New("csv header must have email, title, state, ordered_at")
+	errImportTooManyRows   = errors.New("too many rows")
+)
+
+type OrderImportErrorResponse struct {
+	// Line, CSV 줄 번호 (머리글이 1)
+	Line    int    `json:"line" validate:"required" example:"3"`
+	Column  string `json:"column" validate:"required" example:"email" enums:"email,title,state,ordered_at,due_date,done_at"`
+	Failure string `json:"failure" validate:"required" example:"CUSTOMER_NOT_FOUND" enums:"REQUIRED,TOO_LONG,CUSTOMER_NOT_FOUND,INVALID_STATE,INVALID_DATE,DONE_AT_MISMATCH,DONE_BEFORE_ORDERED"`
+} // @name OrderImportErrorResponse
+
+type OrderImportResponse struct {
+	// Imported, 저장한 의뢰 수, dryRun 이면 저장할 수 있는 의뢰 수, 오류가 있으면 0
+	Imported int                        `json:"imported" validate:"required" example:"120"`
+	DryRun   bool                       `json:"dryRun" validate:"required" example:"false"`
+	Errors   []OrderImportErrorResponse `json:"errors" validate:"required"`
+} // @name OrderImportResponse
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 지난 의뢰 CSV 가져오기
+// @Description multipart/form-data 의 file 필드로 CSV(UTF-8, 최대 1000행, 2MB)를 올림, 머리글은 email, title, state, ordered_at 필수, due_date, done_at 선택
+// @Description email 은 고객 아이디, title 은 요구사항, state 는 상태 코드(DONE 등) 또는 상태 이름, 완료/취소 상태는 done_at 필수
+// @Description 날짜는 2021-10-27, 2021-10-27 13:00, RFC3339 형식, 시간대가 없으면 KST, 모든 행이 맞을 때만 저장하고 이용권, 알림, 이벤트는 만들지 않음, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "CSV 파일"
+// @Param dryRun query bool false "확인만 하고 저장하지 않음"
+// @Success 200 {object} OrderImportResponse "가져오기 성공 또는 확인 완료"
+// @Failure 400 {object} domain.ErrorResponse "CSV 형식 오류, 필수 머리글 없음, 행 수 초과"
+// @Failure 413 {object} domain.ErrorResponse "파일 크기 초과"
+// @Failure 422 {object} OrderImportResponse "행 오류, 아무것도 저장하지 않음"
+// @Router /order/bulk [post]
+func (c *OrderController) importOrders(ctx echo.Context) error {
+	dryRun, _ := strconv.ParseBool(ctx.QueryParam("dryRun"))
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "importOrders, form file error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if header.Size > domain.OrderImportMaxSize {
+		return ctx.JSON(http.StatusRequestEntityTooLarge, domain.ErrorResponse{Message: "file too large"})
+	}
+
+	body, err := header.Open()
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "importOrders, form file open error")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+	defer body.Close()
+
+	rows, err := readImportRows(body)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "importOrders, csv read error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	result, err := c.useCase.ImportOrders(ctx.Request().Context(), domain.ImportOrders{
+		Rows:   rows,
+		DryRun: dryRun,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("rows", len(rows)).
+			Error(tag, "importOrders, unhandled error useCase.ImportOrders")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := OrderImportResponse{
+		Imported: result.Imported,
+		DryRun:   dryRun,
+		Errors:   make([]OrderImportErrorResponse, len(result.Errors)),
+	}
+	for i, src := range result.Errors {
+		res.Errors[i] = OrderImportErrorResponse{
+			Line:    src.Line,
+			Column:  string(src.Column),
+			Failure: string(src.Failure),
+		}
+	}
+
+	if len(res.Errors) > 0 {
+		return ctx.JSON(http.StatusUnprocessableEntity, res)
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// readImportRows 첫 행은 머리글, 빈 행은 건너뜀
+func readImportRows(r io.Reader) (rows []domain.OrderImportRow, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	head, err := reader.Read()
+	if err != nil {
+		return
+	}
+
+	columns := make(map[domain.OrderImportColumn]int, len(head))
+	for i, name := range head {
+		// 엑셀에서 저장한 UTF-8 CSV 는 BOM 으로 시작
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[domain.OrderImportColumn(strings.ToLower(strings.TrimSpace(name)))] = i
+	}
+	for _, required := range []domain.OrderImportColumn{
+		domain.OrderImportColumnEmail,
+		domain.OrderImportColumnTitle,
+		domain.OrderImportColumnState,
+		domain.OrderImportColumnOrderedAt,
+	} {
+		if _, ok := columns[required]; !ok {
+			err = errImportMissingColumn
+			return
+		}
+	}
+
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			return
+		}
+		if readErr != nil {
+			err = readErr
+			return
+		}
+
+		value := func(column domain.OrderImportColumn) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		if len(rows) == domain.OrderImportMaxRows {
+			err = errImportTooManyRows
+			return
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, domain.OrderImportRow{
+			Line:          line,
+			CustomerEmail: value(domain.OrderImportColumnEmail),
+			Title:         value(domain.OrderImportColumnTitle),
+			State:         value(domain.OrderImportColumnState),
+			OrderedAt:     value(domain.OrderImportColumnOrderedAt),
+			DueDate:       value(domain.OrderImportColumnDueDate),
+			DoneAt:        value(domain.OrderImportColumnDoneAt),
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type WatchedOrderResponse struct {
+	OrderId            uuid.UUID  `json:"orderId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Number             *string    `json:"number" example:"EF-2021-00123"`
+	OrderedAt          time.Time  `json:"orderedAt" validate:"required"`
+	OrdererName        string     `json:"ordererName" validate:"required"`
+	OrdererChannelName string     `json:"ordererChannelName" validate:"required"`
+	OrdererChannelLink string     `json:"ordererChannelLink" validate:"required"`
+	AssigneeNickname   *string    `json:"assigneeNickname" example:"편집왕"`
+	OrderState         uint8      `json:"orderState" validate:"required"`
+	OrderStateContent  string     `json:"orderStateContent" validate:"required"`
+	DoneAt             *time.Time `json:"doneAt"`
+} // @name WatchedOrderResponse
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 지켜보기
+// @Description 담당이 아니어도 상태 변경 알림을 같이 받음, 이미 지켜보는 의뢰면 그대로 성공
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Success 204 "지켜보기 시작"
+// @Failure 404 {object} domain.ErrorResponse "없는 의뢰"
+// @Router /order/{order_id}/watch [post]
+func (c *OrderController) watchOrder(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		OrderId uuid.UUID `param:"orderId" validate:"required"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "watch order, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.WatchOrder{
+		OrderId: req.OrderId,
+		UserId:  userId,
+	}
+	err = c.useCase.WatchOrder(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusUnauthorized, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "watchOrder, unhandled error useCase.WatchOrder")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 의뢰 지켜보기 해제
+// @Description 지켜보지 않던 의뢰여도 성공
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param order_id path string true "의뢰 식별 아이디(UUID)"
+// @Success 204 "지켜보기 해제"
+// @Router /order/{order_id}/watch [delete]
+func (c *OrderController) unwatchOrder(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		OrderId uuid.UUID `param:"orderId" validate:"required"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "unwatch order, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.UnwatchOrder{
+		OrderId: req.OrderId,
+		UserId:  userId,
+	}
+	err = c.useCase.UnwatchOrder(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "unwatchOrder, unhandled error useCase.UnwatchOrder")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// @Tags (Order) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 지켜보는 의뢰 목록
+// @Description 최근에 지켜보기 시작한 순, 끝난 의뢰 포함
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} WatchedOrderResponse "지켜보는 의뢰 목록"
+// @Success 204 "지켜보는 의뢰 없음"
+// @Router /order/watched [get]
+func (c *OrderController) fetchWatchedOrders(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.FetchWatchedOrders(ctx.Request().Context(), userId)
+
+	switch err {
+	case nil:
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "fetchWatchedOrders, unhandled error useCase.FetchWatchedOrders")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]WatchedOrderResponse, len(list))
+	for i, src := range list {
+		res[i] = WatchedOrderResponse{
+			OrderId:            src.OrderId,
+			Number:             src.Number,
+			OrderedAt:          src.OrderedAt,
+			OrdererName:        src.OrdererName,
+			OrdererChannelName: src.OrdererChannelName,
+			OrdererChannelLink: src.OrdererChannelLink,
+			AssigneeNickname:   src.AssigneeNickname,
+			OrderState:         src.OrderState,
+			OrderStateContent:  src.OrderStateContent,
+			DoneAt:             src.DoneAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewOrderAssignmentRepository(db *gorm.DB) domain.OrderAssignmentRepository {
+	db.AutoMigrate(&domain.OrderAssignment{})
+	return &assignmentRepo{db: db}
+}
+
+type assignmentRepo struct {
+	db *gorm.DB
+}
+
+func (r *assignmentRepo) Create(ctx context.Context, assignment *domain.OrderAssignment) error {
+	return r.db.WithContext(ctx).Create(assignment).Error
+}
+
+func (r *assignmentRepo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *assignmentRepo) With(tx gormx.Tx) domain.OrderAssignmentTxRepository {
+	return &assignmentRepo{db: tx.Get()}
+}
+
+func (r *assignmentRepo) FetchByOrderId(ctx context.Context, orderId uuid.UUID) (list []domain.OrderAssignment, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`order_id` = ?", orderId).
+		Order("`assigned_at` asc").
+		Find(&list).Error
+	return
+}
+
+// loads 끝나지 않은 의뢰 수, 마지막 자동 배정 시각은 담당자별 하위 쿼리
+func (r *assignmentRepo) loads(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("user").
+		Select("`user`.`id` AS `manager_id`, `manager`.`capacity`, `manager`.`skills`, "+
+			"(SELECT COUNT(*) FROM `order` WHERE `order`.`assignee` = `user`.`id` AND `order`.`is_draft` = ? AND `order`.`done_at` IS NULL) AS `open`, "+
+			"(SELECT MAX(`assigned_at`) FROM `order_assignment` WHERE `order_assignment`.`assignee` = `user`.`id` AND `order_assignment`.`source` = ?) AS `last_auto_assigned_at`",
+			false, domain.OrderAssignmentSourceAuto).
+		Joins("JOIN `manager` ON `manager`.`id` = `user`.`id`").
+		Where("`user`.`deleted_at` IS NULL")
+}
+
+func (r *assignmentRepo) FetchManagerLoads(ctx context.Context, skills []domain.SkillTag, date time.Time) (list []domain.ManagerLoad, err error) {
+	db := r.loads(ctx).
+		Where("`user`.`role` = ?", domain.AdminUserRole).
+		Where("NOT EXISTS (SELECT 1 FROM `manager_unavailability` WHERE `manager_unavailability`.`manager_id` = `user`.`id` AND `manager_unavailability`.`date` = ?)", date)
+	if len(skills) > 0 {
+		// 작업을 정하지 않은 담당자(NULL)는 제외
+		db = db.Where("JSON_CONTAINS(`manager`.`skills`, ?)", domain.EncodeSkillFilter(skills))
+	}
+
+	err = db.Scan(&list).Error
+	return
+}
+
+func (r *assignmentRepo) GetManagerLoad(ctx context.Context, managerId uuid.UUID) (load *domain.ManagerLoad, err error) {
+	var list []domain.ManagerLoad
+	err = r.loads(ctx).
+		Where("`user`.`id` = ? AND `user`.`role` IN ?", managerId, []domain.UserRole{domain.AdminUserRole, domain.SuperAdminUserRole}).
+		Limit(1).
+		Scan(&list).Error
+	if err == nil && len(list) > 0 {
+		load = &list[0]
+	}
+	return
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewOrderHistoryRepository(db *gorm.DB) domain.OrderHistoryRepository {
+	db.AutoMigrate(&domain.OrderHistory{})
+	return &historyRepo{db: db}
+}
+
+type historyRepo struct {
+	db *gorm.DB
+}
+
+func (r *historyRepo) Create(ctx context.Context, histories []domain.OrderHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&histories).Error
+}
+
+func (r *historyRepo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *historyRepo) With(tx gormx.Tx) domain.OrderHistoryTxRepository {
+	return &historyRepo{db: tx.Get()}
+}
+
+func (r *historyRepo) FetchByOrderId(ctx context.Context, orderId uuid.UUID) (list []domain.OrderHistory, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`order_id` = ?", orderId).
+		Order("`changed_at` asc").
+		Find(&list).Error
+	return
+}
@@ -3,15 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stockfolioofficial/back-editfolio/domain"
 	"github.com/stockfolioofficial/back-editfolio/util/gormx"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func NewOrderRepository(db *gorm.DB) domain.OrderRepository {
-	db.AutoMigrate(&domain.Order{})
+	db.AutoMigrate(&domain.Order{}, &domain.OrderNumberSequence{})
 	return &repo{
 		db: db,
 	}
@@ -25,7 +27,7 @@ func (r *repo) GetRecentByOrdererId(ctx context.Context, ordererId uuid.UUID) (o
 	var entity domain.Order
 	err = r.db.WithContext(ctx).
 		Order("ordered_at desc").
-		Where("`orderer` = ?", ordererId).
+		Where("`orderer` = ? AND `is_draft` = ?", ordererId, false).
 		First(&entity).Error
 	if err == nil {
 		order = &entity
@@ -36,8 +38,48 @@ func (r *repo) GetRecentByOrdererId(ctx context.Context, ordererId uuid.UUID) (o
 	return
 }
 
+func (r *repo) FetchByIds(ctx context.Context, ids []uuid.UUID) (list []domain.Order, err error) {
+	err = r.db.WithContext(ctx).Find(&list, ids).Error
+	return
+}
+
+func (r *repo) FetchSnapshot(ctx context.Context, since time.Time, limit int) (list []domain.Order, err error) {
+	err = r.db.WithContext(ctx).
+		Where("is_draft = ? AND (ordered_at >= ? OR done_at IS NULL)", false, since).
+		Order("ordered_at DESC").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchByOrdererId(ctx context.Context, ordererId uuid.UUID) (list []domain.Order, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`ordered_at` asc").
+		Where("`orderer` = ?", ordererId).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchActiveByAssignee(ctx context.Context, assignee uuid.UUID) (list []domain.Order, err error) {
+	err = r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("`assignee` = ? AND `is_draft` = ? AND `done_at` IS NULL", assignee, false).
+		Order("`ordered_at` asc").
+		Find(&list).Error
+	return
+}
+
+func (r *repo) ReassignOrderer(ctx context.Context, from, to uuid.UUID) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.Order{}).
+		Where("`orderer` = ?", from).
+		Update("orderer", to)
+	return res.RowsAffected, res.Error
+}
+
 func (r *repo) Fetch(ctx context.Context, option domain.FetchOrderOption) (list []domain.Order, err error) {
-	db := r.db.WithContext(ctx)
+	db := r.db.WithContext(ctx).
+		Where("`is_draft` = ?", false)
 
 	switch option.OrderState {
 	case domain.OrderGeneralStateReady:
@@ -56,15 +98,51 @@ func (r *repo) Fetch(ctx context.Context, option domain.FetchOrderOption) (list
 			Where("`done_at` IS NOT NULL")
 	}
 
-	//TODO
-	//if len(option.Query) > 0 {
-	//	db = db.Where()
-	//}
+	if option.Archived {
+		db = db.Where("`archived_at` IS NOT NULL")
+	} else {
+		db = db.Where("`archived_at` IS NULL")
+	}
+
+	if number, ok := domain.ParseOrderNumber(option.Query); ok {
+		db = db.Where("`number` = ?", number)
+	}
+	//TODO 이름, 채널 검색
 
 	err = db.Find(&list).Error
 	return
 }
 
+func (r *repo) ArchiveDoneBefore(ctx context.Context, doneBefore, now time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.Order{}).
+		Where("`is_draft` = ? AND `done_at` < ? AND `archived_at` IS NULL", false, doneBefore).
+		Update("archived_at", now)
+	return res.RowsAffected, res.Error
+}
+
+func (r *repo) NextNumber(ctx context.Context, prefix string, year int) (seq int64, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entity := domain.OrderNumberSequence{Prefix: prefix, Year: year}
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("`prefix` = ? AND `year` = ?", prefix, year).
+			First(&entity).Error
+		if err != nil {
+			return err
+		}
+
+		entity.Value++
+		seq = entity.Value
+		return tx.Model(&entity).Update("value", entity.Value).Error
+	})
+	return
+}
+
 func (r *repo) Save(ctx context.Context, order *domain.Order) error {
 	return gormx.Upsert(ctx, r.db, order)
 }
@@ -94,3 +172,17 @@ func (r *repo) GetById(ctx context.Context, orderId uuid.UUID) (order *domain.Or
 
 	return
 }
+
+func (r *repo) GetByIdForUpdate(ctx context.Context, orderId uuid.UUID) (order *domain.Order, err error) {
+	var entity domain.Order
+	err = r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&entity, orderId).Error
+	if err == nil {
+		order = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
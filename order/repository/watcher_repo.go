@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewOrderWatcherRepository(db *gorm.DB) domain.OrderWatcherRepository {
+	db.AutoMigrate(&domain.OrderWatcher{})
+	return &watcherRepo{db: db}
+}
+
+type watcherRepo struct {
+	db *gorm.DB
+}
+
+func (r *watcherRepo) Watch(ctx context.Context, watcher domain.OrderWatcher) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&watcher).Error
+}
+
+func (r *watcherRepo) Unwatch(ctx context.Context, orderId, userId uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("`order_id` = ? AND `user_id` = ?", orderId, userId).
+		Delete(&domain.OrderWatcher{}).Error
+}
+
+func (r *watcherRepo) FetchByOrderIds(ctx context.Context, orderIds []uuid.UUID) (list []domain.OrderWatcher, err error) {
+	if len(orderIds) == 0 {
+		return
+	}
+	err = r.db.WithContext(ctx).
+		Where("`order_id` IN ?", orderIds).
+		Find(&list).Error
+	return
+}
+
+func (r *watcherRepo) FetchByUserId(ctx context.Context, userId uuid.UUID) (list []domain.OrderWatcher, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`user_id` = ?", userId).
+		Order("`created_at` desc").
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) ArchiveOrder(ctx context.Context, in domain.ArchiveOrder) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	order, err := u.orderRepo.GetById(c, in.OrderId)
+	if err != nil {
+		return
+	}
+
+	if order == nil || order.IsDraft {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if !in.Archived {
+		order.Unarchive()
+	} else if !order.Archive(u.calendar.Now()) {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	return u.orderRepo.Save(c, order)
+}
+
+func (u *ucase) ArchiveDoneOrders(ctx context.Context) (archived int64, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	days, err := u.settingReader.Int(c, domain.SettingKeyOrderArchiveAfterDays)
+	if err != nil || days <= 0 {
+		return
+	}
+
+	now := u.calendar.Now()
+	return u.orderRepo.ArchiveDoneBefore(c, now.AddDate(0, 0, -int(days)), now)
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+// pickAssignee skills 를 모두 가지고 오늘 쉬지 않는 담당자 중 설정한 방법으로 고른 담당자, 자동 배정을 끄거나 자리가 없으면 nil
+// 배정하지 못해도 의뢰 요청은 받아야 하므로 조회 실패는 기록만 하고 nil
+func (u *ucase) pickAssignee(ctx context.Context, skills []domain.SkillTag) (assignee *uuid.UUID, strategy domain.OrderAssignStrategy) {
+	raw, err := u.settingReader.String(ctx, domain.SettingKeyOrderAssignStrategy)
+	if err != nil {
+		logx.From(ctx).WithError(err).Warn(tag, "pickAssignee, read strategy failed")
+		return
+	}
+
+	strategy = domain.OrderAssignStrategy(raw)
+	if strategy == domain.OrderAssignStrategyNone || !strategy.IsValid() {
+		return
+	}
+
+	capacity, err := u.settingReader.Int(ctx, domain.SettingKeyOrderManagerCapacity)
+	if err != nil {
+		logx.From(ctx).WithError(err).Warn(tag, "pickAssignee, read capacity failed")
+		return
+	}
+
+	loads, err := u.assignmentRepo.FetchManagerLoads(ctx, skills, u.calendar.DateOf(u.calendar.Now()))
+	if err != nil {
+		logx.From(ctx).WithError(err).Warn(tag, "pickAssignee, fetch manager loads failed")
+		return
+	}
+
+	assignee = domain.PickAssignee(strategy, loads, capacity)
+	return
+}
+
+// skipUnavailableDays 오늘부터 마감일까지 담당자가 쉬는 날만큼 마감일을 늦춤
+// 마감일 계산 때문에 의뢰 요청이 실패하면 안 되므로 조회 실패는 기록만 하고 그대로
+func (u *ucase) skipUnavailableDays(ctx context.Context, managerId uuid.UUID, due time.Time) time.Time {
+	today := u.calendar.DateOf(u.calendar.Now())
+	list, err := u.unavailabilityRepo.FetchByManagerId(ctx, managerId, today)
+	if err != nil {
+		logx.From(ctx).WithError(err).Warn(tag, "skipUnavailableDays, fetch unavailable dates failed")
+		return due
+	}
+
+	dates := make([]time.Time, len(list))
+	for i := range list {
+		dates[i] = list[i].Date
+	}
+	return domain.SkipUnavailableDays(today, due, dates)
+}
+
+// checkCapacity 가져가기 전 담당자 한도 확인, 어드민이 아니면 ErrNoPermission, 작업 확인용으로 담당자 부하 반환
+func (u *ucase) checkCapacity(ctx context.Context, managerId uuid.UUID) (load *domain.ManagerLoad, err error) {
+	load, err = u.assignmentRepo.GetManagerLoad(ctx, managerId)
+	if err != nil {
+		return
+	}
+	if load == nil {
+		err = domain.ErrNoPermission
+		return
+	}
+
+	capacity, err := u.settingReader.Int(ctx, domain.SettingKeyOrderManagerCapacity)
+	if err != nil {
+		return
+	}
+
+	if !load.HasRoom(capacity) {
+		err = domain.ErrManagerAtCapacity
+	}
+	return
+}
+
+// saveAssigned 상태 변경 이벤트, 배정 기록, 상태가 바뀌었으면 변경 기록과 함께 저장
+func (u *ucase) saveAssigned(ctx context.Context, order *domain.Order, assignment *domain.OrderAssignment, history *domain.OrderHistory) error {
+	event, err := u.stateChangedEvent(order, history, u.watchersOf(ctx, order.Id)[order.Id])
+	if err != nil {
+		return err
+	}
+
+	return u.orderRepo.Transaction(ctx, func(or domain.OrderTxRepository) error {
+		err := or.Save(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		err = u.outboxRepo.With(or).Save(ctx, &event)
+		if err != nil {
+			return err
+		}
+
+		if history != nil {
+			err = u.historyRepo.With(or).Create(ctx, []domain.OrderHistory{*history})
+			if err != nil {
+				return err
+			}
+		}
+
+		if assignment == nil {
+			return nil
+		}
+		return u.assignmentRepo.With(or).Create(ctx, assignment)
+	})
+}
+
+func (u *ucase) FetchOrderAssignments(ctx context.Context, orderId uuid.UUID) (res []domain.OrderAssignmentInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	order, err := u.orderRepo.GetById(c, orderId)
+	if err != nil {
+		return
+	}
+
+	if order == nil || order.IsDraft {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	list, err := u.assignmentRepo.FetchByOrderId(c, orderId)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.OrderAssignmentInfo, len(list))
+	for i, src := range list {
+		res[i] = domain.OrderAssignmentInfo{
+			Previous:   src.Previous,
+			Assignee:   src.Assignee,
+			Source:     src.Source,
+			Strategy:   src.Strategy,
+			AssignedBy: src.AssignedBy,
+			AssignedAt: src.AssignedAt,
+		}
+	}
+	return
+}
+
+func (u *ucase) UpdateOrderSkills(ctx context.Context, in domain.UpdateOrderSkills) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	order, err := u.orderRepo.GetById(c, in.OrderId)
+	if err != nil {
+		return
+	}
+
+	if order == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = order.SetRequiredSkills(in.Skills)
+	if err != nil {
+		return
+	}
+
+	return u.orderRepo.Save(c, order)
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) DeliverOrder(ctx context.Context, in domain.DeliverOrder) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	order, err := u.orderRepo.GetById(c, in.OrderId)
+	if err != nil {
+		return
+	}
+
+	if order == nil || order.IsDraft {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	if order.IsCanceled() {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	file, err := u.deliveredFile(c, in.Url)
+	if err != nil {
+		return
+	}
+
+	if file != nil {
+		// 납품한 파일이 연결 안된 파일 정리에 지워지지 않도록 의뢰에 연결
+		if file.OrderId == nil {
+			file.AttachToOrder(order.Id)
+			err = u.fileRepo.Save(c, file)
+			if err != nil {
+				return
+			}
+		}
+
+		if file.IsVideo() {
+			err = u.requestPreview(c, *file)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	order.Deliver(in.Url)
+	return u.orderRepo.Save(c, order)
+}
+
+// deliveredFile 우리 저장소를 가리키는 주소면 파일, 다른 곳이면 nil
+// 우리 저장소인데 파일이 없으면 잘못된 주소라 ErrWeirdData
+func (u *ucase) deliveredFile(ctx context.Context, url string) (file *domain.File, err error) {
+	key, ok := u.storage.KeyOf(url)
+	if !ok {
+		return
+	}
+
+	file, err = u.fileRepo.GetByKey(ctx, key)
+	if err != nil {
+		return
+	}
+
+	if file == nil || file.IsDeleted() {
+		file, err = nil, domain.ErrWeirdData
+	}
+	return
+}
+
+// requestPreview 이미 만들었거나 대기 중이면 그대로, 실패했던 미리보기는 다시 시도
+func (u *ucase) requestPreview(ctx context.Context, file domain.File) error {
+	preview, err := u.previewRepo.GetByFileId(ctx, file.Id)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case preview == nil:
+		created := domain.CreateFilePreview(file, u.clock.Now())
+		preview = &created
+	case preview.Status == domain.FilePreviewStatusFailed:
+		preview.Retry(u.clock.Now())
+	default:
+		return nil
+	}
+	return u.previewRepo.Save(ctx, preview)
+}
+
+// deliveryOf 납품 주소가 없으면 nil, 미리보기가 만들어졌으면 presigned URL 포함
+func (u *ucase) deliveryOf(ctx context.Context, order domain.Order) (res *domain.OrderDeliveryInfo, err error) {
+	if order.DeliveryUrl == nil {
+		return
+	}
+
+	res = &domain.OrderDeliveryInfo{Url: *order.DeliveryUrl}
+
+	key, ok := u.storage.KeyOf(*order.DeliveryUrl)
+	if !ok {
+		return
+	}
+
+	file, err := u.fileRepo.GetByKey(ctx, key)
+	if err != nil || file == nil || !file.IsVideo() {
+		return
+	}
+
+	preview, err := u.previewRepo.GetByFileId(ctx, file.Id)
+	if err != nil || preview == nil {
+		return
+	}
+
+	info := &domain.FilePreviewInfo{Status: preview.Status}
+	res.Preview = info
+	if !preview.IsDone() {
+		return
+	}
+
+	thumbnailURL, err := u.storage.PresignGet(ctx, *preview.ThumbnailKey, domain.FilePreviewURLTTL)
+	if err != nil {
+		return
+	}
+	gifURL, err := u.storage.PresignGet(ctx, *preview.GifKey, domain.FilePreviewURLTTL)
+	if err != nil {
+		return
+	}
+
+	expiresAt := u.clock.Now().Add(domain.FilePreviewURLTTL)
+	info.ThumbnailURL = &thumbnailURL
+	info.GifURL = &gifURL
+	info.ExpiresAt = &expiresAt
+	return
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+// stateHistory 바뀐 지금 상태로 변경 기록 생성, from 이 nil 이면 의뢰 요청 기록
+func (u *ucase) stateHistory(order *domain.Order, from *uint8, actor *uuid.UUID, memo *string) domain.OrderHistory {
+	return domain.CreateOrderHistory(domain.CreateOrderHistoryOption{
+		Id:      u.ids.NewId(),
+		OrderId: order.Id,
+		From:    from,
+		To:      order.State,
+		Actor:   actor,
+		Memo:    memo,
+		Now:     u.calendar.Now(),
+	})
+}
+
+func (u *ucase) FetchOrderHistory(ctx context.Context, in domain.FetchOrderHistory) (res []domain.OrderHistoryInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		order *domain.Order
+		user  *domain.User
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		order, err = u.orderRepo.GetById(gc, in.OrderId)
+		return
+	})
+	g.Go(func() (err error) {
+		user, err = u.userRepo.GetById(gc, in.UserId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user,
+			domain.User.IsCustomer,
+			domain.User.IsAdmin,
+			domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	// 다른 고객의 의뢰는 있는지도 알려주지 않음
+	if order == nil || order.IsDraft || (user.IsCustomer() && order.Orderer != user.Id) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	var (
+		list   []domain.OrderHistory
+		states []domain.OrderState
+	)
+	g, gc = errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		list, err = u.historyRepo.FetchByOrderId(gc, in.OrderId)
+		return
+	})
+	g.Go(func() (err error) {
+		states, err = u.orderStateRepo.FetchFull(gc)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	contents := make(map[uint8]string, len(states))
+	for _, state := range states {
+		contents[state.Id] = state.Content
+	}
+
+	nicknames := make(map[uuid.UUID]*string)
+	res = make([]domain.OrderHistoryInfo, len(list))
+	for i, src := range list {
+		res[i] = domain.OrderHistoryInfo{
+			FromState:      src.FromState,
+			ToState:        src.ToState,
+			ToStateContent: contentOf(contents, src.ToState),
+			ActorId:        src.ActorId,
+			Memo:           src.Memo,
+			ChangedAt:      src.ChangedAt,
+		}
+		if src.FromState != nil {
+			content := contentOf(contents, *src.FromState)
+			res[i].FromStateContent = &content
+		}
+		if src.ActorId == nil || *src.ActorId == order.Orderer {
+			continue
+		}
+
+		nickname, ok := nicknames[*src.ActorId]
+		if !ok {
+			var manager *domain.Manager
+			manager, err = u.managerRepo.GetById(c, *src.ActorId)
+			if err != nil {
+				return
+			}
+			if manager != nil {
+				nickname = &manager.Nickname
+			}
+			nicknames[*src.ActorId] = nickname
+		}
+		res[i].ActorNickname = nickname
+	}
+	return
+}
+
+// contentOf 지워진 상태는 알 수 없는 상태로, todo string resource
+func contentOf(contents map[uint8]string, state uint8) string {
+	if content, ok := contents[state]; ok {
+		return content
+	}
+	return "알 수 없는 상태"
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+var orderImportHistoryMemo = "일괄 등록"
+
+func (u *ucase) ImportOrders(ctx context.Context, in domain.ImportOrders) (res domain.ImportOrdersResult, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if len(in.Rows) == 0 || len(in.Rows) > domain.OrderImportMaxRows {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	var (
+		states    []domain.OrderState
+		customers = make(map[string]*domain.User)
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		states, err = u.orderStateRepo.FetchFull(gc)
+		return
+	})
+	g.Go(func() error {
+		var emails []string
+		for _, row := range in.Rows {
+			if row.CustomerEmail != "" {
+				emails = append(emails, row.CustomerEmail)
+			}
+		}
+		if len(emails) == 0 {
+			return nil
+		}
+
+		users, err := u.userRepo.FetchByUsernames(gc, emails)
+		if err != nil {
+			return err
+		}
+
+		for i := range users {
+			if domain.CheckUserAlive(&users[i], domain.User.IsCustomer) {
+				customers[strings.ToLower(users[i].Username)] = &users[i]
+			}
+		}
+		return nil
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	orders := make([]domain.Order, 0, len(in.Rows))
+	for _, row := range in.Rows {
+		order, rowErrors := u.importRow(row, states, customers)
+		if len(rowErrors) > 0 {
+			res.Errors = append(res.Errors, rowErrors...)
+			continue
+		}
+		orders = append(orders, order)
+	}
+
+	// 하나라도 틀리면 아무것도 저장하지 않음, 고쳐서 같은 파일을 다시 올리면 됨
+	if len(res.Errors) > 0 || in.DryRun {
+		if len(res.Errors) == 0 {
+			res.Imported = len(orders)
+		}
+		return
+	}
+
+	// 일괄 등록은 요청한 시각에 지금 상태로 만든 것으로 기록
+	histories := make([]domain.OrderHistory, len(orders))
+	for i := range orders {
+		histories[i] = domain.CreateOrderHistory(domain.CreateOrderHistoryOption{
+			Id:      u.ids.NewId(),
+			OrderId: orders[i].Id,
+			To:      orders[i].State,
+			Memo:    &orderImportHistoryMemo,
+			Now:     orders[i].OrderedAt,
+		})
+	}
+
+	err = u.orderRepo.Transaction(c, func(or domain.OrderTxRepository) error {
+		for i := range orders {
+			// 지난 의뢰도 요청한 해의 순번을 이어서 받음
+			year := orders[i].OrderedAt.In(u.calendar.Location()).Year()
+			seq, err := or.NextNumber(c, domain.OrderNumberPrefix, year)
+			if err != nil {
+				return err
+			}
+			orders[i].AssignNumber(year, seq)
+
+			err = or.Save(c, &orders[i])
+			if err != nil {
+				return err
+			}
+		}
+		return u.historyRepo.With(or).Create(c, histories)
+	})
+	if err != nil {
+		return
+	}
+
+	res.Imported = len(orders)
+	return
+}
+
+// importRow 행의 모든 오류를 한 번에 돌려줌
+func (u *ucase) importRow(
+	row domain.OrderImportRow,
+	states []domain.OrderState,
+	customers map[string]*domain.User,
+) (order domain.Order, errs []domain.OrderImportRowError) {
+	fail := func(column domain.OrderImportColumn, failure domain.OrderImportFailure) {
+		errs = append(errs, domain.OrderImportRowError{
+			Line:    row.Line,
+			Column:  column,
+			Failure: failure,
+		})
+	}
+
+	customer := customers[strings.ToLower(row.CustomerEmail)]
+	switch {
+	case row.CustomerEmail == "":
+		fail(domain.OrderImportColumnEmail, domain.OrderImportRequired)
+	case customer == nil:
+		fail(domain.OrderImportColumnEmail, domain.OrderImportCustomerNotFound)
+	}
+
+	switch {
+	case row.Title == "":
+		fail(domain.OrderImportColumnTitle, domain.OrderImportRequired)
+	case utf8.RuneCountInString(row.Title) > domain.OrderImportTitleMaxLength:
+		fail(domain.OrderImportColumnTitle, domain.OrderImportTooLong)
+	}
+
+	state := domain.MatchOrderImportState(states, row.State)
+	switch {
+	case row.State == "":
+		fail(domain.OrderImportColumnState, domain.OrderImportRequired)
+	case state == nil:
+		fail(domain.OrderImportColumnState, domain.OrderImportInvalidState)
+	}
+
+	loc := u.calendar.Location()
+	orderedAt, ok := domain.ParseOrderImportTime(row.OrderedAt, loc)
+	switch {
+	case row.OrderedAt == "":
+		fail(domain.OrderImportColumnOrderedAt, domain.OrderImportRequired)
+	case !ok:
+		fail(domain.OrderImportColumnOrderedAt, domain.OrderImportInvalidDate)
+	}
+
+	var dueDate *time.Time
+	if row.DueDate != "" {
+		parsed, ok := domain.ParseOrderImportTime(row.DueDate, loc)
+		if ok {
+			date := u.calendar.DateOf(parsed)
+			dueDate = &date
+		} else {
+			fail(domain.OrderImportColumnDueDate, domain.OrderImportInvalidDate)
+		}
+	}
+
+	var doneAt *time.Time
+	if row.DoneAt != "" {
+		parsed, ok := domain.ParseOrderImportTime(row.DoneAt, loc)
+		if ok {
+			doneAt = &parsed
+		} else {
+			fail(domain.OrderImportColumnDoneAt, domain.OrderImportInvalidDate)
+		}
+	}
+
+	if state != nil && (row.DoneAt == "" || doneAt != nil) {
+		terminal := state.Code == domain.OrderStateCodeDone || state.Code == domain.OrderStateCodeCancel
+		switch {
+		case terminal != (doneAt != nil):
+			fail(domain.OrderImportColumnDoneAt, domain.OrderImportDoneAtMismatch)
+		case doneAt != nil && !orderedAt.IsZero() && doneAt.Before(orderedAt):
+			fail(domain.OrderImportColumnDoneAt, domain.OrderImportDoneBeforeOrdered)
+		}
+	}
+
+	if len(errs) > 0 {
+		return
+	}
+
+	order = domain.ImportOrder(u.ids.NewId(), customer.Id, row.Title, *state, orderedAt, dueDate, doneAt)
+	return
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) ReassignOrders(ctx context.Context, in domain.ReassignOrders) (res domain.ReassignOrdersResult, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if in.From == in.To {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		user, err := u.userRepo.GetById(gc, in.ReassignedBy)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user, domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		// 넘기는 담당자는 이미 삭제됐을 수 있음, 받는 담당자만 확인
+		load, err := u.assignmentRepo.GetManagerLoad(gc, in.To)
+		if err == nil && load == nil {
+			err = domain.ErrWeirdData
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	err = u.orderRepo.Transaction(c, func(or domain.OrderTxRepository) error {
+		orders, err := or.FetchActiveByAssignee(c, in.From)
+		if err != nil {
+			return err
+		}
+
+		orderIds := make([]uuid.UUID, len(orders))
+		for i := range orders {
+			orderIds[i] = orders[i].Id
+		}
+		watchers := u.watchersOf(c, orderIds...)
+
+		assignmentRepo := u.assignmentRepo.With(or)
+		outboxRepo := u.outboxRepo.With(or)
+		now := u.calendar.Now()
+		for i := range orders {
+			order := &orders[i]
+			order.Assignee = &in.To
+			err = or.Save(c, order)
+			if err != nil {
+				return err
+			}
+
+			assignment := domain.CreateOrderAssignment(domain.CreateOrderAssignmentOption{
+				Id:         u.ids.NewId(),
+				Order:      *order,
+				Previous:   &in.From,
+				Source:     domain.OrderAssignmentSourceManual,
+				AssignedBy: &in.ReassignedBy,
+				Now:        now,
+			})
+			err = assignmentRepo.Create(c, &assignment)
+			if err != nil {
+				return err
+			}
+
+			event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+				Id:            u.ids.NewId(),
+				AggregateType: domain.OutboxAggregateTypeOrder,
+				AggregateId:   order.Id,
+				EventType:     domain.OutboxEventTypeOrderReassigned,
+				Data: domain.OrderReassignedEvent{
+					OrderId:   order.Id,
+					OrdererId: order.Orderer,
+					Previous:  in.From,
+					Assignee:  in.To,
+					Watchers:  watchers[order.Id],
+				},
+				Now: now,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = outboxRepo.Save(c, &event)
+			if err != nil {
+				return err
+			}
+
+			res.OrderIds = append(res.OrderIds, order.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		res = domain.ReassignOrdersResult{}
+		return
+	}
+
+	// 넘길 의뢰가 없어도 요청은 남김
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.ReassignedBy,
+		TargetId: in.From,
+		Action:   domain.AuditActionOrdersReassigned,
+		Ip:       in.Ip,
+	})
+	return
+}
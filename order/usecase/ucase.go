@@ -10,8 +10,11 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
 )
 
+const tag = "[ORDER] "
+
 func NewOrderUseCase(
 	orderRepo domain.OrderRepository,
 	userRepo domain.UserRepository,
@@ -19,31 +22,73 @@ func NewOrderUseCase(
 	customerRepo domain.CustomerRepository,
 	orderStateRepo domain.OrderStateRepository,
 	orderTicketRepo domain.OrderTicketRepository,
+	outboxRepo domain.OutboxRepository,
+	assignmentRepo domain.OrderAssignmentRepository,
+	historyRepo domain.OrderHistoryRepository,
+	watcherRepo domain.OrderWatcherRepository,
+	unavailabilityRepo domain.ManagerUnavailabilityRepository,
+	fileRepo domain.FileRepository,
+	previewRepo domain.FilePreviewRepository,
+	storage domain.BlobStorage,
+	settingReader domain.SettingReader,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	calendar domain.Calendar,
+	termsGate domain.TermsGate,
+	auditLogger domain.AuditLogger,
 	timeout time.Duration,
 ) domain.OrderUseCase {
 	return &ucase{
-		orderRepo:       orderRepo,
-		userRepo:        userRepo,
-		managerRepo:     managerRepo,
-		customerRepo:    customerRepo,
-		orderStateRepo:  orderStateRepo,
-		orderTicketRepo: orderTicketRepo,
-		timeout:         timeout,
+		orderRepo:          orderRepo,
+		userRepo:           userRepo,
+		managerRepo:        managerRepo,
+		customerRepo:       customerRepo,
+		orderStateRepo:     orderStateRepo,
+		orderTicketRepo:    orderTicketRepo,
+		outboxRepo:         outboxRepo,
+		assignmentRepo:     assignmentRepo,
+		historyRepo:        historyRepo,
+		watcherRepo:        watcherRepo,
+		unavailabilityRepo: unavailabilityRepo,
+		fileRepo:           fileRepo,
+		previewRepo:        previewRepo,
+		storage:            storage,
+		settingReader:      settingReader,
+		ids:                ids,
+		clock:              clock,
+		calendar:           calendar,
+		termsGate:          termsGate,
+		auditLogger:        auditLogger,
+		timeout:            timeout,
 	}
 }
 
 type ucase struct {
-	orderRepo       domain.OrderRepository
-	userRepo        domain.UserRepository
-	managerRepo     domain.ManagerRepository
-	customerRepo    domain.CustomerRepository
-	orderStateRepo  domain.OrderStateRepository
-	orderTicketRepo domain.OrderTicketRepository
-	timeout         time.Duration
+	orderRepo          domain.OrderRepository
+	userRepo           domain.UserRepository
+	managerRepo        domain.ManagerRepository
+	customerRepo       domain.CustomerRepository
+	orderStateRepo     domain.OrderStateRepository
+	orderTicketRepo    domain.OrderTicketRepository
+	outboxRepo         domain.OutboxRepository
+	assignmentRepo     domain.OrderAssignmentRepository
+	historyRepo        domain.OrderHistoryRepository
+	watcherRepo        domain.OrderWatcherRepository
+	unavailabilityRepo domain.ManagerUnavailabilityRepository
+	fileRepo           domain.FileRepository
+	previewRepo        domain.FilePreviewRepository
+	storage            domain.BlobStorage
+	settingReader      domain.SettingReader
+	ids                domain.IdGenerator
+	clock              domain.Clock
+	calendar           domain.Calendar
+	termsGate          domain.TermsGate
+	auditLogger        domain.AuditLogger
+	timeout            time.Duration
 }
 
 func (u *ucase) RequestOrder(ctx context.Context, in domain.RequestOrder) (newId uuid.UUID, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	var defaultState uint8 = 1
@@ -67,6 +112,9 @@ func (u *ucase) RequestOrder(ctx context.Context, in domain.RequestOrder) (newId
 
 		return
 	})
+	g.Go(func() error {
+		return u.termsGate.RequireAccepted(gc, in.UserId)
+	})
 	g.Go(func() error {
 		exists, _ := u.orderStateRepo.GetByCode(gc, domain.OrderStateCodeDefault)
 		if exists != nil {
@@ -75,15 +123,50 @@ func (u *ucase) RequestOrder(ctx context.Context, in domain.RequestOrder) (newId
 
 		return nil
 	})
+	var slaHours int64
+	g.Go(func() (err error) {
+		slaHours, err = u.settingReader.Int(gc, domain.SettingKeyOrderSlaHours)
+		return
+	})
+	var (
+		assignee  *uuid.UUID
+		strategy  domain.OrderAssignStrategy
+		takeState *domain.OrderState
+	)
+	g.Go(func() (err error) {
+		assignee, strategy = u.pickAssignee(gc, in.Skills)
+		if assignee == nil {
+			return
+		}
+
+		takeState, err = u.orderStateRepo.GetByCode(gc, domain.OrderStateCodeTake)
+		if err == nil && takeState == nil {
+			err = errors.New("orderStateRepo.GetByCode domain.OrderStateCodeTake not exists state")
+		}
+		return
+	})
 	err = g.Wait()
 	if err != nil {
 		return
 	}
 
+	var dueDate *time.Time
+	if slaHours > 0 {
+		// 마감일은 KST 기준 날짜, 자동 배정된 담당자가 쉬는 날만큼 늦춤
+		date := u.calendar.DateOf(u.calendar.Now().Add(time.Duration(slaHours) * time.Hour))
+		if assignee != nil {
+			date = u.skipUnavailableDays(c, *assignee, date)
+		}
+		dueDate = &date
+	}
+
 	err = u.orderTicketRepo.Transaction(c, func(otr domain.OrderTicketTxRepository) (err error) {
 		orderOption := domain.CreateOrderOption{
-			Orderer:     in.UserId,
-			State:       defaultState,
+			Id:      u.ids.NewId(),
+			Orderer: in.UserId,
+			State:   defaultState,
+			DueDate: dueDate,
+			Now:     u.clock.Now(),
 		}
 		if len(in.Requirement) > 0 {
 			orderOption.Requirement = &in.Requirement
@@ -91,7 +174,7 @@ func (u *ucase) RequestOrder(ctx context.Context, in domain.RequestOrder) (newId
 
 		or := u.orderRepo.With(otr)
 
-		ticket, err := otr.GetByOwnerIdBetweenStartAndEnd(c, in.UserId, time.Now())
+		ticket, err := otr.GetByOwnerIdBetweenStartAndEnd(c, in.UserId, u.calendar.Now())
 		if err != nil {
 			return
 		}
@@ -101,8 +184,51 @@ func (u *ucase) RequestOrder(ctx context.Context, in domain.RequestOrder) (newId
 		}
 
 		ticket.UseOrder()
+		orderOption.TicketId = &ticket.Id
 		orderOption.EditCount = ticket.EditCount
 		order := domain.CreateOrder(orderOption)
+		err = order.SetRequiredSkills(in.Skills)
+		if err != nil {
+			return
+		}
+		year := order.OrderedAt.In(u.calendar.Location()).Year()
+		seq, err := or.NextNumber(c, domain.OrderNumberPrefix, year)
+		if err != nil {
+			return
+		}
+		order.AssignNumber(year, seq)
+
+		var assignment *domain.OrderAssignment
+		if assignee != nil {
+			order.Assignee = assignee
+			order.State = takeState.Id
+			created := domain.CreateOrderAssignment(domain.CreateOrderAssignmentOption{
+				Id:       u.ids.NewId(),
+				Order:    order,
+				Source:   domain.OrderAssignmentSourceAuto,
+				Strategy: &strategy,
+				Now:      u.calendar.Now(),
+			})
+			assignment = &created
+		}
+		history := u.stateHistory(&order, nil, &in.UserId, nil)
+
+		event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+			Id:            u.ids.NewId(),
+			AggregateType: domain.OutboxAggregateTypeOrder,
+			AggregateId:   order.Id,
+			EventType:     domain.OutboxEventTypeOrderRequested,
+			Data: domain.OrderRequestedEvent{
+				OrderId:   order.Id,
+				OrdererId: order.Orderer,
+				TicketId:  order.TicketId,
+				Assignee:  order.Assignee,
+			},
+			Now: u.clock.Now(),
+		})
+		if err != nil {
+			return
+		}
 
 		g, gc = errgroup.WithContext(c)
 		g.Go(func() error {
@@ -111,6 +237,17 @@ func (u *ucase) RequestOrder(ctx context.Context, in domain.RequestOrder) (newId
 		g.Go(func() error {
 			return or.Save(gc, &order)
 		})
+		g.Go(func() error {
+			return u.outboxRepo.With(otr).Save(gc, &event)
+		})
+		g.Go(func() error {
+			return u.historyRepo.With(otr).Create(gc, []domain.OrderHistory{history})
+		})
+		if assignment != nil {
+			g.Go(func() error {
+				return u.assignmentRepo.With(otr).Create(gc, assignment)
+			})
+		}
 		err = g.Wait()
 		if err != nil {
 			return
@@ -124,7 +261,7 @@ func (u *ucase) RequestOrder(ctx context.Context, in domain.RequestOrder) (newId
 }
 
 func (u *ucase) RequestEditOrder(ctx context.Context, in domain.RequestEditOrder) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	var (
@@ -178,16 +315,20 @@ func (u *ucase) RequestEditOrder(ctx context.Context, in domain.RequestEditOrder
 		err = domain.ErrItemAlreadyExist
 		return
 	}
+	from := order.State
 	order.UseEdit()
 	order.State = state.Id
-	err = u.orderRepo.Save(c, order)
+	history := u.stateHistory(order, &from, &in.UserId, nil)
+	err = u.saveStateChanged(c, []domain.OrderHistory{history}, order)
 	return
 }
 
 func (u *ucase) OrderDone(ctx context.Context, in domain.OrderDone) (orderId uuid.UUID, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
+	now := u.clock.Now()
+
 	var (
 		order *domain.Order
 		state *domain.OrderState
@@ -215,7 +356,7 @@ func (u *ucase) OrderDone(ctx context.Context, in domain.OrderDone) (orderId uui
 			err = domain.ErrItemNotFound
 		}
 
-		order.Done()
+		order.Done(now)
 		return
 	})
 	g.Go(func() (err error) {
@@ -230,8 +371,42 @@ func (u *ucase) OrderDone(ctx context.Context, in domain.OrderDone) (orderId uui
 		return
 	}
 
+	from := order.State
 	order.State = state.Id
-	err = u.orderRepo.Save(c, order)
+	history := u.stateHistory(order, &from, &in.UserId, nil)
+	event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeOrder,
+		AggregateId:   order.Id,
+		EventType:     domain.OutboxEventTypeOrderDone,
+		Data: domain.OrderDoneEvent{
+			OrderId:   order.Id,
+			OrdererId: order.Orderer,
+			Watchers:  u.watchersOf(c, order.Id)[order.Id],
+		},
+		Now: now,
+	})
+	if err != nil {
+		return
+	}
+
+	err = u.orderRepo.Transaction(c, func(or domain.OrderTxRepository) error {
+		err := or.Save(c, order)
+		if err != nil {
+			return err
+		}
+
+		err = u.historyRepo.With(or).Create(c, []domain.OrderHistory{history})
+		if err != nil {
+			return err
+		}
+
+		err = u.completeTicketOrder(c, u.orderTicketRepo.With(or), order)
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.With(or).Save(c, &event)
+	})
 	if err != nil {
 		return
 	}
@@ -240,8 +415,23 @@ func (u *ucase) OrderDone(ctx context.Context, in domain.OrderDone) (orderId uui
 	return
 }
 
+// completeTicketOrder 의뢰를 맡긴 이용권의 남은 편집 횟수를 줄임, 이용권 없이 만든 의뢰는 그대로
+func (u *ucase) completeTicketOrder(ctx context.Context, orderTicketRepo domain.OrderTicketRepository, order *domain.Order) error {
+	if order.TicketId == nil {
+		return nil
+	}
+
+	ticket, err := orderTicketRepo.GetById(ctx, *order.TicketId)
+	if err != nil || ticket == nil {
+		return err
+	}
+
+	ticket.CompleteOrder()
+	return orderTicketRepo.Save(ctx, ticket)
+}
+
 func (u *ucase) UpdateOrderInfo(ctx context.Context, in domain.UpdateOrderInfo) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	order, err := u.orderRepo.GetById(c, in.OrderId)
@@ -254,7 +444,6 @@ func (u *ucase) UpdateOrderInfo(ctx context.Context, in domain.UpdateOrderInfo)
 		return
 	}
 
-
 	var (
 		aExists *domain.Manager
 		sExists *domain.OrderState
@@ -294,25 +483,115 @@ func (u *ucase) UpdateOrderInfo(ctx context.Context, in domain.UpdateOrderInfo)
 		return
 	}
 
-	order.DueDate = &in.DueDate
-	order.Assignee = &in.Assignee
+	// 관리자가 직접 배정하면 한도를 넘어도 그대로 배정
+	var assignment *domain.OrderAssignment
+	if order.Assignee == nil || *order.Assignee != in.Assignee {
+		previous := order.Assignee
+		order.Assignee = &in.Assignee
+		created := domain.CreateOrderAssignment(domain.CreateOrderAssignmentOption{
+			Id:         u.ids.NewId(),
+			Order:      *order,
+			Previous:   previous,
+			Source:     domain.OrderAssignmentSourceManual,
+			AssignedBy: &in.UpdatedBy,
+			Now:        u.calendar.Now(),
+		})
+		assignment = &created
+	}
+
+	dueDate := u.calendar.DateOf(in.DueDate)
+	order.DueDate = &dueDate
+	from := order.State
 	if sExists == nil {
 		order.State = in.OrderState
 	} else {
 		order.State = sExists.Id
 	}
 
-	return u.orderRepo.Save(c, order)
+	var history *domain.OrderHistory
+	if order.State != from {
+		changed := u.stateHistory(order, &from, &in.UpdatedBy, in.Memo)
+		history = &changed
+	}
+
+	return u.saveAssigned(c, order, assignment, history)
 }
 
+// BatchUpdateOrderState 의뢰별로 변경 가능 여부를 확인해 가능한 의뢰만 한 트랜잭션으로 변경
+func (u *ucase) BatchUpdateOrderState(ctx context.Context, in domain.BatchUpdateOrderState) (res []domain.OrderStateTransitionResult, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		state  *domain.OrderState
+		orders []domain.Order
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		state, err = u.orderStateRepo.GetById(gc, in.OrderState)
+		if err != nil {
+			return
+		}
+
+		if state == nil {
+			err = domain.ErrWeirdData
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		orders, err = u.orderRepo.FetchByIds(gc, in.OrderIds)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	byId := make(map[uuid.UUID]*domain.Order, len(orders))
+	for i := range orders {
+		byId[orders[i].Id] = &orders[i]
+	}
+
+	var (
+		targets   []*domain.Order
+		histories []domain.OrderHistory
+	)
+	res = make([]domain.OrderStateTransitionResult, len(in.OrderIds))
+	for i, id := range in.OrderIds {
+		res[i].OrderId = id
+
+		order, ok := byId[id]
+		if !ok {
+			res[i].Failure = domain.OrderStateTransitionNotFound
+			continue
+		}
+
+		res[i].Failure = order.CheckStateTransition(*state)
+		// 이미 같은 상태(중복으로 들어온 아이디 포함)면 저장하지 않음
+		if res[i].Failure == "" && order.State != state.Id {
+			from := order.State
+			order.State = state.Id
+			targets = append(targets, order)
+			histories = append(histories, u.stateHistory(order, &from, &in.UpdatedBy, in.Memo))
+		}
+	}
+
+	if len(targets) == 0 {
+		return
+	}
+
+	err = u.saveStateChanged(c, histories, targets...)
+	return
+}
 
 func (u *ucase) OrderAssignSelf(ctx context.Context, in domain.OrderAssignSelf) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	var (
 		order *domain.Order
 		state *domain.OrderState
+		load  *domain.ManagerLoad
 	)
 	g, gc := errgroup.WithContext(c)
 	g.Go(func() (err error) {
@@ -339,8 +618,10 @@ func (u *ucase) OrderAssignSelf(ctx context.Context, in domain.OrderAssignSelf)
 			domain.User.IsAdmin,
 			domain.User.IsSuperAdmin) {
 			err = domain.ErrNoPermission
+			return
 		}
 
+		load, err = u.checkCapacity(gc, in.Assignee)
 		return
 	})
 	g.Go(func() (err error) {
@@ -355,7 +636,279 @@ func (u *ucase) OrderAssignSelf(ctx context.Context, in domain.OrderAssignSelf)
 		return
 	}
 
+	if !load.HasSkills(order.RequiredSkillTags()) {
+		err = domain.ErrSkillMismatch
+		return
+	}
+
+	from := order.State
 	order.State = state.Id
-	err = u.orderRepo.Save(c, order)
+	assignment := domain.CreateOrderAssignment(domain.CreateOrderAssignmentOption{
+		Id:         u.ids.NewId(),
+		Order:      *order,
+		Source:     domain.OrderAssignmentSourceSelf,
+		AssignedBy: &in.Assignee,
+		Now:        u.calendar.Now(),
+	})
+	var history *domain.OrderHistory
+	if order.State != from {
+		changed := u.stateHistory(order, &from, &in.Assignee, nil)
+		history = &changed
+	}
+	err = u.saveAssigned(c, order, &assignment, history)
 	return
-}
\ No newline at end of file
+}
+
+func (u *ucase) CancelOrder(ctx context.Context, in domain.CancelOrder) (res domain.CancelOrderResult, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	now := u.clock.Now()
+
+	var (
+		user  *domain.User
+		state *domain.OrderState
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		user, err = u.userRepo.GetById(gc, in.UserId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user,
+			domain.User.IsCustomer,
+			domain.User.IsAdmin,
+			domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		state, _ = u.orderStateRepo.GetByCode(gc, domain.OrderStateCodeCancel)
+		if state == nil {
+			err = errors.New("orderStateRepo.GetByCode domain.OrderStateCodeCancel not exists state")
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	watchers := u.watchersOf(c, in.OrderId)[in.OrderId]
+	err = u.orderTicketRepo.Transaction(c, func(otr domain.OrderTicketTxRepository) (err error) {
+		or := u.orderRepo.With(otr)
+		// 동시에 취소해도 이용권은 한 번만 복구되도록 잠근 채로 확인
+		order, err := or.GetByIdForUpdate(c, in.OrderId)
+		if err != nil {
+			return
+		}
+
+		if order == nil {
+			return domain.ErrItemNotFound
+		}
+
+		if user.IsCustomer() && order.Orderer != user.Id {
+			// 고객은 자기 의뢰만 취소 가능
+			return domain.ErrNoPermission
+		}
+
+		if order.IsCanceled() {
+			return domain.ErrOrderAlreadyCanceled
+		}
+
+		if order.IsDone() {
+			return domain.ErrOrderNotCancelable
+		}
+
+		refundType := order.CancelRefundPolicy()
+		if !user.IsCustomer() && in.RefundType != nil {
+			// 어드민 override
+			refundType = *in.RefundType
+		}
+
+		from := order.State
+		order.Cancel(in.Reason, refundType, now)
+		order.State = state.Id
+		var memo *string
+		if in.Reason != "" {
+			memo = &in.Reason
+		}
+		history := u.stateHistory(order, &from, &in.UserId, memo)
+		event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+			Id:            u.ids.NewId(),
+			AggregateType: domain.OutboxAggregateTypeOrder,
+			AggregateId:   order.Id,
+			EventType:     domain.OutboxEventTypeOrderCanceled,
+			Data: domain.OrderCanceledEvent{
+				OrderId:    order.Id,
+				OrdererId:  order.Orderer,
+				RefundType: refundType,
+				Watchers:   watchers,
+			},
+			Now: now,
+		})
+		if err != nil {
+			return
+		}
+
+		if refundType.IsRestoreQuota() {
+			var ticket *domain.OrderTicket
+			if order.TicketId != nil {
+				ticket, err = otr.GetById(c, *order.TicketId)
+			} else {
+				ticket, err = otr.GetByOwnerIdBetweenStartAndEnd(c, order.Orderer, order.OrderedAt)
+			}
+			if err != nil {
+				return
+			}
+
+			if ticket != nil {
+				ticket.RestoreOrder()
+				err = otr.Save(c, ticket)
+				if err != nil {
+					return
+				}
+				res.QuotaRestored = true
+			}
+		}
+
+		err = or.Save(c, order)
+		if err != nil {
+			return
+		}
+
+		err = u.historyRepo.With(otr).Create(c, []domain.OrderHistory{history})
+		if err != nil {
+			return
+		}
+
+		res.OrderId = order.Id
+		res.RefundType = refundType
+		return u.outboxRepo.With(otr).Save(c, &event)
+	})
+	if err != nil {
+		res = domain.CancelOrderResult{}
+	}
+	return
+}
+
+// DuplicateOrder 기존 의뢰를 같은 고객의 임시 의뢰로 복제, 이용권은 쓰지 않음
+func (u *ucase) DuplicateOrder(ctx context.Context, in domain.DuplicateOrder) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		source       *domain.Order
+		defaultState uint8 = 1
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		source, err = u.orderRepo.GetById(gc, in.OrderId)
+		if err != nil {
+			return
+		}
+
+		if source == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		user, err := u.userRepo.GetById(gc, in.UserId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user,
+			domain.User.IsAdmin,
+			domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() error {
+		exists, _ := u.orderStateRepo.GetByCode(gc, domain.OrderStateCodeDefault)
+		if exists != nil {
+			defaultState = exists.Id
+		}
+
+		return nil
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	draft := source.Duplicate(u.ids.NewId(), defaultState, u.clock.Now())
+	err = u.orderRepo.Save(c, &draft)
+	if err != nil {
+		return
+	}
+
+	newId = draft.Id
+	return
+}
+
+// stateChangedEvent 상태가 바뀌지 않은 배정 변경이면 history 는 nil
+func (u *ucase) stateChangedEvent(order *domain.Order, history *domain.OrderHistory, watchers []uuid.UUID) (domain.OutboxEvent, error) {
+	data := domain.OrderStateChangedEvent{
+		OrderId:   order.Id,
+		OrdererId: order.Orderer,
+		State:     order.State,
+		Assignee:  order.Assignee,
+		Watchers:  watchers,
+	}
+	if history != nil {
+		data.Memo = history.Memo
+	}
+
+	return domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeOrder,
+		AggregateId:   order.Id,
+		EventType:     domain.OutboxEventTypeOrderStateChanged,
+		Data:          data,
+		Now:           u.clock.Now(),
+	})
+}
+
+// saveStateChanged 의뢰마다 상태 변경 이벤트, 변경 기록을 같은 트랜잭션에서 저장
+func (u *ucase) saveStateChanged(ctx context.Context, histories []domain.OrderHistory, orders ...*domain.Order) error {
+	historyOf := make(map[uuid.UUID]*domain.OrderHistory, len(histories))
+	for i := range histories {
+		historyOf[histories[i].OrderId] = &histories[i]
+	}
+
+	orderIds := make([]uuid.UUID, len(orders))
+	for i, order := range orders {
+		orderIds[i] = order.Id
+	}
+	watchers := u.watchersOf(ctx, orderIds...)
+
+	events := make([]domain.OutboxEvent, len(orders))
+	for i, order := range orders {
+		event, err := u.stateChangedEvent(order, historyOf[order.Id], watchers[order.Id])
+		if err != nil {
+			return err
+		}
+		events[i] = event
+	}
+
+	return u.orderRepo.Transaction(ctx, func(or domain.OrderTxRepository) error {
+		outboxRepo := u.outboxRepo.With(or)
+		for i, order := range orders {
+			err := or.Save(ctx, order)
+			if err != nil {
+				return err
+			}
+
+			err = outboxRepo.Save(ctx, &events[i])
+			if err != nil {
+				return err
+			}
+		}
+		return u.historyRepo.With(or).Create(ctx, histories)
+	})
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
 	"github.com/stockfolioofficial/back-editfolio/util/pointer"
 	"github.com/stockfolioofficial/back-editfolio/util/safe"
 	"golang.org/x/sync/errgroup"
 )
 
 func (u *ucase) GetRecentProcessingOrder(ctx context.Context, userId uuid.UUID) (res domain.RecentOrderInfo, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	order, err := u.orderRepo.GetRecentByOrdererId(c, userId)
@@ -25,6 +26,7 @@ func (u *ucase) GetRecentProcessingOrder(ctx context.Context, userId uuid.UUID)
 
 	res = domain.RecentOrderInfo{
 		OrderId:            order.Id,
+		Number:             order.Number,
 		OrderedAt:          order.OrderedAt,
 		DueDate:            order.DueDate,
 		OrderState:         order.State,
@@ -63,6 +65,10 @@ func (u *ucase) GetRecentProcessingOrder(ctx context.Context, userId uuid.UUID)
 		}
 		return
 	})
+	g.Go(func() (err error) {
+		res.Delivery, err = u.deliveryOf(gc, *order)
+		return
+	})
 	err = g.Wait()
 	if err != nil {
 		res = domain.RecentOrderInfo{}
@@ -73,7 +79,7 @@ func (u *ucase) GetRecentProcessingOrder(ctx context.Context, userId uuid.UUID)
 }
 
 func (u *ucase) GetOrderDetailInfo(ctx context.Context, orderId uuid.UUID) (res domain.OrderDetailInfo, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	order, err := u.orderRepo.GetById(c, orderId)
@@ -86,8 +92,31 @@ func (u *ucase) GetOrderDetailInfo(ctx context.Context, orderId uuid.UUID) (res
 		return
 	}
 
+	return u.detailInfoOf(c, *order)
+}
+
+// GetMyOrderDetailInfo 다른 고객의 의뢰, 임시 의뢰는 ErrItemNotFound
+func (u *ucase) GetMyOrderDetailInfo(ctx context.Context, userId, orderId uuid.UUID) (res domain.OrderDetailInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	order, err := u.orderRepo.GetById(c, orderId)
+	if err != nil {
+		return
+	}
+
+	if order == nil || order.Orderer != userId || order.IsDraft {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	return u.detailInfoOf(c, *order)
+}
+
+func (u *ucase) detailInfoOf(c context.Context, order domain.Order) (res domain.OrderDetailInfo, err error) {
 	res = domain.OrderDetailInfo{
 		OrderId:            order.Id,
+		Number:             order.Number,
 		OrderedAt:          order.OrderedAt,
 		Orderer:            order.Orderer,
 		DueDate:            order.DueDate,
@@ -96,6 +125,7 @@ func (u *ucase) GetOrderDetailInfo(ctx context.Context, orderId uuid.UUID) (res
 		OrderStateContent:  "알 수 없는 상태", // todo string resource
 		RemainingEditCount: order.RemainingEditCount(),
 		Requirement:        safe.StringOrZero(order.Requirement),
+		RequiredSkills:     order.RequiredSkillTags(),
 	}
 
 	g, gc := errgroup.WithContext(c)
@@ -135,6 +165,10 @@ func (u *ucase) GetOrderDetailInfo(ctx context.Context, orderId uuid.UUID) (res
 		}
 		return
 	})
+	g.Go(func() (err error) {
+		res.Delivery, err = u.deliveryOf(gc, order)
+		return
+	})
 	err = g.Wait()
 	if err != nil {
 		return
@@ -143,12 +177,20 @@ func (u *ucase) GetOrderDetailInfo(ctx context.Context, orderId uuid.UUID) (res
 	return
 }
 
-
 func (u *ucase) Fetch(ctx context.Context, option domain.FetchOrderOption) (res []domain.OrderInfo, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	list, err := u.orderRepo.Fetch(c, option)
+	if err != nil {
+		return
+	}
+
+	return u.orderInfosOf(c, list)
+}
+
+// orderInfosOf 목록 순서 그대로 고객, 담당자, 상태 정보를 채움
+func (u *ucase) orderInfosOf(c context.Context, list []domain.Order) (res []domain.OrderInfo, err error) {
 	res = make([]domain.OrderInfo, len(list))
 
 	bufSize := int(float64(len(list)) * 0.7)
@@ -164,6 +206,7 @@ func (u *ucase) Fetch(ctx context.Context, option domain.FetchOrderOption) (res
 		src := list[i]
 		res[i] = domain.OrderInfo{
 			OrderId:   src.Id,
+			Number:    src.Number,
 			OrderedAt: src.OrderedAt,
 			DoneAt:    src.DoneAt,
 		}
@@ -258,4 +301,59 @@ func (u *ucase) Fetch(ctx context.Context, option domain.FetchOrderOption) (res
 	}
 
 	return
-}
\ No newline at end of file
+}
+
+// FetchMyOrders 임시 의뢰 제외, 최근 요청 순
+func (u *ucase) FetchMyOrders(ctx context.Context, userId uuid.UUID) (res []domain.MyOrderInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.orderRepo.FetchByOrdererId(c, userId)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.MyOrderInfo, 0, len(list))
+	stateIds := make([]uint8, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		src := list[i]
+		if src.IsDraft {
+			continue
+		}
+
+		res = append(res, domain.MyOrderInfo{
+			OrderId:           src.Id,
+			Number:            src.Number,
+			OrderedAt:         src.OrderedAt,
+			DueDate:           src.DueDate,
+			OrderState:        src.State,
+			OrderStateContent: "알 수 없는 상태", // todo string resource
+			DoneAt:            src.DoneAt,
+			CanceledAt:        src.CanceledAt,
+		})
+		stateIds = append(stateIds, src.State)
+	}
+
+	if len(res) == 0 {
+		return
+	}
+
+	states, err := u.orderStateRepo.FetchByIds(c, stateIds)
+	if err != nil {
+		res = nil
+		return
+	}
+
+	stateMap := make(map[uint8]domain.OrderState, len(states))
+	for i := range states {
+		stateMap[states[i].Id] = states[i]
+	}
+
+	for i := range res {
+		if state, ok := stateMap[res[i].OrderState]; ok {
+			res[i].OrderStateContent = state.LongContent
+			res[i].OrderStateEmoji = state.Emoji
+		}
+	}
+	return
+}
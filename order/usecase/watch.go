@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+// watchersOf 의뢰별 지켜보는 관리자, 알림 대상일 뿐이라 실패하면 로그만 남기고 지켜보는 관리자 없이 진행
+func (u *ucase) watchersOf(ctx context.Context, orderIds ...uuid.UUID) map[uuid.UUID][]uuid.UUID {
+	list, err := u.watcherRepo.FetchByOrderIds(ctx, orderIds)
+	if err != nil {
+		logx.From(ctx).WithError(err).Warn(tag, "watchersOf, fetch watchers failed")
+		return nil
+	}
+	return domain.WatcherIdsByOrder(list)
+}
+
+// checkWatcher 어드민, 슈퍼 어드민만, 임시 의뢰나 없는 의뢰는 ErrItemNotFound
+func (u *ucase) checkWatcher(ctx context.Context, orderId, userId uuid.UUID) error {
+	g, gc := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		user, err := u.userRepo.GetById(gc, userId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(user, domain.User.IsAdmin, domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		order, err := u.orderRepo.GetById(gc, orderId)
+		if err != nil {
+			return
+		}
+
+		if order == nil || order.IsDraft {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	return g.Wait()
+}
+
+func (u *ucase) WatchOrder(ctx context.Context, in domain.WatchOrder) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	err = u.checkWatcher(c, in.OrderId, in.UserId)
+	if err != nil {
+		return
+	}
+
+	return u.watcherRepo.Watch(c, domain.OrderWatcher{
+		OrderId:   in.OrderId,
+		UserId:    in.UserId,
+		CreatedAt: u.calendar.Now(),
+	})
+}
+
+// UnwatchOrder 지켜보지 않던 의뢰여도 성공
+func (u *ucase) UnwatchOrder(ctx context.Context, in domain.UnwatchOrder) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	return u.watcherRepo.Unwatch(c, in.OrderId, in.UserId)
+}
+
+func (u *ucase) FetchWatchedOrders(ctx context.Context, userId uuid.UUID) (res []domain.OrderInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	watched, err := u.watcherRepo.FetchByUserId(c, userId)
+	if err != nil {
+		return
+	}
+
+	orderIds := make([]uuid.UUID, len(watched))
+	for i := range watched {
+		orderIds[i] = watched[i].OrderId
+	}
+
+	orders, err := u.orderRepo.FetchByIds(c, orderIds)
+	if err != nil {
+		return
+	}
+
+	// 지켜보기 시작한 순서대로, 그 사이 없어진 의뢰는 건너뜀
+	byId := make(map[uuid.UUID]domain.Order, len(orders))
+	for i := range orders {
+		byId[orders[i].Id] = orders[i]
+	}
+	list := make([]domain.Order, 0, len(orders))
+	for _, id := range orderIds {
+		if order, ok := byId[id]; ok && !order.IsDraft {
+			list = append(list, order)
+		}
+	}
+
+	return u.orderInfosOf(c, list)
+}
@@ -2,8 +2,8 @@ package handler
 
 import (
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
 	"net/http"
 )
 
@@ -47,16 +47,18 @@ func useCaseToOrderStateInfoListResponse(list []domain.OrderStateInfo) (res Orde
 // @Description 제작 상태 목록 전부 가져오는 기능
 // @Accept json
 // @Produce json
+// @Param If-None-Match header string false "이전 응답의 ETag"
 // @Success 200 {object} OrderStateInfoListResponse true "성공"
+// @Success 304 "변경 없음"
 // @Router /order/state/full [get]
 func (c *OrderStateController) fetchFull(ctx echo.Context) error {
 	list, err := c.useCase.FetchFull(ctx.Request().Context())
-	if err != nil{
-		log.WithError(err).Error(tag, "fetch full, unhandled error useCase.FetchFull")
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetch full, unhandled error useCase.FetchFull")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 
-	return ctx.JSON(http.StatusOK, useCaseToOrderStateInfoListResponse(list))
+	return echox.CachedJSON(ctx, domain.OrderStateMaxAge, useCaseToOrderStateInfoListResponse(list))
 }
 
 // @Tags 기타
@@ -65,8 +67,10 @@ func (c *OrderStateController) fetchFull(ctx echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param order_state_id path int true "제작 상태 식별 아이디"
+// @Param If-None-Match header string false "이전 응답의 ETag"
 // @Success 200 {object} OrderStateInfoListResponse true "성공"
 // @Success 204 "값이 없음"
+// @Success 304 "변경 없음"
 // @Router /order/state/{order_state_id}/sub [get]
 func (c *OrderStateController) fetchSub(ctx echo.Context) error {
 	var req struct {
@@ -75,13 +79,13 @@ func (c *OrderStateController) fetchSub(ctx echo.Context) error {
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "internalCreateTicket data binding error")
+		echox.Log(ctx).WithError(err).Trace(tag, "internalCreateTicket data binding error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
 	}
 
 	list, err := c.useCase.FetchByParentId(ctx.Request().Context(), req.OrderStateId)
-	if err != nil{
-		log.WithError(err).Error(tag, "fetchSub, unhandled error useCase.FetchByParentId")
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchSub, unhandled error useCase.FetchByParentId")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 
@@ -89,11 +93,10 @@ func (c *OrderStateController) fetchSub(ctx echo.Context) error {
 		return ctx.NoContent(http.StatusNoContent)
 	}
 
-	return ctx.JSON(http.StatusOK, useCaseToOrderStateInfoListResponse(list))
+	return echox.CachedJSON(ctx, domain.OrderStateMaxAge, useCaseToOrderStateInfoListResponse(list))
 }
 
 func (c *OrderStateController) Bind(e *echo.Echo) {
 	e.GET("/order/state/full", c.fetchFull)
 	e.GET("/order/state/:orderStateId/sub", c.fetchSub)
 }
-
@@ -3,12 +3,14 @@ package repository
 import (
 	"context"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
 	"github.com/stockfolioofficial/back-editfolio/util/pointer"
 	"gorm.io/gorm"
 )
 
 func NewOrderStateRepository(db *gorm.DB) domain.OrderStateRepository {
 	db.AutoMigrate(&domain.OrderState{})
+	gormx.MigrateEnum(db, domain.OrderStateCodeEnum.Table, domain.OrderStateCodeEnum.Column, domain.OrderStateCodeEnum.Values)
 	bookedOrderState := []domain.OrderState{
 		{
 			Id:          1,
@@ -76,8 +78,15 @@ func NewOrderStateRepository(db *gorm.DB) domain.OrderStateRepository {
 			ParentId:    pointer.Uint8(7),
 			GroupId:     pointer.Uint8(2),
 		},
+		{
+			Id:          9,
+			Code:        domain.OrderStateCodeCancel,
+			Content:     "취소",
+			LongContent: "의뢰가 취소되었습니다",
+			Emoji:       "🙅",
+		},
 	}
-	db.Create(bookedOrderState)
+	gormx.Seed(db, bookedOrderState)
 	return &repo{db: db}
 }
 
@@ -115,7 +124,6 @@ func (r *repo) FetchByGroupId(ctx context.Context, groupId uint8) (list []domain
 	return
 }
 
-
 func (r *repo) GetById(ctx context.Context, id uint8) (res *domain.OrderState, err error) {
 	var entity domain.OrderState
 	err = r.db.WithContext(ctx).First(&entity, id).Error
@@ -141,5 +149,3 @@ func (r *repo) FetchByIds(ctx context.Context, ids []uint8) (list []domain.Order
 		Find(&list, ids).Error
 	return
 }
-
-
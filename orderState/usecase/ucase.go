@@ -2,8 +2,17 @@ package usecase
 
 import (
 	"context"
-	"github.com/stockfolioofficial/back-editfolio/domain"
+	"strconv"
 	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	cacheFullKey = "full"
 )
 
 func NewOrderStateUseCase(
@@ -13,47 +22,58 @@ func NewOrderStateUseCase(
 	return &ucase{
 		orderStateRepo: orderStateRepo,
 		timeout:        timeout,
+		cache:          cache.New(domain.OrderStateCacheName, domain.OrderStateCacheTTL),
 	}
 }
 
 type ucase struct {
 	orderStateRepo domain.OrderStateRepository
-	timeout time.Duration
+	timeout        time.Duration
+	cache          *cache.Store
 }
 
 // FetchByParentId
 // Deprecated
 func (u *ucase) FetchByParentId(ctx context.Context, parentId uint8) (res []domain.OrderStateInfo, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
-	state, err := u.orderStateRepo.GetById(c, parentId)
-	if err != nil {
-		return
-	}
+	cached, err := u.cache.GetOrLoad(tenant.CacheKey(ctx, strconv.Itoa(int(parentId))), func() (interface{}, error) {
+		state, err := u.orderStateRepo.GetById(c, parentId)
+		if err != nil || state == nil || state.GroupId == nil {
+			return []domain.OrderStateInfo(nil), err
+		}
 
-	if state != nil && state.GroupId != nil {
-		var list []domain.OrderState
-		list, err = u.orderStateRepo.FetchByGroupId(c, *state.GroupId)
+		list, err := u.orderStateRepo.FetchByGroupId(c, *state.GroupId)
 		if err != nil {
-			return
+			return nil, err
 		}
-		res = domainToOrderStateInfoList(list)
+		return domainToOrderStateInfoList(list), nil
+	})
+	if err != nil {
+		return
 	}
 
+	res = cached.([]domain.OrderStateInfo)
 	return
 }
 
 func (u *ucase) FetchFull(ctx context.Context) (res []domain.OrderStateInfo, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
-	list, err := u.orderStateRepo.FetchFull(c)
+	cached, err := u.cache.GetOrLoad(tenant.CacheKey(ctx, cacheFullKey), func() (interface{}, error) {
+		list, err := u.orderStateRepo.FetchFull(c)
+		if err != nil {
+			return nil, err
+		}
+		return domainToOrderStateInfoList(list), nil
+	})
 	if err != nil {
 		return
 	}
 
-	res = domainToOrderStateInfoList(list)
+	res = cached.([]domain.OrderStateInfo)
 	return
 }
 
@@ -71,4 +91,4 @@ func domainToOrderStateInfoList(list []domain.OrderState) (res []domain.OrderSta
 	}
 
 	return
-}
\ No newline at end of file
+}
@@ -3,8 +3,8 @@ package handler
 import (
 	"fmt"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
 	"net/http"
 )
 
@@ -15,12 +15,19 @@ func (c *OrderTicketController) internalCreateTicket(ctx echo.Context) error {
 		Value      uint16 `json:"value" validate:"required,max=30000"`
 		Unit       string `json:"unit" validate:"required,eq=M|eq=D"`
 		OrderCount uint8  `json:"orderCount" validate:"required,max=30"`
-		EditCount  uint8  `json:"editCount" validate:"required,max=60"`
+		EditCount  uint8  `json:"editCount" validate:"max=60"` // 0 이면 기본 수정 횟수 설정값
+
+		PaymentFingerprint *string `json:"paymentFingerprint" validate:"omitempty,max=128"`
+
+		PlanName      *string `json:"planName" validate:"omitempty,max=60"`
+		Amount        *int64  `json:"amount" validate:"omitempty,min=0"`
+		PaymentMethod *string `json:"paymentMethod" validate:"omitempty,max=60"`
+		ReceiptUrl    *string `json:"receiptUrl" validate:"omitempty,url,max=1000"`
 	}
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "internalCreateTicket data binding error")
+		echox.Log(ctx).WithError(err).Trace(tag, "internalCreateTicket data binding error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
 	}
 
@@ -31,6 +38,13 @@ func (c *OrderTicketController) internalCreateTicket(ctx echo.Context) error {
 		Unit:       domain.SubscribeUnit(req.Unit),
 		OrderCount: req.OrderCount,
 		EditCount:  req.EditCount,
+
+		PaymentFingerprint: req.PaymentFingerprint,
+
+		PlanName:      req.PlanName,
+		Amount:        req.Amount,
+		PaymentMethod: req.PaymentMethod,
+		ReceiptUrl:    req.ReceiptUrl,
 	})
 
 	switch err {
@@ -46,7 +60,9 @@ func (c *OrderTicketController) internalCreateTicket(ctx echo.Context) error {
 		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{
 			Message: fmt.Sprintf("ex_order_id=%s, exists", req.ExOrderId),
 		})
+	case domain.ErrContractNotSigned:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.ContractNotSignedResponse)
 	default:
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
-}
\ No newline at end of file
+}
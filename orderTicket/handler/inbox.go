@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewPaymentSettledInboxHandler 결제 정산 메시지로 구독권 생성, 이미 생성된 결제는 성공으로 처리
+// 계약서 서명 전인 기업 고객은 실패로 남겨 서명 후(user.contract_signed) 같은 메시지를 다시 받으면 생성
+func NewPaymentSettledInboxHandler(useCase domain.OrderTicketUseCase) domain.InboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var msg struct {
+			ExOrderId          string  `json:"exOrderId"`
+			Username           string  `json:"username"`
+			Value              uint16  `json:"value"`
+			Unit               string  `json:"unit"`
+			OrderCount         uint8   `json:"orderCount"`
+			EditCount          uint8   `json:"editCount"`
+			PaymentFingerprint *string `json:"paymentFingerprint"`
+			PlanName           *string `json:"planName"`
+			Amount             *int64  `json:"amount"`
+			PaymentMethod      *string `json:"paymentMethod"`
+			ReceiptUrl         *string `json:"receiptUrl"`
+		}
+
+		err := json.Unmarshal(payload, &msg)
+		if err != nil {
+			return err
+		}
+
+		_, err = useCase.CreateSubscribeTicket(ctx, domain.CreateSubscribeTicket{
+			ExOrderId:  msg.ExOrderId,
+			Username:   msg.Username,
+			Value:      msg.Value,
+			Unit:       domain.SubscribeUnit(msg.Unit),
+			OrderCount: msg.OrderCount,
+			EditCount:  msg.EditCount,
+
+			PaymentFingerprint: msg.PaymentFingerprint,
+
+			PlanName:      msg.PlanName,
+			Amount:        msg.Amount,
+			PaymentMethod: msg.PaymentMethod,
+			ReceiptUrl:    msg.ReceiptUrl,
+		})
+		if err == domain.ErrItemAlreadyExist {
+			return nil
+		}
+		return err
+	}
+}
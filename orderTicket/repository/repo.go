@@ -25,6 +25,10 @@ func (r *repo) Transaction(ctx context.Context, fn func(orderTicketRepo domain.O
 	}, options...)
 }
 
+func (r *repo) With(tx gormx.Tx) domain.OrderTicketTxRepository {
+	return &repo{db: tx.Get()}
+}
+
 func (r *repo) Save(ctx context.Context, orderTicket *domain.OrderTicket) error {
 	return gormx.Upsert(ctx, r.db, orderTicket)
 }
@@ -86,6 +90,32 @@ func (r *repo) GetByOwnerIdBetweenStartAndEnd(ctx context.Context, id uuid.UUID,
 	return
 }
 
+func (r *repo) ExistsByOwnerIdAndPaymentFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) (exists bool, err error) {
+	var cnt int64
+	err = r.db.WithContext(ctx).
+		Model(&domain.OrderTicket{}).
+		Where("`owner_id` = ? AND `payment_fingerprint` = ?", id, fingerprint).
+		Count(&cnt).Error
+	exists = cnt > 0
+	return
+}
+
+func (r *repo) FetchByOwnerId(ctx context.Context, id uuid.UUID) (list []domain.OrderTicket, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`created_at` asc").
+		Where("`owner_id` = ?", id).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) ReassignOwner(ctx context.Context, from, to uuid.UUID) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.OrderTicket{}).
+		Where("`owner_id` = ?", from).
+		Update("owner_id", to)
+	return res.RowsAffected, res.Error
+}
+
 func (r *repo) Get() *gorm.DB {
 	return r.db
 }
@@ -4,18 +4,34 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
 	"golang.org/x/sync/errgroup"
+	"math"
 	"time"
 )
 
 func NewOrderTicketUseCase(
 	orderTicketRepo domain.OrderTicketRepository,
 	userRepo domain.UserRepository,
+	referralRepo domain.ReferralRepository,
+	creditRepo domain.CreditRepository,
+	settingReader domain.SettingReader,
+	calendar domain.Calendar,
+	contractGate domain.ContractGate,
+	ids domain.IdGenerator,
+	clock domain.Clock,
 	timeout time.Duration,
 ) domain.OrderTicketUseCase {
 	return &ucase{
 		orderTicketRepo: orderTicketRepo,
 		userRepo:        userRepo,
+		referralRepo:    referralRepo,
+		creditRepo:      creditRepo,
+		settingReader:   settingReader,
+		calendar:        calendar,
+		contractGate:    contractGate,
+		ids:             ids,
+		clock:           clock,
 		timeout:         timeout,
 	}
 }
@@ -23,11 +39,18 @@ func NewOrderTicketUseCase(
 type ucase struct {
 	orderTicketRepo domain.OrderTicketRepository
 	userRepo        domain.UserRepository
+	referralRepo    domain.ReferralRepository
+	creditRepo      domain.CreditRepository
+	settingReader   domain.SettingReader
+	calendar        domain.Calendar
+	contractGate    domain.ContractGate
+	ids             domain.IdGenerator
+	clock           domain.Clock
 	timeout         time.Duration
 }
 
 func (u *ucase) CreateSubscribeTicket(ctx context.Context, in domain.CreateSubscribeTicket) (ticketId uuid.UUID, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	var userId uuid.UUID
@@ -63,44 +86,71 @@ func (u *ucase) CreateSubscribeTicket(ctx context.Context, in domain.CreateSubsc
 		return
 	}
 
-	ticket, err :=  u.orderTicketRepo.GetEndByOwnerId(c, userId)
+	// 기업 고객은 계약서 서명 전까지 구독권을 만들지 않음, 결제 시스템이 서명 이벤트를 받고 다시 보냄
+	err = u.contractGate.RequireSigned(c, userId)
 	if err != nil {
 		return
 	}
 
+	ticket, err := u.orderTicketRepo.GetEndByOwnerId(c, userId)
+	if err != nil {
+		return
+	}
+
+	// 이용 기간은 서버 TZ 와 무관하게 KST 기준 월/일로 계산
 	var (
-		startAt = time.Now()
-		endAt time.Time
+		startAt = u.calendar.Now()
+		endAt   time.Time
 	)
 	if ticket != nil && ticket.EndAt != nil && ticket.EndAt.After(startAt) {
-		startAt = *ticket.EndAt
+		startAt = ticket.EndAt.In(u.calendar.Location())
 	}
 
 	switch in.Unit {
 	case domain.SubscribeUnitMonth:
-		monthEndDay := time.Date(startAt.Year(), startAt.Month() + time.Month(in.Value) + 1, 0,
-			startAt.Hour(), startAt.Minute(), startAt.Second(),
-			startAt.Nanosecond(), startAt.Location(),
-		)
-		if startAt.Day() < monthEndDay.Day() {
-			endAt = startAt.AddDate(0, int(in.Value), 0)
-		} else {
-			endAt = monthEndDay
-		}
+		endAt = u.calendar.AddMonths(startAt, int(in.Value))
 	case domain.SubscribeUnitDay:
 		endAt = startAt.AddDate(0, 0, int(in.Value))
 	}
 
+	editCount := in.EditCount
+	if editCount == 0 {
+		var limit int64
+		limit, err = u.settingReader.Int(c, domain.SettingKeyOrderRevisionLimit)
+		if err != nil {
+			return
+		}
+		if limit > 0 && limit <= math.MaxUint8 {
+			editCount = uint8(limit)
+		}
+	}
+
 	newTicket := domain.CreateOrderTicket(domain.CreateOrderTicketOption{
+		Id:              u.ids.NewId(),
 		ExOrderId:       in.ExOrderId,
 		OwnerId:         userId,
 		TotalOrderCount: in.OrderCount,
-		EditCount:       in.EditCount,
+		EditCount:       editCount,
 		StartAt:         &startAt,
 		EndAt:           &endAt,
+
+		PaymentFingerprint: in.PaymentFingerprint,
+
+		PlanName:      in.PlanName,
+		Amount:        in.Amount,
+		PaymentMethod: in.PaymentMethod,
+		ReceiptUrl:    in.ReceiptUrl,
+		Now:           u.clock.Now(),
 	})
 
-	err = u.orderTicketRepo.Save(c, &newTicket)
+	err = u.orderTicketRepo.Transaction(c, func(orderTicketRepo domain.OrderTicketTxRepository) error {
+		err := orderTicketRepo.Save(c, &newTicket)
+		if err != nil {
+			return err
+		}
+
+		return u.rewardReferral(c, orderTicketRepo, newTicket)
+	})
 	if err != nil {
 		return
 	}
@@ -109,3 +159,44 @@ func (u *ucase) CreateSubscribeTicket(ctx context.Context, in domain.CreateSubsc
 	return
 }
 
+// rewardReferral 피추천인의 첫 결제 시 추천인에게 크레딧 지급, 추천인과 같은 결제 수단이면 거절
+func (u *ucase) rewardReferral(ctx context.Context, orderTicketRepo domain.OrderTicketTxRepository, ticket domain.OrderTicket) (err error) {
+	referralRepo := u.referralRepo.With(orderTicketRepo)
+	now := u.clock.Now()
+	referral, err := referralRepo.GetByRefereeId(ctx, ticket.OwnerId)
+	if err != nil || referral == nil || !referral.IsPending() {
+		return
+	}
+
+	if ticket.PaymentFingerprint != nil {
+		var samePayment bool
+		samePayment, err = orderTicketRepo.ExistsByOwnerIdAndPaymentFingerprint(ctx, referral.ReferrerId, *ticket.PaymentFingerprint)
+		if err != nil {
+			return
+		}
+
+		if samePayment {
+			referral.Reject(domain.ReferralRejectReasonSamePaymentMethod, now)
+			return referralRepo.Save(ctx, referral)
+		}
+	}
+
+	referral.Reward(domain.ReferralRewardCredit, now)
+	reference := "referral:" + referral.Id.String()
+	tx := domain.EarnCredit(domain.EarnCreditOption{
+		Ids:        u.ids,
+		CustomerId: referral.ReferrerId,
+		Source:     domain.CreditAccountReferral,
+		Kind:       domain.CreditEntryKindEarn,
+		Amount:     referral.RewardCredit,
+		Reference:  &reference,
+		Now:        now,
+	})
+
+	err = u.creditRepo.With(orderTicketRepo).SaveTransaction(ctx, &tx)
+	if err != nil {
+		return
+	}
+
+	return referralRepo.Save(ctx, referral)
+}
@@ -0,0 +1,150 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	kafkaJsonContentType = "application/vnd.kafka.json.v2+json"
+	kafkaPublishTimeout  = 10 * time.Second
+)
+
+// NewEventPublisher Kafka REST Proxy 주소가 없으면 이벤트를 로그로만 남기는 publisher 반환
+// policy 는 한 번 발행 안에서의 재시도, 다 실패하면 다음 발행 작업에서 OutboxMaxAttempts 까지 다시 보냄
+func NewEventPublisher(restProxy, topicPrefix string, topics map[string]string, policy retry.Policy) domain.EventPublisher {
+	if restProxy == "" {
+		return &logPublisher{}
+	}
+
+	return &kafkaPublisher{
+		restProxy:   strings.TrimRight(restProxy, "/"),
+		topicPrefix: topicPrefix,
+		topics:      topics,
+		client:      &http.Client{},
+		policy:      policy,
+	}
+}
+
+type kafkaPublisher struct {
+	restProxy   string
+	topicPrefix string
+	topics      map[string]string
+	client      *http.Client
+	policy      retry.Policy
+}
+
+type kafkaEnvelope struct {
+	Id          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateId string          `json:"aggregateId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Data        json.RawMessage `json:"data"`
+}
+
+type kafkaRecord struct {
+	Key   string        `json:"key"`
+	Value kafkaEnvelope `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition *int32  `json:"partition"`
+		Offset    *int64  `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (p *kafkaPublisher) topic(aggregateType domain.OutboxAggregateType) string {
+	if topic, ok := p.topics[string(aggregateType)]; ok {
+		return topic
+	}
+	return p.topicPrefix + string(aggregateType)
+}
+
+// Publish aggregate id 를 파티션 키로 사용해 같은 aggregate 의 이벤트 순서 유지
+func (p *kafkaPublisher) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	body, err := json.Marshal(struct {
+		Records []kafkaRecord `json:"records"`
+	}{
+		Records: []kafkaRecord{{
+			Key: event.AggregateId.String(),
+			Value: kafkaEnvelope{
+				Id:          event.Id.String(),
+				Type:        string(event.EventType),
+				AggregateId: event.AggregateId.String(),
+				OccurredAt:  event.CreatedAt,
+				Data:        json.RawMessage(event.Payload),
+			},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/topics/%s", p.restProxy, p.topic(event.AggregateType))
+	return retry.Do(ctx, p.policy, func(ctx context.Context) error {
+		return p.produce(ctx, url, body)
+	})
+}
+
+func (p *kafkaPublisher) produce(ctx context.Context, url string, body []byte) error {
+	// 호출 측 남은 예산을 넘기지 않도록 제한
+	c, cancel := budget.Slice(ctx, kafkaPublishTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaJsonContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return &retry.StatusError{Service: "kafka rest proxy", Code: res.StatusCode}
+	}
+
+	var produced kafkaProduceResponse
+	err = json.NewDecoder(res.Body).Decode(&produced)
+	if err != nil {
+		return err
+	}
+
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			msg := ""
+			if offset.Error != nil {
+				msg = *offset.Error
+			}
+			return fmt.Errorf("kafka rest proxy, error_code=%d, %s", *offset.ErrorCode, msg)
+		}
+	}
+	return nil
+}
+
+type logPublisher struct{}
+
+func (p *logPublisher) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	log.WithField("id", event.Id).
+		WithField("type", event.EventType).
+		WithField("aggregateId", event.AggregateId).
+		Trace("[OUTBOX] ", "kafka not configured, skip publish")
+	return nil
+}
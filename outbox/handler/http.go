@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+const (
+	tag = "[OUTBOX] "
+)
+
+func NewOutboxController(useCase domain.OutboxUseCase) *OutboxController {
+	return &OutboxController{useCase: useCase}
+}
+
+type OutboxController struct {
+	useCase domain.OutboxUseCase
+}
+
+func (c *OutboxController) Bind(e *echo.Echo) {
+	// INTERNAL
+	e.POST("/internal/outbox/dispatch", c.internalDispatch)
+}
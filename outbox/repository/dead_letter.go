@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+// NewOutboxDeadLetterRepository 테이블은 NewOutboxRepository 가 만듦
+func NewOutboxDeadLetterRepository(db *gorm.DB) domain.DeadLetterRepository {
+	return &deadLetterRepo{db: db}
+}
+
+type deadLetterRepo struct {
+	db *gorm.DB
+}
+
+// dead 발행하지 못했고 시도 횟수를 다 쓴 이벤트, ids 가 있으면 그 중에서만
+func (r *deadLetterRepo) dead(ctx context.Context, ids []uuid.UUID) *gorm.DB {
+	db := r.db.WithContext(ctx).
+		Where("`published_at` IS NULL AND `attempts` >= ?", domain.OutboxMaxAttempts)
+	if len(ids) > 0 {
+		db = db.Where("`id` IN ?", ids)
+	}
+	return db
+}
+
+func (r *deadLetterRepo) FetchDead(ctx context.Context, option domain.FetchDeadLetterOption) (list []domain.DeadLetter, err error) {
+	db := r.dead(ctx, nil).
+		Order("`created_at` desc").
+		Limit(option.Limit)
+	if option.Before != nil {
+		db = db.Where("`created_at` < ?", option.Before)
+	}
+
+	var events []domain.OutboxEvent
+	err = db.Find(&events).Error
+	if err != nil {
+		return
+	}
+
+	list = make([]domain.DeadLetter, len(events))
+	for i := range events {
+		list[i] = deadLetterOf(events[i])
+	}
+	return
+}
+
+func (r *deadLetterRepo) GetDead(ctx context.Context, id uuid.UUID) (letter *domain.DeadLetter, err error) {
+	var entity domain.OutboxEvent
+	err = r.dead(ctx, []uuid.UUID{id}).First(&entity).Error
+	if err == nil {
+		dead := deadLetterOf(entity)
+		letter = &dead
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
+
+// RetryDead 시도 횟수만 되돌림, 마지막 에러는 다음 발행에 성공할 때 지워짐
+func (r *deadLetterRepo) RetryDead(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	res := r.dead(ctx, ids).
+		Model(&domain.OutboxEvent{}).
+		Update("attempts", 0)
+	return res.RowsAffected, res.Error
+}
+
+func (r *deadLetterRepo) PurgeDead(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	res := r.dead(ctx, ids).Delete(&domain.OutboxEvent{})
+	return res.RowsAffected, res.Error
+}
+
+func deadLetterOf(event domain.OutboxEvent) domain.DeadLetter {
+	return domain.DeadLetter{
+		Id:        event.Id,
+		Kind:      domain.DeadLetterKindOutbox,
+		Type:      string(event.EventType),
+		Target:    string(event.AggregateType) + "/" + event.AggregateId.String(),
+		Attempts:  event.Attempts,
+		LastError: event.LastError,
+		Payload:   event.Payload,
+		CreatedAt: event.CreatedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewOutboxRepository(db *gorm.DB) domain.OutboxRepository {
+	db.AutoMigrate(&domain.OutboxEvent{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.OutboxTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(outboxRepo domain.OutboxTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) Save(ctx context.Context, event *domain.OutboxEvent) error {
+	return gormx.Upsert(ctx, r.db, event)
+}
+
+func (r *repo) FetchPending(ctx context.Context, limit int) (list []domain.OutboxEvent, err error) {
+	err = r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("`created_at` asc").
+		Where("`published_at` IS NULL AND `attempts` < ?", domain.OutboxMaxAttempts).
+		Limit(limit).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) GetBacklog(ctx context.Context) (backlog domain.OutboxBacklog, err error) {
+	var row struct {
+		Pending         int64
+		Dead            int64
+		OldestPendingAt *time.Time
+	}
+	err = r.db.WithContext(ctx).
+		Model(&domain.OutboxEvent{}).
+		Select("COALESCE(SUM(`attempts` < ?), 0) AS pending, COALESCE(SUM(`attempts` >= ?), 0) AS dead, "+
+			"MIN(CASE WHEN `attempts` < ? THEN `created_at` END) AS oldest_pending_at",
+			domain.OutboxMaxAttempts, domain.OutboxMaxAttempts, domain.OutboxMaxAttempts).
+		Where("`published_at` IS NULL").
+		Scan(&row).Error
+	if err != nil {
+		return
+	}
+
+	backlog = domain.OutboxBacklog{
+		Pending:         row.Pending,
+		Dead:            row.Dead,
+		OldestPendingAt: row.OldestPendingAt,
+	}
+	return
+}
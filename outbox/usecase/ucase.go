@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewOutboxUseCase(
+	outboxRepo domain.OutboxRepository,
+	publisher domain.EventPublisher,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.OutboxUseCase {
+	return &ucase{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		clock:      clock,
+		timeout:    timeout,
+	}
+}
+
+type ucase struct {
+	outboxRepo domain.OutboxRepository
+	publisher  domain.EventPublisher
+	clock      domain.Clock
+	timeout    time.Duration
+}
+
+// DispatchOutbox 발행 대기 이벤트를 aggregate 별로 나눠 동시에 발행
+// 같은 aggregate 의 이벤트는 생성 순서대로 발행하고, 실패하면 그 aggregate 의 다음 이벤트는 이번 회차에서 건너뜀
+func (u *ucase) DispatchOutbox(ctx context.Context) (published int, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	err = u.outboxRepo.Transaction(c, func(outboxRepo domain.OutboxTxRepository) error {
+		list, err := outboxRepo.FetchPending(c, domain.OutboxDispatchBatchSize)
+		if err != nil {
+			return err
+		}
+
+		var groups [][]*domain.OutboxEvent
+		groupIndex := make(map[string]int)
+		for i := range list {
+			key := list[i].AggregateId.String()
+			idx, ok := groupIndex[key]
+			if !ok {
+				idx = len(groups)
+				groupIndex[key] = idx
+				groups = append(groups, nil)
+			}
+			groups[idx] = append(groups[idx], &list[i])
+		}
+
+		// 발행만 동시에 하고, 트랜잭션 커넥션은 하나라 저장은 아래에서 순서대로
+		attempted := make([][]*domain.OutboxEvent, len(groups))
+		pool, _ := workerpool.New(c, workerpool.Option{Size: domain.OutboxDispatchConcurrency})
+		for i := range groups {
+			i := i
+			pool.Go(func(ctx context.Context) error {
+				for _, event := range groups[i] {
+					attempted[i] = append(attempted[i], event)
+					pubErr := u.publisher.Publish(ctx, *event)
+					if pubErr != nil {
+						event.Failed(pubErr)
+						return nil
+					}
+					event.Published(u.clock.Now())
+				}
+				return nil
+			})
+		}
+		err = pool.Wait()
+		if err != nil {
+			return err
+		}
+
+		for _, events := range attempted {
+			for _, event := range events {
+				err = outboxRepo.Save(c, event)
+				if err != nil {
+					return err
+				}
+				if event.PublishedAt != nil {
+					published++
+				}
+			}
+		}
+		return nil
+	})
+	return
+}
+
+func (u *ucase) GetBacklog(ctx context.Context) (backlog domain.OutboxBacklog, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	backlog, err = u.outboxRepo.GetBacklog(c)
+	return
+}
@@ -0,0 +1,29 @@
+package adapter
+
+import (
+	"image/color"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// brandColor 에디트폴리오 대표 색, 배경은 흰색으로 두어야 대부분의 카메라가 읽음
+var brandColor = color.RGBA{R: 0x1f, G: 0x2a, B: 0x44, A: 0xff}
+
+func NewQRCodeEncoder() domain.QRCodeEncoder {
+	return &encoder{}
+}
+
+type encoder struct{}
+
+// PNG 로고를 덮어도 읽히도록 복원 수준은 중간(15%)
+func (e *encoder) PNG(content string, size int) ([]byte, error) {
+	q, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	q.ForegroundColor = brandColor
+	q.BackgroundColor = color.White
+	return q.PNG(size)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[QR_CODE] "
+
+	// qrCodeCacheControl 같은 주소는 항상 같은 이미지라서 브라우저 캐시 허용
+	qrCodeCacheControl = "private, max-age=86400"
+)
+
+func NewQRCodeController(useCase domain.QRCodeUseCase) *QRCodeController {
+	return &QRCodeController{useCase: useCase}
+}
+
+type QRCodeController struct {
+	useCase domain.QRCodeUseCase
+}
+
+func (c *QRCodeController) Bind(e *echo.Echo) {
+	// ===== CUSTOMER, ADMIN =====
+	e.GET("/util/qr", c.generateQRCode, middleware.RequireAuth())
+}
+
+// @Tags 기타
+// @Security Auth-Jwt-Bearer
+// @Summary QR 코드 이미지
+// @Description 결제, 온보딩, 짧은 주소만 가능(허용 주소는 설정값), 크기와 색은 서버에서 고정, 로그인한 사용자만
+// @Produce png
+// @Param target query string true "QR 코드에 넣을 주소"
+// @Success 200 {file} binary "PNG 이미지"
+// @Failure 400 {object} domain.ErrorResponse "허용하지 않는 주소"
+// @Router /util/qr [get]
+func (c *QRCodeController) generateQRCode(ctx echo.Context) error {
+	target := ctx.QueryParam("target")
+	png, err := c.useCase.Generate(ctx.Request().Context(), target)
+
+	switch err {
+	case nil:
+		ctx.Response().Header().Set(echo.HeaderCacheControl, qrCodeCacheControl)
+		return ctx.Blob(http.StatusOK, "image/png", png)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "target not allowed"})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("target", target).
+			Error(tag, "generateQRCode, unhandled error useCase.Generate")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+func NewQRCodeUseCase(encoder domain.QRCodeEncoder, targets domain.QRTargets) domain.QRCodeUseCase {
+	return &ucase{
+		encoder: encoder,
+		targets: targets,
+	}
+}
+
+type ucase struct {
+	encoder domain.QRCodeEncoder
+	targets domain.QRTargets
+}
+
+// Generate DB, 외부 호출이 없어 제한 시간은 따로 두지 않음
+func (u *ucase) Generate(_ context.Context, target string) (png []byte, err error) {
+	if _, ok := u.targets.Match(target); !ok {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	png, err = u.encoder.PNG(target, domain.QRCodeSize)
+	return
+}
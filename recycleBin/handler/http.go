@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[RECYCLE-BIN] "
+)
+
+func NewRecycleBinController(useCase domain.RecycleBinUseCase, userUseCase domain.UserUseCase) *RecycleBinController {
+	return &RecycleBinController{useCase: useCase, userUseCase: userUseCase}
+}
+
+type RecycleBinController struct {
+	useCase     domain.RecycleBinUseCase
+	userUseCase domain.UserUseCase
+}
+
+func (c *RecycleBinController) Bind(e *echo.Echo) {
+	// ===== SUPER_ADMIN =====
+	e.GET("/recycle-bin", c.fetchRecycleBin,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.POST("/recycle-bin/user/:userId/restore", echox.UserID(c.restoreUser),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type RecycleBinItemResponse struct {
+	Id            uuid.UUID  `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name          string     `json:"name" validate:"required" example:"홍길동"`
+	Username      string     `json:"username" validate:"required" example:"test@test.com"`
+	DeletedAt     time.Time  `json:"deletedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+	DeletedBy     *uuid.UUID `json:"deletedBy" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DeletedByName *string    `json:"deletedByName" example:"편집자"`
+} // @name RecycleBinItemResponse
+
+type RecycleBinResponse struct {
+	Admins    []RecycleBinItemResponse `json:"admins" validate:"required"`
+	Customers []RecycleBinItemResponse `json:"customers" validate:"required"`
+} // @name RecycleBinResponse
+
+func toItemResponses(list []domain.RecycleBinItem) []RecycleBinItemResponse {
+	res := make([]RecycleBinItemResponse, len(list))
+	for i, src := range list {
+		res[i] = RecycleBinItemResponse{
+			Id:            src.Id,
+			Name:          src.Name,
+			Username:      src.Username,
+			DeletedAt:     src.DeletedAt,
+			DeletedBy:     src.DeletedBy,
+			DeletedByName: src.DeletedByName,
+		}
+	}
+	return res
+}
+
+type FetchRecycleBinRequest struct {
+	Days uint16 `query:"days" validate:"omitempty,max=365"`
+}
+
+// @Tags (RecycleBin) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 휴지통
+// @Description 최근 삭제된 어드민, 고객 목록 (최근 삭제 순), 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param days query int false "조회 기간(일), 기본 30 최대 365"
+// @Success 200 {object} RecycleBinResponse "성공"
+// @Router /recycle-bin [get]
+func (c *RecycleBinController) fetchRecycleBin(ctx echo.Context) error {
+	var req FetchRecycleBinRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch recycle bin, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	bin, err := c.useCase.FetchRecycleBin(ctx.Request().Context(), domain.FetchRecycleBinOption{
+		Days: req.Days,
+	})
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchRecycleBin, unhandled error useCase.FetchRecycleBin")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, RecycleBinResponse{
+		Admins:    toItemResponses(bin.Admins),
+		Customers: toItemResponses(bin.Customers),
+	})
+}
+
+type RestoreUserRequest struct {
+	UserId uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// @Tags (RecycleBin) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 삭제된 유저 복구
+// @Description 삭제된 어드민, 고객을 복구, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "유저 식별 아이디(UUID)"
+// @Success 204 "복구 완료"
+// @Failure 404 {object} domain.ErrorResponse "삭제된 유저가 아님"
+// @Failure 409 {object} domain.ErrorResponse "삭제 후 같은 이메일로 다른 계정이 생김, 다른 고객으로 병합된 계정"
+// @Router /recycle-bin/user/{user_id}/restore [post]
+func (c *RecycleBinController) restoreUser(ctx echo.Context, userId uuid.UUID) error {
+	var req RestoreUserRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "restore user, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.userUseCase.RestoreUser(ctx.Request().Context(), domain.RestoreUser{
+		UserId:     req.UserId,
+		RestoredBy: userId,
+		Ip:         ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemAlreadyExist, domain.ErrUserMerged:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", req.UserId).
+			Error(tag, "restoreUser, unhandled error userUseCase.RestoreUser")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+func NewRecycleBinUseCase(
+	userRepo domain.UserRepository,
+	managerRepo domain.ManagerRepository,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.RecycleBinUseCase {
+	return &ucase{
+		userRepo:    userRepo,
+		managerRepo: managerRepo,
+		clock:       clock,
+		timeout:     timeout,
+	}
+}
+
+type ucase struct {
+	userRepo    domain.UserRepository
+	managerRepo domain.ManagerRepository
+	clock       domain.Clock
+	timeout     time.Duration
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchRecycleBin(ctx context.Context, option domain.FetchRecycleBinOption) (res domain.RecycleBin, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	days := option.Days
+	if days == 0 {
+		days = domain.RecycleBinDefaultDays
+	}
+	if days > domain.RecycleBinMaxDays {
+		days = domain.RecycleBinMaxDays
+	}
+
+	list, err := u.userRepo.FetchDeleted(c, u.clock.Now().AddDate(0, 0, -int(days)))
+	if err != nil || len(list) == 0 {
+		return
+	}
+
+	// 삭제한 사람 이름은 한번에 조회
+	var deleterIds []uuid.UUID
+	for _, user := range list {
+		if user.DeletedBy != nil {
+			deleterIds = append(deleterIds, *user.DeletedBy)
+		}
+	}
+
+	names := make(map[uuid.UUID]string)
+	if len(deleterIds) > 0 {
+		var managers []domain.Manager
+		managers, err = u.managerRepo.FetchByIds(c, deleterIds)
+		if err != nil {
+			return
+		}
+		for _, manager := range managers {
+			names[manager.Id] = manager.Nickname
+		}
+	}
+
+	for _, user := range list {
+		item := domain.RecycleBinItem{
+			Type:      domain.RecycleBinItemTypeOf(user),
+			Id:        user.Id,
+			Username:  user.Username,
+			DeletedAt: *user.DeletedAt,
+			DeletedBy: user.DeletedBy,
+		}
+		if user.DeletedBy != nil {
+			if name, ok := names[*user.DeletedBy]; ok {
+				item.DeletedByName = &name
+			}
+		}
+
+		switch item.Type {
+		case domain.RecycleBinItemTypeCustomer:
+			if user.Customer != nil {
+				item.Name = user.Customer.Name
+			}
+			res.Customers = append(res.Customers, item)
+		default:
+			if user.Manager != nil {
+				item.Name = user.Manager.Name
+			}
+			res.Admins = append(res.Admins, item)
+		}
+	}
+	return
+}
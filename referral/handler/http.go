@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[REFERRAL] "
+)
+
+func NewReferralController(useCase domain.ReferralUseCase) *ReferralController {
+	return &ReferralController{useCase: useCase}
+}
+
+type ReferralController struct {
+	useCase domain.ReferralUseCase
+}
+
+type ReferralStatResponse struct {
+	Total    int64 `json:"total" validate:"required" example:"5"`
+	Pending  int64 `json:"pending" validate:"required" example:"2"`
+	Rewarded int64 `json:"rewarded" validate:"required" example:"2"`
+	Rejected int64 `json:"rejected" validate:"required" example:"1"`
+	Credit   int64 `json:"credit" validate:"required" example:"10000"`
+} // @name ReferralStatResponse
+
+func useCaseToReferralStatResponse(src domain.ReferralStatData) ReferralStatResponse {
+	return ReferralStatResponse{
+		Total:    src.Total,
+		Pending:  src.Pending,
+		Rewarded: src.Rewarded,
+		Rejected: src.Rejected,
+		Credit:   src.Credit,
+	}
+}
+
+type MyReferralResponse struct {
+	// Code, 아직 발급하지 않았으면 null, POST /referral/me/code 로 발급
+	Code *string              `json:"code" example:"K7Q2M9XA"`
+	Stat ReferralStatResponse `json:"stat" validate:"required"`
+} // @name MyReferralResponse
+
+// @Tags (Referral) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 추천 코드 및 추천 현황
+// @Description 추천 코드와 추천 현황을 가져오는 기능, 코드를 발급하지 않았으면 code 는 null, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} MyReferralResponse "성공"
+// @Router /referral/me [get]
+func (c *ReferralController) getMyReferral(ctx echo.Context, userId uuid.UUID) error {
+	res, err := c.useCase.GetMyReferral(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("in", userId).
+			Error(tag, "getMyReferral, unhandled error useCase.GetMyReferral")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, MyReferralResponse{
+		Code: res.Code,
+		Stat: useCaseToReferralStatResponse(res.Stat),
+	})
+}
+
+type ReferralCodeResponse struct {
+	Code string `json:"code" validate:"required" example:"K7Q2M9XA"`
+} // @name ReferralCodeResponse
+
+// @Tags (Referral) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 추천 코드 발급
+// @Description 추천 코드가 없으면 발급하는 기능, 이미 있으면 그 코드를 돌려줌, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} ReferralCodeResponse "발급 완료"
+// @Router /referral/me/code [post]
+func (c *ReferralController) issueReferralCode(ctx echo.Context, userId uuid.UUID) error {
+	code, err := c.useCase.IssueReferralCode(ctx.Request().Context(), userId, ctx.RealIP())
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("in", userId).
+			Error(tag, "issueReferralCode, unhandled error useCase.IssueReferralCode")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, ReferralCodeResponse{Code: code})
+}
+
+type AttributeReferralRequest struct {
+	// Code, 추천인 코드
+	Code string `json:"code" validate:"required,max=20" example:"K7Q2M9XA"`
+} // @name AttributeReferralRequest
+
+// @Tags (Referral) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 추천인 코드 등록
+// @Description 가입한 고객이 첫 결제 전에 추천인 코드를 등록하는 기능, 첫 결제 시 추천인에게 크레딧 지급, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body AttributeReferralRequest true "추천인 코드 등록 데이터 구조"
+// @Success 204 "등록 완료"
+// @Failure 400 {object} domain.ErrorResponse "자기 자신 추천, 이미 결제한 고객"
+// @Failure 404 {object} domain.ErrorResponse "추천 코드 없음"
+// @Failure 409 {object} domain.ErrorResponse "이미 추천인 등록됨"
+// @Router /referral/me [post]
+func (c *ReferralController) attributeReferral(ctx echo.Context, userId uuid.UUID) error {
+	var req AttributeReferralRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "attribute referral, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.AttributeReferral{
+		UserId: userId,
+		Code:   req.Code,
+		Ip:     ctx.RealIP(),
+	}
+	err = c.useCase.AttributeReferral(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ItemExist)
+	case domain.ErrReferralNotAllowed:
+		return ctx.JSON(http.StatusBadRequest, domain.ReferralNotAllowedResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "attributeReferral, unhandled error useCase.AttributeReferral")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *ReferralController) Bind(e *echo.Echo) {
+	// CUSTOMER
+	e.GET("/referral/me", echox.UserID(c.getMyReferral),
+		middleware.RequireRole(domain.CustomerUserRole))
+	e.POST("/referral/me", echox.UserID(c.attributeReferral),
+		middleware.RequireRole(domain.CustomerUserRole))
+	e.POST("/referral/me/code", echox.UserID(c.issueReferralCode),
+		middleware.RequireRole(domain.CustomerUserRole))
+
+	// ADMIN
+	e.GET("/dashboard/referral", c.getReferralStats,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+type ReferrerStatResponse struct {
+	ReferrerId uuid.UUID            `json:"referrerId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Stat       ReferralStatResponse `json:"stat" validate:"required"`
+} // @name ReferrerStatResponse
+
+type ReferralStatsResponse struct {
+	Total     ReferralStatResponse   `json:"total" validate:"required"`
+	Referrers []ReferrerStatResponse `json:"referrers" validate:"required"`
+} // @name ReferralStatsResponse
+
+// @Tags (Referral) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 추천 프로그램 현황
+// @Description 전체 및 추천인별 추천 현황을 가져오는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} ReferralStatsResponse "성공"
+// @Router /dashboard/referral [get]
+func (c *ReferralController) getReferralStats(ctx echo.Context) error {
+	res, err := c.useCase.GetReferralStats(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "getReferralStats, unhandled error useCase.GetReferralStats")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	referrers := make([]ReferrerStatResponse, len(res.Referrers))
+	for i := range res.Referrers {
+		referrers[i] = ReferrerStatResponse{
+			ReferrerId: res.Referrers[i].ReferrerId,
+			Stat:       useCaseToReferralStatResponse(res.Referrers[i].Stat),
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, ReferralStatsResponse{
+		Total:     useCaseToReferralStatResponse(res.Total),
+		Referrers: referrers,
+	})
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewReferralRepository(db *gorm.DB) domain.ReferralRepository {
+	db.AutoMigrate(&domain.ReferralCode{}, &domain.Referral{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.ReferralTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) SaveCode(ctx context.Context, code *domain.ReferralCode) error {
+	return r.db.WithContext(ctx).Create(code).Error
+}
+
+func (r *repo) Save(ctx context.Context, referral *domain.Referral) error {
+	return gormx.Upsert(ctx, r.db, referral)
+}
+
+func (r *repo) GetCodeByCustomerId(ctx context.Context, customerId uuid.UUID) (res *domain.ReferralCode, err error) {
+	var entity domain.ReferralCode
+	err = r.db.WithContext(ctx).
+		Where("`customer_id` = ?", customerId).
+		First(&entity).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetCodeByCode(ctx context.Context, code string) (res *domain.ReferralCode, err error) {
+	var entity domain.ReferralCode
+	err = r.db.WithContext(ctx).
+		Where("`code` = ?", code).
+		First(&entity).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetByRefereeId(ctx context.Context, refereeId uuid.UUID) (res *domain.Referral, err error) {
+	var entity domain.Referral
+	err = r.db.WithContext(ctx).
+		Where("`referee_id` = ?", refereeId).
+		First(&entity).Error
+	if err == nil {
+		res = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) CountByReferrerId(ctx context.Context, referrerId *uuid.UUID) (list []domain.ReferralCount, err error) {
+	db := r.db.WithContext(ctx).
+		Model(&domain.Referral{}).
+		Select("`referrer_id`, `status`, COUNT(*) AS `count`, COALESCE(SUM(`reward_credit`), 0) AS `credit`").
+		Group("`referrer_id`, `status`")
+
+	if referrerId != nil {
+		db = db.Where("`referrer_id` = ?", *referrerId)
+	}
+
+	err = db.Scan(&list).Error
+	return
+}
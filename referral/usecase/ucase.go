@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+const maxCodeRetry = 5
+
+func NewReferralUseCase(
+	referralRepo domain.ReferralRepository,
+	orderTicketRepo domain.OrderTicketRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.ReferralUseCase {
+	return &ucase{
+		referralRepo:    referralRepo,
+		orderTicketRepo: orderTicketRepo,
+		ids:             ids,
+		clock:           clock,
+		timeout:         timeout,
+	}
+}
+
+type ucase struct {
+	referralRepo    domain.ReferralRepository
+	orderTicketRepo domain.OrderTicketRepository
+	ids             domain.IdGenerator
+	clock           domain.Clock
+	timeout         time.Duration
+}
+
+// getOrCreateCode 추천 코드가 없으면 중복되지 않는 코드로 새로 발급
+func (u *ucase) getOrCreateCode(ctx context.Context, userId uuid.UUID, ip string) (code *domain.ReferralCode, err error) {
+	code, err = u.referralRepo.GetCodeByCustomerId(ctx, userId)
+	if err != nil || code != nil {
+		return
+	}
+
+	for i := 0; i < maxCodeRetry; i++ {
+		newCode := domain.CreateReferralCode(userId, ip, u.clock.Now())
+		var exists *domain.ReferralCode
+		exists, err = u.referralRepo.GetCodeByCode(ctx, newCode.Code)
+		if err != nil {
+			return
+		}
+
+		if exists != nil {
+			continue
+		}
+
+		err = u.referralRepo.SaveCode(ctx, &newCode)
+		if err != nil {
+			return
+		}
+
+		code = &newCode
+		return
+	}
+
+	err = domain.ErrItemAlreadyExist
+	return
+}
+
+func (u *ucase) IssueReferralCode(ctx context.Context, userId uuid.UUID, ip string) (code string, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	referralCode, err := u.getOrCreateCode(c, userId, ip)
+	if err != nil {
+		return
+	}
+
+	code = referralCode.Code
+	return
+}
+
+func (u *ucase) AttributeReferral(ctx context.Context, in domain.AttributeReferral) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		code   *domain.ReferralCode
+		ticket *domain.OrderTicket
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		code, err = u.referralRepo.GetCodeByCode(gc, in.Code)
+		if err != nil {
+			return
+		}
+
+		if code == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		exists, err := u.referralRepo.GetByRefereeId(gc, in.UserId)
+		if err != nil {
+			return
+		}
+
+		if exists != nil {
+			err = domain.ErrItemAlreadyExist
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		ticket, err = u.orderTicketRepo.GetEndByOwnerId(gc, in.UserId)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	// 자기 자신 추천, 이미 결제한 고객은 추천 대상이 아님
+	if code.CustomerId == in.UserId || ticket != nil {
+		err = domain.ErrReferralNotAllowed
+		return
+	}
+
+	referral := domain.CreateReferral(domain.CreateReferralOption{
+		Id:        u.ids.NewId(),
+		Code:      *code,
+		RefereeId: in.UserId,
+		SignupIp:  in.Ip,
+		Now:       u.clock.Now(),
+	})
+	return u.referralRepo.Save(c, &referral)
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func addReferralCount(stat *domain.ReferralStatData, count domain.ReferralCount) {
+	stat.Total += count.Count
+	stat.Credit += count.Credit
+	switch count.Status {
+	case domain.ReferralStatusPending:
+		stat.Pending += count.Count
+	case domain.ReferralStatusRewarded:
+		stat.Rewarded += count.Count
+	case domain.ReferralStatusRejected:
+		stat.Rejected += count.Count
+	}
+}
+
+func (u *ucase) GetMyReferral(ctx context.Context, userId uuid.UUID) (res domain.MyReferralInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	code, err := u.referralRepo.GetCodeByCustomerId(c, userId)
+	if err != nil {
+		return
+	}
+
+	list, err := u.referralRepo.CountByReferrerId(c, &userId)
+	if err != nil {
+		return
+	}
+
+	if code != nil {
+		res.Code = &code.Code
+	}
+	for i := range list {
+		addReferralCount(&res.Stat, list[i])
+	}
+	return
+}
+
+func (u *ucase) GetReferralStats(ctx context.Context) (res domain.ReferralStatsInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.referralRepo.CountByReferrerId(c, nil)
+	if err != nil {
+		return
+	}
+
+	index := make(map[uuid.UUID]int)
+	for i := range list {
+		count := list[i]
+		addReferralCount(&res.Total, count)
+
+		idx, ok := index[count.ReferrerId]
+		if !ok {
+			idx = len(res.Referrers)
+			index[count.ReferrerId] = idx
+			res.Referrers = append(res.Referrers, domain.ReferrerStatData{ReferrerId: count.ReferrerId})
+		}
+		addReferralCount(&res.Referrers[idx].Stat, count)
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package adapter
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+func NewReportEncoder() domain.ReportEncoder {
+	return reportEncoder{}
+}
+
+type reportEncoder struct{}
+
+func (reportEncoder) NewWriter(format domain.ReportFormat, w io.Writer) (domain.ReportRowWriter, error) {
+	switch format {
+	case domain.ReportFormatCSV:
+		return newCSVWriter(w)
+	case domain.ReportFormatXLSX:
+		return newXLSXWriter(w)
+	}
+	return nil, domain.ErrWeirdData
+}
+
+// utf8BOM 엑셀에서 한글이 깨지지 않도록 CSV 앞에 붙임
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	_, err := w.Write(utf8BOM)
+	if err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = escapeFormula(cell)
+	}
+	return c.w.Write(escaped)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeFormula 스프레드시트가 수식으로 실행하지 않도록 =, +, -, @ 로 시작하는 값 앞에 ' 추가
+func escapeFormula(cell string) string {
+	if cell == "" {
+		return cell
+	}
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + cell
+	}
+	return cell
+}
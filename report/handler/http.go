@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[REPORT] "
+)
+
+func NewReportController(useCase domain.ReportUseCase) *ReportController {
+	return &ReportController{useCase: useCase}
+}
+
+type ReportController struct {
+	useCase domain.ReportUseCase
+}
+
+func (c *ReportController) Bind(e *echo.Echo) {
+	// ===== ADMIN =====
+	e.POST("/report", echox.UserID(c.requestReport),
+		middleware.RequireRole(domain.AdminUserRole, domain.SuperAdminUserRole))
+	e.GET("/report/:reportId", echox.UserID(c.getReport),
+		middleware.RequireRole(domain.AdminUserRole, domain.SuperAdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/report/generate", c.internalGenerateReports)
+}
+
+type RequestReportRequest struct {
+	// Type 리포트 종류, ORDERS: 의뢰(의뢰 일시 기준), CUSTOMERS: 고객(가입 일시 기준), CREDIT_ENTRIES: 크레딧 원장(기록 일시 기준)
+	Type domain.ReportType `json:"type" validate:"required,oneof=ORDERS CUSTOMERS CREDIT_ENTRIES" example:"ORDERS"`
+	// Format 파일 형식
+	Format domain.ReportFormat `json:"format" validate:"required,oneof=CSV XLSX" example:"XLSX"`
+	// From 시작 날짜(KST), 포함
+	From string `json:"from" validate:"required" example:"2024-05-01"`
+	// To 끝 날짜(KST), 포함, 최대 1년
+	To string `json:"to" validate:"required" example:"2024-05-31"`
+} // @name RequestReportRequest
+
+type ReportResponse struct {
+	Id     uuid.UUID           `json:"reportId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type   domain.ReportType   `json:"type" validate:"required" example:"ORDERS"`
+	Format domain.ReportFormat `json:"format" validate:"required" example:"XLSX"`
+	// Status PENDING: 대기, RUNNING: 만드는 중, DONE: 완료, FAILED: 실패
+	Status domain.ReportStatus `json:"status" validate:"required" example:"DONE"`
+	// From, To [from, to) 기간
+	From time.Time `json:"from" validate:"required" example:"2024-05-01T00:00:00+09:00"`
+	To   time.Time `json:"to" validate:"required" example:"2024-06-01T00:00:00+09:00"`
+	// Rows 제목 줄 제외 행 수
+	Rows       int64      `json:"rows" validate:"required" example:"152"`
+	LastError  *string    `json:"lastError"`
+	CreatedAt  time.Time  `json:"createdAt" validate:"required" example:"2024-06-01T10:00:00+09:00"`
+	FinishedAt *time.Time `json:"finishedAt" example:"2024-06-01T10:01:00+09:00"`
+	// DownloadURL 완료면 인증 없이 내려받을 수 있는 주소, expiresAt 까지 유효
+	DownloadURL *string    `json:"downloadUrl" example:"https://storage.editfolio.com/report/550e8400-e29b-41d4-a716-446655440000.xlsx?X-Amz-Signature=..."`
+	ExpiresAt   *time.Time `json:"expiresAt" example:"2024-06-02T10:05:00+09:00"`
+} // @name ReportResponse
+
+func reportResponseOf(src domain.ReportJobInfo) ReportResponse {
+	return ReportResponse{
+		Id:          src.Id,
+		Type:        src.Type,
+		Format:      src.Format,
+		Status:      src.Status,
+		From:        src.From,
+		To:          src.To,
+		Rows:        src.Rows,
+		LastError:   src.LastError,
+		CreatedAt:   src.CreatedAt,
+		FinishedAt:  src.FinishedAt,
+		DownloadURL: src.DownloadURL,
+		ExpiresAt:   src.ExpiresAt,
+	}
+}
+
+// @Tags (Report) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 리포트 요청
+// @Description 리포트를 바로 만들지 않고 대기열에 넣음, GET /report/{report_id} 로 상태를 확인하거나 완료 알림(내려받기 링크)을 기다림, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body RequestReportRequest true "리포트 종류, 형식, 기간"
+// @Success 202 {object} ReportResponse "대기열에 추가"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 날짜, 기간"
+// @Router /report [post]
+func (c *ReportController) requestReport(ctx echo.Context, userId uuid.UUID) error {
+	var req RequestReportRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "requestReport, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.RequestReport(ctx.Request().Context(), domain.RequestReport{
+		Type:        req.Type,
+		Format:      req.Format,
+		From:        req.From,
+		To:          req.To,
+		RequestedBy: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusAccepted, reportResponseOf(res))
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "requestReport, unhandled error useCase.RequestReport")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Report) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 리포트 상태
+// @Description 요청한 사람만 조회 가능, 완료면 내려받기 주소 포함, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param report_id path string true "리포트 아이디(UUID)"
+// @Success 200 {object} ReportResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 리포트"
+// @Router /report/{report_id} [get]
+func (c *ReportController) getReport(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		ReportId uuid.UUID `param:"reportId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "getReport, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.GetReport(ctx.Request().Context(), req.ReportId, userId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, reportResponseOf(res))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("reportId", req.ReportId).
+			Error(tag, "getReport, unhandled error useCase.GetReport")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *ReportController) internalGenerateReports(ctx echo.Context) error {
+	res, err := c.useCase.GenerateReports(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalGenerateReports, unhandled error useCase.GenerateReports")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	echox.Log(ctx).WithField("generated", res.Generated).
+		WithField("failed", res.Failed).
+		Info(tag, "generate reports")
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"generated": res.Generated,
+		"failed":    res.Failed,
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewReportJobRepository(db *gorm.DB) domain.ReportJobRepository {
+	db.AutoMigrate(&domain.ReportJob{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.ReportJobTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(reportJobRepo domain.ReportJobTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) Save(ctx context.Context, job *domain.ReportJob) error {
+	return gormx.Upsert(ctx, r.db, job)
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (job *domain.ReportJob, err error) {
+	var entity domain.ReportJob
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		job = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchPending(ctx context.Context, now time.Time, limit int) (list []domain.ReportJob, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`status` = ? OR (`status` = ? AND `started_at` < ?)",
+			domain.ReportStatusPending, domain.ReportStatusRunning, now.Add(-domain.ReportRunTimeout)).
+		Order("`created_at`").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) Claim(ctx context.Context, job *domain.ReportJob, now time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.ReportJob{}).
+		Where("`id` = ? AND `status` = ? AND `updated_at` = ?", job.Id, job.Status, job.UpdatedAt).
+		UpdateColumns(map[string]interface{}{
+			"status":     domain.ReportStatusRunning,
+			"started_at": now,
+			"updated_at": now,
+		})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+
+	job.Status = domain.ReportStatusRunning
+	job.StartedAt = &now
+	job.UpdatedAt = now
+	return true, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+// reportQuery 종류별 제목 줄과 쿼리, 쿼리는 [From, To) 두 인자를 받음
+type reportQuery struct {
+	header []string
+	sql    string
+}
+
+var reportQueries = map[domain.ReportType]reportQuery{
+	domain.ReportTypeOrders: {
+		header: []string{"의뢰 아이디", "의뢰 번호", "고객 아이디", "고객 이름", "상태", "의뢰 일시", "마감일", "완료 일시", "취소 일시"},
+		sql: "SELECT o.`id`, o.`number`, o.`orderer`, c.`name`, s.`content`, " +
+			"o.`ordered_at`, DATE_FORMAT(o.`due_date`, '%Y-%m-%d'), o.`done_at`, o.`canceled_at` " +
+			"FROM `order` o " +
+			"LEFT JOIN `customer` c ON c.`id` = o.`orderer` " +
+			"LEFT JOIN `order_state` s ON s.`id` = o.`state` " +
+			"WHERE o.`is_draft` = FALSE AND o.`ordered_at` >= ? AND o.`ordered_at` < ? " +
+			"ORDER BY o.`ordered_at`",
+	},
+	domain.ReportTypeCustomers: {
+		header: []string{"고객 아이디", "이름", "채널 이름", "이메일", "연락처", "사업자등록번호", "상호", "대표자", "가입 일시"},
+		sql: "SELECT c.`id`, c.`name`, c.`channel_name`, c.`email`, c.`mobile`, " +
+			"c.`business_number`, c.`business_name`, c.`business_representative`, u.`created_at` " +
+			"FROM `customer` c " +
+			"JOIN `user` u ON u.`id` = c.`id` " +
+			"WHERE u.`deleted_at` IS NULL AND u.`created_at` >= ? AND u.`created_at` < ? " +
+			"ORDER BY u.`created_at`",
+	},
+	domain.ReportTypeCreditEntries: {
+		header: []string{"기록 아이디", "거래 아이디", "고객 아이디", "계정", "금액", "종류", "참조", "메모", "기록 일시"},
+		sql: "SELECT `id`, `transaction_id`, `customer_id`, `account`, `amount`, `kind`, `reference`, `memo`, `created_at` " +
+			"FROM `credit_entry` " +
+			"WHERE `created_at` >= ? AND `created_at` < ? " +
+			"ORDER BY `created_at`, `id`",
+	},
+}
+
+// NewReportSource 시각은 calendar 시간대로 씀
+func NewReportSource(db *gorm.DB, calendar domain.Calendar) domain.ReportSource {
+	return &source{db: db, loc: calendar.Location()}
+}
+
+type source struct {
+	db  *gorm.DB
+	loc *time.Location
+}
+
+// Export 한 번에 다 읽지 않고 행 단위로 흘려 씀
+func (s *source) Export(ctx context.Context, job domain.ReportJob, w domain.ReportRowWriter) (count int64, err error) {
+	query, ok := reportQueries[job.Type]
+	if !ok {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	err = w.WriteRow(query.header)
+	if err != nil {
+		return
+	}
+
+	rows, err := s.db.WithContext(ctx).Raw(query.sql, job.From, job.To).Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	var (
+		values = make([]interface{}, len(query.header))
+		dest   = make([]interface{}, len(query.header))
+		cells  = make([]string, len(query.header))
+	)
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		err = rows.Scan(dest...)
+		if err != nil {
+			return
+		}
+		for i, v := range values {
+			cells[i] = s.format(v)
+		}
+
+		err = w.WriteRow(cells)
+		if err != nil {
+			return
+		}
+		count++
+	}
+	err = rows.Err()
+	return
+}
+
+func (s *source) format(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case time.Time:
+		return value.In(s.loc).Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/diagnostics"
+	"github.com/stockfolioofficial/back-editfolio/core/workerpool"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[REPORT] "
+
+// NewReportUseCase 리포트는 요청 때 대기열에만 넣고 스케줄러 실행에서 만듦
+func NewReportUseCase(
+	reportJobRepo domain.ReportJobRepository,
+	outboxRepo domain.OutboxRepository,
+	source domain.ReportSource,
+	encoder domain.ReportEncoder,
+	storage domain.BlobStorage,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	calendar domain.Calendar,
+	timeout time.Duration,
+) domain.ReportUseCase {
+	return &ucase{
+		reportJobRepo: reportJobRepo,
+		outboxRepo:    outboxRepo,
+		source:        source,
+		encoder:       encoder,
+		storage:       storage,
+		ids:           ids,
+		clock:         clock,
+		calendar:      calendar,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	reportJobRepo domain.ReportJobRepository
+	outboxRepo    domain.OutboxRepository
+	source        domain.ReportSource
+	encoder       domain.ReportEncoder
+	storage       domain.BlobStorage
+	ids           domain.IdGenerator
+	clock         domain.Clock
+	calendar      domain.Calendar
+	timeout       time.Duration
+}
+
+func (u *ucase) RequestReport(ctx context.Context, in domain.RequestReport) (res domain.ReportJobInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if !in.Type.IsValid() || !in.Format.IsValid() {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	from, err := time.ParseInLocation(domain.ReportDateLayout, in.From, u.calendar.Location())
+	if err != nil {
+		err = domain.ErrWeirdData
+		return
+	}
+	to, err := time.ParseInLocation(domain.ReportDateLayout, in.To, u.calendar.Location())
+	if err != nil {
+		err = domain.ErrWeirdData
+		return
+	}
+	// 마지막 날짜 포함
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) || to.Sub(from) > domain.ReportMaxPeriod {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	job := domain.CreateReportJob(u.ids.NewId(), domain.CreateReportJobOption{
+		Type:        in.Type,
+		Format:      in.Format,
+		From:        from,
+		To:          to,
+		RequestedBy: in.RequestedBy,
+		Now:         u.clock.Now(),
+	})
+	err = u.reportJobRepo.Save(c, &job)
+	if err != nil {
+		return
+	}
+
+	res = infoOf(job)
+	return
+}
+
+func (u *ucase) GenerateReports(ctx context.Context) (res domain.ReportRun, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.reportJobRepo.FetchPending(c, u.clock.Now(), domain.ReportBatch)
+	if err != nil {
+		return
+	}
+
+	var (
+		ran     = make([]bool, len(list))
+		results = make([]error, len(list))
+		rows    = make([]int64, len(list))
+	)
+	pool, _ := workerpool.New(c, workerpool.Option{Size: domain.ReportConcurrency})
+	for i := range list {
+		i := i
+		pool.Go(func(ctx context.Context) error {
+			claimed, err := u.reportJobRepo.Claim(ctx, &list[i], u.clock.Now())
+			if err != nil || !claimed {
+				// 다른 서버가 가져감
+				return nil
+			}
+			ran[i] = true
+			rows[i], results[i] = u.generate(ctx, list[i])
+			return nil
+		})
+	}
+	_ = pool.Wait()
+
+	// 하나가 실패해도 나머지는 계속, 실패 횟수를 기록하고 다음 실행에 다시 시도
+	for i := range list {
+		job := &list[i]
+		if !ran[i] {
+			continue
+		}
+		if results[i] == nil {
+			job.Succeed(rows[i], u.clock.Now())
+			res.Generated++
+		} else {
+			logx.From(ctx).WithError(results[i]).WithField("reportId", job.Id).Warn(tag, "generate report failed")
+			job.Fail(results[i], u.clock.Now())
+			res.Failed++
+		}
+
+		err = u.finish(c, job)
+		if err != nil {
+			return
+		}
+	}
+
+	diagnostics.AddCounter("report.generated", uint64(res.Generated))
+	diagnostics.AddCounter("report.failed", uint64(res.Failed))
+	return
+}
+
+// generate 임시 파일에 쓴 뒤 크기를 알고 올림
+func (u *ucase) generate(ctx context.Context, job domain.ReportJob) (rows int64, err error) {
+	tmp, err := os.CreateTemp("", "report-*."+job.Format.Ext())
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w, err := u.encoder.NewWriter(job.Format, tmp)
+	if err != nil {
+		return
+	}
+	rows, err = u.source.Export(ctx, job, w)
+	if err != nil {
+		return
+	}
+	err = w.Close()
+	if err != nil {
+		return
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return
+	}
+	_, err = tmp.Seek(0, io.SeekStart)
+	if err != nil {
+		return
+	}
+
+	err = u.storage.Put(ctx, job.Key(), tmp, size, job.Format.ContentType())
+	return
+}
+
+// finish 완료, 최종 실패면 요청자 알림 이벤트를 같은 트랜잭션에서 저장
+func (u *ucase) finish(ctx context.Context, job *domain.ReportJob) error {
+	if !job.IsFinished() {
+		return u.reportJobRepo.Save(ctx, job)
+	}
+
+	data := domain.ReportFinishedEvent{
+		ReportId:    job.Id,
+		RequestedBy: job.RequestedBy,
+		Type:        job.Type,
+		Format:      job.Format,
+		Rows:        job.Rows,
+	}
+	eventType := domain.OutboxEventTypeReportFailed
+	if job.IsDone() {
+		eventType = domain.OutboxEventTypeReportCompleted
+		url, expiresAt, err := u.downloadURL(ctx, *job)
+		if err != nil {
+			return err
+		}
+		data.DownloadURL = &url
+		data.ExpiresAt = &expiresAt
+	}
+
+	event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeReport,
+		AggregateId:   job.Id,
+		EventType:     eventType,
+		Data:          data,
+		Now:           u.clock.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.reportJobRepo.Transaction(ctx, func(reportJobRepo domain.ReportJobTxRepository) error {
+		err := reportJobRepo.Save(ctx, job)
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.With(reportJobRepo).Save(ctx, &event)
+	})
+}
+
+func (u *ucase) downloadURL(ctx context.Context, job domain.ReportJob) (url string, expiresAt time.Time, err error) {
+	expiresAt = u.clock.Now().Add(domain.ReportURLTTL)
+	url, err = u.storage.PresignGet(ctx, *job.ResultKey, domain.ReportURLTTL)
+	return
+}
+
+func infoOf(job domain.ReportJob) domain.ReportJobInfo {
+	return domain.ReportJobInfo{
+		Id:         job.Id,
+		Type:       job.Type,
+		Format:     job.Format,
+		Status:     job.Status,
+		From:       job.From,
+		To:         job.To,
+		Rows:       job.Rows,
+		LastError:  job.LastError,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) GetReport(ctx context.Context, id, requesterId uuid.UUID) (res domain.ReportJobInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	job, err := u.reportJobRepo.GetById(c, id)
+	if err != nil {
+		return
+	}
+
+	if job == nil || job.RequestedBy != requesterId {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = infoOf(*job)
+	if job.IsDone() {
+		url, expiresAt, err := u.downloadURL(c, *job)
+		if err != nil {
+			return res, err
+		}
+		res.DownloadURL = &url
+		res.ExpiresAt = &expiresAt
+	}
+	return
+}
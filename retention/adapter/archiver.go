@@ -0,0 +1,103 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewBlobArchiver 보관 대상 행을 파일 저장소의 prefix 아래 CSV 로 저장
+func NewBlobArchiver(storage domain.BlobStorage, prefix string) domain.RetentionArchiver {
+	return &blobArchiver{storage: storage, prefix: prefix}
+}
+
+type blobArchiver struct {
+	storage domain.BlobStorage
+	prefix  string
+}
+
+// Archive 올린 뒤 크기까지 확인해야 성공, 에러면 호출하는 쪽은 행을 지우면 안됨
+func (a *blobArchiver) Archive(ctx context.Context, name string, rows []map[string]interface{}) (location string, err error) {
+	if len(rows) == 0 {
+		return
+	}
+
+	// 한 번에 RetentionBatchSize 행이라 메모리에 모아서 올림
+	var buf bytes.Buffer
+	err = writeCsv(&buf, rows)
+	if err != nil {
+		return
+	}
+
+	key := path.Join(a.prefix, name+".csv")
+	size := int64(buf.Len())
+	err = a.storage.Put(ctx, key, &buf, size, "text/csv")
+	if err != nil {
+		return
+	}
+
+	info, err := a.storage.Stat(ctx, key)
+	if err != nil {
+		return
+	}
+	if info.Size != size {
+		err = fmt.Errorf("archive %s: size mismatch %d != %d", key, info.Size, size)
+		return
+	}
+
+	location = key
+	return
+}
+
+func writeCsv(buf *bytes.Buffer, rows []map[string]interface{}) error {
+	columns := make([]string, 0, len(rows[0]))
+	for k := range rows[0] {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+
+	w := csv.NewWriter(buf)
+	err := w.Write(columns)
+	if err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = format(row[column])
+		}
+
+		err = w.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
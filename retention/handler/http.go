@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[RETENTION] "
+)
+
+func NewRetentionController(useCase domain.RetentionUseCase) *RetentionController {
+	return &RetentionController{useCase: useCase}
+}
+
+type RetentionController struct {
+	useCase domain.RetentionUseCase
+}
+
+type RetentionRunResponse struct {
+	Table      string     `json:"table" validate:"required" example:"analytics_event"`
+	Cutoff     time.Time  `json:"cutoff" validate:"required" example:"2021-07-29T04:44:18+00:00"`
+	Archived   int64      `json:"archived" validate:"required" example:"1200"`
+	Purged     int64      `json:"purged" validate:"required" example:"1200"`
+	Error      *string    `json:"error" example:"archive failed"`
+	StartedAt  time.Time  `json:"startedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+	FinishedAt *time.Time `json:"finishedAt" example:"2021-10-27T04:44:20+00:00"`
+} // @name RetentionRunResponse
+
+func useCaseToRunResponse(list []domain.RetentionRunInfo) []RetentionRunResponse {
+	res := make([]RetentionRunResponse, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = RetentionRunResponse{
+			Table:      src.Table,
+			Cutoff:     src.Cutoff,
+			Archived:   src.Archived,
+			Purged:     src.Purged,
+			Error:      src.Error,
+			StartedAt:  src.StartedAt,
+			FinishedAt: src.FinishedAt,
+		}
+	}
+	return res
+}
+
+// @Tags (Retention) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 데이터 보관 기간 정리 이력
+// @Description 테이블별 보관/삭제 건수 이력을 최근 순으로 가져오는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} RetentionRunResponse "성공"
+// @Success 204 "이력 없음"
+// @Router /dashboard/retention [get]
+func (c *RetentionController) fetchRecentRuns(ctx echo.Context) error {
+	list, err := c.useCase.FetchRecentRuns(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchRecentRuns, unhandled error useCase.FetchRecentRuns")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	return ctx.JSON(http.StatusOK, useCaseToRunResponse(list))
+}
+
+func (c *RetentionController) internalRunRetention(ctx echo.Context) error {
+	list, err := c.useCase.RunRetention(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "internalRunRetention, unhandled error useCase.RunRetention")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	for _, run := range list {
+		entry := echox.Log(ctx).WithField("table", run.Table).
+			WithField("archived", run.Archived).
+			WithField("purged", run.Purged)
+		if run.Error != nil {
+			entry.WithField("error", *run.Error).Error(tag, "retention run failed")
+		} else {
+			entry.Info(tag, "retention run")
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, useCaseToRunResponse(list))
+}
+
+func (c *RetentionController) Bind(e *echo.Echo) {
+	// ADMIN
+	e.GET("/dashboard/retention", c.fetchRecentRuns,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// INTERNAL
+	e.POST("/internal/retention/run", c.internalRunRetention)
+}
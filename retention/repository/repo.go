@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewRetentionRepository(db *gorm.DB) domain.RetentionRepository {
+	db.AutoMigrate(&domain.RetentionRun{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) SaveRun(ctx context.Context, run *domain.RetentionRun) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+func (r *repo) FetchExpiredRows(ctx context.Context, target domain.RetentionTarget, cutoff time.Time, limit int) (list []map[string]interface{}, err error) {
+	db := r.db.WithContext(ctx).
+		Table(target.Table).
+		Order(fmt.Sprintf("`%s` asc", target.TimeColumn)).
+		Where(fmt.Sprintf("`%s` < ?", target.TimeColumn), cutoff).
+		Limit(limit)
+
+	if target.Condition != "" {
+		db = db.Where(target.Condition)
+	}
+
+	err = db.Find(&list).Error
+	return
+}
+
+func (r *repo) DeleteRows(ctx context.Context, table string, ids []interface{}) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Exec(fmt.Sprintf("DELETE FROM `%s` WHERE `id` IN ?", table), ids)
+	return res.RowsAffected, res.Error
+}
+
+func (r *repo) FetchRecentRuns(ctx context.Context, limit int) (list []domain.RetentionRun, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`started_at` desc").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
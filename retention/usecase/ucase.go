@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const maxBatchPerRun = 100
+
+func NewRetentionUseCase(
+	retentionRepo domain.RetentionRepository,
+	archiver domain.RetentionArchiver,
+	policies domain.RetentionPolicies,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.RetentionUseCase {
+	return &ucase{
+		retentionRepo: retentionRepo,
+		archiver:      archiver,
+		policies:      policies,
+		ids:           ids,
+		clock:         clock,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	retentionRepo domain.RetentionRepository
+	archiver      domain.RetentionArchiver
+	policies      domain.RetentionPolicies
+	ids           domain.IdGenerator
+	clock         domain.Clock
+	timeout       time.Duration
+}
+
+func toRunInfo(src domain.RetentionRun) domain.RetentionRunInfo {
+	return domain.RetentionRunInfo{
+		Table:      src.Table,
+		Cutoff:     src.Cutoff,
+		Archived:   src.Archived,
+		Purged:     src.Purged,
+		Error:      src.Error,
+		StartedAt:  src.StartedAt,
+		FinishedAt: src.FinishedAt,
+	}
+}
+
+// purge 보관 기간이 지난 행을 보관한 뒤 삭제, 보관에 실패한 배치는 삭제하지 않음
+func (u *ucase) purge(ctx context.Context, target domain.RetentionTarget, run *domain.RetentionRun) (err error) {
+	var archives []string
+	defer func() {
+		if len(archives) > 0 {
+			joined := strings.Join(archives, ",")
+			if len(joined) > 2000 {
+				joined = joined[:2000]
+			}
+			run.Archives = &joined
+		}
+	}()
+
+	for batch := 0; batch < maxBatchPerRun; batch++ {
+		var rows []map[string]interface{}
+		rows, err = u.retentionRepo.FetchExpiredRows(ctx, target, run.Cutoff, domain.RetentionBatchSize)
+		if err != nil || len(rows) == 0 {
+			return
+		}
+
+		var location string
+		location, err = u.archiver.Archive(ctx, fmt.Sprintf("%s/%s-%03d", target.Table, run.Id, batch), rows)
+		if err != nil {
+			return
+		}
+		archives = append(archives, location)
+		run.Archived += int64(len(rows))
+
+		ids := make([]interface{}, len(rows))
+		for i := range rows {
+			ids[i] = rows[i]["id"]
+		}
+
+		var purged int64
+		purged, err = u.retentionRepo.DeleteRows(ctx, target.Table, ids)
+		if err != nil {
+			return
+		}
+		run.Purged += purged
+	}
+	return
+}
+
+func (u *ucase) RunRetention(ctx context.Context) (res []domain.RetentionRunInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	for _, target := range domain.RetentionTargets {
+		days := u.policies[target.Table]
+		if days == 0 {
+			continue
+		}
+
+		now := u.clock.Now()
+		run := domain.RetentionRun{
+			Id:        u.ids.NewId(),
+			Table:     target.Table,
+			Cutoff:    now.AddDate(0, 0, -int(days)),
+			StartedAt: now,
+		}
+
+		purgeErr := u.purge(c, target, &run)
+		if purgeErr != nil {
+			msg := purgeErr.Error()
+			if len(msg) > 1000 {
+				msg = msg[:1000]
+			}
+			run.Error = &msg
+		}
+
+		finishedAt := u.clock.Now()
+		run.FinishedAt = &finishedAt
+		err = u.retentionRepo.SaveRun(c, &run)
+		if err != nil {
+			return
+		}
+
+		res = append(res, toRunInfo(run))
+	}
+	return
+}
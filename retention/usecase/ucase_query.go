@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const recentRunLimit = 50
+
+func (u *ucase) FetchRecentRuns(ctx context.Context) (res []domain.RetentionRunInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.retentionRepo.FetchRecentRuns(c, recentRunLimit)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.RetentionRunInfo, len(list))
+	for i := range list {
+		res[i] = toRunInfo(list[i])
+	}
+	return
+}
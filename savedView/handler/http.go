@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[SAVED-VIEW] "
+)
+
+func NewSavedViewController(useCase domain.SavedViewUseCase) *SavedViewController {
+	return &SavedViewController{useCase: useCase}
+}
+
+type SavedViewController struct {
+	useCase domain.SavedViewUseCase
+}
+
+func (c *SavedViewController) Bind(e *echo.Echo) {
+	// ADMIN
+	e.GET("/saved-view", echox.UserID(c.fetchSavedViews),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/saved-view", echox.UserID(c.createSavedView),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/saved-view/:viewId", echox.UserID(c.updateSavedView),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.DELETE("/saved-view/:viewId", echox.UserID(c.deleteSavedView),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+}
+
+type SavedViewDefinition struct {
+	Query        string            `json:"q" example:"홍길동"`
+	CustomFields map[string]string `json:"customFields" swaggertype:"object,string"`
+	Sort         string            `json:"sort" example:"createdAt" enums:"createdAt,name"`
+	Desc         bool              `json:"desc" example:"true"`
+} // @name SavedViewDefinition
+
+func (d SavedViewDefinition) toDomain() domain.SavedViewDefinition {
+	return domain.SavedViewDefinition{
+		Query:        d.Query,
+		CustomFields: d.CustomFields,
+		Sort:         d.Sort,
+		Desc:         d.Desc,
+	}
+}
+
+type SavedViewResponse struct {
+	Id         uuid.UUID           `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Target     string              `json:"target" validate:"required" example:"CUSTOMER" enums:"CUSTOMER"`
+	Name       string              `json:"name" validate:"required" example:"연간 계약 고객"`
+	Definition SavedViewDefinition `json:"definition" validate:"required"`
+	UpdatedAt  time.Time           `json:"updatedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name SavedViewResponse
+
+// @Tags (SavedView) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 내 저장된 보기 목록
+// @Description 내가 저장한 목록 보기, 목록 조회 시 view 파라미터로 적용, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param target query string false "적용 목록" Enums(CUSTOMER)
+// @Success 200 {array} SavedViewResponse "성공"
+// @Success 204 "저장된 보기 없음"
+// @Router /saved-view [get]
+func (c *SavedViewController) fetchSavedViews(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.FetchSavedViews(ctx.Request().Context(), userId,
+		domain.SavedViewTarget(ctx.QueryParam("target")))
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchSavedViews, unhandled error useCase.FetchSavedViews")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]SavedViewResponse, len(list))
+	for i, src := range list {
+		res[i] = SavedViewResponse{
+			Id:     src.Id,
+			Target: string(src.Target),
+			Name:   src.Name,
+			Definition: SavedViewDefinition{
+				Query:        src.Definition.Query,
+				CustomFields: src.Definition.CustomFields,
+				Sort:         src.Definition.Sort,
+				Desc:         src.Definition.Desc,
+			},
+			UpdatedAt: src.UpdatedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type CreateSavedViewRequest struct {
+	Target     string              `json:"target" validate:"required,eq=CUSTOMER" example:"CUSTOMER"`
+	Name       string              `json:"name" validate:"required,min=1,max=60" example:"연간 계약 고객"`
+	Definition SavedViewDefinition `json:"definition" validate:"required"`
+} // @name CreateSavedViewRequest
+
+type CreateSavedViewResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name CreateSavedViewResponse
+
+// @Tags (SavedView) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 보기 저장
+// @Description 목록 필터/정렬을 이름을 붙여 저장, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateSavedViewRequest true "저장할 보기"
+// @Success 201 {object} CreateSavedViewResponse "저장 성공"
+// @Failure 400 {object} domain.ErrorResponse "대상 목록에서 쓸 수 없는 정렬, 추가 항목"
+// @Router /saved-view [post]
+func (c *SavedViewController) createSavedView(ctx echo.Context, userId uuid.UUID) error {
+	var req CreateSavedViewRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create saved view, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	newId, err := c.useCase.CreateSavedView(ctx.Request().Context(), domain.CreateSavedViewInput{
+		OwnerId:    userId,
+		Target:     domain.SavedViewTarget(req.Target),
+		Name:       req.Name,
+		Definition: req.Definition.toDomain(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, CreateSavedViewResponse{Id: newId})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "createSavedView, unhandled error useCase.CreateSavedView")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateSavedViewRequest struct {
+	ViewId     uuid.UUID           `param:"viewId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name       string              `json:"name" validate:"required,min=1,max=60" example:"연간 계약 고객"`
+	Definition SavedViewDefinition `json:"definition" validate:"required"`
+} // @name UpdateSavedViewRequest
+
+// @Tags (SavedView) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 저장된 보기 수정
+// @Description 내가 저장한 보기의 이름, 필터/정렬 수정, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param view_id path string true "보기 식별 아이디(UUID)"
+// @Param requestBody body UpdateSavedViewRequest true "수정할 보기"
+// @Success 204 "수정 성공"
+// @Failure 400 {object} domain.ErrorResponse "대상 목록에서 쓸 수 없는 정렬, 추가 항목"
+// @Failure 404 {object} domain.ErrorResponse "없는 보기"
+// @Router /saved-view/{view_id} [put]
+func (c *SavedViewController) updateSavedView(ctx echo.Context, userId uuid.UUID) error {
+	var req UpdateSavedViewRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update saved view, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateSavedView(ctx.Request().Context(), domain.UpdateSavedView{
+		ViewId:     req.ViewId,
+		OwnerId:    userId,
+		Name:       req.Name,
+		Definition: req.Definition.toDomain(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("viewId", req.ViewId).
+			Error(tag, "updateSavedView, unhandled error useCase.UpdateSavedView")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (SavedView) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 저장된 보기 삭제
+// @Description 내가 저장한 보기 삭제, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param view_id path string true "보기 식별 아이디(UUID)"
+// @Success 204 "삭제 성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 보기"
+// @Router /saved-view/{view_id} [delete]
+func (c *SavedViewController) deleteSavedView(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		ViewId uuid.UUID `param:"viewId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "delete saved view, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.DeleteSavedView(ctx.Request().Context(), domain.DeleteSavedView{
+		ViewId:  req.ViewId,
+		OwnerId: userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("viewId", req.ViewId).
+			Error(tag, "deleteSavedView, unhandled error useCase.DeleteSavedView")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewSavedViewRepository(db *gorm.DB) domain.SavedViewRepository {
+	db.AutoMigrate(&domain.SavedView{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, view *domain.SavedView) error {
+	return r.db.WithContext(ctx).Save(view).Error
+}
+
+func (r *repo) Delete(ctx context.Context, view *domain.SavedView) error {
+	return r.db.WithContext(ctx).Delete(view).Error
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (view *domain.SavedView, err error) {
+	var entity domain.SavedView
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		view = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
+
+func (r *repo) FetchByOwnerId(ctx context.Context, ownerId uuid.UUID, target domain.SavedViewTarget) (list []domain.SavedView, err error) {
+	db := r.db.WithContext(ctx).
+		Order("`name` asc").
+		Where("`owner_id` = ?", ownerId)
+	if target != "" {
+		db = db.Where("`target` = ?", target)
+	}
+	err = db.Find(&list).Error
+	return
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewSavedViewUseCase(savedViewRepo domain.SavedViewRepository, ids domain.IdGenerator, clock domain.Clock, timeout time.Duration) domain.SavedViewUseCase {
+	return &ucase{
+		savedViewRepo: savedViewRepo,
+		ids:           ids,
+		clock:         clock,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	savedViewRepo domain.SavedViewRepository
+	ids           domain.IdGenerator
+	clock         domain.Clock
+	timeout       time.Duration
+}
+
+func (u *ucase) CreateSavedView(ctx context.Context, in domain.CreateSavedViewInput) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	view, err := domain.CreateSavedView(domain.CreateSavedViewOption{
+		Id:         u.ids.NewId(),
+		OwnerId:    in.OwnerId,
+		Target:     in.Target,
+		Name:       in.Name,
+		Definition: in.Definition,
+		Now:        u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	err = u.savedViewRepo.Save(c, &view)
+	if err != nil {
+		return
+	}
+
+	newId = view.Id
+	return
+}
+
+func (u *ucase) UpdateSavedView(ctx context.Context, in domain.UpdateSavedView) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	view, err := u.getOwnedView(c, in.ViewId, in.OwnerId)
+	if err != nil {
+		return
+	}
+
+	err = view.Update(in.Name, in.Definition, u.clock.Now())
+	if err != nil {
+		return
+	}
+
+	err = u.savedViewRepo.Save(c, view)
+	return
+}
+
+func (u *ucase) DeleteSavedView(ctx context.Context, in domain.DeleteSavedView) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	view, err := u.getOwnedView(c, in.ViewId, in.OwnerId)
+	if err != nil {
+		return
+	}
+
+	err = u.savedViewRepo.Delete(c, view)
+	return
+}
+
+// getOwnedView 다른 어드민의 보기는 없는 것으로 취급
+func (u *ucase) getOwnedView(ctx context.Context, viewId, ownerId uuid.UUID) (view *domain.SavedView, err error) {
+	view, err = u.savedViewRepo.GetById(ctx, viewId)
+	if err != nil {
+		return
+	}
+
+	if view == nil || view.OwnerId != ownerId {
+		view = nil
+		err = domain.ErrItemNotFound
+	}
+	return
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchSavedViews(ctx context.Context, ownerId uuid.UUID, target domain.SavedViewTarget) (res []domain.SavedViewInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.savedViewRepo.FetchByOwnerId(c, ownerId, target)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.SavedViewInfo, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = domain.SavedViewInfo{
+			Id:         src.Id,
+			Target:     src.Target,
+			Name:       src.Name,
+			Definition: src.ParseDefinition(),
+			UpdatedAt:  src.UpdatedAt,
+		}
+	}
+	return
+}
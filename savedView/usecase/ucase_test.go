@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/clock"
+	"github.com/stockfolioofficial/back-editfolio/core/idgen"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+type memoryRepo struct {
+	views map[uuid.UUID]domain.SavedView
+}
+
+func (r *memoryRepo) Save(_ context.Context, view *domain.SavedView) error {
+	r.views[view.Id] = *view
+	return nil
+}
+
+func (r *memoryRepo) Delete(_ context.Context, view *domain.SavedView) error {
+	delete(r.views, view.Id)
+	return nil
+}
+
+func (r *memoryRepo) GetById(_ context.Context, id uuid.UUID) (*domain.SavedView, error) {
+	view, ok := r.views[id]
+	if !ok {
+		return nil, nil
+	}
+	return &view, nil
+}
+
+func (r *memoryRepo) FetchByOwnerId(_ context.Context, ownerId uuid.UUID, target domain.SavedViewTarget) (list []domain.SavedView, err error) {
+	for _, view := range r.views {
+		if view.OwnerId == ownerId && view.Target == target {
+			list = append(list, view)
+		}
+	}
+	return
+}
+
+func TestSavedViewStampsInjectedClock(t *testing.T) {
+	viewId := uuid.MustParse("00000000-0000-4000-8000-000000000001")
+	createdAt := time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)
+	fixed := clock.NewFixed(createdAt)
+	repo := &memoryRepo{views: map[uuid.UUID]domain.SavedView{}}
+	u := NewSavedViewUseCase(repo, idgen.Sequence(viewId), fixed, time.Second)
+
+	ownerId := uuid.New()
+	id, err := u.CreateSavedView(context.Background(), domain.CreateSavedViewInput{
+		OwnerId:    ownerId,
+		Target:     domain.SavedViewTargetCustomer,
+		Name:       "최근 고객",
+		Definition: domain.SavedViewDefinition{Sort: string(domain.CustomerSortKeyCreatedAt), Desc: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateSavedView: %v", err)
+	}
+	if id != viewId {
+		t.Fatalf("created id %v, want %v", id, viewId)
+	}
+
+	created := repo.views[id]
+	if !created.CreatedAt.Equal(createdAt) || !created.UpdatedAt.Equal(createdAt) {
+		t.Fatalf("created at %v, updated at %v, want both %v", created.CreatedAt, created.UpdatedAt, createdAt)
+	}
+
+	fixed.Advance(time.Hour)
+	err = u.UpdateSavedView(context.Background(), domain.UpdateSavedView{
+		ViewId:     id,
+		OwnerId:    ownerId,
+		Name:       "이름순",
+		Definition: domain.SavedViewDefinition{Sort: string(domain.CustomerSortKeyName)},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSavedView: %v", err)
+	}
+
+	updated := repo.views[id]
+	if !updated.CreatedAt.Equal(createdAt) {
+		t.Errorf("created at moved to %v, want %v", updated.CreatedAt, createdAt)
+	}
+	if want := createdAt.Add(time.Hour); !updated.UpdatedAt.Equal(want) {
+		t.Errorf("updated at %v, want %v", updated.UpdatedAt, want)
+	}
+}
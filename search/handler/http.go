@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[SEARCH] "
+)
+
+func NewSearchController(useCase domain.SearchUseCase) *SearchController {
+	return &SearchController{useCase: useCase}
+}
+
+type SearchController struct {
+	useCase domain.SearchUseCase
+}
+
+func (c *SearchController) Bind(e *echo.Echo) {
+	// ===== ADMIN =====
+	// 어드민 화면 검색창 자동 완성
+	e.GET("/search/suggest", c.suggest,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+}
+
+type SearchSuggestionResponse struct {
+	Type  string    `json:"type" validate:"required" example:"CUSTOMER" enums:"CUSTOMER,ORDER"`
+	Id    uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Label string    `json:"label" validate:"required" example:"홍길동 (example@example.com)"`
+} // @name SearchSuggestionResponse
+
+type SearchSuggestRequest struct {
+	Query string `query:"q" validate:"max=100"`
+}
+
+// @Tags (Search) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 빠른 검색
+// @Description 고객 이름, 이메일, 채널 이름, 휴대폰 번호와 의뢰 번호 앞부분이 일치하는 결과 최대 5개, 의뢰 번호(EF-)를 입력 중이면 의뢰 먼저, 2글자 미만이면 결과 없음, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param q query string true "검색어"
+// @Success 200 {array} SearchSuggestionResponse "성공"
+// @Success 204 "결과 없음"
+// @Router /search/suggest [get]
+func (c *SearchController) suggest(ctx echo.Context) error {
+	var req SearchSuggestRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "suggest, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.Suggest(ctx.Request().Context(), req.Query)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "suggest, unhandled error useCase.Suggest")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]SearchSuggestionResponse, len(list))
+	for i := range list {
+		res[i] = SearchSuggestionResponse{
+			Type:  string(list[i].Type),
+			Id:    list[i].Id,
+			Label: list[i].Label,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewSearchRepository(db *gorm.DB) domain.SearchRepository {
+	return &repo{db: db}
+}
+
+// likeEscaper 검색어의 LIKE 와일드카드를 글자 그대로 찾도록
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type repo struct {
+	db *gorm.DB
+}
+
+// prefixOf 앞부분 일치만 써야 각 컬럼 인덱스를 탐
+func prefixOf(query string) string {
+	return likeEscaper.Replace(query) + "%"
+}
+
+func (r *repo) SuggestCustomers(ctx context.Context, prefix string, limit int) (list []domain.SearchSuggestion, err error) {
+	var rows []struct {
+		Id    uuid.UUID
+		Name  string
+		Email string
+	}
+
+	like := prefixOf(prefix)
+	cond := r.db.Where("`customer`.`name` LIKE ?", like).
+		Or("`customer`.`email` LIKE ?", like).
+		Or("`customer`.`channel_name` LIKE ?", like)
+	if mobile := strings.ReplaceAll(prefix, "-", ""); mobile != "" && strings.Trim(mobile, "0123456789") == "" {
+		cond = cond.Or("`customer`.`mobile` LIKE ?", prefixOf(mobile))
+	}
+
+	err = r.db.WithContext(ctx).
+		Table("customer").
+		Select("`customer`.`id`, `customer`.`name`, `customer`.`email`").
+		Joins("JOIN `user` ON `user`.`id` = `customer`.`id`").
+		Where("`user`.`deleted_at` IS NULL").
+		Where(cond).
+		Order("`customer`.`name` asc").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return
+	}
+
+	list = make([]domain.SearchSuggestion, len(rows))
+	for i, row := range rows {
+		list[i] = domain.SearchSuggestion{
+			Type:  domain.SearchSuggestionTypeCustomer,
+			Id:    row.Id,
+			Label: row.Name + " (" + row.Email + ")",
+		}
+	}
+	return
+}
+
+func (r *repo) SuggestOrders(ctx context.Context, prefix string, limit int) (list []domain.SearchSuggestion, err error) {
+	var rows []struct {
+		Id     uuid.UUID
+		Number string
+		Name   *string
+	}
+
+	err = r.db.WithContext(ctx).
+		Table("order").
+		Select("`order`.`id`, `order`.`number`, `customer`.`name`").
+		Joins("LEFT JOIN `customer` ON `customer`.`id` = `order`.`orderer`").
+		Where("`order`.`number` LIKE ?", prefixOf(strings.ToUpper(prefix))).
+		Order("`order`.`number` desc").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return
+	}
+
+	list = make([]domain.SearchSuggestion, len(rows))
+	for i, row := range rows {
+		label := row.Number
+		if row.Name != nil {
+			label += " " + *row.Name
+		}
+		list[i] = domain.SearchSuggestion{
+			Type:  domain.SearchSuggestionTypeOrder,
+			Id:    row.Id,
+			Label: label,
+		}
+	}
+	return
+}
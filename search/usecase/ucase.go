@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewSearchUseCase(searchRepo domain.SearchRepository, timeout time.Duration) domain.SearchUseCase {
+	return &ucase{
+		searchRepo: searchRepo,
+		cache:      cache.NewWithLimit(domain.SearchSuggestCacheName, domain.SearchSuggestCacheTTL, domain.SearchSuggestCacheLimit),
+		timeout:    timeout,
+	}
+}
+
+type ucase struct {
+	searchRepo domain.SearchRepository
+	cache      *cache.Store
+	timeout    time.Duration
+}
+
+// Suggest 고객, 의뢰를 동시에 찾고 의뢰 번호를 입력 중이면 의뢰부터 채움
+func (u *ucase) Suggest(ctx context.Context, query string) (res []domain.SearchSuggestion, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	query, ok := domain.NormalizeSearchQuery(query)
+	if !ok {
+		return
+	}
+
+	cached, err := u.cache.GetOrLoad(tenant.CacheKey(ctx, query), func() (interface{}, error) {
+		var customers, orders []domain.SearchSuggestion
+		g, gc := errgroup.WithContext(c)
+		g.Go(func() (err error) {
+			customers, err = u.searchRepo.SuggestCustomers(gc, query, domain.SearchSuggestLimit)
+			return
+		})
+		g.Go(func() (err error) {
+			orders, err = u.searchRepo.SuggestOrders(gc, query, domain.SearchSuggestLimit)
+			return
+		})
+		err := g.Wait()
+		if err != nil {
+			return nil, err
+		}
+
+		first, second := customers, orders
+		if domain.LooksLikeOrderNumber(query) {
+			first, second = orders, customers
+		}
+
+		list := append(append(make([]domain.SearchSuggestion, 0, len(first)+len(second)), first...), second...)
+		if len(list) > domain.SearchSuggestLimit {
+			list = list[:domain.SearchSuggestLimit]
+		}
+		return list, nil
+	})
+	if err != nil {
+		return
+	}
+
+	res = cached.([]domain.SearchSuggestion)
+	return
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[SETTING] "
+)
+
+func NewSettingController(useCase domain.SettingUseCase) *SettingController {
+	return &SettingController{useCase: useCase}
+}
+
+type SettingController struct {
+	useCase domain.SettingUseCase
+}
+
+func (c *SettingController) Bind(e *echo.Echo) {
+	// ===== SUPER_ADMIN =====
+	e.GET("/setting", c.fetchSettings,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.PUT("/setting/:key", echox.UserID(c.updateSetting),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.DELETE("/setting/:key", echox.UserID(c.resetSetting),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type SettingResponse struct {
+	Key         string     `json:"key" validate:"required" example:"order.sla_hours"`
+	Type        string     `json:"type" validate:"required" example:"INT" enums:"INT,BOOL,STRING"`
+	Value       string     `json:"value" validate:"required" example:"48"`
+	Default     string     `json:"default" validate:"required" example:"72"`
+	Description string     `json:"description" validate:"required" example:"주문 기본 마감 시간(시간)"`
+	UpdatedBy   *uuid.UUID `json:"updatedBy" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UpdatedAt   *time.Time `json:"updatedAt" example:"2021-10-27T04:44:18+00:00"`
+} // @name SettingResponse
+
+// @Tags (Setting) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 시스템 설정 목록
+// @Description 설정 가능한 항목 전부와 현재 값, 저장된 값이 없으면 기본값, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} SettingResponse "성공"
+// @Router /setting [get]
+func (c *SettingController) fetchSettings(ctx echo.Context) error {
+	list, err := c.useCase.FetchSettings(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchSettings, unhandled error useCase.FetchSettings")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := make([]SettingResponse, len(list))
+	for i, src := range list {
+		res[i] = SettingResponse{
+			Key:         string(src.Key),
+			Type:        string(src.Type),
+			Value:       src.Value,
+			Default:     src.Default,
+			Description: src.Description,
+			UpdatedBy:   src.UpdatedBy,
+			UpdatedAt:   src.UpdatedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type UpdateSettingRequest struct {
+	Key   string `param:"key" json:"-" validate:"required" example:"order.sla_hours"`
+	Value string `json:"value" validate:"max=1000" example:"48"`
+} // @name UpdateSettingRequest
+
+// @Tags (Setting) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 시스템 설정 변경
+// @Description 설정 값 변경, 타입(INT, BOOL)에 맞지 않으면 400, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param key path string true "설정 키"
+// @Param requestBody body UpdateSettingRequest true "설정 값"
+// @Success 204 "변경 성공"
+// @Failure 400 {object} domain.ErrorResponse "값 형식이 맞지 않음"
+// @Failure 404 {object} domain.ErrorResponse "없는 설정 키"
+// @Router /setting/{key} [put]
+func (c *SettingController) updateSetting(ctx echo.Context, userId uuid.UUID) error {
+	var req UpdateSettingRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update setting, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateSetting(ctx.Request().Context(), domain.UpdateSetting{
+		Key:       domain.SettingKey(req.Key),
+		Value:     req.Value,
+		UpdatedBy: userId,
+		Ip:        ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "setting not found"})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "updateSetting, unhandled error useCase.UpdateSetting")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Setting) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 시스템 설정 기본값으로 되돌리기
+// @Description 저장된 설정 값을 지워 기본값 사용, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param key path string true "설정 키"
+// @Success 204 "초기화 성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 설정 키"
+// @Router /setting/{key} [delete]
+func (c *SettingController) resetSetting(ctx echo.Context, userId uuid.UUID) error {
+	err := c.useCase.ResetSetting(ctx.Request().Context(), domain.ResetSetting{
+		Key:     domain.SettingKey(ctx.Param("key")),
+		ResetBy: userId,
+		Ip:      ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "setting not found"})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "resetSetting, unhandled error useCase.ResetSetting")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewSettingRepository(db *gorm.DB) domain.SettingRepository {
+	db.AutoMigrate(&domain.Setting{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, setting *domain.Setting) error {
+	return gormx.Upsert(ctx, r.db, setting)
+}
+
+func (r *repo) Delete(ctx context.Context, key domain.SettingKey) error {
+	return r.db.WithContext(ctx).
+		Delete(&domain.Setting{}, "`key` = ?", key).Error
+}
+
+func (r *repo) FetchAll(ctx context.Context) (list []domain.Setting, err error) {
+	err = r.db.WithContext(ctx).
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	cacheAllKey = "all"
+)
+
+// NewSettingReader 다른 유스케이스에서 설정을 읽을 때 사용, 설정 유스케이스와 같은 캐시를 공유
+func NewSettingReader(
+	settingRepo domain.SettingRepository,
+	timeout time.Duration,
+) domain.SettingReader {
+	return &reader{
+		settingRepo: settingRepo,
+		cache:       cache.New(domain.SettingCacheName, domain.SettingCacheTTL),
+		timeout:     timeout,
+	}
+}
+
+type reader struct {
+	settingRepo domain.SettingRepository
+	cache       *cache.Store
+	timeout     time.Duration
+}
+
+func (r *reader) values(ctx context.Context) (map[domain.SettingKey]string, error) {
+	cached, err := r.cache.GetOrLoad(tenant.CacheKey(ctx, cacheAllKey), func() (interface{}, error) {
+		c, cancel := budget.Slice(ctx, r.timeout)
+		defer cancel()
+
+		list, err := r.settingRepo.FetchAll(c)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[domain.SettingKey]string, len(list))
+		for _, setting := range list {
+			values[setting.Key] = setting.Value
+		}
+		return values, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(map[domain.SettingKey]string), nil
+}
+
+// raw 저장값이 없거나 정의와 맞지 않으면 기본값, 정의에 없는 키면 ErrItemNotFound
+func (r *reader) raw(ctx context.Context, key domain.SettingKey) (string, error) {
+	def, ok := domain.GetSettingDefinition(key)
+	if !ok {
+		return "", domain.ErrItemNotFound
+	}
+
+	values, err := r.values(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if value, ok := values[key]; ok && def.Validate(value) == nil {
+		return value, nil
+	}
+	return def.Default, nil
+}
+
+func (r *reader) Int(ctx context.Context, key domain.SettingKey) (int64, error) {
+	value, err := r.raw(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func (r *reader) Bool(ctx context.Context, key domain.SettingKey) (bool, error) {
+	value, err := r.raw(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+func (r *reader) String(ctx context.Context, key domain.SettingKey) (string, error) {
+	return r.raw(ctx, key)
+}
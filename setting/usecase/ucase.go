@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewSettingUseCase(
+	settingRepo domain.SettingRepository,
+	auditLogger domain.AuditLogger,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.SettingUseCase {
+	return &ucase{
+		settingRepo: settingRepo,
+		auditLogger: auditLogger,
+		cache:       cache.New(domain.SettingCacheName, domain.SettingCacheTTL),
+		clock:       clock,
+		timeout:     timeout,
+	}
+}
+
+type ucase struct {
+	settingRepo domain.SettingRepository
+	auditLogger domain.AuditLogger
+	cache       *cache.Store
+	clock       domain.Clock
+	timeout     time.Duration
+}
+
+func (u *ucase) UpdateSetting(ctx context.Context, in domain.UpdateSetting) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	def, ok := domain.GetSettingDefinition(in.Key)
+	if !ok {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = def.Validate(in.Value)
+	if err != nil {
+		return
+	}
+
+	err = u.settingRepo.Save(c, &domain.Setting{
+		Key:       in.Key,
+		Value:     in.Value,
+		UpdatedBy: &in.UpdatedBy,
+		UpdatedAt: u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	u.cache.InvalidateAll()
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId: in.UpdatedBy,
+		Action:  domain.AuditActionSettingUpdated,
+		Ip:      in.Ip,
+		Detail:  string(in.Key),
+	})
+	return
+}
+
+func (u *ucase) ResetSetting(ctx context.Context, in domain.ResetSetting) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if _, ok := domain.GetSettingDefinition(in.Key); !ok {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = u.settingRepo.Delete(c, in.Key)
+	if err != nil {
+		return
+	}
+
+	u.cache.InvalidateAll()
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId: in.ResetBy,
+		Action:  domain.AuditActionSettingReset,
+		Ip:      in.Ip,
+		Detail:  string(in.Key),
+	})
+	return
+}
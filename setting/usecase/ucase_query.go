@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchSettings(ctx context.Context) (res []domain.SettingInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.settingRepo.FetchAll(c)
+	if err != nil {
+		return
+	}
+
+	saved := make(map[domain.SettingKey]domain.Setting, len(list))
+	for _, setting := range list {
+		saved[setting.Key] = setting
+	}
+
+	res = make([]domain.SettingInfo, len(domain.SettingDefinitions))
+	for i, def := range domain.SettingDefinitions {
+		info := domain.SettingInfo{
+			Key:         def.Key,
+			Type:        def.Type,
+			Value:       def.Default,
+			Default:     def.Default,
+			Description: def.Description,
+		}
+		if setting, ok := saved[def.Key]; ok {
+			updatedAt := setting.UpdatedAt
+			info.Value = setting.Value
+			info.UpdatedBy = setting.UpdatedBy
+			info.UpdatedAt = &updatedAt
+		}
+		res[i] = info
+	}
+	return
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[SHADOW] "
+
+	defaultRecordLimit = 50
+)
+
+func NewShadowController(useCase domain.ShadowUseCase) *ShadowController {
+	return &ShadowController{useCase: useCase}
+}
+
+type ShadowController struct {
+	useCase domain.ShadowUseCase
+}
+
+func (c *ShadowController) Bind(e *echo.Echo) {
+	// ===== SUPER_ADMIN =====
+	e.GET("/shadow/rule", c.fetchRules,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.POST("/shadow/rule", echox.UserID(c.createRule),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.DELETE("/shadow/rule/:ruleId", c.deleteRule,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.GET("/shadow/record", c.fetchRecords,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.GET("/shadow/record/:recordId", c.getRecord,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type ShadowRuleResponse struct {
+	Id         uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Method     string    `json:"method" validate:"required" example:"GET"`
+	Route      string    `json:"route" validate:"required" example:"/order/:orderId"`
+	SampleRate uint8     `json:"sampleRate" validate:"required" example:"10"`
+	CreatedBy  uuid.UUID `json:"createdBy" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt  time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+	ExpiresAt  time.Time `json:"expiresAt" validate:"required" example:"2021-10-27T05:44:18+00:00"`
+	Active     bool      `json:"active" validate:"required" example:"true"`
+} // @name ShadowRuleResponse
+
+// @Tags (Shadow) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 요청 기록 규칙 목록
+// @Description 라우트별 요청/응답 기록(섀도우) 규칙 목록, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} ShadowRuleResponse "성공"
+// @Success 204 "규칙 없음"
+// @Router /shadow/rule [get]
+func (c *ShadowController) fetchRules(ctx echo.Context) error {
+	list, err := c.useCase.FetchRules(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchRules, unhandled error useCase.FetchRules")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]ShadowRuleResponse, len(list))
+	for i, src := range list {
+		res[i] = ShadowRuleResponse{
+			Id:         src.Id,
+			Method:     src.Method,
+			Route:      src.Route,
+			SampleRate: src.SampleRate,
+			CreatedBy:  src.CreatedBy,
+			CreatedAt:  src.CreatedAt,
+			ExpiresAt:  src.ExpiresAt,
+			Active:     src.Active,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type CreateShadowRuleRequest struct {
+	Method string `json:"method" validate:"required,oneof=GET POST PUT PATCH DELETE" example:"GET"`
+	// Route echo 라우트 패턴 그대로 (ex. /order/:orderId)
+	Route string `json:"route" validate:"required,startswith=/,max=200" example:"/order/:orderId"`
+	// SampleRate 기록할 요청 비율(%)
+	SampleRate uint8 `json:"sampleRate" validate:"required,min=1,max=100" example:"10"`
+	// DurationMinutes 규칙 유지 시간, 최대 1440분(하루)
+	DurationMinutes uint16 `json:"durationMinutes" validate:"required,min=1,max=1440" example:"60"`
+} // @name CreateShadowRuleRequest
+
+type CreateShadowRuleResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name CreateShadowRuleResponse
+
+// @Tags (Shadow) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 요청 기록 규칙 추가
+// @Description 라우트 요청 중 일정 비율을 개인정보를 가린 뒤 요청/응답 쌍으로 기록, 규칙은 지정한 시간 뒤 자동 만료, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateShadowRuleRequest true "기록 규칙"
+// @Success 201 {object} CreateShadowRuleResponse "추가 성공"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 비율, 유지 시간"
+// @Router /shadow/rule [post]
+func (c *ShadowController) createRule(ctx echo.Context, userId uuid.UUID) error {
+	var req CreateShadowRuleRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create shadow rule, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	newId, err := c.useCase.CreateRule(ctx.Request().Context(), domain.CreateShadowRuleInput{
+		Method:     req.Method,
+		Route:      req.Route,
+		SampleRate: req.SampleRate,
+		Duration:   time.Duration(req.DurationMinutes) * time.Minute,
+		CreatedBy:  userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, CreateShadowRuleResponse{Id: newId})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "createRule, unhandled error useCase.CreateRule")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Shadow) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 요청 기록 규칙 삭제
+// @Description 기록 중지, 이미 기록된 요청은 보관 기간 정책에 따라 정리됨, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param rule_id path string true "규칙 식별 아이디(UUID)"
+// @Success 204 "삭제 성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 규칙"
+// @Router /shadow/rule/{rule_id} [delete]
+func (c *ShadowController) deleteRule(ctx echo.Context) error {
+	var req struct {
+		RuleId uuid.UUID `param:"ruleId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "delete shadow rule, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.DeleteRule(ctx.Request().Context(), req.RuleId)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("ruleId", req.RuleId).
+			Error(tag, "deleteRule, unhandled error useCase.DeleteRule")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ShadowRecordResponse struct {
+	Id           uuid.UUID  `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Method       string     `json:"method" validate:"required" example:"GET"`
+	Route        string     `json:"route" validate:"required" example:"/order/:orderId"`
+	Path         string     `json:"path" validate:"required" example:"/order/550e8400-e29b-41d4-a716-446655440000"`
+	Query        string     `json:"query" example:"q=%5BREDACTED%5D"`
+	UserId       *uuid.UUID `json:"userId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RequestBody  string     `json:"requestBody" example:"{\"requirement\":\"...\"}"`
+	Status       int        `json:"status" validate:"required" example:"200"`
+	ResponseBody string     `json:"responseBody" example:"{\"orderId\":\"...\"}"`
+	DurationMs   int64      `json:"durationMs" validate:"required" example:"35"`
+	RecordedAt   time.Time  `json:"recordedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name ShadowRecordResponse
+
+func useCaseToRecordResponse(src domain.ShadowRecordInfo) ShadowRecordResponse {
+	return ShadowRecordResponse{
+		Id:           src.Id,
+		Method:       src.Method,
+		Route:        src.Route,
+		Path:         src.Path,
+		Query:        src.Query,
+		UserId:       src.UserId,
+		RequestBody:  src.RequestBody,
+		Status:       src.Status,
+		ResponseBody: src.ResponseBody,
+		DurationMs:   src.DurationMs,
+		RecordedAt:   src.RecordedAt,
+	}
+}
+
+type FetchShadowRecordRequest struct {
+	Method string `query:"method"`
+	Route  string `query:"route"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+// @Tags (Shadow) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 기록된 요청 목록
+// @Description 개인정보를 가린 요청/응답 기록, 최근 순, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param method query string false "HTTP 메서드"
+// @Param route query string false "라우트 패턴 (ex. /order/:orderId)"
+// @Param limit query int false "개수, 기본 50 최대 200"
+// @Success 200 {array} ShadowRecordResponse "성공"
+// @Success 204 "기록 없음"
+// @Router /shadow/record [get]
+func (c *ShadowController) fetchRecords(ctx echo.Context) error {
+	var req FetchShadowRecordRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch shadow records, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultRecordLimit
+	}
+
+	list, err := c.useCase.FetchRecords(ctx.Request().Context(), domain.FetchShadowRecordOption{
+		Method: req.Method,
+		Route:  req.Route,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchRecords, unhandled error useCase.FetchRecords")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]ShadowRecordResponse, len(list))
+	for i := range list {
+		res[i] = useCaseToRecordResponse(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (Shadow) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 기록된 요청 상세
+// @Description 개인정보를 가린 요청/응답 한 쌍, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param record_id path string true "기록 식별 아이디(UUID)"
+// @Success 200 {object} ShadowRecordResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 기록"
+// @Router /shadow/record/{record_id} [get]
+func (c *ShadowController) getRecord(ctx echo.Context) error {
+	var req struct {
+		RecordId uuid.UUID `param:"recordId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get shadow record, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.GetRecord(ctx.Request().Context(), req.RecordId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, useCaseToRecordResponse(res))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("recordId", req.RecordId).
+			Error(tag, "getRecord, unhandled error useCase.GetRecord")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewShadowRepository(db *gorm.DB) domain.ShadowRepository {
+	db.AutoMigrate(&domain.ShadowRule{}, &domain.ShadowRecord{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) SaveRule(ctx context.Context, rule *domain.ShadowRule) error {
+	return r.db.WithContext(ctx).Save(rule).Error
+}
+
+func (r *repo) DeleteRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	res := r.db.WithContext(ctx).Delete(&domain.ShadowRule{}, id)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *repo) FetchRules(ctx context.Context) (list []domain.ShadowRule, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`created_at` desc").
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchActiveRules(ctx context.Context, now time.Time) (list []domain.ShadowRule, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`expires_at` > ?", now).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) SaveRecord(ctx context.Context, record *domain.ShadowRecord) error {
+	return r.db.WithContext(ctx).Create(record).Error
+}
+
+func (r *repo) GetRecordById(ctx context.Context, id uuid.UUID) (record *domain.ShadowRecord, err error) {
+	var entity domain.ShadowRecord
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		record = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
+
+func (r *repo) FetchRecords(ctx context.Context, option domain.FetchShadowRecordOption) (list []domain.ShadowRecord, err error) {
+	db := r.db.WithContext(ctx).
+		Order("`recorded_at` desc").
+		Limit(option.Limit)
+	if option.Method != "" {
+		db = db.Where("`method` = ?", option.Method)
+	}
+	if option.Route != "" {
+		db = db.Where("`route` = ?", option.Route)
+	}
+	err = db.Find(&list).Error
+	return
+}
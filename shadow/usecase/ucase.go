@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/redact"
+)
+
+const (
+	cacheActiveKey = "active"
+)
+
+func NewShadowUseCase(shadowRepo domain.ShadowRepository, ids domain.IdGenerator, clock domain.Clock, timeout time.Duration) domain.ShadowUseCase {
+	return &ucase{
+		shadowRepo: shadowRepo,
+		cache:      cache.New(domain.ShadowRuleCacheName, domain.ShadowRuleCacheTTL),
+		ids:        ids,
+		clock:      clock,
+		timeout:    timeout,
+	}
+}
+
+type ucase struct {
+	shadowRepo domain.ShadowRepository
+	cache      *cache.Store
+	ids        domain.IdGenerator
+	clock      domain.Clock
+	timeout    time.Duration
+}
+
+func ruleKey(method, route string) string {
+	return method + " " + route
+}
+
+// activeRules 메서드+라우트별 켜진 규칙
+func (u *ucase) activeRules(ctx context.Context) (map[string]domain.ShadowRule, error) {
+	cached, err := u.cache.GetOrLoad(tenant.CacheKey(ctx, cacheActiveKey), func() (interface{}, error) {
+		c, cancel := budget.Slice(ctx, u.timeout)
+		defer cancel()
+
+		list, err := u.shadowRepo.FetchActiveRules(c, u.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		rules := make(map[string]domain.ShadowRule, len(list))
+		for _, rule := range list {
+			rules[ruleKey(rule.Method, rule.Route)] = rule
+		}
+		return rules, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(map[string]domain.ShadowRule), nil
+}
+
+func (u *ucase) Sample(ctx context.Context, method, route string) (ruleId uuid.UUID, ok bool) {
+	rules, err := u.activeRules(ctx)
+	if err != nil {
+		// 기록은 디버깅용이라 실패해도 요청은 그대로 처리
+		return
+	}
+
+	rule, exists := rules[ruleKey(method, route)]
+	if !exists || !rule.IsActive(u.clock.Now()) {
+		return
+	}
+
+	if rand.Intn(100) >= int(rule.SampleRate) {
+		return
+	}
+	return rule.Id, true
+}
+
+func (u *ucase) Record(ctx context.Context, ruleId uuid.UUID, capture domain.ShadowCapture) error {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	record := domain.ShadowRecord{
+		Id:           u.ids.NewId(),
+		RuleId:       ruleId,
+		Method:       capture.Method,
+		Route:        capture.Route,
+		Path:         redact.String(capture.Path),
+		Query:        redact.Query(capture.Query),
+		UserId:       capture.UserId,
+		RequestBody:  redact.JSON(capture.RequestBody),
+		Status:       capture.Status,
+		ResponseBody: redact.JSON(capture.ResponseBody),
+		DurationMs:   capture.Duration.Milliseconds(),
+		RecordedAt:   u.clock.Now(),
+	}
+	return u.shadowRepo.SaveRecord(c, &record)
+}
+
+func (u *ucase) CreateRule(ctx context.Context, in domain.CreateShadowRuleInput) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	rule, err := domain.CreateShadowRule(domain.CreateShadowRuleOption{
+		Id:         u.ids.NewId(),
+		Method:     in.Method,
+		Route:      in.Route,
+		SampleRate: in.SampleRate,
+		Duration:   in.Duration,
+		CreatedBy:  in.CreatedBy,
+		Now:        u.clock.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	err = u.shadowRepo.SaveRule(c, &rule)
+	if err != nil {
+		return
+	}
+
+	u.cache.InvalidateAll()
+	newId = rule.Id
+	return
+}
+
+func (u *ucase) DeleteRule(ctx context.Context, id uuid.UUID) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	deleted, err := u.shadowRepo.DeleteRule(c, id)
+	if err != nil {
+		return
+	}
+	if !deleted {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	u.cache.InvalidateAll()
+	return
+}
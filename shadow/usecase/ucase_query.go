@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchRules(ctx context.Context) (res []domain.ShadowRuleInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.shadowRepo.FetchRules(c)
+	if err != nil {
+		return
+	}
+
+	now := u.clock.Now()
+	res = make([]domain.ShadowRuleInfo, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = domain.ShadowRuleInfo{
+			Id:         src.Id,
+			Method:     src.Method,
+			Route:      src.Route,
+			SampleRate: src.SampleRate,
+			CreatedBy:  src.CreatedBy,
+			CreatedAt:  src.CreatedAt,
+			ExpiresAt:  src.ExpiresAt,
+			Active:     src.IsActive(now),
+		}
+	}
+	return
+}
+
+func (u *ucase) GetRecord(ctx context.Context, id uuid.UUID) (res domain.ShadowRecordInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	record, err := u.shadowRepo.GetRecordById(c, id)
+	if err != nil {
+		return
+	}
+	if record == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = toRecordInfo(*record)
+	return
+}
+
+func (u *ucase) FetchRecords(ctx context.Context, option domain.FetchShadowRecordOption) (res []domain.ShadowRecordInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.shadowRepo.FetchRecords(c, option)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.ShadowRecordInfo, len(list))
+	for i := range list {
+		res[i] = toRecordInfo(list[i])
+	}
+	return
+}
+
+func toRecordInfo(src domain.ShadowRecord) domain.ShadowRecordInfo {
+	return domain.ShadowRecordInfo{
+		Id:           src.Id,
+		Method:       src.Method,
+		Route:        src.Route,
+		Path:         src.Path,
+		Query:        src.Query,
+		UserId:       src.UserId,
+		RequestBody:  src.RequestBody,
+		Status:       src.Status,
+		ResponseBody: src.ResponseBody,
+		DurationMs:   src.DurationMs,
+		RecordedAt:   src.RecordedAt,
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[SHORT_LINK] "
+)
+
+// NewShortLinkController baseURL 짧은 주소 앞부분 (ex. https://efol.io), 비어있으면 상대 경로
+func NewShortLinkController(useCase domain.ShortLinkUseCase, baseURL string) *ShortLinkController {
+	return &ShortLinkController{useCase: useCase, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+type ShortLinkController struct {
+	useCase domain.ShortLinkUseCase
+	baseURL string
+}
+
+func (c *ShortLinkController) Bind(e *echo.Echo) {
+	// ===== ADMIN =====
+	e.POST("/short-links", echox.UserID(c.createShortLink),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/short-links/:code", c.getShortLink,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== PUBLIC =====
+	e.GET(domain.ShortLinkPath+":code", c.followShortLink)
+}
+
+func (c *ShortLinkController) shortUrlOf(code string) string {
+	return c.baseURL + domain.ShortLinkPath + code
+}
+
+type CreateShortLinkRequest struct {
+	Kind      string     `json:"kind" validate:"required" example:"DELIVERY" enums:"DELIVERY,PAYMENT,SURVEY"`
+	TargetUrl string     `json:"targetUrl" validate:"required,url" example:"https://editfolio.com/delivery/550e8400-e29b-41d4-a716-446655440000"`
+	ExpiresAt *time.Time `json:"expiresAt" example:"2021-11-27T04:44:18+00:00"`
+} // @name CreateShortLinkRequest
+
+type CreateShortLinkResponse struct {
+	Code     string `json:"code" validate:"required" example:"aB3kZ9q"`
+	ShortUrl string `json:"shortUrl" validate:"required" example:"https://efol.io/l/aB3kZ9q"`
+} // @name CreateShortLinkResponse
+
+// @Tags (ShortLink) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 짧은 주소 만들기
+// @Description 문자, 알림톡 길이 제한용, 납품/결제/설문 주소를 짧은 주소로 바꿈, 만료 시각이 없으면 계속 사용, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateShortLinkRequest true "종류, 원래 주소, 만료 시각"
+// @Success 201 {object} CreateShortLinkResponse "만들기 성공"
+// @Failure 400 {object} domain.ErrorResponse "없는 종류, http(s) 가 아닌 주소, 지난 만료 시각"
+// @Router /short-links [post]
+func (c *ShortLinkController) createShortLink(ctx echo.Context, userId uuid.UUID) error {
+	var req CreateShortLinkRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create short link, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	code, err := c.useCase.CreateShortLink(ctx.Request().Context(), domain.CreateShortLinkInput{
+		Kind:      domain.ShortLinkKind(req.Kind),
+		TargetUrl: req.TargetUrl,
+		CreatorId: userId,
+		ExpiresAt: req.ExpiresAt,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, CreateShortLinkResponse{
+			Code:     code,
+			ShortUrl: c.shortUrlOf(code),
+		})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "createShortLink, unhandled error useCase.CreateShortLink")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ShortLinkResponse struct {
+	Code          string     `json:"code" validate:"required" example:"aB3kZ9q"`
+	ShortUrl      string     `json:"shortUrl" validate:"required" example:"https://efol.io/l/aB3kZ9q"`
+	Kind          string     `json:"kind" validate:"required" example:"DELIVERY" enums:"DELIVERY,PAYMENT,SURVEY"`
+	TargetUrl     string     `json:"targetUrl" validate:"required" example:"https://editfolio.com/delivery/550e8400-e29b-41d4-a716-446655440000"`
+	CreatorId     uuid.UUID  `json:"creatorId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ExpiresAt     *time.Time `json:"expiresAt" example:"2021-11-27T04:44:18+00:00"`
+	ClickCount    int64      `json:"clickCount" validate:"required" example:"12"`
+	LastClickedAt *time.Time `json:"lastClickedAt" example:"2021-10-28T04:44:18+00:00"`
+	CreatedAt     time.Time  `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name ShortLinkResponse
+
+// @Tags (ShortLink) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 짧은 주소 클릭 통계
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param code path string true "짧은 주소 코드"
+// @Success 200 {object} ShortLinkResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 코드"
+// @Router /short-links/{code} [get]
+func (c *ShortLinkController) getShortLink(ctx echo.Context) error {
+	code := ctx.Param("code")
+	res, err := c.useCase.GetShortLink(ctx.Request().Context(), code)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, ShortLinkResponse{
+			Code:          res.Code,
+			ShortUrl:      c.shortUrlOf(res.Code),
+			Kind:          string(res.Kind),
+			TargetUrl:     res.TargetUrl,
+			CreatorId:     res.CreatorId,
+			ExpiresAt:     res.ExpiresAt,
+			ClickCount:    res.ClickCount,
+			LastClickedAt: res.LastClickedAt,
+			CreatedAt:     res.CreatedAt,
+		})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("code", code).
+			Error(tag, "getShortLink, unhandled error useCase.GetShortLink")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (ShortLink) 공용 기능
+// @Summary 짧은 주소 이동
+// @Description 클릭을 기록하고 원래 주소로 이동(302), 토큰 필요 없음
+// @Param code path string true "짧은 주소 코드"
+// @Success 302 "원래 주소로 이동"
+// @Failure 404 {object} domain.ErrorResponse "없는 코드"
+// @Failure 410 {object} domain.ErrorResponse "만료된 주소"
+// @Router /l/{code} [get]
+func (c *ShortLinkController) followShortLink(ctx echo.Context) error {
+	code := ctx.Param("code")
+	target, err := c.useCase.Follow(ctx.Request().Context(), code)
+
+	switch err {
+	case nil:
+		// 클릭 수가 정확하도록 브라우저가 캐시하지 않게 함
+		ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return ctx.Redirect(http.StatusFound, target)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrShortLinkExpired:
+		return ctx.JSON(http.StatusGone, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("code", code).
+			Error(tag, "followShortLink, unhandled error useCase.Follow")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
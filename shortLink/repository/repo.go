@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewShortLinkRepository(db *gorm.DB) domain.ShortLinkRepository {
+	db.AutoMigrate(&domain.ShortLink{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Create(ctx context.Context, link *domain.ShortLink) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(link)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *repo) GetByCode(ctx context.Context, code string) (link *domain.ShortLink, err error) {
+	var entity domain.ShortLink
+	err = r.db.WithContext(ctx).
+		Where("`code` = ?", code).
+		First(&entity).Error
+	if err == nil {
+		link = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
+
+func (r *repo) RecordClick(ctx context.Context, code string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.ShortLink{}).
+		Where("`code` = ?", code).
+		UpdateColumns(map[string]interface{}{
+			"click_count":     gorm.Expr("`click_count` + 1"),
+			"last_clicked_at": at,
+		}).Error
+}
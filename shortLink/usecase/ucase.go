@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+const tag = "[SHORT_LINK] "
+
+func NewShortLinkUseCase(shortLinkRepo domain.ShortLinkRepository, clock domain.Clock, timeout time.Duration) domain.ShortLinkUseCase {
+	return &ucase{
+		shortLinkRepo: shortLinkRepo,
+		clock:         clock,
+		timeout:       timeout,
+	}
+}
+
+type ucase struct {
+	shortLinkRepo domain.ShortLinkRepository
+	clock         domain.Clock
+	timeout       time.Duration
+}
+
+func (u *ucase) CreateShortLink(ctx context.Context, in domain.CreateShortLinkInput) (code string, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	for i := 0; i < domain.ShortLinkCodeRetry; i++ {
+		var link domain.ShortLink
+		link, err = domain.CreateShortLink(domain.CreateShortLinkOption{
+			Kind:      in.Kind,
+			TargetUrl: in.TargetUrl,
+			CreatorId: in.CreatorId,
+			ExpiresAt: in.ExpiresAt,
+			Now:       u.clock.Now(),
+		})
+		if err != nil {
+			return
+		}
+
+		var created bool
+		created, err = u.shortLinkRepo.Create(c, &link)
+		if err != nil {
+			return
+		}
+		if created {
+			code = link.Code
+			return
+		}
+	}
+
+	// 코드 공간이 충분해서 여기까지 오면 난수 생성 문제
+	err = domain.ErrItemAlreadyExist
+	return
+}
+
+func (u *ucase) Follow(ctx context.Context, code string) (target string, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	link, err := u.shortLinkRepo.GetByCode(c, code)
+	if err != nil {
+		return
+	}
+	if link == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	now := u.clock.Now()
+	if link.IsExpired(now) {
+		err = domain.ErrShortLinkExpired
+		return
+	}
+
+	// 클릭 기록이 실패해도 이동은 막지 않음
+	if clickErr := u.shortLinkRepo.RecordClick(c, link.Code, now); clickErr != nil {
+		logx.From(ctx).WithError(clickErr).WithField("code", link.Code).Warn(tag, "record click failed")
+	}
+
+	target = link.TargetUrl
+	return
+}
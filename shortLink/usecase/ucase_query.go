@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) GetShortLink(ctx context.Context, code string) (res domain.ShortLinkInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	link, err := u.shortLinkRepo.GetByCode(c, code)
+	if err != nil {
+		return
+	}
+	if link == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = domain.ShortLinkInfo{
+		Code:          link.Code,
+		Kind:          link.Kind,
+		TargetUrl:     link.TargetUrl,
+		CreatorId:     link.CreatorId,
+		ExpiresAt:     link.ExpiresAt,
+		ClickCount:    link.ClickCount,
+		LastClickedAt: link.LastClickedAt,
+		CreatedAt:     link.CreatedAt,
+	}
+	return
+}
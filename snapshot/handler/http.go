@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[SNAPSHOT] "
+)
+
+func NewCustomerSnapshotController(useCase domain.CustomerSnapshotUseCase) *CustomerSnapshotController {
+	return &CustomerSnapshotController{useCase: useCase}
+}
+
+type CustomerSnapshotController struct {
+	useCase domain.CustomerSnapshotUseCase
+}
+
+func (c *CustomerSnapshotController) Bind(e *echo.Echo) {
+	// ===== SUPER_ADMIN =====
+	e.GET("/customer/:userId/snapshot", c.exportCustomer,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.POST("/snapshot/customer", c.importCustomer,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type ExportCustomerSnapshotRequest struct {
+	UserId uuid.UUID `param:"userId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Mask 개인정보 가림 여부, 기본 true
+	Mask *bool `query:"mask" example:"true"`
+} // @name ExportCustomerSnapshotRequest
+
+// @Tags (Snapshot) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 고객 스냅샷 내보내기
+// @Description 고객 정보와 의뢰, 이용권 전체를 JSON 번들로 내려받음, 기본으로 개인정보를 가림, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Param mask query bool false "개인정보 가림 여부, 기본 true"
+// @Success 200 {object} domain.CustomerSnapshot "스냅샷 번들"
+// @Failure 404 {object} domain.ErrorResponse "고객 없음"
+// @Router /customer/{user_id}/snapshot [get]
+func (c *CustomerSnapshotController) exportCustomer(ctx echo.Context) error {
+	var req ExportCustomerSnapshotRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "export customer snapshot, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.ExportCustomer(ctx.Request().Context(), domain.ExportCustomerSnapshot{
+		UserId: req.UserId,
+		Mask:   req.Mask == nil || *req.Mask,
+	})
+
+	switch err {
+	case nil:
+		ctx.Response().Header().Set(echo.HeaderContentDisposition,
+			fmt.Sprintf(`attachment; filename="customer-%s.json"`, req.UserId))
+		return ctx.JSON(http.StatusOK, res)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", req.UserId).
+			Error(tag, "exportCustomer, unhandled error useCase.ExportCustomer")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ImportCustomerSnapshotResponse struct {
+	UserId uuid.UUID `json:"userId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// IdMap 번들의 식별 아이디 -> 새로 만든 식별 아이디
+	IdMap            map[string]uuid.UUID `json:"idMap" validate:"required" swaggertype:"object,string"`
+	DroppedAssignees int                  `json:"droppedAssignees" validate:"required" example:"1"`
+} // @name ImportCustomerSnapshotResponse
+
+// @Tags (Snapshot) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 고객 스냅샷 가져오기
+// @Description 내보낸 번들을 새 식별 아이디로 바꿔 저장, 이 환경에 없는 담당자는 배정 해제, 설정(snapshot.import_enabled)이 켜진 환경(스테이징)에서만 가능, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param username query string false "가져온 고객의 아이디, 비우면 자동 생성"
+// @Param requestBody body domain.CustomerSnapshot true "스냅샷 번들"
+// @Success 201 {object} ImportCustomerSnapshotResponse "가져오기 성공"
+// @Failure 400 {object} domain.ErrorResponse "지원하지 않는 번들 버전"
+// @Failure 403 {object} domain.ErrorResponse "가져오기가 꺼진 환경"
+// @Failure 409 {object} domain.ErrorResponse "이미 있는 아이디"
+// @Router /snapshot/customer [post]
+func (c *CustomerSnapshotController) importCustomer(ctx echo.Context) error {
+	var snapshot domain.CustomerSnapshot
+
+	err := ctx.Bind(&snapshot)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "import customer snapshot, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.ImportCustomer(ctx.Request().Context(), domain.ImportCustomerSnapshot{
+		Snapshot: snapshot,
+		Username: ctx.QueryParam("username"),
+	})
+
+	switch err {
+	case nil:
+		idMap := make(map[string]uuid.UUID, len(res.IdMap))
+		for from, to := range res.IdMap {
+			idMap[from.String()] = to
+		}
+		return ctx.JSON(http.StatusCreated, ImportCustomerSnapshotResponse{
+			UserId:           res.UserId,
+			IdMap:            idMap,
+			DroppedAssignees: res.DroppedAssignees,
+		})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ItemExist)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("sourceUserId", snapshot.User.Id).
+			Error(tag, "importCustomer, unhandled error useCase.ImportCustomer")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
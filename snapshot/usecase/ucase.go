@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+// NewCustomerSnapshotUseCase importEnabled 가 false 면 가져오기는 ErrNoPermission, 운영 환경에서는 끔
+func NewCustomerSnapshotUseCase(
+	userRepo domain.UserRepository,
+	customerRepo domain.CustomerRepository,
+	managerRepo domain.ManagerRepository,
+	orderRepo domain.OrderRepository,
+	orderTicketRepo domain.OrderTicketRepository,
+	importEnabled bool,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.CustomerSnapshotUseCase {
+	return &ucase{
+		userRepo:        userRepo,
+		customerRepo:    customerRepo,
+		managerRepo:     managerRepo,
+		orderRepo:       orderRepo,
+		orderTicketRepo: orderTicketRepo,
+		importEnabled:   importEnabled,
+		ids:             ids,
+		clock:           clock,
+		timeout:         timeout,
+	}
+}
+
+type ucase struct {
+	userRepo        domain.UserRepository
+	customerRepo    domain.CustomerRepository
+	managerRepo     domain.ManagerRepository
+	orderRepo       domain.OrderRepository
+	orderTicketRepo domain.OrderTicketRepository
+	importEnabled   bool
+	ids             domain.IdGenerator
+	clock           domain.Clock
+	timeout         time.Duration
+}
+
+func (u *ucase) ImportCustomer(ctx context.Context, in domain.ImportCustomerSnapshot) (res domain.CustomerSnapshotImportResult, err error) {
+	if !u.importEnabled {
+		err = domain.ErrNoPermission
+		return
+	}
+
+	snapshot := in.Snapshot
+	if snapshot.Version != domain.CustomerSnapshotVersion {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	idMap := make(map[uuid.UUID]uuid.UUID, 1+len(snapshot.Tickets)+len(snapshot.Orders))
+
+	user := domain.CreateUser(domain.UserCreateOption{
+		Id:       u.ids.NewId(),
+		Role:     domain.CustomerUserRole,
+		Username: in.Username,
+		Now:      u.clock.Now(),
+	})
+	if user.Username == "" {
+		user.Username = user.Id.String()[:8] + "." + snapshot.User.Username
+	}
+	// 로그인 불가한 임의 비밀번호, 필요하면 어드민이 재설정
+	user.UpdatePassword(uuid.NewString(), u.clock.Now())
+	idMap[snapshot.User.Id] = user.Id
+
+	exists, err := u.userRepo.GetByUsername(c, user.Username)
+	if err != nil {
+		return
+	}
+	if exists != nil {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	customer := snapshot.Customer
+	customer.Id = user.Id
+
+	tickets := make([]domain.OrderTicket, len(snapshot.Tickets))
+	for i, src := range snapshot.Tickets {
+		ticket := src
+		ticket.Id = u.ids.NewId()
+		ticket.OwnerId = user.Id
+		// 외부 주문 번호는 유니크라 원본과 겹치지 않도록 새 식별 아이디 사용
+		ticket.ExOrderId = "snapshot-" + ticket.Id.String()
+		idMap[src.Id] = ticket.Id
+		tickets[i] = ticket
+	}
+
+	managers, err := u.fetchManagerIds(c, snapshot.Orders)
+	if err != nil {
+		return
+	}
+
+	orders := make([]domain.Order, len(snapshot.Orders))
+	for i, src := range snapshot.Orders {
+		order := src
+		order.Id = u.ids.NewId()
+		order.Orderer = user.Id
+		if order.TicketId != nil {
+			if id, ok := idMap[*order.TicketId]; ok {
+				order.TicketId = &id
+			} else {
+				order.TicketId = nil
+			}
+		}
+		if order.Assignee != nil && !managers[*order.Assignee] {
+			order.Assignee = nil
+			res.DroppedAssignees++
+		}
+		idMap[src.Id] = order.Id
+		orders[i] = order
+	}
+
+	err = u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		cr := u.customerRepo.With(ur)
+		otr := u.orderTicketRepo.With(ur)
+		or := u.orderRepo.With(ur)
+
+		g, gc := errgroup.WithContext(c)
+		g.Go(func() error {
+			return ur.Save(gc, &user)
+		})
+		g.Go(func() error {
+			return cr.Save(gc, &customer)
+		})
+		err := g.Wait()
+		if err != nil {
+			return err
+		}
+
+		for i := range tickets {
+			err = otr.Save(c, &tickets[i])
+			if err != nil {
+				return err
+			}
+		}
+		for i := range orders {
+			err = or.Save(c, &orders[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	res.UserId = user.Id
+	res.IdMap = idMap
+	return
+}
+
+// fetchManagerIds 의뢰 담당자 중 이 환경에 있는 매니저
+func (u *ucase) fetchManagerIds(ctx context.Context, orders []domain.Order) (res map[uuid.UUID]bool, err error) {
+	var ids []uuid.UUID
+	for _, order := range orders {
+		if order.Assignee != nil {
+			ids = append(ids, *order.Assignee)
+		}
+	}
+
+	res = make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return
+	}
+
+	managers, err := u.managerRepo.FetchByIds(ctx, ids)
+	if err != nil {
+		return
+	}
+	for _, manager := range managers {
+		res[manager.Id] = true
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func (u *ucase) ExportCustomer(ctx context.Context, in domain.ExportCustomerSnapshot) (res domain.CustomerSnapshot, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByIdWithCustomer(c, in.UserId)
+	if err != nil {
+		return
+	}
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) || user.Customer == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	var (
+		tickets []domain.OrderTicket
+		orders  []domain.Order
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		tickets, err = u.orderTicketRepo.FetchByOwnerId(gc, user.Id)
+		return
+	})
+	g.Go(func() (err error) {
+		orders, err = u.orderRepo.FetchByOrdererId(gc, user.Id)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	res = domain.CustomerSnapshot{
+		Version:    domain.CustomerSnapshotVersion,
+		ExportedAt: u.clock.Now(),
+		User: domain.SnapshotUser{
+			Id:        user.Id,
+			Username:  user.Username,
+			CreatedAt: user.CreatedAt,
+		},
+		Customer: *user.Customer,
+		Tickets:  tickets,
+		Orders:   orders,
+	}
+	if in.Mask {
+		res.Mask()
+	}
+	return
+}
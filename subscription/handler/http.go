@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[SUBSCRIPTION] "
+)
+
+func NewSubscriptionController(useCase domain.SubscriptionUseCase) *SubscriptionController {
+	return &SubscriptionController{useCase: useCase}
+}
+
+type SubscriptionController struct {
+	useCase domain.SubscriptionUseCase
+}
+
+func (c *SubscriptionController) Bind(e *echo.Echo) {
+	// ===== SUPER ADMIN =====
+	e.POST("/subscription/plan", c.createPlan,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+
+	// ===== ADMIN =====
+	e.GET("/subscription/plan", c.fetchPlans,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/subscription", echox.UserID(c.attachPlan),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== CUSTOMER, ADMIN =====
+	e.GET("/user/customer/:userId/subscription", echox.UserID(c.getCustomerSubscription), middleware.RequireAuth())
+}
+
+type SubscriptionPlanResponse struct {
+	Id   uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name string    `json:"name" validate:"required" example:"스탠다드 3개월"`
+	// OrderCount, 편집 횟수 (맡길 수 있는 의뢰 수)
+	OrderCount uint8 `json:"orderCount" validate:"required" example:"8"`
+	// Months, 월 구독 기간
+	Months    uint8     `json:"months" validate:"required" example:"3"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name SubscriptionPlanResponse
+
+type CreateSubscriptionPlanRequest struct {
+	Name       string `json:"name" validate:"required,min=1,max=60" example:"스탠다드 3개월"`
+	OrderCount uint8  `json:"orderCount" validate:"required,max=30" example:"8"`
+	Months     uint8  `json:"months" validate:"required,max=36" example:"3"`
+} // @name CreateSubscriptionPlanRequest
+
+type CreateSubscriptionPlanResponse struct {
+	Id uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name CreateSubscriptionPlanResponse
+
+// @Tags (Subscription) 슈퍼 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼 어드민] 구독 상품 만들기
+// @Description 편집 횟수, 월 구독 기간으로 상품을 만듦, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body CreateSubscriptionPlanRequest true "구독 상품"
+// @Success 201 {object} CreateSubscriptionPlanResponse "성공"
+// @Failure 409 {object} domain.ErrorResponse "같은 이름의 상품이 있음"
+// @Router /subscription/plan [post]
+func (c *SubscriptionController) createPlan(ctx echo.Context) error {
+	var req CreateSubscriptionPlanRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "create plan, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	newId, err := c.useCase.CreatePlan(ctx.Request().Context(), domain.CreateSubscriptionPlanInput{
+		Name:       req.Name,
+		OrderCount: req.OrderCount,
+		Months:     req.Months,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, CreateSubscriptionPlanResponse{Id: newId})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "createPlan, unhandled error useCase.CreatePlan")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Subscription) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 구독 상품 목록
+// @Description 만든 순, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} SubscriptionPlanResponse "성공"
+// @Success 204 "상품 없음"
+// @Router /subscription/plan [get]
+func (c *SubscriptionController) fetchPlans(ctx echo.Context) error {
+	list, err := c.useCase.FetchPlans(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchPlans, unhandled error useCase.FetchPlans")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]SubscriptionPlanResponse, len(list))
+	for i, src := range list {
+		res[i] = SubscriptionPlanResponse{
+			Id:         src.Id,
+			Name:       src.Name,
+			OrderCount: src.OrderCount,
+			Months:     src.Months,
+			CreatedAt:  src.CreatedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type AttachSubscriptionPlanRequest struct {
+	CustomerId uuid.UUID `json:"customerId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PlanId     uuid.UUID `json:"planId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name AttachSubscriptionPlanRequest
+
+type AttachSubscriptionPlanResponse struct {
+	// TicketId, 만든 이용권
+	TicketId uuid.UUID `json:"ticketId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name AttachSubscriptionPlanResponse
+
+// @Tags (Subscription) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객에게 구독 상품 붙이기
+// @Description 상품 내용대로 이용권을 만듦, 이용 중인 이용권이 있으면 끝나는 때부터 시작, 감사 로그(SUBSCRIPTION_ATTACHED)에 기록
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body AttachSubscriptionPlanRequest true "고객, 상품"
+// @Success 201 {object} AttachSubscriptionPlanResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 고객, 상품"
+// @Failure 422 {object} domain.ErrorResponse "계약서 서명 전인 기업 고객"
+// @Router /subscription [post]
+func (c *SubscriptionController) attachPlan(ctx echo.Context, userId uuid.UUID) error {
+	var req AttachSubscriptionPlanRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "attach plan, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	in := domain.AttachSubscriptionPlan{
+		CustomerId: req.CustomerId,
+		PlanId:     req.PlanId,
+		AttachedBy: userId,
+		Ip:         ctx.RealIP(),
+	}
+	ticketId, err := c.useCase.AttachPlan(ctx.Request().Context(), in)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, AttachSubscriptionPlanResponse{TicketId: ticketId})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrContractNotSigned:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.ContractNotSignedResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("in", in).
+			Error(tag, "attachPlan, unhandled error useCase.AttachPlan")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type CustomerSubscriptionResponse struct {
+	TicketId uuid.UUID `json:"ticketId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// PlanId, 관리자가 구독 상품으로 붙인 이용권만, 결제로 만든 이용권은 null
+	PlanId   *uuid.UUID `json:"planId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PlanName *string    `json:"planName" example:"스탠다드 3개월"`
+	StartAt  *time.Time `json:"startAt" example:"2021-10-27T04:44:18+00:00"`
+	EndAt    *time.Time `json:"endAt" example:"2022-01-27T04:44:18+00:00"`
+
+	// TotalEditCount, 편집 횟수 (맡길 수 있는 의뢰 수)
+	TotalEditCount uint8 `json:"totalEditCount" validate:"required" example:"8"`
+	// DoneEditCount, 완료된 의뢰 수
+	DoneEditCount uint8 `json:"doneEditCount" validate:"required" example:"3"`
+	// RemainingEditCount, 남은 편집 횟수, 의뢰가 완료될 때 줄어듦
+	RemainingEditCount uint8 `json:"remainingEditCount" validate:"required" example:"5"`
+	// RequestableEditCount, 지금 더 맡길 수 있는 의뢰 수, 진행 중인 의뢰도 뺌
+	RequestableEditCount uint8 `json:"requestableEditCount" validate:"required" example:"4"`
+} // @name CustomerSubscriptionResponse
+
+// @Tags (Subscription) 고객, 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary 고객 구독 현황
+// @Description 지금 이용 중인 이용권의 편집 횟수, 대시보드용, 고객 본인 또는 'ADMIN', 'SUPER_ADMIN' 만
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Success 200 {object} CustomerSubscriptionResponse "성공"
+// @Success 204 "이용 중인 이용권 없음"
+// @Failure 403 {object} domain.ErrorResponse "다른 고객"
+// @Router /user/customer/{user_id}/subscription [get]
+func (c *SubscriptionController) getCustomerSubscription(ctx echo.Context, userId uuid.UUID) error {
+	var req struct {
+		CustomerId uuid.UUID `param:"userId"`
+	}
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get customer subscription, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	info, err := c.useCase.GetCustomerSubscription(ctx.Request().Context(), domain.CustomerSubscriptionAccess{
+		CustomerId:  req.CustomerId,
+		RequesterId: userId,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrNoPermission:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "getCustomerSubscription, unhandled error useCase.GetCustomerSubscription")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if info == nil {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	return ctx.JSON(http.StatusOK, CustomerSubscriptionResponse{
+		TicketId:             info.TicketId,
+		PlanId:               info.PlanId,
+		PlanName:             info.PlanName,
+		StartAt:              info.StartAt,
+		EndAt:                info.EndAt,
+		TotalEditCount:       info.TotalEditCount,
+		DoneEditCount:        info.DoneEditCount,
+		RemainingEditCount:   info.RemainingEditCount,
+		RequestableEditCount: info.RequestableEditCount,
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewSubscriptionPlanRepository(db *gorm.DB) domain.SubscriptionPlanRepository {
+	db.AutoMigrate(&domain.SubscriptionPlan{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, plan *domain.SubscriptionPlan) error {
+	return gormx.Upsert(ctx, r.db, plan)
+}
+
+func (r *repo) GetById(ctx context.Context, planId uuid.UUID) (plan *domain.SubscriptionPlan, err error) {
+	var entity domain.SubscriptionPlan
+	err = r.db.WithContext(ctx).First(&entity, planId).Error
+	if err == nil {
+		plan = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) GetByName(ctx context.Context, name string) (plan *domain.SubscriptionPlan, err error) {
+	var entity domain.SubscriptionPlan
+	err = r.db.WithContext(ctx).
+		Where("`name` = ?", name).
+		First(&entity).Error
+	if err == nil {
+		plan = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchAll(ctx context.Context) (list []domain.SubscriptionPlan, err error) {
+	err = r.db.WithContext(ctx).
+		Order("`created_at` asc").
+		Find(&list).Error
+	return
+}
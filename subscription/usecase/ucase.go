@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const tag = "[SUBSCRIPTION] "
+
+func NewSubscriptionUseCase(
+	planRepo domain.SubscriptionPlanRepository,
+	orderTicketRepo domain.OrderTicketRepository,
+	userRepo domain.UserRepository,
+	settingReader domain.SettingReader,
+	calendar domain.Calendar,
+	contractGate domain.ContractGate,
+	auditLogger domain.AuditLogger,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.SubscriptionUseCase {
+	return &ucase{
+		planRepo:        planRepo,
+		orderTicketRepo: orderTicketRepo,
+		userRepo:        userRepo,
+		settingReader:   settingReader,
+		calendar:        calendar,
+		contractGate:    contractGate,
+		auditLogger:     auditLogger,
+		ids:             ids,
+		clock:           clock,
+		timeout:         timeout,
+	}
+}
+
+type ucase struct {
+	planRepo        domain.SubscriptionPlanRepository
+	orderTicketRepo domain.OrderTicketRepository
+	userRepo        domain.UserRepository
+	settingReader   domain.SettingReader
+	calendar        domain.Calendar
+	contractGate    domain.ContractGate
+	auditLogger     domain.AuditLogger
+	ids             domain.IdGenerator
+	clock           domain.Clock
+	timeout         time.Duration
+}
+
+func (u *ucase) CreatePlan(ctx context.Context, in domain.CreateSubscriptionPlanInput) (newId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	exists, err := u.planRepo.GetByName(c, in.Name)
+	if err != nil {
+		return
+	}
+
+	if exists != nil {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	plan := domain.CreateSubscriptionPlan(domain.CreateSubscriptionPlanOption{
+		Id:         u.ids.NewId(),
+		Name:       in.Name,
+		OrderCount: in.OrderCount,
+		Months:     in.Months,
+		Now:        u.clock.Now(),
+	})
+	err = u.planRepo.Save(c, &plan)
+	if err != nil {
+		return
+	}
+
+	newId = plan.Id
+	return
+}
+
+func (u *ucase) FetchPlans(ctx context.Context) (list []domain.SubscriptionPlan, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	return u.planRepo.FetchAll(c)
+}
+
+func (u *ucase) AttachPlan(ctx context.Context, in domain.AttachSubscriptionPlan) (ticketId uuid.UUID, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		plan      *domain.SubscriptionPlan
+		end       *domain.OrderTicket
+		editCount uint8
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		customer, err := u.userRepo.GetById(gc, in.CustomerId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(customer, domain.User.IsCustomer) {
+			err = domain.ErrItemNotFound
+			return
+		}
+
+		// 기업 고객은 계약서 서명 전까지 구독권을 만들지 않음
+		return u.contractGate.RequireSigned(gc, in.CustomerId)
+	})
+	g.Go(func() (err error) {
+		plan, err = u.planRepo.GetById(gc, in.PlanId)
+		if err == nil && plan == nil {
+			err = domain.ErrItemNotFound
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		end, err = u.orderTicketRepo.GetEndByOwnerId(gc, in.CustomerId)
+		return
+	})
+	g.Go(func() (err error) {
+		limit, err := u.settingReader.Int(gc, domain.SettingKeyOrderRevisionLimit)
+		if err == nil && limit > 0 && limit <= math.MaxUint8 {
+			editCount = uint8(limit)
+		}
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	// 이용 기간은 서버 TZ 와 무관하게 KST 기준 월로 계산, 결제로 만든 이용권과 같음
+	startAt := u.calendar.Now()
+	if end != nil && end.EndAt != nil && end.EndAt.After(startAt) {
+		startAt = end.EndAt.In(u.calendar.Location())
+	}
+	endAt := u.calendar.AddMonths(startAt, int(plan.Months))
+
+	ticket := domain.CreateOrderTicket(domain.CreateOrderTicketOption{
+		Id:              u.ids.NewId(),
+		OwnerId:         in.CustomerId,
+		TotalOrderCount: plan.OrderCount,
+		EditCount:       editCount,
+		StartAt:         &startAt,
+		EndAt:           &endAt,
+		PlanId:          &plan.Id,
+		PlanName:        &plan.Name,
+		Now:             u.clock.Now(),
+	})
+	// 결제 주문 번호가 없어 이용권 아이디로 대신함
+	ticket.ExOrderId = "subscription:" + ticket.Id.String()
+
+	err = u.orderTicketRepo.Save(c, &ticket)
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.AttachedBy,
+		TargetId: in.CustomerId,
+		Action:   domain.AuditActionSubscriptionAttached,
+		Ip:       in.Ip,
+	})
+
+	ticketId = ticket.Id
+	return
+}
+
+func (u *ucase) GetCustomerSubscription(ctx context.Context, in domain.CustomerSubscriptionAccess) (res *domain.CustomerSubscription, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var ticket *domain.OrderTicket
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		if in.RequesterId == in.CustomerId {
+			return
+		}
+
+		requester, err := u.userRepo.GetById(gc, in.RequesterId)
+		if err != nil {
+			return
+		}
+
+		if !domain.CheckUserAlive(requester,
+			domain.User.IsAdmin,
+			domain.User.IsSuperAdmin) {
+			err = domain.ErrNoPermission
+		}
+		return
+	})
+	g.Go(func() (err error) {
+		ticket, err = u.orderTicketRepo.GetByOwnerIdBetweenStartAndEnd(gc, in.CustomerId, u.calendar.Now())
+		return
+	})
+	err = g.Wait()
+	if err != nil || ticket == nil {
+		return
+	}
+
+	res = &domain.CustomerSubscription{
+		TicketId:             ticket.Id,
+		PlanId:               ticket.PlanId,
+		PlanName:             ticket.PlanName,
+		StartAt:              ticket.StartAt,
+		EndAt:                ticket.EndAt,
+		TotalEditCount:       ticket.TotalOrderCount,
+		DoneEditCount:        ticket.DoneOrderCount,
+		RemainingEditCount:   ticket.RemainingEditCount(),
+		RequestableEditCount: ticket.RemainingOrderCount(),
+	}
+	return
+}
@@ -0,0 +1,410 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[TASK] "
+)
+
+func NewTaskController(useCase domain.TaskUseCase) *TaskController {
+	return &TaskController{useCase: useCase}
+}
+
+type TaskController struct {
+	useCase domain.TaskUseCase
+}
+
+func (c *TaskController) Bind(e *echo.Echo) {
+	// ===== ADMIN =====
+	e.POST("/task", echox.UserID(c.registerTask),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/task", echox.UserID(c.fetchTasks),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/task/:taskId", c.getTask,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.PUT("/task/:taskId", c.updateTask,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/task/:taskId/done", echox.UserID(c.doneTask),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.POST("/task/:taskId/reopen", c.reopenTask,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.DELETE("/task/:taskId", c.deleteTask,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== INTERNAL =====
+	// 스케줄러가 주기적으로 호출
+	e.POST("/internal/task/remind", c.internalRemindTasks)
+}
+
+type TaskResponse struct {
+	Id    uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title string    `json:"title" validate:"required" example:"세금계산서 재발행 확인 전화"`
+	Memo  string    `json:"memo" validate:"required" example:"담당자 부재, 오후에 다시"`
+	DueAt time.Time `json:"dueAt" validate:"required" example:"2024-05-03T15:00:00+09:00"`
+	// AssigneeId, 담당 관리자
+	AssigneeId uuid.UUID  `json:"assigneeId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CustomerId *uuid.UUID `json:"customerId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderId    *uuid.UUID `json:"orderId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedBy  uuid.UUID  `json:"createdBy" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt  time.Time  `json:"createdAt" validate:"required" example:"2024-05-01T10:00:00+09:00"`
+	DoneAt     *time.Time `json:"doneAt" example:"2024-05-03T14:20:00+09:00"`
+	DoneBy     *uuid.UUID `json:"doneBy" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Overdue, 마감이 지났는데 열려 있음
+	Overdue bool `json:"overdue" validate:"required" example:"false"`
+} // @name TaskResponse
+
+func responseOf(src domain.TaskInfo) TaskResponse {
+	return TaskResponse{
+		Id:         src.Id,
+		Title:      src.Title,
+		Memo:       src.Memo,
+		DueAt:      src.DueAt,
+		AssigneeId: src.AssigneeId,
+		CustomerId: src.CustomerId,
+		OrderId:    src.OrderId,
+		CreatedBy:  src.CreatedBy,
+		CreatedAt:  src.CreatedAt,
+		DoneAt:     src.DoneAt,
+		DoneBy:     src.DoneBy,
+		Overdue:    src.Overdue,
+	}
+}
+
+type RegisterTaskRequest struct {
+	Title string    `json:"title" validate:"required,max=200" example:"세금계산서 재발행 확인 전화"`
+	Memo  string    `json:"memo" validate:"max=2000" example:"담당자 부재, 오후에 다시"`
+	DueAt time.Time `json:"dueAt" validate:"required" example:"2024-05-03T15:00:00+09:00"`
+	// AssigneeId, 비어있으면 본인
+	AssigneeId *uuid.UUID `json:"assigneeId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CustomerId *uuid.UUID `json:"customerId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderId    *uuid.UUID `json:"orderId" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name RegisterTaskRequest
+
+// @Tags (Task) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 할 일 등록
+// @Description 고객, 의뢰에 연결 가능, 마감 1시간 전에 담당자에게 알림, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body RegisterTaskRequest true "할 일"
+// @Success 201 {object} TaskResponse "등록"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Failure 404 {object} domain.ErrorResponse "없는 담당자, 고객, 의뢰"
+// @Router /task [post]
+func (c *TaskController) registerTask(ctx echo.Context, userId uuid.UUID) error {
+	var req RegisterTaskRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "register task, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	task, err := c.useCase.RegisterTask(ctx.Request().Context(), domain.RegisterTask{
+		Title:      req.Title,
+		Memo:       req.Memo,
+		DueAt:      req.DueAt,
+		AssigneeId: req.AssigneeId,
+		CustomerId: req.CustomerId,
+		OrderId:    req.OrderId,
+		CreatedBy:  userId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusCreated, responseOf(task))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "registerTask, unhandled error useCase.RegisterTask")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type FetchTasksRequest struct {
+	// Mine, true 면 내가 담당한 일만, assigneeId 무시
+	Mine       bool       `query:"mine"`
+	AssigneeId *uuid.UUID `query:"assigneeId"`
+	CustomerId *uuid.UUID `query:"customerId"`
+	OrderId    *uuid.UUID `query:"orderId"`
+	Status     string     `query:"status" validate:"omitempty,oneof=OPEN DONE ALL"`
+	// Overdue, true 면 마감이 지난 열린 일만, status 무시
+	Overdue bool `query:"overdue"`
+} // @name FetchTasksRequest
+
+// @Tags (Task) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 할 일 목록
+// @Description 마감이 빠른 순, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param mine query bool false "내가 담당한 일만"
+// @Param assigneeId query string false "담당 관리자 아이디(UUID)"
+// @Param customerId query string false "연결한 고객 아이디(UUID)"
+// @Param orderId query string false "연결한 의뢰 아이디(UUID)"
+// @Param status query string false "상태 (기본 OPEN)" Enums(OPEN, DONE, ALL)
+// @Param overdue query bool false "마감이 지난 열린 일만"
+// @Success 200 {array} TaskResponse "성공"
+// @Success 204 "없음"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Router /task [get]
+func (c *TaskController) fetchTasks(ctx echo.Context, userId uuid.UUID) error {
+	var req FetchTasksRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch tasks, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	assigneeId := req.AssigneeId
+	if req.Mine {
+		assigneeId = &userId
+	}
+
+	status := domain.TaskStatusFilter(req.Status)
+	if status == "" {
+		status = domain.TaskStatusFilterOpen
+	}
+
+	list, err := c.useCase.FetchTasks(ctx.Request().Context(), domain.FetchTasks{
+		AssigneeId: assigneeId,
+		CustomerId: req.CustomerId,
+		OrderId:    req.OrderId,
+		Status:     status,
+		Overdue:    req.Overdue,
+	})
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchTasks, unhandled error useCase.FetchTasks")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]TaskResponse, len(list))
+	for i := range list {
+		res[i] = responseOf(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type taskIdRequest struct {
+	TaskId uuid.UUID `param:"taskId"`
+}
+
+// @Tags (Task) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 할 일 조회
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param task_id path string true "할 일 아이디(UUID)"
+// @Success 200 {object} TaskResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 할 일"
+// @Router /task/{task_id} [get]
+func (c *TaskController) getTask(ctx echo.Context) error {
+	var req taskIdRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get task, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	task, err := c.useCase.GetTask(ctx.Request().Context(), req.TaskId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, responseOf(task))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "getTask, unhandled error useCase.GetTask")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateTaskRequest struct {
+	TaskId uuid.UUID `json:"-" param:"taskId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	Title string    `json:"title" validate:"required,max=200" example:"세금계산서 재발행 확인 전화"`
+	Memo  string    `json:"memo" validate:"max=2000" example:"담당자 부재, 오후에 다시"`
+	DueAt time.Time `json:"dueAt" validate:"required" example:"2024-05-03T15:00:00+09:00"`
+	// AssigneeId, 마감이나 담당자가 바뀌면 알림을 다시 보냄
+	AssigneeId uuid.UUID `json:"assigneeId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name UpdateTaskRequest
+
+// @Tags (Task) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 할 일 수정
+// @Description 연결한 고객, 의뢰는 바꿀 수 없음, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param task_id path string true "할 일 아이디(UUID)"
+// @Param requestBody body UpdateTaskRequest true "할 일"
+// @Success 200 {object} TaskResponse "수정"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Failure 404 {object} domain.ErrorResponse "없는 할 일, 담당자"
+// @Router /task/{task_id} [put]
+func (c *TaskController) updateTask(ctx echo.Context) error {
+	var req UpdateTaskRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update task, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	task, err := c.useCase.UpdateTask(ctx.Request().Context(), domain.UpdateTask{
+		TaskId:     req.TaskId,
+		Title:      req.Title,
+		Memo:       req.Memo,
+		DueAt:      req.DueAt,
+		AssigneeId: req.AssigneeId,
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, responseOf(task))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "updateTask, unhandled error useCase.UpdateTask")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Task) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 할 일 완료
+// @Description 이미 완료면 그대로, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param task_id path string true "할 일 아이디(UUID)"
+// @Success 200 {object} TaskResponse "완료"
+// @Failure 404 {object} domain.ErrorResponse "없는 할 일"
+// @Router /task/{task_id}/done [post]
+func (c *TaskController) doneTask(ctx echo.Context, userId uuid.UUID) error {
+	var req taskIdRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "done task, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	task, err := c.useCase.DoneTask(ctx.Request().Context(), req.TaskId, userId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, responseOf(task))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "doneTask, unhandled error useCase.DoneTask")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Task) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 할 일 다시 열기
+// @Description 완료를 취소, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param task_id path string true "할 일 아이디(UUID)"
+// @Success 200 {object} TaskResponse "다시 열림"
+// @Failure 404 {object} domain.ErrorResponse "없는 할 일"
+// @Router /task/{task_id}/reopen [post]
+func (c *TaskController) reopenTask(ctx echo.Context) error {
+	var req taskIdRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "reopen task, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	task, err := c.useCase.ReopenTask(ctx.Request().Context(), req.TaskId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, responseOf(task))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "reopenTask, unhandled error useCase.ReopenTask")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Task) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 할 일 삭제
+// @Description 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param task_id path string true "할 일 아이디(UUID)"
+// @Success 204 "삭제"
+// @Failure 404 {object} domain.ErrorResponse "없는 할 일"
+// @Router /task/{task_id} [delete]
+func (c *TaskController) deleteTask(ctx echo.Context) error {
+	var req taskIdRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "delete task, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.DeleteTask(ctx.Request().Context(), req.TaskId)
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "deleteTask, unhandled error useCase.DeleteTask")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+func (c *TaskController) internalRemindTasks(ctx echo.Context) error {
+	count, err := c.useCase.RemindTasks(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("reminded", count).
+			Error(tag, "internalRemindTasks, unhandled error useCase.RemindTasks")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, echo.Map{
+		"reminded": count,
+	})
+}
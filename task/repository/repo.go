@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewTaskRepository(db *gorm.DB) domain.TaskRepository {
+	db.AutoMigrate(&domain.Task{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Get() *gorm.DB {
+	return r.db
+}
+
+func (r *repo) With(tx gormx.Tx) domain.TaskTxRepository {
+	return &repo{db: tx.Get()}
+}
+
+func (r *repo) Transaction(ctx context.Context, fn func(taskRepo domain.TaskTxRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repo{db: tx})
+	})
+}
+
+func (r *repo) Save(ctx context.Context, task *domain.Task) error {
+	return gormx.Upsert(ctx, r.db, task)
+}
+
+func (r *repo) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.Task{}, id).Error
+}
+
+func (r *repo) GetById(ctx context.Context, id uuid.UUID) (task *domain.Task, err error) {
+	var entity domain.Task
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		task = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) Fetch(ctx context.Context, option domain.FetchTaskOption) (list []domain.Task, err error) {
+	query := r.db.WithContext(ctx)
+	if option.AssigneeId != nil {
+		query = query.Where("`assignee_id` = ?", *option.AssigneeId)
+	}
+	if option.CustomerId != nil {
+		query = query.Where("`customer_id` = ?", *option.CustomerId)
+	}
+	if option.OrderId != nil {
+		query = query.Where("`order_id` = ?", *option.OrderId)
+	}
+
+	switch {
+	case option.OverdueAt != nil:
+		query = query.Where("`done_at` IS NULL AND `due_at` < ?", *option.OverdueAt)
+	case option.Status == domain.TaskStatusFilterOpen:
+		query = query.Where("`done_at` IS NULL")
+	case option.Status == domain.TaskStatusFilterDone:
+		query = query.Where("`done_at` IS NOT NULL")
+	}
+
+	err = query.
+		Order("`due_at`").
+		Order("`id`").
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchToRemind(ctx context.Context, until time.Time, limit int) (list []domain.Task, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`done_at` IS NULL AND `reminded_at` IS NULL AND `due_at` < ?", until).
+		Order("`due_at`").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewTaskUseCase(
+	taskRepo domain.TaskRepository,
+	userRepo domain.UserRepository,
+	orderRepo domain.OrderRepository,
+	outboxRepo domain.OutboxRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.TaskUseCase {
+	return &ucase{
+		taskRepo:   taskRepo,
+		userRepo:   userRepo,
+		orderRepo:  orderRepo,
+		outboxRepo: outboxRepo,
+		ids:        ids,
+		clock:      clock,
+		timeout:    timeout,
+	}
+}
+
+type ucase struct {
+	taskRepo   domain.TaskRepository
+	userRepo   domain.UserRepository
+	orderRepo  domain.OrderRepository
+	outboxRepo domain.OutboxRepository
+	ids        domain.IdGenerator
+	clock      domain.Clock
+	timeout    time.Duration
+}
+
+func (u *ucase) toInfo(src domain.Task) domain.TaskInfo {
+	return domain.TaskInfo{
+		Id:         src.Id,
+		Title:      src.Title,
+		Memo:       src.Memo,
+		DueAt:      src.DueAt,
+		AssigneeId: src.AssigneeId,
+		CustomerId: src.CustomerId,
+		OrderId:    src.OrderId,
+		CreatedBy:  src.CreatedBy,
+		CreatedAt:  src.CreatedAt,
+		DoneAt:     src.DoneAt,
+		DoneBy:     src.DoneBy,
+		Overdue:    src.IsOverdue(u.clock.Now()),
+	}
+}
+
+func (u *ucase) RegisterTask(ctx context.Context, in domain.RegisterTask) (res domain.TaskInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	assigneeId := in.CreatedBy
+	if in.AssigneeId != nil {
+		assigneeId = *in.AssigneeId
+	}
+
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() error {
+		return u.checkAssignee(gc, assigneeId)
+	})
+	g.Go(func() error {
+		return u.checkCustomer(gc, in.CustomerId)
+	})
+	g.Go(func() error {
+		return u.checkOrder(gc, in.OrderId)
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	task := domain.CreateTask(domain.CreateTaskOption{
+		Id:         u.ids.NewId(),
+		Title:      in.Title,
+		Memo:       in.Memo,
+		DueAt:      in.DueAt,
+		AssigneeId: assigneeId,
+		CustomerId: in.CustomerId,
+		OrderId:    in.OrderId,
+		CreatedBy:  in.CreatedBy,
+		Now:        u.clock.Now(),
+	})
+	err = u.taskRepo.Save(c, &task)
+	if err != nil {
+		return
+	}
+
+	res = u.toInfo(task)
+	return
+}
+
+func (u *ucase) UpdateTask(ctx context.Context, in domain.UpdateTask) (res domain.TaskInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	task, err := u.getTask(c, in.TaskId)
+	if err != nil {
+		return
+	}
+
+	if task.AssigneeId != in.AssigneeId {
+		err = u.checkAssignee(c, in.AssigneeId)
+		if err != nil {
+			return
+		}
+	}
+
+	task.Update(domain.UpdateTaskOption{
+		Title:      in.Title,
+		Memo:       in.Memo,
+		DueAt:      in.DueAt,
+		AssigneeId: in.AssigneeId,
+		Now:        u.clock.Now(),
+	})
+	err = u.taskRepo.Save(c, task)
+	if err != nil {
+		return
+	}
+
+	res = u.toInfo(*task)
+	return
+}
+
+func (u *ucase) DoneTask(ctx context.Context, taskId, doneBy uuid.UUID) (res domain.TaskInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	task, err := u.getTask(c, taskId)
+	if err != nil {
+		return
+	}
+
+	if !task.IsDone() {
+		task.Done(doneBy, u.clock.Now())
+		err = u.taskRepo.Save(c, task)
+		if err != nil {
+			return
+		}
+	}
+
+	res = u.toInfo(*task)
+	return
+}
+
+func (u *ucase) ReopenTask(ctx context.Context, taskId uuid.UUID) (res domain.TaskInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	task, err := u.getTask(c, taskId)
+	if err != nil {
+		return
+	}
+
+	if task.IsDone() {
+		task.Reopen(u.clock.Now())
+		err = u.taskRepo.Save(c, task)
+		if err != nil {
+			return
+		}
+	}
+
+	res = u.toInfo(*task)
+	return
+}
+
+func (u *ucase) DeleteTask(ctx context.Context, taskId uuid.UUID) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	_, err = u.getTask(c, taskId)
+	if err != nil {
+		return
+	}
+
+	return u.taskRepo.Delete(c, taskId)
+}
+
+// RemindTasks 일마다 알림 표시와 이벤트를 같은 트랜잭션으로 저장, 실패하면 그때까지 보낸 수와 함께 반환
+func (u *ucase) RemindTasks(ctx context.Context) (count int, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	now := u.clock.Now()
+	list, err := u.taskRepo.FetchToRemind(c, now.Add(domain.TaskRemindBefore), domain.TaskRemindBatchSize)
+	if err != nil {
+		return
+	}
+
+	for i := range list {
+		task := list[i]
+		task.Remind(now)
+
+		var event domain.OutboxEvent
+		event, err = domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+			Id:            u.ids.NewId(),
+			AggregateType: domain.OutboxAggregateTypeTask,
+			AggregateId:   task.Id,
+			EventType:     domain.OutboxEventTypeTaskReminderDue,
+			Data: domain.TaskReminderDueEvent{
+				TaskId:     task.Id,
+				AssigneeId: task.AssigneeId,
+				Title:      task.Title,
+				DueAt:      task.DueAt,
+				CustomerId: task.CustomerId,
+				OrderId:    task.OrderId,
+			},
+			Now: now,
+		})
+		if err != nil {
+			return
+		}
+
+		err = u.taskRepo.Transaction(c, func(taskRepo domain.TaskTxRepository) error {
+			err := taskRepo.Save(c, &task)
+			if err != nil {
+				return err
+			}
+			return u.outboxRepo.With(taskRepo).Save(c, &event)
+		})
+		if err != nil {
+			return
+		}
+		count++
+	}
+	return
+}
+
+func (u *ucase) getTask(ctx context.Context, taskId uuid.UUID) (task *domain.Task, err error) {
+	task, err = u.taskRepo.GetById(ctx, taskId)
+	if err == nil && task == nil {
+		err = domain.ErrItemNotFound
+	}
+	return
+}
+
+// checkAssignee 살아있는 관리자만 담당 가능
+func (u *ucase) checkAssignee(ctx context.Context, assigneeId uuid.UUID) error {
+	user, err := u.userRepo.GetById(ctx, assigneeId)
+	if err != nil {
+		return err
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsAdmin, domain.User.IsSuperAdmin) {
+		return domain.ErrItemNotFound
+	}
+	return nil
+}
+
+func (u *ucase) checkCustomer(ctx context.Context, customerId *uuid.UUID) error {
+	if customerId == nil {
+		return nil
+	}
+
+	user, err := u.userRepo.GetById(ctx, *customerId)
+	if err != nil {
+		return err
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		return domain.ErrItemNotFound
+	}
+	return nil
+}
+
+func (u *ucase) checkOrder(ctx context.Context, orderId *uuid.UUID) error {
+	if orderId == nil {
+		return nil
+	}
+
+	order, err := u.orderRepo.GetById(ctx, *orderId)
+	if err != nil {
+		return err
+	}
+
+	if order == nil {
+		return domain.ErrItemNotFound
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) GetTask(ctx context.Context, taskId uuid.UUID) (res domain.TaskInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	task, err := u.getTask(c, taskId)
+	if err != nil {
+		return
+	}
+
+	res = u.toInfo(*task)
+	return
+}
+
+func (u *ucase) FetchTasks(ctx context.Context, in domain.FetchTasks) (res []domain.TaskInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	option := domain.FetchTaskOption{
+		AssigneeId: in.AssigneeId,
+		CustomerId: in.CustomerId,
+		OrderId:    in.OrderId,
+		Status:     in.Status,
+	}
+	if in.Overdue {
+		now := u.clock.Now()
+		option.OverdueAt = &now
+	}
+
+	list, err := u.taskRepo.Fetch(c, option)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.TaskInfo, len(list))
+	for i := range list {
+		res[i] = u.toInfo(list[i])
+	}
+	return
+}
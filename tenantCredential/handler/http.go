@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[TENANT_CREDENTIAL] "
+)
+
+func NewTenantCredentialController(useCase domain.TenantCredentialUseCase) *TenantCredentialController {
+	return &TenantCredentialController{useCase: useCase}
+}
+
+type TenantCredentialController struct {
+	useCase domain.TenantCredentialUseCase
+}
+
+func (c *TenantCredentialController) Bind(e *echo.Echo) {
+	// ===== SUPER_ADMIN =====
+	e.GET("/tenant/:tenantKey/credential", c.fetchTenantCredentials,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.PUT("/tenant/:tenantKey/credential/:provider", echox.UserID(c.setTenantCredential),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.DELETE("/tenant/:tenantKey/credential/:provider", echox.UserID(c.deleteTenantCredential),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+}
+
+type TenantCredentialResponse struct {
+	Provider  string    `json:"provider" validate:"required" example:"PG" enums:"PG,ALIMTALK"`
+	Fields    []string  `json:"fields" validate:"required" example:"merchantId,secretKey"`
+	UpdatedBy uuid.UUID `json:"updatedBy" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UpdatedAt time.Time `json:"updatedAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name TenantCredentialResponse
+
+// @Tags (TenantCredential) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 테넌트 연동 자격 증명 목록
+// @Description 저장된 연동과 항목 이름만 반환, 값은 반환하지 않음, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param tenantKey path string true "테넌트 키"
+// @Success 200 {array} TenantCredentialResponse "성공"
+// @Success 204 "저장된 자격 증명 없음"
+// @Router /tenant/{tenantKey}/credential [get]
+func (c *TenantCredentialController) fetchTenantCredentials(ctx echo.Context) error {
+	list, err := c.useCase.FetchTenantCredentials(ctx.Request().Context(), ctx.Param("tenantKey"))
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchTenantCredentials, unhandled error useCase.FetchTenantCredentials")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]TenantCredentialResponse, len(list))
+	for i, src := range list {
+		res[i] = TenantCredentialResponse{
+			Provider:  string(src.Provider),
+			Fields:    src.Fields,
+			UpdatedBy: src.UpdatedBy,
+			UpdatedAt: src.UpdatedAt,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type SetTenantCredentialRequest struct {
+	TenantKey string `param:"tenantKey" json:"-" validate:"required" example:"agency-a"`
+	Provider  string `param:"provider" json:"-" validate:"required" example:"PG"`
+
+	// Values 연동별 필수 항목, PG: merchantId, secretKey / ALIMTALK: senderKey, apiKey, profileId
+	Values map[string]string `json:"values" validate:"required"`
+} // @name SetTenantCredentialRequest
+
+// @Tags (TenantCredential) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 테넌트 연동 자격 증명 저장
+// @Description 화이트라벨 대행사의 PG 키, 알림톡 발신 프로필을 암호화해서 저장, 기존 값은 교체, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param tenantKey path string true "테넌트 키 (영문 소문자, 숫자, -)"
+// @Param provider path string true "연동" Enums(PG, ALIMTALK)
+// @Param requestBody body SetTenantCredentialRequest true "자격 증명 값"
+// @Success 204 "저장 성공"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 테넌트 키, 연동 또는 항목"
+// @Router /tenant/{tenantKey}/credential/{provider} [put]
+func (c *TenantCredentialController) setTenantCredential(ctx echo.Context, userId uuid.UUID) error {
+	var req SetTenantCredentialRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "set tenant credential, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.SetTenantCredential(ctx.Request().Context(), domain.SetTenantCredential{
+		TenantKey: req.TenantKey,
+		Provider:  domain.CredentialProvider(strings.ToUpper(req.Provider)),
+		Values:    req.Values,
+		UpdatedBy: userId,
+		Ip:        ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "setTenantCredential, unhandled error useCase.SetTenantCredential")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (TenantCredential) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 테넌트 연동 자격 증명 삭제
+// @Description 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param tenantKey path string true "테넌트 키"
+// @Param provider path string true "연동" Enums(PG, ALIMTALK)
+// @Success 204 "삭제 성공"
+// @Failure 404 {object} domain.ErrorResponse "저장된 자격 증명 없음"
+// @Router /tenant/{tenantKey}/credential/{provider} [delete]
+func (c *TenantCredentialController) deleteTenantCredential(ctx echo.Context, userId uuid.UUID) error {
+	err := c.useCase.DeleteTenantCredential(ctx.Request().Context(), domain.DeleteTenantCredential{
+		TenantKey: ctx.Param("tenantKey"),
+		Provider:  domain.CredentialProvider(strings.ToUpper(ctx.Param("provider"))),
+		DeletedBy: userId,
+		Ip:        ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "credential not found"})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "deleteTenantCredential, unhandled error useCase.DeleteTenantCredential")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
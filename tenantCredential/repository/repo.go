@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewTenantCredentialRepository(db *gorm.DB) domain.TenantCredentialRepository {
+	db.AutoMigrate(&domain.TenantCredential{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) Save(ctx context.Context, credential *domain.TenantCredential) error {
+	return gormx.Upsert(ctx, r.db, credential)
+}
+
+func (r *repo) Delete(ctx context.Context, tenantKey string, provider domain.CredentialProvider) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Delete(&domain.TenantCredential{}, "`tenant_key` = ? AND `provider` = ?", tenantKey, provider)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repo) Get(ctx context.Context, tenantKey string, provider domain.CredentialProvider) (credential *domain.TenantCredential, err error) {
+	var entity domain.TenantCredential
+	err = r.db.WithContext(ctx).
+		Where("`tenant_key` = ? AND `provider` = ?", tenantKey, provider).
+		First(&entity).Error
+	if err == nil {
+		credential = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchByTenant(ctx context.Context, tenantKey string) (list []domain.TenantCredential, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`tenant_key` = ?", tenantKey).
+		Order("`provider`").
+		Find(&list).Error
+	return
+}
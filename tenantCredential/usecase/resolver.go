@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+// NewTenantCredentialResolver 연동 adapter 에서 사용, 관리 유스케이스와 같은 캐시를 공유하므로 변경 즉시 반영
+func NewTenantCredentialResolver(
+	credentialRepo domain.TenantCredentialRepository,
+	cipher domain.CredentialCipher,
+	timeout time.Duration,
+) domain.TenantCredentialResolver {
+	return &resolver{
+		credentialRepo: credentialRepo,
+		cipher:         cipher,
+		cache:          cache.New(domain.TenantCredentialCacheName, domain.TenantCredentialCacheTTL),
+		timeout:        timeout,
+	}
+}
+
+type resolver struct {
+	credentialRepo domain.TenantCredentialRepository
+	cipher         domain.CredentialCipher
+	cache          *cache.Store
+	timeout        time.Duration
+}
+
+// values 복호화한 항목 값, 저장된 값이 없으면 ErrItemNotFound
+func (r *resolver) values(ctx context.Context, tenantKey string, provider domain.CredentialProvider) (map[string]string, error) {
+	cached, err := r.cache.GetOrLoad(cacheKey(tenantKey, provider), func() (interface{}, error) {
+		c, cancel := budget.Slice(ctx, r.timeout)
+		defer cancel()
+
+		credential, err := r.credentialRepo.Get(c, tenantKey, provider)
+		if err != nil {
+			return nil, err
+		}
+		if credential == nil {
+			return nil, domain.ErrItemNotFound
+		}
+
+		plain, err := r.cipher.Open(credential.Sealed, credential.AAD())
+		if err != nil {
+			return nil, err
+		}
+
+		var values map[string]string
+		err = json.Unmarshal(plain, &values)
+		return values, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(map[string]string), nil
+}
+
+func (r *resolver) PG(ctx context.Context, tenantKey string) (res domain.PGCredential, err error) {
+	values, err := r.values(ctx, tenantKey, domain.CredentialProviderPG)
+	if err != nil {
+		return
+	}
+
+	res = domain.PGCredential{
+		MerchantId: values["merchantId"],
+		SecretKey:  values["secretKey"],
+	}
+	return
+}
+
+func (r *resolver) Alimtalk(ctx context.Context, tenantKey string) (res domain.AlimtalkCredential, err error) {
+	values, err := r.values(ctx, tenantKey, domain.CredentialProviderAlimtalk)
+	if err != nil {
+		return
+	}
+
+	res = domain.AlimtalkCredential{
+		SenderKey: values["senderKey"],
+		ApiKey:    values["apiKey"],
+		ProfileId: values["profileId"],
+	}
+	return
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewTenantCredentialUseCase(
+	credentialRepo domain.TenantCredentialRepository,
+	cipher domain.CredentialCipher,
+	auditLogger domain.AuditLogger,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.TenantCredentialUseCase {
+	return &ucase{
+		credentialRepo: credentialRepo,
+		cipher:         cipher,
+		auditLogger:    auditLogger,
+		cache:          cache.New(domain.TenantCredentialCacheName, domain.TenantCredentialCacheTTL),
+		clock:          clock,
+		timeout:        timeout,
+	}
+}
+
+type ucase struct {
+	credentialRepo domain.TenantCredentialRepository
+	cipher         domain.CredentialCipher
+	auditLogger    domain.AuditLogger
+	cache          *cache.Store
+	clock          domain.Clock
+	timeout        time.Duration
+}
+
+func (u *ucase) SetTenantCredential(ctx context.Context, in domain.SetTenantCredential) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if !domain.IsTenantKey(in.TenantKey) || !in.Provider.IsValid() {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	err = domain.ValidateCredentialValues(in.Provider, in.Values)
+	if err != nil {
+		return
+	}
+
+	plain, err := json.Marshal(in.Values)
+	if err != nil {
+		return
+	}
+
+	credential := domain.TenantCredential{
+		TenantKey: in.TenantKey,
+		Provider:  in.Provider,
+		UpdatedBy: in.UpdatedBy,
+		UpdatedAt: u.clock.Now(),
+	}
+	credential.Sealed, err = u.cipher.Seal(plain, credential.AAD())
+	if err != nil {
+		return
+	}
+
+	err = u.credentialRepo.Save(c, &credential)
+	if err != nil {
+		return
+	}
+
+	u.cache.Invalidate(cacheKey(in.TenantKey, in.Provider))
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId: in.UpdatedBy,
+		Action:  domain.AuditActionTenantCredentialSet,
+		Ip:      in.Ip,
+		Detail:  cacheKey(in.TenantKey, in.Provider),
+	})
+	return
+}
+
+func (u *ucase) DeleteTenantCredential(ctx context.Context, in domain.DeleteTenantCredential) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	deleted, err := u.credentialRepo.Delete(c, in.TenantKey, in.Provider)
+	if err != nil {
+		return
+	}
+
+	if !deleted {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	u.cache.Invalidate(cacheKey(in.TenantKey, in.Provider))
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId: in.DeletedBy,
+		Action:  domain.AuditActionTenantCredentialDeleted,
+		Ip:      in.Ip,
+		Detail:  cacheKey(in.TenantKey, in.Provider),
+	})
+	return
+}
+
+func cacheKey(tenantKey string, provider domain.CredentialProvider) string {
+	return tenantKey + "/" + string(provider)
+}
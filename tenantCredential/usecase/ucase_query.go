@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) FetchTenantCredentials(ctx context.Context, tenantKey string) (res []domain.TenantCredentialInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.credentialRepo.FetchByTenant(c, tenantKey)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.TenantCredentialInfo, len(list))
+	for i, credential := range list {
+		res[i] = domain.TenantCredentialInfo{
+			Provider:  credential.Provider,
+			Fields:    credential.Fields(),
+			UpdatedBy: credential.UpdatedBy,
+			UpdatedAt: credential.UpdatedAt,
+		}
+	}
+	return
+}
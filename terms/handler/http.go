@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+const (
+	tag = "[TERMS] "
+)
+
+func NewTermsController(useCase domain.TermsUseCase) *TermsController {
+	return &TermsController{useCase: useCase}
+}
+
+type TermsController struct {
+	useCase domain.TermsUseCase
+}
+
+func (c *TermsController) Bind(e *echo.Echo) {
+	// ===== SUPER ADMIN =====
+	e.POST("/terms", echox.UserID(c.publishTerms),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+
+	// ===== ADMIN =====
+	e.GET("/customer/:userId/terms", c.fetchCustomerTermsAcceptances,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// ===== CUSTOMER =====
+	e.GET("/terms/me", echox.UserID(c.fetchMyTerms),
+		middleware.RequireRole(domain.CustomerUserRole))
+	e.POST("/terms/me/accept", echox.UserID(c.acceptTerms),
+		middleware.RequireRole(domain.CustomerUserRole))
+
+	// ===== ALL =====
+	// 가입 전에도 읽을 수 있어야 하므로 인증 없음
+	e.GET("/terms", c.fetchLatestTerms)
+	e.GET("/terms/:termsId", c.getTerms)
+}
+
+type TermsResponse struct {
+	Id      uuid.UUID `json:"id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Kind    string    `json:"kind" validate:"required" example:"TERMS_OF_SERVICE" enums:"TERMS_OF_SERVICE,SERVICE_AGREEMENT"`
+	Version uint16    `json:"version" validate:"required" example:"3"`
+	Title   string    `json:"title" validate:"required" example:"에디트폴리오 이용약관"`
+	Body    string    `json:"body" validate:"required" example:"제1조 (목적) ..."`
+	// Required, 이 버전에 다시 동의해야 의뢰 가능
+	Required    bool      `json:"required" validate:"required" example:"true"`
+	PublishedAt time.Time `json:"publishedAt" validate:"required" example:"2024-05-01T00:00:00+09:00"`
+} // @name TermsResponse
+
+func responseOf(src domain.TermsDocumentInfo) TermsResponse {
+	return TermsResponse{
+		Id:          src.Id,
+		Kind:        string(src.Kind),
+		Version:     src.Version,
+		Title:       src.Title,
+		Body:        src.Body,
+		Required:    src.Required,
+		PublishedAt: src.PublishedAt,
+	}
+}
+
+type PublishTermsRequest struct {
+	Kind  string `json:"kind" validate:"required,oneof=TERMS_OF_SERVICE SERVICE_AGREEMENT" example:"TERMS_OF_SERVICE"`
+	Title string `json:"title" validate:"required,max=200" example:"에디트폴리오 이용약관"`
+	Body  string `json:"body" validate:"required" example:"제1조 (목적) ..."`
+	// Required, true 면 모든 고객이 다시 동의해야 의뢰 가능
+	Required bool `json:"required" example:"true"`
+} // @name PublishTermsRequest
+
+// @Tags (Terms) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 약관 새 버전 게시
+// @Description 같은 종류의 마지막 버전 + 1 로 게시, 게시한 버전은 수정, 삭제 불가, required 면 고객이 다시 동의하기 전까지 의뢰 불가, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body PublishTermsRequest true "약관 내용"
+// @Success 201 {object} TermsResponse "게시"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Router /terms [post]
+func (c *TermsController) publishTerms(ctx echo.Context, userId uuid.UUID) error {
+	var req PublishTermsRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "publish terms, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	document, err := c.useCase.PublishTerms(ctx.Request().Context(), domain.PublishTerms{
+		Kind:        domain.TermsKind(req.Kind),
+		Title:       req.Title,
+		Body:        req.Body,
+		Required:    req.Required,
+		PublishedBy: userId,
+	})
+
+	switch err {
+	case nil:
+		echox.Log(ctx).WithField("kind", document.Kind).
+			WithField("version", document.Version).
+			WithField("publishedBy", userId).
+			Info(tag, "terms published")
+		return ctx.JSON(http.StatusCreated, responseOf(document))
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "publishTerms, unhandled error useCase.PublishTerms")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// @Tags (Terms) 공용 기능
+// @Summary 최신 약관
+// @Description 종류별 가장 최근 버전, 인증 없이 조회 가능
+// @Accept json
+// @Produce json
+// @Success 200 {array} TermsResponse "성공"
+// @Success 204 "게시된 약관 없음"
+// @Router /terms [get]
+func (c *TermsController) fetchLatestTerms(ctx echo.Context) error {
+	list, err := c.useCase.FetchLatestTerms(ctx.Request().Context())
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchLatestTerms, unhandled error useCase.FetchLatestTerms")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]TermsResponse, len(list))
+	for i := range list {
+		res[i] = responseOf(list[i])
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// @Tags (Terms) 공용 기능
+// @Summary 약관 버전 조회
+// @Description 지난 버전 포함, 동의 기록에서 동의한 내용 확인용, 인증 없이 조회 가능
+// @Accept json
+// @Produce json
+// @Param terms_id path string true "약관 아이디(UUID)"
+// @Success 200 {object} TermsResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 약관"
+// @Router /terms/{terms_id} [get]
+func (c *TermsController) getTerms(ctx echo.Context) error {
+	var req struct {
+		TermsId uuid.UUID `param:"termsId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "get terms, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	document, err := c.useCase.GetTermsDocument(ctx.Request().Context(), req.TermsId)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, responseOf(document))
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("termsId", req.TermsId).
+			Error(tag, "getTerms, unhandled error useCase.GetTermsDocument")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type MyTermsResponse struct {
+	TermsResponse
+	// Accepted, 이 버전에 동의함
+	Accepted bool `json:"accepted" validate:"required" example:"false"`
+	// Pending, 이 종류에 동의해야 의뢰 가능
+	Pending bool `json:"pending" validate:"required" example:"true"`
+} // @name MyTermsResponse
+
+// @Tags (Terms) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 약관 동의 상태
+// @Description 종류별 가장 최근 버전과 동의 여부, pending 이 하나라도 true 면 동의 전까지 의뢰 불가, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {array} MyTermsResponse "성공"
+// @Success 204 "게시된 약관 없음"
+// @Router /terms/me [get]
+func (c *TermsController) fetchMyTerms(ctx echo.Context, userId uuid.UUID) error {
+	list, err := c.useCase.FetchMyTerms(ctx.Request().Context(), userId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "fetchMyTerms, unhandled error useCase.FetchMyTerms")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]MyTermsResponse, len(list))
+	for i := range list {
+		res[i] = MyTermsResponse{
+			TermsResponse: responseOf(list[i].TermsDocumentInfo),
+			Accepted:      list[i].Accepted,
+			Pending:       list[i].Pending,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type AcceptTermsRequest struct {
+	// TermsIds, 동의할 약관 아이디, 종류별 가장 최근 버전만 가능
+	TermsIds []uuid.UUID `json:"termsIds" validate:"required,min=1,max=10" example:"550e8400-e29b-41d4-a716-446655440000"`
+} // @name AcceptTermsRequest
+
+// @Tags (Terms) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 약관 동의
+// @Description 동의 시각, 요청 IP, User-Agent 를 기록, 이미 동의한 버전은 그대로 둠, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body AcceptTermsRequest true "동의할 약관"
+// @Success 204 "동의 완료"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류, 최신 버전이 아닌 약관"
+// @Failure 403 {object} domain.ErrorResponse "고객이 아님"
+// @Router /terms/me/accept [post]
+func (c *TermsController) acceptTerms(ctx echo.Context, userId uuid.UUID) error {
+	var req AcceptTermsRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "accept terms, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.AcceptTerms(ctx.Request().Context(), domain.AcceptTerms{
+		UserId:      userId,
+		DocumentIds: req.TermsIds,
+		Ip:          ctx.RealIP(),
+		UserAgent:   truncate(ctx.Request().UserAgent(), 500),
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusForbidden, domain.NoPermissionResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "acceptTerms, unhandled error useCase.AcceptTerms")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type TermsAcceptanceResponse struct {
+	TermsId    uuid.UUID `json:"termsId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Kind       string    `json:"kind" validate:"required" example:"TERMS_OF_SERVICE" enums:"TERMS_OF_SERVICE,SERVICE_AGREEMENT"`
+	Version    uint16    `json:"version" validate:"required" example:"3"`
+	AcceptedAt time.Time `json:"acceptedAt" validate:"required" example:"2024-05-02T10:12:00+09:00"`
+	Ip         string    `json:"ip" validate:"required" example:"203.0.113.7"`
+	UserAgent  string    `json:"userAgent" validate:"required" example:"Mozilla/5.0"`
+} // @name TermsAcceptanceResponse
+
+// @Tags (Terms) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 약관 동의 기록
+// @Description 최근 동의부터, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Success 200 {array} TermsAcceptanceResponse "성공"
+// @Success 204 "동의 기록 없음"
+// @Router /customer/{user_id}/terms [get]
+func (c *TermsController) fetchCustomerTermsAcceptances(ctx echo.Context) error {
+	var req struct {
+		UserId uuid.UUID `param:"userId"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch customer terms acceptances, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.FetchTermsAcceptances(ctx.Request().Context(), req.UserId)
+	if err != nil {
+		echox.Log(ctx).WithError(err).
+			WithField("userId", req.UserId).
+			Error(tag, "fetchCustomerTermsAcceptances, unhandled error useCase.FetchTermsAcceptances")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make([]TermsAcceptanceResponse, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = TermsAcceptanceResponse{
+			TermsId:    src.DocumentId,
+			Kind:       string(src.Kind),
+			Version:    src.Version,
+			AcceptedAt: src.AcceptedAt,
+			Ip:         src.Ip,
+			UserAgent:  src.UserAgent,
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
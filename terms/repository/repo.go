@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewTermsRepository(db *gorm.DB) domain.TermsRepository {
+	db.AutoMigrate(&domain.TermsDocument{}, &domain.TermsAcceptance{})
+	return &repo{db: db}
+}
+
+type repo struct {
+	db *gorm.DB
+}
+
+func (r *repo) CreateDocument(ctx context.Context, document *domain.TermsDocument) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last uint16
+		err := tx.Model(&domain.TermsDocument{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("COALESCE(MAX(`version`), 0)").
+			Where("`kind` = ?", document.Kind).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		document.Version = last + 1
+		return tx.Create(document).Error
+	})
+}
+
+func (r *repo) GetDocumentById(ctx context.Context, id uuid.UUID) (document *domain.TermsDocument, err error) {
+	var entity domain.TermsDocument
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		document = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchLatestDocuments(ctx context.Context) ([]domain.TermsDocument, error) {
+	return r.fetchLatest(ctx, r.db.Model(&domain.TermsDocument{}))
+}
+
+func (r *repo) FetchLatestRequiredDocuments(ctx context.Context) ([]domain.TermsDocument, error) {
+	return r.fetchLatest(ctx, r.db.Model(&domain.TermsDocument{}).Where("`required` = ?", true))
+}
+
+// fetchLatest scope 안에서 종류별 가장 큰 Version
+func (r *repo) fetchLatest(ctx context.Context, scope *gorm.DB) (list []domain.TermsDocument, err error) {
+	latest := scope.
+		Select("`kind`, MAX(`version`) AS `version`").
+		Group("`kind`")
+
+	err = r.db.WithContext(ctx).
+		Joins("JOIN (?) AS `latest` ON `latest`.`kind` = `terms_document`.`kind` AND `latest`.`version` = `terms_document`.`version`", latest).
+		Order("`terms_document`.`kind`").
+		Find(&list).Error
+	return
+}
+
+func (r *repo) SaveAcceptances(ctx context.Context, list []domain.TermsAcceptance) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&list).Error
+}
+
+func (r *repo) FetchAcceptancesByUser(ctx context.Context, userId uuid.UUID) (list []domain.TermsAcceptance, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`user_id` = ?", userId).
+		Order("`accepted_at` desc").
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+// NewTermsGate 다시 동의가 필요한 버전을 한 번도 올리지 않았으면 아무것도 막지 않음
+func NewTermsGate(termsRepo domain.TermsRepository) domain.TermsGate {
+	return &gate{termsRepo: termsRepo}
+}
+
+type gate struct {
+	termsRepo domain.TermsRepository
+}
+
+func (g *gate) RequireAccepted(ctx context.Context, userId uuid.UUID) error {
+	required, err := g.termsRepo.FetchLatestRequiredDocuments(ctx)
+	if err != nil || len(required) == 0 {
+		return err
+	}
+
+	acceptances, err := g.termsRepo.FetchAcceptancesByUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if len(pendingKinds(required, acceptances)) > 0 {
+		return domain.ErrTermsNotAccepted
+	}
+	return nil
+}
+
+// pendingKinds 다시 동의가 필요한 버전 이상에 동의하지 않은 종류
+// 그 뒤에 나온 Required 가 아닌 버전에 동의했어도 동의한 것으로 봄
+func pendingKinds(required []domain.TermsDocument, acceptances []domain.TermsAcceptance) map[domain.TermsKind]bool {
+	accepted := make(map[domain.TermsKind]uint16)
+	for _, acceptance := range acceptances {
+		if acceptance.Version > accepted[acceptance.Kind] {
+			accepted[acceptance.Kind] = acceptance.Version
+		}
+	}
+
+	pending := make(map[domain.TermsKind]bool)
+	for _, document := range required {
+		if accepted[document.Kind] < document.Version {
+			pending[document.Kind] = true
+		}
+	}
+	return pending
+}
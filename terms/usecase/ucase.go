@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func NewTermsUseCase(
+	termsRepo domain.TermsRepository,
+	userRepo domain.UserRepository,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+	timeout time.Duration,
+) domain.TermsUseCase {
+	return &ucase{
+		termsRepo: termsRepo,
+		userRepo:  userRepo,
+		ids:       ids,
+		clock:     clock,
+		timeout:   timeout,
+	}
+}
+
+type ucase struct {
+	termsRepo domain.TermsRepository
+	userRepo  domain.UserRepository
+	ids       domain.IdGenerator
+	clock     domain.Clock
+	timeout   time.Duration
+}
+
+func (u *ucase) PublishTerms(ctx context.Context, in domain.PublishTerms) (res domain.TermsDocumentInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if !in.Kind.IsValid() {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	document := domain.CreateTermsDocument(domain.CreateTermsDocumentOption{
+		Id:        u.ids.NewId(),
+		Kind:      in.Kind,
+		Title:     in.Title,
+		Body:      in.Body,
+		Required:  in.Required,
+		CreatedBy: in.PublishedBy,
+		Now:       u.clock.Now(),
+	})
+	err = u.termsRepo.CreateDocument(c, &document)
+	if err != nil {
+		return
+	}
+
+	res = documentInfoOf(document)
+	return
+}
+
+func (u *ucase) AcceptTerms(ctx context.Context, in domain.AcceptTerms) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetById(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	latest, err := u.termsRepo.FetchLatestDocuments(c)
+	if err != nil {
+		return
+	}
+
+	latestById := make(map[uuid.UUID]domain.TermsDocument, len(latest))
+	for _, document := range latest {
+		latestById[document.Id] = document
+	}
+
+	now := u.clock.Now()
+	list := make([]domain.TermsAcceptance, 0, len(in.DocumentIds))
+	for _, id := range in.DocumentIds {
+		// 지난 버전에 동의하는 건 의미가 없으므로 거절
+		document, ok := latestById[id]
+		if !ok {
+			err = domain.ErrWeirdData
+			return
+		}
+
+		list = append(list, domain.TermsAcceptance{
+			Id:         u.ids.NewId(),
+			UserId:     in.UserId,
+			DocumentId: document.Id,
+			Kind:       document.Kind,
+			Version:    document.Version,
+			AcceptedAt: now,
+			Ip:         in.Ip,
+			UserAgent:  in.UserAgent,
+		})
+	}
+
+	return u.termsRepo.SaveAcceptances(c, list)
+}
+
+func documentInfoOf(src domain.TermsDocument) domain.TermsDocumentInfo {
+	return domain.TermsDocumentInfo{
+		Id:          src.Id,
+		Kind:        src.Kind,
+		Version:     src.Version,
+		Title:       src.Title,
+		Body:        src.Body,
+		Required:    src.Required,
+		PublishedAt: src.PublishedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"golang.org/x/sync/errgroup"
+)
+
+func (u *ucase) GetTermsDocument(ctx context.Context, id uuid.UUID) (res domain.TermsDocumentInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	document, err := u.termsRepo.GetDocumentById(c, id)
+	if err != nil {
+		return
+	}
+
+	if document == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	res = documentInfoOf(*document)
+	return
+}
+
+func (u *ucase) FetchLatestTerms(ctx context.Context) (res []domain.TermsDocumentInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.termsRepo.FetchLatestDocuments(c)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.TermsDocumentInfo, len(list))
+	for i := range list {
+		res[i] = documentInfoOf(list[i])
+	}
+	return
+}
+
+func (u *ucase) FetchMyTerms(ctx context.Context, userId uuid.UUID) (res []domain.MyTermsInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	var (
+		latest, required []domain.TermsDocument
+		acceptances      []domain.TermsAcceptance
+	)
+	g, gc := errgroup.WithContext(c)
+	g.Go(func() (err error) {
+		latest, err = u.termsRepo.FetchLatestDocuments(gc)
+		return
+	})
+	g.Go(func() (err error) {
+		required, err = u.termsRepo.FetchLatestRequiredDocuments(gc)
+		return
+	})
+	g.Go(func() (err error) {
+		acceptances, err = u.termsRepo.FetchAcceptancesByUser(gc, userId)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	acceptedIds := make(map[uuid.UUID]bool, len(acceptances))
+	for _, acceptance := range acceptances {
+		acceptedIds[acceptance.DocumentId] = true
+	}
+	pending := pendingKinds(required, acceptances)
+
+	res = make([]domain.MyTermsInfo, len(latest))
+	for i := range latest {
+		res[i] = domain.MyTermsInfo{
+			TermsDocumentInfo: documentInfoOf(latest[i]),
+			Accepted:          acceptedIds[latest[i].Id],
+			Pending:           pending[latest[i].Kind],
+		}
+	}
+	return
+}
+
+func (u *ucase) FetchTermsAcceptances(ctx context.Context, customerId uuid.UUID) (res []domain.TermsAcceptanceInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	list, err := u.termsRepo.FetchAcceptancesByUser(c, customerId)
+	if err != nil {
+		return
+	}
+
+	res = make([]domain.TermsAcceptanceInfo, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = domain.TermsAcceptanceInfo{
+			DocumentId: src.DocumentId,
+			Kind:       src.Kind,
+			Version:    src.Version,
+			AcceptedAt: src.AcceptedAt,
+			Ip:         src.Ip,
+			UserAgent:  src.UserAgent,
+		}
+	}
+	return
+}
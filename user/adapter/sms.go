@@ -0,0 +1,135 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/retry"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+const (
+	nhnCloudSmsAPIURL  = "https://api-sms.cloud.toast.com"
+	nhnCloudSmsTimeout = 10 * time.Second
+	smsErrorBodyLimit  = 1024
+)
+
+var ErrSmsNotConfigured = errors.New("sms provider not configured")
+
+// NHNCloudSmsOption NHN Cloud SMS 앱 키와 사전 등록한 발신 번호
+type NHNCloudSmsOption struct {
+	AppKey    string
+	SecretKey string
+	SendNo    string
+	// Retry 일시적인 실패(네트워크, 429, 5xx) 재시도
+	Retry retry.Policy
+}
+
+// NewNHNCloudSms 앱 키가 없으면 보낼 때만 ErrSmsNotConfigured
+func NewNHNCloudSms(option NHNCloudSmsOption) domain.SmsSender {
+	if option.AppKey == "" {
+		return disabledSms{}
+	}
+	return &nhnCloudSms{option: option, client: &http.Client{}}
+}
+
+type nhnCloudSms struct {
+	option NHNCloudSmsOption
+	client *http.Client
+}
+
+type nhnCloudSmsRecipient struct {
+	RecipientNo string `json:"recipientNo"`
+}
+
+type nhnCloudSmsRequest struct {
+	Body          string                 `json:"body"`
+	SendNo        string                 `json:"sendNo"`
+	RecipientList []nhnCloudSmsRecipient `json:"recipientList"`
+}
+
+type nhnCloudSmsResponse struct {
+	Header struct {
+		IsSuccessful  bool   `json:"isSuccessful"`
+		ResultCode    int    `json:"resultCode"`
+		ResultMessage string `json:"resultMessage"`
+	} `json:"header"`
+}
+
+func (s *nhnCloudSms) Send(ctx context.Context, to, text string) error {
+	raw, err := json.Marshal(nhnCloudSmsRequest{
+		Body:          text,
+		SendNo:        s.option.SendNo,
+		RecipientList: []nhnCloudSmsRecipient{{RecipientNo: to}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return retry.Do(ctx, s.option.Retry, func(ctx context.Context) error {
+		return s.send(ctx, raw)
+	})
+}
+
+// send 서비스가 받았다고 응답한(header.isSuccessful) 문자는 다시 보내지 않음
+func (s *nhnCloudSms) send(ctx context.Context, raw []byte) error {
+	c, cancel := budget.Slice(ctx, nhnCloudSmsTimeout)
+	defer cancel()
+
+	endpoint := nhnCloudSmsAPIURL + "/sms/v3.0/appKeys/" + url.PathEscape(s.option.AppKey) + "/sender/sms"
+	req, err := http.NewRequestWithContext(c, http.MethodPost, endpoint, bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
+	req.Header.Set("X-Secret-Key", s.option.SecretKey)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		errBody, _ := io.ReadAll(io.LimitReader(res.Body, smsErrorBodyLimit))
+		return &retry.StatusError{Service: "nhn cloud sms", Code: res.StatusCode, Body: string(errBody)}
+	}
+
+	var out nhnCloudSmsResponse
+	err = json.NewDecoder(res.Body).Decode(&out)
+	if err != nil {
+		return err
+	}
+	if !out.Header.IsSuccessful {
+		// 발신 번호, 수신 번호 오류 등은 다시 보내도 같음
+		return retry.Permanent(fmt.Errorf("nhn cloud sms rejected, code %d: %s", out.Header.ResultCode, out.Header.ResultMessage))
+	}
+	return nil
+}
+
+type disabledSms struct{}
+
+func (disabledSms) Send(context.Context, string, string) error {
+	return ErrSmsNotConfigured
+}
+
+// NewLogSms 문자를 보내지 않고 로그로만 남김, 개발용
+func NewLogSms() domain.SmsSender {
+	return logSms{}
+}
+
+type logSms struct{}
+
+func (logSms) Send(_ context.Context, to, text string) error {
+	log.WithField("to", to).WithField("text", text).Info("sms not sent, debug mode")
+	return nil
+}
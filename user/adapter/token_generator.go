@@ -1,35 +1,79 @@
 package adapter
 
 import (
+	"context"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
 	"github.com/stockfolioofficial/back-editfolio/domain"
 )
 
 type tokenGenerator struct {
-	secret []byte
+	secret func() ([]byte, error)
+	clock  domain.Clock
 }
 
 type customClaims struct {
 	jwt.StandardClaims
 	Roles []string `json:"roles"`
+	// Scopes 없으면 역할의 모든 권한
+	Scopes []string `json:"scopes,omitempty"`
+	// TokenVersion 버전 도입 전에 발급한 토큰은 0
+	TokenVersion uint32 `json:"ver"`
+	// Tenant 발급한 테넌트 키, 기본 DB 면 생략
+	Tenant string `json:"tenant,omitempty"`
 }
 
-func NewTokenGenerateAdapter(secret []byte) domain.TokenGenerateAdapter {
+// NewTokenGenerateAdapter secret 은 서명할 때마다 호출, 비밀 저장소의 키 교체를 반영하기 위함
+func NewTokenGenerateAdapter(secret func() ([]byte, error), clock domain.Clock) domain.TokenGenerateAdapter {
 	return &tokenGenerator{
 		secret: secret,
+		clock:  clock,
 	}
 }
 
-func (t *tokenGenerator) Generate(u domain.User) (string, error) {
-	now := time.Now()
+func (t *tokenGenerator) Generate(ctx context.Context, identity domain.Identity) (string, error) {
+	key, err := t.secret()
+	if err != nil {
+		return "", err
+	}
+
+	now := t.clock.Now()
 	return jwt.NewWithClaims(jwt.SigningMethodHS256, customClaims{
 		StandardClaims: jwt.StandardClaims{
-			Subject:  u.Id.String(),
+			Subject:  identity.Id.String(),
 			IssuedAt: now.Unix(),
 			// Issuer: , tobe defined
 		},
-		Roles: []string{string(u.Role)},
-	}).SignedString([]byte(t.secret))
+		Roles:        []string{string(identity.Role)},
+		TokenVersion: identity.TokenVersion,
+		Tenant:       tenant.KeyOf(ctx),
+	}).SignedString(key)
+}
+
+func (t *tokenGenerator) GenerateScoped(ctx context.Context, identity domain.Identity, keyId uuid.UUID, scopes []domain.TokenScope, expiresAt time.Time) (string, error) {
+	key, err := t.secret()
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, customClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        keyId.String(),
+			Subject:   identity.Id.String(),
+			IssuedAt:  t.clock.Now().Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		Roles:        []string{string(identity.Role)},
+		Scopes:       names,
+		TokenVersion: identity.TokenVersion,
+		Tenant:       tenant.KeyOf(ctx),
+	}).SignedString(key)
 }
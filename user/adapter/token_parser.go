@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+type tokenParser struct {
+	secret func() ([]byte, error)
+	clock  domain.Clock
+}
+
+// NewTokenParseAdapter secret 은 검증할 때마다 호출, 발급과 같은 키를 읽어야 함
+// 만료, 발급 시각은 jwt.TimeFunc 대신 clock 으로 검사
+func NewTokenParseAdapter(secret func() ([]byte, error), clock domain.Clock) domain.TokenParseAdapter {
+	return &tokenParser{
+		secret: secret,
+		clock:  clock,
+	}
+}
+
+// Parse 서명, 만료, 내용이 맞지 않으면 ErrInvalidToken, 키를 못 읽으면 그 에러
+func (t *tokenParser) Parse(raw string) (claims domain.TokenClaims, err error) {
+	key, err := t.secret()
+	if err != nil {
+		return
+	}
+
+	var c customClaims
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	_, err = parser.ParseWithClaims(raw, &c, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return key, nil
+	})
+	if err != nil {
+		err = domain.ErrInvalidToken
+		return
+	}
+
+	now := t.clock.Now().Unix()
+	if !c.VerifyExpiresAt(now, false) || !c.VerifyIssuedAt(now, false) || !c.VerifyNotBefore(now, false) {
+		err = domain.ErrInvalidToken
+		return
+	}
+
+	subject, err := uuid.Parse(c.Subject)
+	if err != nil || len(c.Roles) == 0 {
+		err = domain.ErrInvalidToken
+		return
+	}
+
+	claims = domain.TokenClaims{
+		Subject:      subject,
+		Role:         domain.UserRole(c.Roles[0]),
+		IssuedAt:     time.Unix(c.IssuedAt, 0),
+		TokenVersion: c.TokenVersion,
+		Tenant:       c.Tenant,
+	}
+	if c.Id != "" {
+		claims.KeyId, err = uuid.Parse(c.Id)
+		if err != nil {
+			err = domain.ErrInvalidToken
+			return
+		}
+	}
+	for _, scope := range c.Scopes {
+		claims.Scopes = append(claims.Scopes, domain.TokenScope(scope))
+	}
+	if c.ExpiresAt != 0 {
+		expiresAt := time.Unix(c.ExpiresAt, 0)
+		claims.ExpiresAt = &expiresAt
+	}
+	return
+}
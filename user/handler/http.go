@@ -3,8 +3,7 @@ package handler
 import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
-	"github.com/stockfolioofficial/back-editfolio/core/debug"
+	"github.com/stockfolioofficial/back-editfolio/core/middleware"
 	"github.com/stockfolioofficial/back-editfolio/domain"
 	"github.com/stockfolioofficial/back-editfolio/util/echox"
 	"net/http"
@@ -31,7 +30,7 @@ func (c *UserController) createSuperAdmin(ctx echo.Context) error {
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "createSuperAdmin, request data bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "createSuperAdmin, request data bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -50,7 +49,7 @@ func (c *UserController) createSuperAdmin(ctx echo.Context) error {
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.ItemExist)
 	default:
-		log.WithError(err).Error(tag, "createSuperAdmin, unhandled error useCase.CreateSuperAdminUser")
+		echox.Log(ctx).WithError(err).Error(tag, "createSuperAdmin, unhandled error useCase.CreateSuperAdminUser")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -58,6 +57,19 @@ func (c *UserController) createSuperAdmin(ctx echo.Context) error {
 func (c *UserController) Bind(e *echo.Echo) {
 	// get token
 	e.POST("/sign-in", c.signInUser)
+	// rotate password, then get token
+	e.POST("/sign-in/pw", c.rotatePassword)
+	// rotate refresh token, then get token
+	e.POST("/user/token/refresh", c.refreshToken)
+	// least privilege token (ex. dashboard display)
+	e.POST("/user/token/scoped", echox.UserID(c.issueScopedToken), middleware.RequireAuth())
+
+	// confirm username(email) change
+	e.POST("/user/email/confirm", c.confirmUsernameChange)
+
+	// forgot password, reset by mail token
+	e.POST("/user/password/reset-request", c.requestPasswordReset)
+	e.POST("/user/password/reset", c.resetPassword)
 
 	// ===== INIT ====
 	e.POST("/sa", c.createSuperAdmin)
@@ -66,54 +78,94 @@ func (c *UserController) Bind(e *echo.Echo) {
 	// Fetch admin
 	// v1, todo refactor
 	e.GET("/admin", c.fetchAdmin,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 	// v1, todo refactor
 	e.GET("/admin/creator", c.fetchAdminCreator,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 
 	// Self control
 	// Get my info (admin)
-	e.GET("/admin/me", echox.UserID(c.getAdminMyInfo), debug.JwtBypassOnDebug())
+	e.GET("/admin/me", echox.UserID(c.getAdminMyInfo), middleware.RequireAuth())
 	// Update my info
-	e.PUT("/admin/me", echox.UserID(c.updateAdminMyInfo), debug.JwtBypassOnDebug())
+	e.PUT("/admin/me", echox.UserID(c.updateAdminMyInfo), middleware.RequireAuth())
 	// Update admin password
-	e.PATCH("/admin/me/pw", echox.UserID(c.updateAdminMyPassword), debug.JwtBypassOnDebug())
+	e.PATCH("/admin/me/pw", echox.UserID(c.updateAdminMyPassword), middleware.RequireAuth())
 
 	// ===== CUSTOMER =====
 	// Customer control
 	// Fetch customer
 	// v1, todo refactor
-	e.GET("/customer", c.fetchCustomer,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.GET("/customer", echox.UserID(c.fetchCustomer),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	// Fetch customer page
+	e.GET("/user/customer", echox.UserID(c.fetchCustomers),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 
 	// Create customer
 	e.POST("/customer", c.createCustomer,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	// Create customers from CSV
+	e.POST("/user/customer/bulk", c.importCustomers,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 	// Get Customer
 	e.GET("/customer/:userId", c.getCustomerDetailInfo,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 
 	// Update customer
 	e.PUT("/customer/:userId", c.updateCustomer,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	// Update customer business(tax invoice) info
+	e.PUT("/customer/:userId/business", c.updateCustomerBusinessInfo,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	// Update customer profile(channel, persona, memo)
+	e.PUT("/user/customer/:userId/profile", c.updateCustomerProfile,
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 	// Delete customer
-	e.DELETE("/customer/:userId", c.deleteCustomerUser,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	e.DELETE("/customer/:userId", echox.UserID(c.deleteCustomerUser),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+	// Restore deleted customer
+	e.PATCH("/user/customer/:userId/restore", echox.UserID(c.restoreCustomerUser),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
+
+	// Merge duplicate customer
+	e.POST("/user/customer/merge", echox.UserID(c.mergeCustomer),
+		middleware.RequireRole(domain.SuperAdminUserRole, domain.AdminUserRole))
 
 	e.GET("/customer/me", echox.UserID(c.getMyCustomerInfo),
-		debug.JwtBypassOnDebugWithRole(domain.CustomerUserRole))
+		middleware.RequireRole(domain.CustomerUserRole))
+	e.GET("/user/customer/me", echox.UserID(c.getMyCustomerProfile),
+		middleware.RequireRole(domain.CustomerUserRole))
+	// 휴대폰 번호는 초기 비밀번호라 문자 인증 후에만 변경
+	e.POST("/user/customer/mobile/verify-request", echox.UserID(c.requestMobileVerification),
+		middleware.RequireRole(domain.CustomerUserRole))
+	e.PATCH("/user/customer/mobile", echox.UserID(c.updateMyMobile),
+		middleware.RequireRole(domain.CustomerUserRole))
 
 	// ===== SUPER_ADMIN =====
 	// Create admin
-	e.POST("/admin", c.createAdmin,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole))
+	e.POST("/admin", echox.UserID(c.createAdmin),
+		middleware.RequireRole(domain.SuperAdminUserRole))
 	// Update admin info
-	e.PUT("/admin/:userId", c.updateAdminBySuperAdmin,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole))
+	e.PUT("/admin/:userId", echox.UserID(c.updateAdminBySuperAdmin),
+		middleware.RequireRole(domain.SuperAdminUserRole))
 	// Update admin info
-	e.PATCH("/admin/:userId/pw", c.updateAdminPasswordBySuperAdmin,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole))
+	e.PATCH("/admin/:userId/pw", echox.UserID(c.updateAdminPasswordBySuperAdmin),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	// Update admin order capacity
+	e.PUT("/admin/:userId/capacity", c.updateManagerCapacity,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	e.PUT("/admin/:userId/skills", c.updateManagerSkills,
+		middleware.RequireRole(domain.SuperAdminUserRole))
 	// Delete admin
-	e.DELETE("/admin/:userId", c.deleteAdminBySuperAdmin,
-		debug.JwtBypassOnDebugWithRole(domain.SuperAdminUserRole))
+	e.DELETE("/admin/:userId", echox.UserID(c.deleteAdminBySuperAdmin),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	// Fetch admin accounts with manager info
+	e.GET("/user/admin", c.fetchAdminUsers,
+		middleware.RequireRole(domain.SuperAdminUserRole))
+	// Force password rotation for a role
+	e.POST("/user/admin/force-password-rotation", echox.UserID(c.forcePasswordRotation),
+		middleware.RequireRole(domain.SuperAdminUserRole))
+
+	// INTERNAL
+	e.GET("/internal/customer/tax-invoice-info", c.internalGetTaxInvoiceInfo)
 }
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -41,12 +44,11 @@ func (c *UserController) getAdminMyInfo(ctx echo.Context, userId uuid.UUID) erro
 	case domain.ErrItemNotFound:
 		return ctx.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
 	default:
-		log.WithError(err).Error(tag, "getAdminMyInfo, unhandled error useCase.GetAdminInfoDetailByUserId")
+		echox.Log(ctx).WithError(err).Error(tag, "getAdminMyInfo, unhandled error useCase.GetAdminInfoDetailByUserId")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
 
-
 type UpdateAdminMyInfoRequest struct {
 	Email    string `json:"email" validate:"required,email" example:"example@example.com"`
 	Name     string `json:"name" validate:"required,min=2,max=60" example:"sch"`
@@ -67,14 +69,14 @@ func (c *UserController) updateAdminMyInfo(ctx echo.Context, userId uuid.UUID) e
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "update admin, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "update admin, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 
 	if err != nil {
-		log.WithError(err).Trace(tag, "UUID error")
+		echox.Log(ctx).WithError(err).Trace(tag, "UUID error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -95,7 +97,7 @@ func (c *UserController) updateAdminMyInfo(ctx echo.Context, userId uuid.UUID) e
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.ItemExist)
 	default:
-		log.WithError(err).Error(tag, "create admin, unhandled error useCase.UpdateAdminInfo")
+		echox.Log(ctx).WithError(err).Error(tag, "create admin, unhandled error useCase.UpdateAdminInfo")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -113,12 +115,13 @@ type UpdateAdminMyPasswordRequest struct {
 // @Produce json
 // @Param requestBody body UpdateAdminMyPasswordRequest true "비밀번호 수정 데이터 구조"
 // @Success 204 "비밀번호 변경 성공"
+// @Failure 422 {object} domain.ErrorResponse "비밀번호 규칙에 맞지 않음 (U-14), 최근에 쓴 비밀번호 (U-15)"
 // @Router /admin/me/pw [patch]
 func (c *UserController) updateAdminMyPassword(ctx echo.Context, userId uuid.UUID) error {
 	var req UpdateAdminMyPasswordRequest
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "update password, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "update password, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -137,8 +140,12 @@ func (c *UserController) updateAdminMyPassword(ctx echo.Context, userId uuid.UUI
 		return ctx.JSON(http.StatusUnauthorized, domain.UserWrongPasswordToUpdatePassword)
 	case domain.ErrItemNotFound:
 		return ctx.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrPasswordTooWeak:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.PasswordTooWeakResponse)
+	case domain.ErrPasswordReused:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.PasswordReusedResponse)
 	default:
-		log.WithError(err).Error(tag, "update password, unhandled error useCase.UpdateAdminPassword")
+		echox.Log(ctx).WithError(err).Error(tag, "update password, unhandled error useCase.UpdateAdminPassword")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -164,13 +171,14 @@ type CreateCustomerRequest struct {
 // @Produce json
 // @Param requestBody body CreateCustomerRequest true "고객 생성 정보 데이터 구조"
 // @Success 201 {object} CreatedUserResponse "고객 생성 완료"
+// @Failure 409 {object} domain.ErrorResponse "이미 있는 이메일"
 // @Router /customer [post]
 func (c *UserController) createCustomer(ctx echo.Context) error {
 	var req CreateCustomerRequest
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "create customer, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "create customer, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -188,7 +196,7 @@ func (c *UserController) createCustomer(ctx echo.Context) error {
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
 	default:
-		log.WithError(err).Error(tag, "create customer, unhandled error useCase.CreateCustomerUser")
+		echox.Log(ctx).WithError(err).Error(tag, "create customer, unhandled error useCase.CreateCustomerUser")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -209,9 +217,6 @@ type UpdateCustomerInfoRequest struct {
 	// Email, 이메일 주소
 	Email string `json:"email" validate:"required,email" example:"example@example.com"`
 
-	// Mobile, 형식 : 01012345678
-	Mobile string `json:"mobile" validate:"required,sf_mobile" example:"01012345678"`
-
 	//PersonaLink, 길이 2048 제한
 	PersonaLink string `json:"personaLink" validate:"max=2048" example:"https://www.youtube.com/channel/UCdfhK0yIMjmhcQ3gP-qpXRw"`
 
@@ -225,7 +230,7 @@ type UpdateCustomerInfoRequest struct {
 // @Tags (User) 어드민 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [어드민] 고객 정보 수정
-// @Description 고객 정보 수정하는 기능, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Description 고객 정보 수정하는 기능, 휴대폰 번호는 고객이 인증해서만 변경, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
 // @Accept json
 // @Produce json
 // @Param user_id path string true "고객 식별 아이디(UUID)"
@@ -237,7 +242,7 @@ func (c *UserController) updateCustomer(ctx echo.Context) error {
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "update customer, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "update customer, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -249,7 +254,6 @@ func (c *UserController) updateCustomer(ctx echo.Context) error {
 		ChannelName:  req.ChannelName,
 		ChannelLink:  req.ChannelLink,
 		Email:        req.Email,
-		Mobile:       req.Mobile,
 		PersonaLink:  req.PersonaLink,
 		OnedriveLink: req.OnedriveLink,
 		Memo:         req.Memo,
@@ -263,7 +267,127 @@ func (c *UserController) updateCustomer(ctx echo.Context) error {
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.ErrItemAlreadyExist) // TODO refactor
 	default:
-		log.WithError(err).Error(tag, "update customer, unhandled error useCase.UpdateCustomerUser")
+		echox.Log(ctx).WithError(err).Error(tag, "update customer, unhandled error useCase.UpdateCustomerUser")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateCustomerBusinessInfoRequest struct {
+	UserId uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// BusinessNumber, 사업자등록번호, 하이픈 있어도 됨
+	BusinessNumber string `json:"businessNumber" validate:"required,sf_business_number" example:"123-45-67891"`
+
+	// BusinessName, 상호, 길이 100 제한
+	BusinessName string `json:"businessName" validate:"required,max=100" example:"(주)스톡폴리오"`
+
+	// Representative, 대표자 이름, 길이 60 제한
+	Representative string `json:"representative" validate:"required,max=60" example:"홍길동"`
+} //@name UpdateCustomerBusinessInfoRequest
+
+// @Tags (User) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 사업자 정보 수정
+// @Description 세금계산서 발행에 쓰는 사업자등록번호, 상호, 대표자 저장, 사업자등록번호는 검증 번호까지 확인, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Param requestBody body UpdateCustomerBusinessInfoRequest true "사업자 정보"
+// @Success 204 "수정 완료"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류, 잘못된 사업자등록번호"
+// @Failure 404 {object} domain.ErrorResponse "없는 고객"
+// @Router /customer/{user_id}/business [put]
+func (c *UserController) updateCustomerBusinessInfo(ctx echo.Context) error {
+	var req UpdateCustomerBusinessInfoRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update customer business info, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateCustomerBusinessInfo(ctx.Request().Context(), domain.UpdateCustomerBusinessInfo{
+		UserId: req.UserId,
+		BusinessInfo: domain.BusinessInfo{
+			Number:         req.BusinessNumber,
+			Name:           req.BusinessName,
+			Representative: req.Representative,
+		},
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", req.UserId).
+			Error(tag, "updateCustomerBusinessInfo, unhandled error useCase.UpdateCustomerBusinessInfo")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateCustomerProfileRequest struct {
+	UserId uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	// ChannelName, 길이 100 제한
+	ChannelName string `json:"channelName" validate:"max=100" example:"밥굽남"`
+
+	// ChannelLink, 길이 2048 제한
+	ChannelLink string `json:"channelLink" validate:"max=2048" example:"https://www.youtube.com/channel/UCdfhK0yIMjmhcQ3gP-qpXRw"`
+
+	// PersonaLink, 길이 2048 제한
+	PersonaLink string `json:"personaLink" validate:"max=2048" example:"https://www.youtube.com/channel/UCdfhK0yIMjmhcQ3gP-qpXRw"`
+
+	// Memo, 형식 : text
+	Memo string `json:"memo" example:"이사람 까다로움"`
+} //@name UpdateCustomerProfileRequest
+
+// @Tags (User) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 프로필 수정
+// @Description 채널, 페르소나, 메모만 저장, 고객 프로필이 없던 계정이면 새로 만듦, 이름, 이메일, 휴대폰 번호는 고객 정보 수정으로, 이용권(구독) 정보는 이용권 API 로, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Param requestBody body UpdateCustomerProfileRequest true "고객 프로필"
+// @Success 204 "수정 완료"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Failure 404 {object} domain.ErrorResponse "없는 고객"
+// @Router /user/customer/{user_id}/profile [put]
+func (c *UserController) updateCustomerProfile(ctx echo.Context) error {
+	var req UpdateCustomerProfileRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "update customer profile, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateCustomerProfile(ctx.Request().Context(), domain.UpdateCustomerProfile{
+		UserId:      req.UserId,
+		ChannelName: req.ChannelName,
+		ChannelLink: req.ChannelLink,
+		PersonaLink: req.PersonaLink,
+		Memo:        req.Memo,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", req.UserId).
+			Error(tag, "updateCustomerProfile, unhandled error useCase.UpdateCustomerProfile")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -282,18 +406,20 @@ type DeleteCustomerRequest struct {
 // @Param user_id path string true "고객 식별 아이디(UUID)"
 // @Success 204 "삭제 완료"
 // @Router /customer/{user_id} [delete]
-func (c *UserController) deleteCustomerUser(ctx echo.Context) error {
+func (c *UserController) deleteCustomerUser(ctx echo.Context, userId uuid.UUID) error {
 	var req DeleteCustomerRequest
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "delete customer, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "delete customer, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 	err = c.useCase.DeleteCustomerUser(ctx.Request().Context(), domain.DeleteCustomerUser{
-		UserId: req.Id,
+		UserId:    req.Id,
+		DeletedBy: userId,
+		Ip:        ctx.RealIP(),
 	})
 
 	switch err {
@@ -302,13 +428,137 @@ func (c *UserController) deleteCustomerUser(ctx echo.Context) error {
 	case domain.ErrItemNotFound:
 		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
 	default:
-		log.WithError(err).Error(tag, "delete customer failed")
+		echox.Log(ctx).WithError(err).Error(tag, "delete customer failed")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
 
+type RestoreCustomerRequest struct {
+	// Id, 유저 Id
+	Id uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+} //@name RestoreCustomerRequest
+
+// @Tags (User) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 삭제된 고객 복구
+// @Description 삭제를 취소하고 다시 로그인할 수 있게 함, 삭제할 때 폐기된 로그인 토큰은 복구하지 않음, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param user_id path string true "고객 식별 아이디(UUID)"
+// @Success 204 "복구 완료"
+// @Failure 404 {object} domain.ErrorResponse "없거나 삭제되지 않은 고객"
+// @Failure 409 {object} domain.ErrorResponse "삭제 후 같은 이메일로 다른 계정이 생김, 다른 고객으로 병합된 계정"
+// @Router /user/customer/{user_id}/restore [patch]
+func (c *UserController) restoreCustomerUser(ctx echo.Context, userId uuid.UUID) error {
+	var req RestoreCustomerRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "restore customer, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.RestoreCustomerUser(ctx.Request().Context(), domain.RestoreUser{
+		UserId:     req.Id,
+		RestoredBy: userId,
+		Ip:         ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		echox.Log(ctx).WithField("userId", req.Id).
+			WithField("restoredBy", userId).
+			Info(tag, "customer restored")
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemAlreadyExist, domain.ErrUserMerged:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", req.Id).
+			Error(tag, "restoreCustomerUser, unhandled error useCase.RestoreCustomerUser")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type MergeCustomerRequest struct {
+	// SurvivorId 남길 고객 Id
+	SurvivorId uuid.UUID `json:"survivorId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// DuplicateId 합친 뒤 삭제할 중복 고객 Id
+	DuplicateId uuid.UUID `json:"duplicateId" validate:"required,nefield=SurvivorId" example:"650e8400-e29b-41d4-a716-446655440000"`
+} //@name MergeCustomerRequest
+
+type MergeCustomerResponse struct {
+	SurvivorId   uuid.UUID `json:"survivorId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	MovedOrders  int64     `json:"movedOrders" validate:"required" example:"3"`
+	MovedTickets int64     `json:"movedTickets" validate:"required" example:"1"`
+	MovedCredit  int64     `json:"movedCredit" validate:"required" example:"5000"`
+} //@name MergeCustomerResponse
+
+// @Tags (User) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 중복 고객 합치기
+// @Description 중복 고객의 의뢰, 이용권, 크레딧, 메모, 추가 항목을 남길 고객으로 옮기고 중복 고객은 삭제(휴지통), 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body MergeCustomerRequest true "합칠 고객"
+// @Success 200 {object} MergeCustomerResponse "합치기 완료"
+// @Failure 404 {object} domain.ErrorResponse "없거나 삭제된 고객"
+// @Failure 409 {object} domain.ErrorResponse "두 고객 모두 진행 중인 의뢰가 있음"
+// @Router /user/customer/merge [post]
+func (c *UserController) mergeCustomer(ctx echo.Context, userId uuid.UUID) error {
+	var req MergeCustomerRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "merge customer, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	res, err := c.useCase.MergeCustomerUser(ctx.Request().Context(), domain.MergeCustomerUser{
+		SurvivorId:  req.SurvivorId,
+		DuplicateId: req.DuplicateId,
+		MergedBy:    userId,
+		Ip:          ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, MergeCustomerResponse{
+			SurvivorId:   res.SurvivorId,
+			MovedOrders:  res.MovedOrders,
+			MovedTickets: res.MovedTickets,
+			MovedCredit:  res.MovedCredit,
+		})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemAlreadyExist:
+		return ctx.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("survivorId", req.SurvivorId).
+			WithField("duplicateId", req.DuplicateId).
+			Error(tag, "mergeCustomer, unhandled error useCase.MergeCustomerUser")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+// customFieldQueryPrefix 고객 목록에서 추가 항목 필터용 쿼리 파라미터 접두어
+const customFieldQueryPrefix = "cf."
+
 type FetchCustomerRequest struct {
 	Query string `json:"-" query:"q"`
+	Sort  string `json:"-" query:"sort"`
+	Desc  bool   `json:"-" query:"desc"`
+	// View 저장된 보기 아이디, 직접 지정한 값이 보기보다 우선
+	View *uuid.UUID `json:"-" query:"view"`
 }
 
 type CustomerInfoResponse struct {
@@ -319,10 +569,30 @@ type CustomerInfoResponse struct {
 	Email       string    `json:"email" validate:"required,email" example:"example@example.com"`
 	Mobile      string    `json:"mobile" validate:"required" example:"01012345678"`
 	CreatedAt   time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+
+	CustomFields map[string]interface{} `json:"customFields" swaggertype:"object"`
 } // @name CustomerInfoResponse
 
+func (CustomerInfoResponse) FieldResource() string {
+	return domain.FieldResourceCustomerList
+}
+
 type CustomerInfoListResponse []CustomerInfoResponse
 
+func customerInfoResponseOf(src domain.CustomerInfoData) CustomerInfoResponse {
+	return CustomerInfoResponse{
+		UserId:      src.UserId,
+		Name:        src.Name,
+		ChannelName: src.ChannelName,
+		ChannelLink: src.ChannelLink,
+		Email:       src.Email,
+		Mobile:      src.Mobile,
+		CreatedAt:   src.CreatedAt,
+
+		CustomFields: src.CustomFields,
+	}
+}
+
 // @Tags (User) 어드민 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [어드민] 고객 목록
@@ -330,24 +600,38 @@ type CustomerInfoListResponse []CustomerInfoResponse
 // @Accept json
 // @Produce json
 // @Param q query string false "검색어"
+// @Param cf.{key} query string false "추가 항목 값 필터 (ex. cf.contract_type=연간)"
+// @Param sort query string false "정렬 기준" Enums(createdAt, name)
+// @Param desc query bool false "내림차순 여부"
+// @Param view query string false "저장된 보기 식별 아이디(UUID)"
 // @Success 200 {object} CustomerInfoListResponse "성공"
+// @Failure 404 {object} domain.ErrorResponse "없는 보기"
 // @Router /customer [get]
-func (c *UserController) fetchCustomer(ctx echo.Context) error {
+func (c *UserController) fetchCustomer(ctx echo.Context, userId uuid.UUID) error {
 	var req FetchCustomerRequest
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "fetch full customer, request data bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch full customer, request data bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 
-	list, err := c.useCase.FetchAllCustomer(ctx.Request().Context(), domain.FetchCustomerOption{
-		Query: req.Query,
-	})
-
+	option, err := customerOptionOf(ctx, req, userId)
 	if err != nil {
-		log.WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.FetchAllCustomer(ctx.Request().Context(), option)
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 
@@ -358,21 +642,127 @@ func (c *UserController) fetchCustomer(ctx echo.Context) error {
 	res := make(CustomerInfoListResponse, len(list))
 
 	for i := range list {
-		src := list[i]
-		res[i] = CustomerInfoResponse{
-			UserId:      src.UserId,
-			Name:        src.Name,
-			ChannelName: src.ChannelName,
-			ChannelLink: src.ChannelLink,
-			Email:       src.Email,
-			Mobile:      src.Mobile,
-			CreatedAt:   src.CreatedAt,
-		}
+		res[i] = customerInfoResponseOf(list[i])
 	}
 
 	return ctx.JSON(http.StatusOK, res)
 }
 
+// customerOptionOf 목록 요청 값과 cf. 쿼리 파라미터로 조회 조건을 만듦, 잘못된 정렬, 추가 항목 키면 에러
+func customerOptionOf(ctx echo.Context, req FetchCustomerRequest, viewerId uuid.UUID) (option domain.FetchCustomerOption, err error) {
+	if req.Sort != "" && !domain.CustomerSortKey(req.Sort).IsValid() {
+		err = errors.New("invalid sort")
+		return
+	}
+
+	option = domain.FetchCustomerOption{
+		Query:    req.Query,
+		Sort:     domain.CustomerSortKey(req.Sort),
+		SortDesc: req.Desc,
+		ViewId:   req.View,
+		ViewerId: viewerId,
+	}
+	for name, values := range ctx.QueryParams() {
+		if !strings.HasPrefix(name, customFieldQueryPrefix) || len(values) == 0 {
+			continue
+		}
+
+		key := strings.TrimPrefix(name, customFieldQueryPrefix)
+		if !domain.IsCustomFieldKey(key) {
+			err = errors.New("invalid custom field key")
+			return
+		}
+		if option.CustomFields == nil {
+			option.CustomFields = make(map[string]string)
+		}
+		option.CustomFields[key] = values[0]
+	}
+	return
+}
+
+type FetchCustomerPageRequest struct {
+	FetchCustomerRequest
+
+	Page int `json:"-" query:"page" validate:"omitempty,min=1"`
+	Size int `json:"-" query:"size" validate:"omitempty,min=1,max=100"`
+}
+
+type CustomerInfoPageResponse struct {
+	List  []CustomerInfoResponse `json:"list" validate:"required"`
+	Total int64                  `json:"total" validate:"required" example:"123"`
+	Page  int                    `json:"page" validate:"required" example:"1"`
+	Size  int                    `json:"size" validate:"required" example:"20"`
+} // @name CustomerInfoPageResponse
+
+func (CustomerInfoPageResponse) FieldResource() string {
+	return domain.FieldResourceCustomerList
+}
+
+func (CustomerInfoPageResponse) FieldResourceKey() string {
+	return "list"
+}
+
+// @Tags (User) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 목록 (페이지)
+// @Description 고객 목록을 페이지 단위로 가져오는 기능, 검색어는 이름, 이메일, 연락처(하이픈 무시) 일부 일치, total 은 조건에 맞는 전체 고객 수, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param q query string false "검색어"
+// @Param cf.{key} query string false "추가 항목 값 필터 (ex. cf.contract_type=연간)"
+// @Param sort query string false "정렬 기준" Enums(createdAt, name)
+// @Param desc query bool false "내림차순 여부"
+// @Param view query string false "저장된 보기 식별 아이디(UUID)"
+// @Param page query int false "페이지, 1 부터 (기본 1)"
+// @Param size query int false "페이지 크기 (기본 20, 최대 100)"
+// @Success 200 {object} CustomerInfoPageResponse "성공, 결과가 없어도 빈 list"
+// @Failure 400 {object} domain.ErrorResponse "잘못된 정렬, 추가 항목 키, 페이지"
+// @Failure 404 {object} domain.ErrorResponse "없는 보기"
+// @Router /user/customer [get]
+func (c *UserController) fetchCustomers(ctx echo.Context, userId uuid.UUID) error {
+	var req FetchCustomerPageRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch customers, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	option, err := customerOptionOf(ctx, req.FetchCustomerRequest, userId)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	page, err := c.useCase.FetchCustomers(ctx.Request().Context(), domain.FetchCustomers{
+		FetchCustomerOption: option,
+		Page:                req.Page,
+		Size:                req.Size,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "fetchCustomers, unhandled error useCase.FetchCustomers")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := CustomerInfoPageResponse{
+		List:  make([]CustomerInfoResponse, len(page.List)),
+		Total: page.Total,
+		Page:  page.Page,
+		Size:  page.Size,
+	}
+	for i := range page.List {
+		res.List[i] = customerInfoResponseOf(page.List[i])
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
 
 type CustomerDetailInfoResponse struct {
 	UserId       uuid.UUID `json:"userId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -384,8 +774,29 @@ type CustomerDetailInfoResponse struct {
 	PersonaLink  string    `json:"personaLink" validate:"required" example:"https://www.youtube.com/channel/UCdfhK0yIMjmhcQ3gP-qpXRw"`
 	OnedriveLink string    `json:"onedriveLink" validate:"required" example:"https://www.youtube.com/channel/UCdfhK0yIMjmhcQ3gP-qpXRw"`
 	Memo         string    `json:"memo" example:"이사람 까다로움"`
+
+	// EmailUndeliverable, true 면 메일 발송 서비스가 영구 반송, 수신 거부 신고를 알린 주소, 이메일이 바뀌거나 다시 전달되면 false
+	EmailUndeliverable       bool       `json:"emailUndeliverable" validate:"required" example:"false"`
+	EmailUndeliverableAt     *time.Time `json:"emailUndeliverableAt" example:"2024-05-01T10:00:00+09:00"`
+	EmailUndeliverableReason *string    `json:"emailUndeliverableReason" example:"smtp; 550 5.1.1 user unknown"`
+
+	// BusinessNumber, 사업자등록번호(하이픈 없음), 등록 전이면 null
+	BusinessNumber *string `json:"businessNumber" example:"1234567891"`
+	BusinessName   *string `json:"businessName" example:"(주)스톡폴리오"`
+	Representative *string `json:"representative" example:"홍길동"`
+
+	// StorageUsed, 올린 파일 용량(bytes), 진행 중인 업로드 포함
+	StorageUsed int64 `json:"storageUsed" validate:"required" example:"53687091200"`
+	// StorageQuota, 파일 저장 한도(bytes)
+	StorageQuota int64 `json:"storageQuota" validate:"required" example:"85899345920"`
+
+	CustomFields map[string]interface{} `json:"customFields" swaggertype:"object"`
 } // @name CustomerDetailInfoResponse
 
+func (CustomerDetailInfoResponse) FieldResource() string {
+	return domain.FieldResourceCustomerDetail
+}
+
 // @Tags (User) 어드민 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [어드민] 고객 상세 정보
@@ -401,7 +812,7 @@ func (c *UserController) getCustomerDetailInfo(ctx echo.Context) error {
 	}
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "get customer detail info, request data bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "get customer detail info, request data bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
@@ -411,7 +822,7 @@ func (c *UserController) getCustomerDetailInfo(ctx echo.Context) error {
 
 	switch err {
 	case nil:
-		return ctx.JSON(http.StatusOK, CustomerDetailInfoResponse{
+		res := CustomerDetailInfoResponse{
 			UserId:       detail.UserId,
 			Name:         detail.Name,
 			ChannelName:  detail.ChannelName,
@@ -421,17 +832,32 @@ func (c *UserController) getCustomerDetailInfo(ctx echo.Context) error {
 			PersonaLink:  detail.PersonaLink,
 			OnedriveLink: detail.OnedriveLink,
 			Memo:         detail.Memo,
-		})
+			StorageUsed:  detail.StorageUsage.Used + detail.StorageUsage.Reserved,
+			StorageQuota: detail.StorageUsage.Quota,
+
+			EmailUndeliverable:       detail.EmailUndeliverableAt != nil,
+			EmailUndeliverableAt:     detail.EmailUndeliverableAt,
+			EmailUndeliverableReason: detail.EmailUndeliverableReason,
+
+			CustomFields: detail.CustomFields,
+		}
+		if business := detail.Business; business != nil {
+			res.BusinessNumber = &business.Number
+			res.BusinessName = &business.Name
+			res.Representative = &business.Representative
+		}
+		return ctx.JSON(http.StatusOK, res)
 	case domain.ErrItemNotFound:
 		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
 	default:
-		log.WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
+		echox.Log(ctx).WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
 
 type FetchAdminRequest struct {
-	Query string `json:"-" query:"q"`
+	Query  string            `json:"-" query:"q"`
+	Skills []domain.SkillTag `json:"-" query:"skill" validate:"max=3,dive,oneof=MOTION_GRAPHICS SHORTS COLOR_GRADING"`
 }
 
 type AdminInfoResponse struct {
@@ -440,8 +866,15 @@ type AdminInfoResponse struct {
 	Nickname  string    `json:"nickname" validate:"required" example:"(대충 어드민 닉네임)"`
 	Email     string    `json:"email" validate:"required" example:"example@example.com"`
 	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+
+	// Skills, 할 수 있는 작업, 없으면 빈 배열
+	Skills []domain.SkillTag `json:"skills" validate:"required" example:"MOTION_GRAPHICS,SHORTS" enums:"MOTION_GRAPHICS,SHORTS,COLOR_GRADING"`
 } // @name AdminInfoResponse
 
+func (AdminInfoResponse) FieldResource() string {
+	return domain.FieldResourceAdmin
+}
+
 type AdminInfoListResponse []AdminInfoResponse
 
 // @Tags (User) 어드민 기능
@@ -451,24 +884,26 @@ type AdminInfoListResponse []AdminInfoResponse
 // @Accept json
 // @Produce json
 // @Param q query string false "검색어"
+// @Param skill query []string false "모두 할 수 있는 작업, 여러 번 지정 가능" collectionFormat(multi) Enums(MOTION_GRAPHICS, SHORTS, COLOR_GRADING)
 // @Success 200 {object} AdminInfoListResponse "성공"
 // @Router /admin [get]
 func (c *UserController) fetchAdmin(ctx echo.Context) error {
 	var req FetchAdminRequest
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "fetch full admin, request data bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch full admin, request data bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 
 	list, err := c.useCase.FetchAllAdmin(ctx.Request().Context(), domain.FetchAdminOption{
-		Query: req.Query,
+		Query:  req.Query,
+		Skills: req.Skills,
 	})
 
 	if err != nil {
-		log.WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
+		echox.Log(ctx).WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 
@@ -485,6 +920,7 @@ func (c *UserController) fetchAdmin(ctx echo.Context) error {
 			Name:      src.Name,
 			Nickname:  src.Nickname,
 			Email:     src.Email,
+			Skills:    src.Skills,
 			CreatedAt: src.CreatedAt,
 		}
 	}
@@ -492,6 +928,80 @@ func (c *UserController) fetchAdmin(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, res)
 }
 
+type FetchAdminUserRequest struct {
+	Query  string            `json:"-" query:"q"`
+	Status string            `json:"-" query:"status" validate:"omitempty,oneof=ACTIVE DELETED ALL"`
+	Skills []domain.SkillTag `json:"-" query:"skill" validate:"max=3,dive,oneof=MOTION_GRAPHICS SHORTS COLOR_GRADING"`
+}
+
+type AdminUserInfoResponse struct {
+	AdminInfoResponse
+	Role   string `json:"role" validate:"required" example:"ADMIN" enums:"ADMIN,SUPER_ADMIN"`
+	Status string `json:"status" validate:"required" example:"ACTIVE" enums:"ACTIVE,PASSWORD_CHANGE_REQUIRED,DELETED"`
+	// DeletedAt, 삭제된 어드민만
+	DeletedAt *time.Time `json:"deletedAt,omitempty" example:"2021-11-02T04:44:18+00:00"`
+} // @name AdminUserInfoResponse
+
+type AdminUserInfoListResponse []AdminUserInfoResponse
+
+// @Tags (User) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 어드민 계정 목록
+// @Description 어드민, 슈퍼어드민 계정과 어드민 정보(이름, 닉네임), 최근 생성 순, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param q query string false "검색어, 이름, 닉네임, 이메일"
+// @Param status query string false "삭제 여부, 기본 ACTIVE" Enums(ACTIVE, DELETED, ALL)
+// @Param skill query []string false "모두 할 수 있는 작업, 여러 번 지정 가능" collectionFormat(multi) Enums(MOTION_GRAPHICS, SHORTS, COLOR_GRADING)
+// @Success 200 {object} AdminUserInfoListResponse "성공"
+// @Success 204 "조건에 맞는 어드민 없음"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Router /user/admin [get]
+func (c *UserController) fetchAdminUsers(ctx echo.Context) error {
+	var req FetchAdminUserRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch admin users, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	list, err := c.useCase.FetchAllAdmin(ctx.Request().Context(), domain.FetchAdminOption{
+		Query:  req.Query,
+		Status: domain.AdminStatusFilter(req.Status),
+		Skills: req.Skills,
+	})
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "fetchAdminUsers, unhandled error useCase.FetchAllAdmin")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	if len(list) == 0 {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	res := make(AdminUserInfoListResponse, len(list))
+	for i := range list {
+		src := list[i]
+		res[i] = AdminUserInfoResponse{
+			AdminInfoResponse: AdminInfoResponse{
+				UserId:    src.UserId,
+				Name:      src.Name,
+				Nickname:  src.Nickname,
+				Email:     src.Email,
+				Skills:    src.Skills,
+				CreatedAt: src.CreatedAt,
+			},
+			Role:      string(src.Role),
+			Status:    string(src.Status),
+			DeletedAt: src.DeletedAt,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, res)
+}
+
 type AdminCreatorInfoResponse struct {
 	UserId   uuid.UUID `json:"userId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
 	Name     string    `json:"name" validate:"required" example:"(대충 편집자 이름)"`
@@ -507,24 +1017,26 @@ type AdminCreatorInfoListResponse []AdminCreatorInfoResponse
 // @Accept json
 // @Produce json
 // @Param q query string false "검색어"
+// @Param skill query []string false "모두 할 수 있는 작업, 여러 번 지정 가능" collectionFormat(multi) Enums(MOTION_GRAPHICS, SHORTS, COLOR_GRADING)
 // @Success 200 {object} AdminCreatorInfoListResponse "성공"
 // @Router /admin/creator [get]
 func (c *UserController) fetchAdminCreator(ctx echo.Context) error {
 	var req FetchAdminRequest
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "fetch full admin, request data bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "fetch full admin, request data bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 
 	list, err := c.useCase.FetchAllAdmin(ctx.Request().Context(), domain.FetchAdminOption{
-		Query: req.Query,
+		Query:  req.Query,
+		Skills: req.Skills,
 	})
 
 	if err != nil {
-		log.WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
+		echox.Log(ctx).WithError(err).Error(tag, "fetch full customer, unhandled error useCase.FetchAllCustomer")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 
@@ -537,9 +1049,9 @@ func (c *UserController) fetchAdminCreator(ctx echo.Context) error {
 	for i := range list {
 		src := list[i]
 		res[i] = AdminCreatorInfoResponse{
-			UserId:    src.UserId,
-			Name:      src.Name,
-			Nickname:  src.Nickname,
+			UserId:   src.UserId,
+			Name:     src.Name,
+			Nickname: src.Nickname,
 		}
 	}
 
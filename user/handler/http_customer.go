@@ -3,8 +3,8 @@ package handler
 import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
 	"net/http"
 	"time"
 )
@@ -12,9 +12,9 @@ import (
 type CustomerSimpleNotify string
 
 const (
-	CustomerSimpleNotifyNone CustomerSimpleNotify = "NONE"
+	CustomerSimpleNotifyNone             CustomerSimpleNotify = "NONE"
 	CustomerSimpleNotifyNeedBuySubscribe CustomerSimpleNotify = "NEED_BUY_SUBSCRIBE"
-	CustomerSimpleNotifyNeedBuyOneEdit CustomerSimpleNotify = "NEED_BUY_ONE_EDIT"
+	CustomerSimpleNotifyNeedBuyOneEdit   CustomerSimpleNotify = "NEED_BUY_ONE_EDIT"
 )
 
 type CustomerSimpleInfoResponse struct {
@@ -25,6 +25,11 @@ type CustomerSimpleInfoResponse struct {
 	RemainingOrderCount uint8      `json:"remainingOrderCount" validate:"required" example:"4"`
 	OnedriveLink        string     `json:"onedriveLink" validate:"required" example:"(대충 링크)"`
 
+	// StorageUsed, 올린 파일 용량(bytes), 진행 중인 업로드 포함
+	StorageUsed int64 `json:"storageUsed" validate:"required" example:"53687091200"`
+	// StorageQuota, 파일 저장 한도(bytes)
+	StorageQuota int64 `json:"storageQuota" validate:"required" example:"85899345920"`
+
 	// Simple notification :
 	// * NONE - 없음
 	// * NEED_BUY_SUBSCRIBE - 구독권 구매 필요
@@ -32,6 +37,10 @@ type CustomerSimpleInfoResponse struct {
 	SimpleNotify CustomerSimpleNotify `json:"simpleNotify" example:"NONE" enums:"NONE,NEED_BUY_SUBSCRIBE,NEED_BUY_ONE_EDIT"`
 } // @name CustomerSimpleInfoResponse
 
+func (CustomerSimpleInfoResponse) FieldResource() string {
+	return domain.FieldResourceCustomerSelf
+}
+
 // @Tags (User) 고객 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [고객] 내 정보 가져오기
@@ -43,7 +52,7 @@ type CustomerSimpleInfoResponse struct {
 func (c *UserController) getMyCustomerInfo(ctx echo.Context, userId uuid.UUID) error {
 	out, err := c.useCase.CustomerSubscribeInfoByUserId(ctx.Request().Context(), userId)
 	if err != nil {
-		log.WithError(err).
+		echox.Log(ctx).WithError(err).
 			WithField("in", userId).
 			Error(tag, "getMyCustomerInfo, unhandled error useCase.GetCustomerInfoDetailByUserId")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
@@ -56,6 +65,8 @@ func (c *UserController) getMyCustomerInfo(ctx echo.Context, userId uuid.UUID) e
 		SubscribeEnd:        out.SubscribeEnd,
 		RemainingOrderCount: out.RemainingOrderCount,
 		OnedriveLink:        out.OnedriveLink,
+		StorageUsed:         out.StorageUsage.Used + out.StorageUsage.Reserved,
+		StorageQuota:        out.StorageUsage.Quota,
 		SimpleNotify:        CustomerSimpleNotifyNone,
 	}
 
@@ -71,9 +82,178 @@ func (c *UserController) getMyCustomerInfo(ctx echo.Context, userId uuid.UUID) e
 		now.After(*out.SubscribeEnd) {
 		res.SimpleNotify = CustomerSimpleNotifyNeedBuySubscribe
 	} else if out.SubscribeStart == nil || out.SubscribeEnd == nil {
-		log.WithField("out", out).Error("의도 하지 않는 구독 일자")
+		echox.Log(ctx).WithField("out", out).Error("의도 하지 않는 구독 일자")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 
 	return ctx.JSON(http.StatusOK, res)
-}
\ No newline at end of file
+}
+
+type CustomerMyProfileResponse struct {
+	UserId       uuid.UUID `json:"userId" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name         string    `json:"name" validate:"required" example:"나 고객"`
+	ChannelName  string    `json:"channelName" validate:"required" example:"(대충 채널 이름)"`
+	ChannelLink  string    `json:"channelLink" validate:"required" example:"(대충 채널 url 링크)"`
+	Email        string    `json:"email" validate:"required" example:"example@example.com"`
+	Mobile       string    `json:"mobile" validate:"required" example:"01012345678"`
+	PersonaLink  string    `json:"personaLink" validate:"required" example:"https://www.youtube.com/channel/UCdfhK0yIMjmhcQ3gP-qpXRw"`
+	OnedriveLink string    `json:"onedriveLink" validate:"required" example:"(대충 링크)"`
+
+	// BusinessNumber, 사업자등록번호(하이픈 없음), 등록 전이면 null
+	BusinessNumber *string `json:"businessNumber" example:"1234567891"`
+	BusinessName   *string `json:"businessName" example:"(주)스톡폴리오"`
+	Representative *string `json:"representative" example:"홍길동"`
+
+	CreatedAt time.Time `json:"createdAt" validate:"required" example:"2021-10-27T04:44:18+00:00"`
+} // @name CustomerMyProfileResponse
+
+func (CustomerMyProfileResponse) FieldResource() string {
+	return domain.FieldResourceCustomerSelf
+}
+
+// @Tags (User) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 내 프로필 가져오기
+// @Description 토큰의 유저 아이디로 내 계정, 고객 정보를 가져옴, 관리자 메모는 포함하지 않음, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Success 200 {object} CustomerMyProfileResponse "성공"
+// @Failure 403 {object} domain.ErrorResponse "고객이 아님"
+// @Failure 404 {object} domain.ErrorResponse "고객 정보 없음"
+// @Router /user/customer/me [get]
+func (c *UserController) getMyCustomerProfile(ctx echo.Context, userId uuid.UUID) error {
+	detail, err := c.useCase.GetCustomerInfoDetailByUserId(ctx.Request().Context(), userId)
+
+	switch err {
+	case nil:
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "getMyCustomerProfile, unhandled error useCase.GetCustomerInfoDetailByUserId")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	res := CustomerMyProfileResponse{
+		UserId:       detail.UserId,
+		Name:         detail.Name,
+		ChannelName:  detail.ChannelName,
+		ChannelLink:  detail.ChannelLink,
+		Email:        detail.Email,
+		Mobile:       detail.Mobile,
+		PersonaLink:  detail.PersonaLink,
+		OnedriveLink: detail.OnedriveLink,
+		CreatedAt:    detail.CreatedAt,
+	}
+	if business := detail.Business; business != nil {
+		res.BusinessNumber = &business.Number
+		res.BusinessName = &business.Name
+		res.Representative = &business.Representative
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+type MobileVerifyRequest struct {
+	// Mobile, 바꿀 번호, 형식 : 01012345678
+	Mobile string `json:"mobile" validate:"required,sf_mobile" example:"01012345678"`
+} // @name MobileVerifyRequest
+
+// @Tags (User) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 휴대폰 번호 변경 인증 번호 요청
+// @Description 바꿀 번호로 6자리 인증 번호 문자 발송, 5분 동안 유효, 새로 요청하면 이전 번호는 무효, 한 계정에 1시간에 5번까지, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body MobileVerifyRequest true "바꿀 번호"
+// @Success 202
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류, 지금과 같은 번호"
+// @Failure 404 {object} domain.ErrorResponse "고객 정보 없음"
+// @Failure 429 {object} domain.ErrorResponse "요청이 너무 잦음"
+// @Router /user/customer/mobile/verify-request [post]
+func (c *UserController) requestMobileVerification(ctx echo.Context, userId uuid.UUID) error {
+	var req MobileVerifyRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "requestMobileVerification, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.RequestMobileVerification(ctx.Request().Context(), domain.RequestMobileVerification{
+		UserId: userId,
+		Mobile: req.Mobile,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusAccepted)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrTooManyRequests:
+		return ctx.JSON(http.StatusTooManyRequests, domain.TooManyRequestsResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "requestMobileVerification, unhandled error useCase.RequestMobileVerification")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateMobileRequest struct {
+	// Mobile, 인증 번호를 요청한 번호, 형식 : 01012345678
+	Mobile string `json:"mobile" validate:"required,sf_mobile" example:"01012345678"`
+	// Code, 문자로 받은 6자리 인증 번호
+	Code string `json:"code" validate:"required,len=6,numeric" example:"123456"`
+} // @name UpdateMobileRequest
+
+// @Tags (User) 고객 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [고객] 휴대폰 번호 변경
+// @Description 문자로 받은 인증 번호가 맞을 때만 변경, 인증 번호 하나로 5번까지 틀릴 수 있음, 비밀번호는 바뀌지 않음, 역할(role)이 'CUSTOMER' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body UpdateMobileRequest true "바꿀 번호, 인증 번호"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류, 인증 번호가 틀림 (U-11)"
+// @Failure 404 {object} domain.ErrorResponse "요청한 인증 없음, 이미 사용한 인증"
+// @Failure 410 {object} domain.ErrorResponse "인증 번호 만료 (U-12)"
+// @Failure 429 {object} domain.ErrorResponse "너무 많이 틀림, 인증 번호를 다시 요청해야함"
+// @Router /user/customer/mobile [patch]
+func (c *UserController) updateMyMobile(ctx echo.Context, userId uuid.UUID) error {
+	var req UpdateMobileRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "updateMyMobile, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateCustomerMobile(ctx.Request().Context(), domain.UpdateCustomerMobile{
+		UserId: userId,
+		Mobile: req.Mobile,
+		Code:   req.Code,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrVerificationCodeMismatch:
+		return ctx.JSON(http.StatusBadRequest, domain.VerificationCodeMismatchResponse)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrTokenExpired:
+		return ctx.JSON(http.StatusGone, domain.VerificationCodeExpiredResponse)
+	case domain.ErrTooManyRequests:
+		return ctx.JSON(http.StatusTooManyRequests, domain.TooManyRequestsResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("userId", userId).
+			Error(tag, "updateMyMobile, unhandled error useCase.UpdateCustomerMobile")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
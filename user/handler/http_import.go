@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+var (
+	errImportMissingColumn = errors.New("csv header must have name, email, mobile")
+	errImportTooManyRows   = errors.New("too many rows")
+)
+
+type CustomerImportRowResponse struct {
+	// Line, CSV 줄 번호 (머리글이 1)
+	Line    int  `json:"line" validate:"required" example:"3"`
+	Success bool `json:"success" validate:"required" example:"false"`
+	// UserId, 만든 고객 아이디, 성공한 행만
+	UserId *uuid.UUID `json:"userId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Column, Failure 실패한 행만
+	Column  string `json:"column,omitempty" example:"email" enums:"name,email,mobile"`
+	Failure string `json:"failure,omitempty" example:"ALREADY_EXIST" enums:"REQUIRED,INVALID,DUPLICATED,ALREADY_EXIST"`
+} // @name CustomerImportRowResponse
+
+type CustomerImportResponse struct {
+	Created int                         `json:"created" validate:"required" example:"40"`
+	Failed  int                         `json:"failed" validate:"required" example:"2"`
+	Results []CustomerImportRowResponse `json:"results" validate:"required"`
+} // @name CustomerImportResponse
+
+// @Tags (User) 어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [어드민] 고객 CSV 일괄 생성
+// @Description multipart/form-data 의 file 필드로 CSV(UTF-8, 최대 500행, 1MB)를 올림, 머리글은 name, email, mobile 필수
+// @Description 행마다 고객 생성과 같은 형식 검사, 이미 있거나 파일 안에서 겹치는 이메일은 건너뛰고 나머지를 한 번에 생성, 역할(role)이 'ADMIN', 'SUPER_ADMIN' 이여야함
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "CSV 파일"
+// @Success 200 {object} CustomerImportResponse "행별 결과"
+// @Failure 400 {object} domain.ErrorResponse "CSV 형식 오류, 필수 머리글 없음, 행 수 초과"
+// @Failure 413 {object} domain.ErrorResponse "파일 크기 초과"
+// @Router /user/customer/bulk [post]
+func (c *UserController) importCustomers(ctx echo.Context) error {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "importCustomers, form file error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	if header.Size > domain.CustomerImportMaxSize {
+		return ctx.JSON(http.StatusRequestEntityTooLarge, domain.ErrorResponse{Message: "file too large"})
+	}
+
+	body, err := header.Open()
+	if err != nil {
+		echox.Log(ctx).WithError(err).Error(tag, "importCustomers, form file open error")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+	defer body.Close()
+
+	rows, err := readCustomerImportRows(body)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "importCustomers, csv read error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	// 형식이 틀린 행은 바로 결과에 넣고 나머지만 생성
+	results := make([]domain.CustomerImportResult, len(rows))
+	var valid []domain.CustomerImportRow
+	var validAt []int
+	for i, row := range rows {
+		results[i].Line = row.Line
+		column, failure := validateCustomerImportRow(ctx, row)
+		if failure != "" {
+			results[i].Column = column
+			results[i].Failure = failure
+			continue
+		}
+		valid = append(valid, row)
+		validAt = append(validAt, i)
+	}
+
+	created, err := c.useCase.ImportCustomers(ctx.Request().Context(), domain.ImportCustomers{
+		Rows: valid,
+	})
+
+	switch err {
+	case nil:
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("rows", len(valid)).
+			Error(tag, "importCustomers, unhandled error useCase.ImportCustomers")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+
+	for i := range created {
+		results[validAt[i]] = created[i]
+	}
+
+	res := CustomerImportResponse{
+		Results: make([]CustomerImportRowResponse, len(results)),
+	}
+	for i, src := range results {
+		res.Results[i] = CustomerImportRowResponse{
+			Line:    src.Line,
+			Success: src.IsSuccess(),
+			UserId:  src.UserId,
+			Column:  string(src.Column),
+			Failure: string(src.Failure),
+		}
+		if src.IsSuccess() {
+			res.Created++
+		} else {
+			res.Failed++
+		}
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// validateCustomerImportRow 고객 생성 요청(CreateCustomerRequest)과 같은 검사, 첫 번째 오류만
+func validateCustomerImportRow(ctx echo.Context, row domain.CustomerImportRow) (column domain.CustomerImportColumn, failure domain.CustomerImportFailure) {
+	err := ctx.Validate(&CreateCustomerRequest{
+		Name:   row.Name,
+		Email:  row.Email,
+		Mobile: row.Mobile,
+	})
+	if err == nil {
+		return
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) || len(fieldErrors) == 0 {
+		return domain.CustomerImportColumnName, domain.CustomerImportInvalid
+	}
+
+	column = domain.CustomerImportColumn(strings.ToLower(fieldErrors[0].Field()))
+	failure = domain.CustomerImportInvalid
+	if fieldErrors[0].Tag() == "required" {
+		failure = domain.CustomerImportRequired
+	}
+	return
+}
+
+// readCustomerImportRows 첫 행은 머리글, 빈 행은 건너뜀
+func readCustomerImportRows(r io.Reader) (rows []domain.CustomerImportRow, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	head, err := reader.Read()
+	if err != nil {
+		return
+	}
+
+	columns := make(map[domain.CustomerImportColumn]int, len(head))
+	for i, name := range head {
+		// 엑셀에서 저장한 UTF-8 CSV 는 BOM 으로 시작
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[domain.CustomerImportColumn(strings.ToLower(strings.TrimSpace(name)))] = i
+	}
+	for _, required := range []domain.CustomerImportColumn{
+		domain.CustomerImportColumnName,
+		domain.CustomerImportColumnEmail,
+		domain.CustomerImportColumnMobile,
+	} {
+		if _, ok := columns[required]; !ok {
+			err = errImportMissingColumn
+			return
+		}
+	}
+
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			return
+		}
+		if readErr != nil {
+			err = readErr
+			return
+		}
+
+		value := func(column domain.CustomerImportColumn) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		if len(rows) == domain.CustomerImportMaxRows {
+			err = errImportTooManyRows
+			return
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, domain.CustomerImportRow{
+			Line:  line,
+			Name:  value(domain.CustomerImportColumnName),
+			Email: value(domain.CustomerImportColumnEmail),
+			// 010-1234-5678 형식으로 적은 파일이 많아 하이픈은 빼고 검사
+			Mobile: strings.ReplaceAll(value(domain.CustomerImportColumnMobile), "-", ""),
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
+)
+
+// internalGetTaxInvoiceInfo 결제 시스템이 세금계산서 발행 전에 사업자 정보 조회, 등록 전이면 422 로 발행 보류
+func (c *UserController) internalGetTaxInvoiceInfo(ctx echo.Context) error {
+	var req struct {
+		Username string `query:"username" validate:"required,email"`
+	}
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "internalGetTaxInvoiceInfo, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	}
+
+	info, err := c.useCase.GetTaxInvoiceInfo(ctx.Request().Context(), req.Username)
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, echo.Map{
+			"businessNumber": info.Number,
+			"businessName":   info.Name,
+			"representative": info.Representative,
+		})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrBusinessInfoRequired:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.BusinessInfoRequiredResponse)
+	default:
+		echox.Log(ctx).WithError(err).
+			WithField("username", req.Username).
+			Error(tag, "internalGetTaxInvoiceInfo, unhandled error useCase.GetTaxInvoiceInfo")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
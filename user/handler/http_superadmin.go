@@ -3,8 +3,8 @@ package handler
 import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	log "github.com/sirupsen/logrus"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/echox"
 	"net/http"
 )
 
@@ -31,22 +31,24 @@ type CreateAdminRequest struct {
 // @Param requestBody body CreateAdminRequest true "어드민 생성 정보 데이터 구조"
 // @Success 201 {object} CreatedUserResponse "어드민 생성 완료"
 // @Router /admin [post]
-func (c *UserController) createAdmin(ctx echo.Context) error {
+func (c *UserController) createAdmin(ctx echo.Context, userId uuid.UUID) error {
 	var req CreateAdminRequest
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "create admin, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "create admin, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 
 	newId, err := c.useCase.CreateAdminUser(ctx.Request().Context(), domain.CreateAdminUser{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: req.Password,
-		Nickname: req.Nickname,
+		Name:      req.Name,
+		Email:     req.Email,
+		Password:  req.Password,
+		Nickname:  req.Nickname,
+		CreatedBy: userId,
+		Ip:        ctx.RealIP(),
 	})
 
 	switch err {
@@ -55,7 +57,7 @@ func (c *UserController) createAdmin(ctx echo.Context) error {
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.ItemExist)
 	default:
-		log.WithError(err).Error(tag, "create admin, unhandled error useCase.CreateAdminUser")
+		echox.Log(ctx).WithError(err).Error(tag, "create admin, unhandled error useCase.CreateAdminUser")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -67,7 +69,6 @@ type UpdateAdminInfoRequest struct {
 	Nickname string    `json:"nickname" validate:"required,min=2,max=60" example:"nickname"`
 } // @name UpdateAdminInfoRequest
 
-
 // @Tags (User) 슈퍼어드민 기능
 // @Security Auth-Jwt-Bearer
 // @Summary [슈퍼어드민] 어드민 정보 수정
@@ -78,22 +79,24 @@ type UpdateAdminInfoRequest struct {
 // @Param user_id path string true "어드민 식별 아이디(UUID)"
 // @Success 204 "어드민 정보 수정 성공"
 // @Router /admin/{user_id} [put]
-func (c *UserController) updateAdminBySuperAdmin(ctx echo.Context) error {
+func (c *UserController) updateAdminBySuperAdmin(ctx echo.Context, userId uuid.UUID) error {
 	var req UpdateAdminInfoRequest
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "force update admin, request body bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "force update admin, request body bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 
 	err = c.useCase.ForceUpdateAdminInfo(ctx.Request().Context(), domain.ForceUpdateAdminInfo{
-		UserId:   req.UserId,
-		Name:     req.Name,
-		Username: req.Email,
-		Nickname: req.Nickname,
+		UserId:    req.UserId,
+		Name:      req.Name,
+		Username:  req.Email,
+		Nickname:  req.Nickname,
+		UpdatedBy: userId,
+		Ip:        ctx.RealIP(),
 	})
 
 	switch err {
@@ -104,7 +107,7 @@ func (c *UserController) updateAdminBySuperAdmin(ctx echo.Context) error {
 	case domain.ErrItemAlreadyExist:
 		return ctx.JSON(http.StatusConflict, domain.EmailExistsResponse)
 	default:
-		log.WithError(err).Error(tag, "force-update admin, unhandled error useCase.ForceUpdateAdminInfoBySuperAdmin")
+		echox.Log(ctx).WithError(err).Error(tag, "force-update admin, unhandled error useCase.ForceUpdateAdminInfoBySuperAdmin")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
@@ -123,21 +126,24 @@ type UpdateAdminPasswordRequest struct {
 // @Param requestBody body UpdateAdminPasswordRequest true "어드민 패스워드 수정 데이터 구조"
 // @Param user_id path string true "어드민 식별 아이디(UUID)"
 // @Success 204 "어드민 패스워드 수정 성공"
+// @Failure 422 {object} domain.ErrorResponse "비밀번호 규칙에 맞지 않음 (U-14), 최근에 쓴 비밀번호 (U-15)"
 // @Router /admin/{user_id}/pw [patch]
-func (c *UserController) updateAdminPasswordBySuperAdmin(ctx echo.Context) error {
+func (c *UserController) updateAdminPasswordBySuperAdmin(ctx echo.Context, userId uuid.UUID) error {
 	var req UpdateAdminPasswordRequest
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "updateAdminPasswordBySuperAdmin, request data bind error")
+		echox.Log(ctx).WithError(err).Trace(tag, "updateAdminPasswordBySuperAdmin, request data bind error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 
 	in := domain.ForceUpdateAdminPassword{
-		UserId:   req.UserId,
-		Password: req.Password,
+		UserId:    req.UserId,
+		Password:  req.Password,
+		UpdatedBy: userId,
+		Ip:        ctx.RealIP(),
 	}
 	err = c.useCase.ForceUpdateAdminPassword(ctx.Request().Context(), in)
 
@@ -146,14 +152,109 @@ func (c *UserController) updateAdminPasswordBySuperAdmin(ctx echo.Context) error
 		return ctx.NoContent(http.StatusNoContent)
 	case domain.ErrItemNotFound:
 		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrPasswordTooWeak:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.PasswordTooWeakResponse)
+	case domain.ErrPasswordReused:
+		return ctx.JSON(http.StatusUnprocessableEntity, domain.PasswordReusedResponse)
 	default:
-		log.WithError(err).
+		echox.Log(ctx).WithError(err).
 			WithField("in", in).
 			Error(tag, "updateAdminPasswordBySuperAdmin, unhandled error useCase.ForceUpdateAdminPassword")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
 }
 
+type UpdateManagerCapacityRequest struct {
+	UserId uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Capacity, 동시에 맡을 수 있는 끝나지 않은 의뢰 수, null 이면 설정(order.manager_capacity) 값, 0 이면 제한 없음
+	Capacity *uint16 `json:"capacity" validate:"omitempty,max=1000" example:"8"`
+} // @name UpdateManagerCapacityRequest
+
+// @Tags (User) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 어드민 동시 진행 의뢰 한도 수정
+// @Description 자동 배정, 직접 가져가기에 적용, 관리자가 의뢰 정보 수정으로 배정할 때는 적용 안 함, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body UpdateManagerCapacityRequest true "한도"
+// @Param user_id path string true "어드민 식별 아이디(UUID)"
+// @Success 204 "수정 완료"
+// @Failure 404 {object} domain.ErrorResponse "없는 어드민"
+// @Router /admin/{user_id}/capacity [put]
+func (c *UserController) updateManagerCapacity(ctx echo.Context) error {
+	var req UpdateManagerCapacityRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "updateManagerCapacity, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateManagerCapacity(ctx.Request().Context(), domain.UpdateManagerCapacity{
+		UserId:   req.UserId,
+		Capacity: req.Capacity,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "updateManagerCapacity, unhandled error useCase.UpdateManagerCapacity")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type UpdateManagerSkillsRequest struct {
+	UserId uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Skills, 할 수 있는 작업, 빈 배열이면 필요한 작업이 있는 의뢰는 자동 배정 받지 않음
+	Skills []domain.SkillTag `json:"skills" validate:"required,max=3,dive,oneof=MOTION_GRAPHICS SHORTS COLOR_GRADING" example:"MOTION_GRAPHICS,SHORTS" enums:"MOTION_GRAPHICS,SHORTS,COLOR_GRADING"`
+} // @name UpdateManagerSkillsRequest
+
+// @Tags (User) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 어드민 작업(스킬) 수정
+// @Description MOTION_GRAPHICS: 모션그래픽, SHORTS: 쇼츠, COLOR_GRADING: 색보정, 자동 배정, 직접 가져가기는 의뢰에 필요한 작업을 모두 할 수 있어야함, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body UpdateManagerSkillsRequest true "할 수 있는 작업"
+// @Param user_id path string true "어드민 식별 아이디(UUID)"
+// @Success 204 "수정 완료"
+// @Failure 400 {object} domain.ErrorResponse "요청 데이터 오류"
+// @Failure 404 {object} domain.ErrorResponse "없는 어드민"
+// @Router /admin/{user_id}/skills [put]
+func (c *UserController) updateManagerSkills(ctx echo.Context) error {
+	var req UpdateManagerSkillsRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "updateManagerSkills, request data bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	err = c.useCase.UpdateManagerSkills(ctx.Request().Context(), domain.UpdateManagerSkills{
+		UserId: req.UserId,
+		Skills: req.Skills,
+	})
+
+	switch err {
+	case nil:
+		return ctx.NoContent(http.StatusNoContent)
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case domain.ErrItemNotFound:
+		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "updateManagerSkills, unhandled error useCase.UpdateManagerSkills")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
 type DeleteAdminRequest struct {
 	// Id, 어드민 Id
 	Id uuid.UUID `param:"userId" json:"-" validate:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -168,18 +269,20 @@ type DeleteAdminRequest struct {
 // @Param user_id path string true "어드민 식별 아이디(UUID)"
 // @Success 204 "삭제 완료"
 // @Router /admin/{user_id} [delete]
-func (c *UserController) deleteAdminBySuperAdmin(ctx echo.Context) error {
+func (c *UserController) deleteAdminBySuperAdmin(ctx echo.Context, userId uuid.UUID) error {
 	var req DeleteAdminRequest
 
 	err := ctx.Bind(&req)
 	if err != nil {
-		log.WithError(err).Trace(tag, "delete admin, request body error")
+		echox.Log(ctx).WithError(err).Trace(tag, "delete admin, request body error")
 		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 	err = c.useCase.DeleteAdminUser(ctx.Request().Context(), domain.DeleteAdminUser{
-		UserId: req.Id,
+		UserId:    req.Id,
+		DeletedBy: userId,
+		Ip:        ctx.RealIP(),
 	})
 
 	switch err {
@@ -188,7 +291,54 @@ func (c *UserController) deleteAdminBySuperAdmin(ctx echo.Context) error {
 	case domain.ErrItemNotFound:
 		return ctx.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
 	default:
-		log.WithError(err).Error(tag, "delete customer failed")
+		echox.Log(ctx).WithError(err).Error(tag, "delete customer failed")
+		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
+	}
+}
+
+type ForcePasswordRotationRequest struct {
+	// Role 대상 역할, ADMIN 또는 SUPER_ADMIN
+	Role domain.UserRole `json:"role" validate:"required,oneof=ADMIN SUPER_ADMIN" example:"ADMIN"`
+} // @name ForcePasswordRotationRequest
+
+type ForcePasswordRotationResponse struct {
+	// Affected 변경 대상이 된 유저 수
+	Affected int64 `json:"affected" example:"12"`
+} // @name ForcePasswordRotationResponse
+
+// @Tags (User) 슈퍼어드민 기능
+// @Security Auth-Jwt-Bearer
+// @Summary [슈퍼어드민] 비밀번호 일괄 변경 강제
+// @Description 역할의 모든 유저가 다음 로그인 때 비밀번호를 바꾸도록 표시, 이미 발급된 토큰은 유지됨, 역할(role)이 'SUPER_ADMIN' 이여야함
+// @Accept json
+// @Produce json
+// @Param requestBody body ForcePasswordRotationRequest true "대상 역할"
+// @Success 200 {object} ForcePasswordRotationResponse "표시 완료"
+// @Router /user/admin/force-password-rotation [post]
+func (c *UserController) forcePasswordRotation(ctx echo.Context, userId uuid.UUID) error {
+	var req ForcePasswordRotationRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		echox.Log(ctx).WithError(err).Trace(tag, "forcePasswordRotation, request body bind error")
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+
+	affected, err := c.useCase.ForcePasswordRotation(ctx.Request().Context(), domain.ForcePasswordRotation{
+		Role:        req.Role,
+		RequestedBy: userId,
+		Ip:          ctx.RealIP(),
+	})
+
+	switch err {
+	case nil:
+		return ctx.JSON(http.StatusOK, ForcePasswordRotationResponse{Affected: affected})
+	case domain.ErrWeirdData:
+		return ctx.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		echox.Log(ctx).WithError(err).Error(tag, "forcePasswordRotation, unhandled error useCase.ForcePasswordRotation")
 		return ctx.JSON(http.StatusInternalServerError, domain.ServerInternalErrorResponse)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+// NewIdentityRepository user 테이블의 인증 컬럼만 읽고 씀, 테이블은 NewUserRepository 가 만듦
+func NewIdentityRepository(db *gorm.DB) domain.IdentityRepository {
+	return &identityRepo{db: db}
+}
+
+type identityRepo struct {
+	db *gorm.DB
+}
+
+func (r *identityRepo) Save(ctx context.Context, identity *domain.Identity) error {
+	return gormx.Upsert(ctx, r.db, identity)
+}
+
+func (r *identityRepo) With(tx gormx.Tx) domain.IdentityRepository {
+	return &identityRepo{db: tx.Get()}
+}
+
+func (r *identityRepo) GetById(ctx context.Context, id uuid.UUID) (identity *domain.Identity, err error) {
+	var entity domain.Identity
+	err = r.db.WithContext(ctx).First(&entity, id).Error
+	if err == nil {
+		identity = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *identityRepo) GetByUsername(ctx context.Context, username string) (identity *domain.Identity, err error) {
+	var entity domain.Identity
+	err = r.db.WithContext(ctx).
+		Where("`username` = ?", username).
+		First(&entity).Error
+	if err == nil {
+		identity = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *identityRepo) RequirePasswordChange(ctx context.Context, role domain.UserRole) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Identity{}).
+		Where("`role` = ? and `deleted_at` is null", role).
+		Update("password_change_required", true)
+	return result.RowsAffected, result.Error
+}
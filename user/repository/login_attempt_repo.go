@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func NewLoginAttemptRepository(db *gorm.DB) domain.LoginAttemptRepository {
+	db.AutoMigrate(&domain.LoginAttempt{})
+	return &loginAttemptRepo{db: db}
+}
+
+type loginAttemptRepo struct {
+	db *gorm.DB
+}
+
+func (r *loginAttemptRepo) Fail(ctx context.Context, userId uuid.UUID, now time.Time, lockFor time.Duration) (attempt domain.LoginAttempt, locked bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 처음 틀린 요청이 동시에 와도 한 행만 생기도록 먼저 만들어 둠
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&domain.LoginAttempt{UserId: userId, LastFailedAt: now}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&attempt, "`user_id` = ?", userId).Error
+		if err != nil {
+			return err
+		}
+
+		// 그 사이 다른 요청이 잠갔으면 세지 않음
+		if attempt.IsLocked(now) {
+			locked = true
+			return nil
+		}
+
+		locked = attempt.Fail(now, lockFor)
+		return tx.Save(&attempt).Error
+	})
+	return
+}
+
+func (r *loginAttemptRepo) Delete(ctx context.Context, userId uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("`user_id` = ?", userId).
+		Delete(&domain.LoginAttempt{}).Error
+}
+
+func (r *loginAttemptRepo) GetByUserId(ctx context.Context, userId uuid.UUID) (attempt *domain.LoginAttempt, err error) {
+	var entity domain.LoginAttempt
+	err = r.db.WithContext(ctx).First(&entity, "`user_id` = ?", userId).Error
+	if err == nil {
+		attempt = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	return
+}
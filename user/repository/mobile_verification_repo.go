@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewMobileVerificationRepository(db *gorm.DB) domain.MobileVerificationRepository {
+	db.AutoMigrate(&domain.MobileVerification{})
+	return &mobileVerificationRepo{db: db}
+}
+
+type mobileVerificationRepo struct {
+	db *gorm.DB
+}
+
+func (r *mobileVerificationRepo) Create(ctx context.Context, verification *domain.MobileVerification) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.MobileVerification{}).
+			Where("`user_id` = ? AND `used_at` IS NULL", verification.UserId).
+			UpdateColumn("used_at", verification.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(verification).Error
+	})
+}
+
+func (r *mobileVerificationRepo) With(tx gormx.Tx) domain.MobileVerificationRepository {
+	return &mobileVerificationRepo{db: tx.Get()}
+}
+
+func (r *mobileVerificationRepo) GetPending(ctx context.Context, userId uuid.UUID) (verification *domain.MobileVerification, err error) {
+	var entity domain.MobileVerification
+	err = r.db.WithContext(ctx).
+		Where("`user_id` = ? AND `used_at` IS NULL", userId).
+		Order("`created_at` desc").
+		First(&entity).Error
+	if err == nil {
+		verification = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *mobileVerificationRepo) CountByUserSince(ctx context.Context, userId uuid.UUID, since time.Time) (cnt int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.MobileVerification{}).
+		Where("`user_id` = ? AND `created_at` >= ?", userId, since).
+		Count(&cnt).Error
+	return
+}
+
+func (r *mobileVerificationRepo) AddAttempt(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.MobileVerification{}).
+		Where("`id` = ?", id).
+		UpdateColumn("attempts", gorm.Expr("`attempts` + 1")).Error
+}
+
+func (r *mobileVerificationRepo) Use(ctx context.Context, id uuid.UUID, at time.Time) (used bool, err error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.MobileVerification{}).
+		Where("`id` = ? AND `used_at` IS NULL", id).
+		UpdateColumn("used_at", at)
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewPasswordHistoryRepository(db *gorm.DB) domain.PasswordHistoryRepository {
+	db.AutoMigrate(&domain.PasswordHistory{})
+	return &passwordHistoryRepo{db: db}
+}
+
+type passwordHistoryRepo struct {
+	db *gorm.DB
+}
+
+func (r *passwordHistoryRepo) Save(ctx context.Context, history *domain.PasswordHistory) error {
+	return r.db.WithContext(ctx).Save(history).Error
+}
+
+func (r *passwordHistoryRepo) With(tx gormx.Tx) domain.PasswordHistoryRepository {
+	return &passwordHistoryRepo{db: tx.Get()}
+}
+
+func (r *passwordHistoryRepo) FetchRecentByUserId(ctx context.Context, userId uuid.UUID, limit int) (list []domain.PasswordHistory, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`user_id` = ?", userId).
+		Order("`created_at` desc").
+		Limit(limit).
+		Find(&list).Error
+	return
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+	"gorm.io/gorm"
+)
+
+func NewPasswordResetRepository(db *gorm.DB) domain.PasswordResetRepository {
+	db.AutoMigrate(&domain.PasswordReset{})
+	return &passwordResetRepo{db: db}
+}
+
+type passwordResetRepo struct {
+	db *gorm.DB
+}
+
+func (r *passwordResetRepo) Create(ctx context.Context, reset *domain.PasswordReset) error {
+	return r.db.WithContext(ctx).Create(reset).Error
+}
+
+func (r *passwordResetRepo) With(tx gormx.Tx) domain.PasswordResetRepository {
+	return &passwordResetRepo{db: tx.Get()}
+}
+
+func (r *passwordResetRepo) GetByHash(ctx context.Context, tokenHash string) (reset *domain.PasswordReset, err error) {
+	var entity domain.PasswordReset
+	err = r.db.WithContext(ctx).
+		Where("`token_hash` = ?", tokenHash).
+		First(&entity).Error
+	if err == nil {
+		reset = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *passwordResetRepo) CountByUserSince(ctx context.Context, userId uuid.UUID, since time.Time) (cnt int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.PasswordReset{}).
+		Where("`user_id` = ? AND `created_at` >= ?", userId, since).
+		Count(&cnt).Error
+	return
+}
+
+func (r *passwordResetRepo) Use(ctx context.Context, id, userId uuid.UUID, at time.Time) (used bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.PasswordReset{}).
+			Where("`id` = ? AND `used_at` IS NULL", id).
+			UpdateColumn("used_at", at)
+		if res.Error != nil {
+			return res.Error
+		}
+		used = res.RowsAffected > 0
+		if !used {
+			return nil
+		}
+
+		return tx.Model(&domain.PasswordReset{}).
+			Where("`user_id` = ? AND `used_at` IS NULL", userId).
+			UpdateColumn("used_at", at).Error
+	})
+	return
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewRefreshTokenRepository(db *gorm.DB) domain.RefreshTokenRepository {
+	db.AutoMigrate(&domain.RefreshToken{})
+	return &refreshTokenRepo{db: db}
+}
+
+type refreshTokenRepo struct {
+	db *gorm.DB
+}
+
+func (r *refreshTokenRepo) Create(ctx context.Context, token *domain.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *refreshTokenRepo) GetByHash(ctx context.Context, tokenHash string) (token *domain.RefreshToken, err error) {
+	var entity domain.RefreshToken
+	err = r.db.WithContext(ctx).
+		Where("`token_hash` = ?", tokenHash).
+		First(&entity).Error
+	if err == nil {
+		token = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *refreshTokenRepo) Rotate(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("`id` = ? AND `rotated_at` IS NULL AND `revoked_at` IS NULL", id).
+		UpdateColumn("rotated_at", at)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *refreshTokenRepo) RevokeFamily(ctx context.Context, familyId uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("`family_id` = ? AND `revoked_at` IS NULL", familyId).
+		UpdateColumn("revoked_at", at).Error
+}
+
+func (r *refreshTokenRepo) RevokeByUser(ctx context.Context, userId uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("`user_id` = ? AND `revoked_at` IS NULL AND `expires_at` > ?", userId, at).
+		UpdateColumn("revoked_at", at).Error
+}
@@ -3,20 +3,28 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stockfolioofficial/back-editfolio/domain"
 	"github.com/stockfolioofficial/back-editfolio/util/gormx"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func NewUserRepository(db *gorm.DB) domain.UserRepository {
 	db.AutoMigrate(&domain.User{})
+	gormx.MigrateEnum(db, domain.UserRoleEnum.Table, domain.UserRoleEnum.Column, domain.UserRoleEnum.Values)
 	return &repo{
 		db: db,
 	}
 }
 
+// likeEscaper 검색어의 LIKE 와일드카드를 글자 그대로 찾도록
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type repo struct {
 	db *gorm.DB
 }
@@ -32,27 +40,111 @@ func (r *repo) ExistsSuperUser(ctx context.Context) (exists bool, err error) {
 }
 
 func (r *repo) FetchAllAdmin(ctx context.Context, option domain.FetchAdminOption) (list []domain.User, err error) {
-	err = r.db.WithContext(ctx).
+	db := r.db.WithContext(ctx).
 		Joins("Manager").
-		Where("`deleted_at` IS NULL").
 		Where(r.db.Where("`role` = ?", domain.AdminUserRole).
-			Or("`role` = ?", domain.SuperAdminUserRole)).
+			Or("`role` = ?", domain.SuperAdminUserRole))
+
+	switch option.Status {
+	case domain.AdminStatusFilterAll:
+	case domain.AdminStatusFilterDeleted:
+		db = db.Where("`user`.`deleted_at` IS NOT NULL")
+	default:
+		db = db.Where("`user`.`deleted_at` IS NULL")
+	}
+
+	if query := strings.TrimSpace(option.Query); query != "" {
+		like := "%" + likeEscaper.Replace(query) + "%"
+		db = db.Where(r.db.Where("`Manager`.`name` LIKE ?", like).
+			Or("`Manager`.`nickname` LIKE ?", like).
+			Or("`user`.`username` LIKE ?", like))
+	}
+
+	if len(option.Skills) > 0 {
+		db = db.Where("JSON_CONTAINS(`Manager`.`skills`, ?)", domain.EncodeSkillFilter(option.Skills))
+	}
+
+	err = db.
+		Order("`user`.`created_at` desc").
+		Order("`user`.`id`").
 		Find(&list).Error
 	return
 }
 
 func (r *repo) FetchAllCustomer(ctx context.Context, option domain.FetchCustomerOption) (list []domain.User, err error) {
+	err = r.customerQuery(ctx, option).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) FetchCustomerPage(ctx context.Context, option domain.FetchCustomerOption, offset, limit int) (list []domain.User, total int64, err error) {
+	err = r.customerQuery(ctx, option).
+		Model(&domain.User{}).
+		Count(&total).Error
+	if err != nil || total == 0 {
+		return
+	}
+
+	err = r.customerQuery(ctx, option).
+		Offset(offset).
+		Limit(limit).
+		Find(&list).Error
+	return
+}
+
+// customerQuery 삭제되지 않은 고객을 option 의 검색어, 추가 항목, 정렬로 거름
+func (r *repo) customerQuery(ctx context.Context, option domain.FetchCustomerOption) *gorm.DB {
+	db := r.db.WithContext(ctx).
+		Joins("Customer").
+		Where("`deleted_at` IS NULL").
+		Where("`role` = ?", domain.CustomerUserRole)
+
+	if query := strings.TrimSpace(option.Query); query != "" {
+		like := "%" + likeEscaper.Replace(query) + "%"
+		mobile := "%" + likeEscaper.Replace(strings.ReplaceAll(query, "-", "")) + "%"
+		db = db.Where(r.db.Where("`Customer`.`name` LIKE ?", like).
+			Or("`Customer`.`email` LIKE ?", like).
+			Or("`Customer`.`mobile` LIKE ?", mobile))
+	}
+
+	for key, value := range option.CustomFields {
+		// key 는 domain.IsCustomFieldKey 로 확인된 값만 들어옴
+		db = db.Where(fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(`Customer`.`custom_fields`, '$.%s')) = ?", key), value)
+	}
+
+	var direction = "asc"
+	if option.SortDesc {
+		direction = "desc"
+	}
+	switch option.Sort {
+	case domain.CustomerSortKeyCreatedAt:
+		db = db.Order("`user`.`created_at` " + direction)
+	case domain.CustomerSortKeyName:
+		db = db.Order("`Customer`.`name` " + direction)
+	}
+	// 페이지 사이에 같은 고객이 겹치거나 빠지지 않게 아이디로 마무리
+	return db.Order("`user`.`id`")
+}
+
+func (r *repo) GetByIdWithCustomer(ctx context.Context, id uuid.UUID) (user *domain.User, err error) {
+	var entity domain.User
 	err = r.db.WithContext(ctx).
 		Joins("Customer").
 		Where("`deleted_at` IS NULL").
-		Where("`role` = ?", domain.CustomerUserRole).
-		Find(&list).Error
+		First(&entity, id).Error
+	if err == nil {
+		user = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
 	return
 }
 
-func (r *repo) GetByIdWithCustomer(ctx context.Context, id uuid.UUID) (user *domain.User, err error) {
+func (r *repo) GetByIdWithCustomerForUpdate(ctx context.Context, id uuid.UUID) (user *domain.User, err error) {
 	var entity domain.User
 	err = r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Joins("Customer").
 		Where("`deleted_at` IS NULL").
 		First(&entity, id).Error
@@ -80,6 +172,32 @@ func (r *repo) GetByIdWithManager(ctx context.Context, id uuid.UUID) (user *doma
 	return
 }
 
+func (r *repo) GetDeletedById(ctx context.Context, id uuid.UUID) (user *domain.User, err error) {
+	var entity domain.User
+	err = r.db.WithContext(ctx).
+		Joins("Customer").
+		Joins("Manager").
+		Where("`user`.`deleted_at` IS NOT NULL").
+		First(&entity, "`user`.`id` = ?", id).Error
+	if err == nil {
+		user = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
+func (r *repo) FetchDeleted(ctx context.Context, since time.Time) (list []domain.User, err error) {
+	err = r.db.WithContext(ctx).
+		Joins("Customer").
+		Joins("Manager").
+		Where("`user`.`deleted_at` >= ?", since).
+		Order("`user`.`deleted_at` desc").
+		Find(&list).Error
+	return
+}
+
 func (r *repo) GetByUsername(ctx context.Context, username string) (user *domain.User, err error) {
 	var entity domain.User
 	err = r.db.WithContext(ctx).
@@ -94,6 +212,27 @@ func (r *repo) GetByUsername(ctx context.Context, username string) (user *domain
 	return
 }
 
+func (r *repo) FetchByUsernames(ctx context.Context, usernames []string) (list []domain.User, err error) {
+	err = r.db.WithContext(ctx).
+		Where("`username` IN ?", usernames).
+		Find(&list).Error
+	return
+}
+
+func (r *repo) GetByPendingUsernameToken(ctx context.Context, hashedToken string) (user *domain.User, err error) {
+	var entity domain.User
+	err = r.db.WithContext(ctx).
+		Where("`pending_username_token` = ?", hashedToken).
+		First(&entity).Error
+	if err == nil {
+		user = &entity
+	} else if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	return
+}
+
 func (r *repo) GetById(ctx context.Context, userId uuid.UUID) (user *domain.User, err error) {
 	var entity domain.User
 	err = r.db.WithContext(ctx).First(&entity, userId).Error
@@ -110,6 +249,14 @@ func (r *repo) Save(ctx context.Context, user *domain.User) error {
 	return gormx.Upsert(ctx, r.db, user)
 }
 
+func (r *repo) Create(ctx context.Context, user *domain.User) error {
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Create(user).Error
+	if gormx.IsDuplicateKey(err) {
+		err = domain.ErrItemAlreadyExist
+	}
+	return err
+}
+
 func (r *repo) Get() *gorm.DB {
 	return r.db
 }
@@ -118,4 +265,4 @@ func (r *repo) Transaction(ctx context.Context, fn func(userRepo domain.UserTxRe
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return fn(&repo{db: tx})
 	}, options...)
-}
\ No newline at end of file
+}
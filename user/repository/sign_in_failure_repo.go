@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"gorm.io/gorm"
+)
+
+func NewSignInFailureRepository(db *gorm.DB) domain.SignInFailureRepository {
+	db.AutoMigrate(&domain.SignInFailure{})
+	return &signInFailureRepo{db: db}
+}
+
+type signInFailureRepo struct {
+	db *gorm.DB
+}
+
+func (r *signInFailureRepo) Create(ctx context.Context, failure *domain.SignInFailure) error {
+	return r.db.WithContext(ctx).Create(failure).Error
+}
+
+func (r *signInFailureRepo) CountSince(ctx context.Context, since time.Time) (count int64, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&domain.SignInFailure{}).
+		Where("`failed_at` >= ?", since).
+		Count(&count).Error
+	return
+}
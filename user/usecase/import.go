@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+func (u *ucase) ImportCustomers(ctx context.Context, in domain.ImportCustomers) (res []domain.CustomerImportResult, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if len(in.Rows) > domain.CustomerImportMaxRows {
+		err = domain.ErrWeirdData
+		return
+	}
+	if len(in.Rows) == 0 {
+		return
+	}
+
+	emails := make([]string, len(in.Rows))
+	for i, row := range in.Rows {
+		emails[i] = row.Email
+	}
+	users, err := u.userRepo.FetchByUsernames(c, emails)
+	if err != nil {
+		return
+	}
+
+	// 삭제된 계정도 아이디가 unique 라 그대로 막음
+	taken := make(map[string]bool, len(users))
+	for i := range users {
+		taken[strings.ToLower(users[i].Username)] = true
+	}
+	seen := make(map[string]bool, len(in.Rows))
+
+	type created struct {
+		user     domain.User
+		customer domain.Customer
+		event    domain.OutboxEvent
+	}
+	var list []created
+
+	now := u.clock.Now()
+	res = make([]domain.CustomerImportResult, len(in.Rows))
+	for i, row := range in.Rows {
+		res[i].Line = row.Line
+
+		key := strings.ToLower(row.Email)
+		switch {
+		case taken[key]:
+			res[i].Column = domain.CustomerImportColumnEmail
+			res[i].Failure = domain.CustomerImportAlreadyExist
+			continue
+		case seen[key]:
+			res[i].Column = domain.CustomerImportColumnEmail
+			res[i].Failure = domain.CustomerImportDuplicated
+			continue
+		}
+		seen[key] = true
+
+		user, customer, event, createErr := u.createCustomerUser(domain.CreateCustomerUser{
+			Name:   row.Name,
+			Email:  row.Email,
+			Mobile: row.Mobile,
+		}, now)
+		if createErr != nil {
+			err = createErr
+			return
+		}
+		list = append(list, created{user: user, customer: customer, event: event})
+		res[i].UserId = &user.Id
+	}
+
+	if len(list) == 0 {
+		return
+	}
+
+	err = u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		cr := u.customerRepo.With(ur)
+		obr := u.outboxRepo.With(ur)
+		for i := range list {
+			err := ur.Create(c, &list[i].user)
+			if err != nil {
+				return err
+			}
+
+			err = cr.Save(c, &list[i].customer)
+			if err != nil {
+				return err
+			}
+
+			err = obr.Save(c, &list[i].event)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		res = nil
+	}
+	return
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+// aliveCustomer 삭제되지 않은 고객과 고객 프로필, 프로필이 없으면 ErrItemNotFound
+func (u *ucase) aliveCustomer(ctx context.Context, userId uuid.UUID) (user *domain.User, err error) {
+	user, err = u.userRepo.GetById(ctx, userId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = user.LoadCustomerInfo(ctx, u.customerRepo)
+	if err != nil {
+		return
+	}
+
+	if user.Customer == nil {
+		err = domain.ErrItemNotFound
+	}
+	return
+}
+
+func (u *ucase) RequestMobileVerification(ctx context.Context, in domain.RequestMobileVerification) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.aliveCustomer(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if user.Customer.Mobile == in.Mobile {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	now := u.clock.Now()
+	cnt, err := u.mobileVerificationRepo.CountByUserSince(c, user.Id, now.Add(-domain.MobileVerificationRequestWindow))
+	if err != nil {
+		return
+	}
+	if cnt >= domain.MobileVerificationRequestLimit {
+		err = domain.ErrTooManyRequests
+		return
+	}
+
+	code, verification, err := domain.NewMobileVerification(u.ids.NewId(), user.Id, in.Mobile, now)
+	if err != nil {
+		return
+	}
+
+	// 발송에 실패해도 요청 수에 넣어야 하므로 먼저 저장
+	err = u.mobileVerificationRepo.Create(c, &verification)
+	if err != nil {
+		return
+	}
+
+	return u.smsSender.Send(c, in.Mobile, domain.MobileVerificationMessage(code))
+}
+
+func (u *ucase) UpdateCustomerMobile(ctx context.Context, in domain.UpdateCustomerMobile) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	verification, err := u.mobileVerificationRepo.GetPending(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if verification == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	now := u.clock.Now()
+	if verification.IsExpired(now) {
+		err = domain.ErrTokenExpired
+		return
+	}
+
+	if verification.IsExhausted() {
+		err = domain.ErrTooManyRequests
+		return
+	}
+
+	if verification.Mobile != in.Mobile || !verification.Match(in.Code) {
+		// 틀린 횟수를 못 남기면 계속 시도할 수 있으므로 에러로 끝냄
+		err = u.mobileVerificationRepo.AddAttempt(c, verification.Id)
+		if err == nil {
+			err = domain.ErrVerificationCodeMismatch
+		}
+		return
+	}
+
+	user, err := u.aliveCustomer(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	user.UpdateCustomerMobile(verification.Mobile, now)
+	return u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		// 같은 인증 번호로 동시에 들어온 요청은 하나만 통과
+		used, err := u.mobileVerificationRepo.With(ur).Use(c, verification.Id, now)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return domain.ErrItemNotFound
+		}
+
+		g, gc := errgroup.WithContext(c)
+		g.Go(func() error {
+			return ur.Save(gc, user)
+		})
+		g.Go(func() error {
+			return u.customerRepo.With(ur).Save(gc, user.Customer)
+		})
+		return g.Wait()
+	})
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/gormx"
+)
+
+// NewPasswordPolicy 규칙은 매번 설정에서 읽으므로 바꾸면 다음 변경부터 적용
+func NewPasswordPolicy(
+	historyRepo domain.PasswordHistoryRepository,
+	settingReader domain.SettingReader,
+	ids domain.IdGenerator,
+	clock domain.Clock,
+) domain.PasswordPolicy {
+	return &passwordPolicy{
+		historyRepo:   historyRepo,
+		settingReader: settingReader,
+		ids:           ids,
+		clock:         clock,
+	}
+}
+
+type passwordPolicy struct {
+	historyRepo   domain.PasswordHistoryRepository
+	settingReader domain.SettingReader
+	ids           domain.IdGenerator
+	clock         domain.Clock
+}
+
+func (p *passwordPolicy) Rule(ctx context.Context) (rule domain.PasswordRule, err error) {
+	var minLength, charClasses, history int64
+	g, gc := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		minLength, err = p.settingReader.Int(gc, domain.SettingKeyPasswordMinLength)
+		return
+	})
+	g.Go(func() (err error) {
+		charClasses, err = p.settingReader.Int(gc, domain.SettingKeyPasswordCharClasses)
+		return
+	})
+	g.Go(func() (err error) {
+		history, err = p.settingReader.Int(gc, domain.SettingKeyPasswordHistory)
+		return
+	})
+	g.Go(func() (err error) {
+		rule.MaxAgeDays, err = p.settingReader.Int(gc, domain.SettingKeyPasswordRotationDays)
+		return
+	})
+	err = g.Wait()
+	if err != nil {
+		return
+	}
+
+	rule.MinLength = int(minLength)
+	rule.CharClasses = int(charClasses)
+	rule.HistoryCount = int(history)
+	if rule.HistoryCount > domain.PasswordHistoryMax {
+		rule.HistoryCount = domain.PasswordHistoryMax
+	}
+	return
+}
+
+func (p *passwordPolicy) Check(ctx context.Context, identity domain.Identity, plain string) (err error) {
+	rule, err := p.Rule(ctx)
+	if err != nil {
+		return
+	}
+
+	err = rule.Check(plain)
+	if err != nil || rule.HistoryCount <= 0 {
+		return
+	}
+
+	if identity.ComparePassword(plain) {
+		err = domain.ErrPasswordReused
+		return
+	}
+
+	// 지금 비밀번호가 하나를 차지함
+	if rule.HistoryCount == 1 {
+		return
+	}
+
+	list, err := p.historyRepo.FetchRecentByUserId(ctx, identity.Id, rule.HistoryCount-1)
+	if err != nil {
+		return
+	}
+
+	for _, history := range list {
+		old := domain.Identity{Password: history.Hash}
+		if old.ComparePassword(plain) {
+			err = domain.ErrPasswordReused
+			return
+		}
+	}
+	return
+}
+
+func (p *passwordPolicy) Remember(ctx context.Context, tx gormx.Tx, identity domain.Identity) error {
+	return p.historyRepo.With(tx).Save(ctx, &domain.PasswordHistory{
+		Id:        p.ids.NewId(),
+		UserId:    identity.Id,
+		Hash:      identity.Password,
+		CreatedAt: p.clock.Now(),
+	})
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+)
+
+// NewTokenVersionReader 인증 미들웨어가 요청마다 호출, 유저 유스케이스와 같은 캐시를 공유
+func NewTokenVersionReader(
+	identityRepo domain.IdentityRepository,
+	timeout time.Duration,
+) domain.TokenVersionReader {
+	return &tokenVersionReader{
+		identityRepo: identityRepo,
+		cache:        cache.New(domain.TokenVersionCacheName, domain.TokenVersionCacheTTL),
+		timeout:      timeout,
+	}
+}
+
+type tokenVersionReader struct {
+	identityRepo domain.IdentityRepository
+	cache        *cache.Store
+	timeout      time.Duration
+}
+
+type tokenVersion struct {
+	version uint32
+	ok      bool
+}
+
+func (r *tokenVersionReader) TokenVersion(ctx context.Context, userId uuid.UUID) (version uint32, ok bool, err error) {
+	cached, err := r.cache.GetOrLoad(tenant.CacheKey(ctx, userId.String()), func() (interface{}, error) {
+		c, cancel := budget.Slice(ctx, r.timeout)
+		defer cancel()
+
+		identity, err := r.identityRepo.GetById(c, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !domain.CheckIdentityAlive(identity) {
+			return tokenVersion{}, nil
+		}
+		return tokenVersion{version: identity.TokenVersion, ok: true}, nil
+	})
+	if err != nil {
+		return
+	}
+
+	v := cached.(tokenVersion)
+	return v.version, v.ok, nil
+}
@@ -1,68 +1,394 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/core/cache"
+	"github.com/stockfolioofficial/back-editfolio/core/tenant"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
 )
 
+const tag = "[USER] "
+
 func NewUserUseCase(
 	userRepo domain.UserRepository,
+	identityRepo domain.IdentityRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	passwordResetRepo domain.PasswordResetRepository,
+	signInFailureRepo domain.SignInFailureRepository,
+	mobileVerificationRepo domain.MobileVerificationRepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
 	tokenAdapter domain.TokenGenerateAdapter,
 	managerRepo domain.ManagerRepository,
 	customerRepo domain.CustomerRepository,
 	orderTicketRepo domain.OrderTicketRepository,
+	outboxRepo domain.OutboxRepository,
+	savedViewRepo domain.SavedViewRepository,
+	orderRepo domain.OrderRepository,
+	creditRepo domain.CreditRepository,
+	settingReader domain.SettingReader,
+	storageQuota domain.StorageQuota,
+	passwordPolicy domain.PasswordPolicy,
+	auditLogger domain.AuditLogger,
+	smsSender domain.SmsSender,
+	ids domain.IdGenerator,
+	clock domain.Clock,
 	timeout time.Duration,
 ) domain.UserUseCase {
 	return &ucase{
-		userRepo:        userRepo,
-		tokenAdapter:    tokenAdapter,
-		managerRepo:     managerRepo,
-		customerRepo:    customerRepo,
-		orderTicketRepo: orderTicketRepo,
-		timeout:         timeout,
+		userRepo:               userRepo,
+		identityRepo:           identityRepo,
+		refreshTokenRepo:       refreshTokenRepo,
+		passwordResetRepo:      passwordResetRepo,
+		signInFailureRepo:      signInFailureRepo,
+		mobileVerificationRepo: mobileVerificationRepo,
+		loginAttemptRepo:       loginAttemptRepo,
+		tokenAdapter:           tokenAdapter,
+		managerRepo:            managerRepo,
+		customerRepo:           customerRepo,
+		orderTicketRepo:        orderTicketRepo,
+		outboxRepo:             outboxRepo,
+		savedViewRepo:          savedViewRepo,
+		orderRepo:              orderRepo,
+		creditRepo:             creditRepo,
+		settingReader:          settingReader,
+		storageQuota:           storageQuota,
+		passwordPolicy:         passwordPolicy,
+		auditLogger:            auditLogger,
+		smsSender:              smsSender,
+		tokenVersions:          cache.New(domain.TokenVersionCacheName, domain.TokenVersionCacheTTL),
+		ids:                    ids,
+		clock:                  clock,
+		timeout:                timeout,
 	}
 }
 
 type ucase struct {
-	userRepo        domain.UserRepository
-	tokenAdapter    domain.TokenGenerateAdapter
-	managerRepo     domain.ManagerRepository
-	customerRepo    domain.CustomerRepository
-	orderTicketRepo domain.OrderTicketRepository
-	timeout         time.Duration
+	userRepo               domain.UserRepository
+	identityRepo           domain.IdentityRepository
+	refreshTokenRepo       domain.RefreshTokenRepository
+	passwordResetRepo      domain.PasswordResetRepository
+	signInFailureRepo      domain.SignInFailureRepository
+	mobileVerificationRepo domain.MobileVerificationRepository
+	loginAttemptRepo       domain.LoginAttemptRepository
+	tokenAdapter           domain.TokenGenerateAdapter
+	managerRepo            domain.ManagerRepository
+	customerRepo           domain.CustomerRepository
+	orderTicketRepo        domain.OrderTicketRepository
+	outboxRepo             domain.OutboxRepository
+	savedViewRepo          domain.SavedViewRepository
+	orderRepo              domain.OrderRepository
+	creditRepo             domain.CreditRepository
+	settingReader          domain.SettingReader
+	storageQuota           domain.StorageQuota
+	passwordPolicy         domain.PasswordPolicy
+	auditLogger            domain.AuditLogger
+	smsSender              domain.SmsSender
+	tokenVersions          *cache.Store
+	ids                    domain.IdGenerator
+	clock                  domain.Clock
+	timeout                time.Duration
 }
 
-func (u *ucase) SignInUser(ctx context.Context, si domain.SignInUser) (token string, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+func (u *ucase) SignInUser(ctx context.Context, si domain.SignInUser) (res domain.TokenPair, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
-	user, err := u.userRepo.GetByUsername(c, si.Username)
+	identity, err := u.identityRepo.GetByUsername(c, si.Username)
 	if err != nil {
 		return
 	}
 
-	if user == nil {
+	if identity == nil {
+		u.recordSignInFailure(c, si.Username)
 		err = domain.ErrItemNotFound
 		return
 	}
 
-	if user.ComparePassword(si.Password) {
-		// token generate
-		token, err = u.tokenAdapter.Generate(*user)
-	} else {
-		err = domain.ErrUserWrongPassword
+	err = u.checkPassword(c, *identity, si.Password)
+	if err == domain.ErrUserWrongPassword || err == domain.ErrUserLocked {
+		u.recordSignInFailure(c, si.Username)
+	}
+	if err != nil {
+		return
+	}
+
+	rule, err := u.passwordPolicy.Rule(c)
+	if err != nil {
+		return
+	}
+
+	if identity.NeedPasswordRotation(u.clock.Now(), rule.MaxAgeDays) {
+		err = domain.ErrPasswordChangeRequired
+		return
+	}
+
+	// token generate
+	return u.issueTokenPair(c, *identity, u.ids.NewId())
+}
+
+// recordSignInFailure 운영 알림용 기록, 실패해도 로그인 응답은 그대로
+func (u *ucase) recordSignInFailure(ctx context.Context, username string) {
+	err := u.signInFailureRepo.Create(ctx, &domain.SignInFailure{
+		Id:       u.ids.NewId(),
+		Username: username,
+		FailedAt: u.clock.Now(),
+	})
+	if err != nil {
+		logx.From(ctx).WithError(err).Warn(tag, "record sign in failure failed")
+	}
+}
+
+// checkPassword 잠긴 계정은 비밀번호를 비교하지 않고 ErrUserLocked
+// LoginAttemptLimit 번 연속으로 틀리면 잠그고 ErrUserLocked, 맞으면 틀린 횟수 초기화
+func (u *ucase) checkPassword(ctx context.Context, identity domain.Identity, password string) error {
+	now := u.clock.Now()
+	attempt, err := u.loginAttemptRepo.GetByUserId(ctx, identity.Id)
+	if err != nil {
+		return err
+	}
+
+	if attempt != nil && attempt.IsLocked(now) {
+		return domain.ErrUserLocked
+	}
+
+	if identity.ComparePassword(password) {
+		if attempt != nil {
+			err = u.loginAttemptRepo.Delete(ctx, identity.Id)
+			if err != nil {
+				logx.From(ctx).WithError(err).Warn(tag, "reset login attempt failed")
+			}
+		}
+		return nil
+	}
+
+	lockMinutes, err := u.settingReader.Int(ctx, domain.SettingKeySignInLockMinutes)
+	if err != nil {
+		return err
+	}
+
+	_, locked, err := u.loginAttemptRepo.Fail(ctx, identity.Id, now, time.Duration(lockMinutes)*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	if locked {
+		return domain.ErrUserLocked
+	}
+	return domain.ErrUserWrongPassword
+}
+
+func (u *ucase) RotatePassword(ctx context.Context, in domain.RotatePassword) (res domain.TokenPair, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	identity, err := u.identityRepo.GetByUsername(c, in.Username)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckIdentityAlive(identity,
+		domain.Identity.IsAdmin,
+		domain.Identity.IsSuperAdmin) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	// 로그인 대신 쓸 수 있으므로 같은 잠금 적용
+	err = u.checkPassword(c, *identity, in.OldPassword)
+	if err != nil {
+		return
+	}
+
+	// 같은 비밀번호로 다시 설정하는 건 변경으로 보지 않음
+	if identity.ComparePassword(in.NewPassword) {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	err = u.changeAdminPassword(c, identity, in.NewPassword)
+	if err != nil {
+		return
+	}
+
+	err = u.revokeTokens(c, identity.Id, u.clock.Now())
+	if err != nil {
+		return
+	}
+
+	return u.issueTokenPair(c, *identity, u.ids.NewId())
+}
+
+// RefreshToken 쓴 토큰은 바로 바꾸고 같은 Family 로 새로 발급, 이미 바뀐 토큰이 다시 오면 Family 전체 폐기
+func (u *ucase) RefreshToken(ctx context.Context, token string) (res domain.TokenPair, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	refresh, err := u.refreshTokenRepo.GetByHash(c, domain.HashRefreshToken(token))
+	if err != nil {
+		return
+	}
+
+	if refresh == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	now := u.clock.Now()
+	if refresh.RevokedAt != nil || refresh.IsExpired(now) {
+		err = domain.ErrTokenExpired
+		return
+	}
+
+	if refresh.RotatedAt != nil {
+		logx.From(ctx).WithField("userId", refresh.UserId).
+			WithField("familyId", refresh.FamilyId).
+			Warn(tag, "refresh token reused, revoke family")
+		err = u.refreshTokenRepo.RevokeFamily(c, refresh.FamilyId, now)
+		if err == nil {
+			err = domain.ErrTokenExpired
+		}
+		return
+	}
+
+	identity, err := u.identityRepo.GetById(c, refresh.UserId)
+	if err != nil {
+		return
+	}
+
+	// 폐기 전에 발급된 토큰이 남아있을 수 있어 비밀번호 변경 시각도 확인
+	if !domain.CheckIdentityAlive(identity) ||
+		(identity.PasswordChangedAt != nil && identity.PasswordChangedAt.After(refresh.CreatedAt)) {
+		err = u.refreshTokenRepo.RevokeByUser(c, refresh.UserId, now)
+		if err == nil {
+			err = domain.ErrTokenExpired
+		}
+		return
+	}
+
+	rule, err := u.passwordPolicy.Rule(c)
+	if err != nil {
+		return
+	}
+
+	if identity.NeedPasswordRotation(now, rule.MaxAgeDays) {
+		err = domain.ErrPasswordChangeRequired
+		return
+	}
+
+	rotated, err := u.refreshTokenRepo.Rotate(c, refresh.Id, now)
+	if err != nil {
+		return
+	}
+
+	// 같은 토큰으로 동시에 갱신, 먼저 바꾼 쪽만 인정
+	if !rotated {
+		err = u.refreshTokenRepo.RevokeFamily(c, refresh.FamilyId, now)
+		if err == nil {
+			err = domain.ErrTokenExpired
+		}
+		return
+	}
+
+	return u.issueTokenPair(c, *identity, refresh.FamilyId)
+}
+
+func (u *ucase) issueTokenPair(ctx context.Context, identity domain.Identity, familyId uuid.UUID) (res domain.TokenPair, err error) {
+	res.Token, err = u.tokenAdapter.Generate(ctx, identity)
+	if err != nil {
+		return
+	}
+
+	refreshToken, entity, err := domain.NewRefreshToken(u.ids.NewId(), identity.Id, familyId, u.clock.Now())
+	if err != nil {
+		return
+	}
+
+	err = u.refreshTokenRepo.Create(ctx, &entity)
+	if err != nil {
+		return
+	}
+
+	res.RefreshToken = refreshToken
+	res.RefreshExpiresAt = entity.ExpiresAt
+	return
+}
+
+func (u *ucase) IssueScopedToken(ctx context.Context, in domain.IssueScopedToken) (res domain.ScopedToken, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if len(in.Scopes) == 0 || in.TTL < 0 || in.TTL > domain.ScopedTokenMaxTTL {
+		err = domain.ErrWeirdData
+		return
 	}
+	for _, scope := range in.Scopes {
+		if !scope.IsValid() {
+			err = domain.ErrWeirdData
+			return
+		}
+	}
+
+	identity, err := u.identityRepo.GetById(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckIdentityAlive(identity) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	ttl := in.TTL
+	if ttl == 0 {
+		ttl = domain.ScopedTokenDefaultTTL
+	}
+
+	res.KeyId = u.ids.NewId()
+	res.Scopes = in.Scopes
+	res.ExpiresAt = u.clock.Now().Add(ttl)
+	res.Token, err = u.tokenAdapter.GenerateScoped(c, *identity, res.KeyId, in.Scopes, res.ExpiresAt)
+	return
+}
 
+func (u *ucase) ForcePasswordRotation(ctx context.Context, in domain.ForcePasswordRotation) (affected int64, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	if in.Role != domain.AdminUserRole && in.Role != domain.SuperAdminUserRole {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	affected, err = u.identityRepo.RequirePasswordChange(c, in.Role)
+	if err != nil {
+		return
+	}
+
+	logx.From(ctx).WithFields(log.Fields{
+		"role":        in.Role,
+		"requestedBy": in.RequestedBy,
+		"affected":    affected,
+	}).Warn(tag, "force password rotation")
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId: in.RequestedBy,
+		Action:  domain.AuditActionPasswordRotationForced,
+		Ip:      in.Ip,
+		Detail:  string(in.Role),
+	})
 	return
 }
 
 func (u *ucase) CreateSuperAdminUser(ctx context.Context, in domain.CreateSuperAdminUser) (newId uuid.UUID, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	//TODO 나중에 유저네임 이미 있는거 체크도 필요할듯
@@ -75,7 +401,7 @@ func (u *ucase) CreateSuperAdminUser(ctx context.Context, in domain.CreateSuperA
 		return
 	}
 
-	var user = createUser(domain.SuperAdminUserRole, in.Email, in.Password)
+	var user = u.createUser(domain.SuperAdminUserRole, in.Email, in.Password, u.clock.Now())
 	var manager = domain.CreateManager(domain.ManagerCreateOption{
 		User:     &user,
 		Name:     in.Name,
@@ -86,7 +412,7 @@ func (u *ucase) CreateSuperAdminUser(ctx context.Context, in domain.CreateSuperA
 		mr := u.managerRepo.With(ur)
 		g, gc := errgroup.WithContext(c)
 		g.Go(func() error {
-			return ur.Save(gc, &user)
+			return ur.Create(gc, &user)
 		})
 		g.Go(func() error {
 			return mr.Save(gc, &manager)
@@ -97,9 +423,8 @@ func (u *ucase) CreateSuperAdminUser(ctx context.Context, in domain.CreateSuperA
 	return
 }
 
-
 func (u *ucase) CreateCustomerUser(ctx context.Context, in domain.CreateCustomerUser) (newId uuid.UUID, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	exists, err := u.userRepo.GetByUsername(c, in.Email)
@@ -111,32 +436,32 @@ func (u *ucase) CreateCustomerUser(ctx context.Context, in domain.CreateCustomer
 		return
 	}
 
-	var user = createUser(domain.CustomerUserRole, in.Email, in.Mobile)
-	var customer = domain.CreateCustomer(domain.CustomerCreateOption{
-		User:   &user,
-		Name:   in.Name,
-		Email:  in.Email,
-		Mobile: in.Mobile,
-	})
+	user, customer, event, err := u.createCustomerUser(in, u.clock.Now())
+	if err != nil {
+		return
+	}
 
 	err = u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
 		mr := u.customerRepo.With(ur)
+		obr := u.outboxRepo.With(ur)
 		g, gc := errgroup.WithContext(c)
 		g.Go(func() error {
-			return ur.Save(gc, &user)
+			return ur.Create(gc, &user)
 		})
 		g.Go(func() error {
 			return mr.Save(gc, &customer)
 		})
+		g.Go(func() error {
+			return obr.Save(gc, &event)
+		})
 		return g.Wait()
 	})
 	newId = user.Id
 	return
 }
 
-
 func (u *ucase) CreateAdminUser(ctx context.Context, in domain.CreateAdminUser) (newId uuid.UUID, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	email, err := u.userRepo.GetByUsername(c, in.Email)
@@ -146,7 +471,7 @@ func (u *ucase) CreateAdminUser(ctx context.Context, in domain.CreateAdminUser)
 		return
 	}
 
-	var user = createUser(domain.AdminUserRole, in.Email, in.Password)
+	var user = u.createUser(domain.AdminUserRole, in.Email, in.Password, u.clock.Now())
 	var manager = domain.CreateManager(domain.ManagerCreateOption{
 		User:     &user,
 		Name:     in.Name,
@@ -157,19 +482,29 @@ func (u *ucase) CreateAdminUser(ctx context.Context, in domain.CreateAdminUser)
 		mr := u.managerRepo.With(ur)
 		g, gc := errgroup.WithContext(c)
 		g.Go(func() error {
-			return ur.Save(gc, &user)
+			return ur.Create(gc, &user)
 		})
 		g.Go(func() error {
 			return mr.Save(gc, &manager)
 		})
 		return g.Wait()
 	})
+	if err != nil {
+		return
+	}
+
 	newId = user.Id
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.CreatedBy,
+		TargetId: user.Id,
+		Action:   domain.AuditActionAdminCreated,
+		Ip:       in.Ip,
+	})
 	return
 }
 
 func (u *ucase) UpdateCustomerUser(ctx context.Context, in domain.UpdateCustomerUser) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	exists, err := u.userRepo.GetByUsername(c, in.Email)
@@ -209,48 +544,149 @@ func (u *ucase) UpdateCustomerUser(ctx context.Context, in domain.UpdateCustomer
 		in.Name,
 		in.ChannelName,
 		in.ChannelLink,
-		in.Email,
-		in.Mobile,
 		in.PersonaLink,
 		in.OnedriveLink,
 		in.Memo,
+		u.clock.Now(),
 	)
 
+	event, err := u.requestUsernameChange(user, in.Email)
+	if err != nil {
+		return
+	}
+
 	return u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		mr := u.customerRepo.With(ur)
 		g, gc := errgroup.WithContext(c)
 		g.Go(func() error {
-			return u.userRepo.Save(gc, user)
+			return ur.Save(gc, user)
 		})
 		g.Go(func() error {
-			return u.customerRepo.Save(gc, user.Customer)
+			return mr.Save(gc, user.Customer)
 		})
+		if event != nil {
+			g.Go(func() error {
+				return u.outboxRepo.With(ur).Save(gc, event)
+			})
+		}
 		return g.Wait()
 	})
 }
 
-func (u *ucase) UpdateAdminPassword(ctx context.Context, in domain.UpdateAdminPassword) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+func (u *ucase) UpdateCustomerProfile(ctx context.Context, in domain.UpdateCustomerProfile) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	user, err := u.userRepo.GetById(c, in.UserId)
-	if !domain.CheckUserAlive(user,
-		domain.User.IsAdmin,
-		domain.User.IsSuperAdmin) {
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	user.Customer, err = u.customerRepo.GetById(c, user.Id)
+	if err != nil {
+		return
+	}
+
+	user.UpdateCustomerProfile(in.ChannelName, in.ChannelLink, in.PersonaLink, in.Memo, u.clock.Now())
+
+	return u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		mr := u.customerRepo.With(ur)
+		g, gc := errgroup.WithContext(c)
+		g.Go(func() error {
+			return ur.Save(gc, user)
+		})
+		g.Go(func() error {
+			return mr.Save(gc, user.Customer)
+		})
+		return g.Wait()
+	})
+}
+
+func (u *ucase) UpdateCustomerBusinessInfo(ctx context.Context, in domain.UpdateCustomerBusinessInfo) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetById(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = user.LoadCustomerInfo(c, u.customerRepo)
+	if err != nil {
+		return
+	}
+
+	err = user.Customer.UpdateBusinessInfo(in.BusinessInfo)
+	if err != nil {
+		return
+	}
+
+	return u.customerRepo.Save(c, user.Customer)
+}
+
+func (u *ucase) UpdateAdminPassword(ctx context.Context, in domain.UpdateAdminPassword) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	identity, err := u.identityRepo.GetById(c, in.UserId)
+	if !domain.CheckIdentityAlive(identity,
+		domain.Identity.IsAdmin,
+		domain.Identity.IsSuperAdmin) {
 		err = domain.ErrItemNotFound
 		return
 	}
 
-	if !user.ComparePassword(in.OldPassword) {
+	if !identity.ComparePassword(in.OldPassword) {
 		err = domain.ErrUserWrongPassword
 		return
 	}
 
-	user.UpdatePassword(in.NewPassword)
-	return u.userRepo.Save(c, user)
+	err = u.changeAdminPassword(c, identity, in.NewPassword)
+	if err != nil {
+		return
+	}
+
+	return u.revokeTokens(c, identity.Id, u.clock.Now())
+}
+
+// changeAdminPassword 비밀번호 규칙, 재사용 검사 후 바꾸기 전 해시 기록과 변경을 한 트랜잭션으로 저장
+// steps 는 같은 트랜잭션에서 저장 전에 실행
+func (u *ucase) changeAdminPassword(ctx context.Context, identity *domain.Identity, plain string, steps ...func(ur domain.UserTxRepository) error) (err error) {
+	err = u.passwordPolicy.Check(ctx, *identity, plain)
+	if err != nil {
+		return
+	}
+
+	before := *identity
+	identity.UpdatePassword(plain, u.clock.Now())
+	return u.userRepo.Transaction(ctx, func(ur domain.UserTxRepository) error {
+		for _, step := range steps {
+			err := step(ur)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := u.passwordPolicy.Remember(ctx, ur, before)
+		if err != nil {
+			return err
+		}
+		return u.identityRepo.With(ur).Save(ctx, identity)
+	})
 }
 
 func (u *ucase) UpdateAdminInfo(ctx context.Context, in domain.UpdateAdminInfo) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	exists, err := u.userRepo.GetByUsername(c, in.Username)
@@ -287,7 +723,13 @@ func (u *ucase) UpdateAdminInfo(ctx context.Context, in domain.UpdateAdminInfo)
 		return
 	}
 
-	user.UpdateManagerInfo(in.Username, in.Name, in.Nickname)
+	user.UpdateManagerInfo(in.Name, in.Nickname, u.clock.Now())
+
+	event, err := u.requestUsernameChange(user, in.Username)
+	if err != nil {
+		return
+	}
+
 	return u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
 		g, gc := errgroup.WithContext(c)
 		g.Go(func() error {
@@ -296,12 +738,17 @@ func (u *ucase) UpdateAdminInfo(ctx context.Context, in domain.UpdateAdminInfo)
 		g.Go(func() error {
 			return u.managerRepo.Save(gc, user.Manager)
 		})
+		if event != nil {
+			g.Go(func() error {
+				return u.outboxRepo.With(ur).Save(gc, event)
+			})
+		}
 		return g.Wait()
 	})
 }
 
 func (u *ucase) ForceUpdateAdminInfo(ctx context.Context, in domain.ForceUpdateAdminInfo) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	exists, err := u.userRepo.GetByUsername(c, in.Username)
@@ -338,21 +785,73 @@ func (u *ucase) ForceUpdateAdminInfo(ctx context.Context, in domain.ForceUpdateA
 		return
 	}
 
-	user.UpdateManagerInfo(in.Username, in.Name, in.Nickname)
-	return u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
-		g, gc := errgroup.WithContext(c)
+	user.UpdateManagerInfo(in.Name, in.Nickname, u.clock.Now())
+
+	event, err := u.requestUsernameChange(user, in.Username)
+	if err != nil {
+		return
+	}
+
+	err = u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		g, gc := errgroup.WithContext(c)
 		g.Go(func() error {
 			return u.userRepo.Save(gc, user)
 		})
 		g.Go(func() error {
 			return u.managerRepo.Save(gc, user.Manager)
 		})
+		if event != nil {
+			g.Go(func() error {
+				return u.outboxRepo.With(ur).Save(gc, event)
+			})
+		}
 		return g.Wait()
 	})
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.UpdatedBy,
+		TargetId: user.Id,
+		Action:   domain.AuditActionAdminInfoForceUpdated,
+		Ip:       in.Ip,
+	})
+	return
 }
 
 func (u *ucase) ForceUpdateAdminPassword(ctx context.Context, in domain.ForceUpdateAdminPassword) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	identity, err := u.identityRepo.GetById(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckIdentityAlive(identity,
+		domain.Identity.IsAdmin,
+		domain.Identity.IsSuperAdmin) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = u.changeAdminPassword(c, identity, in.Password)
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.UpdatedBy,
+		TargetId: identity.Id,
+		Action:   domain.AuditActionAdminPasswordForceUpdated,
+		Ip:       in.Ip,
+	})
+	return u.revokeTokens(c, identity.Id, u.clock.Now())
+}
+
+func (u *ucase) UpdateManagerCapacity(ctx context.Context, in domain.UpdateManagerCapacity) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	user, err := u.userRepo.GetById(c, in.UserId)
@@ -367,12 +866,45 @@ func (u *ucase) ForceUpdateAdminPassword(ctx context.Context, in domain.ForceUpd
 		return
 	}
 
-	user.UpdatePassword(in.Password)
-	return u.userRepo.Save(c, user)
+	err = user.LoadManagerInfo(c, u.managerRepo)
+	if err != nil {
+		return
+	}
+
+	user.Manager.Capacity = in.Capacity
+	return u.managerRepo.Save(c, user.Manager)
+}
+
+func (u *ucase) UpdateManagerSkills(ctx context.Context, in domain.UpdateManagerSkills) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetById(c, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user,
+		domain.User.IsAdmin,
+		domain.User.IsSuperAdmin) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = user.LoadManagerInfo(c, u.managerRepo)
+	if err != nil {
+		return
+	}
+
+	err = user.Manager.SetSkillTags(in.Skills)
+	if err != nil {
+		return
+	}
+	return u.managerRepo.Save(c, user.Manager)
 }
 
 func (u *ucase) DeleteCustomerUser(ctx context.Context, in domain.DeleteCustomerUser) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	user, err := u.userRepo.GetById(c, in.UserId)
@@ -385,12 +917,89 @@ func (u *ucase) DeleteCustomerUser(ctx context.Context, in domain.DeleteCustomer
 		return
 	}
 
-	user.Delete()
-	return u.userRepo.Save(c, user)
+	user.Delete(in.DeletedBy, u.clock.Now())
+	err = u.userRepo.Save(c, user)
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.DeletedBy,
+		TargetId: user.Id,
+		Action:   domain.AuditActionCustomerDeleted,
+		Ip:       in.Ip,
+	})
+	return u.revokeTokens(c, user.Id, u.clock.Now())
+}
+
+func (u *ucase) RestoreUser(ctx context.Context, in domain.RestoreUser) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	return u.restoreUser(c, in)
+}
+
+func (u *ucase) RestoreCustomerUser(ctx context.Context, in domain.RestoreUser) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	return u.restoreUser(c, in, domain.User.IsCustomer)
+}
+
+// restoreUser 삭제된 유저 중 scope 에 맞는 유저만 복구
+func (u *ucase) restoreUser(ctx context.Context, in domain.RestoreUser, scope ...func(user domain.User) bool) (err error) {
+	user, err := u.userRepo.GetDeletedById(ctx, in.UserId)
+	if err != nil {
+		return
+	}
+
+	if user == nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+	for _, allow := range scope {
+		if !allow(*user) {
+			err = domain.ErrItemNotFound
+			return
+		}
+	}
+
+	if user.MergedInto != nil {
+		err = domain.ErrUserMerged
+		return
+	}
+
+	// 아이디는 삭제된 계정도 unique 라 그대로지만, 고객 이메일은 그 사이 다른 계정의 아이디가 됐을 수 있음
+	email := user.Username
+	if user.Customer != nil && user.Customer.Email != "" {
+		email = user.Customer.Email
+	}
+	exists, err := u.userRepo.GetByUsername(ctx, email)
+	if err != nil {
+		return
+	}
+	if exists != nil && exists.Id != user.Id {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	user.Restore(u.clock.Now())
+	err = u.userRepo.Save(ctx, user)
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(ctx, domain.AuditEntry{
+		ActorId:  in.RestoredBy,
+		TargetId: user.Id,
+		Action:   domain.AuditActionUserRestored,
+		Ip:       in.Ip,
+	})
+	return
 }
 
 func (u *ucase) DeleteAdminUser(ctx context.Context, in domain.DeleteAdminUser) (err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	user, err := u.userRepo.GetById(c, in.UserId)
@@ -400,16 +1009,383 @@ func (u *ucase) DeleteAdminUser(ctx context.Context, in domain.DeleteAdminUser)
 		return
 	}
 
-	user.Delete()
-	return u.userRepo.Save(c, user)
+	user.Delete(in.DeletedBy, u.clock.Now())
+	err = u.userRepo.Save(c, user)
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.DeletedBy,
+		TargetId: user.Id,
+		Action:   domain.AuditActionAdminDeleted,
+		Ip:       in.Ip,
+	})
+	return u.revokeTokens(c, user.Id, u.clock.Now())
+}
+
+// MergeCustomerUser 진행 중인 의뢰가 양쪽에 모두 있으면 의뢰가 하나라는 전제가 깨져서 ErrItemAlreadyExist
+func (u *ucase) MergeCustomerUser(ctx context.Context, in domain.MergeCustomerUser) (res domain.CustomerMergeResult, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	now := u.clock.Now()
+	if in.SurvivorId == in.DuplicateId {
+		err = domain.ErrWeirdData
+		return
+	}
+
+	res.SurvivorId = in.SurvivorId
+	err = u.userRepo.Transaction(c, func(ur domain.UserTxRepository) (err error) {
+		// 반대 방향 병합이 동시에 들어와도 교착되지 않도록 id 순서대로 잠금
+		first, second := in.SurvivorId, in.DuplicateId
+		if bytes.Compare(first[:], second[:]) > 0 {
+			first, second = second, first
+		}
+		locked := make(map[uuid.UUID]*domain.User, 2)
+		for _, id := range []uuid.UUID{first, second} {
+			locked[id], err = ur.GetByIdWithCustomerForUpdate(c, id)
+			if err != nil {
+				return
+			}
+		}
+		survivor, duplicate := locked[in.SurvivorId], locked[in.DuplicateId]
+		if !domain.CheckUserAlive(survivor, domain.User.IsCustomer) || survivor.Customer == nil ||
+			!domain.CheckUserAlive(duplicate, domain.User.IsCustomer) || duplicate.Customer == nil {
+			err = domain.ErrItemNotFound
+			return
+		}
+
+		or := u.orderRepo.With(ur)
+		survivorOrder, err := or.GetRecentByOrdererId(c, in.SurvivorId)
+		if err != nil {
+			return
+		}
+		duplicateOrder, err := or.GetRecentByOrdererId(c, in.DuplicateId)
+		if err != nil {
+			return
+		}
+		if survivorOrder != nil && !survivorOrder.IsDone() &&
+			duplicateOrder != nil && !duplicateOrder.IsDone() {
+			err = domain.ErrItemAlreadyExist
+			return
+		}
+
+		cr := u.creditRepo.With(ur)
+		lots, err := cr.FetchAvailableLots(c, in.DuplicateId, now)
+		if err != nil {
+			return
+		}
+
+		err = survivor.Customer.MergeFrom(*duplicate.Customer)
+		if err != nil {
+			return
+		}
+		duplicate.MergeInto(in.SurvivorId, in.MergedBy, now)
+
+		creditTx, movedCredit := domain.MoveCredit(in.DuplicateId, in.SurvivorId, lots, u.ids, now)
+		res.MovedCredit = movedCredit
+
+		res.MovedOrders, err = or.ReassignOrderer(c, in.DuplicateId, in.SurvivorId)
+		if err != nil {
+			return
+		}
+
+		res.MovedTickets, err = u.orderTicketRepo.With(ur).ReassignOwner(c, in.DuplicateId, in.SurvivorId)
+		if err != nil {
+			return
+		}
+
+		if !creditTx.IsEmpty() {
+			err = cr.SaveTransaction(c, &creditTx)
+			if err != nil {
+				return
+			}
+		}
+
+		err = u.customerRepo.With(ur).Save(c, survivor.Customer)
+		if err != nil {
+			return
+		}
+
+		// 연관 고객 정보까지 다시 저장하지 않도록 유저만 저장
+		duplicate.Customer = nil
+		err = ur.Save(c, duplicate)
+		if err != nil {
+			return
+		}
+
+		// 감사 기록, 병합 내용은 이벤트로 남김
+		event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+			Id:            u.ids.NewId(),
+			AggregateType: domain.OutboxAggregateTypeUser,
+			AggregateId:   in.SurvivorId,
+			EventType:     domain.OutboxEventTypeCustomerMerged,
+			Data: domain.CustomerMergedEvent{
+				SurvivorId:   in.SurvivorId,
+				DuplicateId:  in.DuplicateId,
+				MergedBy:     in.MergedBy,
+				MovedOrders:  res.MovedOrders,
+				MovedTickets: res.MovedTickets,
+				MovedCredit:  res.MovedCredit,
+			},
+			Now: now,
+		})
+		if err != nil {
+			return
+		}
+		return u.outboxRepo.With(ur).Save(c, &event)
+	})
+	if err != nil {
+		return
+	}
+
+	u.auditLogger.Record(c, domain.AuditEntry{
+		ActorId:  in.MergedBy,
+		TargetId: in.DuplicateId,
+		Action:   domain.AuditActionCustomerMerged,
+		Ip:       in.Ip,
+		Detail:   in.SurvivorId.String(),
+	})
+	err = u.revokeTokens(c, in.DuplicateId, now)
+	return
+}
+
+// requestUsernameChange 새 아이디로 바로 바꾸지 않고 확인 메일 발송 이벤트 생성, 변경이 없으면 nil
+func (u *ucase) requestUsernameChange(user *domain.User, username string) (event *domain.OutboxEvent, err error) {
+	old := user.Username
+	now := u.clock.Now()
+	token, err := user.RequestUsernameChange(username, now)
+	if err != nil || token == "" {
+		return
+	}
+
+	created, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeUser,
+		AggregateId:   user.Id,
+		EventType:     domain.OutboxEventTypeUsernameChangeRequested,
+		Data: domain.UsernameChangeRequestedEvent{
+			UserId:      user.Id,
+			OldUsername: old,
+			NewUsername: username,
+			Token:       token,
+			ExpiresAt:   *user.PendingUsernameExpiresAt,
+		},
+		Now: now,
+	})
+	if err != nil {
+		return
+	}
+	event = &created
+	return
+}
+
+func (u *ucase) ConfirmUsernameChange(ctx context.Context, token string) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByPendingUsernameToken(c, domain.HashUsernameChangeToken(token))
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	// 요청 이후 다른 계정이 같은 아이디를 선점했을 수 있으므로 다시 확인
+	exists, err := u.userRepo.GetByUsername(c, *user.PendingUsername)
+	if err != nil {
+		return
+	}
+	if exists != nil && exists.Id != user.Id {
+		err = domain.ErrItemAlreadyExist
+		return
+	}
+
+	if user.IsCustomer() {
+		err = user.LoadCustomerInfo(c, u.customerRepo)
+		if err != nil {
+			return
+		}
+	}
+
+	err = user.ConfirmUsernameChange(u.clock.Now())
+	if err != nil {
+		return
+	}
+
+	return u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		g, gc := errgroup.WithContext(c)
+		g.Go(func() error {
+			return ur.Save(gc, user)
+		})
+		if user.Customer != nil {
+			g.Go(func() error {
+				return u.customerRepo.With(ur).Save(gc, user.Customer)
+			})
+		}
+		return g.Wait()
+	})
+}
+
+func (u *ucase) RequestPasswordReset(ctx context.Context, username string) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	identity, err := u.identityRepo.GetByUsername(c, username)
+	if err != nil {
+		return
+	}
+
+	// 가입 여부를 알 수 없게 없는 계정도 성공처럼 끝냄
+	if !domain.CheckIdentityAlive(identity) {
+		return
+	}
+
+	now := u.clock.Now()
+	cnt, err := u.passwordResetRepo.CountByUserSince(c, identity.Id, now.Add(-domain.PasswordResetRequestWindow))
+	if err != nil {
+		return
+	}
+	if cnt >= domain.PasswordResetRequestLimit {
+		logx.From(ctx).WithField("userId", identity.Id).Info(tag, "password reset request limit reached")
+		return
+	}
+
+	token, reset, err := domain.NewPasswordReset(u.ids.NewId(), identity.Id, now)
+	if err != nil {
+		return
+	}
+
+	event, err := domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeUser,
+		AggregateId:   identity.Id,
+		EventType:     domain.OutboxEventTypePasswordResetRequested,
+		Data: domain.PasswordResetRequestedEvent{
+			UserId:    identity.Id,
+			Username:  identity.Username,
+			Token:     token,
+			ExpiresAt: reset.ExpiresAt,
+		},
+		Now: now,
+	})
+	if err != nil {
+		return
+	}
+
+	return u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+		err := u.passwordResetRepo.With(ur).Create(c, &reset)
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.With(ur).Save(c, &event)
+	})
+}
+
+func (u *ucase) ResetPassword(ctx context.Context, in domain.ResetPassword) (err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	reset, err := u.passwordResetRepo.GetByHash(c, domain.HashPasswordResetToken(in.Token))
+	if err != nil {
+		return
+	}
+
+	if reset == nil || reset.UsedAt != nil {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	now := u.clock.Now()
+	if reset.IsExpired(now) {
+		err = domain.ErrTokenExpired
+		return
+	}
+
+	user, err := u.userRepo.GetById(c, reset.UserId)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	// 같은 토큰으로 동시에 들어온 요청은 하나만 통과
+	useToken := func(ur domain.UserTxRepository) error {
+		used, err := u.passwordResetRepo.With(ur).Use(c, reset.Id, reset.UserId, now)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return domain.ErrItemNotFound
+		}
+		return nil
+	}
+
+	if user.IsCustomer() {
+		user.UpdatePassword(in.Password, now)
+		err = u.userRepo.Transaction(c, func(ur domain.UserTxRepository) error {
+			err := useToken(ur)
+			if err != nil {
+				return err
+			}
+			return ur.Save(c, user)
+		})
+	} else {
+		err = u.changeAdminPassword(c, &user.Identity, in.Password, useToken)
+	}
+	if err != nil {
+		return
+	}
+
+	return u.revokeTokens(c, user.Id, now)
+}
+
+// revokeTokens 갱신 토큰 폐기, 접근 토큰 버전 캐시도 비워 이 서버에서는 바뀐 버전을 바로 확인
+func (u *ucase) revokeTokens(ctx context.Context, userId uuid.UUID, at time.Time) error {
+	u.tokenVersions.Invalidate(tenant.CacheKey(ctx, userId.String()))
+	return u.refreshTokenRepo.RevokeByUser(ctx, userId, at)
+}
+
+// createCustomerUser 고객 유저와 고객 생성 이벤트, 초기 비밀번호는 휴대폰 번호
+func (u *ucase) createCustomerUser(in domain.CreateCustomerUser, now time.Time) (user domain.User, customer domain.Customer, event domain.OutboxEvent, err error) {
+	user = u.createUser(domain.CustomerUserRole, in.Email, in.Mobile, now)
+	customer = domain.CreateCustomer(domain.CustomerCreateOption{
+		User:   &user,
+		Name:   in.Name,
+		Email:  in.Email,
+		Mobile: in.Mobile,
+	})
+
+	event, err = domain.CreateOutboxEvent(domain.CreateOutboxEventOption{
+		Id:            u.ids.NewId(),
+		AggregateType: domain.OutboxAggregateTypeUser,
+		AggregateId:   user.Id,
+		EventType:     domain.OutboxEventTypeCustomerCreated,
+		Data: domain.CustomerCreatedEvent{
+			UserId: user.Id,
+			Name:   in.Name,
+			Email:  in.Email,
+		},
+		Now: now,
+	})
+	return
 }
 
-func createUser(role domain.UserRole, username, password string) (user domain.User) {
+func (u *ucase) createUser(role domain.UserRole, username, password string, now time.Time) (user domain.User) {
 	user = domain.CreateUser(domain.UserCreateOption{
+		Id:       u.ids.NewId(),
 		Role:     role,
 		Username: username,
+		Now:      now,
 	})
 
-	user.UpdatePassword(password)
+	user.UpdatePassword(password, now)
 	return
-}
\ No newline at end of file
+}
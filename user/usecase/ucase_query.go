@@ -5,12 +5,12 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/stockfolioofficial/back-editfolio/domain"
+	"github.com/stockfolioofficial/back-editfolio/util/budget"
 	"golang.org/x/sync/errgroup"
-	"time"
 )
 
 func (u *ucase) FetchAllAdmin(ctx context.Context, option domain.FetchAdminOption) (res []domain.AdminInfoData, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	list, err := u.userRepo.FetchAllAdmin(c, option)
@@ -28,10 +28,14 @@ func (u *ucase) FetchAllAdmin(ctx context.Context, option domain.FetchAdminOptio
 		}
 		res[i] = domain.AdminInfoData{
 			UserId:    src.Id,
+			Role:      src.Role,
+			Status:    src.Status(),
 			Name:      src.Manager.Name,
 			Nickname:  src.Manager.Nickname,
 			Email:     src.Username,
+			Skills:    src.Manager.SkillTags(),
 			CreatedAt: src.CreatedAt,
+			DeletedAt: src.DeletedAt,
 		}
 	}
 
@@ -39,14 +43,68 @@ func (u *ucase) FetchAllAdmin(ctx context.Context, option domain.FetchAdminOptio
 }
 
 func (u *ucase) FetchAllCustomer(ctx context.Context, option domain.FetchCustomerOption) (res []domain.CustomerInfoData, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
+	err = u.applyCustomerView(c, &option)
+	if err != nil {
+		return
+	}
+
 	list, err := u.userRepo.FetchAllCustomer(c, option)
 	if err != nil {
 		return
 	}
 
+	res, err = customerInfoListOf(list)
+	return
+}
+
+func (u *ucase) FetchCustomers(ctx context.Context, in domain.FetchCustomers) (res domain.CustomerInfoPage, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	err = u.applyCustomerView(c, &in.FetchCustomerOption)
+	if err != nil {
+		return
+	}
+
+	offset := in.Offset()
+	list, total, err := u.userRepo.FetchCustomerPage(c, in.FetchCustomerOption, offset, in.Size)
+	if err != nil {
+		return
+	}
+
+	res.List, err = customerInfoListOf(list)
+	if err != nil {
+		return
+	}
+
+	res.Total = total
+	res.Page = in.Page
+	res.Size = in.Size
+	return
+}
+
+// applyCustomerView 저장된 보기가 있으면 option 에 적용, 요청자 본인의 고객 목록 보기가 아니면 ErrItemNotFound
+func (u *ucase) applyCustomerView(ctx context.Context, option *domain.FetchCustomerOption) (err error) {
+	if option.ViewId == nil {
+		return
+	}
+
+	view, err := u.savedViewRepo.GetById(ctx, *option.ViewId)
+	if err != nil {
+		return
+	}
+	if view == nil || view.OwnerId != option.ViewerId || view.Target != domain.SavedViewTargetCustomer {
+		err = domain.ErrItemNotFound
+		return
+	}
+	view.ApplyToCustomerOption(option)
+	return
+}
+
+func customerInfoListOf(list []domain.User) (res []domain.CustomerInfoData, err error) {
 	res = make([]domain.CustomerInfoData, len(list))
 	for i := range list {
 		src := list[i]
@@ -63,14 +121,15 @@ func (u *ucase) FetchAllCustomer(ctx context.Context, option domain.FetchCustome
 			Email:       src.Customer.Email,
 			Mobile:      src.Customer.Mobile,
 			CreatedAt:   src.CreatedAt,
+
+			CustomFields: src.Customer.CustomFieldValues(),
 		}
 	}
-
 	return
 }
 
 func (u *ucase) GetAdminInfoDetailByUserId(ctx context.Context, userId uuid.UUID) (res domain.AdminInfoDetailData, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	user, err := u.userRepo.GetByIdWithManager(c, userId)
@@ -100,9 +159,8 @@ func (u *ucase) GetAdminInfoDetailByUserId(ctx context.Context, userId uuid.UUID
 	return
 }
 
-
 func (u *ucase) GetCustomerInfoDetailByUserId(ctx context.Context, userId uuid.UUID) (res domain.CustomerInfoDetailData, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	detail, err := u.userRepo.GetByIdWithCustomer(c, userId)
@@ -121,23 +179,35 @@ func (u *ucase) GetCustomerInfoDetailByUserId(ctx context.Context, userId uuid.U
 	}
 
 	res = domain.CustomerInfoDetailData{
-		UserId:         detail.Id,
-		Name:           detail.Customer.Name,
-		ChannelName:    detail.Customer.ChannelName,
-		ChannelLink:    detail.Customer.ChannelLink,
-		Email:          detail.Customer.Email,
-		Mobile:         detail.Customer.Mobile,
-		PersonaLink:    detail.Customer.PersonaLink,
-		OnedriveLink:   detail.Customer.OnedriveLink,
-		Memo:           detail.Customer.Memo,
-		CreatedAt:      detail.CreatedAt,
-		UpdatedAt:      detail.UpdatedAt,
+		UserId:       detail.Id,
+		Name:         detail.Customer.Name,
+		ChannelName:  detail.Customer.ChannelName,
+		ChannelLink:  detail.Customer.ChannelLink,
+		Email:        detail.Customer.Email,
+		Mobile:       detail.Customer.Mobile,
+		PersonaLink:  detail.Customer.PersonaLink,
+		OnedriveLink: detail.Customer.OnedriveLink,
+		Memo:         detail.Customer.Memo,
+		CustomFields: detail.Customer.CustomFieldValues(),
+		CreatedAt:    detail.CreatedAt,
+		UpdatedAt:    detail.UpdatedAt,
+
+		EmailUndeliverableAt:     detail.Customer.EmailUndeliverableAt,
+		EmailUndeliverableReason: detail.Customer.EmailUndeliverableReason,
+	}
+	if info, ok := detail.Customer.BusinessInfo(); ok {
+		res.Business = &info
+	}
+
+	res.StorageUsage, err = u.storageQuota.Usage(c, detail.Id)
+	if err != nil {
+		res = domain.CustomerInfoDetailData{}
 	}
 	return
 }
 
 func (u *ucase) CustomerSubscribeInfoByUserId(ctx context.Context, userId uuid.UUID) (res domain.CustomerSubscribeInfoData, err error) {
-	c, cancel := context.WithTimeout(ctx, u.timeout)
+	c, cancel := budget.Slice(ctx, u.timeout)
 	defer cancel()
 
 	g, gc := errgroup.WithContext(c)
@@ -157,14 +227,13 @@ func (u *ucase) CustomerSubscribeInfoByUserId(ctx context.Context, userId uuid.U
 			return
 		}
 
-
 		res.UserId = userId
 		res.Name = exists.Customer.Name
 		res.OnedriveLink = exists.Customer.OnedriveLink
 		return
 	})
 	g.Go(func() (err error) {
-		ticket, err := u.orderTicketRepo.GetByOwnerIdBetweenStartAndEnd(gc, userId, time.Now())
+		ticket, err := u.orderTicketRepo.GetByOwnerIdBetweenStartAndEnd(gc, userId, u.clock.Now())
 		if err != nil {
 			return
 		}
@@ -177,6 +246,10 @@ func (u *ucase) CustomerSubscribeInfoByUserId(ctx context.Context, userId uuid.U
 
 		return
 	})
+	g.Go(func() (err error) {
+		res.StorageUsage, err = u.storageQuota.Usage(gc, userId)
+		return
+	})
 	err = g.Wait()
 	if err != nil {
 		res = domain.CustomerSubscribeInfoData{}
@@ -185,3 +258,29 @@ func (u *ucase) CustomerSubscribeInfoByUserId(ctx context.Context, userId uuid.U
 
 	return
 }
+
+func (u *ucase) GetTaxInvoiceInfo(ctx context.Context, username string) (res domain.BusinessInfo, err error) {
+	c, cancel := budget.Slice(ctx, u.timeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByUsername(c, username)
+	if err != nil {
+		return
+	}
+
+	if !domain.CheckUserAlive(user, domain.User.IsCustomer) {
+		err = domain.ErrItemNotFound
+		return
+	}
+
+	err = user.LoadCustomerInfo(c, u.customerRepo)
+	if err != nil {
+		return
+	}
+
+	res, ok := user.Customer.BusinessInfo()
+	if !ok {
+		err = domain.ErrBusinessInfoRequired
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stockfolioofficial/back-editfolio/core/idgen"
+	"github.com/stockfolioofficial/back-editfolio/domain"
+)
+
+func TestCreateCustomerUserUsesInjectedIds(t *testing.T) {
+	userId := uuid.MustParse("00000000-0000-4000-8000-000000000001")
+	eventId := uuid.MustParse("00000000-0000-4000-8000-000000000002")
+	now := time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)
+	u := &ucase{ids: idgen.Sequence(userId, eventId)}
+
+	user, customer, event, err := u.createCustomerUser(domain.CreateCustomerUser{
+		Name:   "홍길동",
+		Email:  "customer@example.com",
+		Mobile: "01012345678",
+	}, now)
+	if err != nil {
+		t.Fatalf("createCustomerUser: %v", err)
+	}
+
+	if user.Id != userId {
+		t.Errorf("user id %v, want %v", user.Id, userId)
+	}
+	if customer.Id != userId {
+		t.Errorf("customer id %v, want user id %v", customer.Id, userId)
+	}
+	if event.Id != eventId || event.AggregateId != userId {
+		t.Errorf("event id %v aggregate %v, want %v aggregate %v", event.Id, event.AggregateId, eventId, userId)
+	}
+	if !user.CreatedAt.Equal(now) || !event.CreatedAt.Equal(now) {
+		t.Errorf("user created at %v, event created at %v, want %v", user.CreatedAt, event.CreatedAt, now)
+	}
+}
@@ -0,0 +1,81 @@
+package awsv4
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// containerCredentialsHost ECS 작업 역할 자격 증명 엔드포인트
+const containerCredentialsHost = "http://169.254.170.2"
+
+var ErrNoCredentials = errors.New("aws credentials not found")
+
+type Credentials struct {
+	AccessKeyId     string    `json:"AccessKeyId"`
+	SecretAccessKey string    `json:"SecretAccessKey"`
+	Token           string    `json:"Token"`
+	Expiration      time.Time `json:"Expiration"`
+}
+
+// CredentialSource 환경 변수(AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN) 우선,
+// 없으면 ECS 작업 역할 자격 증명을 만료 5분 전까지 재사용
+type CredentialSource struct {
+	client *http.Client
+
+	mu    sync.Mutex
+	creds Credentials
+}
+
+func NewCredentialSource(client *http.Client) *CredentialSource {
+	return &CredentialSource{client: client}
+}
+
+func (s *CredentialSource) Get(ctx context.Context) (Credentials, error) {
+	if id := os.Getenv("AWS_ACCESS_KEY_ID"); id != "" {
+		return Credentials{
+			AccessKeyId:     id,
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			Token:           os.Getenv("AWS_SESSION_TOKEN"),
+		}, nil
+	}
+
+	uri := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI")
+	if uri == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Until(s.creds.Expiration) > 5*time.Minute {
+		return s.creds, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, containerCredentialsHost+uri, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Credentials{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Credentials{}, fmt.Errorf("container credentials: status %d", resp.StatusCode)
+	}
+
+	var creds Credentials
+	err = json.NewDecoder(resp.Body).Decode(&creds)
+	if err != nil {
+		return Credentials{}, err
+	}
+	s.creds = creds
+	return creds, nil
+}
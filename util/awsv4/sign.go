@@ -0,0 +1,193 @@
+package awsv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	timeFormat = "20060102T150405Z"
+	dateFormat = "20060102"
+	algorithm  = "AWS4-HMAC-SHA256"
+
+	// UnsignedPayload presigned URL 처럼 본문 해시를 미리 알 수 없을 때
+	UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// Signer AWS Signature Version 4, S3 호환 저장소(MinIO 등)도 같은 방식
+type Signer struct {
+	Region  string
+	Service string
+}
+
+func HashPayload(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// Sign Authorization 헤더 서명, req 에 이미 설정된 헤더와 host 를 모두 서명에 포함
+func (s Signer) Sign(req *http.Request, payloadHash string, creds Credentials, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(timeFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.Service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+	if creds.Token != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.Token)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	canonicalHeaders, signedHeaders := canonicalize(headers)
+
+	scope := s.scope(now)
+	signature := s.signature(creds, now, scope, strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n"))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, creds.AccessKeyId, scope, signedHeaders, signature))
+}
+
+// Presign 쿼리 문자열 서명 URL, host 헤더만 서명하므로 호출하는 쪽은 다른 헤더 제약 없음
+func (s Signer) Presign(method string, u *url.URL, creds Credentials, now time.Time, expires time.Duration) string {
+	return s.PresignHeaders(method, u, nil, creds, now, expires)
+}
+
+// PresignHeaders headers 도 서명에 넣음, 호출하는 쪽은 같은 값으로 헤더를 보내야 함
+func (s Signer) PresignHeaders(method string, u *url.URL, headers map[string]string, creds Credentials, now time.Time, expires time.Duration) string {
+	now = now.UTC()
+	scope := s.scope(now)
+
+	signing := map[string]string{"host": u.Host}
+	for name, value := range headers {
+		signing[strings.ToLower(name)] = strings.TrimSpace(value)
+	}
+	canonicalHeaders, signedHeaders := canonicalize(signing)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", creds.AccessKeyId+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(timeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	if creds.Token != "" {
+		query.Set("X-Amz-Security-Token", creds.Token)
+	}
+
+	signature := s.signature(creds, now, scope, strings.Join([]string{
+		method,
+		canonicalURI(u),
+		canonicalQuery(query),
+		canonicalHeaders,
+		signedHeaders,
+		UnsignedPayload,
+	}, "\n"))
+	query.Set("X-Amz-Signature", signature)
+
+	signed := *u
+	signed.RawQuery = canonicalQuery(query)
+	return signed.String()
+}
+
+func (s Signer) scope(now time.Time) string {
+	return fmt.Sprintf("%s/%s/%s/aws4_request", now.Format(dateFormat), s.Region, s.Service)
+}
+
+func (s Signer) signature(creds Credentials, now time.Time, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		algorithm,
+		now.Format(timeFormat),
+		scope,
+		HashPayload([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), now.Format(dateFormat))
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, s.Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalize 헤더 이름순 정렬
+func canonicalize(headers map[string]string) (canonical, signed string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + ":" + headers[name] + "\n")
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+func canonicalURI(u *url.URL) string {
+	if u.Path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i := range segments {
+		segments[i] = Escape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, Escape(key)+"="+Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// Escape RFC 3986 unreserved 문자만 그대로 두는 인코딩 (url.QueryEscape 는 공백을 + 로 바꿔서 사용 불가)
+func Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
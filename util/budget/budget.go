@@ -0,0 +1,64 @@
+package budget
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// maxReserve 응답 작성용 여유분 상한
+	maxReserve = time.Second
+)
+
+type windowKey struct{}
+
+type window struct {
+	start time.Time
+	total time.Duration
+}
+
+// WithBudget 요청 전체 제한 시간 설정, 하위 호출은 Slice 로 남은 시간만 나눠 받음
+func WithBudget(ctx context.Context, total time.Duration) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, windowKey{}, window{start: time.Now(), total: total})
+	return context.WithTimeout(ctx, total)
+}
+
+// Elapsed 요청 시작 후 지난 시간, 예산이 없으면 0
+func Elapsed(ctx context.Context) time.Duration {
+	if w, ok := ctx.Value(windowKey{}).(window); ok {
+		return time.Since(w.start)
+	}
+	return 0
+}
+
+// Remaining 남은 시간, deadline 이 없으면 false
+func Remaining(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
+
+// Slice 하위 호출(repository, adapter)용 context,
+// 남은 예산에서 응답 작성용 여유분(전체의 10%, 최대 1초)을 빼고 limit 이하로 제한
+func Slice(ctx context.Context, limit time.Duration) (context.Context, context.CancelFunc) {
+	timeout := limit
+	if remaining, ok := Remaining(ctx); ok {
+		if w, ok := ctx.Value(windowKey{}).(window); ok {
+			remaining -= reserveOf(w.total)
+		}
+		if remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func reserveOf(total time.Duration) time.Duration {
+	reserve := total / 10
+	if reserve > maxReserve {
+		return maxReserve
+	}
+	return reserve
+}
@@ -0,0 +1,46 @@
+package echox
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CachedJSON Cache-Control, ETag 를 붙여 응답, If-None-Match 가 같으면 본문 없이 304
+func CachedJSON(ctx echo.Context, maxAge time.Duration, i interface{}) error {
+	body, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+
+	sum := sha1.Sum(body)
+	etag := `W/"` + hex.EncodeToString(sum[:]) + `"`
+
+	header := ctx.Response().Header()
+	header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	header.Set("ETag", etag)
+
+	if matchETag(ctx.Request().Header.Get("If-None-Match"), etag) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+	return ctx.JSONBlob(http.StatusOK, body)
+}
+
+func matchETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag || `W/`+candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package echox
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+
+	// brotliLevel 응답마다 압축하므로 압축률보다 속도 우선
+	brotliLevel = 4
+)
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(io.Discard)
+	},
+}
+
+// Compress 응답 본문이 threshold 바이트 이상일 때만 br, gzip 순으로 협상해 압축
+// 작은 응답은 압축 비용이 더 커서 그대로 보냄
+func Compress(threshold int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			encoding := negotiateEncoding(req.Header.Get(echo.HeaderAcceptEncoding))
+			if encoding == "" || req.Method == http.MethodHead {
+				return next(ctx)
+			}
+
+			res := ctx.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			w := &compressWriter{
+				ResponseWriter: res.Writer,
+				encoding:       encoding,
+				threshold:      threshold,
+			}
+			res.Writer = w
+			defer func() {
+				_ = w.Close()
+				res.Writer = w.ResponseWriter
+			}()
+
+			return next(ctx)
+		}
+	}
+}
+
+// negotiateEncoding q=0 으로 거부한 인코딩은 제외
+func negotiateEncoding(accept string) string {
+	var gzipOk bool
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if len(fields) > 1 && strings.TrimSpace(fields[1]) == "q=0" {
+			continue
+		}
+
+		switch name {
+		case encodingBrotli:
+			return encodingBrotli
+		case encodingGzip:
+			gzipOk = true
+		}
+	}
+
+	if gzipOk {
+		return encodingGzip
+	}
+	return ""
+}
+
+func compressible(header http.Header) bool {
+	if header.Get(echo.HeaderContentEncoding) != "" {
+		return false
+	}
+
+	contentType := header.Get(echo.HeaderContentType)
+	for _, prefix := range []string{"image/", "video/", "audio/", "application/zip", "application/gzip"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// compressWriter threshold 까지는 버퍼에 모았다가 넘으면 압축 시작, 끝까지 못 넘으면 원본 그대로 씀
+type compressWriter struct {
+	http.ResponseWriter
+	encoding  string
+	threshold int
+
+	status  int
+	buf     bytes.Buffer
+	decided bool
+	enc     io.WriteCloser
+	gz      *gzip.Writer
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf.Write(b)
+	if w.buf.Len() >= w.threshold {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *compressWriter) decide(compress bool) (err error) {
+	w.decided = true
+
+	header := w.Header()
+	if compress && compressible(header) {
+		header.Del(echo.HeaderContentLength)
+		header.Set(echo.HeaderContentEncoding, w.encoding)
+
+		switch w.encoding {
+		case encodingBrotli:
+			w.enc = brotli.NewWriterLevel(w.ResponseWriter, brotliLevel)
+		default:
+			w.gz = gzipWriterPool.Get().(*gzip.Writer)
+			w.gz.Reset(w.ResponseWriter)
+			w.enc = w.gz
+		}
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+
+	if w.buf.Len() > 0 {
+		if w.enc != nil {
+			_, err = w.enc.Write(w.buf.Bytes())
+		} else {
+			_, err = w.ResponseWriter.Write(w.buf.Bytes())
+		}
+	}
+	w.buf = bytes.Buffer{}
+	return
+}
+
+func (w *compressWriter) Close() (err error) {
+	if !w.decided {
+		err = w.decide(false)
+		if err != nil {
+			return
+		}
+	}
+
+	if w.enc != nil {
+		err = w.enc.Close()
+		if w.gz != nil {
+			w.gz.Reset(io.Discard)
+			gzipWriterPool.Put(w.gz)
+			w.gz = nil
+		}
+		w.enc = nil
+	}
+	return
+}
+
+// Flush 스트리밍 응답은 threshold 와 관계없이 바로 압축 시작
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(true)
+	}
+	if flusher, ok := w.enc.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
@@ -0,0 +1,128 @@
+package echox
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// FieldResource 필드 정책을 적용할 응답 타입, 슬라이스로 응답해도 요소 타입 기준으로 적용
+type FieldResource interface {
+	FieldResource() string
+}
+
+// FieldResourceEnvelope 목록을 감싼 응답(페이지 등), FieldResourceKey 필드 아래에만 정책을 적용하고 나머지는 그대로
+type FieldResourceEnvelope interface {
+	FieldResource
+	FieldResourceKey() string
+}
+
+// FieldPolicyFunc 리소스, 역할별 허용 필드, 정책이 없으면 false
+type FieldPolicyFunc func(resource, role string) (allowed []string, ok bool)
+
+var fieldResourceType = reflect.TypeOf((*FieldResource)(nil)).Elem()
+
+// resourceOf 응답 값 또는 슬라이스 요소가 FieldResource 면 리소스 이름
+func resourceOf(i interface{}) (string, bool) {
+	if r, ok := i.(FieldResource); ok {
+		return r.FieldResource(), true
+	}
+
+	t := reflect.TypeOf(i)
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+		return "", false
+	}
+
+	elem := t.Elem()
+	if !elem.Implements(fieldResourceType) {
+		return "", false
+	}
+	if elem.Kind() == reflect.Ptr {
+		return reflect.New(elem.Elem()).Interface().(FieldResource).FieldResource(), true
+	}
+	return reflect.Zero(elem).Interface().(FieldResource).FieldResource(), true
+}
+
+// filterFields 정책에 없는 필드를 지운 값, JSON 으로 한 번 변환 후 걸러냄
+func filterFields(c echo.Context, i interface{}, policy FieldPolicyFunc) (interface{}, error) {
+	resource, ok := resourceOf(i)
+	if !ok {
+		return i, nil
+	}
+
+	principal, _ := PrincipalOf(c)
+	allowed, ok := policy(resource, principal.Role)
+	if !ok {
+		return i, nil
+	}
+
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	err = dec.Decode(&generic)
+	if err != nil {
+		return nil, err
+	}
+
+	if envelope, ok := i.(FieldResourceEnvelope); ok {
+		if m, ok := generic.(map[string]interface{}); ok {
+			key := envelope.FieldResourceKey()
+			m[key] = fieldFilter(allowed).apply(m[key], "")
+			return m, nil
+		}
+	}
+	return fieldFilter(allowed).apply(generic, ""), nil
+}
+
+type fieldFilter []string
+
+type fieldMatch int
+
+const (
+	fieldDenied fieldMatch = iota
+	fieldAllowed
+	// fieldPartial 하위 필드 일부만 허용
+	fieldPartial
+)
+
+func (f fieldFilter) match(path string) fieldMatch {
+	res := fieldDenied
+	for _, allowed := range f {
+		if allowed == "*" || allowed == path {
+			return fieldAllowed
+		}
+		if strings.HasPrefix(allowed, path+".") {
+			res = fieldPartial
+		}
+	}
+	return res
+}
+
+func (f fieldFilter) apply(v interface{}, prefix string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			path := prefix + key
+			switch f.match(path) {
+			case fieldAllowed:
+			case fieldPartial:
+				val[key] = f.apply(child, path+".")
+			default:
+				delete(val, key)
+			}
+		}
+	case []interface{}:
+		for i := range val {
+			val[i] = f.apply(val[i], prefix)
+		}
+	}
+	return v
+}
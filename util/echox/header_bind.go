@@ -1,28 +1,29 @@
 package echox
 
 import (
+	"net/http"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
+// UserID 인증된 요청자 아이디, 인증 미들웨어(RequireAuth, RequireRole) 뒤에서만 사용
 func UserID(wrapper func(ctx echo.Context, userID uuid.UUID) error) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
-		strID := ctx.Request().Header.Get("User-ID")
-		id, err := uuid.Parse(strID)
-		if err != nil { // TODO error handling
-			return err
+		principal, ok := PrincipalOf(ctx)
+		if !ok {
+			return echo.NewHTTPError(http.StatusUnauthorized)
 		}
-		return wrapper(ctx, id)
+		return wrapper(ctx, principal.UserId)
 	}
 }
 
 func OptionalUserID(wrapper func(ctx echo.Context, userID *uuid.UUID) error) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
-		strID := ctx.Request().Header.Get("User-ID")
-		id, err := uuid.Parse(strID)
-		if err != nil {
+		principal, ok := PrincipalOf(ctx)
+		if !ok {
 			return wrapper(ctx, nil)
 		}
-		return wrapper(ctx, &id)
+		return wrapper(ctx, &principal.UserId)
 	}
-}
\ No newline at end of file
+}
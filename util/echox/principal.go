@@ -0,0 +1,28 @@
+package echox
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const principalKey = "echox.principal"
+
+// Principal 인증된 요청자, 인증 미들웨어가 토큰을 확인한 뒤에만 넣음
+type Principal struct {
+	UserId uuid.UUID
+	Role   string
+	// Scopes 범위를 줄인 토큰이면 허용 범위(쉼표 구분), 없으면 역할의 모든 권한
+	Scopes string
+	// KeyId 범위를 줄인 토큰(API 키)이면 토큰 아이디(jti), 사용량 집계 단위, 아니면 uuid.Nil
+	KeyId uuid.UUID
+}
+
+func SetPrincipal(ctx echo.Context, p Principal) {
+	ctx.Set(principalKey, p)
+}
+
+// PrincipalOf 인증되지 않은 요청이면 false
+func PrincipalOf(ctx echo.Context) (Principal, bool) {
+	p, ok := ctx.Get(principalKey).(Principal)
+	return p, ok
+}
@@ -0,0 +1,52 @@
+package echox
+
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/stockfolioofficial/back-editfolio/util/logx"
+)
+
+// requestIdPattern 로드밸런서, 앞단 서비스가 보낸 아이디는 이 형식일 때만 이어받음
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestLogger X-Request-Id 를 이어받거나 만들어 응답 헤더로 돌려주고, 요청 아이디, 메서드, 라우트가 붙은 로거를 context 에 넣음
+func RequestLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			requestId := req.Header.Get(echo.HeaderXRequestID)
+			if !requestIdPattern.MatchString(requestId) {
+				requestId = uuid.NewString()
+			}
+			ctx.Response().Header().Set(echo.HeaderXRequestID, requestId)
+
+			entry := log.WithField("requestId", requestId).
+				WithField("method", req.Method).
+				WithField("route", ctx.Path())
+			ctx.SetRequest(req.WithContext(logx.With(req.Context(), entry)))
+			return next(ctx)
+		}
+	}
+}
+
+// LogPrincipal 인증 미들웨어 다음에, 인증된 요청이면 로거에 요청자 아이디 추가
+func LogPrincipal() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if principal, ok := PrincipalOf(ctx); ok {
+				req := ctx.Request()
+				entry := logx.From(req.Context()).WithField("userId", principal.UserId)
+				ctx.SetRequest(req.WithContext(logx.With(req.Context(), entry)))
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// Log 요청 로거, 핸들러에서 패키지 log 대신 사용
+func Log(ctx echo.Context) *log.Entry {
+	return logx.From(ctx.Request().Context())
+}
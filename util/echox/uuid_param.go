@@ -0,0 +1,56 @@
+package echox
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorCodeInvalidUUID 경로/헤더의 UUID 형식 오류
+const ErrorCodeInvalidUUID = "V-1"
+
+type invalidUUIDResponse struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+// InvalidUUID 400 응답 에러, 에러 코드와 파라미터 이름을 담아서 반환
+func InvalidUUID(name string) error {
+	return echo.NewHTTPError(http.StatusBadRequest, invalidUUIDResponse{
+		ErrorCode: ErrorCodeInvalidUUID,
+		Message:   "invalid uuid: " + name,
+	})
+}
+
+// ParseUUID 빈 값, 형식 오류, nil UUID 는 false
+func ParseUUID(s string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(s)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// UUIDParams 라우팅된 경로 파라미터 중 names 에 해당하는 값이 UUID 가 아니면 핸들러 실행 전에 400
+// 바인딩 단계까지 가면 nil UUID 로 바인딩되거나 파서 메시지만 내려가서 미리 막음
+func UUIDParams(names ...string) echo.MiddlewareFunc {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			for i, name := range ctx.ParamNames() {
+				if _, ok := set[name]; !ok {
+					continue
+				}
+				if _, ok := ParseUUID(ctx.ParamValues()[i]); !ok {
+					return InvalidUUID(name)
+				}
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package gormx
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrEnumValueInUse 허용 값에서 빠지는 값, 또는 처음 제약을 걸 때 목록에 없는 값을 쓰는 행이 있음
+var ErrEnumValueInUse = errors.New("enum value in use")
+
+var regCheckValue = regexp.MustCompile(`'((?:[^'\\]|''|\\.)*)'`)
+
+// MigrateEnum table.column 에 허용 값 CHECK 제약(chk_<table>_<column>)을 values 에 맞춤, MySQL 8.0.16 미만이면 아무것도 안함
+// 값 추가는 그대로 반영, 값을 빼는 건 파괴적 변경이라 AllowDestructive 가 필요하고 그 값을 쓰는 행이 있으면 거부
+// SafeMigrate 로 연 DB 면 같은 MigrateOption(DryRun, OnRefused)을 따름
+func MigrateEnum(db *gorm.DB, table, column string, values []string) (err error) {
+	var option MigrateOption
+	if m, ok := db.Migrator().(*safeMigrator); ok {
+		option = m.dialector.option
+		m.dialector.record(fmt.Sprintf("migrate enum %s.%s", table, column), func(db *gorm.DB) error {
+			return MigrateEnum(db, table, column, values)
+		})
+	}
+	defer func() {
+		if err != nil && option.OnRefused != nil {
+			option.OnRefused(err)
+		}
+	}()
+
+	// CHECK 제약을 지원하지 않는 버전은 건너뜀, 값 검증은 디코딩에서만
+	var supported int64
+	err = db.Raw("SELECT COUNT(*) FROM `information_schema`.`TABLES` " +
+		"WHERE `TABLE_SCHEMA` = 'information_schema' AND `TABLE_NAME` = 'CHECK_CONSTRAINTS'").
+		Scan(&supported).Error
+	if err != nil || supported == 0 {
+		return
+	}
+
+	name := fmt.Sprintf("chk_%s_%s", table, column)
+	current, exists, err := currentEnumValues(db, name)
+	if err != nil {
+		return
+	}
+
+	var removed []string
+	for _, v := range current {
+		if !contains(values, v) {
+			removed = append(removed, v)
+		}
+	}
+	if exists && len(removed) == 0 && len(current) == len(values) {
+		return
+	}
+
+	if len(removed) > 0 && !option.AllowDestructive {
+		err = fmt.Errorf("%w: remove enum value %s.%s %v", ErrDestructiveMigration, table, column, removed)
+		return
+	}
+
+	// dry run 이면 AutoMigrate 를 건너뛰어 새 테이블이 아직 없을 수 있음
+	var unknown []string
+	if db.Migrator().HasTable(table) {
+		err = db.Table(table).
+			Distinct(column).
+			Where(fmt.Sprintf("`%s` NOT IN ?", column), values).
+			Pluck(column, &unknown).Error
+		if err != nil {
+			return
+		}
+	}
+	if len(unknown) > 0 {
+		err = fmt.Errorf("%w: %s.%s %v", ErrEnumValueInUse, table, column, unknown)
+		return
+	}
+
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(v) + "'"
+	}
+
+	var statements []string
+	if exists {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE `%s` DROP CHECK `%s`", table, name))
+	}
+	statements = append(statements, fmt.Sprintf("ALTER TABLE `%s` ADD CONSTRAINT `%s` CHECK (`%s` IN (%s))",
+		table, name, column, strings.Join(quoted, ", ")))
+
+	if option.DryRun {
+		if option.Out != nil {
+			for _, stmt := range statements {
+				fmt.Fprintf(option.Out, "%s;\n", stmt)
+			}
+		}
+		return
+	}
+
+	for _, stmt := range statements {
+		err = db.Exec(stmt).Error
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// currentEnumValues CHECK 절에서 따옴표로 감싼 값만 뽑음, MySQL 은 _utf8mb4'ADMIN' 처럼 저장
+func currentEnumValues(db *gorm.DB, name string) (values []string, exists bool, err error) {
+	var clauses []string
+	err = db.Raw("SELECT `CHECK_CLAUSE` FROM `information_schema`.`CHECK_CONSTRAINTS` "+
+		"WHERE `CONSTRAINT_SCHEMA` = DATABASE() AND `CONSTRAINT_NAME` = ?", name).
+		Scan(&clauses).Error
+	if err != nil || len(clauses) == 0 {
+		return
+	}
+
+	exists = true
+	for _, match := range regCheckValue.FindAllStringSubmatch(clauses[0], -1) {
+		values = append(values, strings.NewReplacer(`''`, `'`, `\\`, `\`, `\'`, `'`).Replace(match[1]))
+	}
+	return
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package gormx
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlDuplicateEntry ER_DUP_ENTRY, unique 인덱스 위반
+const mysqlDuplicateEntry = 1062
+
+// IsDuplicateKey unique 인덱스 위반으로 실패한 INSERT, UPDATE 인지
+func IsDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
@@ -0,0 +1,326 @@
+package gormx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var ErrDestructiveMigration = errors.New("destructive migration, run with --allow-destructive")
+
+// MigrateOption AutoMigrate 실행 전 점검 정책
+type MigrateOption struct {
+	// AllowDestructive 컬럼/테이블 삭제, 타입 축소 허용
+	AllowDestructive bool
+	// DryRun 실행할 SQL 을 Out 으로 출력만 하고 DB 에는 반영 안함
+	DryRun bool
+	Out    io.Writer
+	// OnRefused 파괴적 변경이 막혔을 때 호출, 레포지토리는 AutoMigrate 에러를 무시하므로 여기서 처리
+	OnRefused func(err error)
+}
+
+// SafeMigrate AutoMigrate 를 바로 실행하지 않고 먼저 SQL 만 수집해 파괴적 변경 여부를 확인하도록 dialector 를 감쌈
+func SafeMigrate(dialector gorm.Dialector, option MigrateOption) gorm.Dialector {
+	return &safeDialector{Dialector: dialector, option: option}
+}
+
+type safeDialector struct {
+	gorm.Dialector
+	option MigrateOption
+
+	mu    sync.Mutex
+	steps []migrateStep
+	seen  map[string]bool
+}
+
+// migrateStep 레포지토리 생성 때 실행한 스키마 변경, 초기 데이터 입력
+type migrateStep struct {
+	key string
+	run func(db *gorm.DB) error
+}
+
+// record 테넌트 DB 에도 같은 순서로 실행할 수 있게 기억, 같은 key 는 한 번만
+func (d *safeDialector) record(key string, run func(db *gorm.DB) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.seen == nil {
+		d.seen = make(map[string]bool)
+	}
+	if d.seen[key] {
+		return
+	}
+	d.seen[key] = true
+	d.steps = append(d.steps, migrateStep{key: key, run: run})
+}
+
+func dialectorOf(db *gorm.DB) (*safeDialector, bool) {
+	d, ok := db.Dialector.(*safeDialector)
+	return d, ok
+}
+
+// ReplayMigrations SafeMigrate 로 연 DB 에서 지금까지 실행한 AutoMigrate, MigrateEnum, Seed 를 db 에 다시 실행
+// 테넌트 DB 처럼 같은 스키마가 필요한 DB 에 사용, db 의 context 로 대상을 고름
+func ReplayMigrations(db *gorm.DB) error {
+	d, ok := dialectorOf(db)
+	if !ok {
+		return nil
+	}
+
+	d.mu.Lock()
+	steps := append([]migrateStep(nil), d.steps...)
+	d.mu.Unlock()
+
+	for _, step := range steps {
+		if err := step.run(db); err != nil {
+			return fmt.Errorf("%s: %w", step.key, err)
+		}
+	}
+	return nil
+}
+
+// Seed 레포지토리 생성 때 넣는 초기 데이터, 이미 있는 행만 건너뛰고 새 행은 넣으므로 고정 아이디를 써야 함
+// SafeMigrate 로 연 DB 면 ReplayMigrations 때 다시 넣음, dry run 이면 넣지 않음
+func Seed(db *gorm.DB, value interface{}) {
+	if d, ok := dialectorOf(db); ok {
+		d.record(fmt.Sprintf("seed %T", value), func(db *gorm.DB) error {
+			Seed(db, value)
+			return nil
+		})
+		if d.option.DryRun {
+			return
+		}
+	}
+	// 한 번에 넣으면 이미 있는 행 하나 때문에 새로 추가한 행까지 빠짐
+	db.Clauses(clause.OnConflict{DoNothing: true}).Create(value)
+}
+
+func (d *safeDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return &safeMigrator{
+		Migrator:  d.Dialector.Migrator(db),
+		db:        db,
+		dialector: d,
+	}
+}
+
+func (d *safeDialector) SavePoint(tx *gorm.DB, name string) error {
+	if savePointer, ok := d.Dialector.(gorm.SavePointerDialectorInterface); ok {
+		return savePointer.SavePoint(tx, name)
+	}
+	return gorm.ErrUnsupportedDriver
+}
+
+func (d *safeDialector) RollbackTo(tx *gorm.DB, name string) error {
+	if savePointer, ok := d.Dialector.(gorm.SavePointerDialectorInterface); ok {
+		return savePointer.RollbackTo(tx, name)
+	}
+	return gorm.ErrUnsupportedDriver
+}
+
+type safeMigrator struct {
+	gorm.Migrator
+	db        *gorm.DB
+	dialector *safeDialector
+}
+
+func (m *safeMigrator) AutoMigrate(dst ...interface{}) error {
+	var option = m.dialector.option
+
+	for _, model := range dst {
+		model := model
+		m.dialector.record(fmt.Sprintf("auto migrate %T", model), func(db *gorm.DB) error {
+			return db.AutoMigrate(model)
+		})
+	}
+
+	plan := &migratePlan{dialector: m.dialector.Dialector}
+	// 테넌트 DB 로 보내도록 context 유지
+	dry := m.db.WithContext(m.db.Statement.Context)
+	dry.Statement.ConnPool = &planConnPool{ConnPool: dry.Statement.ConnPool, plan: plan}
+
+	err := m.dialector.Dialector.Migrator(dry).AutoMigrate(dst...)
+	if err != nil {
+		return err
+	}
+
+	if len(plan.destructive) > 0 && !option.AllowDestructive {
+		err = fmt.Errorf("%w: %s", ErrDestructiveMigration, strings.Join(plan.destructive, ", "))
+		if option.OnRefused != nil {
+			option.OnRefused(err)
+		}
+		return err
+	}
+
+	if option.DryRun {
+		if option.Out != nil {
+			for _, stmt := range plan.statements {
+				fmt.Fprintf(option.Out, "%s;\n", stmt)
+			}
+		}
+		return nil
+	}
+
+	return m.Migrator.AutoMigrate(dst...)
+}
+
+func (m *safeMigrator) DropTable(dst ...interface{}) error {
+	for _, value := range dst {
+		err := m.destructive(fmt.Sprintf("drop table %s", m.tableName(value)))
+		if err != nil {
+			return err
+		}
+	}
+	return m.Migrator.DropTable(dst...)
+}
+
+func (m *safeMigrator) DropColumn(dst interface{}, field string) error {
+	err := m.destructive(fmt.Sprintf("drop column %s.%s", m.tableName(dst), field))
+	if err != nil {
+		return err
+	}
+	return m.Migrator.DropColumn(dst, field)
+}
+
+func (m *safeMigrator) MigrateColumn(dst interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	// AutoMigrate 점검 단계에서만 판단, 실제 실행은 점검을 통과한 뒤라 막지 않음
+	if pool, ok := m.db.Statement.ConnPool.(*planConnPool); ok {
+		if reason := narrowing(m.FullDataTypeOf(field).SQL, columnType); reason != "" {
+			pool.plan.destructive = append(pool.plan.destructive,
+				fmt.Sprintf("%s %s.%s", reason, m.tableName(dst), field.DBName))
+		}
+	}
+	return m.Migrator.MigrateColumn(dst, field, columnType)
+}
+
+func (m *safeMigrator) destructive(op string) error {
+	if pool, ok := m.db.Statement.ConnPool.(*planConnPool); ok {
+		pool.plan.destructive = append(pool.plan.destructive, op)
+		return nil
+	}
+
+	if !m.dialector.option.AllowDestructive {
+		return fmt.Errorf("%w: %s", ErrDestructiveMigration, op)
+	}
+	return nil
+}
+
+func (m *safeMigrator) tableName(value interface{}) string {
+	if name, ok := value.(string); ok {
+		return name
+	}
+
+	stmt := &gorm.Statement{DB: m.db}
+	if err := stmt.Parse(value); err != nil {
+		return fmt.Sprintf("%T", value)
+	}
+	return stmt.Table
+}
+
+type migratePlan struct {
+	dialector   gorm.Dialector
+	statements  []string
+	destructive []string
+}
+
+// planConnPool 조회는 그대로 DB 로 보내고 변경(Exec)만 기록
+type planConnPool struct {
+	gorm.ConnPool
+	plan *migratePlan
+}
+
+func (p *planConnPool) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	p.plan.statements = append(p.plan.statements, p.plan.dialector.Explain(query, args...))
+	return driver.RowsAffected(0), nil
+}
+
+var regDataType = regexp.MustCompile(`^(?:unsigned\s+)?(\w+)(?:\s*\((\d+)(?:\s*,\s*\d+)?\))?`)
+
+type dataTypeKind int
+
+const (
+	unknownDataType dataTypeKind = iota
+	integerDataType
+	decimalDataType
+	stringDataType
+	binaryDataType
+)
+
+var fixedCapacity = map[string]struct {
+	kind     dataTypeKind
+	capacity int64
+}{
+	"bool":       {integerDataType, 1},
+	"boolean":    {integerDataType, 1},
+	"tinyint":    {integerDataType, 1},
+	"smallint":   {integerDataType, 2},
+	"mediumint":  {integerDataType, 3},
+	"int":        {integerDataType, 4},
+	"integer":    {integerDataType, 4},
+	"bigint":     {integerDataType, 8},
+	"tinytext":   {stringDataType, 1<<8 - 1},
+	"text":       {stringDataType, 1<<16 - 1},
+	"mediumtext": {stringDataType, 1<<24 - 1},
+	"longtext":   {stringDataType, 1<<32 - 1},
+	"tinyblob":   {binaryDataType, 1<<8 - 1},
+	"blob":       {binaryDataType, 1<<16 - 1},
+	"mediumblob": {binaryDataType, 1<<24 - 1},
+	"longblob":   {binaryDataType, 1<<32 - 1},
+}
+
+var sizedKind = map[string]dataTypeKind{
+	"char":      stringDataType,
+	"varchar":   stringDataType,
+	"binary":    binaryDataType,
+	"varbinary": binaryDataType,
+	"decimal":   decimalDataType,
+	"numeric":   decimalDataType,
+}
+
+func capacityOf(name string, size int64) (dataTypeKind, int64) {
+	if fixed, ok := fixedCapacity[name]; ok {
+		return fixed.kind, fixed.capacity
+	}
+	if kind, ok := sizedKind[name]; ok && size > 0 {
+		return kind, size
+	}
+	return unknownDataType, 0
+}
+
+// narrowing 기존 컬럼보다 담을 수 있는 값이 줄어드는 변경이면 사유 반환, 판단 못하는 타입은 통과
+func narrowing(fullDataType string, columnType gorm.ColumnType) string {
+	matches := regDataType.FindStringSubmatch(strings.ToLower(strings.TrimSpace(fullDataType)))
+	if matches == nil {
+		return ""
+	}
+	newSize, _ := strconv.ParseInt(matches[2], 10, 64)
+	newKind, newCapacity := capacityOf(matches[1], newSize)
+
+	oldName := strings.TrimPrefix(strings.ToLower(columnType.DatabaseTypeName()), "unsigned ")
+	oldSize, _ := columnType.Length()
+	if precision, _, ok := columnType.DecimalSize(); ok && oldSize <= 0 {
+		oldSize = precision
+	}
+	oldKind, oldCapacity := capacityOf(oldName, oldSize)
+
+	if newKind == unknownDataType || oldKind == unknownDataType {
+		return ""
+	}
+	if newKind != oldKind {
+		return fmt.Sprintf("change type %s -> %s", oldName, matches[1])
+	}
+	if newCapacity < oldCapacity {
+		return fmt.Sprintf("narrow type %s(%d) -> %s(%d)", oldName, oldCapacity, matches[1], newCapacity)
+	}
+	return ""
+}
@@ -0,0 +1,22 @@
+package logx
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type entryKey struct{}
+
+// With 요청 로거를 context 에 넣음, 하위 호출은 From 으로 같은 필드(requestId 등)를 남김
+func With(ctx context.Context, entry *log.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// From context 의 요청 로거, 요청 밖(스케줄러, 초기화 등)이면 기본 로거
+func From(ctx context.Context) *log.Entry {
+	if entry, ok := ctx.Value(entryKey{}).(*log.Entry); ok {
+		return entry
+	}
+	return log.NewEntry(log.StandardLogger())
+}
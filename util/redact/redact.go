@@ -0,0 +1,114 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Mask 가린 값 자리에 넣는 문자열
+const Mask = "[REDACTED]"
+
+// sensitiveKeys 값 전체를 가리는 키 (소문자, _ - 제거 후 비교)
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"pw":            true,
+	"newpassword":   true,
+	"oldpassword":   true,
+	"token":         true,
+	"accesstoken":   true,
+	"refreshtoken":  true,
+	"authorization": true,
+	"secret":        true,
+	"secretkey":     true,
+	"apikey":        true,
+	"merchantkey":   true,
+	"values":        true,
+	"email":         true,
+	"username":      true,
+	"mobile":        true,
+	"phone":         true,
+	"name":          true,
+	"memo":          true,
+	"address":       true,
+}
+
+var (
+	regEmail  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	regMobile = regexp.MustCompile(`01[016789]-?\d{3,4}-?\d{4}`)
+	regJWT    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`)
+)
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	key = strings.NewReplacer("_", "", "-", "").Replace(key)
+	return sensitiveKeys[key]
+}
+
+// String 값 안의 이메일, 휴대폰 번호, 토큰을 가림
+func String(s string) string {
+	s = regJWT.ReplaceAllString(s, Mask)
+	s = regEmail.ReplaceAllString(s, Mask)
+	return regMobile.ReplaceAllString(s, Mask)
+}
+
+// JSON 민감한 키의 값은 통째로, 나머지 문자열은 String 으로 가림
+// JSON 이 아니면 길이만 남김
+func JSON(raw []byte) string {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "[non-json body omitted, " + strconv.Itoa(len(raw)) + " bytes]"
+	}
+
+	res, err := json.Marshal(value(v))
+	if err != nil {
+		return Mask
+	}
+	return string(res)
+}
+
+// Query 쿼리 파라미터를 같은 규칙으로 가려 인코딩
+func Query(values url.Values) string {
+	res := make(url.Values, len(values))
+	for key, list := range values {
+		masked := make([]string, len(list))
+		for i, v := range list {
+			if isSensitiveKey(key) {
+				masked[i] = Mask
+			} else {
+				masked[i] = String(v)
+			}
+		}
+		res[key] = masked
+	}
+	return res.Encode()
+}
+
+func value(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			if isSensitiveKey(key) && child != nil {
+				val[key] = Mask
+			} else {
+				val[key] = value(child)
+			}
+		}
+	case []interface{}:
+		for i := range val {
+			val[i] = value(val[i])
+		}
+	case string:
+		return String(val)
+	}
+	return v
+}